@@ -2,12 +2,15 @@ package access
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/onflow/flow/protobuf/go/flow/access"
 	"github.com/onflow/flow/protobuf/go/flow/entities"
+	gethCommon "github.com/onflow/go-ethereum/common"
 
 	"github.com/onflow/flow-go/engine/access/subscription"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/fvm/evm/debug"
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -53,6 +56,19 @@ type API interface {
 	ExecuteScriptAtBlockHeight(ctx context.Context, blockHeight uint64, script []byte, arguments [][]byte) ([]byte, error)
 	ExecuteScriptAtBlockID(ctx context.Context, blockID flow.Identifier, script []byte, arguments [][]byte) ([]byte, error)
 
+	// ExecuteScriptsAtBlockHeight executes a batch of scripts concurrently against the state at the given
+	// block height using the locally indexed registers, and returns a result for each script in the same
+	// order. A script failing to execute does not fail the batch, its error is returned in its result.
+	// All scripts of the batch share a computation and memory budget, scripts which could not be executed
+	// because it was used up fail with codes.ResourceExhausted.
+	//
+	// Expected errors during normal operations:
+	// - codes.FailedPrecondition: if local script execution is not enabled.
+	// - codes.InvalidArgument: if the batch is empty or contains too many scripts.
+	// - codes.NotFound: if the block at the given height is not found.
+	// - codes.OutOfRange: if the registers for the given height are not indexed.
+	ExecuteScriptsAtBlockHeight(ctx context.Context, blockHeight uint64, scripts []Script) ([]ScriptResult, error)
+
 	GetEventsForHeightRange(ctx context.Context, eventType string, startHeight, endHeight uint64, requiredEventEncodingVersion entities.EventEncodingVersion) ([]flow.BlockEvents, error)
 	GetEventsForBlockIDs(ctx context.Context, eventType string, blockIDs []flow.Identifier, requiredEventEncodingVersion entities.EventEncodingVersion) ([]flow.BlockEvents, error)
 
@@ -63,6 +79,191 @@ type API interface {
 	GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error)
 	GetExecutionResultByID(ctx context.Context, id flow.Identifier) (*flow.ExecutionResult, error)
 
+	// GetAccountTransactions returns the transactions which involved the given account as payer, proposer,
+	// authorizer or through an emitted event, ordered from newest to oldest.
+	//
+	// Parameters:
+	// - ctx: Context for the operation.
+	// - address: The address of the account.
+	// - startHeight: The lowest block height to include. 0 uses the lowest indexed height.
+	// - endHeight: The highest block height to include. 0 uses the highest indexed height.
+	// - cursor: The position to continue from, as returned with the previous page. nil starts at the end height.
+	// - limit: The maximum number of transactions to return.
+	//
+	// Expected errors during normal operations:
+	// - codes.InvalidArgument: if the height range or limit is invalid.
+	// - codes.OutOfRange: if the height range is not indexed.
+	// - codes.FailedPrecondition: if the account transaction index is not available.
+	GetAccountTransactions(
+		ctx context.Context,
+		address flow.Address,
+		startHeight uint64,
+		endHeight uint64,
+		cursor *flow.AccountTransactionCursor,
+		limit uint32,
+	) (*AccountTransactionsPage, error)
+
+	// GetEVMLogs returns the logs emitted by EVM transactions which match the filter, ordered from
+	// oldest to newest.
+	//
+	// Parameters:
+	// - ctx: Context for the operation.
+	// - filter: The contract addresses and topics the logs must match.
+	// - startHeight: The lowest block height to include. 0 uses the lowest indexed height.
+	// - endHeight: The highest block height to include. 0 uses the highest indexed height.
+	// - cursor: The position to continue from, as returned with the previous page. nil starts at the start height.
+	// - limit: The maximum number of logs to return.
+	//
+	// Expected errors during normal operations:
+	// - codes.InvalidArgument: if the filter, height range or limit is invalid.
+	// - codes.OutOfRange: if the height range is not indexed.
+	// - codes.FailedPrecondition: if the EVM log index is not available.
+	GetEVMLogs(
+		ctx context.Context,
+		filter flow.EVMLogFilter,
+		startHeight uint64,
+		endHeight uint64,
+		cursor *flow.EVMLogCursor,
+		limit uint32,
+	) (*EVMLogsPage, error)
+
+	// GetAccountStorageDiff returns the registers of the given account which were added, removed or modified
+	// between the two heights, ordered by key. Where possible, the registers are described by what they store,
+	// and if decodePaths is set, the values stored at the account's storage paths are decoded and compared
+	// for the first page. If the returned diff is not the last page, it contains the cursor for the next page.
+	//
+	// Expected errors during normal operations:
+	// - codes.InvalidArgument: if the height range or limit is invalid.
+	// - codes.OutOfRange: if the height range is not indexed.
+	// - codes.FailedPrecondition: if the register index is not available.
+	GetAccountStorageDiff(
+		ctx context.Context,
+		address flow.Address,
+		fromHeight uint64,
+		toHeight uint64,
+		cursor *string,
+		limit uint32,
+		decodePaths bool,
+	) (*flow.AccountStorageDiff, error)
+
+	// GetContractImpact returns the contracts which import the given contract, directly or transitively,
+	// and the values stored by the given accounts which reference types defined by the contract or by
+	// any of its dependents. It estimates what is affected by an upgrade of the contract.
+	//
+	// Building the contract graph visits all accounts of the register index, so it is rebuilt in the
+	// background at the latest indexed height. If height is 0, the height of the current graph is used,
+	// otherwise it must match it. The height of the graph is returned with the impact.
+	//
+	// Expected errors during normal operations:
+	// - codes.InvalidArgument: if too many accounts are given.
+	// - codes.NotFound: if the contract is not deployed at the height of the graph.
+	// - codes.OutOfRange: if the height is not the height of the current graph.
+	// - codes.FailedPrecondition: if the register index or the contract graph is not available yet.
+	GetContractImpact(
+		ctx context.Context,
+		contract flow.ContractID,
+		height uint64,
+		accounts []flow.Address,
+	) (*flow.ContractImpact, error)
+
+	// GetAccountStateProof returns the values of the given registers of the account at the given sealed
+	// height, along with a batch proof of their values in the state commitment sealed for the block, so
+	// that clients can verify them without trusting the node. If no keys are given, the account status
+	// register is proven. Registers which aren't allocated are proven to be empty.
+	//
+	// Expected errors during normal operations:
+	// - codes.InvalidArgument: if too many keys are given.
+	// - codes.NotFound: if the block at the height is not found.
+	// - codes.OutOfRange: if the block at the height is not sealed.
+	// - codes.Unavailable: if no execution node provided a valid proof.
+	// - codes.FailedPrecondition: if register proofs are not available on this node.
+	GetAccountStateProof(
+		ctx context.Context,
+		address flow.Address,
+		keys []string,
+		height uint64,
+	) (*flow.AccountStateProof, error)
+
+	// GetEVMBlockTraces returns the traces of the EVM transactions executed by the given Flow block, in
+	// execution order, produced by re-executing them with the given tracer, like debug_traceBlock.
+	//
+	// Expected errors during normal operations:
+	// - codes.InvalidArgument: if the tracer is not supported or its configuration is invalid.
+	// - codes.NotFound: if the block is not found.
+	// - codes.OutOfRange: if the registers or events of the block are not indexed.
+	// - codes.FailedPrecondition: if the register or events index is not available.
+	GetEVMBlockTraces(
+		ctx context.Context,
+		blockID flow.Identifier,
+		config debug.TracerConfig,
+	) ([]debug.TransactionTrace, error)
+
+	// GetEVMTransactionTrace returns the trace of the EVM transaction with the given hash, executed by the
+	// given Flow block, produced by re-executing the block with the given tracer, like debug_traceTransaction.
+	//
+	// Expected errors during normal operations:
+	// - codes.InvalidArgument: if the tracer is not supported or its configuration is invalid.
+	// - codes.NotFound: if the block is not found, or the transaction was not executed by the block.
+	// - codes.OutOfRange: if the registers or events of the block are not indexed.
+	// - codes.FailedPrecondition: if the register or events index is not available.
+	GetEVMTransactionTrace(
+		ctx context.Context,
+		blockID flow.Identifier,
+		txHash gethCommon.Hash,
+		config debug.TracerConfig,
+	) (json.RawMessage, error)
+
+	// SimulateTransaction executes the transaction against the state at the given block height without
+	// submitting it or committing any of its changes. If skipSignatureCheck is true, the transaction's
+	// signatures and sequence number are not verified. Events in the result are CCF encoded.
+	//
+	// A failure of the transaction itself is returned as part of the result, not as an error.
+	//
+	// Expected errors during normal operations:
+	// - codes.FailedPrecondition: if local script execution is not enabled.
+	// - codes.NotFound: if the block at the given height is not found.
+	// - codes.OutOfRange: if the registers for the given height are not indexed.
+	SimulateTransaction(
+		ctx context.Context,
+		tx *flow.TransactionBody,
+		blockHeight uint64,
+		skipSignatureCheck bool,
+	) (*flow.TransactionSimulationResult, error)
+
+	// EstimateTransactionFees executes the transaction against the state at the given block height without
+	// submitting it, and returns the computation it used and the fees it would be charged with the fee
+	// parameters at the block. The transaction's signatures are not verified, and its computation is capped
+	// at the node's fee estimation computation limit.
+	//
+	// A failure of the transaction itself is returned as part of the estimate, not as an error.
+	//
+	// Expected errors during normal operations:
+	// - codes.FailedPrecondition: if local script execution is not enabled.
+	// - codes.NotFound: if the block at the given height is not found.
+	// - codes.OutOfRange: if the registers for the given height are not indexed.
+	EstimateTransactionFees(
+		ctx context.Context,
+		tx *flow.TransactionBody,
+		blockHeight uint64,
+	) (*flow.TransactionFeeEstimate, error)
+
+	// ValidateContractUpdate validates an update of a contract of the account to the given code against
+	// the state at the given block height, without submitting a transaction or committing any changes.
+	// The contract to update is the one declared by the code.
+	//
+	// The reasons for which the update would be rejected are returned as violations, not as an error.
+	//
+	// Expected errors during normal operations:
+	// - codes.FailedPrecondition: if local script execution is not enabled.
+	// - codes.NotFound: if the block at the given height is not found.
+	// - codes.OutOfRange: if the registers for the given height are not indexed.
+	ValidateContractUpdate(
+		ctx context.Context,
+		address flow.Address,
+		code []byte,
+		blockHeight uint64,
+	) (*flow.ContractUpdateValidation, error)
+
 	// SubscribeBlocks
 
 	// SubscribeBlocksFromStartBlockID subscribes to the finalized or sealed blocks starting at the requested
@@ -207,6 +408,20 @@ type API interface {
 	// transaction itself until the block containing the transaction becomes sealed or expired. When the transaction
 	// status becomes TransactionStatusSealed or TransactionStatusExpired, the subscription will automatically shut down.
 	SubscribeTransactionStatuses(ctx context.Context, tx *flow.TransactionBody, requiredEventEncodingVersion entities.EventEncodingVersion) subscription.Subscription
+	// SubscribeEVMLogsFromStartHeight streams the EVM logs matching the filter, starting at the requested
+	// start block height, up until the latest indexed block. Once the latest is reached, the stream will
+	// remain open and responses are sent for each new block as it is indexed.
+	//
+	// Each response is of type *EVMLogsResponse, and is sent for every block, including blocks
+	// without matching logs.
+	//
+	// Parameters:
+	// - ctx: Context for the operation.
+	// - startHeight: The height of the starting block. 0 starts at the latest sealed block.
+	// - filter: The contract addresses and topics the logs must match.
+	//
+	// If invalid parameters will be supplied SubscribeEVMLogsFromStartHeight will return a failed subscription.
+	SubscribeEVMLogsFromStartHeight(ctx context.Context, startHeight uint64, filter flow.EVMLogFilter) subscription.Subscription
 }
 
 // TODO: Combine this with flow.TransactionResult?
@@ -259,6 +474,42 @@ func MessageToTransactionResult(message *access.TransactionResultResponse) *Tran
 	}
 }
 
+// Script is a script executed as part of a batch.
+type Script struct {
+	Script    []byte
+	Arguments [][]byte
+}
+
+// ScriptResult is the result of a script executed as part of a batch.
+type ScriptResult struct {
+	// Value is the JSON-CDC encoded value returned by the script. It is nil if the script failed.
+	Value []byte
+	// ComputationUsed is the amount of computation used by the script.
+	ComputationUsed uint64
+	// Err is the gRPC status error the script failed with, if any.
+	Err error
+}
+
+// AccountTransactionsPage is a page of the transaction history of an account.
+type AccountTransactionsPage struct {
+	Transactions []flow.AccountTransaction
+	// NextCursor points at the first transaction of the next page, nil if this is the last page.
+	NextCursor *flow.AccountTransactionCursor
+}
+
+// EVMLogsPage is a page of EVM logs matching a filter.
+type EVMLogsPage struct {
+	Logs []flow.EVMLog
+	// NextCursor points at the first log of the next page, nil if this is the last page.
+	NextCursor *flow.EVMLogCursor
+}
+
+// EVMLogsResponse holds the EVM logs matching a filter in a single block.
+type EVMLogsResponse struct {
+	BlockHeight uint64
+	Logs        []flow.EVMLog
+}
+
 // NetworkParameters contains the network-wide parameters for the Flow blockchain.
 type NetworkParameters struct {
 	ChainID flow.ChainID
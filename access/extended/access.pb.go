@@ -0,0 +1,3629 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        (unknown)
+// source: access/extended/access.proto
+
+package extended
+
+import (
+	access "github.com/onflow/flow/protobuf/go/flow/access"
+	entities "github.com/onflow/flow/protobuf/go/flow/entities"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AccountTransactionRole is a way an account was involved in a transaction.
+type AccountTransactionRole int32
+
+const (
+	AccountTransactionRole_ACCOUNT_TRANSACTION_ROLE_UNKNOWN     AccountTransactionRole = 0
+	AccountTransactionRole_ACCOUNT_TRANSACTION_ROLE_PAYER       AccountTransactionRole = 1
+	AccountTransactionRole_ACCOUNT_TRANSACTION_ROLE_PROPOSER    AccountTransactionRole = 2
+	AccountTransactionRole_ACCOUNT_TRANSACTION_ROLE_AUTHORIZER  AccountTransactionRole = 3
+	AccountTransactionRole_ACCOUNT_TRANSACTION_ROLE_INTERACTION AccountTransactionRole = 4
+)
+
+// Enum value maps for AccountTransactionRole.
+var (
+	AccountTransactionRole_name = map[int32]string{
+		0: "ACCOUNT_TRANSACTION_ROLE_UNKNOWN",
+		1: "ACCOUNT_TRANSACTION_ROLE_PAYER",
+		2: "ACCOUNT_TRANSACTION_ROLE_PROPOSER",
+		3: "ACCOUNT_TRANSACTION_ROLE_AUTHORIZER",
+		4: "ACCOUNT_TRANSACTION_ROLE_INTERACTION",
+	}
+	AccountTransactionRole_value = map[string]int32{
+		"ACCOUNT_TRANSACTION_ROLE_UNKNOWN":     0,
+		"ACCOUNT_TRANSACTION_ROLE_PAYER":       1,
+		"ACCOUNT_TRANSACTION_ROLE_PROPOSER":    2,
+		"ACCOUNT_TRANSACTION_ROLE_AUTHORIZER":  3,
+		"ACCOUNT_TRANSACTION_ROLE_INTERACTION": 4,
+	}
+)
+
+func (x AccountTransactionRole) Enum() *AccountTransactionRole {
+	p := new(AccountTransactionRole)
+	*p = x
+	return p
+}
+
+func (x AccountTransactionRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccountTransactionRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_access_extended_access_proto_enumTypes[0].Descriptor()
+}
+
+func (AccountTransactionRole) Type() protoreflect.EnumType {
+	return &file_access_extended_access_proto_enumTypes[0]
+}
+
+func (x AccountTransactionRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccountTransactionRole.Descriptor instead.
+func (AccountTransactionRole) EnumDescriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{0}
+}
+
+// RegisterChangeType is how a register changed between two heights.
+type RegisterChangeType int32
+
+const (
+	RegisterChangeType_REGISTER_CHANGE_TYPE_UNKNOWN  RegisterChangeType = 0
+	RegisterChangeType_REGISTER_CHANGE_TYPE_ADDED    RegisterChangeType = 1
+	RegisterChangeType_REGISTER_CHANGE_TYPE_REMOVED  RegisterChangeType = 2
+	RegisterChangeType_REGISTER_CHANGE_TYPE_MODIFIED RegisterChangeType = 3
+)
+
+// Enum value maps for RegisterChangeType.
+var (
+	RegisterChangeType_name = map[int32]string{
+		0: "REGISTER_CHANGE_TYPE_UNKNOWN",
+		1: "REGISTER_CHANGE_TYPE_ADDED",
+		2: "REGISTER_CHANGE_TYPE_REMOVED",
+		3: "REGISTER_CHANGE_TYPE_MODIFIED",
+	}
+	RegisterChangeType_value = map[string]int32{
+		"REGISTER_CHANGE_TYPE_UNKNOWN":  0,
+		"REGISTER_CHANGE_TYPE_ADDED":    1,
+		"REGISTER_CHANGE_TYPE_REMOVED":  2,
+		"REGISTER_CHANGE_TYPE_MODIFIED": 3,
+	}
+)
+
+func (x RegisterChangeType) Enum() *RegisterChangeType {
+	p := new(RegisterChangeType)
+	*p = x
+	return p
+}
+
+func (x RegisterChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RegisterChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_access_extended_access_proto_enumTypes[1].Descriptor()
+}
+
+func (RegisterChangeType) Type() protoreflect.EnumType {
+	return &file_access_extended_access_proto_enumTypes[1]
+}
+
+func (x RegisterChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RegisterChangeType.Descriptor instead.
+func (RegisterChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{1}
+}
+
+// AccountRegisterKind is what an account register stores.
+type AccountRegisterKind int32
+
+const (
+	AccountRegisterKind_ACCOUNT_REGISTER_KIND_UNKNOWN        AccountRegisterKind = 0
+	AccountRegisterKind_ACCOUNT_REGISTER_KIND_STATUS         AccountRegisterKind = 1
+	AccountRegisterKind_ACCOUNT_REGISTER_KIND_PUBLIC_KEY     AccountRegisterKind = 2
+	AccountRegisterKind_ACCOUNT_REGISTER_KIND_CONTRACT_NAMES AccountRegisterKind = 3
+	AccountRegisterKind_ACCOUNT_REGISTER_KIND_CONTRACT_CODE  AccountRegisterKind = 4
+	AccountRegisterKind_ACCOUNT_REGISTER_KIND_STORAGE_DOMAIN AccountRegisterKind = 5
+	AccountRegisterKind_ACCOUNT_REGISTER_KIND_SLAB           AccountRegisterKind = 6
+)
+
+// Enum value maps for AccountRegisterKind.
+var (
+	AccountRegisterKind_name = map[int32]string{
+		0: "ACCOUNT_REGISTER_KIND_UNKNOWN",
+		1: "ACCOUNT_REGISTER_KIND_STATUS",
+		2: "ACCOUNT_REGISTER_KIND_PUBLIC_KEY",
+		3: "ACCOUNT_REGISTER_KIND_CONTRACT_NAMES",
+		4: "ACCOUNT_REGISTER_KIND_CONTRACT_CODE",
+		5: "ACCOUNT_REGISTER_KIND_STORAGE_DOMAIN",
+		6: "ACCOUNT_REGISTER_KIND_SLAB",
+	}
+	AccountRegisterKind_value = map[string]int32{
+		"ACCOUNT_REGISTER_KIND_UNKNOWN":        0,
+		"ACCOUNT_REGISTER_KIND_STATUS":         1,
+		"ACCOUNT_REGISTER_KIND_PUBLIC_KEY":     2,
+		"ACCOUNT_REGISTER_KIND_CONTRACT_NAMES": 3,
+		"ACCOUNT_REGISTER_KIND_CONTRACT_CODE":  4,
+		"ACCOUNT_REGISTER_KIND_STORAGE_DOMAIN": 5,
+		"ACCOUNT_REGISTER_KIND_SLAB":           6,
+	}
+)
+
+func (x AccountRegisterKind) Enum() *AccountRegisterKind {
+	p := new(AccountRegisterKind)
+	*p = x
+	return p
+}
+
+func (x AccountRegisterKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccountRegisterKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_access_extended_access_proto_enumTypes[2].Descriptor()
+}
+
+func (AccountRegisterKind) Type() protoreflect.EnumType {
+	return &file_access_extended_access_proto_enumTypes[2]
+}
+
+func (x AccountRegisterKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccountRegisterKind.Descriptor instead.
+func (AccountRegisterKind) EnumDescriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{2}
+}
+
+// AccountTransactionCursor identifies a position in the transaction history of an account.
+type AccountTransactionCursor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockHeight      uint64 `protobuf:"varint,1,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	TransactionIndex uint32 `protobuf:"varint,2,opt,name=transaction_index,json=transactionIndex,proto3" json:"transaction_index,omitempty"`
+}
+
+func (x *AccountTransactionCursor) Reset() {
+	*x = AccountTransactionCursor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccountTransactionCursor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountTransactionCursor) ProtoMessage() {}
+
+func (x *AccountTransactionCursor) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountTransactionCursor.ProtoReflect.Descriptor instead.
+func (*AccountTransactionCursor) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AccountTransactionCursor) GetBlockHeight() uint64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *AccountTransactionCursor) GetTransactionIndex() uint32 {
+	if x != nil {
+		return x.TransactionIndex
+	}
+	return 0
+}
+
+// AccountTransaction is a transaction which involved an account.
+type AccountTransaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address          []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	BlockHeight      uint64 `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	TransactionId    []byte `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	TransactionIndex uint32 `protobuf:"varint,4,opt,name=transaction_index,json=transactionIndex,proto3" json:"transaction_index,omitempty"`
+	// roles lists all the ways the account was involved in the transaction.
+	Roles []AccountTransactionRole `protobuf:"varint,5,rep,packed,name=roles,proto3,enum=flow.extended.AccountTransactionRole" json:"roles,omitempty"`
+}
+
+func (x *AccountTransaction) Reset() {
+	*x = AccountTransaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccountTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountTransaction) ProtoMessage() {}
+
+func (x *AccountTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountTransaction.ProtoReflect.Descriptor instead.
+func (*AccountTransaction) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AccountTransaction) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *AccountTransaction) GetBlockHeight() uint64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *AccountTransaction) GetTransactionId() []byte {
+	if x != nil {
+		return x.TransactionId
+	}
+	return nil
+}
+
+func (x *AccountTransaction) GetTransactionIndex() uint32 {
+	if x != nil {
+		return x.TransactionIndex
+	}
+	return 0
+}
+
+func (x *AccountTransaction) GetRoles() []AccountTransactionRole {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type GetAccountTransactionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// start_height is the lowest block height to include. 0 uses the lowest indexed height.
+	StartHeight uint64 `protobuf:"varint,2,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	// end_height is the highest block height to include. 0 uses the highest indexed height.
+	EndHeight uint64 `protobuf:"varint,3,opt,name=end_height,json=endHeight,proto3" json:"end_height,omitempty"`
+	// cursor is the position to continue from, as returned with the previous page. If it is not set,
+	// the history is returned from the end height.
+	Cursor *AccountTransactionCursor `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// limit is the maximum number of transactions to return.
+	Limit uint32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *GetAccountTransactionsRequest) Reset() {
+	*x = GetAccountTransactionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAccountTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountTransactionsRequest) ProtoMessage() {}
+
+func (x *GetAccountTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetAccountTransactionsRequest) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *GetAccountTransactionsRequest) GetStartHeight() uint64 {
+	if x != nil {
+		return x.StartHeight
+	}
+	return 0
+}
+
+func (x *GetAccountTransactionsRequest) GetEndHeight() uint64 {
+	if x != nil {
+		return x.EndHeight
+	}
+	return 0
+}
+
+func (x *GetAccountTransactionsRequest) GetCursor() *AccountTransactionCursor {
+	if x != nil {
+		return x.Cursor
+	}
+	return nil
+}
+
+func (x *GetAccountTransactionsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetAccountTransactionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Transactions []*AccountTransaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// next_cursor points at the first transaction of the next page. It is not set on the last page.
+	NextCursor *AccountTransactionCursor `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+}
+
+func (x *GetAccountTransactionsResponse) Reset() {
+	*x = GetAccountTransactionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAccountTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountTransactionsResponse) ProtoMessage() {}
+
+func (x *GetAccountTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetAccountTransactionsResponse) GetTransactions() []*AccountTransaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *GetAccountTransactionsResponse) GetNextCursor() *AccountTransactionCursor {
+	if x != nil {
+		return x.NextCursor
+	}
+	return nil
+}
+
+type GetEventsForHeightRangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type        string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	StartHeight uint64 `protobuf:"varint,2,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	EndHeight   uint64 `protobuf:"varint,3,opt,name=end_height,json=endHeight,proto3" json:"end_height,omitempty"`
+	// where is an optional predicate on the fields of the events, for example
+	// `to == 0x01 and amount > 100.0`. Only the events matching it are returned.
+	Where                string                        `protobuf:"bytes,4,opt,name=where,proto3" json:"where,omitempty"`
+	EventEncodingVersion entities.EventEncodingVersion `protobuf:"varint,5,opt,name=event_encoding_version,json=eventEncodingVersion,proto3,enum=flow.entities.EventEncodingVersion" json:"event_encoding_version,omitempty"`
+}
+
+func (x *GetEventsForHeightRangeRequest) Reset() {
+	*x = GetEventsForHeightRangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEventsForHeightRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventsForHeightRangeRequest) ProtoMessage() {}
+
+func (x *GetEventsForHeightRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventsForHeightRangeRequest.ProtoReflect.Descriptor instead.
+func (*GetEventsForHeightRangeRequest) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetEventsForHeightRangeRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GetEventsForHeightRangeRequest) GetStartHeight() uint64 {
+	if x != nil {
+		return x.StartHeight
+	}
+	return 0
+}
+
+func (x *GetEventsForHeightRangeRequest) GetEndHeight() uint64 {
+	if x != nil {
+		return x.EndHeight
+	}
+	return 0
+}
+
+func (x *GetEventsForHeightRangeRequest) GetWhere() string {
+	if x != nil {
+		return x.Where
+	}
+	return ""
+}
+
+func (x *GetEventsForHeightRangeRequest) GetEventEncodingVersion() entities.EventEncodingVersion {
+	if x != nil {
+		return x.EventEncodingVersion
+	}
+	return entities.EventEncodingVersion(0)
+}
+
+type GetEventsForHeightRangeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*access.EventsResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *GetEventsForHeightRangeResponse) Reset() {
+	*x = GetEventsForHeightRangeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEventsForHeightRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventsForHeightRangeResponse) ProtoMessage() {}
+
+func (x *GetEventsForHeightRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventsForHeightRangeResponse.ProtoReflect.Descriptor instead.
+func (*GetEventsForHeightRangeResponse) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetEventsForHeightRangeResponse) GetResults() []*access.EventsResponse_Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type SimulateTransactionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Transaction *entities.Transaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	// block_height is the height of the block whose state the transaction is executed against.
+	BlockHeight uint64 `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	// skip_signature_check disables the verification of the signatures and the sequence number of the
+	// transaction.
+	SkipSignatureCheck   bool                          `protobuf:"varint,3,opt,name=skip_signature_check,json=skipSignatureCheck,proto3" json:"skip_signature_check,omitempty"`
+	EventEncodingVersion entities.EventEncodingVersion `protobuf:"varint,4,opt,name=event_encoding_version,json=eventEncodingVersion,proto3,enum=flow.entities.EventEncodingVersion" json:"event_encoding_version,omitempty"`
+}
+
+func (x *SimulateTransactionRequest) Reset() {
+	*x = SimulateTransactionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SimulateTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulateTransactionRequest) ProtoMessage() {}
+
+func (x *SimulateTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulateTransactionRequest.ProtoReflect.Descriptor instead.
+func (*SimulateTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SimulateTransactionRequest) GetTransaction() *entities.Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *SimulateTransactionRequest) GetBlockHeight() uint64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *SimulateTransactionRequest) GetSkipSignatureCheck() bool {
+	if x != nil {
+		return x.SkipSignatureCheck
+	}
+	return false
+}
+
+func (x *SimulateTransactionRequest) GetEventEncodingVersion() entities.EventEncodingVersion {
+	if x != nil {
+		return x.EventEncodingVersion
+	}
+	return entities.EventEncodingVersion(0)
+}
+
+// AccountStorageDelta is the change in storage used by an account.
+type AccountStorageDelta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address           []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	StorageUsedBefore uint64 `protobuf:"varint,2,opt,name=storage_used_before,json=storageUsedBefore,proto3" json:"storage_used_before,omitempty"`
+	StorageUsedAfter  uint64 `protobuf:"varint,3,opt,name=storage_used_after,json=storageUsedAfter,proto3" json:"storage_used_after,omitempty"`
+}
+
+func (x *AccountStorageDelta) Reset() {
+	*x = AccountStorageDelta{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccountStorageDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountStorageDelta) ProtoMessage() {}
+
+func (x *AccountStorageDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountStorageDelta.ProtoReflect.Descriptor instead.
+func (*AccountStorageDelta) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AccountStorageDelta) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *AccountStorageDelta) GetStorageUsedBefore() uint64 {
+	if x != nil {
+		return x.StorageUsedBefore
+	}
+	return 0
+}
+
+func (x *AccountStorageDelta) GetStorageUsedAfter() uint64 {
+	if x != nil {
+		return x.StorageUsedAfter
+	}
+	return 0
+}
+
+type SimulateTransactionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId     []byte `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	BlockHeight uint64 `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	// status_code is 0 if the transaction succeeded, and 1 if it failed.
+	StatusCode      uint32            `protobuf:"varint,3,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	ErrorMessage    string            `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Events          []*entities.Event `protobuf:"bytes,5,rep,name=events,proto3" json:"events,omitempty"`
+	ComputationUsed uint64            `protobuf:"varint,6,opt,name=computation_used,json=computationUsed,proto3" json:"computation_used,omitempty"`
+	MemoryEstimate  uint64            `protobuf:"varint,7,opt,name=memory_estimate,json=memoryEstimate,proto3" json:"memory_estimate,omitempty"`
+	// storage_deltas contains the change in storage used of every account whose storage was modified by the
+	// transaction, ordered by address.
+	StorageDeltas []*AccountStorageDelta `protobuf:"bytes,8,rep,name=storage_deltas,json=storageDeltas,proto3" json:"storage_deltas,omitempty"`
+}
+
+func (x *SimulateTransactionResponse) Reset() {
+	*x = SimulateTransactionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SimulateTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulateTransactionResponse) ProtoMessage() {}
+
+func (x *SimulateTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulateTransactionResponse.ProtoReflect.Descriptor instead.
+func (*SimulateTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SimulateTransactionResponse) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *SimulateTransactionResponse) GetBlockHeight() uint64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *SimulateTransactionResponse) GetStatusCode() uint32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *SimulateTransactionResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *SimulateTransactionResponse) GetEvents() []*entities.Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *SimulateTransactionResponse) GetComputationUsed() uint64 {
+	if x != nil {
+		return x.ComputationUsed
+	}
+	return 0
+}
+
+func (x *SimulateTransactionResponse) GetMemoryEstimate() uint64 {
+	if x != nil {
+		return x.MemoryEstimate
+	}
+	return 0
+}
+
+func (x *SimulateTransactionResponse) GetStorageDeltas() []*AccountStorageDelta {
+	if x != nil {
+		return x.StorageDeltas
+	}
+	return nil
+}
+
+type EstimateTransactionFeesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Transaction *entities.Transaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	// block_height is the height of the block whose state the transaction is executed against.
+	BlockHeight uint64 `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+}
+
+func (x *EstimateTransactionFeesRequest) Reset() {
+	*x = EstimateTransactionFeesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EstimateTransactionFeesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateTransactionFeesRequest) ProtoMessage() {}
+
+func (x *EstimateTransactionFeesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateTransactionFeesRequest.ProtoReflect.Descriptor instead.
+func (*EstimateTransactionFeesRequest) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EstimateTransactionFeesRequest) GetTransaction() *entities.Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *EstimateTransactionFeesRequest) GetBlockHeight() uint64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+// TransactionFeeParameters are the on-chain parameters used to compute transaction fees, as UFix64 values.
+type TransactionFeeParameters struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SurgeFactor         uint64 `protobuf:"varint,1,opt,name=surge_factor,json=surgeFactor,proto3" json:"surge_factor,omitempty"`
+	InclusionEffortCost uint64 `protobuf:"varint,2,opt,name=inclusion_effort_cost,json=inclusionEffortCost,proto3" json:"inclusion_effort_cost,omitempty"`
+	ExecutionEffortCost uint64 `protobuf:"varint,3,opt,name=execution_effort_cost,json=executionEffortCost,proto3" json:"execution_effort_cost,omitempty"`
+}
+
+func (x *TransactionFeeParameters) Reset() {
+	*x = TransactionFeeParameters{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactionFeeParameters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionFeeParameters) ProtoMessage() {}
+
+func (x *TransactionFeeParameters) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionFeeParameters.ProtoReflect.Descriptor instead.
+func (*TransactionFeeParameters) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TransactionFeeParameters) GetSurgeFactor() uint64 {
+	if x != nil {
+		return x.SurgeFactor
+	}
+	return 0
+}
+
+func (x *TransactionFeeParameters) GetInclusionEffortCost() uint64 {
+	if x != nil {
+		return x.InclusionEffortCost
+	}
+	return 0
+}
+
+func (x *TransactionFeeParameters) GetExecutionEffortCost() uint64 {
+	if x != nil {
+		return x.ExecutionEffortCost
+	}
+	return 0
+}
+
+// EstimateTransactionFeesResponse contains the computation and fees needed to execute a transaction.
+// Efforts and fees are UFix64 values, the fees are denominated in FLOW.
+type EstimateTransactionFeesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId     []byte `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	BlockHeight uint64 `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	// status_code is 0 if the transaction succeeded, and 1 if it failed.
+	StatusCode       uint32 `protobuf:"varint,3,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	ErrorMessage     string `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	ComputationLimit uint64 `protobuf:"varint,5,opt,name=computation_limit,json=computationLimit,proto3" json:"computation_limit,omitempty"`
+	ComputationUsed  uint64 `protobuf:"varint,6,opt,name=computation_used,json=computationUsed,proto3" json:"computation_used,omitempty"`
+	// computation_intensities contains the metered intensity of each kind of computation, keyed by the
+	// Cadence computation kind.
+	ComputationIntensities map[uint32]uint64         `protobuf:"bytes,7,rep,name=computation_intensities,json=computationIntensities,proto3" json:"computation_intensities,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	MemoryEstimate         uint64                    `protobuf:"varint,8,opt,name=memory_estimate,json=memoryEstimate,proto3" json:"memory_estimate,omitempty"`
+	InclusionEffort        uint64                    `protobuf:"varint,9,opt,name=inclusion_effort,json=inclusionEffort,proto3" json:"inclusion_effort,omitempty"`
+	ExecutionEffort        uint64                    `protobuf:"varint,10,opt,name=execution_effort,json=executionEffort,proto3" json:"execution_effort,omitempty"`
+	FeeParameters          *TransactionFeeParameters `protobuf:"bytes,11,opt,name=fee_parameters,json=feeParameters,proto3" json:"fee_parameters,omitempty"`
+	InclusionFee           uint64                    `protobuf:"varint,12,opt,name=inclusion_fee,json=inclusionFee,proto3" json:"inclusion_fee,omitempty"`
+	ExecutionFee           uint64                    `protobuf:"varint,13,opt,name=execution_fee,json=executionFee,proto3" json:"execution_fee,omitempty"`
+	TotalFee               uint64                    `protobuf:"varint,14,opt,name=total_fee,json=totalFee,proto3" json:"total_fee,omitempty"`
+}
+
+func (x *EstimateTransactionFeesResponse) Reset() {
+	*x = EstimateTransactionFeesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EstimateTransactionFeesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateTransactionFeesResponse) ProtoMessage() {}
+
+func (x *EstimateTransactionFeesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateTransactionFeesResponse.ProtoReflect.Descriptor instead.
+func (*EstimateTransactionFeesResponse) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EstimateTransactionFeesResponse) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *EstimateTransactionFeesResponse) GetBlockHeight() uint64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *EstimateTransactionFeesResponse) GetStatusCode() uint32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *EstimateTransactionFeesResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *EstimateTransactionFeesResponse) GetComputationLimit() uint64 {
+	if x != nil {
+		return x.ComputationLimit
+	}
+	return 0
+}
+
+func (x *EstimateTransactionFeesResponse) GetComputationUsed() uint64 {
+	if x != nil {
+		return x.ComputationUsed
+	}
+	return 0
+}
+
+func (x *EstimateTransactionFeesResponse) GetComputationIntensities() map[uint32]uint64 {
+	if x != nil {
+		return x.ComputationIntensities
+	}
+	return nil
+}
+
+func (x *EstimateTransactionFeesResponse) GetMemoryEstimate() uint64 {
+	if x != nil {
+		return x.MemoryEstimate
+	}
+	return 0
+}
+
+func (x *EstimateTransactionFeesResponse) GetInclusionEffort() uint64 {
+	if x != nil {
+		return x.InclusionEffort
+	}
+	return 0
+}
+
+func (x *EstimateTransactionFeesResponse) GetExecutionEffort() uint64 {
+	if x != nil {
+		return x.ExecutionEffort
+	}
+	return 0
+}
+
+func (x *EstimateTransactionFeesResponse) GetFeeParameters() *TransactionFeeParameters {
+	if x != nil {
+		return x.FeeParameters
+	}
+	return nil
+}
+
+func (x *EstimateTransactionFeesResponse) GetInclusionFee() uint64 {
+	if x != nil {
+		return x.InclusionFee
+	}
+	return 0
+}
+
+func (x *EstimateTransactionFeesResponse) GetExecutionFee() uint64 {
+	if x != nil {
+		return x.ExecutionFee
+	}
+	return 0
+}
+
+func (x *EstimateTransactionFeesResponse) GetTotalFee() uint64 {
+	if x != nil {
+		return x.TotalFee
+	}
+	return 0
+}
+
+// Script is a script of a batch, with its JSON-CDC encoded arguments.
+type Script struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Script    []byte   `protobuf:"bytes,1,opt,name=script,proto3" json:"script,omitempty"`
+	Arguments [][]byte `protobuf:"bytes,2,rep,name=arguments,proto3" json:"arguments,omitempty"`
+}
+
+func (x *Script) Reset() {
+	*x = Script{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Script) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Script) ProtoMessage() {}
+
+func (x *Script) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Script.ProtoReflect.Descriptor instead.
+func (*Script) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Script) GetScript() []byte {
+	if x != nil {
+		return x.Script
+	}
+	return nil
+}
+
+func (x *Script) GetArguments() [][]byte {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+type ExecuteScriptsAtBlockHeightRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockHeight uint64    `protobuf:"varint,1,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	Scripts     []*Script `protobuf:"bytes,2,rep,name=scripts,proto3" json:"scripts,omitempty"`
+}
+
+func (x *ExecuteScriptsAtBlockHeightRequest) Reset() {
+	*x = ExecuteScriptsAtBlockHeightRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecuteScriptsAtBlockHeightRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteScriptsAtBlockHeightRequest) ProtoMessage() {}
+
+func (x *ExecuteScriptsAtBlockHeightRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteScriptsAtBlockHeightRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteScriptsAtBlockHeightRequest) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExecuteScriptsAtBlockHeightRequest) GetBlockHeight() uint64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *ExecuteScriptsAtBlockHeightRequest) GetScripts() []*Script {
+	if x != nil {
+		return x.Scripts
+	}
+	return nil
+}
+
+// ScriptResult is the result of a script executed as part of a batch.
+type ScriptResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// value is the JSON-CDC encoded value returned by the script. It is empty if the script failed.
+	Value           []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	ComputationUsed uint64 `protobuf:"varint,2,opt,name=computation_used,json=computationUsed,proto3" json:"computation_used,omitempty"`
+	// error_code is the gRPC status code the script failed with, 0 if it succeeded.
+	ErrorCode    uint32 `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorMessage string `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (x *ScriptResult) Reset() {
+	*x = ScriptResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScriptResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScriptResult) ProtoMessage() {}
+
+func (x *ScriptResult) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScriptResult.ProtoReflect.Descriptor instead.
+func (*ScriptResult) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ScriptResult) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *ScriptResult) GetComputationUsed() uint64 {
+	if x != nil {
+		return x.ComputationUsed
+	}
+	return 0
+}
+
+func (x *ScriptResult) GetErrorCode() uint32 {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return 0
+}
+
+func (x *ScriptResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type ExecuteScriptsAtBlockHeightResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockHeight uint64          `protobuf:"varint,1,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	Results     []*ScriptResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *ExecuteScriptsAtBlockHeightResponse) Reset() {
+	*x = ExecuteScriptsAtBlockHeightResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecuteScriptsAtBlockHeightResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteScriptsAtBlockHeightResponse) ProtoMessage() {}
+
+func (x *ExecuteScriptsAtBlockHeightResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteScriptsAtBlockHeightResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteScriptsAtBlockHeightResponse) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ExecuteScriptsAtBlockHeightResponse) GetBlockHeight() uint64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *ExecuteScriptsAtBlockHeightResponse) GetResults() []*ScriptResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type GetAccountStorageDiffRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address    []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	FromHeight uint64 `protobuf:"varint,2,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`
+	ToHeight   uint64 `protobuf:"varint,3,opt,name=to_height,json=toHeight,proto3" json:"to_height,omitempty"`
+	// cursor is the key of the register to continue listing changes after, as returned with the previous
+	// page. If it is empty, changes are listed from the first register.
+	Cursor []byte `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// limit is the maximum number of changes to return.
+	Limit uint32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	// decode_paths requests the values stored at the storage paths of the account to be decoded and
+	// compared. They are only decoded for the first page.
+	DecodePaths bool `protobuf:"varint,6,opt,name=decode_paths,json=decodePaths,proto3" json:"decode_paths,omitempty"`
+}
+
+func (x *GetAccountStorageDiffRequest) Reset() {
+	*x = GetAccountStorageDiffRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAccountStorageDiffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountStorageDiffRequest) ProtoMessage() {}
+
+func (x *GetAccountStorageDiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountStorageDiffRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountStorageDiffRequest) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetAccountStorageDiffRequest) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *GetAccountStorageDiffRequest) GetFromHeight() uint64 {
+	if x != nil {
+		return x.FromHeight
+	}
+	return 0
+}
+
+func (x *GetAccountStorageDiffRequest) GetToHeight() uint64 {
+	if x != nil {
+		return x.ToHeight
+	}
+	return 0
+}
+
+func (x *GetAccountStorageDiffRequest) GetCursor() []byte {
+	if x != nil {
+		return x.Cursor
+	}
+	return nil
+}
+
+func (x *GetAccountStorageDiffRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetAccountStorageDiffRequest) GetDecodePaths() bool {
+	if x != nil {
+		return x.DecodePaths
+	}
+	return false
+}
+
+// AccountRegisterChange is the change of a register of an account between two heights. A register which
+// does not exist at a height has an empty value.
+type AccountRegisterChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key         []byte              `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Type        RegisterChangeType  `protobuf:"varint,2,opt,name=type,proto3,enum=flow.extended.RegisterChangeType" json:"type,omitempty"`
+	ValueBefore []byte              `protobuf:"bytes,3,opt,name=value_before,json=valueBefore,proto3" json:"value_before,omitempty"`
+	ValueAfter  []byte              `protobuf:"bytes,4,opt,name=value_after,json=valueAfter,proto3" json:"value_after,omitempty"`
+	Kind        AccountRegisterKind `protobuf:"varint,5,opt,name=kind,proto3,enum=flow.extended.AccountRegisterKind" json:"kind,omitempty"`
+	// name identifies the register within its kind: the contract name for contract code, the domain for
+	// storage domains, the key index for public keys and the slab index for slabs.
+	Name string `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *AccountRegisterChange) Reset() {
+	*x = AccountRegisterChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccountRegisterChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountRegisterChange) ProtoMessage() {}
+
+func (x *AccountRegisterChange) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountRegisterChange.ProtoReflect.Descriptor instead.
+func (*AccountRegisterChange) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AccountRegisterChange) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *AccountRegisterChange) GetType() RegisterChangeType {
+	if x != nil {
+		return x.Type
+	}
+	return RegisterChangeType_REGISTER_CHANGE_TYPE_UNKNOWN
+}
+
+func (x *AccountRegisterChange) GetValueBefore() []byte {
+	if x != nil {
+		return x.ValueBefore
+	}
+	return nil
+}
+
+func (x *AccountRegisterChange) GetValueAfter() []byte {
+	if x != nil {
+		return x.ValueAfter
+	}
+	return nil
+}
+
+func (x *AccountRegisterChange) GetKind() AccountRegisterKind {
+	if x != nil {
+		return x.Kind
+	}
+	return AccountRegisterKind_ACCOUNT_REGISTER_KIND_UNKNOWN
+}
+
+func (x *AccountRegisterChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// StoragePathChange is the change of the value stored at a storage path between two heights. Values are
+// given in their Cadence string representation, and are empty if no value is stored at the path.
+type StoragePathChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path        string             `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Type        RegisterChangeType `protobuf:"varint,2,opt,name=type,proto3,enum=flow.extended.RegisterChangeType" json:"type,omitempty"`
+	ValueBefore string             `protobuf:"bytes,3,opt,name=value_before,json=valueBefore,proto3" json:"value_before,omitempty"`
+	ValueAfter  string             `protobuf:"bytes,4,opt,name=value_after,json=valueAfter,proto3" json:"value_after,omitempty"`
+}
+
+func (x *StoragePathChange) Reset() {
+	*x = StoragePathChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StoragePathChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoragePathChange) ProtoMessage() {}
+
+func (x *StoragePathChange) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoragePathChange.ProtoReflect.Descriptor instead.
+func (*StoragePathChange) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StoragePathChange) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *StoragePathChange) GetType() RegisterChangeType {
+	if x != nil {
+		return x.Type
+	}
+	return RegisterChangeType_REGISTER_CHANGE_TYPE_UNKNOWN
+}
+
+func (x *StoragePathChange) GetValueBefore() string {
+	if x != nil {
+		return x.ValueBefore
+	}
+	return ""
+}
+
+func (x *StoragePathChange) GetValueAfter() string {
+	if x != nil {
+		return x.ValueAfter
+	}
+	return ""
+}
+
+type GetAccountStorageDiffResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address    []byte                   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	FromHeight uint64                   `protobuf:"varint,2,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`
+	ToHeight   uint64                   `protobuf:"varint,3,opt,name=to_height,json=toHeight,proto3" json:"to_height,omitempty"`
+	Changes    []*AccountRegisterChange `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"`
+	// next_cursor is the cursor of the next page. It is empty on the last page.
+	NextCursor  []byte               `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	PathChanges []*StoragePathChange `protobuf:"bytes,6,rep,name=path_changes,json=pathChanges,proto3" json:"path_changes,omitempty"`
+	// path_changes_decoded is true if the values stored at the storage paths were decoded.
+	PathChangesDecoded bool `protobuf:"varint,7,opt,name=path_changes_decoded,json=pathChangesDecoded,proto3" json:"path_changes_decoded,omitempty"`
+}
+
+func (x *GetAccountStorageDiffResponse) Reset() {
+	*x = GetAccountStorageDiffResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAccountStorageDiffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountStorageDiffResponse) ProtoMessage() {}
+
+func (x *GetAccountStorageDiffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountStorageDiffResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountStorageDiffResponse) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetAccountStorageDiffResponse) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *GetAccountStorageDiffResponse) GetFromHeight() uint64 {
+	if x != nil {
+		return x.FromHeight
+	}
+	return 0
+}
+
+func (x *GetAccountStorageDiffResponse) GetToHeight() uint64 {
+	if x != nil {
+		return x.ToHeight
+	}
+	return 0
+}
+
+func (x *GetAccountStorageDiffResponse) GetChanges() []*AccountRegisterChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *GetAccountStorageDiffResponse) GetNextCursor() []byte {
+	if x != nil {
+		return x.NextCursor
+	}
+	return nil
+}
+
+func (x *GetAccountStorageDiffResponse) GetPathChanges() []*StoragePathChange {
+	if x != nil {
+		return x.PathChanges
+	}
+	return nil
+}
+
+func (x *GetAccountStorageDiffResponse) GetPathChangesDecoded() bool {
+	if x != nil {
+		return x.PathChangesDecoded
+	}
+	return false
+}
+
+// EVMTracerConfig selects the tracer used to trace EVM transactions. If it is not set, the call tracer
+// is used.
+type EVMTracerConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// tracer is the name of the tracer, the call tracer is used if it is empty.
+	Tracer string `protobuf:"bytes,1,opt,name=tracer,proto3" json:"tracer,omitempty"`
+	// config is the JSON encoded configuration of the tracer, if any.
+	Config []byte `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+}
+
+func (x *EVMTracerConfig) Reset() {
+	*x = EVMTracerConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EVMTracerConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EVMTracerConfig) ProtoMessage() {}
+
+func (x *EVMTracerConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EVMTracerConfig.ProtoReflect.Descriptor instead.
+func (*EVMTracerConfig) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *EVMTracerConfig) GetTracer() string {
+	if x != nil {
+		return x.Tracer
+	}
+	return ""
+}
+
+func (x *EVMTracerConfig) GetConfig() []byte {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type GetEVMBlockTracesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId []byte           `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Tracer  *EVMTracerConfig `protobuf:"bytes,2,opt,name=tracer,proto3" json:"tracer,omitempty"`
+}
+
+func (x *GetEVMBlockTracesRequest) Reset() {
+	*x = GetEVMBlockTracesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEVMBlockTracesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEVMBlockTracesRequest) ProtoMessage() {}
+
+func (x *GetEVMBlockTracesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEVMBlockTracesRequest.ProtoReflect.Descriptor instead.
+func (*GetEVMBlockTracesRequest) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetEVMBlockTracesRequest) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *GetEVMBlockTracesRequest) GetTracer() *EVMTracerConfig {
+	if x != nil {
+		return x.Tracer
+	}
+	return nil
+}
+
+// EVMTransactionTrace is the trace of an EVM transaction.
+type EVMTransactionTrace struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxHash []byte `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	// result is the JSON encoded trace produced by the tracer.
+	Result []byte `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *EVMTransactionTrace) Reset() {
+	*x = EVMTransactionTrace{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EVMTransactionTrace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EVMTransactionTrace) ProtoMessage() {}
+
+func (x *EVMTransactionTrace) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EVMTransactionTrace.ProtoReflect.Descriptor instead.
+func (*EVMTransactionTrace) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *EVMTransactionTrace) GetTxHash() []byte {
+	if x != nil {
+		return x.TxHash
+	}
+	return nil
+}
+
+func (x *EVMTransactionTrace) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type GetEVMBlockTracesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Traces []*EVMTransactionTrace `protobuf:"bytes,1,rep,name=traces,proto3" json:"traces,omitempty"`
+}
+
+func (x *GetEVMBlockTracesResponse) Reset() {
+	*x = GetEVMBlockTracesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEVMBlockTracesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEVMBlockTracesResponse) ProtoMessage() {}
+
+func (x *GetEVMBlockTracesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEVMBlockTracesResponse.ProtoReflect.Descriptor instead.
+func (*GetEVMBlockTracesResponse) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetEVMBlockTracesResponse) GetTraces() []*EVMTransactionTrace {
+	if x != nil {
+		return x.Traces
+	}
+	return nil
+}
+
+type GetEVMTransactionTraceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId []byte           `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	TxHash  []byte           `protobuf:"bytes,2,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	Tracer  *EVMTracerConfig `protobuf:"bytes,3,opt,name=tracer,proto3" json:"tracer,omitempty"`
+}
+
+func (x *GetEVMTransactionTraceRequest) Reset() {
+	*x = GetEVMTransactionTraceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEVMTransactionTraceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEVMTransactionTraceRequest) ProtoMessage() {}
+
+func (x *GetEVMTransactionTraceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEVMTransactionTraceRequest.ProtoReflect.Descriptor instead.
+func (*GetEVMTransactionTraceRequest) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetEVMTransactionTraceRequest) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *GetEVMTransactionTraceRequest) GetTxHash() []byte {
+	if x != nil {
+		return x.TxHash
+	}
+	return nil
+}
+
+func (x *GetEVMTransactionTraceRequest) GetTracer() *EVMTracerConfig {
+	if x != nil {
+		return x.Tracer
+	}
+	return nil
+}
+
+type GetEVMTransactionTraceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// result is the JSON encoded trace produced by the tracer.
+	Result []byte `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *GetEVMTransactionTraceResponse) Reset() {
+	*x = GetEVMTransactionTraceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEVMTransactionTraceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEVMTransactionTraceResponse) ProtoMessage() {}
+
+func (x *GetEVMTransactionTraceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEVMTransactionTraceResponse.ProtoReflect.Descriptor instead.
+func (*GetEVMTransactionTraceResponse) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetEVMTransactionTraceResponse) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// EVMLogTopics are the topics a log may have at a position. No topics match any topic.
+type EVMLogTopics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Topics [][]byte `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+}
+
+func (x *EVMLogTopics) Reset() {
+	*x = EVMLogTopics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EVMLogTopics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EVMLogTopics) ProtoMessage() {}
+
+func (x *EVMLogTopics) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EVMLogTopics.ProtoReflect.Descriptor instead.
+func (*EVMLogTopics) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *EVMLogTopics) GetTopics() [][]byte {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+// EVMLogFilter selects EVM logs by emitting contract and topics, with the semantics of eth_getLogs. A log
+// matches if it was emitted by any of the addresses, or if no addresses are given, and if for every
+// position its topic is any of the topics of the position.
+type EVMLogFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Addresses [][]byte        `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Topics    []*EVMLogTopics `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty"`
+}
+
+func (x *EVMLogFilter) Reset() {
+	*x = EVMLogFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EVMLogFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EVMLogFilter) ProtoMessage() {}
+
+func (x *EVMLogFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EVMLogFilter.ProtoReflect.Descriptor instead.
+func (*EVMLogFilter) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *EVMLogFilter) GetAddresses() [][]byte {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *EVMLogFilter) GetTopics() []*EVMLogTopics {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+// EVMLogCursor identifies a position in the EVM log index.
+type EVMLogCursor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockHeight uint64 `protobuf:"varint,1,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	LogIndex    uint32 `protobuf:"varint,2,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+}
+
+func (x *EVMLogCursor) Reset() {
+	*x = EVMLogCursor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EVMLogCursor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EVMLogCursor) ProtoMessage() {}
+
+func (x *EVMLogCursor) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EVMLogCursor.ProtoReflect.Descriptor instead.
+func (*EVMLogCursor) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *EVMLogCursor) GetBlockHeight() uint64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *EVMLogCursor) GetLogIndex() uint32 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+// EVMLog is a log emitted by an EVM transaction.
+type EVMLog struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockHeight uint64 `protobuf:"varint,1,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	// transaction_id is the ID of the Flow transaction which executed the EVM transaction.
+	TransactionId       []byte `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	EvmBlockHeight      uint64 `protobuf:"varint,3,opt,name=evm_block_height,json=evmBlockHeight,proto3" json:"evm_block_height,omitempty"`
+	EvmTransactionHash  []byte `protobuf:"bytes,4,opt,name=evm_transaction_hash,json=evmTransactionHash,proto3" json:"evm_transaction_hash,omitempty"`
+	EvmTransactionIndex uint32 `protobuf:"varint,5,opt,name=evm_transaction_index,json=evmTransactionIndex,proto3" json:"evm_transaction_index,omitempty"`
+	// log_index is the position of the log among all EVM logs emitted in the block.
+	LogIndex uint32   `protobuf:"varint,6,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	Address  []byte   `protobuf:"bytes,7,opt,name=address,proto3" json:"address,omitempty"`
+	Topics   [][]byte `protobuf:"bytes,8,rep,name=topics,proto3" json:"topics,omitempty"`
+	Data     []byte   `protobuf:"bytes,9,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *EVMLog) Reset() {
+	*x = EVMLog{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EVMLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EVMLog) ProtoMessage() {}
+
+func (x *EVMLog) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EVMLog.ProtoReflect.Descriptor instead.
+func (*EVMLog) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *EVMLog) GetBlockHeight() uint64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *EVMLog) GetTransactionId() []byte {
+	if x != nil {
+		return x.TransactionId
+	}
+	return nil
+}
+
+func (x *EVMLog) GetEvmBlockHeight() uint64 {
+	if x != nil {
+		return x.EvmBlockHeight
+	}
+	return 0
+}
+
+func (x *EVMLog) GetEvmTransactionHash() []byte {
+	if x != nil {
+		return x.EvmTransactionHash
+	}
+	return nil
+}
+
+func (x *EVMLog) GetEvmTransactionIndex() uint32 {
+	if x != nil {
+		return x.EvmTransactionIndex
+	}
+	return 0
+}
+
+func (x *EVMLog) GetLogIndex() uint32 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+func (x *EVMLog) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *EVMLog) GetTopics() [][]byte {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *EVMLog) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type GetEVMLogsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Filter *EVMLogFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// start_height is the lowest block height to include. 0 uses the lowest indexed height.
+	StartHeight uint64 `protobuf:"varint,2,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	// end_height is the highest block height to include. 0 uses the highest indexed height.
+	EndHeight uint64 `protobuf:"varint,3,opt,name=end_height,json=endHeight,proto3" json:"end_height,omitempty"`
+	// cursor is the position to continue from, as returned with the previous page. If it is not set, logs
+	// are returned from the start height.
+	Cursor *EVMLogCursor `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// limit is the maximum number of logs to return.
+	Limit uint32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *GetEVMLogsRequest) Reset() {
+	*x = GetEVMLogsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEVMLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEVMLogsRequest) ProtoMessage() {}
+
+func (x *GetEVMLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEVMLogsRequest.ProtoReflect.Descriptor instead.
+func (*GetEVMLogsRequest) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetEVMLogsRequest) GetFilter() *EVMLogFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *GetEVMLogsRequest) GetStartHeight() uint64 {
+	if x != nil {
+		return x.StartHeight
+	}
+	return 0
+}
+
+func (x *GetEVMLogsRequest) GetEndHeight() uint64 {
+	if x != nil {
+		return x.EndHeight
+	}
+	return 0
+}
+
+func (x *GetEVMLogsRequest) GetCursor() *EVMLogCursor {
+	if x != nil {
+		return x.Cursor
+	}
+	return nil
+}
+
+func (x *GetEVMLogsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetEVMLogsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Logs []*EVMLog `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	// next_cursor points at the first log of the next page. It is not set on the last page.
+	NextCursor *EVMLogCursor `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+}
+
+func (x *GetEVMLogsResponse) Reset() {
+	*x = GetEVMLogsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEVMLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEVMLogsResponse) ProtoMessage() {}
+
+func (x *GetEVMLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEVMLogsResponse.ProtoReflect.Descriptor instead.
+func (*GetEVMLogsResponse) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetEVMLogsResponse) GetLogs() []*EVMLog {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+func (x *GetEVMLogsResponse) GetNextCursor() *EVMLogCursor {
+	if x != nil {
+		return x.NextCursor
+	}
+	return nil
+}
+
+type SubscribeEVMLogsFromStartHeightRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// start_block_height is the height of the first block to stream logs of. 0 starts at the latest sealed
+	// block.
+	StartBlockHeight uint64        `protobuf:"varint,1,opt,name=start_block_height,json=startBlockHeight,proto3" json:"start_block_height,omitempty"`
+	Filter           *EVMLogFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+}
+
+func (x *SubscribeEVMLogsFromStartHeightRequest) Reset() {
+	*x = SubscribeEVMLogsFromStartHeightRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeEVMLogsFromStartHeightRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeEVMLogsFromStartHeightRequest) ProtoMessage() {}
+
+func (x *SubscribeEVMLogsFromStartHeightRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeEVMLogsFromStartHeightRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeEVMLogsFromStartHeightRequest) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SubscribeEVMLogsFromStartHeightRequest) GetStartBlockHeight() uint64 {
+	if x != nil {
+		return x.StartBlockHeight
+	}
+	return 0
+}
+
+func (x *SubscribeEVMLogsFromStartHeightRequest) GetFilter() *EVMLogFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type SubscribeEVMLogsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockHeight uint64    `protobuf:"varint,1,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	Logs        []*EVMLog `protobuf:"bytes,2,rep,name=logs,proto3" json:"logs,omitempty"`
+}
+
+func (x *SubscribeEVMLogsResponse) Reset() {
+	*x = SubscribeEVMLogsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extended_access_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeEVMLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeEVMLogsResponse) ProtoMessage() {}
+
+func (x *SubscribeEVMLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extended_access_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeEVMLogsResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeEVMLogsResponse) Descriptor() ([]byte, []int) {
+	return file_access_extended_access_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SubscribeEVMLogsResponse) GetBlockHeight() uint64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *SubscribeEVMLogsResponse) GetLogs() []*EVMLog {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+var File_access_extended_access_proto protoreflect.FileDescriptor
+
+var file_access_extended_access_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x2f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65,
+	0x64, 0x2f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d,
+	0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x1a, 0x18, 0x66,
+	0x6c, 0x6f, 0x77, 0x2f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x2f, 0x61, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1f, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x6a, 0x0a, 0x18, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12,
+	0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22,
+	0xe2, 0x01, 0x0a, 0x12, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x3b, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78,
+	0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x05, 0x72,
+	0x6f, 0x6c, 0x65, 0x73, 0x22, 0xd2, 0x01, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e, 0x64, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x48, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x12, 0x3f, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x27, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64,
+	0x65, 0x64, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x52, 0x06, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0xb1, 0x01, 0x0a, 0x1e, 0x47, 0x65,
+	0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x0c,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64,
+	0x65, 0x64, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x48, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0xe7, 0x01,
+	0x0a, 0x1e, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x46, 0x6f, 0x72, 0x48, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e, 0x64, 0x5f, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x65, 0x6e, 0x64,
+	0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x68, 0x65, 0x72, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x77, 0x68, 0x65, 0x72, 0x65, 0x12, 0x59, 0x0a, 0x16,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x66,
+	0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2e, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x14, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x5f, 0x0a, 0x1f, 0x47, 0x65, 0x74, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x46, 0x6f, 0x72, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x07, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x6c,
+	0x6f, 0x77, 0x2e, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52,
+	0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x8a, 0x02, 0x0a, 0x1a, 0x53, 0x69, 0x6d,
+	0x75, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x66,
+	0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2e, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x73, 0x6b, 0x69, 0x70,
+	0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x73, 0x6b, 0x69, 0x70, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x59, 0x0a, 0x16, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x66, 0x6c, 0x6f,
+	0x77, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x14, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x8d, 0x01, 0x0a, 0x13, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x55, 0x73, 0x65,
+	0x64, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x10, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x55, 0x73, 0x65, 0x64,
+	0x41, 0x66, 0x74, 0x65, 0x72, 0x22, 0xee, 0x02, 0x0a, 0x1b, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61,
+	0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x66, 0x6c, 0x6f, 0x77,
+	0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52,
+	0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x75,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x73,
+	0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x65, 0x73, 0x74,
+	0x69, 0x6d, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x12, 0x49, 0x0a, 0x0e, 0x73,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x73, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e,
+	0x64, 0x65, 0x64, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x52, 0x0d, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x44, 0x65, 0x6c, 0x74, 0x61, 0x73, 0x22, 0x81, 0x01, 0x0a, 0x1e, 0x45, 0x73, 0x74, 0x69, 0x6d,
+	0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x0b, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2e, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0xa5, 0x01, 0x0a, 0x18, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x65, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x75, 0x72, 0x67, 0x65,
+	0x5f, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x73,
+	0x75, 0x72, 0x67, 0x65, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x32, 0x0a, 0x15, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x66, 0x66, 0x6f, 0x72, 0x74, 0x5f, 0x63,
+	0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x73, 0x69, 0x6f, 0x6e, 0x45, 0x66, 0x66, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x32,
+	0x0a, 0x15, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x66, 0x66, 0x6f,
+	0x72, 0x74, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x65,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x66, 0x66, 0x6f, 0x72, 0x74, 0x43, 0x6f,
+	0x73, 0x74, 0x22, 0x84, 0x06, 0x0a, 0x1f, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49,
+	0x64, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f,
+	0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x75,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x73,
+	0x65, 0x64, 0x12, 0x83, 0x01, 0x0a, 0x17, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x4a, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65,
+	0x6e, 0x64, 0x65, 0x64, 0x2e, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x16, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x74,
+	0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x5f, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0e, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74,
+	0x65, 0x12, 0x29, 0x0a, 0x10, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x65,
+	0x66, 0x66, 0x6f, 0x72, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x66, 0x66, 0x6f, 0x72, 0x74, 0x12, 0x29, 0x0a, 0x10,
+	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x66, 0x66, 0x6f, 0x72, 0x74,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x45, 0x66, 0x66, 0x6f, 0x72, 0x74, 0x12, 0x4e, 0x0a, 0x0e, 0x66, 0x65, 0x65, 0x5f, 0x70,
+	0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x27, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x65, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x0d, 0x66, 0x65, 0x65, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c,
+	0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x65, 0x12, 0x23, 0x0a, 0x0d,
+	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0c, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x46, 0x65, 0x65, 0x1a, 0x49,
+	0x0a, 0x1b, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x74,
+	0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3e, 0x0a, 0x06, 0x53, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61,
+	0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09,
+	0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x78, 0x0a, 0x22, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x65, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x41, 0x74, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x12, 0x2f, 0x0a, 0x07, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e,
+	0x64, 0x65, 0x64, 0x2e, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x52, 0x07, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x73, 0x22, 0x93, 0x01, 0x0a, 0x0c, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f,
+	0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x55, 0x73, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x7f, 0x0a, 0x23, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x65, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x41, 0x74, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x35, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65,
+	0x6e, 0x64, 0x65, 0x64, 0x2e, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0xc7, 0x01, 0x0a, 0x1c, 0x47,
+	0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x66, 0x72, 0x6f, 0x6d,
+	0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x5f, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x74, 0x6f, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x64, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x50,
+	0x61, 0x74, 0x68, 0x73, 0x22, 0xf0, 0x01, 0x0a, 0x15, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x35, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21,
+	0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x36, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x66, 0x6c, 0x6f, 0x77,
+	0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xa2, 0x01, 0x0a, 0x11, 0x53, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x50, 0x61, 0x74, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x12, 0x35, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x21, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x66, 0x74, 0x65, 0x72, 0x22, 0xcf, 0x02, 0x0a,
+	0x1d, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6d,
+	0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x66,
+	0x72, 0x6f, 0x6d, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x5f,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x74, 0x6f,
+	0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x3e, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63,
+	0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x6e, 0x65, 0x78,
+	0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x43, 0x0a, 0x0c, 0x70, 0x61, 0x74, 0x68, 0x5f,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x53, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x50, 0x61, 0x74, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52,
+	0x0b, 0x70, 0x61, 0x74, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x14,
+	0x70, 0x61, 0x74, 0x68, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x5f, 0x64, 0x65, 0x63,
+	0x6f, 0x64, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x70, 0x61, 0x74, 0x68,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x22, 0x41,
+	0x0a, 0x0f, 0x45, 0x56, 0x4d, 0x54, 0x72, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x72, 0x61, 0x63, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x74, 0x72, 0x61, 0x63, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x22, 0x6d, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x45, 0x56, 0x4d, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x54, 0x72, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x06, 0x74, 0x72, 0x61, 0x63,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x45, 0x56, 0x4d, 0x54, 0x72, 0x61, 0x63,
+	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x74, 0x72, 0x61, 0x63, 0x65, 0x72,
+	0x22, 0x46, 0x0a, 0x13, 0x45, 0x56, 0x4d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x78, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x57, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x45,
+	0x56, 0x4d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x72, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x06, 0x74, 0x72, 0x61, 0x63, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74,
+	0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x45, 0x56, 0x4d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x06, 0x74, 0x72, 0x61, 0x63, 0x65,
+	0x73, 0x22, 0x8b, 0x01, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x45, 0x56, 0x4d, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x17,
+	0x0a, 0x07, 0x74, 0x78, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x12, 0x36, 0x0a, 0x06, 0x74, 0x72, 0x61, 0x63, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x45, 0x56, 0x4d, 0x54, 0x72, 0x61, 0x63, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x74, 0x72, 0x61, 0x63, 0x65, 0x72, 0x22,
+	0x38, 0x0a, 0x1e, 0x47, 0x65, 0x74, 0x45, 0x56, 0x4d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x26, 0x0a, 0x0c, 0x45, 0x56, 0x4d,
+	0x4c, 0x6f, 0x67, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x70,
+	0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63,
+	0x73, 0x22, 0x61, 0x0a, 0x0c, 0x45, 0x56, 0x4d, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12,
+	0x33, 0x0a, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e,
+	0x45, 0x56, 0x4d, 0x4c, 0x6f, 0x67, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x52, 0x06, 0x74, 0x6f,
+	0x70, 0x69, 0x63, 0x73, 0x22, 0x4e, 0x0a, 0x0c, 0x45, 0x56, 0x4d, 0x4c, 0x6f, 0x67, 0x43, 0x75,
+	0x72, 0x73, 0x6f, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x67, 0x5f, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6c, 0x6f, 0x67, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x22, 0xc5, 0x02, 0x0a, 0x06, 0x45, 0x56, 0x4d, 0x4c, 0x6f, 0x67, 0x12,
+	0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x65, 0x76, 0x6d,
+	0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0e, 0x65, 0x76, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x65, 0x76, 0x6d, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x12, 0x65, 0x76, 0x6d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x48, 0x61, 0x73, 0x68, 0x12, 0x32, 0x0a, 0x15, 0x65, 0x76, 0x6d, 0x5f, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x65, 0x76, 0x6d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x67,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6c, 0x6f,
+	0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xd5, 0x01, 0x0a,
+	0x11, 0x47, 0x65, 0x74, 0x45, 0x56, 0x4d, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64,
+	0x65, 0x64, 0x2e, 0x45, 0x56, 0x4d, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52,
+	0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e,
+	0x64, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x65, 0x6e, 0x64, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x66, 0x6c, 0x6f, 0x77,
+	0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x45, 0x56, 0x4d, 0x4c, 0x6f, 0x67,
+	0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x22, 0x7d, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x45, 0x56, 0x4d, 0x4c, 0x6f,
+	0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x04, 0x6c, 0x6f,
+	0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x45, 0x56, 0x4d, 0x4c, 0x6f, 0x67, 0x52,
+	0x04, 0x6c, 0x6f, 0x67, 0x73, 0x12, 0x3c, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75,
+	0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x66, 0x6c, 0x6f,
+	0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x45, 0x56, 0x4d, 0x4c, 0x6f,
+	0x67, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x22, 0x8b, 0x01, 0x0a, 0x26, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x45, 0x56, 0x4d, 0x4c, 0x6f, 0x67, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c,
+	0x0a, 0x12, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x33, 0x0a, 0x06,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x66,
+	0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x45, 0x56, 0x4d,
+	0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x22, 0x68, 0x0a, 0x18, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x45, 0x56,
+	0x4d, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a,
+	0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x12, 0x29, 0x0a, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x45,
+	0x56, 0x4d, 0x4c, 0x6f, 0x67, 0x52, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x2a, 0xdc, 0x01, 0x0a, 0x16,
+	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x20, 0x41, 0x43, 0x43, 0x4f, 0x55, 0x4e,
+	0x54, 0x5f, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x4f,
+	0x4c, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x22, 0x0a, 0x1e,
+	0x41, 0x43, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x41, 0x43, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x50, 0x41, 0x59, 0x45, 0x52, 0x10, 0x01,
+	0x12, 0x25, 0x0a, 0x21, 0x41, 0x43, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x54, 0x52, 0x41, 0x4e,
+	0x53, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x50, 0x52, 0x4f,
+	0x50, 0x4f, 0x53, 0x45, 0x52, 0x10, 0x02, 0x12, 0x27, 0x0a, 0x23, 0x41, 0x43, 0x43, 0x4f, 0x55,
+	0x4e, 0x54, 0x5f, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52,
+	0x4f, 0x4c, 0x45, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x4f, 0x52, 0x49, 0x5a, 0x45, 0x52, 0x10, 0x03,
+	0x12, 0x28, 0x0a, 0x24, 0x41, 0x43, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x54, 0x52, 0x41, 0x4e,
+	0x53, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x49, 0x4e, 0x54,
+	0x45, 0x52, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x04, 0x2a, 0x9b, 0x01, 0x0a, 0x12, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x20, 0x0a, 0x1c, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x43, 0x48,
+	0x41, 0x4e, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
+	0x4e, 0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x5f,
+	0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x44, 0x44, 0x45,
+	0x44, 0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x5f,
+	0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x4d, 0x4f,
+	0x56, 0x45, 0x44, 0x10, 0x02, 0x12, 0x21, 0x0a, 0x1d, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x45,
+	0x52, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x4f,
+	0x44, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x9d, 0x02, 0x0a, 0x13, 0x41, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x4b, 0x69, 0x6e, 0x64,
+	0x12, 0x21, 0x0a, 0x1d, 0x41, 0x43, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x52, 0x45, 0x47, 0x49,
+	0x53, 0x54, 0x45, 0x52, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
+	0x4e, 0x10, 0x00, 0x12, 0x20, 0x0a, 0x1c, 0x41, 0x43, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x52,
+	0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x55, 0x53, 0x10, 0x01, 0x12, 0x24, 0x0a, 0x20, 0x41, 0x43, 0x43, 0x4f, 0x55, 0x4e, 0x54,
+	0x5f, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x50,
+	0x55, 0x42, 0x4c, 0x49, 0x43, 0x5f, 0x4b, 0x45, 0x59, 0x10, 0x02, 0x12, 0x28, 0x0a, 0x24, 0x41,
+	0x43, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x5f,
+	0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x41, 0x43, 0x54, 0x5f, 0x4e, 0x41,
+	0x4d, 0x45, 0x53, 0x10, 0x03, 0x12, 0x27, 0x0a, 0x23, 0x41, 0x43, 0x43, 0x4f, 0x55, 0x4e, 0x54,
+	0x5f, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x43,
+	0x4f, 0x4e, 0x54, 0x52, 0x41, 0x43, 0x54, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x10, 0x04, 0x12, 0x28,
+	0x0a, 0x24, 0x41, 0x43, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54,
+	0x45, 0x52, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f,
+	0x44, 0x4f, 0x4d, 0x41, 0x49, 0x4e, 0x10, 0x05, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x43, 0x43, 0x4f,
+	0x55, 0x4e, 0x54, 0x5f, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x4b, 0x49, 0x4e,
+	0x44, 0x5f, 0x53, 0x4c, 0x41, 0x42, 0x10, 0x06, 0x32, 0x9f, 0x09, 0x0a, 0x11, 0x45, 0x78, 0x74,
+	0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x41, 0x50, 0x49, 0x12, 0x75,
+	0x0a, 0x16, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2c, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78,
+	0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x78, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x46, 0x6f, 0x72, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x2d, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64,
+	0x2e, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x46, 0x6f, 0x72, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2e, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e,
+	0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x46, 0x6f, 0x72, 0x48, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x6c, 0x0a, 0x13, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78,
+	0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2a, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65,
+	0x64, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x78, 0x0a,
+	0x17, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x65, 0x73, 0x12, 0x2d, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74,
+	0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x84, 0x01, 0x0a, 0x1b, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x65, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x41, 0x74, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x31, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x53,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x41, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x66, 0x6c, 0x6f,
+	0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x65, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x41, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x72,
+	0x0a, 0x15, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x44, 0x69, 0x66, 0x66, 0x12, 0x2b, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65,
+	0x6e, 0x64, 0x65, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x53,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x66, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x45, 0x56, 0x4d, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x54, 0x72, 0x61, 0x63, 0x65, 0x73, 0x12, 0x27, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x56, 0x4d, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x54, 0x72, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x28, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64,
+	0x2e, 0x47, 0x65, 0x74, 0x45, 0x56, 0x4d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x72, 0x61, 0x63,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x75, 0x0a, 0x16, 0x47, 0x65,
+	0x74, 0x45, 0x56, 0x4d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x12, 0x2c, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65,
+	0x6e, 0x64, 0x65, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x56, 0x4d, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64,
+	0x65, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x56, 0x4d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x51, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x45, 0x56, 0x4d, 0x4c, 0x6f, 0x67, 0x73, 0x12,
+	0x20, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e,
+	0x47, 0x65, 0x74, 0x45, 0x56, 0x4d, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x21, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65,
+	0x64, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x56, 0x4d, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x83, 0x01, 0x0a, 0x1f, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x45, 0x56, 0x4d, 0x4c, 0x6f, 0x67, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x35, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x45, 0x56, 0x4d, 0x4c, 0x6f, 0x67, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x27, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x2e,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x45, 0x56, 0x4d, 0x4c, 0x6f, 0x67, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x6e, 0x66, 0x6c, 0x6f, 0x77, 0x2f,
+	0x66, 0x6c, 0x6f, 0x77, 0x2d, 0x67, 0x6f, 0x2f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x2f, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_access_extended_access_proto_rawDescOnce sync.Once
+	file_access_extended_access_proto_rawDescData = file_access_extended_access_proto_rawDesc
+)
+
+func file_access_extended_access_proto_rawDescGZIP() []byte {
+	file_access_extended_access_proto_rawDescOnce.Do(func() {
+		file_access_extended_access_proto_rawDescData = protoimpl.X.CompressGZIP(file_access_extended_access_proto_rawDescData)
+	})
+	return file_access_extended_access_proto_rawDescData
+}
+
+var file_access_extended_access_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_access_extended_access_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_access_extended_access_proto_goTypes = []interface{}{
+	(AccountTransactionRole)(0),                    // 0: flow.extended.AccountTransactionRole
+	(RegisterChangeType)(0),                        // 1: flow.extended.RegisterChangeType
+	(AccountRegisterKind)(0),                       // 2: flow.extended.AccountRegisterKind
+	(*AccountTransactionCursor)(nil),               // 3: flow.extended.AccountTransactionCursor
+	(*AccountTransaction)(nil),                     // 4: flow.extended.AccountTransaction
+	(*GetAccountTransactionsRequest)(nil),          // 5: flow.extended.GetAccountTransactionsRequest
+	(*GetAccountTransactionsResponse)(nil),         // 6: flow.extended.GetAccountTransactionsResponse
+	(*GetEventsForHeightRangeRequest)(nil),         // 7: flow.extended.GetEventsForHeightRangeRequest
+	(*GetEventsForHeightRangeResponse)(nil),        // 8: flow.extended.GetEventsForHeightRangeResponse
+	(*SimulateTransactionRequest)(nil),             // 9: flow.extended.SimulateTransactionRequest
+	(*AccountStorageDelta)(nil),                    // 10: flow.extended.AccountStorageDelta
+	(*SimulateTransactionResponse)(nil),            // 11: flow.extended.SimulateTransactionResponse
+	(*EstimateTransactionFeesRequest)(nil),         // 12: flow.extended.EstimateTransactionFeesRequest
+	(*TransactionFeeParameters)(nil),               // 13: flow.extended.TransactionFeeParameters
+	(*EstimateTransactionFeesResponse)(nil),        // 14: flow.extended.EstimateTransactionFeesResponse
+	(*Script)(nil),                                 // 15: flow.extended.Script
+	(*ExecuteScriptsAtBlockHeightRequest)(nil),     // 16: flow.extended.ExecuteScriptsAtBlockHeightRequest
+	(*ScriptResult)(nil),                           // 17: flow.extended.ScriptResult
+	(*ExecuteScriptsAtBlockHeightResponse)(nil),    // 18: flow.extended.ExecuteScriptsAtBlockHeightResponse
+	(*GetAccountStorageDiffRequest)(nil),           // 19: flow.extended.GetAccountStorageDiffRequest
+	(*AccountRegisterChange)(nil),                  // 20: flow.extended.AccountRegisterChange
+	(*StoragePathChange)(nil),                      // 21: flow.extended.StoragePathChange
+	(*GetAccountStorageDiffResponse)(nil),          // 22: flow.extended.GetAccountStorageDiffResponse
+	(*EVMTracerConfig)(nil),                        // 23: flow.extended.EVMTracerConfig
+	(*GetEVMBlockTracesRequest)(nil),               // 24: flow.extended.GetEVMBlockTracesRequest
+	(*EVMTransactionTrace)(nil),                    // 25: flow.extended.EVMTransactionTrace
+	(*GetEVMBlockTracesResponse)(nil),              // 26: flow.extended.GetEVMBlockTracesResponse
+	(*GetEVMTransactionTraceRequest)(nil),          // 27: flow.extended.GetEVMTransactionTraceRequest
+	(*GetEVMTransactionTraceResponse)(nil),         // 28: flow.extended.GetEVMTransactionTraceResponse
+	(*EVMLogTopics)(nil),                           // 29: flow.extended.EVMLogTopics
+	(*EVMLogFilter)(nil),                           // 30: flow.extended.EVMLogFilter
+	(*EVMLogCursor)(nil),                           // 31: flow.extended.EVMLogCursor
+	(*EVMLog)(nil),                                 // 32: flow.extended.EVMLog
+	(*GetEVMLogsRequest)(nil),                      // 33: flow.extended.GetEVMLogsRequest
+	(*GetEVMLogsResponse)(nil),                     // 34: flow.extended.GetEVMLogsResponse
+	(*SubscribeEVMLogsFromStartHeightRequest)(nil), // 35: flow.extended.SubscribeEVMLogsFromStartHeightRequest
+	(*SubscribeEVMLogsResponse)(nil),               // 36: flow.extended.SubscribeEVMLogsResponse
+	nil,                                            // 37: flow.extended.EstimateTransactionFeesResponse.ComputationIntensitiesEntry
+	(entities.EventEncodingVersion)(0),             // 38: flow.entities.EventEncodingVersion
+	(*access.EventsResponse_Result)(nil),           // 39: flow.access.EventsResponse.Result
+	(*entities.Transaction)(nil),                   // 40: flow.entities.Transaction
+	(*entities.Event)(nil),                         // 41: flow.entities.Event
+}
+var file_access_extended_access_proto_depIdxs = []int32{
+	0,  // 0: flow.extended.AccountTransaction.roles:type_name -> flow.extended.AccountTransactionRole
+	3,  // 1: flow.extended.GetAccountTransactionsRequest.cursor:type_name -> flow.extended.AccountTransactionCursor
+	4,  // 2: flow.extended.GetAccountTransactionsResponse.transactions:type_name -> flow.extended.AccountTransaction
+	3,  // 3: flow.extended.GetAccountTransactionsResponse.next_cursor:type_name -> flow.extended.AccountTransactionCursor
+	38, // 4: flow.extended.GetEventsForHeightRangeRequest.event_encoding_version:type_name -> flow.entities.EventEncodingVersion
+	39, // 5: flow.extended.GetEventsForHeightRangeResponse.results:type_name -> flow.access.EventsResponse.Result
+	40, // 6: flow.extended.SimulateTransactionRequest.transaction:type_name -> flow.entities.Transaction
+	38, // 7: flow.extended.SimulateTransactionRequest.event_encoding_version:type_name -> flow.entities.EventEncodingVersion
+	41, // 8: flow.extended.SimulateTransactionResponse.events:type_name -> flow.entities.Event
+	10, // 9: flow.extended.SimulateTransactionResponse.storage_deltas:type_name -> flow.extended.AccountStorageDelta
+	40, // 10: flow.extended.EstimateTransactionFeesRequest.transaction:type_name -> flow.entities.Transaction
+	37, // 11: flow.extended.EstimateTransactionFeesResponse.computation_intensities:type_name -> flow.extended.EstimateTransactionFeesResponse.ComputationIntensitiesEntry
+	13, // 12: flow.extended.EstimateTransactionFeesResponse.fee_parameters:type_name -> flow.extended.TransactionFeeParameters
+	15, // 13: flow.extended.ExecuteScriptsAtBlockHeightRequest.scripts:type_name -> flow.extended.Script
+	17, // 14: flow.extended.ExecuteScriptsAtBlockHeightResponse.results:type_name -> flow.extended.ScriptResult
+	1,  // 15: flow.extended.AccountRegisterChange.type:type_name -> flow.extended.RegisterChangeType
+	2,  // 16: flow.extended.AccountRegisterChange.kind:type_name -> flow.extended.AccountRegisterKind
+	1,  // 17: flow.extended.StoragePathChange.type:type_name -> flow.extended.RegisterChangeType
+	20, // 18: flow.extended.GetAccountStorageDiffResponse.changes:type_name -> flow.extended.AccountRegisterChange
+	21, // 19: flow.extended.GetAccountStorageDiffResponse.path_changes:type_name -> flow.extended.StoragePathChange
+	23, // 20: flow.extended.GetEVMBlockTracesRequest.tracer:type_name -> flow.extended.EVMTracerConfig
+	25, // 21: flow.extended.GetEVMBlockTracesResponse.traces:type_name -> flow.extended.EVMTransactionTrace
+	23, // 22: flow.extended.GetEVMTransactionTraceRequest.tracer:type_name -> flow.extended.EVMTracerConfig
+	29, // 23: flow.extended.EVMLogFilter.topics:type_name -> flow.extended.EVMLogTopics
+	30, // 24: flow.extended.GetEVMLogsRequest.filter:type_name -> flow.extended.EVMLogFilter
+	31, // 25: flow.extended.GetEVMLogsRequest.cursor:type_name -> flow.extended.EVMLogCursor
+	32, // 26: flow.extended.GetEVMLogsResponse.logs:type_name -> flow.extended.EVMLog
+	31, // 27: flow.extended.GetEVMLogsResponse.next_cursor:type_name -> flow.extended.EVMLogCursor
+	30, // 28: flow.extended.SubscribeEVMLogsFromStartHeightRequest.filter:type_name -> flow.extended.EVMLogFilter
+	32, // 29: flow.extended.SubscribeEVMLogsResponse.logs:type_name -> flow.extended.EVMLog
+	5,  // 30: flow.extended.ExtendedAccessAPI.GetAccountTransactions:input_type -> flow.extended.GetAccountTransactionsRequest
+	7,  // 31: flow.extended.ExtendedAccessAPI.GetEventsForHeightRange:input_type -> flow.extended.GetEventsForHeightRangeRequest
+	9,  // 32: flow.extended.ExtendedAccessAPI.SimulateTransaction:input_type -> flow.extended.SimulateTransactionRequest
+	12, // 33: flow.extended.ExtendedAccessAPI.EstimateTransactionFees:input_type -> flow.extended.EstimateTransactionFeesRequest
+	16, // 34: flow.extended.ExtendedAccessAPI.ExecuteScriptsAtBlockHeight:input_type -> flow.extended.ExecuteScriptsAtBlockHeightRequest
+	19, // 35: flow.extended.ExtendedAccessAPI.GetAccountStorageDiff:input_type -> flow.extended.GetAccountStorageDiffRequest
+	24, // 36: flow.extended.ExtendedAccessAPI.GetEVMBlockTraces:input_type -> flow.extended.GetEVMBlockTracesRequest
+	27, // 37: flow.extended.ExtendedAccessAPI.GetEVMTransactionTrace:input_type -> flow.extended.GetEVMTransactionTraceRequest
+	33, // 38: flow.extended.ExtendedAccessAPI.GetEVMLogs:input_type -> flow.extended.GetEVMLogsRequest
+	35, // 39: flow.extended.ExtendedAccessAPI.SubscribeEVMLogsFromStartHeight:input_type -> flow.extended.SubscribeEVMLogsFromStartHeightRequest
+	6,  // 40: flow.extended.ExtendedAccessAPI.GetAccountTransactions:output_type -> flow.extended.GetAccountTransactionsResponse
+	8,  // 41: flow.extended.ExtendedAccessAPI.GetEventsForHeightRange:output_type -> flow.extended.GetEventsForHeightRangeResponse
+	11, // 42: flow.extended.ExtendedAccessAPI.SimulateTransaction:output_type -> flow.extended.SimulateTransactionResponse
+	14, // 43: flow.extended.ExtendedAccessAPI.EstimateTransactionFees:output_type -> flow.extended.EstimateTransactionFeesResponse
+	18, // 44: flow.extended.ExtendedAccessAPI.ExecuteScriptsAtBlockHeight:output_type -> flow.extended.ExecuteScriptsAtBlockHeightResponse
+	22, // 45: flow.extended.ExtendedAccessAPI.GetAccountStorageDiff:output_type -> flow.extended.GetAccountStorageDiffResponse
+	26, // 46: flow.extended.ExtendedAccessAPI.GetEVMBlockTraces:output_type -> flow.extended.GetEVMBlockTracesResponse
+	28, // 47: flow.extended.ExtendedAccessAPI.GetEVMTransactionTrace:output_type -> flow.extended.GetEVMTransactionTraceResponse
+	34, // 48: flow.extended.ExtendedAccessAPI.GetEVMLogs:output_type -> flow.extended.GetEVMLogsResponse
+	36, // 49: flow.extended.ExtendedAccessAPI.SubscribeEVMLogsFromStartHeight:output_type -> flow.extended.SubscribeEVMLogsResponse
+	40, // [40:50] is the sub-list for method output_type
+	30, // [30:40] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
+}
+
+func init() { file_access_extended_access_proto_init() }
+func file_access_extended_access_proto_init() {
+	if File_access_extended_access_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_access_extended_access_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AccountTransactionCursor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AccountTransaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAccountTransactionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAccountTransactionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEventsForHeightRangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEventsForHeightRangeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SimulateTransactionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AccountStorageDelta); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SimulateTransactionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EstimateTransactionFeesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionFeeParameters); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EstimateTransactionFeesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Script); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecuteScriptsAtBlockHeightRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScriptResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecuteScriptsAtBlockHeightResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAccountStorageDiffRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AccountRegisterChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StoragePathChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAccountStorageDiffResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EVMTracerConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEVMBlockTracesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EVMTransactionTrace); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEVMBlockTracesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEVMTransactionTraceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEVMTransactionTraceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EVMLogTopics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EVMLogFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EVMLogCursor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EVMLog); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEVMLogsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEVMLogsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeEVMLogsFromStartHeightRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extended_access_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeEVMLogsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_access_extended_access_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   35,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_access_extended_access_proto_goTypes,
+		DependencyIndexes: file_access_extended_access_proto_depIdxs,
+		EnumInfos:         file_access_extended_access_proto_enumTypes,
+		MessageInfos:      file_access_extended_access_proto_msgTypes,
+	}.Build()
+	File_access_extended_access_proto = out.File
+	file_access_extended_access_proto_rawDesc = nil
+	file_access_extended_access_proto_goTypes = nil
+	file_access_extended_access_proto_depIdxs = nil
+}
@@ -0,0 +1,516 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: access/extended/access.proto
+
+package extended
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ExtendedAccessAPI_GetAccountTransactions_FullMethodName          = "/flow.extended.ExtendedAccessAPI/GetAccountTransactions"
+	ExtendedAccessAPI_GetEventsForHeightRange_FullMethodName         = "/flow.extended.ExtendedAccessAPI/GetEventsForHeightRange"
+	ExtendedAccessAPI_SimulateTransaction_FullMethodName             = "/flow.extended.ExtendedAccessAPI/SimulateTransaction"
+	ExtendedAccessAPI_EstimateTransactionFees_FullMethodName         = "/flow.extended.ExtendedAccessAPI/EstimateTransactionFees"
+	ExtendedAccessAPI_ExecuteScriptsAtBlockHeight_FullMethodName     = "/flow.extended.ExtendedAccessAPI/ExecuteScriptsAtBlockHeight"
+	ExtendedAccessAPI_GetAccountStorageDiff_FullMethodName           = "/flow.extended.ExtendedAccessAPI/GetAccountStorageDiff"
+	ExtendedAccessAPI_GetEVMBlockTraces_FullMethodName               = "/flow.extended.ExtendedAccessAPI/GetEVMBlockTraces"
+	ExtendedAccessAPI_GetEVMTransactionTrace_FullMethodName          = "/flow.extended.ExtendedAccessAPI/GetEVMTransactionTrace"
+	ExtendedAccessAPI_GetEVMLogs_FullMethodName                      = "/flow.extended.ExtendedAccessAPI/GetEVMLogs"
+	ExtendedAccessAPI_SubscribeEVMLogsFromStartHeight_FullMethodName = "/flow.extended.ExtendedAccessAPI/SubscribeEVMLogsFromStartHeight"
+)
+
+// ExtendedAccessAPIClient is the client API for ExtendedAccessAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExtendedAccessAPIClient interface {
+	// GetAccountTransactions returns the transactions which involved an account as payer, proposer,
+	// authorizer or through an emitted event, ordered from newest to oldest.
+	GetAccountTransactions(ctx context.Context, in *GetAccountTransactionsRequest, opts ...grpc.CallOption) (*GetAccountTransactionsResponse, error)
+	// GetEventsForHeightRange returns the events of a type emitted in a range of blocks. Unlike the
+	// AccessAPI endpoint of the same name, the events can be filtered on the values of their fields.
+	GetEventsForHeightRange(ctx context.Context, in *GetEventsForHeightRangeRequest, opts ...grpc.CallOption) (*GetEventsForHeightRangeResponse, error)
+	// SimulateTransaction executes a transaction against the state at a block height, without submitting it
+	// or committing any of its changes. A failure of the transaction itself is returned in the response.
+	SimulateTransaction(ctx context.Context, in *SimulateTransactionRequest, opts ...grpc.CallOption) (*SimulateTransactionResponse, error)
+	// EstimateTransactionFees executes a transaction against the state at a block height without submitting
+	// it, and returns the computation it used and the fees it would be charged. The signatures of the
+	// transaction are not verified. A failure of the transaction itself is returned in the response.
+	EstimateTransactionFees(ctx context.Context, in *EstimateTransactionFeesRequest, opts ...grpc.CallOption) (*EstimateTransactionFeesResponse, error)
+	// ExecuteScriptsAtBlockHeight executes a batch of scripts against the state at a block height, and
+	// returns a result for each script in the same order. A script failing to execute does not fail the
+	// batch, its error is returned in its result.
+	ExecuteScriptsAtBlockHeight(ctx context.Context, in *ExecuteScriptsAtBlockHeightRequest, opts ...grpc.CallOption) (*ExecuteScriptsAtBlockHeightResponse, error)
+	// GetAccountStorageDiff returns a page of the registers of an account which were added, removed or
+	// modified between two heights, ordered by key.
+	GetAccountStorageDiff(ctx context.Context, in *GetAccountStorageDiffRequest, opts ...grpc.CallOption) (*GetAccountStorageDiffResponse, error)
+	// GetEVMBlockTraces returns the traces of the EVM transactions executed by a Flow block, in execution
+	// order, produced by re-executing them with a tracer, like debug_traceBlock.
+	GetEVMBlockTraces(ctx context.Context, in *GetEVMBlockTracesRequest, opts ...grpc.CallOption) (*GetEVMBlockTracesResponse, error)
+	// GetEVMTransactionTrace returns the trace of an EVM transaction executed by a Flow block, produced by
+	// re-executing the block with a tracer, like debug_traceTransaction.
+	GetEVMTransactionTrace(ctx context.Context, in *GetEVMTransactionTraceRequest, opts ...grpc.CallOption) (*GetEVMTransactionTraceResponse, error)
+	// GetEVMLogs returns a page of the logs emitted by EVM transactions which match a filter, ordered from
+	// oldest to newest.
+	GetEVMLogs(ctx context.Context, in *GetEVMLogsRequest, opts ...grpc.CallOption) (*GetEVMLogsResponse, error)
+	// SubscribeEVMLogsFromStartHeight streams the EVM logs matching a filter, starting at a block height.
+	// A response is sent for every indexed block, including blocks without matching logs. Once the latest
+	// indexed block is reached, the stream remains open and responses are sent for each new block as it is
+	// indexed.
+	SubscribeEVMLogsFromStartHeight(ctx context.Context, in *SubscribeEVMLogsFromStartHeightRequest, opts ...grpc.CallOption) (ExtendedAccessAPI_SubscribeEVMLogsFromStartHeightClient, error)
+}
+
+type extendedAccessAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExtendedAccessAPIClient(cc grpc.ClientConnInterface) ExtendedAccessAPIClient {
+	return &extendedAccessAPIClient{cc}
+}
+
+func (c *extendedAccessAPIClient) GetAccountTransactions(ctx context.Context, in *GetAccountTransactionsRequest, opts ...grpc.CallOption) (*GetAccountTransactionsResponse, error) {
+	out := new(GetAccountTransactionsResponse)
+	err := c.cc.Invoke(ctx, ExtendedAccessAPI_GetAccountTransactions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAccessAPIClient) GetEventsForHeightRange(ctx context.Context, in *GetEventsForHeightRangeRequest, opts ...grpc.CallOption) (*GetEventsForHeightRangeResponse, error) {
+	out := new(GetEventsForHeightRangeResponse)
+	err := c.cc.Invoke(ctx, ExtendedAccessAPI_GetEventsForHeightRange_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAccessAPIClient) SimulateTransaction(ctx context.Context, in *SimulateTransactionRequest, opts ...grpc.CallOption) (*SimulateTransactionResponse, error) {
+	out := new(SimulateTransactionResponse)
+	err := c.cc.Invoke(ctx, ExtendedAccessAPI_SimulateTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAccessAPIClient) EstimateTransactionFees(ctx context.Context, in *EstimateTransactionFeesRequest, opts ...grpc.CallOption) (*EstimateTransactionFeesResponse, error) {
+	out := new(EstimateTransactionFeesResponse)
+	err := c.cc.Invoke(ctx, ExtendedAccessAPI_EstimateTransactionFees_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAccessAPIClient) ExecuteScriptsAtBlockHeight(ctx context.Context, in *ExecuteScriptsAtBlockHeightRequest, opts ...grpc.CallOption) (*ExecuteScriptsAtBlockHeightResponse, error) {
+	out := new(ExecuteScriptsAtBlockHeightResponse)
+	err := c.cc.Invoke(ctx, ExtendedAccessAPI_ExecuteScriptsAtBlockHeight_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAccessAPIClient) GetAccountStorageDiff(ctx context.Context, in *GetAccountStorageDiffRequest, opts ...grpc.CallOption) (*GetAccountStorageDiffResponse, error) {
+	out := new(GetAccountStorageDiffResponse)
+	err := c.cc.Invoke(ctx, ExtendedAccessAPI_GetAccountStorageDiff_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAccessAPIClient) GetEVMBlockTraces(ctx context.Context, in *GetEVMBlockTracesRequest, opts ...grpc.CallOption) (*GetEVMBlockTracesResponse, error) {
+	out := new(GetEVMBlockTracesResponse)
+	err := c.cc.Invoke(ctx, ExtendedAccessAPI_GetEVMBlockTraces_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAccessAPIClient) GetEVMTransactionTrace(ctx context.Context, in *GetEVMTransactionTraceRequest, opts ...grpc.CallOption) (*GetEVMTransactionTraceResponse, error) {
+	out := new(GetEVMTransactionTraceResponse)
+	err := c.cc.Invoke(ctx, ExtendedAccessAPI_GetEVMTransactionTrace_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAccessAPIClient) GetEVMLogs(ctx context.Context, in *GetEVMLogsRequest, opts ...grpc.CallOption) (*GetEVMLogsResponse, error) {
+	out := new(GetEVMLogsResponse)
+	err := c.cc.Invoke(ctx, ExtendedAccessAPI_GetEVMLogs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAccessAPIClient) SubscribeEVMLogsFromStartHeight(ctx context.Context, in *SubscribeEVMLogsFromStartHeightRequest, opts ...grpc.CallOption) (ExtendedAccessAPI_SubscribeEVMLogsFromStartHeightClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ExtendedAccessAPI_ServiceDesc.Streams[0], ExtendedAccessAPI_SubscribeEVMLogsFromStartHeight_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &extendedAccessAPISubscribeEVMLogsFromStartHeightClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ExtendedAccessAPI_SubscribeEVMLogsFromStartHeightClient interface {
+	Recv() (*SubscribeEVMLogsResponse, error)
+	grpc.ClientStream
+}
+
+type extendedAccessAPISubscribeEVMLogsFromStartHeightClient struct {
+	grpc.ClientStream
+}
+
+func (x *extendedAccessAPISubscribeEVMLogsFromStartHeightClient) Recv() (*SubscribeEVMLogsResponse, error) {
+	m := new(SubscribeEVMLogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ExtendedAccessAPIServer is the server API for ExtendedAccessAPI service.
+// All implementations should embed UnimplementedExtendedAccessAPIServer
+// for forward compatibility
+type ExtendedAccessAPIServer interface {
+	// GetAccountTransactions returns the transactions which involved an account as payer, proposer,
+	// authorizer or through an emitted event, ordered from newest to oldest.
+	GetAccountTransactions(context.Context, *GetAccountTransactionsRequest) (*GetAccountTransactionsResponse, error)
+	// GetEventsForHeightRange returns the events of a type emitted in a range of blocks. Unlike the
+	// AccessAPI endpoint of the same name, the events can be filtered on the values of their fields.
+	GetEventsForHeightRange(context.Context, *GetEventsForHeightRangeRequest) (*GetEventsForHeightRangeResponse, error)
+	// SimulateTransaction executes a transaction against the state at a block height, without submitting it
+	// or committing any of its changes. A failure of the transaction itself is returned in the response.
+	SimulateTransaction(context.Context, *SimulateTransactionRequest) (*SimulateTransactionResponse, error)
+	// EstimateTransactionFees executes a transaction against the state at a block height without submitting
+	// it, and returns the computation it used and the fees it would be charged. The signatures of the
+	// transaction are not verified. A failure of the transaction itself is returned in the response.
+	EstimateTransactionFees(context.Context, *EstimateTransactionFeesRequest) (*EstimateTransactionFeesResponse, error)
+	// ExecuteScriptsAtBlockHeight executes a batch of scripts against the state at a block height, and
+	// returns a result for each script in the same order. A script failing to execute does not fail the
+	// batch, its error is returned in its result.
+	ExecuteScriptsAtBlockHeight(context.Context, *ExecuteScriptsAtBlockHeightRequest) (*ExecuteScriptsAtBlockHeightResponse, error)
+	// GetAccountStorageDiff returns a page of the registers of an account which were added, removed or
+	// modified between two heights, ordered by key.
+	GetAccountStorageDiff(context.Context, *GetAccountStorageDiffRequest) (*GetAccountStorageDiffResponse, error)
+	// GetEVMBlockTraces returns the traces of the EVM transactions executed by a Flow block, in execution
+	// order, produced by re-executing them with a tracer, like debug_traceBlock.
+	GetEVMBlockTraces(context.Context, *GetEVMBlockTracesRequest) (*GetEVMBlockTracesResponse, error)
+	// GetEVMTransactionTrace returns the trace of an EVM transaction executed by a Flow block, produced by
+	// re-executing the block with a tracer, like debug_traceTransaction.
+	GetEVMTransactionTrace(context.Context, *GetEVMTransactionTraceRequest) (*GetEVMTransactionTraceResponse, error)
+	// GetEVMLogs returns a page of the logs emitted by EVM transactions which match a filter, ordered from
+	// oldest to newest.
+	GetEVMLogs(context.Context, *GetEVMLogsRequest) (*GetEVMLogsResponse, error)
+	// SubscribeEVMLogsFromStartHeight streams the EVM logs matching a filter, starting at a block height.
+	// A response is sent for every indexed block, including blocks without matching logs. Once the latest
+	// indexed block is reached, the stream remains open and responses are sent for each new block as it is
+	// indexed.
+	SubscribeEVMLogsFromStartHeight(*SubscribeEVMLogsFromStartHeightRequest, ExtendedAccessAPI_SubscribeEVMLogsFromStartHeightServer) error
+}
+
+// UnimplementedExtendedAccessAPIServer should be embedded to have forward compatible implementations.
+type UnimplementedExtendedAccessAPIServer struct {
+}
+
+func (UnimplementedExtendedAccessAPIServer) GetAccountTransactions(context.Context, *GetAccountTransactionsRequest) (*GetAccountTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountTransactions not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) GetEventsForHeightRange(context.Context, *GetEventsForHeightRangeRequest) (*GetEventsForHeightRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEventsForHeightRange not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) SimulateTransaction(context.Context, *SimulateTransactionRequest) (*SimulateTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulateTransaction not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) EstimateTransactionFees(context.Context, *EstimateTransactionFeesRequest) (*EstimateTransactionFeesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateTransactionFees not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) ExecuteScriptsAtBlockHeight(context.Context, *ExecuteScriptsAtBlockHeightRequest) (*ExecuteScriptsAtBlockHeightResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteScriptsAtBlockHeight not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) GetAccountStorageDiff(context.Context, *GetAccountStorageDiffRequest) (*GetAccountStorageDiffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountStorageDiff not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) GetEVMBlockTraces(context.Context, *GetEVMBlockTracesRequest) (*GetEVMBlockTracesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEVMBlockTraces not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) GetEVMTransactionTrace(context.Context, *GetEVMTransactionTraceRequest) (*GetEVMTransactionTraceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEVMTransactionTrace not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) GetEVMLogs(context.Context, *GetEVMLogsRequest) (*GetEVMLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEVMLogs not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) SubscribeEVMLogsFromStartHeight(*SubscribeEVMLogsFromStartHeightRequest, ExtendedAccessAPI_SubscribeEVMLogsFromStartHeightServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEVMLogsFromStartHeight not implemented")
+}
+
+// UnsafeExtendedAccessAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExtendedAccessAPIServer will
+// result in compilation errors.
+type UnsafeExtendedAccessAPIServer interface {
+	mustEmbedUnimplementedExtendedAccessAPIServer()
+}
+
+func RegisterExtendedAccessAPIServer(s grpc.ServiceRegistrar, srv ExtendedAccessAPIServer) {
+	s.RegisterService(&ExtendedAccessAPI_ServiceDesc, srv)
+}
+
+func _ExtendedAccessAPI_GetAccountTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).GetAccountTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedAccessAPI_GetAccountTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).GetAccountTransactions(ctx, req.(*GetAccountTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedAccessAPI_GetEventsForHeightRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventsForHeightRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).GetEventsForHeightRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedAccessAPI_GetEventsForHeightRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).GetEventsForHeightRange(ctx, req.(*GetEventsForHeightRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedAccessAPI_SimulateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulateTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).SimulateTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedAccessAPI_SimulateTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).SimulateTransaction(ctx, req.(*SimulateTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedAccessAPI_EstimateTransactionFees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateTransactionFeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).EstimateTransactionFees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedAccessAPI_EstimateTransactionFees_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).EstimateTransactionFees(ctx, req.(*EstimateTransactionFeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedAccessAPI_ExecuteScriptsAtBlockHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteScriptsAtBlockHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).ExecuteScriptsAtBlockHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedAccessAPI_ExecuteScriptsAtBlockHeight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).ExecuteScriptsAtBlockHeight(ctx, req.(*ExecuteScriptsAtBlockHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedAccessAPI_GetAccountStorageDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountStorageDiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).GetAccountStorageDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedAccessAPI_GetAccountStorageDiff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).GetAccountStorageDiff(ctx, req.(*GetAccountStorageDiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedAccessAPI_GetEVMBlockTraces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEVMBlockTracesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).GetEVMBlockTraces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedAccessAPI_GetEVMBlockTraces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).GetEVMBlockTraces(ctx, req.(*GetEVMBlockTracesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedAccessAPI_GetEVMTransactionTrace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEVMTransactionTraceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).GetEVMTransactionTrace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedAccessAPI_GetEVMTransactionTrace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).GetEVMTransactionTrace(ctx, req.(*GetEVMTransactionTraceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedAccessAPI_GetEVMLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEVMLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).GetEVMLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedAccessAPI_GetEVMLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).GetEVMLogs(ctx, req.(*GetEVMLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedAccessAPI_SubscribeEVMLogsFromStartHeight_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEVMLogsFromStartHeightRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExtendedAccessAPIServer).SubscribeEVMLogsFromStartHeight(m, &extendedAccessAPISubscribeEVMLogsFromStartHeightServer{stream})
+}
+
+type ExtendedAccessAPI_SubscribeEVMLogsFromStartHeightServer interface {
+	Send(*SubscribeEVMLogsResponse) error
+	grpc.ServerStream
+}
+
+type extendedAccessAPISubscribeEVMLogsFromStartHeightServer struct {
+	grpc.ServerStream
+}
+
+func (x *extendedAccessAPISubscribeEVMLogsFromStartHeightServer) Send(m *SubscribeEVMLogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// ExtendedAccessAPI_ServiceDesc is the grpc.ServiceDesc for ExtendedAccessAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExtendedAccessAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flow.extended.ExtendedAccessAPI",
+	HandlerType: (*ExtendedAccessAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAccountTransactions",
+			Handler:    _ExtendedAccessAPI_GetAccountTransactions_Handler,
+		},
+		{
+			MethodName: "GetEventsForHeightRange",
+			Handler:    _ExtendedAccessAPI_GetEventsForHeightRange_Handler,
+		},
+		{
+			MethodName: "SimulateTransaction",
+			Handler:    _ExtendedAccessAPI_SimulateTransaction_Handler,
+		},
+		{
+			MethodName: "EstimateTransactionFees",
+			Handler:    _ExtendedAccessAPI_EstimateTransactionFees_Handler,
+		},
+		{
+			MethodName: "ExecuteScriptsAtBlockHeight",
+			Handler:    _ExtendedAccessAPI_ExecuteScriptsAtBlockHeight_Handler,
+		},
+		{
+			MethodName: "GetAccountStorageDiff",
+			Handler:    _ExtendedAccessAPI_GetAccountStorageDiff_Handler,
+		},
+		{
+			MethodName: "GetEVMBlockTraces",
+			Handler:    _ExtendedAccessAPI_GetEVMBlockTraces_Handler,
+		},
+		{
+			MethodName: "GetEVMTransactionTrace",
+			Handler:    _ExtendedAccessAPI_GetEVMTransactionTrace_Handler,
+		},
+		{
+			MethodName: "GetEVMLogs",
+			Handler:    _ExtendedAccessAPI_GetEVMLogs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEVMLogsFromStartHeight",
+			Handler:       _ExtendedAccessAPI_SubscribeEVMLogsFromStartHeight_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "access/extended/access.proto",
+}
@@ -0,0 +1,469 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: access/extended/executiondata.proto
+
+package extended
+
+import (
+	context "context"
+	executiondata "github.com/onflow/flow/protobuf/go/flow/executiondata"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ExtendedExecutionDataAPI_CreateEventCursor_FullMethodName               = "/flow.extended.ExtendedExecutionDataAPI/CreateEventCursor"
+	ExtendedExecutionDataAPI_SubscribeEventsFromCursor_FullMethodName       = "/flow.extended.ExtendedExecutionDataAPI/SubscribeEventsFromCursor"
+	ExtendedExecutionDataAPI_AcknowledgeEvents_FullMethodName               = "/flow.extended.ExtendedExecutionDataAPI/AcknowledgeEvents"
+	ExtendedExecutionDataAPI_DeleteEventCursor_FullMethodName               = "/flow.extended.ExtendedExecutionDataAPI/DeleteEventCursor"
+	ExtendedExecutionDataAPI_SubscribeEventsFromStartBlockID_FullMethodName = "/flow.extended.ExtendedExecutionDataAPI/SubscribeEventsFromStartBlockID"
+	ExtendedExecutionDataAPI_SubscribeEventsFromStartHeight_FullMethodName  = "/flow.extended.ExtendedExecutionDataAPI/SubscribeEventsFromStartHeight"
+	ExtendedExecutionDataAPI_SubscribeEventsFromLatest_FullMethodName       = "/flow.extended.ExtendedExecutionDataAPI/SubscribeEventsFromLatest"
+)
+
+// ExtendedExecutionDataAPIClient is the client API for ExtendedExecutionDataAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExtendedExecutionDataAPIClient interface {
+	// CreateEventCursor creates a durable event subscription cursor, and returns its name. Cursor names are
+	// issued by the server, so a client can only use the cursors it created.
+	CreateEventCursor(ctx context.Context, in *CreateEventCursorRequest, opts ...grpc.CallOption) (*CreateEventCursorResponse, error)
+	// SubscribeEventsFromCursor streams the events matching the filter of a cursor, starting right after the
+	// last event acknowledged on the cursor. Once the latest block is reached, the stream remains open and
+	// responses are sent for each new block as it becomes available.
+	SubscribeEventsFromCursor(ctx context.Context, in *SubscribeEventsFromCursorRequest, opts ...grpc.CallOption) (ExtendedExecutionDataAPI_SubscribeEventsFromCursorClient, error)
+	// AcknowledgeEvents marks all events up to and including a position as processed by the client of a
+	// cursor.
+	AcknowledgeEvents(ctx context.Context, in *AcknowledgeEventsRequest, opts ...grpc.CallOption) (*AcknowledgeEventsResponse, error)
+	// DeleteEventCursor removes a cursor.
+	DeleteEventCursor(ctx context.Context, in *DeleteEventCursorRequest, opts ...grpc.CallOption) (*DeleteEventCursorResponse, error)
+	// SubscribeEventsFromStartBlockID streams the events matching a filter, starting at the block with the
+	// given ID. Unlike the ExecutionDataAPI endpoint of the same name, the filter accepts event expressions.
+	SubscribeEventsFromStartBlockID(ctx context.Context, in *SubscribeEventsFromStartBlockIDRequest, opts ...grpc.CallOption) (ExtendedExecutionDataAPI_SubscribeEventsFromStartBlockIDClient, error)
+	// SubscribeEventsFromStartHeight streams the events matching a filter, starting at the block with the
+	// given height. Unlike the ExecutionDataAPI endpoint of the same name, the filter accepts event
+	// expressions.
+	SubscribeEventsFromStartHeight(ctx context.Context, in *SubscribeEventsFromStartHeightRequest, opts ...grpc.CallOption) (ExtendedExecutionDataAPI_SubscribeEventsFromStartHeightClient, error)
+	// SubscribeEventsFromLatest streams the events matching a filter, starting at the latest sealed block.
+	// Unlike the ExecutionDataAPI endpoint of the same name, the filter accepts event expressions.
+	SubscribeEventsFromLatest(ctx context.Context, in *SubscribeEventsFromLatestRequest, opts ...grpc.CallOption) (ExtendedExecutionDataAPI_SubscribeEventsFromLatestClient, error)
+}
+
+type extendedExecutionDataAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExtendedExecutionDataAPIClient(cc grpc.ClientConnInterface) ExtendedExecutionDataAPIClient {
+	return &extendedExecutionDataAPIClient{cc}
+}
+
+func (c *extendedExecutionDataAPIClient) CreateEventCursor(ctx context.Context, in *CreateEventCursorRequest, opts ...grpc.CallOption) (*CreateEventCursorResponse, error) {
+	out := new(CreateEventCursorResponse)
+	err := c.cc.Invoke(ctx, ExtendedExecutionDataAPI_CreateEventCursor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedExecutionDataAPIClient) SubscribeEventsFromCursor(ctx context.Context, in *SubscribeEventsFromCursorRequest, opts ...grpc.CallOption) (ExtendedExecutionDataAPI_SubscribeEventsFromCursorClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ExtendedExecutionDataAPI_ServiceDesc.Streams[0], ExtendedExecutionDataAPI_SubscribeEventsFromCursor_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &extendedExecutionDataAPISubscribeEventsFromCursorClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ExtendedExecutionDataAPI_SubscribeEventsFromCursorClient interface {
+	Recv() (*executiondata.SubscribeEventsResponse, error)
+	grpc.ClientStream
+}
+
+type extendedExecutionDataAPISubscribeEventsFromCursorClient struct {
+	grpc.ClientStream
+}
+
+func (x *extendedExecutionDataAPISubscribeEventsFromCursorClient) Recv() (*executiondata.SubscribeEventsResponse, error) {
+	m := new(executiondata.SubscribeEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *extendedExecutionDataAPIClient) AcknowledgeEvents(ctx context.Context, in *AcknowledgeEventsRequest, opts ...grpc.CallOption) (*AcknowledgeEventsResponse, error) {
+	out := new(AcknowledgeEventsResponse)
+	err := c.cc.Invoke(ctx, ExtendedExecutionDataAPI_AcknowledgeEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedExecutionDataAPIClient) DeleteEventCursor(ctx context.Context, in *DeleteEventCursorRequest, opts ...grpc.CallOption) (*DeleteEventCursorResponse, error) {
+	out := new(DeleteEventCursorResponse)
+	err := c.cc.Invoke(ctx, ExtendedExecutionDataAPI_DeleteEventCursor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedExecutionDataAPIClient) SubscribeEventsFromStartBlockID(ctx context.Context, in *SubscribeEventsFromStartBlockIDRequest, opts ...grpc.CallOption) (ExtendedExecutionDataAPI_SubscribeEventsFromStartBlockIDClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ExtendedExecutionDataAPI_ServiceDesc.Streams[1], ExtendedExecutionDataAPI_SubscribeEventsFromStartBlockID_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &extendedExecutionDataAPISubscribeEventsFromStartBlockIDClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ExtendedExecutionDataAPI_SubscribeEventsFromStartBlockIDClient interface {
+	Recv() (*executiondata.SubscribeEventsResponse, error)
+	grpc.ClientStream
+}
+
+type extendedExecutionDataAPISubscribeEventsFromStartBlockIDClient struct {
+	grpc.ClientStream
+}
+
+func (x *extendedExecutionDataAPISubscribeEventsFromStartBlockIDClient) Recv() (*executiondata.SubscribeEventsResponse, error) {
+	m := new(executiondata.SubscribeEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *extendedExecutionDataAPIClient) SubscribeEventsFromStartHeight(ctx context.Context, in *SubscribeEventsFromStartHeightRequest, opts ...grpc.CallOption) (ExtendedExecutionDataAPI_SubscribeEventsFromStartHeightClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ExtendedExecutionDataAPI_ServiceDesc.Streams[2], ExtendedExecutionDataAPI_SubscribeEventsFromStartHeight_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &extendedExecutionDataAPISubscribeEventsFromStartHeightClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ExtendedExecutionDataAPI_SubscribeEventsFromStartHeightClient interface {
+	Recv() (*executiondata.SubscribeEventsResponse, error)
+	grpc.ClientStream
+}
+
+type extendedExecutionDataAPISubscribeEventsFromStartHeightClient struct {
+	grpc.ClientStream
+}
+
+func (x *extendedExecutionDataAPISubscribeEventsFromStartHeightClient) Recv() (*executiondata.SubscribeEventsResponse, error) {
+	m := new(executiondata.SubscribeEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *extendedExecutionDataAPIClient) SubscribeEventsFromLatest(ctx context.Context, in *SubscribeEventsFromLatestRequest, opts ...grpc.CallOption) (ExtendedExecutionDataAPI_SubscribeEventsFromLatestClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ExtendedExecutionDataAPI_ServiceDesc.Streams[3], ExtendedExecutionDataAPI_SubscribeEventsFromLatest_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &extendedExecutionDataAPISubscribeEventsFromLatestClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ExtendedExecutionDataAPI_SubscribeEventsFromLatestClient interface {
+	Recv() (*executiondata.SubscribeEventsResponse, error)
+	grpc.ClientStream
+}
+
+type extendedExecutionDataAPISubscribeEventsFromLatestClient struct {
+	grpc.ClientStream
+}
+
+func (x *extendedExecutionDataAPISubscribeEventsFromLatestClient) Recv() (*executiondata.SubscribeEventsResponse, error) {
+	m := new(executiondata.SubscribeEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ExtendedExecutionDataAPIServer is the server API for ExtendedExecutionDataAPI service.
+// All implementations should embed UnimplementedExtendedExecutionDataAPIServer
+// for forward compatibility
+type ExtendedExecutionDataAPIServer interface {
+	// CreateEventCursor creates a durable event subscription cursor, and returns its name. Cursor names are
+	// issued by the server, so a client can only use the cursors it created.
+	CreateEventCursor(context.Context, *CreateEventCursorRequest) (*CreateEventCursorResponse, error)
+	// SubscribeEventsFromCursor streams the events matching the filter of a cursor, starting right after the
+	// last event acknowledged on the cursor. Once the latest block is reached, the stream remains open and
+	// responses are sent for each new block as it becomes available.
+	SubscribeEventsFromCursor(*SubscribeEventsFromCursorRequest, ExtendedExecutionDataAPI_SubscribeEventsFromCursorServer) error
+	// AcknowledgeEvents marks all events up to and including a position as processed by the client of a
+	// cursor.
+	AcknowledgeEvents(context.Context, *AcknowledgeEventsRequest) (*AcknowledgeEventsResponse, error)
+	// DeleteEventCursor removes a cursor.
+	DeleteEventCursor(context.Context, *DeleteEventCursorRequest) (*DeleteEventCursorResponse, error)
+	// SubscribeEventsFromStartBlockID streams the events matching a filter, starting at the block with the
+	// given ID. Unlike the ExecutionDataAPI endpoint of the same name, the filter accepts event expressions.
+	SubscribeEventsFromStartBlockID(*SubscribeEventsFromStartBlockIDRequest, ExtendedExecutionDataAPI_SubscribeEventsFromStartBlockIDServer) error
+	// SubscribeEventsFromStartHeight streams the events matching a filter, starting at the block with the
+	// given height. Unlike the ExecutionDataAPI endpoint of the same name, the filter accepts event
+	// expressions.
+	SubscribeEventsFromStartHeight(*SubscribeEventsFromStartHeightRequest, ExtendedExecutionDataAPI_SubscribeEventsFromStartHeightServer) error
+	// SubscribeEventsFromLatest streams the events matching a filter, starting at the latest sealed block.
+	// Unlike the ExecutionDataAPI endpoint of the same name, the filter accepts event expressions.
+	SubscribeEventsFromLatest(*SubscribeEventsFromLatestRequest, ExtendedExecutionDataAPI_SubscribeEventsFromLatestServer) error
+}
+
+// UnimplementedExtendedExecutionDataAPIServer should be embedded to have forward compatible implementations.
+type UnimplementedExtendedExecutionDataAPIServer struct {
+}
+
+func (UnimplementedExtendedExecutionDataAPIServer) CreateEventCursor(context.Context, *CreateEventCursorRequest) (*CreateEventCursorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEventCursor not implemented")
+}
+func (UnimplementedExtendedExecutionDataAPIServer) SubscribeEventsFromCursor(*SubscribeEventsFromCursorRequest, ExtendedExecutionDataAPI_SubscribeEventsFromCursorServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEventsFromCursor not implemented")
+}
+func (UnimplementedExtendedExecutionDataAPIServer) AcknowledgeEvents(context.Context, *AcknowledgeEventsRequest) (*AcknowledgeEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcknowledgeEvents not implemented")
+}
+func (UnimplementedExtendedExecutionDataAPIServer) DeleteEventCursor(context.Context, *DeleteEventCursorRequest) (*DeleteEventCursorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEventCursor not implemented")
+}
+func (UnimplementedExtendedExecutionDataAPIServer) SubscribeEventsFromStartBlockID(*SubscribeEventsFromStartBlockIDRequest, ExtendedExecutionDataAPI_SubscribeEventsFromStartBlockIDServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEventsFromStartBlockID not implemented")
+}
+func (UnimplementedExtendedExecutionDataAPIServer) SubscribeEventsFromStartHeight(*SubscribeEventsFromStartHeightRequest, ExtendedExecutionDataAPI_SubscribeEventsFromStartHeightServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEventsFromStartHeight not implemented")
+}
+func (UnimplementedExtendedExecutionDataAPIServer) SubscribeEventsFromLatest(*SubscribeEventsFromLatestRequest, ExtendedExecutionDataAPI_SubscribeEventsFromLatestServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEventsFromLatest not implemented")
+}
+
+// UnsafeExtendedExecutionDataAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExtendedExecutionDataAPIServer will
+// result in compilation errors.
+type UnsafeExtendedExecutionDataAPIServer interface {
+	mustEmbedUnimplementedExtendedExecutionDataAPIServer()
+}
+
+func RegisterExtendedExecutionDataAPIServer(s grpc.ServiceRegistrar, srv ExtendedExecutionDataAPIServer) {
+	s.RegisterService(&ExtendedExecutionDataAPI_ServiceDesc, srv)
+}
+
+func _ExtendedExecutionDataAPI_CreateEventCursor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEventCursorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedExecutionDataAPIServer).CreateEventCursor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedExecutionDataAPI_CreateEventCursor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedExecutionDataAPIServer).CreateEventCursor(ctx, req.(*CreateEventCursorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedExecutionDataAPI_SubscribeEventsFromCursor_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsFromCursorRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExtendedExecutionDataAPIServer).SubscribeEventsFromCursor(m, &extendedExecutionDataAPISubscribeEventsFromCursorServer{stream})
+}
+
+type ExtendedExecutionDataAPI_SubscribeEventsFromCursorServer interface {
+	Send(*executiondata.SubscribeEventsResponse) error
+	grpc.ServerStream
+}
+
+type extendedExecutionDataAPISubscribeEventsFromCursorServer struct {
+	grpc.ServerStream
+}
+
+func (x *extendedExecutionDataAPISubscribeEventsFromCursorServer) Send(m *executiondata.SubscribeEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ExtendedExecutionDataAPI_AcknowledgeEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedExecutionDataAPIServer).AcknowledgeEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedExecutionDataAPI_AcknowledgeEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedExecutionDataAPIServer).AcknowledgeEvents(ctx, req.(*AcknowledgeEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedExecutionDataAPI_DeleteEventCursor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEventCursorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedExecutionDataAPIServer).DeleteEventCursor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtendedExecutionDataAPI_DeleteEventCursor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedExecutionDataAPIServer).DeleteEventCursor(ctx, req.(*DeleteEventCursorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedExecutionDataAPI_SubscribeEventsFromStartBlockID_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsFromStartBlockIDRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExtendedExecutionDataAPIServer).SubscribeEventsFromStartBlockID(m, &extendedExecutionDataAPISubscribeEventsFromStartBlockIDServer{stream})
+}
+
+type ExtendedExecutionDataAPI_SubscribeEventsFromStartBlockIDServer interface {
+	Send(*executiondata.SubscribeEventsResponse) error
+	grpc.ServerStream
+}
+
+type extendedExecutionDataAPISubscribeEventsFromStartBlockIDServer struct {
+	grpc.ServerStream
+}
+
+func (x *extendedExecutionDataAPISubscribeEventsFromStartBlockIDServer) Send(m *executiondata.SubscribeEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ExtendedExecutionDataAPI_SubscribeEventsFromStartHeight_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsFromStartHeightRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExtendedExecutionDataAPIServer).SubscribeEventsFromStartHeight(m, &extendedExecutionDataAPISubscribeEventsFromStartHeightServer{stream})
+}
+
+type ExtendedExecutionDataAPI_SubscribeEventsFromStartHeightServer interface {
+	Send(*executiondata.SubscribeEventsResponse) error
+	grpc.ServerStream
+}
+
+type extendedExecutionDataAPISubscribeEventsFromStartHeightServer struct {
+	grpc.ServerStream
+}
+
+func (x *extendedExecutionDataAPISubscribeEventsFromStartHeightServer) Send(m *executiondata.SubscribeEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ExtendedExecutionDataAPI_SubscribeEventsFromLatest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsFromLatestRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExtendedExecutionDataAPIServer).SubscribeEventsFromLatest(m, &extendedExecutionDataAPISubscribeEventsFromLatestServer{stream})
+}
+
+type ExtendedExecutionDataAPI_SubscribeEventsFromLatestServer interface {
+	Send(*executiondata.SubscribeEventsResponse) error
+	grpc.ServerStream
+}
+
+type extendedExecutionDataAPISubscribeEventsFromLatestServer struct {
+	grpc.ServerStream
+}
+
+func (x *extendedExecutionDataAPISubscribeEventsFromLatestServer) Send(m *executiondata.SubscribeEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// ExtendedExecutionDataAPI_ServiceDesc is the grpc.ServiceDesc for ExtendedExecutionDataAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExtendedExecutionDataAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flow.extended.ExtendedExecutionDataAPI",
+	HandlerType: (*ExtendedExecutionDataAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateEventCursor",
+			Handler:    _ExtendedExecutionDataAPI_CreateEventCursor_Handler,
+		},
+		{
+			MethodName: "AcknowledgeEvents",
+			Handler:    _ExtendedExecutionDataAPI_AcknowledgeEvents_Handler,
+		},
+		{
+			MethodName: "DeleteEventCursor",
+			Handler:    _ExtendedExecutionDataAPI_DeleteEventCursor_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEventsFromCursor",
+			Handler:       _ExtendedExecutionDataAPI_SubscribeEventsFromCursor_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeEventsFromStartBlockID",
+			Handler:       _ExtendedExecutionDataAPI_SubscribeEventsFromStartBlockID_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeEventsFromStartHeight",
+			Handler:       _ExtendedExecutionDataAPI_SubscribeEventsFromStartHeight_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeEventsFromLatest",
+			Handler:       _ExtendedExecutionDataAPI_SubscribeEventsFromLatest_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "access/extended/executiondata.proto",
+}
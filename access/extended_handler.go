@@ -0,0 +1,396 @@
+package access
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/extended"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/fvm/evm/debug"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ExtendedHandler serves the ExtendedAccessAPI, the endpoints of the access API which are not part of
+// the AccessAPI service of the onflow/flow protobuf definitions yet.
+type ExtendedHandler struct {
+	subscription.StreamingData
+	api   API
+	chain flow.Chain
+}
+
+var _ extended.ExtendedAccessAPIServer = (*ExtendedHandler)(nil)
+
+func NewExtendedHandler(api API, chain flow.Chain, maxStreams uint32) *ExtendedHandler {
+	return &ExtendedHandler{
+		StreamingData: subscription.NewStreamingData(maxStreams),
+		api:           api,
+		chain:         chain,
+	}
+}
+
+// GetAccountTransactions returns a page of the transactions which involved an account, ordered from
+// newest to oldest.
+func (h *ExtendedHandler) GetAccountTransactions(
+	ctx context.Context,
+	req *extended.GetAccountTransactionsRequest,
+) (*extended.GetAccountTransactionsResponse, error) {
+	address, err := convert.Address(req.GetAddress(), h.chain)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address: %v", err)
+	}
+
+	page, err := h.api.GetAccountTransactions(
+		ctx,
+		address,
+		req.GetStartHeight(),
+		req.GetEndHeight(),
+		convert.MessageToAccountTransactionCursor(req.GetCursor()),
+		req.GetLimit(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*extended.AccountTransaction, len(page.Transactions))
+	for i, transaction := range page.Transactions {
+		transactions[i] = convert.AccountTransactionToMessage(transaction)
+	}
+
+	return &extended.GetAccountTransactionsResponse{
+		Transactions: transactions,
+		NextCursor:   convert.AccountTransactionCursorToMessage(page.NextCursor),
+	}, nil
+}
+
+// GetEventsForHeightRange returns the events of a type emitted in a range of blocks, which match the
+// optional where clause of the request.
+func (h *ExtendedHandler) GetEventsForHeightRange(
+	ctx context.Context,
+	req *extended.GetEventsForHeightRangeRequest,
+) (*extended.GetEventsForHeightRangeResponse, error) {
+	eventType, err := convert.EventType(req.GetType())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetWhere() == "" {
+		results, err := h.api.GetEventsForHeightRange(ctx, eventType, req.GetStartHeight(), req.GetEndHeight(), req.GetEventEncodingVersion())
+		if err != nil {
+			return nil, err
+		}
+		return blockEventsToResponse(results)
+	}
+
+	expr, err := state_stream.ParseEventExpression(
+		state_stream.DefaultEventFilterConfig,
+		h.chain,
+		fmt.Sprintf("%s where %s", eventType, req.GetWhere()),
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid where clause: %v", err)
+	}
+
+	// expressions are evaluated against CCF encoded payloads, matching events are converted to the
+	// requested encoding afterwards
+	results, err := h.api.GetEventsForHeightRange(ctx, eventType, req.GetStartHeight(), req.GetEndHeight(), entities.EventEncodingVersion_CCF_V0)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, blockEvents := range results {
+		var filtered []flow.Event
+		for _, event := range blockEvents.Events {
+			if expr.Match(event) {
+				filtered = append(filtered, event)
+			}
+		}
+
+		if req.GetEventEncodingVersion() == entities.EventEncodingVersion_JSON_CDC_V0 {
+			filtered, err = convert.CcfEventsToJsonEvents(filtered)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "could not convert events to JSON-CDC: %v", err)
+			}
+		}
+		results[i].Events = filtered
+	}
+
+	return blockEventsToResponse(results)
+}
+
+// blockEventsToResponse converts the block events to a GetEventsForHeightRange response.
+func blockEventsToResponse(results []flow.BlockEvents) (*extended.GetEventsForHeightRangeResponse, error) {
+	resultEvents, err := convert.BlockEventsToMessages(results)
+	if err != nil {
+		return nil, err
+	}
+	return &extended.GetEventsForHeightRangeResponse{
+		Results: resultEvents,
+	}, nil
+}
+
+// SimulateTransaction executes a transaction against the state at a block height without submitting it.
+func (h *ExtendedHandler) SimulateTransaction(
+	ctx context.Context,
+	req *extended.SimulateTransactionRequest,
+) (*extended.SimulateTransactionResponse, error) {
+	tx, err := convert.MessageToTransaction(req.GetTransaction(), h.chain)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := h.api.SimulateTransaction(ctx, &tx, req.GetBlockHeight(), req.GetSkipSignatureCheck())
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := convert.TransactionSimulationResultToMessage(result, req.GetEventEncodingVersion())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not convert simulation result: %v", err)
+	}
+	return response, nil
+}
+
+// EstimateTransactionFees executes a transaction against the state at a block height without submitting it,
+// and returns the computation it used and the fees it would be charged.
+func (h *ExtendedHandler) EstimateTransactionFees(
+	ctx context.Context,
+	req *extended.EstimateTransactionFeesRequest,
+) (*extended.EstimateTransactionFeesResponse, error) {
+	tx, err := convert.MessageToTransaction(req.GetTransaction(), h.chain)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	estimate, err := h.api.EstimateTransactionFees(ctx, &tx, req.GetBlockHeight())
+	if err != nil {
+		return nil, err
+	}
+
+	return convert.TransactionFeeEstimateToMessage(estimate), nil
+}
+
+// ExecuteScriptsAtBlockHeight executes a batch of scripts against the state at a block height.
+func (h *ExtendedHandler) ExecuteScriptsAtBlockHeight(
+	ctx context.Context,
+	req *extended.ExecuteScriptsAtBlockHeightRequest,
+) (*extended.ExecuteScriptsAtBlockHeightResponse, error) {
+	scripts := make([]Script, len(req.GetScripts()))
+	for i, script := range req.GetScripts() {
+		scripts[i] = Script{
+			Script:    script.GetScript(),
+			Arguments: script.GetArguments(),
+		}
+	}
+
+	results, err := h.api.ExecuteScriptsAtBlockHeight(ctx, req.GetBlockHeight(), scripts)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*extended.ScriptResult, len(results))
+	for i, result := range results {
+		messages[i] = &extended.ScriptResult{
+			Value:           result.Value,
+			ComputationUsed: result.ComputationUsed,
+		}
+		if result.Err != nil {
+			st := status.Convert(result.Err)
+			messages[i].ErrorCode = uint32(st.Code())
+			messages[i].ErrorMessage = st.Message()
+		}
+	}
+
+	return &extended.ExecuteScriptsAtBlockHeightResponse{
+		BlockHeight: req.GetBlockHeight(),
+		Results:     messages,
+	}, nil
+}
+
+// GetAccountStorageDiff returns a page of the registers of an account which changed between two heights.
+func (h *ExtendedHandler) GetAccountStorageDiff(
+	ctx context.Context,
+	req *extended.GetAccountStorageDiffRequest,
+) (*extended.GetAccountStorageDiffResponse, error) {
+	address, err := convert.Address(req.GetAddress(), h.chain)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address: %v", err)
+	}
+
+	var cursor *string
+	if len(req.GetCursor()) > 0 {
+		key := string(req.GetCursor())
+		cursor = &key
+	}
+
+	diff, err := h.api.GetAccountStorageDiff(
+		ctx,
+		address,
+		req.GetFromHeight(),
+		req.GetToHeight(),
+		cursor,
+		req.GetLimit(),
+		req.GetDecodePaths(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return convert.AccountStorageDiffToMessage(diff), nil
+}
+
+// GetEVMBlockTraces returns the traces of the EVM transactions executed by a Flow block.
+func (h *ExtendedHandler) GetEVMBlockTraces(
+	ctx context.Context,
+	req *extended.GetEVMBlockTracesRequest,
+) (*extended.GetEVMBlockTracesResponse, error) {
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid block id: %v", err)
+	}
+
+	config, err := messageToTracerConfig(req.GetTracer())
+	if err != nil {
+		return nil, err
+	}
+
+	traces, err := h.api.GetEVMBlockTraces(ctx, blockID, config)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*extended.EVMTransactionTrace, len(traces))
+	for i, trace := range traces {
+		messages[i] = &extended.EVMTransactionTrace{
+			TxHash: trace.TxHash.Bytes(),
+			Result: trace.Result,
+		}
+	}
+
+	return &extended.GetEVMBlockTracesResponse{
+		Traces: messages,
+	}, nil
+}
+
+// GetEVMTransactionTrace returns the trace of an EVM transaction executed by a Flow block.
+func (h *ExtendedHandler) GetEVMTransactionTrace(
+	ctx context.Context,
+	req *extended.GetEVMTransactionTraceRequest,
+) (*extended.GetEVMTransactionTraceResponse, error) {
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid block id: %v", err)
+	}
+
+	if len(req.GetTxHash()) != gethCommon.HashLength {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction hash: must be %d bytes", gethCommon.HashLength)
+	}
+	txHash := gethCommon.BytesToHash(req.GetTxHash())
+
+	config, err := messageToTracerConfig(req.GetTracer())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.api.GetEVMTransactionTrace(ctx, blockID, txHash, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extended.GetEVMTransactionTraceResponse{
+		Result: result,
+	}, nil
+}
+
+// messageToTracerConfig converts the tracer selection of a request to a debug.TracerConfig.
+// The default tracer is used if no tracer is selected.
+//
+// Expected errors during normal operation:
+// - codes.InvalidArgument - if the tracer configuration is not valid JSON.
+func messageToTracerConfig(m *extended.EVMTracerConfig) (debug.TracerConfig, error) {
+	if m == nil {
+		return debug.DefaultTracerConfig, nil
+	}
+
+	config := debug.TracerConfig{Tracer: m.GetTracer()}
+	if len(m.GetConfig()) > 0 {
+		if !json.Valid(m.GetConfig()) {
+			return debug.TracerConfig{}, status.Error(codes.InvalidArgument, "invalid tracer config: must be valid JSON")
+		}
+		config.Config = m.GetConfig()
+	}
+	return config, nil
+}
+
+// GetEVMLogs returns a page of the EVM logs matching a filter, ordered from oldest to newest.
+func (h *ExtendedHandler) GetEVMLogs(
+	ctx context.Context,
+	req *extended.GetEVMLogsRequest,
+) (*extended.GetEVMLogsResponse, error) {
+	filter, err := convert.MessageToEVMLogFilter(req.GetFilter())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+	}
+
+	page, err := h.api.GetEVMLogs(
+		ctx,
+		filter,
+		req.GetStartHeight(),
+		req.GetEndHeight(),
+		convert.MessageToEVMLogCursor(req.GetCursor()),
+		req.GetLimit(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extended.GetEVMLogsResponse{
+		Logs:       convert.EVMLogsToMessages(page.Logs),
+		NextCursor: convert.EVMLogCursorToMessage(page.NextCursor),
+	}, nil
+}
+
+// SubscribeEVMLogsFromStartHeight handles subscription requests for the EVM logs matching a filter, starting
+// at the requested block height. A response is sent for every indexed block, including blocks without
+// matching logs.
+//
+// Expected errors during normal operation:
+// - codes.InvalidArgument - if the filter is invalid.
+// - codes.ResourceExhausted - if the maximum number of streams is reached.
+// - codes.Internal - if stream encountered an error, if stream got unexpected response or could not send response.
+func (h *ExtendedHandler) SubscribeEVMLogsFromStartHeight(
+	request *extended.SubscribeEVMLogsFromStartHeightRequest,
+	stream extended.ExtendedAccessAPI_SubscribeEVMLogsFromStartHeightServer,
+) error {
+	// check if the maximum number of streams is reached
+	if h.StreamCount.Load() >= h.MaxStreams {
+		return status.Errorf(codes.ResourceExhausted, "maximum number of streams reached")
+	}
+	h.StreamCount.Add(1)
+	defer h.StreamCount.Add(-1)
+
+	filter, err := convert.MessageToEVMLogFilter(request.GetFilter())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+	}
+
+	sub := h.api.SubscribeEVMLogsFromStartHeight(stream.Context(), request.GetStartBlockHeight(), filter)
+	return subscription.HandleSubscription(sub, func(resp *EVMLogsResponse) error {
+		err := stream.Send(&extended.SubscribeEVMLogsResponse{
+			BlockHeight: resp.BlockHeight,
+			Logs:        convert.EVMLogsToMessages(resp.Logs),
+		})
+		if err != nil {
+			return rpc.ConvertError(err, "could not send response", codes.Internal)
+		}
+		return nil
+	})
+}
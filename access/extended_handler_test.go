@@ -0,0 +1,687 @@
+package access_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/encoding/ccf"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/access/extended"
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/fvm/evm/debug"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+	"github.com/onflow/flow-go/utils/unittest/generator"
+)
+
+// TestExtendedHandler_GetAccountTransactions tests that account transactions are served with the
+// cursor of the next page, and that requests are validated.
+func TestExtendedHandler_GetAccountTransactions(t *testing.T) {
+	ctx := context.Background()
+	chain := flow.Testnet.Chain()
+	address := unittest.RandomAddressFixtureForChain(flow.Testnet)
+
+	t.Run("returns a page", func(t *testing.T) {
+		api := mock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain, subscription.DefaultMaxGlobalStreams)
+
+		cursor := &flow.AccountTransactionCursor{BlockHeight: 20, TransactionIndex: 1}
+		page := &access.AccountTransactionsPage{
+			Transactions: []flow.AccountTransaction{
+				{
+					Address:          address,
+					BlockHeight:      21,
+					TransactionID:    unittest.IdentifierFixture(),
+					TransactionIndex: 0,
+					Roles:            []flow.AccountTransactionRole{flow.AccountTransactionRolePayer},
+				},
+			},
+			NextCursor: &flow.AccountTransactionCursor{BlockHeight: 20, TransactionIndex: 0},
+		}
+		api.
+			On("GetAccountTransactions", ctx, address, uint64(10), uint64(30), cursor, uint32(1)).
+			Return(page, nil).
+			Once()
+
+		resp, err := handler.GetAccountTransactions(ctx, &extended.GetAccountTransactionsRequest{
+			Address:     address.Bytes(),
+			StartHeight: 10,
+			EndHeight:   30,
+			Cursor:      convert.AccountTransactionCursorToMessage(cursor),
+			Limit:       1,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, resp.GetTransactions(), 1)
+		require.Equal(t, page.Transactions[0], convert.MessageToAccountTransaction(resp.GetTransactions()[0]))
+		require.Equal(t, page.NextCursor, convert.MessageToAccountTransactionCursor(resp.GetNextCursor()))
+	})
+
+	t.Run("last page has no cursor", func(t *testing.T) {
+		api := mock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain, subscription.DefaultMaxGlobalStreams)
+
+		api.
+			On("GetAccountTransactions", ctx, address, uint64(0), uint64(0), (*flow.AccountTransactionCursor)(nil), uint32(10)).
+			Return(&access.AccountTransactionsPage{}, nil).
+			Once()
+
+		resp, err := handler.GetAccountTransactions(ctx, &extended.GetAccountTransactionsRequest{
+			Address: address.Bytes(),
+			Limit:   10,
+		})
+		require.NoError(t, err)
+		require.Empty(t, resp.GetTransactions())
+		require.Nil(t, resp.GetNextCursor())
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		handler := access.NewExtendedHandler(mock.NewAPI(t), chain, subscription.DefaultMaxGlobalStreams)
+
+		_, err := handler.GetAccountTransactions(ctx, &extended.GetAccountTransactionsRequest{
+			Address: unittest.InvalidAddressFixture().Bytes(),
+			Limit:   10,
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("backend errors are returned", func(t *testing.T) {
+		api := mock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain, subscription.DefaultMaxGlobalStreams)
+
+		expected := status.Error(codes.OutOfRange, "height range is not indexed")
+		api.
+			On("GetAccountTransactions", ctx, address, uint64(0), uint64(0), (*flow.AccountTransactionCursor)(nil), uint32(10)).
+			Return(nil, expected).
+			Once()
+
+		_, err := handler.GetAccountTransactions(ctx, &extended.GetAccountTransactionsRequest{
+			Address: address.Bytes(),
+			Limit:   10,
+		})
+		require.Equal(t, expected, err)
+	})
+}
+
+// TestExtendedHandler_GetEventsForHeightRange tests that events are filtered on the values of their fields,
+// and returned in the requested encoding.
+func TestExtendedHandler_GetEventsForHeightRange(t *testing.T) {
+	ctx := context.Background()
+	chain := flow.MonotonicEmulator.Chain()
+	eventType := "A.0000000000000001.FlowToken.TokensDeposited"
+
+	small := depositEventFixture(t, eventType, "1.0")
+	large := depositEventFixture(t, eventType, "200.0")
+	blockEvents := func() []flow.BlockEvents {
+		return []flow.BlockEvents{
+			{BlockID: unittest.IdentifierFixture(), BlockHeight: 10, Events: []flow.Event{small, large}},
+		}
+	}
+
+	t.Run("without where clause", func(t *testing.T) {
+		api := mock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain, subscription.DefaultMaxGlobalStreams)
+
+		results := blockEvents()
+		api.
+			On("GetEventsForHeightRange", ctx, eventType, uint64(10), uint64(20), entities.EventEncodingVersion_CCF_V0).
+			Return(results, nil).
+			Once()
+
+		resp, err := handler.GetEventsForHeightRange(ctx, &extended.GetEventsForHeightRangeRequest{
+			Type:                 eventType,
+			StartHeight:          10,
+			EndHeight:            20,
+			EventEncodingVersion: entities.EventEncodingVersion_CCF_V0,
+		})
+		require.NoError(t, err)
+
+		blocks := convert.MessagesToBlockEvents(resp.GetResults())
+		require.Len(t, blocks, 1)
+		require.Equal(t, results[0].Events, blocks[0].Events)
+	})
+
+	t.Run("with where clause", func(t *testing.T) {
+		api := mock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain, subscription.DefaultMaxGlobalStreams)
+
+		results := blockEvents()
+		api.
+			On("GetEventsForHeightRange", ctx, eventType, uint64(10), uint64(20), entities.EventEncodingVersion_CCF_V0).
+			Return(results, nil).
+			Once()
+
+		resp, err := handler.GetEventsForHeightRange(ctx, &extended.GetEventsForHeightRangeRequest{
+			Type:                 eventType,
+			StartHeight:          10,
+			EndHeight:            20,
+			Where:                "amount > 100.0",
+			EventEncodingVersion: entities.EventEncodingVersion_JSON_CDC_V0,
+		})
+		require.NoError(t, err)
+
+		expected, err := convert.CcfEventToJsonEvent(large)
+		require.NoError(t, err)
+
+		blocks := convert.MessagesToBlockEvents(resp.GetResults())
+		require.Len(t, blocks, 1)
+		require.Equal(t, []flow.Event{*expected}, blocks[0].Events)
+	})
+
+	t.Run("invalid where clause", func(t *testing.T) {
+		handler := access.NewExtendedHandler(mock.NewAPI(t), chain, subscription.DefaultMaxGlobalStreams)
+
+		_, err := handler.GetEventsForHeightRange(ctx, &extended.GetEventsForHeightRangeRequest{
+			Type:  eventType,
+			Where: "amount >",
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+// depositEventFixture returns a CCF encoded event of the given type with a single amount field.
+func depositEventFixture(t *testing.T, eventType string, amount string) flow.Event {
+	location := common.NewAddressLocation(nil, common.Address{0, 0, 0, 0, 0, 0, 0, 1}, "FlowToken")
+	cdcType := cadence.NewEventType(location, "FlowToken.TokensDeposited", []cadence.Field{
+		{Identifier: "amount", Type: cadence.UFix64Type},
+	}, nil)
+
+	value, err := cadence.NewUFix64(amount)
+	require.NoError(t, err)
+
+	payload, err := ccf.Encode(cadence.NewEvent([]cadence.Value{value}).WithType(cdcType))
+	require.NoError(t, err)
+
+	event := unittest.EventFixture(flow.EventType(eventType), 0, 0, unittest.IdentifierFixture(), 0)
+	event.Payload = payload
+	return event
+}
+
+// TestExtendedHandler_SimulateTransaction tests that transactions are simulated at the requested height,
+// and that the result is returned with the events in the requested encoding.
+func TestExtendedHandler_SimulateTransaction(t *testing.T) {
+	ctx := context.Background()
+	chain := flow.Testnet.Chain()
+
+	txMsg := convert.TransactionToMessage(unittest.TransactionBodyFixture())
+	tx, err := convert.MessageToTransaction(txMsg, chain)
+	require.NoError(t, err)
+
+	t.Run("returns the result", func(t *testing.T) {
+		api := mock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain, subscription.DefaultMaxGlobalStreams)
+
+		ccfEvents := generator.GetEventsWithEncoding(2, entities.EventEncodingVersion_CCF_V0)
+		result := &flow.TransactionSimulationResult{
+			BlockID:         unittest.IdentifierFixture(),
+			BlockHeight:     42,
+			Events:          ccfEvents,
+			ComputationUsed: 10,
+			StorageDeltas: []flow.AccountStorageDelta{
+				{Address: tx.Payer, StorageUsedBefore: 100, StorageUsedAfter: 120},
+			},
+		}
+		api.
+			On("SimulateTransaction", ctx, &tx, uint64(42), true).
+			Return(result, nil).
+			Once()
+
+		resp, err := handler.SimulateTransaction(ctx, &extended.SimulateTransactionRequest{
+			Transaction:          txMsg,
+			BlockHeight:          42,
+			SkipSignatureCheck:   true,
+			EventEncodingVersion: entities.EventEncodingVersion_JSON_CDC_V0,
+		})
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), resp.GetStatusCode())
+
+		jsonEvents, err := convert.CcfEventsToJsonEvents(ccfEvents)
+		require.NoError(t, err)
+
+		converted := convert.MessageToTransactionSimulationResult(resp)
+		require.Equal(t, flow.EventsList(jsonEvents), converted.Events)
+		require.Equal(t, result.StorageDeltas, converted.StorageDeltas)
+		require.Equal(t, result.BlockID, converted.BlockID)
+	})
+
+	t.Run("invalid transaction", func(t *testing.T) {
+		handler := access.NewExtendedHandler(mock.NewAPI(t), chain, subscription.DefaultMaxGlobalStreams)
+
+		_, err := handler.SimulateTransaction(ctx, &extended.SimulateTransactionRequest{})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("returns backend errors", func(t *testing.T) {
+		api := mock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain, subscription.DefaultMaxGlobalStreams)
+
+		expectedErr := status.Error(codes.OutOfRange, "registers not indexed")
+		api.
+			On("SimulateTransaction", ctx, &tx, uint64(42), false).
+			Return(nil, expectedErr).
+			Once()
+
+		_, err := handler.SimulateTransaction(ctx, &extended.SimulateTransactionRequest{
+			Transaction: txMsg,
+			BlockHeight: 42,
+		})
+		require.Equal(t, expectedErr, err)
+	})
+}
+
+// TestExtendedHandler_EstimateTransactionFees tests that fees are estimated at the requested height.
+func TestExtendedHandler_EstimateTransactionFees(t *testing.T) {
+	ctx := context.Background()
+	chain := flow.Testnet.Chain()
+
+	txMsg := convert.TransactionToMessage(unittest.TransactionBodyFixture())
+	tx, err := convert.MessageToTransaction(txMsg, chain)
+	require.NoError(t, err)
+
+	t.Run("returns the estimate", func(t *testing.T) {
+		api := mock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain, subscription.DefaultMaxGlobalStreams)
+
+		estimate := &flow.TransactionFeeEstimate{
+			BlockID:                unittest.IdentifierFixture(),
+			BlockHeight:            42,
+			ErrorMessage:           "[Error Code: 1101] cadence runtime error",
+			ComputationLimit:       9999,
+			ComputationUsed:        10,
+			ComputationIntensities: map[common.ComputationKind]uint64{common.ComputationKindStatement: 10},
+			TotalFee:               1_000,
+		}
+		api.
+			On("EstimateTransactionFees", ctx, &tx, uint64(42)).
+			Return(estimate, nil).
+			Once()
+
+		resp, err := handler.EstimateTransactionFees(ctx, &extended.EstimateTransactionFeesRequest{
+			Transaction: txMsg,
+			BlockHeight: 42,
+		})
+		require.NoError(t, err)
+		require.Equal(t, uint32(1), resp.GetStatusCode())
+		require.Equal(t, estimate, convert.MessageToTransactionFeeEstimate(resp))
+	})
+
+	t.Run("invalid transaction", func(t *testing.T) {
+		handler := access.NewExtendedHandler(mock.NewAPI(t), chain, subscription.DefaultMaxGlobalStreams)
+
+		_, err := handler.EstimateTransactionFees(ctx, &extended.EstimateTransactionFeesRequest{})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+// TestExtendedHandler_ExecuteScriptsAtBlockHeight tests that the results of a batch are returned in order,
+// with the errors of failed scripts.
+func TestExtendedHandler_ExecuteScriptsAtBlockHeight(t *testing.T) {
+	ctx := context.Background()
+	chain := flow.Testnet.Chain()
+
+	scripts := []access.Script{
+		{Script: []byte("access(all) fun main(): Int { return 1 }")},
+		{Script: []byte("access(all) fun main(a: Int): Int { return a }"), Arguments: [][]byte{[]byte(`{"type":"Int","value":"2"}`)}},
+	}
+	request := &extended.ExecuteScriptsAtBlockHeightRequest{
+		BlockHeight: 42,
+		Scripts: []*extended.Script{
+			{Script: scripts[0].Script},
+			{Script: scripts[1].Script, Arguments: scripts[1].Arguments},
+		},
+	}
+
+	t.Run("returns the results", func(t *testing.T) {
+		api := mock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain, subscription.DefaultMaxGlobalStreams)
+
+		results := []access.ScriptResult{
+			{Value: []byte(`{"type":"Int","value":"1"}`), ComputationUsed: 3},
+			{ComputationUsed: 5, Err: status.Error(codes.ResourceExhausted, "computation limit exceeded")},
+		}
+		api.
+			On("ExecuteScriptsAtBlockHeight", ctx, uint64(42), scripts).
+			Return(results, nil).
+			Once()
+
+		resp, err := handler.ExecuteScriptsAtBlockHeight(ctx, request)
+		require.NoError(t, err)
+		require.Equal(t, uint64(42), resp.GetBlockHeight())
+		require.Len(t, resp.GetResults(), 2)
+
+		require.Equal(t, results[0].Value, resp.GetResults()[0].GetValue())
+		require.Equal(t, uint64(3), resp.GetResults()[0].GetComputationUsed())
+		require.Equal(t, uint32(codes.OK), resp.GetResults()[0].GetErrorCode())
+
+		require.Empty(t, resp.GetResults()[1].GetValue())
+		require.Equal(t, uint32(codes.ResourceExhausted), resp.GetResults()[1].GetErrorCode())
+		require.Equal(t, "computation limit exceeded", resp.GetResults()[1].GetErrorMessage())
+	})
+
+	t.Run("returns backend errors", func(t *testing.T) {
+		api := mock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain, subscription.DefaultMaxGlobalStreams)
+
+		expectedErr := status.Error(codes.InvalidArgument, "too many scripts")
+		api.
+			On("ExecuteScriptsAtBlockHeight", ctx, uint64(42), scripts).
+			Return(nil, expectedErr).
+			Once()
+
+		_, err := handler.ExecuteScriptsAtBlockHeight(ctx, request)
+		require.Equal(t, expectedErr, err)
+	})
+}
+
+// TestExtendedHandler_GetAccountStorageDiff tests that a page of the storage diff of an account is served
+// with the cursor of the next page, and that requests are validated.
+func TestExtendedHandler_GetAccountStorageDiff(t *testing.T) {
+	ctx := context.Background()
+	chain := flow.Testnet.Chain()
+	address := unittest.RandomAddressFixtureForChain(flow.Testnet)
+
+	t.Run("returns a page", func(t *testing.T) {
+		api := mock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain, subscription.DefaultMaxGlobalStreams)
+
+		cursor := "$0000000000000001"
+		nextCursor := "$0000000000000002"
+		diff := &flow.AccountStorageDiff{
+			Address:    address,
+			FromHeight: 10,
+			ToHeight:   20,
+			Changes: []flow.AccountRegisterChange{
+				{
+					RegisterChange: flow.RegisterChange{
+						ID:          flow.NewRegisterID(address, nextCursor),
+						Type:        flow.RegisterModified,
+						ValueBefore: []byte{1},
+						ValueAfter:  []byte{2},
+					},
+					Kind: flow.AccountRegisterSlab,
+					Name: "2",
+				},
+			},
+			NextCursor: &nextCursor,
+		}
+		api.
+			On("GetAccountStorageDiff", ctx, address, uint64(10), uint64(20), &cursor, uint32(1), true).
+			Return(diff, nil).
+			Once()
+
+		resp, err := handler.GetAccountStorageDiff(ctx, &extended.GetAccountStorageDiffRequest{
+			Address:     address.Bytes(),
+			FromHeight:  10,
+			ToHeight:    20,
+			Cursor:      []byte(cursor),
+			Limit:       1,
+			DecodePaths: true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, diff, convert.MessageToAccountStorageDiff(resp))
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		handler := access.NewExtendedHandler(mock.NewAPI(t), chain, subscription.DefaultMaxGlobalStreams)
+
+		_, err := handler.GetAccountStorageDiff(ctx, &extended.GetAccountStorageDiffRequest{
+			Address: []byte{1, 2, 3},
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("returns backend errors", func(t *testing.T) {
+		api := mock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain, subscription.DefaultMaxGlobalStreams)
+
+		expectedErr := status.Error(codes.OutOfRange, "height range is not indexed")
+		api.
+			On("GetAccountStorageDiff", ctx, address, uint64(10), uint64(20), (*string)(nil), uint32(0), false).
+			Return(nil, expectedErr).
+			Once()
+
+		_, err := handler.GetAccountStorageDiff(ctx, &extended.GetAccountStorageDiffRequest{
+			Address:    address.Bytes(),
+			FromHeight: 10,
+			ToHeight:   20,
+		})
+		require.Equal(t, expectedErr, err)
+	})
+}
+
+// TestExtendedHandler_GetEVMTraces tests that traces are served with the selected tracer, and that requests
+// are validated.
+func TestExtendedHandler_GetEVMTraces(t *testing.T) {
+	ctx := context.Background()
+	chain := flow.Testnet.Chain()
+	blockID := unittest.IdentifierFixture()
+	txHash := gethCommon.HexToHash("0x1234")
+
+	config := debug.TracerConfig{
+		Tracer: "callTracer",
+		Config: json.RawMessage(`{"onlyTopCall":true}`),
+	}
+	tracer := &extended.EVMTracerConfig{
+		Tracer: config.Tracer,
+		Config: config.Config,
+	}
+
+	t.Run("block traces", func(t *testing.T) {
+		api := mock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain, subscription.DefaultMaxGlobalStreams)
+
+		traces := []debug.TransactionTrace{
+			{TxHash: txHash, Result: json.RawMessage(`{"type":"CALL"}`)},
+		}
+		api.
+			On("GetEVMBlockTraces", ctx, blockID, config).
+			Return(traces, nil).
+			Once()
+
+		resp, err := handler.GetEVMBlockTraces(ctx, &extended.GetEVMBlockTracesRequest{
+			BlockId: blockID[:],
+			Tracer:  tracer,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.GetTraces(), 1)
+		require.Equal(t, txHash.Bytes(), resp.GetTraces()[0].GetTxHash())
+		require.Equal(t, []byte(traces[0].Result), resp.GetTraces()[0].GetResult())
+	})
+
+	t.Run("transaction trace with default tracer", func(t *testing.T) {
+		api := mock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain, subscription.DefaultMaxGlobalStreams)
+
+		result := json.RawMessage(`{"type":"CALL"}`)
+		api.
+			On("GetEVMTransactionTrace", ctx, blockID, txHash, debug.DefaultTracerConfig).
+			Return(result, nil).
+			Once()
+
+		resp, err := handler.GetEVMTransactionTrace(ctx, &extended.GetEVMTransactionTraceRequest{
+			BlockId: blockID[:],
+			TxHash:  txHash.Bytes(),
+		})
+		require.NoError(t, err)
+		require.Equal(t, []byte(result), resp.GetResult())
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		handler := access.NewExtendedHandler(mock.NewAPI(t), chain, subscription.DefaultMaxGlobalStreams)
+
+		_, err := handler.GetEVMBlockTraces(ctx, &extended.GetEVMBlockTracesRequest{
+			BlockId: []byte{1, 2, 3},
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = handler.GetEVMBlockTraces(ctx, &extended.GetEVMBlockTracesRequest{
+			BlockId: blockID[:],
+			Tracer:  &extended.EVMTracerConfig{Config: []byte("{invalid")},
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = handler.GetEVMTransactionTrace(ctx, &extended.GetEVMTransactionTraceRequest{
+			BlockId: blockID[:],
+			TxHash:  []byte{1, 2, 3},
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+// TestExtendedHandler_GetEVMLogs tests that a page of EVM logs is served with the cursor of the next page,
+// and that the filter is validated.
+func TestExtendedHandler_GetEVMLogs(t *testing.T) {
+	ctx := context.Background()
+	chain := flow.Testnet.Chain()
+
+	filter := flow.EVMLogFilter{
+		Addresses: []gethCommon.Address{gethCommon.HexToAddress("0x01")},
+		Topics:    [][]gethCommon.Hash{{gethCommon.HexToHash("0x02")}},
+	}
+
+	t.Run("returns a page", func(t *testing.T) {
+		api := mock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain, subscription.DefaultMaxGlobalStreams)
+
+		cursor := &flow.EVMLogCursor{BlockHeight: 12, LogIndex: 1}
+		page := &access.EVMLogsPage{
+			Logs:       []flow.EVMLog{evmLogFixture(12, 1)},
+			NextCursor: &flow.EVMLogCursor{BlockHeight: 13, LogIndex: 0},
+		}
+		api.
+			On("GetEVMLogs", ctx, filter, uint64(10), uint64(20), cursor, uint32(1)).
+			Return(page, nil).
+			Once()
+
+		resp, err := handler.GetEVMLogs(ctx, &extended.GetEVMLogsRequest{
+			Filter:      convert.EVMLogFilterToMessage(filter),
+			StartHeight: 10,
+			EndHeight:   20,
+			Cursor:      convert.EVMLogCursorToMessage(cursor),
+			Limit:       1,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, resp.GetLogs(), 1)
+		require.Equal(t, page.Logs[0], convert.MessageToEVMLog(resp.GetLogs()[0]))
+		require.Equal(t, page.NextCursor, convert.MessageToEVMLogCursor(resp.GetNextCursor()))
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		handler := access.NewExtendedHandler(mock.NewAPI(t), chain, subscription.DefaultMaxGlobalStreams)
+
+		_, err := handler.GetEVMLogs(ctx, &extended.GetEVMLogsRequest{
+			Filter: &extended.EVMLogFilter{Addresses: [][]byte{{1, 2, 3}}},
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+// TestExtendedHandler_SubscribeEVMLogsFromStartHeight tests that the logs of each block are streamed, and
+// that the number of streams is limited.
+func TestExtendedHandler_SubscribeEVMLogsFromStartHeight(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chain := flow.Testnet.Chain()
+	filter := flow.EVMLogFilter{
+		Addresses: []gethCommon.Address{gethCommon.HexToAddress("0x01")},
+	}
+
+	t.Run("streams logs", func(t *testing.T) {
+		api := mock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain, subscription.DefaultMaxGlobalStreams)
+
+		sub := subscription.NewSubscription(1)
+		api.
+			On("SubscribeEVMLogsFromStartHeight", mocktestify.Anything, uint64(10), filter).
+			Return(sub).
+			Once()
+
+		stream := &fakeEVMLogsStream{
+			ctx:       ctx,
+			responses: make(chan *extended.SubscribeEVMLogsResponse, 10),
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			err := handler.SubscribeEVMLogsFromStartHeight(&extended.SubscribeEVMLogsFromStartHeightRequest{
+				StartBlockHeight: 10,
+				Filter:           convert.EVMLogFilterToMessage(filter),
+			}, stream)
+			require.NoError(t, err)
+		}()
+
+		logs := []flow.EVMLog{evmLogFixture(10, 0)}
+		err := sub.Send(ctx, &access.EVMLogsResponse{BlockHeight: 10, Logs: logs}, time.Second)
+		require.NoError(t, err)
+		err = sub.Send(ctx, &access.EVMLogsResponse{BlockHeight: 11}, time.Second)
+		require.NoError(t, err)
+		sub.Close()
+
+		unittest.RequireCloseBefore(t, done, time.Second, "stream did not close")
+		require.Len(t, stream.responses, 2)
+
+		resp := <-stream.responses
+		require.Equal(t, uint64(10), resp.GetBlockHeight())
+		require.Len(t, resp.GetLogs(), 1)
+		require.Equal(t, logs[0], convert.MessageToEVMLog(resp.GetLogs()[0]))
+
+		resp = <-stream.responses
+		require.Equal(t, uint64(11), resp.GetBlockHeight())
+		require.Empty(t, resp.GetLogs())
+	})
+
+	t.Run("maximum number of streams reached", func(t *testing.T) {
+		handler := access.NewExtendedHandler(mock.NewAPI(t), chain, 0)
+
+		stream := &fakeEVMLogsStream{ctx: ctx}
+		err := handler.SubscribeEVMLogsFromStartHeight(&extended.SubscribeEVMLogsFromStartHeightRequest{}, stream)
+		require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+}
+
+// fakeEVMLogsStream is a server stream of EVM logs which collects the sent responses.
+type fakeEVMLogsStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses chan *extended.SubscribeEVMLogsResponse
+}
+
+func (s *fakeEVMLogsStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeEVMLogsStream) Send(resp *extended.SubscribeEVMLogsResponse) error {
+	s.responses <- resp
+	return nil
+}
+
+// evmLogFixture returns an EVM log emitted at the given block height and log index.
+func evmLogFixture(height uint64, index uint32) flow.EVMLog {
+	return flow.EVMLog{
+		BlockHeight:        height,
+		TransactionID:      unittest.IdentifierFixture(),
+		EVMBlockHeight:     height,
+		EVMTransactionHash: gethCommon.HexToHash("0x03"),
+		LogIndex:           index,
+		Address:            gethCommon.HexToAddress("0x01"),
+		Topics:             []gethCommon.Hash{gethCommon.HexToHash("0x02")},
+		Data:               []byte{1},
+	}
+}
@@ -872,6 +872,7 @@ func (h *Handler) ExecuteScriptAtBlockID(
 }
 
 // GetEventsForHeightRange returns events matching a query.
+// Filtering events on the values of their fields is served by the ExtendedHandler.
 func (h *Handler) GetEventsForHeightRange(
 	ctx context.Context,
 	req *access.GetEventsForHeightRangeRequest,
@@ -3,14 +3,19 @@
 package mock
 
 import (
+	access "github.com/onflow/flow-go/access"
+	common "github.com/onflow/go-ethereum/common"
+
 	context "context"
 
-	access "github.com/onflow/flow-go/access"
+	debug "github.com/onflow/flow-go/fvm/evm/debug"
 
 	entities "github.com/onflow/flow/protobuf/go/flow/entities"
 
 	flow "github.com/onflow/flow-go/model/flow"
 
+	json "encoding/json"
+
 	mock "github.com/stretchr/testify/mock"
 
 	subscription "github.com/onflow/flow-go/engine/access/subscription"
@@ -21,6 +26,36 @@ type API struct {
 	mock.Mock
 }
 
+// EstimateTransactionFees provides a mock function with given fields: ctx, tx, blockHeight
+func (_m *API) EstimateTransactionFees(ctx context.Context, tx *flow.TransactionBody, blockHeight uint64) (*flow.TransactionFeeEstimate, error) {
+	ret := _m.Called(ctx, tx, blockHeight)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateTransactionFees")
+	}
+
+	var r0 *flow.TransactionFeeEstimate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, uint64) (*flow.TransactionFeeEstimate, error)); ok {
+		return rf(ctx, tx, blockHeight)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, uint64) *flow.TransactionFeeEstimate); ok {
+		r0 = rf(ctx, tx, blockHeight)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TransactionFeeEstimate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody, uint64) error); ok {
+		r1 = rf(ctx, tx, blockHeight)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecuteScriptAtBlockHeight provides a mock function with given fields: ctx, blockHeight, script, arguments
 func (_m *API) ExecuteScriptAtBlockHeight(ctx context.Context, blockHeight uint64, script []byte, arguments [][]byte) ([]byte, error) {
 	ret := _m.Called(ctx, blockHeight, script, arguments)
@@ -111,6 +146,36 @@ func (_m *API) ExecuteScriptAtLatestBlock(ctx context.Context, script []byte, ar
 	return r0, r1
 }
 
+// ExecuteScriptsAtBlockHeight provides a mock function with given fields: ctx, blockHeight, scripts
+func (_m *API) ExecuteScriptsAtBlockHeight(ctx context.Context, blockHeight uint64, scripts []access.Script) ([]access.ScriptResult, error) {
+	ret := _m.Called(ctx, blockHeight, scripts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteScriptsAtBlockHeight")
+	}
+
+	var r0 []access.ScriptResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []access.Script) ([]access.ScriptResult, error)); ok {
+		return rf(ctx, blockHeight, scripts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []access.Script) []access.ScriptResult); ok {
+		r0 = rf(ctx, blockHeight, scripts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]access.ScriptResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, []access.Script) error); ok {
+		r1 = rf(ctx, blockHeight, scripts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAccount provides a mock function with given fields: ctx, address
 func (_m *API) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
 	ret := _m.Called(ctx, address)
@@ -377,6 +442,96 @@ func (_m *API) GetAccountKeysAtLatestBlock(ctx context.Context, address flow.Add
 	return r0, r1
 }
 
+// GetAccountStateProof provides a mock function with given fields: ctx, address, keys, height
+func (_m *API) GetAccountStateProof(ctx context.Context, address flow.Address, keys []string, height uint64) (*flow.AccountStateProof, error) {
+	ret := _m.Called(ctx, address, keys, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountStateProof")
+	}
+
+	var r0 *flow.AccountStateProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, []string, uint64) (*flow.AccountStateProof, error)); ok {
+		return rf(ctx, address, keys, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, []string, uint64) *flow.AccountStateProof); ok {
+		r0 = rf(ctx, address, keys, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.AccountStateProof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, []string, uint64) error); ok {
+		r1 = rf(ctx, address, keys, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountStorageDiff provides a mock function with given fields: ctx, address, fromHeight, toHeight, cursor, limit, decodePaths
+func (_m *API) GetAccountStorageDiff(ctx context.Context, address flow.Address, fromHeight uint64, toHeight uint64, cursor *string, limit uint32, decodePaths bool) (*flow.AccountStorageDiff, error) {
+	ret := _m.Called(ctx, address, fromHeight, toHeight, cursor, limit, decodePaths)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountStorageDiff")
+	}
+
+	var r0 *flow.AccountStorageDiff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint64, *string, uint32, bool) (*flow.AccountStorageDiff, error)); ok {
+		return rf(ctx, address, fromHeight, toHeight, cursor, limit, decodePaths)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint64, *string, uint32, bool) *flow.AccountStorageDiff); ok {
+		r0 = rf(ctx, address, fromHeight, toHeight, cursor, limit, decodePaths)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.AccountStorageDiff)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64, uint64, *string, uint32, bool) error); ok {
+		r1 = rf(ctx, address, fromHeight, toHeight, cursor, limit, decodePaths)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountTransactions provides a mock function with given fields: ctx, address, startHeight, endHeight, cursor, limit
+func (_m *API) GetAccountTransactions(ctx context.Context, address flow.Address, startHeight uint64, endHeight uint64, cursor *flow.AccountTransactionCursor, limit uint32) (*access.AccountTransactionsPage, error) {
+	ret := _m.Called(ctx, address, startHeight, endHeight, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountTransactions")
+	}
+
+	var r0 *access.AccountTransactionsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint64, *flow.AccountTransactionCursor, uint32) (*access.AccountTransactionsPage, error)); ok {
+		return rf(ctx, address, startHeight, endHeight, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint64, *flow.AccountTransactionCursor, uint32) *access.AccountTransactionsPage); ok {
+		r0 = rf(ctx, address, startHeight, endHeight, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*access.AccountTransactionsPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64, uint64, *flow.AccountTransactionCursor, uint32) error); ok {
+		r1 = rf(ctx, address, startHeight, endHeight, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlockByHeight provides a mock function with given fields: ctx, height
 func (_m *API) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, flow.BlockStatus, error) {
 	ret := _m.Called(ctx, height)
@@ -555,6 +710,126 @@ func (_m *API) GetCollectionByID(ctx context.Context, id flow.Identifier) (*flow
 	return r0, r1
 }
 
+// GetContractImpact provides a mock function with given fields: ctx, contract, height, accounts
+func (_m *API) GetContractImpact(ctx context.Context, contract flow.ContractID, height uint64, accounts []flow.Address) (*flow.ContractImpact, error) {
+	ret := _m.Called(ctx, contract, height, accounts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContractImpact")
+	}
+
+	var r0 *flow.ContractImpact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.ContractID, uint64, []flow.Address) (*flow.ContractImpact, error)); ok {
+		return rf(ctx, contract, height, accounts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.ContractID, uint64, []flow.Address) *flow.ContractImpact); ok {
+		r0 = rf(ctx, contract, height, accounts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.ContractImpact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.ContractID, uint64, []flow.Address) error); ok {
+		r1 = rf(ctx, contract, height, accounts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEVMBlockTraces provides a mock function with given fields: ctx, blockID, config
+func (_m *API) GetEVMBlockTraces(ctx context.Context, blockID flow.Identifier, config debug.TracerConfig) ([]debug.TransactionTrace, error) {
+	ret := _m.Called(ctx, blockID, config)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEVMBlockTraces")
+	}
+
+	var r0 []debug.TransactionTrace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, debug.TracerConfig) ([]debug.TransactionTrace, error)); ok {
+		return rf(ctx, blockID, config)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, debug.TracerConfig) []debug.TransactionTrace); ok {
+		r0 = rf(ctx, blockID, config)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]debug.TransactionTrace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, debug.TracerConfig) error); ok {
+		r1 = rf(ctx, blockID, config)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEVMLogs provides a mock function with given fields: ctx, filter, startHeight, endHeight, cursor, limit
+func (_m *API) GetEVMLogs(ctx context.Context, filter flow.EVMLogFilter, startHeight uint64, endHeight uint64, cursor *flow.EVMLogCursor, limit uint32) (*access.EVMLogsPage, error) {
+	ret := _m.Called(ctx, filter, startHeight, endHeight, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEVMLogs")
+	}
+
+	var r0 *access.EVMLogsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.EVMLogFilter, uint64, uint64, *flow.EVMLogCursor, uint32) (*access.EVMLogsPage, error)); ok {
+		return rf(ctx, filter, startHeight, endHeight, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.EVMLogFilter, uint64, uint64, *flow.EVMLogCursor, uint32) *access.EVMLogsPage); ok {
+		r0 = rf(ctx, filter, startHeight, endHeight, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*access.EVMLogsPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.EVMLogFilter, uint64, uint64, *flow.EVMLogCursor, uint32) error); ok {
+		r1 = rf(ctx, filter, startHeight, endHeight, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEVMTransactionTrace provides a mock function with given fields: ctx, blockID, txHash, config
+func (_m *API) GetEVMTransactionTrace(ctx context.Context, blockID flow.Identifier, txHash common.Hash, config debug.TracerConfig) (json.RawMessage, error) {
+	ret := _m.Called(ctx, blockID, txHash, config)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEVMTransactionTrace")
+	}
+
+	var r0 json.RawMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, common.Hash, debug.TracerConfig) (json.RawMessage, error)); ok {
+		return rf(ctx, blockID, txHash, config)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, common.Hash, debug.TracerConfig) json.RawMessage); ok {
+		r0 = rf(ctx, blockID, txHash, config)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(json.RawMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, common.Hash, debug.TracerConfig) error); ok {
+		r1 = rf(ctx, blockID, txHash, config)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetEventsForBlockIDs provides a mock function with given fields: ctx, eventType, blockIDs, requiredEventEncodingVersion
 func (_m *API) GetEventsForBlockIDs(ctx context.Context, eventType string, blockIDs []flow.Identifier, requiredEventEncodingVersion entities.EventEncodingVersion) ([]flow.BlockEvents, error) {
 	ret := _m.Called(ctx, eventType, blockIDs, requiredEventEncodingVersion)
@@ -1163,6 +1438,36 @@ func (_m *API) SendTransaction(ctx context.Context, tx *flow.TransactionBody) er
 	return r0
 }
 
+// SimulateTransaction provides a mock function with given fields: ctx, tx, blockHeight, skipSignatureCheck
+func (_m *API) SimulateTransaction(ctx context.Context, tx *flow.TransactionBody, blockHeight uint64, skipSignatureCheck bool) (*flow.TransactionSimulationResult, error) {
+	ret := _m.Called(ctx, tx, blockHeight, skipSignatureCheck)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SimulateTransaction")
+	}
+
+	var r0 *flow.TransactionSimulationResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, uint64, bool) (*flow.TransactionSimulationResult, error)); ok {
+		return rf(ctx, tx, blockHeight, skipSignatureCheck)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, uint64, bool) *flow.TransactionSimulationResult); ok {
+		r0 = rf(ctx, tx, blockHeight, skipSignatureCheck)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TransactionSimulationResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody, uint64, bool) error); ok {
+		r1 = rf(ctx, tx, blockHeight, skipSignatureCheck)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubscribeBlockDigestsFromLatest provides a mock function with given fields: ctx, blockStatus
 func (_m *API) SubscribeBlockDigestsFromLatest(ctx context.Context, blockStatus flow.BlockStatus) subscription.Subscription {
 	ret := _m.Called(ctx, blockStatus)
@@ -1343,6 +1648,26 @@ func (_m *API) SubscribeBlocksFromStartHeight(ctx context.Context, startHeight u
 	return r0
 }
 
+// SubscribeEVMLogsFromStartHeight provides a mock function with given fields: ctx, startHeight, filter
+func (_m *API) SubscribeEVMLogsFromStartHeight(ctx context.Context, startHeight uint64, filter flow.EVMLogFilter) subscription.Subscription {
+	ret := _m.Called(ctx, startHeight, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeEVMLogsFromStartHeight")
+	}
+
+	var r0 subscription.Subscription
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, flow.EVMLogFilter) subscription.Subscription); ok {
+		r0 = rf(ctx, startHeight, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(subscription.Subscription)
+		}
+	}
+
+	return r0
+}
+
 // SubscribeTransactionStatuses provides a mock function with given fields: ctx, tx, requiredEventEncodingVersion
 func (_m *API) SubscribeTransactionStatuses(ctx context.Context, tx *flow.TransactionBody, requiredEventEncodingVersion entities.EventEncodingVersion) subscription.Subscription {
 	ret := _m.Called(ctx, tx, requiredEventEncodingVersion)
@@ -1363,6 +1688,36 @@ func (_m *API) SubscribeTransactionStatuses(ctx context.Context, tx *flow.Transa
 	return r0
 }
 
+// ValidateContractUpdate provides a mock function with given fields: ctx, address, code, blockHeight
+func (_m *API) ValidateContractUpdate(ctx context.Context, address flow.Address, code []byte, blockHeight uint64) (*flow.ContractUpdateValidation, error) {
+	ret := _m.Called(ctx, address, code, blockHeight)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateContractUpdate")
+	}
+
+	var r0 *flow.ContractUpdateValidation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, []byte, uint64) (*flow.ContractUpdateValidation, error)); ok {
+		return rf(ctx, address, code, blockHeight)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, []byte, uint64) *flow.ContractUpdateValidation); ok {
+		r0 = rf(ctx, address, code, blockHeight)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.ContractUpdateValidation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, []byte, uint64) error); ok {
+		r1 = rf(ctx, address, code, blockHeight)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewAPI creates a new instance of API. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAPI(t interface {
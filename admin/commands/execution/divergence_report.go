@@ -0,0 +1,89 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/execution/checker"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+var _ commands.AdminCommand = (*GetDivergenceReportCommand)(nil)
+
+// GetDivergenceReportCommand returns the report of how the execution result of a block diverges
+// from the sealed result.
+type GetDivergenceReportCommand struct {
+	core *checker.Core
+}
+
+// NewGetDivergenceReportCommand creates a new GetDivergenceReportCommand object.
+// The core is nil if the checker engine is disabled.
+func NewGetDivergenceReportCommand(core *checker.Core) *GetDivergenceReportCommand {
+	return &GetDivergenceReportCommand{
+		core: core,
+	}
+}
+
+// Handler returns the divergence report of the requested block, which is generated on demand.
+// If no block is requested, the report of the latest divergence detected by the checker is
+// returned, or "no divergence detected" if there is none.
+func (g *GetDivergenceReportCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	if g.core == nil {
+		return nil, fmt.Errorf("checker engine is disabled")
+	}
+
+	blockID, ok := req.ValidatorData.(flow.Identifier)
+	if !ok {
+		report := g.core.LatestDivergenceReport()
+		if report == nil {
+			return "no divergence detected", nil
+		}
+		return commands.ConvertToMap(report)
+	}
+
+	report, err := g.core.DivergenceReport(blockID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, admin.NewInvalidAdminReqErrorf("block %v is not executed or not sealed: %w", blockID, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not generate divergence report: %w", err)
+	}
+
+	return commands.ConvertToMap(report)
+}
+
+// Validator validates the request.
+// It accepts an optional block_id field, the ID of the block to generate the report for.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (g *GetDivergenceReportCommand) Validator(req *admin.CommandRequest) error {
+	if req.Data == nil {
+		return nil
+	}
+
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	raw, ok := input["block_id"]
+	if !ok {
+		return nil
+	}
+
+	errInvalidBlockID := admin.NewInvalidAdminReqParameterError("block_id", "expected a block ID represented as a 64 character long hex string", raw)
+	s, ok := raw.(string)
+	if !ok {
+		return errInvalidBlockID
+	}
+	blockID, err := flow.HexStringToIdentifier(s)
+	if err != nil {
+		return errInvalidBlockID
+	}
+
+	req.ValidatorData = blockID
+	return nil
+}
@@ -0,0 +1,48 @@
+package execution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestGetDivergenceReportParsing(t *testing.T) {
+	cmd := NewGetDivergenceReportCommand(nil)
+
+	t.Run("latest report", func(t *testing.T) {
+		req := &admin.CommandRequest{}
+		require.NoError(t, cmd.Validator(req))
+		require.Nil(t, req.ValidatorData)
+
+		req = &admin.CommandRequest{Data: map[string]interface{}{}}
+		require.NoError(t, cmd.Validator(req))
+		require.Nil(t, req.ValidatorData)
+	})
+
+	t.Run("block id", func(t *testing.T) {
+		blockID := unittest.IdentifierFixture()
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{"block_id": blockID.String()},
+		}
+		require.NoError(t, cmd.Validator(req))
+		require.Equal(t, blockID, req.ValidatorData)
+	})
+
+	t.Run("invalid block id", func(t *testing.T) {
+		for _, value := range []interface{}{"abc", float64(1)} {
+			req := &admin.CommandRequest{
+				Data: map[string]interface{}{"block_id": value},
+			}
+			require.True(t, admin.IsInvalidAdminParameterError(cmd.Validator(req)))
+		}
+	})
+
+	t.Run("checker disabled", func(t *testing.T) {
+		_, err := cmd.Handler(context.Background(), &admin.CommandRequest{})
+		require.Error(t, err)
+	})
+}
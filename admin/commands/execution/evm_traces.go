@@ -0,0 +1,208 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/fvm/evm"
+	"github.com/onflow/flow-go/fvm/evm/debug"
+	evmStorage "github.com/onflow/flow-go/fvm/evm/offchain/storage"
+	"github.com/onflow/flow-go/fvm/evm/offchain/sync"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+var _ commands.AdminCommand = (*GetEVMTracesCommand)(nil)
+
+type getEVMTracesRequest struct {
+	blockID flow.Identifier
+	txHash  *gethCommon.Hash
+	config  debug.TracerConfig
+}
+
+// GetEVMTracesCommand returns the traces of the EVM transactions executed by a block,
+// produced by re-executing them on top of the execution state of the parent block.
+// The traces collected while executing the block are returned if they are stored locally.
+type GetEVMTracesCommand struct {
+	chainID flow.ChainID
+	headers storage.Headers
+	events  storage.Events
+	state   state.ScriptExecutionState
+	// traces are the locally stored traces, if not nil
+	traces *debug.LocalStore
+}
+
+// NewGetEVMTracesCommand creates a new GetEVMTracesCommand object.
+func NewGetEVMTracesCommand(
+	chainID flow.ChainID,
+	headers storage.Headers,
+	events storage.Events,
+	state state.ScriptExecutionState,
+	traces *debug.LocalStore,
+) *GetEVMTracesCommand {
+	return &GetEVMTracesCommand{
+		chainID: chainID,
+		headers: headers,
+		events:  events,
+		state:   state,
+		traces:  traces,
+	}
+}
+
+// Handler returns the trace of the requested transaction, or the traces of all the
+// EVM transactions of the block if no transaction is requested.
+func (g *GetEVMTracesCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*getEVMTracesRequest)
+
+	if data.txHash != nil && g.traces != nil {
+		trace, err := g.traces.Download(data.config.TraceID(*data.txHash, data.blockID))
+		if err == nil {
+			return commands.ConvertToMap(debug.TransactionTrace{TxHash: *data.txHash, Result: trace})
+		}
+		if !errors.Is(err, debug.ErrTraceNotFound) {
+			return nil, fmt.Errorf("could not read stored trace: %w", err)
+		}
+	}
+
+	traces, err := g.traceBlock(data.blockID, data.config)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.txHash == nil {
+		return commands.ConvertToMap(map[string]interface{}{
+			"block_id": data.blockID,
+			"traces":   traces,
+		})
+	}
+
+	for _, trace := range traces {
+		if trace.TxHash == *data.txHash {
+			return commands.ConvertToMap(trace)
+		}
+	}
+
+	return nil, admin.NewInvalidAdminReqErrorf("EVM transaction %s was not executed by block %v", *data.txHash, data.blockID)
+}
+
+func (g *GetEVMTracesCommand) traceBlock(blockID flow.Identifier, config debug.TracerConfig) ([]debug.TransactionTrace, error) {
+	header, err := g.headers.ByBlockID(blockID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, admin.NewInvalidAdminReqErrorf("block %v is not known", blockID)
+		}
+		return nil, fmt.Errorf("could not get block header: %w", err)
+	}
+
+	flowEvents, err := g.events.ByBlockID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get events of block %v: %w", blockID, err)
+	}
+
+	txEvents, blockEvent, err := sync.DecodeBlockEvents(g.chainID, flowEvents)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode EVM events: %w", err)
+	}
+	if blockEvent == nil {
+		// the block is not executed, or did not execute an EVM block
+		return []debug.TransactionTrace{}, nil
+	}
+
+	// transactions are executed on top of the execution state of the parent block
+	registers, _, err := g.state.CreateStorageSnapshot(header.ParentID)
+	if err != nil {
+		return nil, admin.NewInvalidAdminReqErrorf("execution state of parent block %v is not available: %v", header.ParentID, err)
+	}
+
+	return sync.TraceBlockExecution(
+		g.chainID,
+		evm.StorageAccountAddress(g.chainID),
+		evmStorage.NewRegisterSnapshot(registers),
+		txEvents,
+		blockEvent,
+		config,
+	)
+}
+
+// Validator validates the request.
+// It accepts a required block_id field, the ID of the block to trace, an optional tx_hash field,
+// the hash of the EVM transaction to return the trace of, an optional tracer field, the name of
+// the tracer, and an optional tracer_config field, the configuration of the tracer.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (g *GetEVMTracesCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	data := &getEVMTracesRequest{
+		config: debug.DefaultTracerConfig,
+	}
+
+	raw, ok := input["block_id"]
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("the block_id field is required")
+	}
+	errInvalidBlockID := admin.NewInvalidAdminReqParameterError("block_id", "expected a block ID represented as a 64 character long hex string", raw)
+	s, ok := raw.(string)
+	if !ok {
+		return errInvalidBlockID
+	}
+	blockID, err := flow.HexStringToIdentifier(s)
+	if err != nil {
+		return errInvalidBlockID
+	}
+	data.blockID = blockID
+
+	if raw, ok := input["tx_hash"]; ok {
+		errInvalidTxHash := admin.NewInvalidAdminReqParameterError("tx_hash", "expected a 0x prefixed 32 bytes hex string", raw)
+		s, ok := raw.(string)
+		if !ok {
+			return errInvalidTxHash
+		}
+		var txHash gethCommon.Hash
+		err := txHash.UnmarshalText([]byte(s))
+		if err != nil {
+			return errInvalidTxHash
+		}
+		data.txHash = &txHash
+	}
+
+	rawTracer, hasTracer := input["tracer"]
+	rawConfig, hasConfig := input["tracer_config"]
+	if hasTracer || hasConfig {
+		data.config = debug.TracerConfig{}
+
+		if hasTracer {
+			tracer, ok := rawTracer.(string)
+			if !ok {
+				return admin.NewInvalidAdminReqParameterError("tracer", "expected a string", rawTracer)
+			}
+			data.config.Tracer = tracer
+		}
+
+		if hasConfig {
+			config, err := json.Marshal(rawConfig)
+			if err != nil {
+				return admin.NewInvalidAdminReqParameterError("tracer_config", "expected a JSON object", rawConfig)
+			}
+			data.config.Config = config
+		}
+
+		_, err := debug.NewTracer(data.config)
+		if err != nil {
+			return admin.NewInvalidAdminReqErrorf("invalid tracer: %v", err)
+		}
+	}
+
+	req.ValidatorData = data
+
+	return nil
+}
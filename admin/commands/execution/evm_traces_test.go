@@ -0,0 +1,149 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	statemock "github.com/onflow/flow-go/engine/execution/state/mock"
+	"github.com/onflow/flow-go/fvm/evm/debug"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestGetEVMTracesParsing(t *testing.T) {
+	cmd := NewGetEVMTracesCommand(flow.Testnet, nil, nil, nil, nil)
+	blockID := unittest.IdentifierFixture()
+	txHash := gethCommon.HexToHash("0x01")
+
+	t.Run("block id", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{"block_id": blockID.String()},
+		}
+		require.NoError(t, cmd.Validator(req))
+		require.Equal(t, &getEVMTracesRequest{
+			blockID: blockID,
+			config:  debug.DefaultTracerConfig,
+		}, req.ValidatorData)
+	})
+
+	t.Run("tx hash and tracer", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{
+				"block_id":      blockID.String(),
+				"tx_hash":       txHash.Hex(),
+				"tracer":        debug.PrestateTracerName,
+				"tracer_config": map[string]interface{}{"diffMode": true},
+			},
+		}
+		require.NoError(t, cmd.Validator(req))
+		require.Equal(t, &getEVMTracesRequest{
+			blockID: blockID,
+			txHash:  &txHash,
+			config: debug.TracerConfig{
+				Tracer: debug.PrestateTracerName,
+				Config: json.RawMessage(`{"diffMode":true}`),
+			},
+		}, req.ValidatorData)
+	})
+
+	t.Run("missing block id", func(t *testing.T) {
+		for _, data := range []interface{}{nil, map[string]interface{}{}} {
+			req := &admin.CommandRequest{Data: data}
+			require.True(t, admin.IsInvalidAdminParameterError(cmd.Validator(req)))
+		}
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		for _, data := range []map[string]interface{}{
+			{"block_id": "abc"},
+			{"block_id": float64(1)},
+			{"block_id": blockID.String(), "tx_hash": "0x01"},
+			{"block_id": blockID.String(), "tx_hash": float64(1)},
+			{"block_id": blockID.String(), "tracer": float64(1)},
+		} {
+			req := &admin.CommandRequest{Data: data}
+			require.True(t, admin.IsInvalidAdminParameterError(cmd.Validator(req)))
+		}
+	})
+
+	t.Run("unsupported tracer", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{
+				"block_id": blockID.String(),
+				"tracer":   "unknown",
+			},
+		}
+		require.True(t, admin.IsInvalidAdminParameterError(cmd.Validator(req)))
+	})
+}
+
+func TestGetEVMTraces(t *testing.T) {
+	header := unittest.BlockHeaderFixture()
+	blockID := header.ID()
+	txHash := gethCommon.HexToHash("0x01")
+
+	t.Run("returns stored trace", func(t *testing.T) {
+		traces, err := debug.NewLocalStore(t.TempDir())
+		require.NoError(t, err)
+
+		trace := json.RawMessage(`{"type":"CALL"}`)
+		require.NoError(t, traces.Upload(debug.TraceID(txHash, blockID), trace))
+
+		cmd := NewGetEVMTracesCommand(flow.Testnet, nil, nil, nil, traces)
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{
+				"block_id": blockID.String(),
+				"tx_hash":  txHash.Hex(),
+			},
+		}
+		require.NoError(t, cmd.Validator(req))
+
+		result, err := cmd.Handler(context.Background(), req)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"txHash": txHash.Hex(),
+			"result": map[string]interface{}{"type": "CALL"},
+		}, result)
+	})
+
+	t.Run("returns no traces for blocks without EVM events", func(t *testing.T) {
+		headers := storagemock.NewHeaders(t)
+		headers.On("ByBlockID", blockID).Return(header, nil)
+		events := storagemock.NewEvents(t)
+		events.On("ByBlockID", blockID).Return(unittest.EventsFixture(2), nil)
+
+		cmd := NewGetEVMTracesCommand(flow.Testnet, headers, events, statemock.NewScriptExecutionState(t), nil)
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{"block_id": blockID.String()},
+		}
+		require.NoError(t, cmd.Validator(req))
+
+		result, err := cmd.Handler(context.Background(), req)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"block_id": blockID.String(),
+			"traces":   []interface{}{},
+		}, result)
+	})
+
+	t.Run("fails for unknown blocks", func(t *testing.T) {
+		headers := storagemock.NewHeaders(t)
+		headers.On("ByBlockID", blockID).Return(nil, storage.ErrNotFound)
+
+		cmd := NewGetEVMTracesCommand(flow.Testnet, headers, nil, nil, nil)
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{"block_id": blockID.String()},
+		}
+		require.NoError(t, cmd.Validator(req))
+
+		_, err := cmd.Handler(context.Background(), req)
+		require.True(t, admin.IsInvalidAdminParameterError(err))
+	})
+}
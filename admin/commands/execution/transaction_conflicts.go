@@ -0,0 +1,93 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+var _ commands.AdminCommand = (*GetTransactionConflictsCommand)(nil)
+
+// defaultConflictsTop is the default number of hot registers, contracts and transactions returned.
+const defaultConflictsTop = 10
+
+type getTransactionConflictsRequest struct {
+	blockID *flow.Identifier
+	top     int
+}
+
+// GetTransactionConflictsCommand returns the statistics of the conflicts between concurrently
+// executed transactions, to find the registers and contracts hurting parallel execution.
+type GetTransactionConflictsCommand struct {
+	tracker *computer.ConflictTracker
+}
+
+// NewGetTransactionConflictsCommand creates a new GetTransactionConflictsCommand object.
+func NewGetTransactionConflictsCommand(tracker *computer.ConflictTracker) *GetTransactionConflictsCommand {
+	return &GetTransactionConflictsCommand{
+		tracker: tracker,
+	}
+}
+
+// Handler returns the conflict statistics of the requested block, or of the recently executed
+// blocks if no block is requested.
+func (g *GetTransactionConflictsCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*getTransactionConflictsRequest)
+
+	if data.blockID == nil {
+		return commands.ConvertToMap(g.tracker.WindowStats(data.top))
+	}
+
+	stats, ok := g.tracker.BlockStats(*data.blockID, data.top)
+	if !ok {
+		return nil, admin.NewInvalidAdminReqErrorf("block %v is not within the recently executed blocks", *data.blockID)
+	}
+
+	return commands.ConvertToMap(stats)
+}
+
+// Validator validates the request.
+// It accepts an optional block_id field, the ID of the block to return the statistics of,
+// and an optional top field, the number of hot registers, contracts and transactions to return.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (g *GetTransactionConflictsCommand) Validator(req *admin.CommandRequest) error {
+	data := &getTransactionConflictsRequest{
+		top: defaultConflictsTop,
+	}
+	req.ValidatorData = data
+
+	if req.Data == nil {
+		return nil
+	}
+
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	if raw, ok := input["block_id"]; ok {
+		errInvalidBlockID := admin.NewInvalidAdminReqParameterError("block_id", "expected a block ID represented as a 64 character long hex string", raw)
+		s, ok := raw.(string)
+		if !ok {
+			return errInvalidBlockID
+		}
+		blockID, err := flow.HexStringToIdentifier(s)
+		if err != nil {
+			return errInvalidBlockID
+		}
+		data.blockID = &blockID
+	}
+
+	if raw, ok := input["top"]; ok {
+		top, ok := raw.(float64)
+		if !ok || top < 1 || top != float64(int(top)) {
+			return admin.NewInvalidAdminReqParameterError("top", "expected a positive integer", raw)
+		}
+		data.top = int(top)
+	}
+
+	return nil
+}
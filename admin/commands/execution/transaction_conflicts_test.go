@@ -0,0 +1,83 @@
+package execution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestGetTransactionConflictsParsing(t *testing.T) {
+	cmd := NewGetTransactionConflictsCommand(nil)
+
+	t.Run("defaults", func(t *testing.T) {
+		req := &admin.CommandRequest{}
+		require.NoError(t, cmd.Validator(req))
+		require.Equal(t, &getTransactionConflictsRequest{top: defaultConflictsTop}, req.ValidatorData)
+	})
+
+	t.Run("block id and top", func(t *testing.T) {
+		blockID := unittest.IdentifierFixture()
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{
+				"block_id": blockID.String(),
+				"top":      float64(3),
+			},
+		}
+		require.NoError(t, cmd.Validator(req))
+		require.Equal(t, &getTransactionConflictsRequest{blockID: &blockID, top: 3}, req.ValidatorData)
+	})
+
+	t.Run("invalid block id", func(t *testing.T) {
+		for _, value := range []interface{}{"abc", float64(1)} {
+			req := &admin.CommandRequest{
+				Data: map[string]interface{}{"block_id": value},
+			}
+			require.True(t, admin.IsInvalidAdminParameterError(cmd.Validator(req)))
+		}
+	})
+
+	t.Run("invalid top", func(t *testing.T) {
+		for _, value := range []interface{}{"1", float64(0), float64(-1), float64(1.5)} {
+			req := &admin.CommandRequest{
+				Data: map[string]interface{}{"top": value},
+			}
+			require.True(t, admin.IsInvalidAdminParameterError(cmd.Validator(req)))
+		}
+	})
+}
+
+func TestGetTransactionConflicts(t *testing.T) {
+	tracker := computer.NewConflictTracker(metrics.NewNoopCollector(), 10)
+	header := unittest.BlockHeaderFixture()
+	tracker.BlockExecuted(header, 5, nil)
+
+	cmd := NewGetTransactionConflictsCommand(tracker)
+
+	req := &admin.CommandRequest{}
+	require.NoError(t, cmd.Validator(req))
+	result, err := cmd.Handler(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, float64(1), result.(map[string]interface{})["blocks"])
+	require.Equal(t, float64(5), result.(map[string]interface{})["transactions"])
+
+	req = &admin.CommandRequest{
+		Data: map[string]interface{}{"block_id": header.ID().String()},
+	}
+	require.NoError(t, cmd.Validator(req))
+	result, err = cmd.Handler(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, float64(header.Height), result.(map[string]interface{})["from_height"])
+
+	req = &admin.CommandRequest{
+		Data: map[string]interface{}{"block_id": unittest.IdentifierFixture().String()},
+	}
+	require.NoError(t, cmd.Validator(req))
+	_, err = cmd.Handler(context.Background(), req)
+	require.True(t, admin.IsInvalidAdminParameterError(err))
+}
@@ -43,13 +43,17 @@ import (
 	"github.com/onflow/flow-go/consensus/hotstuff/verification"
 	recovery "github.com/onflow/flow-go/consensus/recovery/protocol"
 	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/access/evmrpc"
+	"github.com/onflow/flow-go/engine/access/graphql"
 	"github.com/onflow/flow-go/engine/access/index"
 	"github.com/onflow/flow-go/engine/access/ingestion"
 	"github.com/onflow/flow-go/engine/access/ingestion/tx_error_messages"
 	pingeng "github.com/onflow/flow-go/engine/access/ping"
+	accessproofs "github.com/onflow/flow-go/engine/access/proofs"
 	"github.com/onflow/flow-go/engine/access/rest"
 	commonrest "github.com/onflow/flow-go/engine/access/rest/common"
 	"github.com/onflow/flow-go/engine/access/rest/router"
+	"github.com/onflow/flow-go/engine/access/rest/websockets"
 	"github.com/onflow/flow-go/engine/access/rpc"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
 	rpcConnection "github.com/onflow/flow-go/engine/access/rpc/connection"
@@ -63,6 +67,8 @@ import (
 	synceng "github.com/onflow/flow-go/engine/common/synchronization"
 	"github.com/onflow/flow-go/engine/common/version"
 	"github.com/onflow/flow-go/engine/execution/computation/query"
+	"github.com/onflow/flow-go/fvm/evm"
+	"github.com/onflow/flow-go/fvm/evm/debug"
 	"github.com/onflow/flow-go/fvm/storage/derived"
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/complete/wal"
@@ -181,6 +187,12 @@ type AccessNodeConfig struct {
 	storeTxResultErrorMessages           bool
 	stopControlEnabled                   bool
 	registerDBPruneThreshold             uint64
+	registerDBPruningEnabled             bool
+	registerDBPruneThrottleDelay         time.Duration
+	registerDBPruneTickerInterval        time.Duration
+	accountTransactionsIndexingEnabled   bool
+	evmLogsIndexingEnabled               bool
+	evmTracesDir                         string
 }
 
 type PublicNetworkConfig struct {
@@ -226,7 +238,12 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 				ReadTimeout:    rest.DefaultReadTimeout,
 				IdleTimeout:    rest.DefaultIdleTimeout,
 				MaxRequestSize: commonrest.DefaultMaxRequestSize,
+
+				EnableWebSocketsStreamAPI: false,
+				WebSocketConfig:           websockets.NewDefaultWebsocketConfig(),
 			},
+			GraphQLConfig:  graphql.NewDefaultConfig(),
+			EVMRPCConfig:   evmrpc.NewDefaultConfig(),
 			MaxMsgSize:     grpcutils.DefaultMaxMsgSize,
 			CompressorName: grpcutils.NoCompressor,
 		},
@@ -240,6 +257,8 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 			RegisterIDsRequestLimit: state_stream.DefaultRegisterIDsRequestLimit,
 			ResponseLimit:           subscription.DefaultResponseLimit,
 			HeartbeatInterval:       subscription.DefaultHeartbeatInterval,
+			EventCursorTTL:          statestreambackend.DefaultEventCursorTTL,
+			MaxEventCursors:         statestreambackend.DefaultMaxEventCursors,
 		},
 		stateStreamFilterConf:        nil,
 		ExecutionNodeAddress:         "localhost:9000",
@@ -288,6 +307,12 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 		storeTxResultErrorMessages:           false,
 		stopControlEnabled:                   false,
 		registerDBPruneThreshold:             pruner.DefaultThreshold,
+		registerDBPruningEnabled:             false,
+		registerDBPruneThrottleDelay:         pstorage.DefaultPruneThrottleDelay,
+		registerDBPruneTickerInterval:        pstorage.DefaultPruneTickerInterval,
+		accountTransactionsIndexingEnabled:   false,
+		evmLogsIndexingEnabled:               false,
+		evmTracesDir:                         "",
 	}
 }
 
@@ -332,6 +357,10 @@ type FlowAccessNodeBuilder struct {
 	Reporter                     *index.Reporter
 	EventsIndex                  *index.EventsIndex
 	TxResultsIndex               *index.TransactionResultsIndex
+	AccountTransactions          storage.AccountTransactions
+	AccountTransactionsIndex     *index.AccountTransactionsIndex
+	EVMLogs                      storage.EVMLogs
+	EVMLogsIndex                 *index.EVMLogsIndex
 	IndexerDependencies          *cmd.DependencyList
 	collectionExecutedMetric     module.CollectionExecutedMetric
 	ExecutionDataPruner          *pruner.Pruner
@@ -362,6 +391,7 @@ type FlowAccessNodeBuilder struct {
 
 	ExecNodeIdentitiesProvider *commonrpc.ExecutionNodeIdentitiesProvider
 	TxResultErrorMessagesCore  *tx_error_messages.TxErrorMessagesCore
+	RegisterProofRequester     *accessproofs.Requester
 }
 
 func (builder *FlowAccessNodeBuilder) buildFollowerState() *FlowAccessNodeBuilder {
@@ -556,11 +586,15 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 	var execDataCacheBackend *herocache.BlockExecutionData
 	var executionDataStoreCache *execdatacache.ExecutionDataCache
 	var executionDataDBMode execution_data.ExecutionDataDBMode
+	var registers *pstorage.Registers
 
 	// setup dependency chain to ensure indexer starts after the requester
 	requesterDependable := module.NewProxiedReadyDoneAware()
 	builder.IndexerDependencies.Add(requesterDependable)
 
+	// setup dependency chain to ensure the register db pruner starts after the indexer
+	indexerDependable := module.NewProxiedReadyDoneAware()
+
 	executionDataPrunerEnabled := builder.executionDataPrunerHeightRangeTarget != 0
 
 	builder.
@@ -860,6 +894,18 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 				builder.Storage.LightTransactionResults = bstorage.NewLightTransactionResults(node.Metrics.Cache, node.DB, bstorage.DefaultCacheSize)
 				return nil
 			}).
+			Module("account transactions storage", func(node *cmd.NodeConfig) error {
+				if builder.accountTransactionsIndexingEnabled {
+					builder.AccountTransactions = bstorage.NewAccountTransactions(node.DB)
+				}
+				return nil
+			}).
+			Module("evm logs storage", func(node *cmd.NodeConfig) error {
+				if builder.evmLogsIndexingEnabled {
+					builder.EVMLogs = bstorage.NewEVMLogs(node.DB)
+				}
+				return nil
+			}).
 			DependableComponent("execution data indexer", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 				// Note: using a DependableComponent here to ensure that the indexer does not block
 				// other components from starting while bootstrapping the register db since it may
@@ -918,7 +964,7 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 					}
 				}
 
-				registers, err := pstorage.NewRegisters(pdb, builder.registerDBPruneThreshold)
+				registers, err = pstorage.NewRegisters(pdb, builder.registerDBPruneThreshold)
 				if err != nil {
 					return nil, fmt.Errorf("could not create registers storage: %w", err)
 				}
@@ -953,6 +999,8 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 					builder.Storage.Collections,
 					builder.Storage.Transactions,
 					builder.Storage.LightTransactionResults,
+					builder.AccountTransactions,
+					builder.EVMLogs,
 					builder.RootChainID.Chain(),
 					indexerDerivedChainData,
 					builder.collectionExecutedMetric,
@@ -1016,8 +1064,23 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 					builder.StopControl.RegisterHeightRecorder(builder.ExecutionIndexer)
 				}
 
+				indexerDependable.Init(builder.ExecutionIndexer)
+
 				return builder.ExecutionIndexer, nil
 			}, builder.IndexerDependencies)
+
+		if builder.registerDBPruningEnabled {
+			builder.DependableComponent("register db pruner", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+				return pstorage.NewRegisterPruner(
+					node.Logger,
+					registers,
+					metrics.NewRegisterDBPrunerCollector(),
+					pstorage.WithPruneThreshold(builder.registerDBPruneThreshold),
+					pstorage.WithPruneThrottleDelay(builder.registerDBPruneThrottleDelay),
+					pstorage.WithPruneTickerInterval(builder.registerDBPruneTickerInterval),
+				)
+			}, cmd.NewDependencyList(indexerDependable))
+		}
 	}
 
 	if builder.stateStreamConf.ListenAddr != "" {
@@ -1032,6 +1095,10 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 					builder.stateStreamConf.MaxContracts = value
 				case "AccountAddresses":
 					builder.stateStreamConf.MaxAccountAddress = value
+				case "Expressions":
+					builder.stateStreamConf.MaxExpressions = value
+				case "ExpressionConditions":
+					builder.stateStreamConf.MaxExpressionConditions = value
 				}
 			}
 			builder.stateStreamConf.RpcMetricsEnabled = builder.rpcMetricsEnabled
@@ -1083,6 +1150,11 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 					builder.stateStreamConf.ClientSendBufferSize,
 				),
 				executionDataTracker,
+				node.RootChainID.Chain(),
+				builder.stateStreamConf.EventFilterConfig,
+				bstorage.NewEventSubscriptionCursors(node.DB),
+				builder.stateStreamConf.EventCursorTTL,
+				builder.stateStreamConf.MaxEventCursors,
 			)
 			if err != nil {
 				return nil, fmt.Errorf("could not create state stream backend: %w", err)
@@ -1196,6 +1268,54 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"rest-max-request-size",
 			defaultConfig.rpcConf.RestConfig.MaxRequestSize,
 			"the maximum request size in bytes for payload sent over REST server")
+		flags.BoolVar(&builder.rpcConf.RestConfig.EnableWebSocketsStreamAPI,
+			"experimental-enable-websockets-stream-api",
+			defaultConfig.rpcConf.RestConfig.EnableWebSocketsStreamAPI,
+			"[experimental] enables the multiplexed websocket stream API served at /v1/ws on the REST server")
+		flags.Uint64Var(&builder.rpcConf.RestConfig.WebSocketConfig.MaxSubscriptionsPerConnection,
+			"websocket-max-subscriptions-per-connection",
+			defaultConfig.rpcConf.RestConfig.WebSocketConfig.MaxSubscriptionsPerConnection,
+			"maximum number of active subscriptions on a single websocket stream API connection")
+		flags.Uint64Var(&builder.rpcConf.RestConfig.WebSocketConfig.MaxConnections,
+			"websocket-max-connections",
+			defaultConfig.rpcConf.RestConfig.WebSocketConfig.MaxConnections,
+			"maximum number of concurrent websocket stream API connections")
+		flags.UintVar(&builder.rpcConf.RestConfig.WebSocketConfig.SendBufferSize,
+			"websocket-send-buffer-size",
+			defaultConfig.rpcConf.RestConfig.WebSocketConfig.SendBufferSize,
+			"number of messages buffered per websocket stream API connection")
+		flags.StringVar(&builder.rpcConf.GraphQLConfig.ListenAddress,
+			"graphql-addr",
+			defaultConfig.rpcConf.GraphQLConfig.ListenAddress,
+			"the address the GraphQL server listens on (if empty the GraphQL server will not be started)")
+		flags.Int64Var(&builder.rpcConf.GraphQLConfig.MaxRequestSize,
+			"graphql-max-request-size",
+			defaultConfig.rpcConf.GraphQLConfig.MaxRequestSize,
+			"the maximum request size in bytes for requests sent to the GraphQL server")
+		flags.Uint64Var(&builder.rpcConf.GraphQLConfig.MaxQueryCost,
+			"graphql-max-query-cost",
+			defaultConfig.rpcConf.GraphQLConfig.MaxQueryCost,
+			"the maximum estimated cost of a GraphQL query. every object returned costs 1")
+		flags.Uint64Var(&builder.rpcConf.GraphQLConfig.DefaultListSize,
+			"graphql-default-list-size",
+			defaultConfig.rpcConf.GraphQLConfig.DefaultListSize,
+			"the number of items assumed for GraphQL list fields of unknown size when estimating the cost of a query")
+		flags.Uint64Var(&builder.rpcConf.GraphQLConfig.MaxSubscriptionsPerConnection,
+			"graphql-max-subscriptions-per-connection",
+			defaultConfig.rpcConf.GraphQLConfig.MaxSubscriptionsPerConnection,
+			"maximum number of active subscriptions on a single GraphQL websocket connection")
+		flags.StringVar(&builder.rpcConf.EVMRPCConfig.ListenAddress,
+			"evm-rpc-addr",
+			defaultConfig.rpcConf.EVMRPCConfig.ListenAddress,
+			"the address the EVM JSON-RPC server listens on (if empty the EVM JSON-RPC server will not be started). requires execution-data-indexing-enabled")
+		flags.Int64Var(&builder.rpcConf.EVMRPCConfig.MaxRequestSize,
+			"evm-rpc-max-request-size",
+			defaultConfig.rpcConf.EVMRPCConfig.MaxRequestSize,
+			"the maximum request size in bytes for requests sent to the EVM JSON-RPC server")
+		flags.Uint64Var(&builder.rpcConf.EVMRPCConfig.MaxCallGasLimit,
+			"evm-rpc-max-call-gas-limit",
+			defaultConfig.rpcConf.EVMRPCConfig.MaxCallGasLimit,
+			"the maximum gas limit of calls executed by eth_call and eth_estimateGas on the EVM JSON-RPC server")
 		flags.StringVarP(&builder.rpcConf.CollectionAddr,
 			"static-collection-ingress-addr",
 			"",
@@ -1371,6 +1491,14 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"state-stream-heartbeat-interval",
 			defaultConfig.stateStreamConf.HeartbeatInterval,
 			"default interval in blocks at which heartbeat messages should be sent. applied when client did not specify a value.")
+		flags.DurationVar(&builder.stateStreamConf.EventCursorTTL,
+			"state-stream-event-cursor-ttl",
+			defaultConfig.stateStreamConf.EventCursorTTL,
+			"duration after which event subscription cursors which are not used are removed")
+		flags.UintVar(&builder.stateStreamConf.MaxEventCursors,
+			"state-stream-max-event-cursors",
+			defaultConfig.stateStreamConf.MaxEventCursors,
+			"max number of stored event subscription cursors")
 		flags.Uint32Var(&builder.stateStreamConf.RegisterIDsRequestLimit,
 			"state-stream-max-register-values",
 			defaultConfig.stateStreamConf.RegisterIDsRequestLimit,
@@ -1381,6 +1509,18 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"execution-data-indexing-enabled",
 			defaultConfig.executionDataIndexingEnabled,
 			"whether to enable the execution data indexing")
+		flags.BoolVar(&builder.accountTransactionsIndexingEnabled,
+			"account-transactions-indexing-enabled",
+			defaultConfig.accountTransactionsIndexingEnabled,
+			"whether to index the transactions which involved each account. requires execution-data-indexing-enabled")
+		flags.BoolVar(&builder.evmLogsIndexingEnabled,
+			"evm-logs-indexing-enabled",
+			defaultConfig.evmLogsIndexingEnabled,
+			"whether to index the logs emitted by EVM transactions. requires execution-data-indexing-enabled")
+		flags.StringVar(&builder.evmTracesDir,
+			"evm-traces-dir",
+			defaultConfig.evmTracesDir,
+			"directory to cache the EVM traces produced by the EVM debug tracing API. traces are not cached if empty")
 		flags.StringVar(&builder.registersDBPath, "execution-state-dir", defaultConfig.registersDBPath, "directory to use for execution-state database")
 		flags.StringVar(&builder.checkpointFile, "execution-state-checkpoint", defaultConfig.checkpointFile, "execution-state checkpoint file")
 
@@ -1418,6 +1558,22 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"script-execution-timeout",
 			defaultConfig.scriptExecutorConfig.ExecutionTimeLimit,
 			"timeout value for locally executed scripts. default: 10s")
+		flags.Uint64Var(&builder.scriptExecutorConfig.FeeEstimationComputationLimit,
+			"fee-estimation-computation-limit",
+			defaultConfig.scriptExecutorConfig.FeeEstimationComputationLimit,
+			"maximum number of computation units a transaction can use when estimating its fees. default: 100000")
+		flags.Uint64Var(&builder.scriptExecutorConfig.BatchComputationLimit,
+			"script-execution-batch-computation-limit",
+			defaultConfig.scriptExecutorConfig.BatchComputationLimit,
+			"maximum number of computation units all scripts of a batch can use in total. default: 1000000")
+		flags.Uint64Var(&builder.scriptExecutorConfig.BatchMemoryLimit,
+			"script-execution-batch-memory-limit",
+			defaultConfig.scriptExecutorConfig.BatchMemoryLimit,
+			"maximum amount of memory in bytes all scripts of a batch can use in total. default: 2147483648")
+		flags.IntVar(&builder.scriptExecutorConfig.BatchParallelism,
+			"script-execution-batch-parallelism",
+			defaultConfig.scriptExecutorConfig.BatchParallelism,
+			"maximum number of scripts of a batch executed concurrently. default: 8")
 		flags.Uint64Var(&builder.scriptExecMinBlock,
 			"script-execution-min-height",
 			defaultConfig.scriptExecMinBlock,
@@ -1450,6 +1606,18 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"registerdb-pruning-threshold",
 			defaultConfig.registerDBPruneThreshold,
 			fmt.Sprintf("specifies the number of blocks below the latest stored block height to keep in register db. default: %d", defaultConfig.registerDBPruneThreshold))
+		flags.BoolVar(&builder.registerDBPruningEnabled,
+			"registerdb-pruning-enabled",
+			defaultConfig.registerDBPruningEnabled,
+			"whether to enable the register db pruner, which deletes register versions below the pruning threshold. default: false")
+		flags.DurationVar(&builder.registerDBPruneThrottleDelay,
+			"registerdb-prune-throttle-delay",
+			defaultConfig.registerDBPruneThrottleDelay,
+			"delay between batches of register versions deleted by the register db pruner. default: 10ms")
+		flags.DurationVar(&builder.registerDBPruneTickerInterval,
+			"registerdb-prune-ticker-interval",
+			defaultConfig.registerDBPruneTickerInterval,
+			"interval at which the register db pruner checks whether pruning is needed. default: 10m")
 	}).ValidateFlags(func() error {
 		if builder.supportsObserver && (builder.PublicNetworkConfig.BindAddress == cmd.NotSet || builder.PublicNetworkConfig.BindAddress == "") {
 			return errors.New("public-network-address must be set if supports-observer is true")
@@ -1471,6 +1639,20 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 				return errors.New("execution-data-max-search-ahead must be greater than 0")
 			}
 		}
+		if builder.registerDBPruningEnabled {
+			if !builder.executionDataIndexingEnabled {
+				return errors.New("execution-data-indexing-enabled must be set if registerdb-pruning-enabled is true")
+			}
+			if builder.registerDBPruneThreshold == 0 {
+				return errors.New("registerdb-pruning-threshold must be greater than 0 if registerdb-pruning-enabled is true")
+			}
+		}
+		if builder.accountTransactionsIndexingEnabled && !builder.executionDataIndexingEnabled {
+			return errors.New("execution-data-indexing-enabled must be set if account-transactions-indexing-enabled is true")
+		}
+		if builder.evmLogsIndexingEnabled && !builder.executionDataIndexingEnabled {
+			return errors.New("execution-data-indexing-enabled must be set if evm-logs-indexing-enabled is true")
+		}
 		if builder.stateStreamConf.ListenAddr != "" {
 			if builder.stateStreamConf.ExecutionDataCacheSize == 0 {
 				return errors.New("execution-data-cache-size must be greater than 0")
@@ -1478,17 +1660,17 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			if builder.stateStreamConf.ClientSendBufferSize == 0 {
 				return errors.New("state-stream-send-buffer-size must be greater than 0")
 			}
-			if len(builder.stateStreamFilterConf) > 4 {
-				return errors.New("state-stream-event-filter-limits must have at most 3 keys (EventTypes, Addresses, Contracts, AccountAddresses)")
+			if len(builder.stateStreamFilterConf) > 6 {
+				return errors.New("state-stream-event-filter-limits must have at most 6 keys (EventTypes, Addresses, Contracts, AccountAddresses, Expressions, ExpressionConditions)")
 			}
 			for key, value := range builder.stateStreamFilterConf {
 				switch key {
-				case "EventTypes", "Addresses", "Contracts", "AccountAddresses":
+				case "EventTypes", "Addresses", "Contracts", "AccountAddresses", "Expressions", "ExpressionConditions":
 					if value <= 0 {
 						return fmt.Errorf("state-stream-event-filter-limits %s must be greater than 0", key)
 					}
 				default:
-					return errors.New("state-stream-event-filter-limits may only contain the keys EventTypes, Addresses, Contracts, AccountAddresses")
+					return errors.New("state-stream-event-filter-limits may only contain the keys EventTypes, Addresses, Contracts, AccountAddresses, Expressions, ExpressionConditions")
 				}
 			}
 			if builder.stateStreamConf.ResponseLimit < 0 {
@@ -1517,6 +1699,36 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 		if builder.rpcConf.RestConfig.MaxRequestSize <= 0 {
 			return errors.New("rest-max-request-size must be greater than 0")
 		}
+		if builder.rpcConf.GraphQLConfig.ListenAddress != "" {
+			if builder.rpcConf.GraphQLConfig.MaxRequestSize <= 0 {
+				return errors.New("graphql-max-request-size must be greater than 0")
+			}
+			if builder.rpcConf.GraphQLConfig.MaxQueryCost == 0 {
+				return errors.New("graphql-max-query-cost must be greater than 0")
+			}
+			if builder.rpcConf.GraphQLConfig.MaxSubscriptionsPerConnection == 0 {
+				return errors.New("graphql-max-subscriptions-per-connection must be greater than 0")
+			}
+		}
+		if builder.rpcConf.EVMRPCConfig.ListenAddress != "" {
+			if !builder.executionDataIndexingEnabled {
+				return errors.New("execution-data-indexing-enabled must be set if evm-rpc-addr is set")
+			}
+			if builder.rpcConf.EVMRPCConfig.MaxRequestSize <= 0 {
+				return errors.New("evm-rpc-max-request-size must be greater than 0")
+			}
+			if builder.rpcConf.EVMRPCConfig.MaxCallGasLimit == 0 {
+				return errors.New("evm-rpc-max-call-gas-limit must be greater than 0")
+			}
+		}
+		if builder.rpcConf.RestConfig.EnableWebSocketsStreamAPI {
+			if builder.rpcConf.RestConfig.WebSocketConfig.MaxSubscriptionsPerConnection == 0 {
+				return errors.New("websocket-max-subscriptions-per-connection must be greater than 0")
+			}
+			if builder.rpcConf.RestConfig.WebSocketConfig.MaxConnections == 0 {
+				return errors.New("websocket-max-connections must be greater than 0")
+			}
+		}
 
 		return nil
 	})
@@ -1829,6 +2041,18 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 			builder.TxResultsIndex = index.NewTransactionResultsIndex(builder.Reporter, builder.Storage.LightTransactionResults)
 			return nil
 		}).
+		Module("account transactions index", func(node *cmd.NodeConfig) error {
+			if builder.AccountTransactions != nil {
+				builder.AccountTransactionsIndex = index.NewAccountTransactionsIndex(builder.Reporter, builder.AccountTransactions)
+			}
+			return nil
+		}).
+		Module("evm logs index", func(node *cmd.NodeConfig) error {
+			if builder.EVMLogs != nil {
+				builder.EVMLogsIndex = index.NewEVMLogsIndex(builder.Reporter, builder.EVMLogs)
+			}
+			return nil
+		}).
 		Module("processed finalized block height consumer progress", func(node *cmd.NodeConfig) error {
 			processedFinalizedBlockHeight = bstorage.NewConsumerProgress(builder.DB, module.ConsumeProgressIngestionEngineBlockHeight)
 			return nil
@@ -1904,6 +2128,14 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 
 			return stopControl, nil
 		}).
+		Component("register proof requester", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			var err error
+			builder.RegisterProofRequester, err = accessproofs.New(node.Logger, node.EngineRegistry)
+			if err != nil {
+				return nil, fmt.Errorf("could not create register proof requester: %w", err)
+			}
+			return &module.NoopReadyDoneAware{}, nil
+		}).
 		Component("RPC engine", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 			config := builder.rpcConf
 			backendConfig := config.BackendConfig
@@ -1999,6 +2231,14 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 				fixedENIdentifiers,
 			)
 
+			var evmTracesStore *debug.LocalStore
+			if builder.evmTracesDir != "" {
+				evmTracesStore, err = debug.NewLocalStore(builder.evmTracesDir)
+				if err != nil {
+					return nil, fmt.Errorf("could not create EVM traces store: %w", err)
+				}
+			}
+
 			builder.nodeBackend, err = backend.New(backend.Params{
 				State:                 node.State,
 				CollectionRPC:         builder.CollectionRPC,
@@ -2034,10 +2274,16 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 				EventsIndex:                builder.EventsIndex,
 				TxResultQueryMode:          txResultQueryMode,
 				TxResultsIndex:             builder.TxResultsIndex,
+				AccountTransactionsIndex:   builder.AccountTransactionsIndex,
+				EVMLogsIndex:               builder.EVMLogsIndex,
+				RegistersAsyncStore:        builder.RegistersAsyncStore,
+				EVMTracesStore:             evmTracesStore,
 				LastFullBlockHeight:        lastFullBlockHeight,
 				IndexReporter:              indexReporter,
 				VersionControl:             builder.VersionControl,
 				ExecNodeIdentitiesProvider: builder.ExecNodeIdentitiesProvider,
+				Seals:                      node.Storage.Seals,
+				RegisterProofRequester:     builder.RegisterProofRequester,
 			})
 			if err != nil {
 				return nil, fmt.Errorf("could not initialize backend: %w", err)
@@ -2063,6 +2309,18 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 				return nil, err
 			}
 
+			if builder.executionDataIndexingEnabled {
+				engineBuilder.WithEVMBackend(evmrpc.NewBackend(
+					node.RootChainID,
+					evm.StorageAccountAddress(node.RootChainID),
+					node.Storage.Headers,
+					builder.RegistersAsyncStore,
+					builder.EventsIndex,
+					builder.Reporter,
+					builder.rpcConf.EVMRPCConfig.MaxCallGasLimit,
+				))
+			}
+
 			builder.RpcEng, err = engineBuilder.
 				WithLegacy().
 				WithBlockSignerDecoder(signature.NewBlockSignerDecoder(builder.Committee)).
@@ -25,6 +25,7 @@ import (
 	"github.com/shirou/gopsutil/v3/host"
 	"github.com/shirou/gopsutil/v3/mem"
 	"go.uber.org/atomic"
+	"golang.org/x/time/rate"
 
 	"github.com/onflow/flow-go/admin/commands"
 	executionCommands "github.com/onflow/flow-go/admin/commands/execution"
@@ -46,19 +47,23 @@ import (
 	"github.com/onflow/flow-go/engine/common/provider"
 	"github.com/onflow/flow-go/engine/common/requester"
 	"github.com/onflow/flow-go/engine/common/synchronization"
+	"github.com/onflow/flow-go/engine/execution"
 	"github.com/onflow/flow-go/engine/execution/checker"
 	"github.com/onflow/flow-go/engine/execution/computation"
 	"github.com/onflow/flow-go/engine/execution/computation/committer"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
 	txmetrics "github.com/onflow/flow-go/engine/execution/computation/metrics"
 	"github.com/onflow/flow-go/engine/execution/ingestion"
 	"github.com/onflow/flow-go/engine/execution/ingestion/fetcher"
 	"github.com/onflow/flow-go/engine/execution/ingestion/stop"
 	"github.com/onflow/flow-go/engine/execution/ingestion/uploader"
+	"github.com/onflow/flow-go/engine/execution/proofs"
 	exeprovider "github.com/onflow/flow-go/engine/execution/provider"
 	"github.com/onflow/flow-go/engine/execution/rpc"
 	"github.com/onflow/flow-go/engine/execution/scripts"
 	"github.com/onflow/flow-go/engine/execution/state"
 	"github.com/onflow/flow-go/engine/execution/state/bootstrap"
+	"github.com/onflow/flow-go/engine/execution/statesync"
 	"github.com/onflow/flow-go/engine/execution/storehouse"
 	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/fvm/evm/debug"
@@ -132,19 +137,23 @@ type ExecutionNode struct {
 	followerState          protocol.FollowerState
 	committee              hotstuff.DynamicCommittee
 	ledgerStorage          *ledger.Ledger
-	registerStore          *storehouse.RegisterStore
+	registerStore          execution.RegisterStore
+	registerPrefetcher     execution.RegisterPrefetcher
 	events                 *storage.Events
 	serviceEvents          *storage.ServiceEvents
 	txResults              *storage.TransactionResults
 	results                *storage.ExecutionResults
 	myReceipts             *storage.MyExecutionReceipts
 	providerEngine         exeprovider.ProviderEngine
+	checkerCore            *checker.Core
 	checkerEng             *checker.Engine
 	syncCore               *chainsync.Core
 	syncEngine             *synchronization.Engine
 	followerCore           *hotstuff.FollowerLoop        // follower hotstuff logic
 	followerEng            *followereng.ComplianceEngine // to sync blocks from consensus nodes
 	computationManager     *computation.Manager
+	conflictTracker        *computer.ConflictTracker
+	executionProfiler      *computer.ExecutionProfiler // nil unless execution profiling is enabled
 	collectionRequester    ingestion.CollectionRequester
 	scriptsEng             *scripts.Engine
 	followerDistributor    *pubsub.FollowerDistributor
@@ -161,6 +170,8 @@ type ExecutionNode struct {
 	blobService            network.BlobService
 	blobserviceDependable  *module.ProxiedReadyDoneAware
 	metricsProvider        txmetrics.TransactionExecutionMetricsProvider
+	evmTraces              *debug.LocalStore // locally stored EVM traces, if enabled
+	syncRootExecutionState bool              // sync the root execution state from peers, since there is no root checkpoint
 }
 
 func (builder *ExecutionNodeBuilder) LoadComponentsAndModules() {
@@ -170,6 +181,7 @@ func (builder *ExecutionNodeBuilder) LoadComponentsAndModules() {
 		exeConf:             builder.exeConf,
 		toTriggerCheckpoint: atomic.NewBool(false),
 		ingestionUnit:       engine.NewUnit(),
+		registerPrefetcher:  storehouse.NewNoopRegisterPrefetcher(),
 	}
 
 	builder.FlowNodeBuilder.
@@ -182,6 +194,21 @@ func (builder *ExecutionNodeBuilder) LoadComponentsAndModules() {
 		AdminCommand("stop-at-height", func(config *NodeConfig) commands.AdminCommand {
 			return executionCommands.NewStopAtHeightCommand(exeNode.stopControl)
 		}).
+		AdminCommand("get-divergence-report", func(config *NodeConfig) commands.AdminCommand {
+			return executionCommands.NewGetDivergenceReportCommand(exeNode.checkerCore)
+		}).
+		AdminCommand("get-transaction-conflicts", func(config *NodeConfig) commands.AdminCommand {
+			return executionCommands.NewGetTransactionConflictsCommand(exeNode.conflictTracker)
+		}).
+		AdminCommand("get-evm-traces", func(config *NodeConfig) commands.AdminCommand {
+			return executionCommands.NewGetEVMTracesCommand(
+				config.RootChainID,
+				config.Storage.Headers,
+				exeNode.events,
+				exeNode.executionState,
+				exeNode.evmTraces,
+			)
+		}).
 		AdminCommand("set-uploader-enabled", func(config *NodeConfig) commands.AdminCommand {
 			return uploaderCommands.NewToggleUploaderCommand(exeNode.blockDataUploader)
 		}).
@@ -209,6 +236,7 @@ func (builder *ExecutionNodeBuilder) LoadComponentsAndModules() {
 		Module("blobservice peer manager dependencies", exeNode.LoadBlobservicePeerManagerDependencies).
 		Module("bootstrap", exeNode.LoadBootstrapper).
 		Module("register store", exeNode.LoadRegisterStore).
+		Component("execution state bootstrapper", exeNode.LoadExecutionStateBootstrapper).
 		Component("execution state ledger", exeNode.LoadExecutionStateLedger).
 
 		// TODO: Modules should be able to depends on components
@@ -230,8 +258,10 @@ func (builder *ExecutionNodeBuilder) LoadComponentsAndModules() {
 		Component("GCP block data uploader", exeNode.LoadGCPBlockDataUploader).
 		Component("S3 block data uploader", exeNode.LoadS3BlockDataUploader).
 		Component("transaction execution metrics", exeNode.LoadTransactionExecutionMetrics).
+		Component("execution profiler", exeNode.LoadExecutionProfiler).
 		Component("provider engine", exeNode.LoadProviderEngine).
 		Component("checker engine", exeNode.LoadCheckerEngine).
+		Component("register prefetcher", exeNode.LoadRegisterPrefetcher).
 		Component("ingestion engine", exeNode.LoadIngestionEngine).
 		Component("scripts engine", exeNode.LoadScriptsEngine).
 		Component("consensus committee", exeNode.LoadConsensusCommittee).
@@ -239,6 +269,8 @@ func (builder *ExecutionNodeBuilder) LoadComponentsAndModules() {
 		Component("follower engine", exeNode.LoadFollowerEngine).
 		Component("collection requester engine", exeNode.LoadCollectionRequesterEngine).
 		Component("receipt provider engine", exeNode.LoadReceiptProviderEngine).
+		Component("execution state sync engine", exeNode.LoadStateSyncEngine).
+		Component("register proofs engine", exeNode.LoadRegisterProofsEngine).
 		Component("synchronization engine", exeNode.LoadSynchronizationEngine).
 		Component("grpc server", exeNode.LoadGrpcServer)
 }
@@ -536,6 +568,13 @@ func (exeNode *ExecutionNode) LoadProviderEngine(
 				return nil, fmt.Errorf("could not create evm trace uploader: %w", err)
 			}
 		}
+		if len(exeNode.exeConf.evmTracesDir) > 0 {
+			exeNode.evmTraces, err = debug.NewLocalStore(exeNode.exeConf.evmTracesDir)
+			if err != nil {
+				return nil, fmt.Errorf("could not create evm trace store: %w", err)
+			}
+			evmTraceUploader = exeNode.evmTraces
+		}
 		evmTracer, err := debug.NewEVMCallTracer(evmTraceUploader, node.Logger)
 		if err != nil {
 			return nil, fmt.Errorf("could not create evm tracer: %w", err)
@@ -563,6 +602,11 @@ func (exeNode *ExecutionNode) LoadProviderEngine(
 			})
 	}
 
+	exeNode.conflictTracker = computer.NewConflictTracker(exeNode.collector, exeNode.exeConf.conflictWindowSize)
+	exeNode.exeConf.computationConfig.ConflictTracker = exeNode.conflictTracker
+
+	exeNode.exeConf.computationConfig.ExecutionProfiler = exeNode.executionProfiler
+
 	ledgerViewCommitter := committer.NewLedgerViewCommitter(exeNode.ledgerStorage, node.Tracer)
 	manager, err := computation.New(
 		node.Logger,
@@ -912,9 +956,41 @@ func (exeNode *ExecutionNode) LoadRegisterStore(
 	}
 
 	exeNode.registerStore = registerStore
+
+	if exeNode.exeConf.enableRegisterPrefetcher {
+		prefetcher, err := storehouse.NewRegisterPrefetcher(
+			registerStore,
+			node.Logger,
+			exeNode.collector,
+			exeNode.exeConf.registerPrefetchWorkers,
+		)
+		if err != nil {
+			return fmt.Errorf("could not create register prefetcher: %w", err)
+		}
+
+		// block execution reads the registers through the prefetcher
+		exeNode.registerStore = prefetcher
+		exeNode.registerPrefetcher = prefetcher
+	}
+
 	return nil
 }
 
+func (exeNode *ExecutionNode) LoadRegisterPrefetcher(
+	node *NodeConfig,
+) (
+	module.ReadyDoneAware,
+	error,
+) {
+	prefetcher, ok := exeNode.registerPrefetcher.(*storehouse.RegisterPrefetcher)
+	if !ok {
+		return &module.NoopReadyDoneAware{}, nil
+	}
+
+	// the prefetcher is created with the register store, and started before the ingestion engine uses it
+	return prefetcher, nil
+}
+
 func (exeNode *ExecutionNode) LoadExecutionStateLedger(
 	node *NodeConfig,
 ) (
@@ -941,7 +1017,7 @@ func (exeNode *ExecutionNode) LoadExecutionStateLedgerWALCompactor(
 	module.ReadyDoneAware,
 	error,
 ) {
-	return ledger.NewCompactor(
+	compactor, err := ledger.NewCompactor(
 		exeNode.ledgerStorage,
 		exeNode.diskWAL,
 		node.Logger.With().Str("subcomponent", "checkpointer").Logger(),
@@ -951,6 +1027,16 @@ func (exeNode *ExecutionNode) LoadExecutionStateLedgerWALCompactor(
 		exeNode.toTriggerCheckpoint, // compactor will listen to the signal from admin tool for force triggering checkpointing
 		exeNode.collector,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	compactor.SetDeltaCheckpointPolicy(ledger.DeltaCheckpointPolicy{
+		MaxDeltas:         exeNode.exeConf.checkpointMaxDeltas,
+		MaxDeltaSizeRatio: exeNode.exeConf.checkpointMaxDeltaSizeRatio,
+	})
+
+	return compactor, nil
 }
 
 func (exeNode *ExecutionNode) LoadExecutionDataPruner(
@@ -1014,12 +1100,17 @@ func (exeNode *ExecutionNode) LoadCheckerEngine(
 
 	node.Logger.Info().Msgf("checker engine is enabled")
 
-	core := checker.NewCore(
+	exeNode.checkerCore = checker.NewCore(
 		node.Logger,
 		node.State,
 		exeNode.executionState,
+		node.Storage.Seals,
+		exeNode.results,
+		exeNode.events,
+		exeNode.txResults,
+		exeNode.exeConf.checkerReportDir,
 	)
-	exeNode.checkerEng = checker.NewEngine(core)
+	exeNode.checkerEng = checker.NewEngine(exeNode.checkerCore)
 	return exeNode.checkerEng, nil
 }
 
@@ -1095,6 +1186,7 @@ func (exeNode *ExecutionNode) LoadIngestionEngine(
 		exeNode.providerEngine,
 		exeNode.blockDataUploader,
 		exeNode.stopControl,
+		exeNode.registerPrefetcher,
 	)
 
 	return core, err
@@ -1130,6 +1222,26 @@ func (exeNode *ExecutionNode) LoadTransactionExecutionMetrics(
 	return metricsProvider, nil
 }
 
+func (exeNode *ExecutionNode) LoadExecutionProfiler(
+	node *NodeConfig,
+) (module.ReadyDoneAware, error) {
+	if !exeNode.exeConf.executionProfilingEnabled {
+		return &module.NoopReadyDoneAware{}, nil
+	}
+
+	profiler, err := computer.NewExecutionProfiler(
+		node.Logger,
+		exeNode.exeConf.executionProfileDir,
+		exeNode.exeConf.executionProfileDirMaxBytes,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create execution profiler: %w", err)
+	}
+
+	exeNode.executionProfiler = profiler
+	return profiler, nil
+}
+
 func (exeNode *ExecutionNode) LoadConsensusCommittee(
 	node *NodeConfig,
 ) (
@@ -1283,6 +1395,65 @@ func (exeNode *ExecutionNode) LoadReceiptProviderEngine(
 	return eng, err
 }
 
+func (exeNode *ExecutionNode) LoadStateSyncEngine(
+	node *NodeConfig,
+) (
+	module.ReadyDoneAware,
+	error,
+) {
+	if !exeNode.exeConf.enableStateSyncServing || node.ObserverMode {
+		return &module.NoopReadyDoneAware{}, nil
+	}
+
+	node.Logger.Info().Msgf("serving execution state to bootstrapping execution nodes is enabled")
+
+	checkpoints, err := statesync.NewLedgerCheckpoints(
+		node.Logger,
+		exeNode.ledgerStorage,
+		exeNode.exeConf.stateSyncDir,
+		exeNode.exeConf.stateSyncMaxCheckpoints,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create state sync checkpoints: %w", err)
+	}
+
+	config := statesync.DefaultConfig()
+	config.ChunkSize = exeNode.exeConf.stateSyncChunkSize
+	config.ServeRateLimit = rate.Limit(exeNode.exeConf.stateSyncServeRateLimit)
+	config.ServeWorkers = exeNode.exeConf.stateSyncServeWorkers
+	config.BuildRateLimit = rate.Every(exeNode.exeConf.stateSyncBuildInterval)
+
+	return statesync.New(
+		node.Logger,
+		node.EngineRegistry,
+		node.Me,
+		node.State,
+		node.Storage.Seals,
+		checkpoints,
+		config,
+	)
+}
+
+func (exeNode *ExecutionNode) LoadRegisterProofsEngine(
+	node *NodeConfig,
+) (
+	module.ReadyDoneAware,
+	error,
+) {
+	engineRegister := node.EngineRegistry
+	if node.ObserverMode {
+		engineRegister = &underlay.NoopEngineRegister{}
+	}
+
+	return proofs.New(
+		node.Logger,
+		engineRegister,
+		exeNode.ledgerStorage,
+		exeNode.exeConf.registerProofWorkers,
+		proofs.DefaultRequestQueueCapacity,
+	)
+}
+
 func (exeNode *ExecutionNode) LoadSynchronizationEngine(
 	node *NodeConfig,
 ) (
@@ -1352,6 +1523,16 @@ func (exeNode *ExecutionNode) LoadBootstrapper(node *NodeConfig) error {
 	// if the execution database does not exist, then we need to bootstrap the execution database.
 	if !bootstrapped {
 
+		if exeNode.exeConf.enableStateSyncBootstrap {
+			_, err := os.Stat(path.Join(node.BootstrapDir, bootstrapFilenames.PathRootCheckpoint))
+			if errors.Is(err, os.ErrNotExist) {
+				return exeNode.bootstrapWithoutRootCheckpoint(node, bootstrapper)
+			}
+			if err != nil {
+				return fmt.Errorf("could not check root checkpoint: %w", err)
+			}
+		}
+
 		err := wal.CheckpointHasRootHash(
 			node.Logger,
 			path.Join(node.BootstrapDir, bootstrapFilenames.DirnameExecutionState),
@@ -1386,6 +1567,70 @@ func (exeNode *ExecutionNode) LoadBootstrapper(node *NodeConfig) error {
 	return nil
 }
 
+// bootstrapWithoutRootCheckpoint bootstraps the execution database of a node whose bootstrap folder
+// has no root checkpoint. The root execution state is synced from peers by the execution state
+// bootstrapper, which bootstraps the execution database once the root checkpoint is in the trie
+// folder. If the root execution state was synced before a restart, the execution database is
+// bootstrapped right away.
+func (exeNode *ExecutionNode) bootstrapWithoutRootCheckpoint(node *NodeConfig, bootstrapper *bootstrap.Bootstrapper) error {
+	err := wal.CheckpointHasRootHash(
+		node.Logger,
+		exeNode.exeConf.triedir,
+		bootstrapFilenames.FilenameWALRootCheckpoint,
+		ledgerpkg.RootHash(node.RootSeal.FinalState),
+	)
+	if err == nil {
+		node.Logger.Info().Msg("root execution state was synced from execution nodes")
+		err = bootstrapper.BootstrapExecutionDatabase(node.DB, node.RootSeal)
+		if err != nil {
+			return fmt.Errorf("could not bootstrap execution database: %w", err)
+		}
+		return nil
+	}
+
+	// the register store is bootstrapped from the root checkpoint before components are started
+	if exeNode.exeConf.enableStorehouse {
+		return fmt.Errorf("syncing the root execution state is not supported with storehouse enabled")
+	}
+
+	node.Logger.Info().Msg("no root checkpoint, root execution state will be synced from execution nodes")
+	exeNode.syncRootExecutionState = true
+	return nil
+}
+
+// LoadExecutionStateBootstrapper syncs the root execution state from peers, if the node is bootstrapped
+// without a root checkpoint. It's ready once the execution database is bootstrapped, so it must
+// be started before the components using the execution state.
+func (exeNode *ExecutionNode) LoadExecutionStateBootstrapper(
+	node *NodeConfig,
+) (
+	module.ReadyDoneAware,
+	error,
+) {
+	if !exeNode.syncRootExecutionState {
+		return &module.NoopReadyDoneAware{}, nil
+	}
+
+	config := statesync.DefaultConfig()
+	config.ChunkSize = exeNode.exeConf.stateSyncChunkSize
+
+	bootstrapper := bootstrap.NewBootstrapper(node.Logger)
+	return statesync.NewBootstrapper(
+		node.Logger,
+		node.EngineRegistry,
+		node.Me,
+		node.State,
+		config,
+		node.RootSeal,
+		exeNode.exeConf.stateSyncDir,
+		exeNode.exeConf.triedir,
+		bootstrapFilenames.FilenameWALRootCheckpoint,
+		func() error {
+			return bootstrapper.BootstrapExecutionDatabase(node.DB, node.RootSeal)
+		},
+	), nil
+}
+
 // getContractEpochCounter Gets the epoch counters from the FlowEpoch smart
 // contract from the snapshot provided.
 func getContractEpochCounter(
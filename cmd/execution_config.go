@@ -17,8 +17,12 @@ import (
 	"github.com/onflow/flow-go/utils/grpcutils"
 
 	"github.com/onflow/flow-go/engine/execution/computation"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
 	"github.com/onflow/flow-go/engine/execution/ingestion/stop"
+	"github.com/onflow/flow-go/engine/execution/proofs"
 	"github.com/onflow/flow-go/engine/execution/rpc"
+	"github.com/onflow/flow-go/engine/execution/statesync"
+	"github.com/onflow/flow-go/engine/execution/storehouse"
 	"github.com/onflow/flow-go/fvm/storage/derived"
 	storage "github.com/onflow/flow-go/storage/badger"
 )
@@ -33,6 +37,8 @@ type ExecutionConfig struct {
 	transactionResultsCacheSize           uint
 	checkpointDistance                    uint
 	checkpointsToKeep                     uint
+	checkpointMaxDeltas                   uint
+	checkpointMaxDeltaSizeRatio           float64
 	chunkDataPackDir                      string
 	chunkDataPackCacheSize                uint
 	chunkDataPackRequestsCacheSize        uint32
@@ -60,8 +66,15 @@ type ExecutionConfig struct {
 	// evm tracing configuration
 	evmTracingEnabled  bool
 	evmTracesGCPBucket string
+	evmTracesDir       string
+
+	// transaction execution profiling configuration
+	executionProfilingEnabled   bool
+	executionProfileDir         string
+	executionProfileDirMaxBytes uint64
 
 	computationConfig        computation.ComputationConfig
+	conflictWindowSize       int    // number of recent blocks the transaction conflict statistics are aggregated over
 	receiptRequestWorkers    uint   // common provider engine workers
 	receiptRequestsCacheSize uint32 // common provider engine cache size
 
@@ -72,7 +85,26 @@ type ExecutionConfig struct {
 	onflowOnlyLNs    bool
 	enableStorehouse bool
 	enableChecker    bool
+	// directory the checker writes divergence reports to
+	checkerReportDir string
 	publicAccessID   string
+
+	// prefetch the registers of blocks before their execution, requires the storehouse
+	enableRegisterPrefetcher bool
+	registerPrefetchWorkers  int
+
+	// serve the execution state to bootstrapping execution nodes
+	enableStateSyncServing   bool
+	enableStateSyncBootstrap bool
+	stateSyncDir             string
+	stateSyncChunkSize       uint64
+	stateSyncServeRateLimit  float64 // bytes per second served to a single node
+	stateSyncServeWorkers    uint
+	stateSyncBuildInterval   time.Duration
+	stateSyncMaxCheckpoints  uint
+
+	// serve register proofs to access nodes
+	registerProofWorkers uint
 }
 
 func (exeConf *ExecutionConfig) SetupFlags(flags *pflag.FlagSet) {
@@ -87,11 +119,14 @@ func (exeConf *ExecutionConfig) SetupFlags(flags *pflag.FlagSet) {
 	flags.Uint32Var(&exeConf.mTrieCacheSize, "mtrie-cache-size", 500, "cache size for MTrie")
 	flags.UintVar(&exeConf.checkpointDistance, "checkpoint-distance", 20, "number of WAL segments between checkpoints")
 	flags.UintVar(&exeConf.checkpointsToKeep, "checkpoints-to-keep", 5, "number of recent checkpoints to keep (0 to keep all)")
+	flags.UintVar(&exeConf.checkpointMaxDeltas, "checkpoint-max-deltas", 0, "max number of delta checkpoints created between full checkpoints (0 to disable delta checkpoints)")
+	flags.Float64Var(&exeConf.checkpointMaxDeltaSizeRatio, "checkpoint-max-delta-size-ratio", 0.5, "max total size of delta checkpoints relative to the size of the full checkpoint they are based on, before a new full checkpoint is created (0 for no limit)")
 	flags.UintVar(&exeConf.computationConfig.DerivedDataCacheSize, "cadence-execution-cache", derived.DefaultDerivedDataCacheSize,
 		"cache size for Cadence execution")
 	flags.BoolVar(&exeConf.computationConfig.ExtensiveTracing, "extensive-tracing", false, "adds high-overhead tracing to execution")
 	flags.BoolVar(&exeConf.computationConfig.CadenceTracing, "cadence-tracing", false, "enables cadence runtime level tracing")
 	flags.IntVar(&exeConf.computationConfig.MaxConcurrency, "computer-max-concurrency", 1, "set to greater than 1 to enable concurrent transaction execution")
+	flags.IntVar(&exeConf.conflictWindowSize, "transaction-conflict-window", computer.DefaultConflictWindowSize, "number of recently executed blocks the transaction conflict statistics are aggregated over")
 	flags.StringVar(&exeConf.chunkDataPackDir, "chunk-data-pack-dir", filepath.Join(datadir, "chunk_data_packs"), "directory to use for storing chunk data packs")
 	flags.UintVar(&exeConf.chunkDataPackCacheSize, "chdp-cache", storage.DefaultCacheSize, "cache size for chunk data packs")
 	flags.Uint32Var(&exeConf.chunkDataPackRequestsCacheSize, "chdp-request-queue", mempool.DefaultChunkDataPackRequestQueueSize, "queue size for chunk data pack requests")
@@ -127,10 +162,26 @@ func (exeConf *ExecutionConfig) SetupFlags(flags *pflag.FlagSet) {
 	flags.UintVar(&exeConf.transactionExecutionMetricsBufferSize, "tx-execution-metrics-buffer-size", 200, "buffer size for transaction execution metrics. The buffer size is the number of blocks that are kept in memory by the metrics provider engine")
 	flags.BoolVar(&exeConf.evmTracingEnabled, "evm-tracing-enabled", false, "enable EVM tracing, when set it will generate traces and upload them to the GCP bucket provided by the --evm-traces-gcp-bucket. Warning: this might affect speed of execution")
 	flags.StringVar(&exeConf.evmTracesGCPBucket, "evm-traces-gcp-bucket", "", "define GCP bucket name used for uploading EVM traces, must be used in combination with --evm-tracing-enabled. if left empty the upload step is skipped")
+	flags.StringVar(&exeConf.evmTracesDir, "evm-traces-dir", "", "define local directory the EVM traces are stored in, so they can be served by the get-evm-traces admin command, must be used in combination with --evm-tracing-enabled. cannot be used with --evm-traces-gcp-bucket")
+	flags.BoolVar(&exeConf.executionProfilingEnabled, "execution-profiling-enabled", false, "enable profiling the execution of every transaction, when set it will write the profiles of each executed block in the pprof and JSON lines formats to the directory provided by --execution-profile-dir. Warning: this might affect speed of execution")
+	flags.StringVar(&exeConf.executionProfileDir, "execution-profile-dir", filepath.Join(datadir, "execution_profiles"), "directory the transaction execution profiles are written to, when --execution-profiling-enabled is set")
+	flags.Uint64Var(&exeConf.executionProfileDirMaxBytes, "execution-profile-dir-max-bytes", 10_000_000_000, "maximum size in bytes of the profiles in --execution-profile-dir, the profiles of the lowest heights are removed once it's exceeded, 0 means no limit")
 
 	flags.BoolVar(&exeConf.onflowOnlyLNs, "temp-onflow-only-lns", false, "do not use unless required. forces node to only request collections from onflow collection nodes")
 	flags.BoolVar(&exeConf.enableStorehouse, "enable-storehouse", false, "enable storehouse to store registers on disk, default is false")
+	flags.BoolVar(&exeConf.enableRegisterPrefetcher, "enable-register-prefetcher", false, "enable prefetching the registers of blocks before their execution, requires the storehouse, default is false")
+	flags.IntVar(&exeConf.registerPrefetchWorkers, "register-prefetch-workers", storehouse.DefaultPrefetchWorkers, "number of registers read concurrently when prefetching the registers of a block")
 	flags.BoolVar(&exeConf.enableChecker, "enable-checker", true, "enable checker to check the correctness of the execution result, default is true")
+	flags.StringVar(&exeConf.checkerReportDir, "checker-divergence-report-dir", filepath.Join(datadir, "divergence_reports"), "directory the checker writes a report to when the execution result diverges from the sealed result")
+	flags.BoolVar(&exeConf.enableStateSyncServing, "enable-state-sync-serving", false, "enable serving the execution state to bootstrapping execution nodes, default is false")
+	flags.BoolVar(&exeConf.enableStateSyncBootstrap, "enable-state-sync-bootstrap", false, "enable syncing the root execution state from execution nodes when the bootstrap folder has no root checkpoint, default is false")
+	flags.StringVar(&exeConf.stateSyncDir, "state-sync-dir", filepath.Join(datadir, "state_sync"), "directory the checkpoints served to bootstrapping execution nodes are stored in")
+	flags.Uint64Var(&exeConf.stateSyncChunkSize, "state-sync-chunk-size", statesync.DefaultChunkSize, "max size in bytes of a chunk of the execution state served to bootstrapping execution nodes")
+	flags.Float64Var(&exeConf.stateSyncServeRateLimit, "state-sync-serve-rate-limit", statesync.DefaultServeRateLimit, "max bytes per second of the execution state served to a single bootstrapping execution node")
+	flags.UintVar(&exeConf.stateSyncServeWorkers, "state-sync-serve-workers", statesync.DefaultServeWorkers, "number of workers serving the execution state to bootstrapping execution nodes")
+	flags.DurationVar(&exeConf.stateSyncBuildInterval, "state-sync-build-interval", statesync.DefaultBuildInterval, "min interval between checkpoints of the execution state created on behalf of a single bootstrapping execution node")
+	flags.UintVar(&exeConf.stateSyncMaxCheckpoints, "state-sync-max-checkpoints", statesync.DefaultMaxCheckpoints, "max number of checkpoints of the execution state kept to be served to bootstrapping execution nodes")
+	flags.UintVar(&exeConf.registerProofWorkers, "register-proof-workers", proofs.DefaultWorkers, "number of workers serving register proofs to access nodes")
 	// deprecated. Retain it to prevent nodes that previously had this configuration from crashing.
 	var deprecatedEnableNewIngestionEngine bool
 	flags.BoolVar(&deprecatedEnableNewIngestionEngine, "enable-new-ingestion-engine", true, "enable new ingestion engine, default is true")
@@ -144,6 +195,12 @@ func (exeConf *ExecutionConfig) ValidateFlags() error {
 			return fmt.Errorf("invalid flag. gcp-bucket-name or s3-bucket-name required when blockdata-uploader is enabled")
 		}
 	}
+	if exeConf.enableRegisterPrefetcher && !exeConf.enableStorehouse {
+		return fmt.Errorf("invalid flag. enable-register-prefetcher requires enable-storehouse")
+	}
+	if exeConf.evmTracesDir != "" && exeConf.evmTracesGCPBucket != "" {
+		return fmt.Errorf("invalid flag. evm-traces-dir and evm-traces-gcp-bucket cannot be used together")
+	}
 	if exeConf.executionDataAllowedPeers != "" {
 		ids := strings.Split(exeConf.executionDataAllowedPeers, ",")
 		for _, id := range ids {
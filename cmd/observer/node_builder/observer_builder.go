@@ -40,11 +40,13 @@ import (
 	recovery "github.com/onflow/flow-go/consensus/recovery/protocol"
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/access/apiproxy"
+	"github.com/onflow/flow-go/engine/access/evmrpc"
 	"github.com/onflow/flow-go/engine/access/index"
 	"github.com/onflow/flow-go/engine/access/rest"
 	restapiproxy "github.com/onflow/flow-go/engine/access/rest/apiproxy"
 	commonrest "github.com/onflow/flow-go/engine/access/rest/common"
 	"github.com/onflow/flow-go/engine/access/rest/router"
+	"github.com/onflow/flow-go/engine/access/rest/websockets"
 	"github.com/onflow/flow-go/engine/access/rpc"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
 	rpcConnection "github.com/onflow/flow-go/engine/access/rpc/connection"
@@ -57,6 +59,7 @@ import (
 	synceng "github.com/onflow/flow-go/engine/common/synchronization"
 	"github.com/onflow/flow-go/engine/common/version"
 	"github.com/onflow/flow-go/engine/execution/computation/query"
+	"github.com/onflow/flow-go/fvm/evm"
 	"github.com/onflow/flow-go/fvm/storage/derived"
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/complete/wal"
@@ -168,6 +171,11 @@ type ObserverServiceConfig struct {
 	registerCacheSize                    uint
 	programCacheSize                     uint
 	registerDBPruneThreshold             uint64
+	registerDBPruningEnabled             bool
+	registerDBPruneThrottleDelay         time.Duration
+	registerDBPruneTickerInterval        time.Duration
+	accountTransactionsIndexingEnabled   bool
+	evmLogsIndexingEnabled               bool
 }
 
 // DefaultObserverServiceConfig defines all the default values for the ObserverServiceConfig
@@ -197,7 +205,11 @@ func DefaultObserverServiceConfig() *ObserverServiceConfig {
 				ReadTimeout:    rest.DefaultReadTimeout,
 				IdleTimeout:    rest.DefaultIdleTimeout,
 				MaxRequestSize: commonrest.DefaultMaxRequestSize,
+
+				EnableWebSocketsStreamAPI: false,
+				WebSocketConfig:           websockets.NewDefaultWebsocketConfig(),
 			},
+			EVMRPCConfig:   evmrpc.NewDefaultConfig(),
 			MaxMsgSize:     grpcutils.DefaultMaxMsgSize,
 			CompressorName: grpcutils.NoCompressor,
 		},
@@ -211,6 +223,8 @@ func DefaultObserverServiceConfig() *ObserverServiceConfig {
 			ResponseLimit:           subscription.DefaultResponseLimit,
 			HeartbeatInterval:       subscription.DefaultHeartbeatInterval,
 			RegisterIDsRequestLimit: state_stream.DefaultRegisterIDsRequestLimit,
+			EventCursorTTL:          statestreambackend.DefaultEventCursorTTL,
+			MaxEventCursors:         statestreambackend.DefaultMaxEventCursors,
 		},
 		stateStreamFilterConf:                nil,
 		rpcMetricsEnabled:                    false,
@@ -246,12 +260,15 @@ func DefaultObserverServiceConfig() *ObserverServiceConfig {
 			RetryDelay:         edrequester.DefaultRetryDelay,
 			MaxRetryDelay:      edrequester.DefaultMaxRetryDelay,
 		},
-		scriptExecMinBlock:       0,
-		scriptExecMaxBlock:       math.MaxUint64,
-		registerCacheType:        pstorage.CacheTypeTwoQueue.String(),
-		registerCacheSize:        0,
-		programCacheSize:         0,
-		registerDBPruneThreshold: pruner.DefaultThreshold,
+		scriptExecMinBlock:            0,
+		scriptExecMaxBlock:            math.MaxUint64,
+		registerCacheType:             pstorage.CacheTypeTwoQueue.String(),
+		registerCacheSize:             0,
+		programCacheSize:              0,
+		registerDBPruneThreshold:      pruner.DefaultThreshold,
+		registerDBPruningEnabled:      false,
+		registerDBPruneThrottleDelay:  pstorage.DefaultPruneThrottleDelay,
+		registerDBPruneTickerInterval: pstorage.DefaultPruneTickerInterval,
 	}
 }
 
@@ -293,6 +310,11 @@ type ObserverServiceBuilder struct {
 	EventsIndex         *index.EventsIndex
 	ScriptExecutor      *backend.ScriptExecutor
 
+	AccountTransactions      storage.AccountTransactions
+	AccountTransactionsIndex *index.AccountTransactionsIndex
+	EVMLogs                  storage.EVMLogs
+	EVMLogsIndex             *index.EVMLogsIndex
+
 	// available until after the network has started. Hence, a factory function that needs to be called just before
 	// creating the sync engine
 	SyncEngineParticipantsProviderFactory func() module.IdentifierProvider
@@ -627,6 +649,34 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 			"rest-max-request-size",
 			defaultConfig.rpcConf.RestConfig.MaxRequestSize,
 			"the maximum request size in bytes for payload sent over REST server")
+		flags.StringVar(&builder.rpcConf.EVMRPCConfig.ListenAddress,
+			"evm-rpc-addr",
+			defaultConfig.rpcConf.EVMRPCConfig.ListenAddress,
+			"the address the EVM JSON-RPC server listens on (if empty the EVM JSON-RPC server will not be started). requires execution-data-indexing-enabled")
+		flags.Int64Var(&builder.rpcConf.EVMRPCConfig.MaxRequestSize,
+			"evm-rpc-max-request-size",
+			defaultConfig.rpcConf.EVMRPCConfig.MaxRequestSize,
+			"the maximum request size in bytes for requests sent to the EVM JSON-RPC server")
+		flags.Uint64Var(&builder.rpcConf.EVMRPCConfig.MaxCallGasLimit,
+			"evm-rpc-max-call-gas-limit",
+			defaultConfig.rpcConf.EVMRPCConfig.MaxCallGasLimit,
+			"the maximum gas limit of calls executed by eth_call and eth_estimateGas on the EVM JSON-RPC server")
+		flags.BoolVar(&builder.rpcConf.RestConfig.EnableWebSocketsStreamAPI,
+			"experimental-enable-websockets-stream-api",
+			defaultConfig.rpcConf.RestConfig.EnableWebSocketsStreamAPI,
+			"[experimental] enables the multiplexed websocket stream API served at /v1/ws on the REST server")
+		flags.Uint64Var(&builder.rpcConf.RestConfig.WebSocketConfig.MaxSubscriptionsPerConnection,
+			"websocket-max-subscriptions-per-connection",
+			defaultConfig.rpcConf.RestConfig.WebSocketConfig.MaxSubscriptionsPerConnection,
+			"maximum number of active subscriptions on a single websocket stream API connection")
+		flags.Uint64Var(&builder.rpcConf.RestConfig.WebSocketConfig.MaxConnections,
+			"websocket-max-connections",
+			defaultConfig.rpcConf.RestConfig.WebSocketConfig.MaxConnections,
+			"maximum number of concurrent websocket stream API connections")
+		flags.UintVar(&builder.rpcConf.RestConfig.WebSocketConfig.SendBufferSize,
+			"websocket-send-buffer-size",
+			defaultConfig.rpcConf.RestConfig.WebSocketConfig.SendBufferSize,
+			"number of messages buffered per websocket stream API connection")
 		flags.UintVar(&builder.rpcConf.MaxMsgSize,
 			"rpc-max-message-size",
 			defaultConfig.rpcConf.MaxMsgSize,
@@ -676,6 +726,14 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 			"execution-data-indexing-enabled",
 			defaultConfig.executionDataIndexingEnabled,
 			"whether to enable the execution data indexing")
+		flags.BoolVar(&builder.accountTransactionsIndexingEnabled,
+			"account-transactions-indexing-enabled",
+			defaultConfig.accountTransactionsIndexingEnabled,
+			"whether to index the transactions which involved each account. requires execution-data-indexing-enabled")
+		flags.BoolVar(&builder.evmLogsIndexingEnabled,
+			"evm-logs-indexing-enabled",
+			defaultConfig.evmLogsIndexingEnabled,
+			"whether to index the logs emitted by EVM transactions. requires execution-data-indexing-enabled")
 		flags.BoolVar(&builder.versionControlEnabled,
 			"version-control-enabled",
 			defaultConfig.versionControlEnabled,
@@ -776,6 +834,14 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 			"state-stream-heartbeat-interval",
 			defaultConfig.stateStreamConf.HeartbeatInterval,
 			"default interval in blocks at which heartbeat messages should be sent. applied when client did not specify a value.")
+		flags.DurationVar(&builder.stateStreamConf.EventCursorTTL,
+			"state-stream-event-cursor-ttl",
+			defaultConfig.stateStreamConf.EventCursorTTL,
+			"duration after which event subscription cursors which are not used are removed")
+		flags.UintVar(&builder.stateStreamConf.MaxEventCursors,
+			"state-stream-max-event-cursors",
+			defaultConfig.stateStreamConf.MaxEventCursors,
+			"max number of stored event subscription cursors")
 		flags.Uint32Var(&builder.stateStreamConf.RegisterIDsRequestLimit,
 			"state-stream-max-register-values",
 			defaultConfig.stateStreamConf.RegisterIDsRequestLimit,
@@ -811,6 +877,18 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 			"registerdb-pruning-threshold",
 			defaultConfig.registerDBPruneThreshold,
 			fmt.Sprintf("specifies the number of blocks below the latest stored block height to keep in register db. default: %d", defaultConfig.registerDBPruneThreshold))
+		flags.BoolVar(&builder.registerDBPruningEnabled,
+			"registerdb-pruning-enabled",
+			defaultConfig.registerDBPruningEnabled,
+			"whether to enable the register db pruner, which deletes register versions below the pruning threshold. default: false")
+		flags.DurationVar(&builder.registerDBPruneThrottleDelay,
+			"registerdb-prune-throttle-delay",
+			defaultConfig.registerDBPruneThrottleDelay,
+			"delay between batches of register versions deleted by the register db pruner. default: 10ms")
+		flags.DurationVar(&builder.registerDBPruneTickerInterval,
+			"registerdb-prune-ticker-interval",
+			defaultConfig.registerDBPruneTickerInterval,
+			"interval at which the register db pruner checks whether pruning is needed. default: 10m")
 	}).ValidateFlags(func() error {
 		if builder.executionDataSyncEnabled {
 			if builder.executionDataConfig.FetchTimeout <= 0 {
@@ -829,6 +907,20 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 				return errors.New("execution-data-max-search-ahead must be greater than 0")
 			}
 		}
+		if builder.registerDBPruningEnabled {
+			if !builder.executionDataIndexingEnabled {
+				return errors.New("execution-data-indexing-enabled must be set if registerdb-pruning-enabled is true")
+			}
+			if builder.registerDBPruneThreshold == 0 {
+				return errors.New("registerdb-pruning-threshold must be greater than 0 if registerdb-pruning-enabled is true")
+			}
+		}
+		if builder.accountTransactionsIndexingEnabled && !builder.executionDataIndexingEnabled {
+			return errors.New("execution-data-indexing-enabled must be set if account-transactions-indexing-enabled is true")
+		}
+		if builder.evmLogsIndexingEnabled && !builder.executionDataIndexingEnabled {
+			return errors.New("execution-data-indexing-enabled must be set if evm-logs-indexing-enabled is true")
+		}
 		if builder.stateStreamConf.ListenAddr != "" {
 			if builder.stateStreamConf.ExecutionDataCacheSize == 0 {
 				return errors.New("execution-data-cache-size must be greater than 0")
@@ -836,17 +928,17 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 			if builder.stateStreamConf.ClientSendBufferSize == 0 {
 				return errors.New("state-stream-send-buffer-size must be greater than 0")
 			}
-			if len(builder.stateStreamFilterConf) > 4 {
-				return errors.New("state-stream-event-filter-limits must have at most 4 keys (EventTypes, Addresses, Contracts, AccountAddresses)")
+			if len(builder.stateStreamFilterConf) > 6 {
+				return errors.New("state-stream-event-filter-limits must have at most 6 keys (EventTypes, Addresses, Contracts, AccountAddresses, Expressions, ExpressionConditions)")
 			}
 			for key, value := range builder.stateStreamFilterConf {
 				switch key {
-				case "EventTypes", "Addresses", "Contracts", "AccountAddresses":
+				case "EventTypes", "Addresses", "Contracts", "AccountAddresses", "Expressions", "ExpressionConditions":
 					if value <= 0 {
 						return fmt.Errorf("state-stream-event-filter-limits %s must be greater than 0", key)
 					}
 				default:
-					return errors.New("state-stream-event-filter-limits may only contain the keys EventTypes, Addresses, Contracts, AccountAddresses")
+					return errors.New("state-stream-event-filter-limits may only contain the keys EventTypes, Addresses, Contracts, AccountAddresses, Expressions, ExpressionConditions")
 				}
 			}
 			if builder.stateStreamConf.ResponseLimit < 0 {
@@ -860,6 +952,25 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 		if builder.rpcConf.RestConfig.MaxRequestSize <= 0 {
 			return errors.New("rest-max-request-size must be greater than 0")
 		}
+		if builder.rpcConf.EVMRPCConfig.ListenAddress != "" {
+			if !builder.executionDataIndexingEnabled {
+				return errors.New("execution-data-indexing-enabled must be set if evm-rpc-addr is set")
+			}
+			if builder.rpcConf.EVMRPCConfig.MaxRequestSize <= 0 {
+				return errors.New("evm-rpc-max-request-size must be greater than 0")
+			}
+			if builder.rpcConf.EVMRPCConfig.MaxCallGasLimit == 0 {
+				return errors.New("evm-rpc-max-call-gas-limit must be greater than 0")
+			}
+		}
+		if builder.rpcConf.RestConfig.EnableWebSocketsStreamAPI {
+			if builder.rpcConf.RestConfig.WebSocketConfig.MaxSubscriptionsPerConnection == 0 {
+				return errors.New("websocket-max-subscriptions-per-connection must be greater than 0")
+			}
+			if builder.rpcConf.RestConfig.WebSocketConfig.MaxConnections == 0 {
+				return errors.New("websocket-max-connections must be greater than 0")
+			}
+		}
 
 		return nil
 	})
@@ -1056,11 +1167,15 @@ func (builder *ObserverServiceBuilder) BuildExecutionSyncComponents() *ObserverS
 	var execDataCacheBackend *herocache.BlockExecutionData
 	var executionDataStoreCache *execdatacache.ExecutionDataCache
 	var executionDataDBMode execution_data.ExecutionDataDBMode
+	var registers *pstorage.Registers
 
 	// setup dependency chain to ensure indexer starts after the requester
 	requesterDependable := module.NewProxiedReadyDoneAware()
 	builder.IndexerDependencies.Add(requesterDependable)
 
+	// setup dependency chain to ensure the register db pruner starts after the indexer
+	indexerDependable := module.NewProxiedReadyDoneAware()
+
 	executionDataPrunerEnabled := builder.executionDataPrunerHeightRangeTarget != 0
 
 	builder.
@@ -1312,6 +1427,16 @@ func (builder *ObserverServiceBuilder) BuildExecutionSyncComponents() *ObserverS
 		}).Module("transaction results storage", func(node *cmd.NodeConfig) error {
 			builder.Storage.LightTransactionResults = bstorage.NewLightTransactionResults(node.Metrics.Cache, node.DB, bstorage.DefaultCacheSize)
 			return nil
+		}).Module("account transactions storage", func(node *cmd.NodeConfig) error {
+			if builder.accountTransactionsIndexingEnabled {
+				builder.AccountTransactions = bstorage.NewAccountTransactions(node.DB)
+			}
+			return nil
+		}).Module("evm logs storage", func(node *cmd.NodeConfig) error {
+			if builder.evmLogsIndexingEnabled {
+				builder.EVMLogs = bstorage.NewEVMLogs(node.DB)
+			}
+			return nil
 		}).DependableComponent("execution data indexer", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 			// Note: using a DependableComponent here to ensure that the indexer does not block
 			// other components from starting while bootstrapping the register db since it may
@@ -1370,7 +1495,7 @@ func (builder *ObserverServiceBuilder) BuildExecutionSyncComponents() *ObserverS
 				}
 			}
 
-			registers, err := pstorage.NewRegisters(pdb, builder.registerDBPruneThreshold)
+			registers, err = pstorage.NewRegisters(pdb, builder.registerDBPruneThreshold)
 			if err != nil {
 				return nil, fmt.Errorf("could not create registers storage: %w", err)
 			}
@@ -1406,6 +1531,8 @@ func (builder *ObserverServiceBuilder) BuildExecutionSyncComponents() *ObserverS
 				builder.Storage.Collections,
 				builder.Storage.Transactions,
 				builder.Storage.LightTransactionResults,
+				builder.AccountTransactions,
+				builder.EVMLogs,
 				builder.RootChainID.Chain(),
 				indexerDerivedChainData,
 				collectionExecutedMetric,
@@ -1469,8 +1596,23 @@ func (builder *ObserverServiceBuilder) BuildExecutionSyncComponents() *ObserverS
 				builder.StopControl.RegisterHeightRecorder(builder.ExecutionIndexer)
 			}
 
+			indexerDependable.Init(builder.ExecutionIndexer)
+
 			return builder.ExecutionIndexer, nil
 		}, builder.IndexerDependencies)
+
+		if builder.registerDBPruningEnabled {
+			builder.DependableComponent("register db pruner", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+				return pstorage.NewRegisterPruner(
+					node.Logger,
+					registers,
+					metrics.NewRegisterDBPrunerCollector(),
+					pstorage.WithPruneThreshold(builder.registerDBPruneThreshold),
+					pstorage.WithPruneThrottleDelay(builder.registerDBPruneThrottleDelay),
+					pstorage.WithPruneTickerInterval(builder.registerDBPruneTickerInterval),
+				)
+			}, cmd.NewDependencyList(indexerDependable))
+		}
 	}
 
 	if builder.stateStreamConf.ListenAddr != "" {
@@ -1485,6 +1627,10 @@ func (builder *ObserverServiceBuilder) BuildExecutionSyncComponents() *ObserverS
 					builder.stateStreamConf.MaxContracts = value
 				case "AccountAddresses":
 					builder.stateStreamConf.MaxAccountAddress = value
+				case "Expressions":
+					builder.stateStreamConf.MaxExpressions = value
+				case "ExpressionConditions":
+					builder.stateStreamConf.MaxExpressionConditions = value
 				}
 			}
 			builder.stateStreamConf.RpcMetricsEnabled = builder.rpcMetricsEnabled
@@ -1536,6 +1682,11 @@ func (builder *ObserverServiceBuilder) BuildExecutionSyncComponents() *ObserverS
 					builder.stateStreamConf.ClientSendBufferSize,
 				),
 				executionDataTracker,
+				node.RootChainID.Chain(),
+				builder.stateStreamConf.EventFilterConfig,
+				bstorage.NewEventSubscriptionCursors(node.DB),
+				builder.stateStreamConf.EventCursorTTL,
+				builder.stateStreamConf.MaxEventCursors,
 			)
 			if err != nil {
 				return nil, fmt.Errorf("could not create state stream backend: %w", err)
@@ -1768,6 +1919,18 @@ func (builder *ObserverServiceBuilder) enqueueRPCServer() {
 		builder.TxResultsIndex = index.NewTransactionResultsIndex(builder.Reporter, builder.Storage.LightTransactionResults)
 		return nil
 	})
+	builder.Module("account transactions index", func(node *cmd.NodeConfig) error {
+		if builder.AccountTransactions != nil {
+			builder.AccountTransactionsIndex = index.NewAccountTransactionsIndex(builder.Reporter, builder.AccountTransactions)
+		}
+		return nil
+	})
+	builder.Module("evm logs index", func(node *cmd.NodeConfig) error {
+		if builder.EVMLogs != nil {
+			builder.EVMLogsIndex = index.NewEVMLogsIndex(builder.Reporter, builder.EVMLogs)
+		}
+		return nil
+	})
 	builder.Module("script executor", func(node *cmd.NodeConfig) error {
 		builder.ScriptExecutor = backend.NewScriptExecutor(builder.Logger, builder.scriptExecMinBlock, builder.scriptExecMaxBlock)
 		return nil
@@ -1931,6 +2094,9 @@ func (builder *ObserverServiceBuilder) enqueueRPCServer() {
 			backendParams.ScriptExecutionMode = backend.IndexQueryModeLocalOnly
 			backendParams.EventQueryMode = backend.IndexQueryModeLocalOnly
 			backendParams.TxResultsIndex = builder.TxResultsIndex
+			backendParams.AccountTransactionsIndex = builder.AccountTransactionsIndex
+			backendParams.EVMLogsIndex = builder.EVMLogsIndex
+			backendParams.RegistersAsyncStore = builder.RegistersAsyncStore
 			backendParams.EventsIndex = builder.EventsIndex
 			backendParams.ScriptExecutor = builder.ScriptExecutor
 		}
@@ -1986,6 +2152,18 @@ func (builder *ObserverServiceBuilder) enqueueRPCServer() {
 			UseIndex: builder.localServiceAPIEnabled,
 		})
 
+		if builder.executionDataIndexingEnabled {
+			engineBuilder.WithEVMBackend(evmrpc.NewBackend(
+				node.RootChainID,
+				evm.StorageAccountAddress(node.RootChainID),
+				node.Storage.Headers,
+				builder.RegistersAsyncStore,
+				builder.EventsIndex,
+				builder.Reporter,
+				builder.rpcConf.EVMRPCConfig.MaxCallGasLimit,
+			))
+		}
+
 		// build the rpc engine
 		builder.RpcEng, err = engineBuilder.
 			WithRpcHandler(rpcHandler).
@@ -0,0 +1,130 @@
+package account_storage_diff
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	pstorage "github.com/onflow/flow-go/storage/pebble"
+)
+
+var (
+	flagRegisterDir string
+	flagAddress     string
+	flagFromHeight  uint64
+	flagToHeight    uint64
+	flagCursor      string
+	flagLimit       int
+	flagDecodePaths bool
+)
+
+var Cmd = &cobra.Command{
+	Use:   "account-storage-diff",
+	Short: "Lists the registers of an account which changed between two heights",
+	Run:   run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagRegisterDir, "register-dir", "",
+		"directory of the pebble register index")
+	_ = Cmd.MarkFlagRequired("register-dir")
+
+	Cmd.Flags().StringVar(&flagAddress, "address", "",
+		"address of the account")
+	_ = Cmd.MarkFlagRequired("address")
+
+	Cmd.Flags().Uint64Var(&flagFromHeight, "from-height", 0,
+		"height to compare from")
+	_ = Cmd.MarkFlagRequired("from-height")
+
+	Cmd.Flags().Uint64Var(&flagToHeight, "to-height", 0,
+		"height to compare to")
+	_ = Cmd.MarkFlagRequired("to-height")
+
+	Cmd.Flags().StringVar(&flagCursor, "cursor", "",
+		"hex encoded register key to continue listing changes after")
+
+	Cmd.Flags().IntVar(&flagLimit, "limit", 0,
+		"maximum number of changes to list (0 lists all changes)")
+
+	Cmd.Flags().BoolVar(&flagDecodePaths, "decode-paths", false,
+		"decode the values stored at the account's storage paths")
+}
+
+func run(*cobra.Command, []string) {
+	address, err := flow.StringToAddress(flagAddress)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid address")
+	}
+
+	if flagFromHeight > flagToHeight {
+		log.Fatal().Msg("--from-height must be less than or equal to --to-height")
+	}
+
+	if flagLimit < 0 {
+		log.Fatal().Msg("--limit must not be negative")
+	}
+
+	var cursor *string
+	if flagCursor != "" {
+		key, err := hex.DecodeString(flagCursor)
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid cursor")
+		}
+		c := string(key)
+		cursor = &c
+	}
+
+	db, err := pstorage.OpenRegisterPebbleDB(flagRegisterDir)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to open register db")
+	}
+	defer db.Close()
+
+	registers, err := pstorage.NewRegisters(db, pstorage.PruningDisabled)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to initialize registers")
+	}
+
+	log.Info().Msgf(
+		"registers are indexed from %d to %d",
+		registers.FirstHeight(),
+		registers.LatestHeight(),
+	)
+
+	limit := flagLimit
+	if limit == 0 {
+		// list all changes
+		limit = math.MaxInt
+	}
+
+	diff, err := execution.AccountStorageDiff(
+		registers,
+		address,
+		flagFromHeight,
+		flagToHeight,
+		cursor,
+		limit,
+		flagDecodePaths,
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to get account storage diff")
+	}
+
+	var result models.AccountStorageDiff
+	result.Build(diff)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(result)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to encode account storage diff")
+	}
+}
@@ -0,0 +1,106 @@
+package checkpoint_convert
+
+import (
+	"path/filepath"
+
+	"github.com/docker/go-units"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/ledger/complete/wal"
+)
+
+var (
+	flagCheckpointDir  string
+	flagCheckpointFile string
+	flagOutputDir      string
+	flagOutputFile     string
+	flagVersion        uint16
+	flagNWorker        uint
+)
+
+var Cmd = &cobra.Command{
+	Use:   "checkpoint-convert",
+	Short: "Converts a checkpoint file to checkpoint version 6 (uncompressed) or 7 (compressed)",
+	Run:   run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagCheckpointDir, "checkpoint-dir", "",
+		"directory of the checkpoint file to convert")
+	_ = Cmd.MarkFlagRequired("checkpoint-dir")
+
+	Cmd.Flags().StringVar(&flagCheckpointFile, "checkpoint-file", "",
+		"name of the checkpoint file to convert")
+	_ = Cmd.MarkFlagRequired("checkpoint-file")
+
+	Cmd.Flags().StringVar(&flagOutputDir, "output-dir", "",
+		"directory to write the converted checkpoint file to")
+	_ = Cmd.MarkFlagRequired("output-dir")
+
+	Cmd.Flags().StringVar(&flagOutputFile, "output-file", "",
+		"name of the converted checkpoint file (default: name of the checkpoint file to convert)")
+
+	Cmd.Flags().Uint16Var(&flagVersion, "version", wal.VersionV7,
+		"checkpoint version to convert to, 6 or 7")
+
+	Cmd.Flags().UintVar(&flagNWorker, "n-workers", 16,
+		"number of workers to encode subtries concurrently, valid range [1,16]")
+}
+
+func run(*cobra.Command, []string) {
+	if flagVersion != wal.VersionV6 && flagVersion != wal.VersionV7 {
+		log.Fatal().Msgf("unsupported checkpoint version %d, must be %d or %d",
+			flagVersion, wal.VersionV6, wal.VersionV7)
+	}
+
+	if flagNWorker < 1 || flagNWorker > 16 {
+		log.Fatal().Msgf("invalid number of workers %d, must be between 1 and 16", flagNWorker)
+	}
+
+	outputFile := flagOutputFile
+	if outputFile == "" {
+		outputFile = flagCheckpointFile
+	}
+
+	checkpointPath := filepath.Join(flagCheckpointDir, flagCheckpointFile)
+
+	log.Info().Msgf("loading checkpoint %v", checkpointPath)
+	tries, err := wal.LoadCheckpoint(checkpointPath, log.Logger)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while loading checkpoint")
+	}
+	log.Info().Msgf("checkpoint loaded, total tries: %v", len(tries))
+
+	log.Info().Msgf("storing checkpoint v%d to %v", flagVersion, filepath.Join(flagOutputDir, outputFile))
+	switch flagVersion {
+	case wal.VersionV6:
+		err = wal.StoreCheckpointV6(tries, flagOutputDir, outputFile, log.Logger, flagNWorker)
+	case wal.VersionV7:
+		err = wal.StoreCheckpointV7(tries, flagOutputDir, outputFile, log.Logger, flagNWorker)
+	}
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while storing checkpoint")
+	}
+
+	outputSize, err := wal.ReadCheckpointFileSize(flagOutputDir, outputFile)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while reading size of converted checkpoint")
+	}
+
+	// checkpoints before version 6 are stored in a single file, so their part files can't be found
+	inputSize, err := wal.ReadCheckpointFileSize(flagCheckpointDir, flagCheckpointFile)
+	if err != nil {
+		log.Warn().Err(err).Msg("could not read size of input checkpoint")
+		log.Info().
+			Str("output_size", units.BytesSize(float64(outputSize))).
+			Msg("checkpoint converted")
+		return
+	}
+
+	log.Info().
+		Str("input_size", units.BytesSize(float64(inputSize))).
+		Str("output_size", units.BytesSize(float64(outputSize))).
+		Float64("ratio", float64(outputSize)/float64(inputSize)).
+		Msg("checkpoint converted")
+}
@@ -0,0 +1,167 @@
+package checkpoint_verify
+
+import (
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+)
+
+var (
+	flagCheckpointDir      string
+	flagCheckpointFile     string
+	flagRepair             bool
+	flagBaseCheckpointDir  string
+	flagBaseCheckpointFile string
+	flagWALDir             string
+	flagForestCapacity     int
+	flagNWorker            uint
+)
+
+// Verifies the integrity of a checkpoint and reports the files and byte ranges that are damaged.
+// Damaged part files of a v6 or v7 checkpoint can be repaired by rebuilding them from an older
+// checkpoint and the WAL segments written after it, as long as the header file is intact.
+var Cmd = &cobra.Command{
+	Use:   "checkpoint-verify",
+	Short: "Verifies the integrity of a checkpoint file and optionally repairs damaged part files",
+	Run:   run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagCheckpointDir, "checkpoint-dir", "",
+		"directory of the checkpoint file to verify")
+	_ = Cmd.MarkFlagRequired("checkpoint-dir")
+
+	Cmd.Flags().StringVar(&flagCheckpointFile, "checkpoint-file", "",
+		"name of the checkpoint file to verify")
+	_ = Cmd.MarkFlagRequired("checkpoint-file")
+
+	Cmd.Flags().BoolVar(&flagRepair, "repair", false,
+		"rebuild damaged part files from the base checkpoint and the WAL segments")
+
+	Cmd.Flags().StringVar(&flagBaseCheckpointDir, "base-checkpoint-dir", "",
+		"directory of the base checkpoint file (default: directory of the checkpoint file to verify)")
+
+	Cmd.Flags().StringVar(&flagBaseCheckpointFile, "base-checkpoint-file", "",
+		"name of an earlier checkpoint file to rebuild damaged part files from, such as root.checkpoint. required when --repair flag is set to true.")
+
+	Cmd.Flags().StringVar(&flagWALDir, "wal-dir", "",
+		"directory of the WAL segments (default: directory of the checkpoint file to verify)")
+
+	Cmd.Flags().IntVar(&flagForestCapacity, "forest-capacity", complete.DefaultCacheSize,
+		"number of tries kept in memory while replaying the WAL segments, must be at least the number of tries of the checkpoint")
+
+	Cmd.Flags().UintVar(&flagNWorker, "n-workers", 16,
+		"number of workers to encode subtries concurrently, valid range [1,16]")
+}
+
+func run(*cobra.Command, []string) {
+	if flagNWorker < 1 || flagNWorker > 16 {
+		log.Fatal().Msgf("invalid number of workers %d, must be between 1 and 16", flagNWorker)
+	}
+
+	result := verify()
+	if result.Valid() {
+		return
+	}
+
+	if !flagRepair {
+		log.Fatal().Msgf("checkpoint is damaged, %d damages found", len(result.Damages))
+	}
+
+	if result.Version != wal.VersionV6 && result.Version != wal.VersionV7 {
+		log.Fatal().Msgf("cannot repair checkpoint version %d, only version %d and %d can be repaired",
+			result.Version, wal.VersionV6, wal.VersionV7)
+	}
+
+	if result.HeaderDamaged {
+		log.Fatal().Msg("cannot repair checkpoint with damaged header file, checksums of the part files are unknown")
+	}
+
+	if flagBaseCheckpointFile == "" {
+		log.Fatal().Msg("--base-checkpoint-file is required when --repair flag is set to true")
+	}
+
+	baseDir := flagBaseCheckpointDir
+	if baseDir == "" {
+		baseDir = flagCheckpointDir
+	}
+
+	walDir := flagWALDir
+	if walDir == "" {
+		walDir = flagCheckpointDir
+	}
+
+	to, err := wal.FilenameToNumber(flagCheckpointFile)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot find last segment of checkpoint")
+	}
+
+	// the root checkpoint is created before the first segment
+	from := 0
+	base, err := wal.FilenameToNumber(flagBaseCheckpointFile)
+	if err == nil {
+		from = base + 1
+	}
+
+	if from > to+1 {
+		log.Fatal().Msgf("base checkpoint %v is newer than checkpoint %v", flagBaseCheckpointFile, flagCheckpointFile)
+	}
+
+	baseCheckpointPath := filepath.Join(baseDir, flagBaseCheckpointFile)
+
+	log.Info().Msgf("loading base checkpoint %v", baseCheckpointPath)
+	baseTries, err := wal.LoadCheckpoint(baseCheckpointPath, log.Logger)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while loading base checkpoint")
+	}
+	log.Info().Msgf("base checkpoint loaded, total tries: %v", len(baseTries))
+
+	tries, err := wal.RebuildCheckpointTries(baseTries, walDir, from, to, flagForestCapacity, log.Logger)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while rebuilding tries")
+	}
+	log.Info().Msgf("tries rebuilt, total tries: %v", len(tries))
+
+	repaired, err := wal.RepairCheckpointParts(flagCheckpointDir, flagCheckpointFile, tries, result.DamagedParts, flagNWorker, log.Logger)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while repairing part files")
+	}
+	log.Info().Msgf("repaired part files %v of damaged part files %v", repaired, result.DamagedParts)
+
+	result = verify()
+	if !result.Valid() {
+		log.Fatal().Msgf("checkpoint is still damaged, %d damages found", len(result.Damages))
+	}
+
+	log.Info().Msg("checkpoint repaired")
+}
+
+// verify verifies the checkpoint and logs the damages found.
+func verify() *wal.CheckpointVerification {
+	log.Info().Msgf("verifying checkpoint %v", filepath.Join(flagCheckpointDir, flagCheckpointFile))
+	result, err := wal.VerifyCheckpoint(flagCheckpointDir, flagCheckpointFile, log.Logger)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while verifying checkpoint")
+	}
+
+	for _, damage := range result.Damages {
+		log.Error().
+			Str("file", damage.File).
+			Int64("offset", damage.Offset).
+			Int64("length", damage.Length).
+			Msg(damage.Reason)
+	}
+
+	if result.Valid() {
+		for i, rootHash := range result.RootHashes {
+			log.Info().Msgf("trie %d: %v", i, rootHash)
+		}
+		log.Info().Msgf("checkpoint v%d is valid, total tries: %v", result.Version, len(result.RootHashes))
+	}
+
+	return result
+}
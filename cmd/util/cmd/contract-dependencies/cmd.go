@@ -0,0 +1,312 @@
+package contract_dependencies
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/onflow/flow-go/cmd/util/ledger/util"
+	"github.com/onflow/flow-go/cmd/util/ledger/util/registers"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	pstorage "github.com/onflow/flow-go/storage/pebble"
+)
+
+var (
+	flagRegisterDir     string
+	flagHeight          uint64
+	flagPayloads        string
+	flagState           string
+	flagStateCommitment string
+	flagAddress         string
+	flagName            string
+	flagScanStorage     bool
+	flagNWorker         int
+)
+
+var Cmd = &cobra.Command{
+	Use:   "contract-dependencies",
+	Short: "Lists the contracts depending on a contract, and the stored values referencing its types",
+	Run:   run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagRegisterDir, "register-dir", "",
+		"directory of the pebble register index")
+
+	Cmd.Flags().Uint64Var(&flagHeight, "height", 0,
+		"height to read the registers at, when reading from the register index (default: latest indexed height)")
+
+	Cmd.Flags().StringVar(&flagPayloads, "payloads", "",
+		"input payload file name")
+
+	Cmd.Flags().StringVar(&flagState, "state", "",
+		"directory of the execution state checkpoint")
+
+	Cmd.Flags().StringVar(&flagStateCommitment, "state-commitment", "",
+		"state commitment of the checkpointed trie to read")
+
+	Cmd.Flags().StringVar(&flagAddress, "address", "",
+		"address of the account the contract is deployed to")
+	_ = Cmd.MarkFlagRequired("address")
+
+	Cmd.Flags().StringVar(&flagName, "name", "",
+		"name of the contract")
+	_ = Cmd.MarkFlagRequired("name")
+
+	Cmd.Flags().BoolVar(&flagScanStorage, "scan-storage", false,
+		"scan the storage of all accounts for values referencing types of the contract or its dependents")
+
+	Cmd.Flags().IntVar(&flagNWorker, "n-workers", 8,
+		"number of workers scanning account storage")
+}
+
+// accountSource provides the accounts of the execution state being analyzed.
+type accountSource struct {
+	// graph is the import graph of all deployed contracts
+	graph *execution.ContractGraph
+	// forEachAccount calls f with the address of every account
+	forEachAccount func(f func(address flow.Address) error) error
+	// storedTypeReferences returns the values stored by the account which reference types of the contracts
+	storedTypeReferences func(address flow.Address, contracts []flow.ContractID) ([]flow.StoredTypeReference, error)
+}
+
+func run(*cobra.Command, []string) {
+	address, err := flow.StringToAddress(flagAddress)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid address")
+	}
+	contract := flow.ContractID{Address: address, Name: flagName}
+
+	sources := 0
+	for _, flag := range []string{flagRegisterDir, flagPayloads, flagState} {
+		if flag != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		log.Fatal().Msg("exactly one of --register-dir, --payloads or --state must be provided")
+	}
+	if flagState != "" && flagStateCommitment == "" {
+		log.Fatal().Msg("--state-commitment must be provided when --state is provided")
+	}
+	if flagNWorker < 1 {
+		log.Fatal().Msg("--n-workers must be at least 1")
+	}
+
+	var source *accountSource
+	var height uint64
+	if flagRegisterDir != "" {
+		db, err := pstorage.OpenRegisterPebbleDB(flagRegisterDir)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to open register db")
+		}
+		defer db.Close()
+
+		registerIndex, err := pstorage.NewRegisters(db, pstorage.PruningDisabled)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to initialize registers")
+		}
+
+		height, source = registerIndexSource(registerIndex)
+	} else {
+		source = payloadSource()
+	}
+
+	log.Info().Msgf("found %d contracts", source.graph.Len())
+
+	if !source.graph.Contains(contract) {
+		log.Fatal().Msgf("contract %s is not deployed", contract)
+	}
+
+	impact := source.graph.Impact(contract)
+	impact.Height = height
+
+	if flagScanStorage {
+		impact.StoredReferences, impact.ScannedAccounts, err = scanStorage(source, impact.Contracts())
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to scan account storage")
+		}
+	}
+
+	var result models.ContractImpact
+	result.Build(impact)
+	if flagRegisterDir == "" {
+		// checkpoints and payload files are not associated with a height
+		result.Height = ""
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(result)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to encode contract impact")
+	}
+}
+
+// registerIndexSource reads the accounts from the register index at the requested height,
+// or at the latest indexed height if no height is requested.
+func registerIndexSource(registerIndex *pstorage.Registers) (uint64, *accountSource) {
+	log.Info().Msgf(
+		"registers are indexed from %d to %d",
+		registerIndex.FirstHeight(),
+		registerIndex.LatestHeight(),
+	)
+
+	height := flagHeight
+	if height == 0 {
+		height = registerIndex.LatestHeight()
+	}
+
+	log.Info().Msgf("reading contracts at height %d", height)
+
+	graph, err := execution.ContractGraphAtHeight(context.Background(), registerIndex, height)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to build contract graph")
+	}
+
+	return height, &accountSource{
+		graph: graph,
+		forEachAccount: func(f func(address flow.Address) error) error {
+			return registerIndex.ForEachOwner(func(owner string) error {
+				return f(flow.BytesToAddress([]byte(owner)))
+			})
+		},
+		storedTypeReferences: func(address flow.Address, contracts []flow.ContractID) ([]flow.StoredTypeReference, error) {
+			return execution.StoredTypeReferencesAtHeight(registerIndex, address, height, contracts)
+		},
+	}
+}
+
+// payloadSource reads the accounts from the payload file or the checkpointed trie.
+func payloadSource() *accountSource {
+	var payloads []*ledger.Payload
+	var err error
+
+	if flagPayloads != "" {
+		log.Info().Msgf("reading payloads from %s", flagPayloads)
+
+		_, payloads, err = util.ReadPayloadFile(log.Logger, flagPayloads)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to read payloads")
+		}
+	} else {
+		log.Info().Msgf("reading trie %s", flagStateCommitment)
+
+		stateCommitment := util.ParseStateCommitment(flagStateCommitment)
+		payloads, err = util.ReadTrie(flagState, stateCommitment)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to read state")
+		}
+	}
+
+	registersByAccount, err := registers.NewByAccountFromPayloads(payloads)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to group payloads by account")
+	}
+
+	graph := execution.NewContractGraph()
+	err = registersByAccount.ForEachAccount(func(accountRegisters *registers.AccountRegisters) error {
+		owner := accountRegisters.Owner()
+		if len(owner) != flow.AddressLength {
+			return nil
+		}
+		address := flow.BytesToAddress([]byte(owner))
+
+		return accountRegisters.ForEach(func(_ string, key string, value []byte) error {
+			if flow.IsContractKey(key) && len(value) > 0 {
+				graph.AddContract(flow.ContractID{Address: address, Name: flow.KeyContractName(key)}, value)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to read contracts")
+	}
+
+	return &accountSource{
+		graph: graph,
+		forEachAccount: func(f func(address flow.Address) error) error {
+			return registersByAccount.ForEachAccount(func(accountRegisters *registers.AccountRegisters) error {
+				owner := accountRegisters.Owner()
+				if len(owner) != flow.AddressLength {
+					return nil
+				}
+				return f(flow.BytesToAddress([]byte(owner)))
+			})
+		},
+		storedTypeReferences: func(address flow.Address, contracts []flow.ContractID) ([]flow.StoredTypeReference, error) {
+			accountRegisters := registersByAccount.AccountRegisters(flow.AddressToRegisterOwner(address))
+			return execution.StoredTypeReferences(
+				registers.ReadOnlyLedger{Registers: accountRegisters},
+				address,
+				contracts,
+			)
+		},
+	}
+}
+
+// scanStorage scans the storage of all accounts of the source for values referencing types of the
+// given contracts. It returns the references ordered by address and path, and the number of scanned accounts.
+func scanStorage(source *accountSource, contracts []flow.ContractID) ([]flow.StoredTypeReference, uint64, error) {
+	var (
+		mu         sync.Mutex
+		references []flow.StoredTypeReference
+		scanned    uint64
+	)
+
+	addresses := make(chan flow.Address, flagNWorker)
+	g := errgroup.Group{}
+
+	for i := 0; i < flagNWorker; i++ {
+		g.Go(func() error {
+			for address := range addresses {
+				accountReferences, err := source.storedTypeReferences(address, contracts)
+				if err != nil {
+					log.Warn().Err(err).Msgf("failed to scan storage of account %s", address)
+					continue
+				}
+
+				mu.Lock()
+				references = append(references, accountReferences...)
+				scanned++
+				if scanned%100_000 == 0 {
+					log.Info().Msgf("scanned %d accounts", scanned)
+				}
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+
+	err := source.forEachAccount(func(address flow.Address) error {
+		addresses <- address
+		return nil
+	})
+	close(addresses)
+
+	if waitErr := g.Wait(); err == nil {
+		err = waitErr
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sort.Slice(references, func(i, j int) bool {
+		if c := bytes.Compare(references[i].Address[:], references[j].Address[:]); c != 0 {
+			return c < 0
+		}
+		return references[i].Path < references[j].Path
+	})
+
+	return references, scanned, nil
+}
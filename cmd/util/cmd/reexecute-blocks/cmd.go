@@ -0,0 +1,193 @@
+package reexecute_blocks
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/engine/execution/computation/committer"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/storage"
+	pstorage "github.com/onflow/flow-go/storage/pebble"
+)
+
+var (
+	flagDatadir        string
+	flagCheckpoint     string
+	flagRegisterDir    string
+	flagFromHeight     uint64
+	flagToHeight       uint64
+	flagForestCapacity int
+)
+
+var Cmd = &cobra.Command{
+	Use:   "reexecute-blocks",
+	Short: "Re-executes a range of finalized blocks offline and compares the results with the stored execution results",
+	Long: `Re-executes a range of finalized blocks offline and compares the results with the stored execution results.
+
+The execution state is loaded either from a checkpoint, or from a register store.
+With a checkpoint, the blocks are executed on the state computed for their parent,
+and the state commitments are verified. The checkpoint must contain the state the
+first block starts from.
+With a register store, each block is executed on the registers stored for its parent.
+The register store doesn't hold the trie, so only events, service events and
+computation are verified.`,
+	Run: run,
+}
+
+func init() {
+	Cmd.Flags().StringVarP(&flagDatadir, "datadir", "d", "/var/flow/data/protocol",
+		"directory to the badger database")
+	_ = Cmd.MarkFlagRequired("datadir")
+
+	Cmd.Flags().StringVar(&flagCheckpoint, "checkpoint", "",
+		"checkpoint file holding the execution state at the start of the first block")
+
+	Cmd.Flags().StringVar(&flagRegisterDir, "register-dir", "",
+		"directory of the pebble register store")
+
+	Cmd.Flags().Uint64Var(&flagFromHeight, "from-height", 0,
+		"height of the first block to re-execute")
+	_ = Cmd.MarkFlagRequired("from-height")
+
+	Cmd.Flags().Uint64Var(&flagToHeight, "to-height", 0,
+		"height of the last block to re-execute")
+	_ = Cmd.MarkFlagRequired("to-height")
+
+	Cmd.Flags().IntVar(&flagForestCapacity, "forest-capacity", 100,
+		"number of tries kept in memory when re-executing from a checkpoint")
+}
+
+func run(*cobra.Command, []string) {
+	if (flagCheckpoint == "") == (flagRegisterDir == "") {
+		log.Fatal().Msg("exactly one of --checkpoint and --register-dir must be provided")
+	}
+	if flagFromHeight == 0 || flagFromHeight > flagToHeight {
+		log.Fatal().Msgf("invalid height range [%d, %d]", flagFromHeight, flagToHeight)
+	}
+
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	storages := common.InitStorages(db)
+	state, err := common.InitProtocolState(db, storages)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not init protocol state")
+	}
+
+	var snapshots snapshotProvider
+	var viewCommitter computer.ViewCommitter
+	if flagCheckpoint != "" {
+		ledgerSnapshots, err := loadCheckpoint(storages, flagCheckpoint, flagFromHeight, flagForestCapacity)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not load checkpoint")
+		}
+		snapshots = ledgerSnapshots
+		viewCommitter = committer.NewLedgerViewCommitter(ledgerSnapshots.ledger, trace.NewNoopTracer())
+	} else {
+		registerDB, err := pstorage.OpenRegisterPebbleDB(flagRegisterDir)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not open register store")
+		}
+		defer registerDB.Close()
+
+		registers, err := pstorage.NewRegisters(registerDB, pstorage.PruningDisabled)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not init register store")
+		}
+		snapshots = &registerSnapshots{registers: registers}
+		viewCommitter = committer.NewNoopViewCommitter()
+	}
+
+	reExecutor, err := newReExecutor(log.Logger, state, storages, snapshots, viewCommitter)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not create re-executor")
+	}
+
+	passed, failed, skipped := 0, 0, 0
+	for height := flagFromHeight; height <= flagToHeight; height++ {
+		report, err := reExecutor.executeHeight(context.Background(), height)
+		if err != nil {
+			log.Fatal().Err(err).Uint64("height", height).Msg("could not re-execute block")
+		}
+
+		switch {
+		case report.Skipped != "":
+			skipped++
+			fmt.Printf("SKIP %d %v: %s\n", report.Height, report.BlockID, report.Skipped)
+		case report.Passed():
+			passed++
+			fmt.Printf("PASS %d %v\n", report.Height, report.BlockID)
+		default:
+			failed++
+			fmt.Printf("FAIL %d %v\n", report.Height, report.BlockID)
+			for _, mismatch := range report.Mismatches {
+				fmt.Printf("    %s\n", mismatch)
+			}
+		}
+	}
+
+	fmt.Printf("re-executed %d blocks: %d passed, %d failed, %d skipped\n",
+		flagToHeight-flagFromHeight+1, passed, failed, skipped)
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// loadCheckpoint loads the tries of the checkpoint, and returns the snapshots starting at the
+// state the block at the given height was executed on.
+func loadCheckpoint(
+	storages *storage.All,
+	checkpoint string,
+	fromHeight uint64,
+	capacity int,
+) (*ledgerSnapshots, error) {
+	parent, err := storages.Headers.ByHeight(fromHeight - 1)
+	if err != nil {
+		return nil, fmt.Errorf("could not get block at height %d: %w", fromHeight-1, err)
+	}
+
+	startState, err := parentFinalState(storages, parent.ID())
+	if err != nil {
+		return nil, fmt.Errorf("could not get the state the block at height %d starts from: %w", fromHeight, err)
+	}
+
+	log.Info().Str("checkpoint", checkpoint).Msg("loading checkpoint")
+
+	tries, err := wal.LoadCheckpoint(checkpoint, log.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not load checkpoint: %w", err)
+	}
+
+	l, err := newForestLedger(tries, capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	if !l.HasState(ledger.State(startState)) {
+		return nil, fmt.Errorf("checkpoint does not contain the state %v of the block at height %d", startState, fromHeight-1)
+	}
+
+	return &ledgerSnapshots{
+		ledger: l,
+		commit: startState,
+	}, nil
+}
+
+// parentFinalState returns the final state of the block, as sealed or as stored by the execution node.
+func parentFinalState(storages *storage.All, blockID flow.Identifier) (flow.StateCommitment, error) {
+	seal, err := storages.Seals.FinalizedSealForBlock(blockID)
+	if err == nil {
+		return seal.FinalState, nil
+	}
+	return storages.Commits.ByBlockID(blockID)
+}
@@ -0,0 +1,111 @@
+package reexecute_blocks
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+// forestLedger is an in-memory ledger holding the tries loaded from a checkpoint, and the tries
+// created by re-executing blocks on top of them.
+// Unlike complete.Ledger, updates are not written to a WAL, so the execution state on disk is
+// never modified.
+type forestLedger struct {
+	module.NoopReadyDoneAware
+	forest *mtrie.Forest
+}
+
+var _ ledger.Ledger = (*forestLedger)(nil)
+
+// newForestLedger returns a ledger holding the given tries. At most capacity tries are kept in
+// memory, the least recently used tries are evicted first.
+func newForestLedger(tries []*trie.MTrie, capacity int) (*forestLedger, error) {
+	forest, err := mtrie.NewForest(capacity, &metrics.NoopCollector{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create forest: %w", err)
+	}
+
+	err = forest.AddTries(tries)
+	if err != nil {
+		return nil, fmt.Errorf("could not add tries to forest: %w", err)
+	}
+
+	return &forestLedger{forest: forest}, nil
+}
+
+func (l *forestLedger) InitialState() ledger.State {
+	return ledger.State(l.forest.GetEmptyRootHash())
+}
+
+func (l *forestLedger) HasState(state ledger.State) bool {
+	return l.forest.HasTrie(ledger.RootHash(state))
+}
+
+func (l *forestLedger) GetSingleValue(query *ledger.QuerySingleValue) (ledger.Value, error) {
+	path, err := pathfinder.KeyToPath(query.Key(), complete.DefaultPathFinderVersion)
+	if err != nil {
+		return nil, err
+	}
+	return l.forest.ReadSingleValue(&ledger.TrieReadSingleValue{
+		RootHash: ledger.RootHash(query.State()),
+		Path:     path,
+	})
+}
+
+func (l *forestLedger) Get(query *ledger.Query) ([]ledger.Value, error) {
+	paths, err := pathfinder.KeysToPaths(query.Keys(), complete.DefaultPathFinderVersion)
+	if err != nil {
+		return nil, err
+	}
+	return l.forest.Read(&ledger.TrieRead{
+		RootHash: ledger.RootHash(query.State()),
+		Paths:    paths,
+	})
+}
+
+func (l *forestLedger) Set(update *ledger.Update) (ledger.State, *ledger.TrieUpdate, error) {
+	if update.Size() == 0 {
+		return update.State(),
+			&ledger.TrieUpdate{
+				RootHash: ledger.RootHash(update.State()),
+				Paths:    []ledger.Path{},
+				Payloads: []*ledger.Payload{},
+			},
+			nil
+	}
+
+	trieUpdate, err := pathfinder.UpdateToTrieUpdate(update, complete.DefaultPathFinderVersion)
+	if err != nil {
+		return ledger.DummyState, nil, err
+	}
+
+	rootHash, err := l.forest.Update(trieUpdate)
+	if err != nil {
+		return ledger.DummyState, nil, fmt.Errorf("could not update state: %w", err)
+	}
+
+	return ledger.State(rootHash), trieUpdate, nil
+}
+
+func (l *forestLedger) Prove(query *ledger.Query) (ledger.Proof, error) {
+	paths, err := pathfinder.KeysToPaths(query.Keys(), complete.DefaultPathFinderVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	batchProof, err := l.forest.Proofs(&ledger.TrieRead{
+		RootHash: ledger.RootHash(query.State()),
+		Paths:    paths,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not get proofs: %w", err)
+	}
+
+	return ledger.EncodeTrieBatchProof(batchProof), nil
+}
@@ -0,0 +1,452 @@
+package reexecute_blocks
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/crypto"
+
+	"github.com/onflow/flow-go/engine/execution/computation"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/environment"
+	"github.com/onflow/flow-go/fvm/storage/derived"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/blobs"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	exedataprovider "github.com/onflow/flow-go/module/executiondatasync/provider"
+	"github.com/onflow/flow-go/module/executiondatasync/tracker"
+	"github.com/onflow/flow-go/module/local"
+	"github.com/onflow/flow-go/module/mempool/entity"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/network"
+	protocolstate "github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// BlockReport is the outcome of re-executing a single block.
+type BlockReport struct {
+	Height  uint64
+	BlockID flow.Identifier
+	// Skipped is set if the block could not be compared, for example because no execution result
+	// is stored for it.
+	Skipped string
+	// Mismatches lists the differences between the computed and the stored execution result.
+	Mismatches []string
+}
+
+// Passed returns true if the computed execution result matches the stored one.
+func (r *BlockReport) Passed() bool {
+	return r.Skipped == "" && len(r.Mismatches) == 0
+}
+
+// snapshotProvider provides the execution state a block is executed on.
+type snapshotProvider interface {
+	// snapshot returns the storage snapshot at the start of the block, and its state commitment.
+	snapshot(header *flow.Header) (snapshot.StorageSnapshot, flow.StateCommitment, error)
+
+	// executed is called with the final state commitment of each executed block.
+	executed(commit flow.StateCommitment)
+
+	// verifiesStates returns true if the state commitments computed by the committer are
+	// comparable with the ones of the stored execution results.
+	verifiesStates() bool
+}
+
+// ledgerSnapshots provides the execution state from an in-memory ledger initialized from a
+// checkpoint. Blocks are executed on the state computed for their parent, so the state
+// commitments are verified.
+type ledgerSnapshots struct {
+	ledger *forestLedger
+	commit flow.StateCommitment
+}
+
+func (l *ledgerSnapshots) snapshot(_ *flow.Header) (snapshot.StorageSnapshot, flow.StateCommitment, error) {
+	if !l.ledger.HasState(ledger.State(l.commit)) {
+		return nil, flow.DummyStateCommitment, fmt.Errorf("state %v is not in the ledger", l.commit)
+	}
+	return state.NewLedgerStorageSnapshot(l.ledger, l.commit), l.commit, nil
+}
+
+func (l *ledgerSnapshots) executed(commit flow.StateCommitment) {
+	l.commit = commit
+}
+
+func (l *ledgerSnapshots) verifiesStates() bool {
+	return true
+}
+
+// registerSnapshots provides the execution state from a register store. Each block is executed
+// on the registers stored for its parent. The register store doesn't hold the trie, so state
+// commitments can't be computed and are not verified, and the start state of blocks is reported
+// as flow.DummyStateCommitment.
+type registerSnapshots struct {
+	registers storage.RegisterIndex
+}
+
+func (r *registerSnapshots) snapshot(header *flow.Header) (snapshot.StorageSnapshot, flow.StateCommitment, error) {
+	height := header.Height - 1
+	if height < r.registers.FirstHeight() || height > r.registers.LatestHeight() {
+		return nil, flow.DummyStateCommitment, fmt.Errorf("height %d is not indexed, indexed range: [%d-%d]",
+			height, r.registers.FirstHeight(), r.registers.LatestHeight())
+	}
+
+	return snapshot.NewReadFuncStorageSnapshot(func(id flow.RegisterID) (flow.RegisterValue, error) {
+		value, err := r.registers.Get(id, height)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		return value, err
+	}), flow.DummyStateCommitment, nil
+}
+
+func (r *registerSnapshots) executed(flow.StateCommitment) {}
+
+func (r *registerSnapshots) verifiesStates() bool {
+	return false
+}
+
+// reExecutor re-executes blocks from the protocol database, and compares the results with the
+// stored execution results.
+type reExecutor struct {
+	log              zerolog.Logger
+	headers          storage.Headers
+	blocks           storage.Blocks
+	collections      storage.Collections
+	seals            storage.Seals
+	results          storage.ExecutionResults
+	snapshots        snapshotProvider
+	blockComputer    computer.BlockComputer
+	derivedChainData *derived.DerivedChainData
+}
+
+func newReExecutor(
+	log zerolog.Logger,
+	state protocolstate.State,
+	storages *storage.All,
+	snapshots snapshotProvider,
+	viewCommitter computer.ViewCommitter,
+) (*reExecutor, error) {
+	chainID := state.Params().ChainID()
+
+	vm := fvm.NewVirtualMachine()
+	opts := append(
+		fvmOptions(chainID),
+		fvm.WithLogger(log.With().Str("module", "FVM").Logger()),
+		fvm.WithBlocks(environment.NewBlockFinder(storages.Headers)),
+	)
+	opts = append(opts, computation.DefaultFVMOptions(chainID, false, false)...)
+	vmCtx := fvm.NewContext(opts...)
+
+	// the receipts of re-executed blocks are signed with a throwaway key
+	me, err := newThrowawayLocal()
+	if err != nil {
+		return nil, err
+	}
+
+	// the execution data is not stored, only its ID is computed
+	provider := exedataprovider.NewProvider(
+		zerolog.Nop(),
+		metrics.NewNoopCollector(),
+		execution_data.DefaultSerializer,
+		newDiscardBlobService(),
+		&tracker.NoopStorage{},
+	)
+
+	blockComputer, err := computer.NewBlockComputer(
+		vm,
+		vmCtx,
+		metrics.NewNoopCollector(),
+		trace.NewNoopTracer(),
+		log.With().Str("component", "block_computer").Logger(),
+		viewCommitter,
+		me,
+		provider,
+		nil,
+		state,
+		1,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create block computer: %w", err)
+	}
+
+	derivedChainData, err := derived.NewDerivedChainData(derived.DefaultDerivedDataCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("could not create derived chain data: %w", err)
+	}
+
+	return &reExecutor{
+		log:              log,
+		headers:          storages.Headers,
+		blocks:           storages.Blocks,
+		collections:      storages.Collections,
+		seals:            storages.Seals,
+		results:          storages.Results,
+		snapshots:        snapshots,
+		blockComputer:    blockComputer,
+		derivedChainData: derivedChainData,
+	}, nil
+}
+
+// fvmOptions returns the chain specific options execution nodes run the FVM with.
+func fvmOptions(chainID flow.ChainID) []fvm.Option {
+	opts := []fvm.Option{
+		fvm.WithChain(chainID.Chain()),
+		fvm.WithAccountStorageLimit(true),
+	}
+	switch chainID {
+	case flow.Testnet,
+		flow.Sandboxnet,
+		flow.Previewnet,
+		flow.Mainnet:
+		opts = append(opts,
+			fvm.WithTransactionFeesEnabled(true),
+		)
+	}
+	switch chainID {
+	case flow.Testnet,
+		flow.Sandboxnet,
+		flow.Previewnet,
+		flow.Localnet,
+		flow.Benchnet:
+		opts = append(opts,
+			fvm.WithContractDeploymentRestricted(false),
+		)
+	}
+	return opts
+}
+
+// expectedResult returns the execution result the block was sealed with, or the result stored
+// for the block if it is not sealed.
+func (e *reExecutor) expectedResult(blockID flow.Identifier) (*flow.ExecutionResult, error) {
+	seal, err := e.seals.FinalizedSealForBlock(blockID)
+	if err == nil {
+		return e.results.ByID(seal.ResultID)
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("could not get seal: %w", err)
+	}
+	return e.results.ByBlockID(blockID)
+}
+
+// executeHeight re-executes the finalized block at the given height, and compares the result with
+// the stored execution result.
+// Blocks without a stored execution result are still executed, so the execution state of the
+// following blocks is correct, but they are reported as skipped.
+// No error returns are expected during normal operation.
+func (e *reExecutor) executeHeight(ctx context.Context, height uint64) (*BlockReport, error) {
+	header, err := e.headers.ByHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("could not get header at height %d: %w", height, err)
+	}
+	blockID := header.ID()
+	report := &BlockReport{
+		Height:  height,
+		BlockID: blockID,
+	}
+
+	// the computed result is based on the same previous result as the expected result, so
+	// their IDs are comparable
+	parentResultID := flow.ZeroID
+	expected, err := e.expectedResult(blockID)
+	if err == nil {
+		parentResultID = expected.PreviousResultID
+	} else if !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("could not get execution result of block %v: %w", blockID, err)
+	}
+
+	block, err := e.blocks.ByID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get block %v: %w", blockID, err)
+	}
+
+	collections := make(map[flow.Identifier]*entity.CompleteCollection, len(block.Payload.Guarantees))
+	for _, guarantee := range block.Payload.Guarantees {
+		collection, err := e.collections.ByID(guarantee.CollectionID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get collection %v: %w", guarantee.CollectionID, err)
+		}
+		collections[guarantee.CollectionID] = &entity.CompleteCollection{
+			Guarantee:    guarantee,
+			Transactions: collection.Transactions,
+		}
+	}
+
+	storageSnapshot, startState, err := e.snapshots.snapshot(header)
+	if err != nil {
+		return nil, fmt.Errorf("could not get execution state of block %v: %w", blockID, err)
+	}
+
+	executableBlock := &entity.ExecutableBlock{
+		Block:               block,
+		CompleteCollections: collections,
+		StartState:          &startState,
+	}
+
+	derivedBlockData := e.derivedChainData.GetOrCreateDerivedBlockData(blockID, header.ParentID)
+
+	computationResult, err := e.blockComputer.ExecuteBlock(
+		ctx,
+		parentResultID,
+		executableBlock,
+		storageSnapshot,
+		derivedBlockData,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute block %v: %w", blockID, err)
+	}
+
+	computed := &computationResult.ExecutionReceipt.ExecutionResult
+	if finalState, err := computed.FinalStateCommitment(); err == nil {
+		e.snapshots.executed(finalState)
+	}
+
+	if expected == nil {
+		report.Skipped = "no execution result stored for the block"
+		return report, nil
+	}
+
+	report.Mismatches, err = compareResults(expected, computed, e.snapshots.verifiesStates())
+	if err != nil {
+		return nil, fmt.Errorf("could not compare execution results of block %v: %w", blockID, err)
+	}
+
+	return report, nil
+}
+
+// compareResults returns the differences between the expected and the computed execution result.
+// State commitments, execution data and result IDs are only compared if verifyStates is true.
+func compareResults(expected *flow.ExecutionResult, computed *flow.ExecutionResult, verifyStates bool) ([]string, error) {
+	var mismatches []string
+	mismatch := func(format string, args ...interface{}) {
+		mismatches = append(mismatches, fmt.Sprintf(format, args...))
+	}
+
+	if len(expected.Chunks) != len(computed.Chunks) {
+		mismatch("number of chunks: expected %d, computed %d", len(expected.Chunks), len(computed.Chunks))
+	}
+
+	for i := 0; i < len(expected.Chunks) && i < len(computed.Chunks); i++ {
+		e := expected.Chunks[i]
+		c := computed.Chunks[i]
+
+		if verifyStates {
+			if e.StartState != c.StartState {
+				mismatch("chunk %d start state: expected %v, computed %v", i, e.StartState, c.StartState)
+			}
+			if e.EndState != c.EndState {
+				mismatch("chunk %d end state: expected %v, computed %v", i, e.EndState, c.EndState)
+			}
+		}
+		if e.EventCollection != c.EventCollection {
+			mismatch("chunk %d event collection: expected %v, computed %v", i, e.EventCollection, c.EventCollection)
+		}
+		if e.NumberOfTransactions != c.NumberOfTransactions {
+			mismatch("chunk %d number of transactions: expected %d, computed %d", i, e.NumberOfTransactions, c.NumberOfTransactions)
+		}
+		if e.TotalComputationUsed != c.TotalComputationUsed {
+			mismatch("chunk %d computation used: expected %d, computed %d", i, e.TotalComputationUsed, c.TotalComputationUsed)
+		}
+	}
+
+	sameServiceEvents, err := expected.ServiceEvents.EqualTo(computed.ServiceEvents)
+	if err != nil {
+		return nil, fmt.Errorf("could not compare service events: %w", err)
+	}
+	if !sameServiceEvents {
+		mismatch("service events: expected %d events, computed %d events", len(expected.ServiceEvents), len(computed.ServiceEvents))
+	}
+
+	if verifyStates {
+		if expected.ExecutionDataID != computed.ExecutionDataID {
+			mismatch("execution data ID: expected %v, computed %v", expected.ExecutionDataID, computed.ExecutionDataID)
+		}
+		// the result ID covers all fields of the result, so it only differs if any of the
+		// above differs, or the results are based on different previous results.
+		if len(mismatches) == 0 && expected.ID() != computed.ID() {
+			mismatch("result ID: expected %v, computed %v", expected.ID(), computed.ID())
+		}
+	}
+
+	return mismatches, nil
+}
+
+// newThrowawayLocal returns a local identity with a random staking key.
+func newThrowawayLocal() (*local.Local, error) {
+	seed := make([]byte, crypto.KeyGenSeedMinLen)
+	_, err := rand.Read(seed)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate seed: %w", err)
+	}
+
+	sk, err := crypto.GeneratePrivateKey(crypto.BLSBLS12381, seed)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate staking key: %w", err)
+	}
+
+	me, err := local.New(flow.IdentitySkeleton{
+		NodeID:        flow.ZeroID,
+		Role:          flow.RoleExecution,
+		StakingPubKey: sk.PublicKey(),
+	}, sk)
+	if err != nil {
+		return nil, fmt.Errorf("could not create local identity: %w", err)
+	}
+	return me, nil
+}
+
+// discardBlobService is a blob service which discards all blobs added to it. It is used to compute
+// the execution data IDs of re-executed blocks without storing the execution data.
+type discardBlobService struct {
+	component.Component
+}
+
+var _ network.BlobService = (*discardBlobService)(nil)
+
+func newDiscardBlobService() *discardBlobService {
+	return &discardBlobService{
+		Component: component.NewComponentManagerBuilder().Build(),
+	}
+}
+
+func (*discardBlobService) GetBlob(context.Context, cid.Cid) (blobs.Blob, error) {
+	return nil, network.ErrBlobNotFound
+}
+
+func (*discardBlobService) GetBlobs(context.Context, []cid.Cid) <-chan blobs.Blob {
+	ch := make(chan blobs.Blob)
+	close(ch)
+	return ch
+}
+
+func (*discardBlobService) AddBlob(context.Context, blobs.Blob) error {
+	return nil
+}
+
+func (*discardBlobService) AddBlobs(context.Context, []blobs.Blob) error {
+	return nil
+}
+
+func (*discardBlobService) DeleteBlob(context.Context, cid.Cid) error {
+	return nil
+}
+
+func (s *discardBlobService) GetSession(context.Context) network.BlobGetter {
+	return s
+}
+
+func (*discardBlobService) TriggerReprovide(context.Context) error {
+	return nil
+}
@@ -0,0 +1,129 @@
+package reexecute_blocks
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/testutils"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestForestLedger tests that the forest ledger computes the same states as the complete ledger.
+func TestForestLedger(t *testing.T) {
+	completeLedger, err := complete.NewLedger(&fixtures.NoopWAL{}, 100, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion)
+	require.NoError(t, err)
+	compactor := fixtures.NewNoopCompactor(completeLedger)
+	<-compactor.Ready()
+	defer func() {
+		<-completeLedger.Done()
+		<-compactor.Done()
+	}()
+
+	forestLedger, err := newForestLedger([]*trie.MTrie{trie.NewEmptyMTrie()}, 100)
+	require.NoError(t, err)
+	require.Equal(t, completeLedger.InitialState(), forestLedger.InitialState())
+
+	keys := testutils.RandomUniqueKeys(10, 2, 1, 10)
+	values := testutils.RandomValues(10, 1, 32)
+
+	update, err := ledger.NewUpdate(completeLedger.InitialState(), keys, values)
+	require.NoError(t, err)
+
+	expectedState, expectedTrieUpdate, err := completeLedger.Set(update)
+	require.NoError(t, err)
+
+	state, trieUpdate, err := forestLedger.Set(update)
+	require.NoError(t, err)
+	require.Equal(t, expectedState, state)
+	require.True(t, expectedTrieUpdate.Equals(trieUpdate))
+	require.True(t, forestLedger.HasState(state))
+
+	query, err := ledger.NewQuery(state, keys)
+	require.NoError(t, err)
+
+	read, err := forestLedger.Get(query)
+	require.NoError(t, err)
+	require.Equal(t, values, read)
+
+	expectedProof, err := completeLedger.Prove(query)
+	require.NoError(t, err)
+	proof, err := forestLedger.Prove(query)
+	require.NoError(t, err)
+	require.Equal(t, expectedProof, proof)
+}
+
+func TestCompareResults(t *testing.T) {
+	expected := unittest.ExecutionResultFixture(unittest.WithServiceEvents(1))
+
+	copyResult := func() *flow.ExecutionResult {
+		computed := *expected
+		computed.Chunks = make(flow.ChunkList, len(expected.Chunks))
+		for i, chunk := range expected.Chunks {
+			c := *chunk
+			computed.Chunks[i] = &c
+		}
+		return &computed
+	}
+
+	t.Run("matching results", func(t *testing.T) {
+		mismatches, err := compareResults(expected, copyResult(), true)
+		require.NoError(t, err)
+		require.Empty(t, mismatches)
+	})
+
+	t.Run("mismatching states", func(t *testing.T) {
+		computed := copyResult()
+		computed.Chunks[1].EndState = unittest.StateCommitmentFixture()
+		computed.ExecutionDataID = unittest.IdentifierFixture()
+
+		mismatches, err := compareResults(expected, computed, true)
+		require.NoError(t, err)
+		require.Len(t, mismatches, 2)
+		require.Contains(t, mismatches[0], "chunk 1 end state")
+		require.Contains(t, mismatches[1], "execution data ID")
+
+		// states are not compared without a trie
+		mismatches, err = compareResults(expected, computed, false)
+		require.NoError(t, err)
+		require.Empty(t, mismatches)
+	})
+
+	t.Run("mismatching events", func(t *testing.T) {
+		computed := copyResult()
+		computed.Chunks[0].EventCollection = unittest.IdentifierFixture()
+		computed.ServiceEvents = nil
+
+		mismatches, err := compareResults(expected, computed, false)
+		require.NoError(t, err)
+		require.Len(t, mismatches, 2)
+		require.Contains(t, mismatches[0], "chunk 0 event collection")
+		require.Contains(t, mismatches[1], "service events")
+	})
+
+	t.Run("different previous result", func(t *testing.T) {
+		computed := copyResult()
+		computed.PreviousResultID = unittest.IdentifierFixture()
+
+		mismatches, err := compareResults(expected, computed, true)
+		require.NoError(t, err)
+		require.Len(t, mismatches, 1)
+		require.Contains(t, mismatches[0], "result ID")
+	})
+
+	t.Run("missing chunk", func(t *testing.T) {
+		computed := copyResult()
+		computed.Chunks = computed.Chunks[:1]
+
+		mismatches, err := compareResults(expected, computed, false)
+		require.NoError(t, err)
+		require.Equal(t, []string{"number of chunks: expected 2, computed 1"}, mismatches)
+	})
+}
@@ -10,13 +10,17 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	account_storage_diff "github.com/onflow/flow-go/cmd/util/cmd/account-storage-diff"
 	"github.com/onflow/flow-go/cmd/util/cmd/addresses"
 	"github.com/onflow/flow-go/cmd/util/cmd/atree_inlined_status"
 	bootstrap_execution_state_payloads "github.com/onflow/flow-go/cmd/util/cmd/bootstrap-execution-state-payloads"
 	check_storage "github.com/onflow/flow-go/cmd/util/cmd/check-storage"
 	checkpoint_collect_stats "github.com/onflow/flow-go/cmd/util/cmd/checkpoint-collect-stats"
+	checkpoint_convert "github.com/onflow/flow-go/cmd/util/cmd/checkpoint-convert"
 	checkpoint_list_tries "github.com/onflow/flow-go/cmd/util/cmd/checkpoint-list-tries"
 	checkpoint_trie_stats "github.com/onflow/flow-go/cmd/util/cmd/checkpoint-trie-stats"
+	checkpoint_verify "github.com/onflow/flow-go/cmd/util/cmd/checkpoint-verify"
+	contract_dependencies "github.com/onflow/flow-go/cmd/util/cmd/contract-dependencies"
 	debug_script "github.com/onflow/flow-go/cmd/util/cmd/debug-script"
 	debug_tx "github.com/onflow/flow-go/cmd/util/cmd/debug-tx"
 	diff_states "github.com/onflow/flow-go/cmd/util/cmd/diff-states"
@@ -35,12 +39,14 @@ import (
 	read_execution_state "github.com/onflow/flow-go/cmd/util/cmd/read-execution-state"
 	read_hotstuff "github.com/onflow/flow-go/cmd/util/cmd/read-hotstuff/cmd"
 	read_protocol_state "github.com/onflow/flow-go/cmd/util/cmd/read-protocol-state/cmd"
+	reexecute_blocks "github.com/onflow/flow-go/cmd/util/cmd/reexecute-blocks"
 	index_er "github.com/onflow/flow-go/cmd/util/cmd/reindex/cmd"
 	rollback_executed_height "github.com/onflow/flow-go/cmd/util/cmd/rollback-executed-height/cmd"
 	run_script "github.com/onflow/flow-go/cmd/util/cmd/run-script"
 	"github.com/onflow/flow-go/cmd/util/cmd/snapshot"
 	system_addresses "github.com/onflow/flow-go/cmd/util/cmd/system-addresses"
 	truncate_database "github.com/onflow/flow-go/cmd/util/cmd/truncate-database"
+	validate_contract_update "github.com/onflow/flow-go/cmd/util/cmd/validate-contract-update"
 	"github.com/onflow/flow-go/cmd/util/cmd/version"
 	"github.com/onflow/flow-go/module/profiler"
 )
@@ -126,6 +132,12 @@ func addCommands() {
 	rootCmd.AddCommand(debug_script.Cmd)
 	rootCmd.AddCommand(generate_authorization_fixes.Cmd)
 	rootCmd.AddCommand(evm_state_exporter.Cmd)
+	rootCmd.AddCommand(account_storage_diff.Cmd)
+	rootCmd.AddCommand(contract_dependencies.Cmd)
+	rootCmd.AddCommand(reexecute_blocks.Cmd)
+	rootCmd.AddCommand(validate_contract_update.Cmd)
+	rootCmd.AddCommand(checkpoint_convert.Cmd)
+	rootCmd.AddCommand(checkpoint_verify.Cmd)
 }
 
 func initConfig() {
@@ -2,6 +2,7 @@ package run_script
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -9,6 +10,7 @@ import (
 
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/flow/protobuf/go/flow/entities"
+	gethCommon "github.com/onflow/go-ethereum/common"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
@@ -20,6 +22,7 @@ import (
 	"github.com/onflow/flow-go/engine/access/subscription"
 	"github.com/onflow/flow-go/engine/execution/computation"
 	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/evm/debug"
 	"github.com/onflow/flow-go/fvm/storage/snapshot"
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/model/flow"
@@ -423,6 +426,14 @@ func (*api) ExecuteScriptAtBlockID(
 	return nil, errors.New("unimplemented")
 }
 
+func (*api) ExecuteScriptsAtBlockHeight(
+	_ context.Context,
+	_ uint64,
+	_ []access.Script,
+) ([]access.ScriptResult, error) {
+	return nil, errors.New("unimplemented")
+}
+
 func (a *api) GetEventsForHeightRange(
 	_ context.Context,
 	_ string,
@@ -461,6 +472,101 @@ func (*api) GetExecutionResultByID(_ context.Context, _ flow.Identifier) (*flow.
 	return nil, errors.New("unimplemented")
 }
 
+func (*api) GetAccountTransactions(
+	_ context.Context,
+	_ flow.Address,
+	_ uint64,
+	_ uint64,
+	_ *flow.AccountTransactionCursor,
+	_ uint32,
+) (*access.AccountTransactionsPage, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (*api) GetEVMLogs(
+	_ context.Context,
+	_ flow.EVMLogFilter,
+	_ uint64,
+	_ uint64,
+	_ *flow.EVMLogCursor,
+	_ uint32,
+) (*access.EVMLogsPage, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (*api) SimulateTransaction(
+	_ context.Context,
+	_ *flow.TransactionBody,
+	_ uint64,
+	_ bool,
+) (*flow.TransactionSimulationResult, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (*api) GetAccountStorageDiff(
+	_ context.Context,
+	_ flow.Address,
+	_ uint64,
+	_ uint64,
+	_ *string,
+	_ uint32,
+	_ bool,
+) (*flow.AccountStorageDiff, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (*api) GetContractImpact(
+	_ context.Context,
+	_ flow.ContractID,
+	_ uint64,
+	_ []flow.Address,
+) (*flow.ContractImpact, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (*api) GetAccountStateProof(
+	_ context.Context,
+	_ flow.Address,
+	_ []string,
+	_ uint64,
+) (*flow.AccountStateProof, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (*api) GetEVMBlockTraces(
+	_ context.Context,
+	_ flow.Identifier,
+	_ debug.TracerConfig,
+) ([]debug.TransactionTrace, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (*api) GetEVMTransactionTrace(
+	_ context.Context,
+	_ flow.Identifier,
+	_ gethCommon.Hash,
+	_ debug.TracerConfig,
+) (json.RawMessage, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (*api) EstimateTransactionFees(
+	_ context.Context,
+	_ *flow.TransactionBody,
+	_ uint64,
+) (*flow.TransactionFeeEstimate, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (*api) ValidateContractUpdate(
+	_ context.Context,
+	_ flow.Address,
+	_ []byte,
+	_ uint64,
+) (*flow.ContractUpdateValidation, error) {
+	return nil, errors.New("unimplemented")
+}
+
 func (*api) SubscribeBlocksFromStartBlockID(
 	_ context.Context,
 	_ flow.Identifier,
@@ -537,3 +643,11 @@ func (*api) SubscribeTransactionStatuses(
 ) subscription.Subscription {
 	return nil
 }
+
+func (*api) SubscribeEVMLogsFromStartHeight(
+	_ context.Context,
+	_ uint64,
+	_ flow.EVMLogFilter,
+) subscription.Subscription {
+	return nil
+}
@@ -0,0 +1,211 @@
+package validate_contract_update
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/ledger/util"
+	"github.com/onflow/flow-go/cmd/util/ledger/util/registers"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/execution/computation"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	pstorage "github.com/onflow/flow-go/storage/pebble"
+)
+
+var (
+	flagRegisterDir     string
+	flagHeight          uint64
+	flagPayloads        string
+	flagState           string
+	flagStateCommitment string
+	flagChain           string
+	flagAddress         string
+	flagCode            string
+)
+
+var Cmd = &cobra.Command{
+	Use:   "validate-contract-update",
+	Short: "Validates an update of a contract against the execution state, without executing it",
+	Long: `Validates an update of a contract of an account to new code against the execution state,
+and prints all reasons for which the update would be rejected. Exits with status 1 if the update is invalid.`,
+	Run: run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagRegisterDir, "register-dir", "",
+		"directory of the pebble register index")
+
+	Cmd.Flags().Uint64Var(&flagHeight, "height", 0,
+		"height to read the registers at, when reading from the register index (default: latest indexed height)")
+
+	Cmd.Flags().StringVar(&flagPayloads, "payloads", "",
+		"input payload file name")
+
+	Cmd.Flags().StringVar(&flagState, "state", "",
+		"directory of the execution state checkpoint")
+
+	Cmd.Flags().StringVar(&flagStateCommitment, "state-commitment", "",
+		"state commitment of the checkpointed trie to read")
+
+	Cmd.Flags().StringVar(&flagChain, "chain", "",
+		"chain name")
+	_ = Cmd.MarkFlagRequired("chain")
+
+	Cmd.Flags().StringVar(&flagAddress, "address", "",
+		"address of the account the contract is deployed to")
+	_ = Cmd.MarkFlagRequired("address")
+
+	Cmd.Flags().StringVar(&flagCode, "code", "",
+		"file containing the new code of the contract")
+	_ = Cmd.MarkFlagRequired("code")
+}
+
+func run(*cobra.Command, []string) {
+	chainID := flow.ChainID(flagChain)
+	// validate chain ID
+	_ = chainID.Chain()
+
+	address, err := flow.StringToAddress(flagAddress)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid address")
+	}
+
+	sources := 0
+	for _, flag := range []string{flagRegisterDir, flagPayloads, flagState} {
+		if flag != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		log.Fatal().Msg("exactly one of --register-dir, --payloads or --state must be provided")
+	}
+	if flagState != "" && flagStateCommitment == "" {
+		log.Fatal().Msg("--state-commitment must be provided when --state is provided")
+	}
+
+	code, err := os.ReadFile(flagCode)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to read contract code")
+	}
+
+	var storageSnapshot snapshot.StorageSnapshot
+	var height uint64
+	if flagRegisterDir != "" {
+		db, err := pstorage.OpenRegisterPebbleDB(flagRegisterDir)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to open register db")
+		}
+		defer db.Close()
+
+		registerIndex, err := pstorage.NewRegisters(db, pstorage.PruningDisabled)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to initialize registers")
+		}
+
+		height, storageSnapshot = registerIndexSnapshot(registerIndex)
+	} else {
+		storageSnapshot = payloadSnapshot()
+	}
+
+	options := computation.DefaultFVMOptions(chainID, false, false)
+	ctx := fvm.NewContext(options...)
+
+	validation, err := fvm.ValidateContractUpdate(
+		fvm.NewVirtualMachine(),
+		ctx,
+		address,
+		code,
+		storageSnapshot)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to validate contract update")
+	}
+	validation.BlockHeight = height
+
+	var result models.ContractUpdateValidation
+	result.Build(validation)
+	// the state is not associated with a block
+	result.BlockId = ""
+	if flagRegisterDir == "" {
+		// checkpoints and payload files are not associated with a height
+		result.BlockHeight = ""
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(result)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to encode contract update validation")
+	}
+
+	if !validation.Valid() {
+		os.Exit(1)
+	}
+}
+
+// registerIndexSnapshot reads the registers from the register index at the requested height,
+// or at the latest indexed height if no height is requested.
+func registerIndexSnapshot(registerIndex *pstorage.Registers) (uint64, snapshot.StorageSnapshot) {
+	log.Info().Msgf(
+		"registers are indexed from %d to %d",
+		registerIndex.FirstHeight(),
+		registerIndex.LatestHeight(),
+	)
+
+	height := flagHeight
+	if height == 0 {
+		height = registerIndex.LatestHeight()
+	}
+	if height < registerIndex.FirstHeight() || height > registerIndex.LatestHeight() {
+		log.Fatal().Msgf("height %d is not indexed", height)
+	}
+
+	log.Info().Msgf("validating against registers at height %d", height)
+
+	return height, snapshot.NewReadFuncStorageSnapshot(func(id flow.RegisterID) (flow.RegisterValue, error) {
+		value, err := registerIndex.Get(id, height)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		return value, err
+	})
+}
+
+// payloadSnapshot reads the registers from the payload file or the checkpointed trie.
+func payloadSnapshot() snapshot.StorageSnapshot {
+	var payloads []*ledger.Payload
+	var err error
+
+	if flagPayloads != "" {
+		log.Info().Msgf("reading payloads from %s", flagPayloads)
+
+		_, payloads, err = util.ReadPayloadFile(log.Logger, flagPayloads)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to read payloads")
+		}
+	} else {
+		log.Info().Msgf("reading trie %s", flagStateCommitment)
+
+		stateCommitment := util.ParseStateCommitment(flagStateCommitment)
+		payloads, err = util.ReadTrie(flagState, stateCommitment)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to read state")
+		}
+	}
+
+	registersByAccount, err := registers.NewByAccountFromPayloads(payloads)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to group payloads by account")
+	}
+
+	return registers.StorageSnapshot{
+		Registers: registersByAccount,
+	}
+}
@@ -0,0 +1,382 @@
+package evmrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	gethABI "github.com/onflow/go-ethereum/accounts/abi"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/onflow/go-ethereum/common/hexutil"
+	gethVM "github.com/onflow/go-ethereum/core/vm"
+	gethRPC "github.com/onflow/go-ethereum/rpc"
+
+	"github.com/onflow/flow-go/fvm/evm/types"
+)
+
+// Namespace is the JSON-RPC namespace the API is registered under.
+const Namespace = "eth"
+
+// API implements the read-only methods of the Ethereum JSON-RPC API, served from the EVM
+// state indexed by the node. Methods are exposed as eth_<method name>.
+type API struct {
+	backend *Backend
+}
+
+// NewAPI returns a new EVM JSON-RPC API using the given backend.
+func NewAPI(backend *Backend) *API {
+	return &API{
+		backend: backend,
+	}
+}
+
+// TransactionArgs represents the arguments of a call.
+type TransactionArgs struct {
+	From  *gethCommon.Address `json:"from"`
+	To    *gethCommon.Address `json:"to"`
+	Gas   *hexutil.Uint64     `json:"gas"`
+	Value *hexutil.Big        `json:"value"`
+	Data  *hexutil.Bytes      `json:"data"`
+	Input *hexutil.Bytes      `json:"input"`
+}
+
+// data returns the input of the call, preferring the input field over the data field.
+func (args *TransactionArgs) data() []byte {
+	if args.Input != nil {
+		return *args.Input
+	}
+	if args.Data != nil {
+		return *args.Data
+	}
+	return nil
+}
+
+// ChainId returns the EVM chain ID.
+func (api *API) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(types.EVMChainIDFromFlowChainID(api.backend.ChainID()))
+}
+
+// BlockNumber returns the number of the latest indexed EVM block.
+func (api *API) BlockNumber() (hexutil.Uint64, error) {
+	_, block, err := api.backend.LatestBlock()
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(block.Height), nil
+}
+
+// GetBalance returns the balance of the given address at the end of the given block.
+func (api *API) GetBalance(
+	_ context.Context,
+	address gethCommon.Address,
+	blockNrOrHash gethRPC.BlockNumberOrHash,
+) (*hexutil.Big, error) {
+	height, _, err := api.resolve(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	view, err := api.backend.View(height)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := view.GetBalance(address)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(balance), nil
+}
+
+// GetTransactionCount returns the nonce of the given address at the end of the given block.
+func (api *API) GetTransactionCount(
+	_ context.Context,
+	address gethCommon.Address,
+	blockNrOrHash gethRPC.BlockNumberOrHash,
+) (*hexutil.Uint64, error) {
+	height, _, err := api.resolve(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	view, err := api.backend.View(height)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := view.GetNonce(address)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Uint64)(&nonce), nil
+}
+
+// GetCode returns the code of the given address at the end of the given block.
+func (api *API) GetCode(
+	_ context.Context,
+	address gethCommon.Address,
+	blockNrOrHash gethRPC.BlockNumberOrHash,
+) (hexutil.Bytes, error) {
+	height, _, err := api.resolve(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	view, err := api.backend.View(height)
+	if err != nil {
+		return nil, err
+	}
+	code, err := view.GetCode(address)
+	if err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+// GetStorageAt returns the value of the given storage slot of the given address at the end
+// of the given block.
+func (api *API) GetStorageAt(
+	_ context.Context,
+	address gethCommon.Address,
+	hexKey string,
+	blockNrOrHash gethRPC.BlockNumberOrHash,
+) (hexutil.Bytes, error) {
+	key, err := decodeHash(hexKey)
+	if err != nil {
+		return nil, err
+	}
+	height, _, err := api.resolve(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	view, err := api.backend.View(height)
+	if err != nil {
+		return nil, err
+	}
+	value, err := view.GetSlab(address, key)
+	if err != nil {
+		return nil, err
+	}
+	return value[:], nil
+}
+
+// Call executes the given call on top of the state at the end of the given block, without
+// creating a transaction, and returns the returned data. The gas limit of the call is capped
+// at the max call gas limit. The latest block is used if no block is given.
+func (api *API) Call(
+	_ context.Context,
+	args TransactionArgs,
+	blockNrOrHash *gethRPC.BlockNumberOrHash,
+) (hexutil.Bytes, error) {
+	height, err := api.resolveOrLatest(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	gasLimit := api.backend.MaxCallGasLimit()
+	if args.Gas != nil && uint64(*args.Gas) < gasLimit {
+		gasLimit = uint64(*args.Gas)
+	}
+
+	res, err := api.call(height, args, gasLimit)
+	if err != nil {
+		return nil, err
+	}
+	err = resultError(res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.ReturnedData, nil
+}
+
+// EstimateGas returns the lowest gas limit the given call succeeds with, when executed on
+// top of the state at the end of the given block. The latest block is used if no block is given.
+func (api *API) EstimateGas(
+	_ context.Context,
+	args TransactionArgs,
+	blockNrOrHash *gethRPC.BlockNumberOrHash,
+) (hexutil.Uint64, error) {
+	height, err := api.resolveOrLatest(blockNrOrHash)
+	if err != nil {
+		return 0, err
+	}
+
+	high := api.backend.MaxCallGasLimit()
+	if args.Gas != nil && uint64(*args.Gas) < high {
+		high = uint64(*args.Gas)
+	}
+
+	res, err := api.call(height, args, high)
+	if err != nil {
+		return 0, err
+	}
+	err = resultError(res)
+	if err != nil {
+		return 0, err
+	}
+
+	// the call may need more gas than it consumes, e.g. because of refunds or the gas
+	// withheld from nested calls, so the consumed gas is tried first, and if it is not
+	// enough the lowest gas limit the call succeeds with is searched for.
+	if res.GasConsumed >= high {
+		return hexutil.Uint64(high), nil
+	}
+	ok, err := api.succeeds(height, args, res.GasConsumed)
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		return hexutil.Uint64(res.GasConsumed), nil
+	}
+
+	low := res.GasConsumed
+	for low+1 < high {
+		mid := low + (high-low)/2
+		ok, err := api.succeeds(height, args, mid)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+
+	return hexutil.Uint64(high), nil
+}
+
+// GetBlockByNumber returns the given block, with the full transactions if fullTx is true
+// and only the transaction hashes otherwise. Returns nil if the block has not been executed yet.
+func (api *API) GetBlockByNumber(
+	_ context.Context,
+	number gethRPC.BlockNumber,
+	fullTx bool,
+) (map[string]interface{}, error) {
+	height, block, err := api.backend.BlockByNumber(number)
+	if err != nil {
+		if errors.Is(err, errBlockNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	txEvents, err := api.backend.Transactions(height, block)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalBlock(block, txEvents, fullTx)
+}
+
+// call executes the given call with the given gas limit on top of the state at the end
+// of the Flow block at the given height.
+func (api *API) call(height uint64, args TransactionArgs, gasLimit uint64) (*types.Result, error) {
+	view, err := api.backend.View(height)
+	if err != nil {
+		return nil, err
+	}
+
+	var from, to gethCommon.Address
+	if args.From != nil {
+		from = *args.From
+	}
+	if args.To != nil {
+		to = *args.To
+	}
+	value := big.NewInt(0)
+	if args.Value != nil {
+		value = args.Value.ToInt()
+	}
+
+	return view.DryCall(from, to, args.data(), value, gasLimit)
+}
+
+// succeeds returns true if the given call succeeds with the given gas limit.
+func (api *API) succeeds(height uint64, args TransactionArgs, gasLimit uint64) (bool, error) {
+	res, err := api.call(height, args, gasLimit)
+	if err != nil {
+		return false, err
+	}
+	return !res.Invalid() && !res.Failed(), nil
+}
+
+// resolve returns the height of the Flow block whose state matches the given block.
+func (api *API) resolve(blockNrOrHash gethRPC.BlockNumberOrHash) (uint64, *types.Block, error) {
+	height, block, err := api.backend.BlockByNumberOrHash(blockNrOrHash)
+	if err != nil {
+		if errors.Is(err, errBlockNotFound) {
+			return 0, nil, errors.New("header not found")
+		}
+		return 0, nil, err
+	}
+	return height, block, nil
+}
+
+// resolveOrLatest is like resolve, but resolves to the latest block if no block is given.
+func (api *API) resolveOrLatest(blockNrOrHash *gethRPC.BlockNumberOrHash) (uint64, error) {
+	ref := gethRPC.BlockNumberOrHashWithNumber(gethRPC.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		ref = *blockNrOrHash
+	}
+	height, _, err := api.resolve(ref)
+	return height, err
+}
+
+// resultError returns the error of the call with the given result, if it failed.
+func resultError(res *types.Result) error {
+	if res.Invalid() {
+		return res.ValidationError
+	}
+	if !res.Failed() {
+		return nil
+	}
+	if errors.Is(res.VMError, gethVM.ErrExecutionReverted) {
+		return newRevertError(res.ReturnedData)
+	}
+	return res.VMError
+}
+
+// revertError is the error of a reverted call, carrying the revert reason
+// as error data, as expected by Ethereum tooling.
+type revertError struct {
+	error
+	reason string
+}
+
+func newRevertError(data []byte) *revertError {
+	err := gethVM.ErrExecutionReverted
+	reason, errUnpack := gethABI.UnpackRevert(data)
+	if errUnpack == nil {
+		err = fmt.Errorf("%w: %v", gethVM.ErrExecutionReverted, reason)
+	}
+	return &revertError{
+		error:  err,
+		reason: hexutil.Encode(data),
+	}
+}
+
+// ErrorCode returns the JSON-RPC error code of a revert.
+func (e *revertError) ErrorCode() int {
+	return 3
+}
+
+// ErrorData returns the hex encoded revert reason.
+func (e *revertError) ErrorData() interface{} {
+	return e.reason
+}
+
+// decodeHash decodes a hex encoded storage key of up to 32 bytes.
+func decodeHash(s string) (gethCommon.Hash, error) {
+	if len(s) >= 2 && (s[:2] == "0x" || s[:2] == "0X") {
+		s = s[2:]
+	}
+	if len(s)%2 == 1 {
+		s = "0" + s
+	}
+	b, err := hexutil.Decode("0x" + s)
+	if err != nil {
+		return gethCommon.Hash{}, fmt.Errorf("invalid hex storage key: %w", err)
+	}
+	if len(b) > gethCommon.HashLength {
+		return gethCommon.Hash{}, errors.New("storage key is longer than 32 bytes")
+	}
+	return gethCommon.BytesToHash(b), nil
+}
@@ -0,0 +1,305 @@
+package evmrpc_test
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onflow/cadence/common"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/onflow/go-ethereum/common/hexutil"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	gethRPC "github.com/onflow/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/access/evmrpc"
+	"github.com/onflow/flow-go/engine/access/index"
+	"github.com/onflow/flow-go/fvm/evm/events"
+	. "github.com/onflow/flow-go/fvm/evm/testutils"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	syncmock "github.com/onflow/flow-go/module/state_synchronization/mock"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestAPI checks the EVM JSON-RPC API against states of an EVM chain indexed at the
+// following Flow heights:
+//   - 10: genesis EVM block, with the test contract deployed and the test account funded
+//   - 11: EVM block 1, storing 42
+//   - 12: no EVM block
+//   - 13: EVM block 2, storing 43 and emitting a log
+func TestAPI(t *testing.T) {
+
+	const chainID = flow.Emulator
+	RunWithTestBackend(t, func(backend *TestBackend) {
+		RunWithTestFlowEVMRootAddress(t, backend, func(rootAddr flow.Address) {
+			RunWithDeployedContract(t,
+				GetStorageTestContract(t), backend, rootAddr, func(testContract *TestContract) {
+					RunWithEOATestAccount(t, backend, rootAddr, func(testAccount *EOATestAccount) {
+						handler := SetupHandler(chainID, backend, rootAddr)
+						gasFeeCollector := RandomAddress(t)
+
+						states := map[uint64]*TestValueStore{}
+						flowEvents := map[uint64][]flow.Event{}
+						blockIDs := map[uint64]flow.Identifier{}
+						txHashes := map[uint64]gethCommon.Hash{}
+
+						commitBlock := func(height uint64, method string, value int64) {
+							tx := testAccount.PrepareSignAndEncodeTx(t,
+								testContract.DeployedAt.ToCommon(),
+								testContract.MakeCallData(t, method, big.NewInt(value)),
+								big.NewInt(0),
+								uint64(100_000),
+								big.NewInt(0),
+							)
+							rs := handler.Run(tx, gasFeeCollector)
+							require.Equal(t, types.ErrorCode(0), rs.ErrorCode)
+							handler.CommitBlockProposal()
+
+							var decoded gethTypes.Transaction
+							require.NoError(t, decoded.UnmarshalBinary(tx))
+							txHashes[height] = decoded.Hash()
+							flowEvents[height] = chainEvents(t, chainID, backend.Events())
+							backend.DropEvents()
+						}
+
+						states[10] = backend.Clone()
+						commitBlock(11, "store", 42)
+						states[11] = backend.Clone()
+						states[12] = backend.Clone()
+						commitBlock(13, "storeWithLog", 43)
+						states[13] = backend.Clone()
+
+						for height := range states {
+							blockIDs[height] = unittest.IdentifierFixture()
+						}
+
+						api := evmrpc.NewAPI(newBackend(t, chainID, rootAddr, states, flowEvents, blockIDs))
+						ctx := context.Background()
+						latest := gethRPC.BlockNumberOrHashWithNumber(gethRPC.LatestBlockNumber)
+						atBlock := func(number int64) gethRPC.BlockNumberOrHash {
+							return gethRPC.BlockNumberOrHashWithNumber(gethRPC.BlockNumber(number))
+						}
+						contract := testContract.DeployedAt.ToCommon()
+						account := testAccount.Address().ToCommon()
+
+						t.Run("block number", func(t *testing.T) {
+							number, err := api.BlockNumber()
+							require.NoError(t, err)
+							require.Equal(t, hexutil.Uint64(2), number)
+						})
+
+						t.Run("call", func(t *testing.T) {
+							retrieve := hexutil.Bytes(testContract.MakeCallData(t, "retrieve"))
+							args := evmrpc.TransactionArgs{To: &contract, Data: &retrieve}
+
+							expected := map[int64]int64{0: 0, 1: 42, 2: 43}
+							for number, value := range expected {
+								block := atBlock(number)
+								returned, err := api.Call(ctx, args, &block)
+								require.NoError(t, err)
+								require.Equal(t, value, new(big.Int).SetBytes(returned).Int64())
+							}
+
+							// latest block by default
+							returned, err := api.Call(ctx, args, nil)
+							require.NoError(t, err)
+							require.Equal(t, big.NewInt(43), new(big.Int).SetBytes(returned))
+
+							// calls are executed in the context of the block
+							blockNumber := hexutil.Bytes(testContract.MakeCallData(t, "blockNumber"))
+							block := atBlock(1)
+							returned, err = api.Call(ctx, evmrpc.TransactionArgs{To: &contract, Data: &blockNumber}, &block)
+							require.NoError(t, err)
+							require.Equal(t, big.NewInt(1), new(big.Int).SetBytes(returned))
+						})
+
+						t.Run("call reverts", func(t *testing.T) {
+							assertError := hexutil.Bytes(testContract.MakeCallData(t, "assertError"))
+							_, err := api.Call(ctx, evmrpc.TransactionArgs{To: &contract, Data: &assertError}, nil)
+							require.ErrorContains(t, err, "execution reverted: Assert Error Message")
+
+							dataErr, ok := err.(gethRPC.DataError)
+							require.True(t, ok)
+							require.NotEmpty(t, dataErr.ErrorData())
+							codeErr, ok := err.(gethRPC.Error)
+							require.True(t, ok)
+							require.Equal(t, 3, codeErr.ErrorCode())
+						})
+
+						t.Run("estimate gas", func(t *testing.T) {
+							store := hexutil.Bytes(testContract.MakeCallData(t, "store", big.NewInt(7)))
+							args := evmrpc.TransactionArgs{From: &account, To: &contract, Data: &store}
+
+							estimate, err := api.EstimateGas(ctx, args, nil)
+							require.NoError(t, err)
+
+							gas := estimate
+							args.Gas = &gas
+							_, err = api.Call(ctx, args, nil)
+							require.NoError(t, err)
+
+							gas = estimate - 1
+							_, err = api.Call(ctx, args, nil)
+							require.Error(t, err)
+						})
+
+						t.Run("state", func(t *testing.T) {
+							nonce, err := api.GetTransactionCount(ctx, account, atBlock(1))
+							require.NoError(t, err)
+							require.Equal(t, hexutil.Uint64(1), *nonce)
+
+							nonce, err = api.GetTransactionCount(ctx, account, latest)
+							require.NoError(t, err)
+							require.Equal(t, hexutil.Uint64(2), *nonce)
+
+							balance, err := api.GetBalance(ctx, account, latest)
+							require.NoError(t, err)
+							require.True(t, balance.ToInt().Sign() > 0)
+
+							code, err := api.GetCode(ctx, contract, latest)
+							require.NoError(t, err)
+							require.NotEmpty(t, code)
+
+							value, err := api.GetStorageAt(ctx, contract, "0x0", atBlock(1))
+							require.NoError(t, err)
+							require.Equal(t, big.NewInt(42), new(big.Int).SetBytes(value))
+						})
+
+						t.Run("unknown blocks", func(t *testing.T) {
+							_, err := api.GetBalance(ctx, account, atBlock(3))
+							require.ErrorContains(t, err, "header not found")
+
+							_, err = api.GetBalance(ctx, account, gethRPC.BlockNumberOrHashWithHash(gethCommon.Hash{1}, false))
+							require.ErrorContains(t, err, "not supported")
+
+							block, err := api.GetBlockByNumber(ctx, 3, false)
+							require.NoError(t, err)
+							require.Nil(t, block)
+						})
+
+						t.Run("get block by number", func(t *testing.T) {
+							block, err := api.GetBlockByNumber(ctx, 1, false)
+							require.NoError(t, err)
+							require.Equal(t, hexutil.Uint64(1), block["number"])
+							require.Len(t, block["transactions"], 1)
+							require.Equal(t, txHashes[11], block["transactions"].([]interface{})[0])
+
+							latestBlock, err := api.GetBlockByNumber(ctx, gethRPC.LatestBlockNumber, true)
+							require.NoError(t, err)
+							require.Equal(t, hexutil.Uint64(2), latestBlock["number"])
+							require.Equal(t, block["hash"], latestBlock["parentHash"])
+							require.NotEqual(t, block["logsBloom"], latestBlock["logsBloom"])
+
+							tx := latestBlock["transactions"].([]interface{})[0].(*evmrpc.Transaction)
+							require.Equal(t, txHashes[13], tx.Hash)
+							require.Equal(t, latestBlock["hash"], tx.BlockHash)
+							require.Equal(t, account, tx.From)
+							require.Equal(t, &contract, tx.To)
+							require.Equal(t, hexutil.Uint64(0), tx.TransactionIndex)
+						})
+
+						t.Run("server", func(t *testing.T) {
+							config := evmrpc.NewDefaultConfig()
+							server, err := evmrpc.NewServer(newBackend(t, chainID, rootAddr, states, flowEvents, blockIDs), config)
+							require.NoError(t, err)
+
+							httpServer := httptest.NewServer(server.Handler)
+							defer httpServer.Close()
+
+							client, err := gethRPC.DialHTTP(httpServer.URL)
+							require.NoError(t, err)
+							defer client.Close()
+
+							var number hexutil.Uint64
+							err = client.Call(&number, "eth_blockNumber")
+							require.NoError(t, err)
+							require.Equal(t, hexutil.Uint64(2), number)
+
+							var chain hexutil.Big
+							err = client.Call(&chain, "eth_chainId")
+							require.NoError(t, err)
+							require.Equal(t, types.EVMChainIDFromFlowChainID(chainID), chain.ToInt())
+						})
+					})
+				})
+		})
+	})
+}
+
+// newBackend returns a backend serving the given registers and events, indexed at the given heights.
+func newBackend(
+	t *testing.T,
+	chainID flow.ChainID,
+	rootAddr flow.Address,
+	states map[uint64]*TestValueStore,
+	flowEvents map[uint64][]flow.Event,
+	blockIDs map[uint64]flow.Identifier,
+) *evmrpc.Backend {
+	const lowest, highest = 10, 13
+
+	registerIndex := storagemock.NewRegisterIndex(t)
+	registerIndex.On("FirstHeight").Return(uint64(lowest)).Maybe()
+	registerIndex.On("LatestHeight").Return(uint64(highest)).Maybe()
+	registerIndex.On("Get", mock.Anything, mock.Anything).Return(
+		func(id flow.RegisterID, height uint64) (flow.RegisterValue, error) {
+			value, err := states[height].GetValue([]byte(id.Owner), []byte(id.Key))
+			if err != nil {
+				return nil, err
+			}
+			if len(value) == 0 {
+				return nil, storage.ErrNotFound
+			}
+			return value, nil
+		}).Maybe()
+	registers := execution.NewRegistersAsyncStore()
+	require.NoError(t, registers.Initialize(registerIndex))
+
+	indexReporter := syncmock.NewIndexReporter(t)
+	indexReporter.On("LowestIndexedHeight").Return(uint64(lowest), nil).Maybe()
+	indexReporter.On("HighestIndexedHeight").Return(uint64(highest), nil).Maybe()
+	reporter := index.NewReporter()
+	require.NoError(t, reporter.Initialize(indexReporter))
+
+	headers := storagemock.NewHeaders(t)
+	eventsStorage := storagemock.NewEvents(t)
+	for height, blockID := range blockIDs {
+		headers.On("BlockIDByHeight", height).Return(blockID, nil).Maybe()
+		eventsStorage.On("ByBlockID", blockID).Return(flowEvents[height], nil).Maybe()
+	}
+
+	return evmrpc.NewBackend(
+		chainID,
+		rootAddr,
+		headers,
+		registers,
+		index.NewEventsIndex(reporter, eventsStorage),
+		reporter,
+		evmrpc.DefaultMaxCallGasLimit,
+	)
+}
+
+// chainEvents relabels the events emitted by the test backend with the types of the
+// events emitted by the EVM contract of the given chain.
+func chainEvents(t *testing.T, chainID flow.ChainID, allEvents flow.EventsList) []flow.Event {
+	evmContract := common.Address(systemcontracts.SystemContractsForChain(chainID).EVMContract.Address)
+
+	chainEvents := make([]flow.Event, len(allEvents))
+	for i, event := range allEvents {
+		cadenceEvent, err := events.FlowEventToCadenceEvent(event)
+		require.NoError(t, err)
+
+		location := common.NewAddressLocation(nil, evmContract, cadenceEvent.EventType.QualifiedIdentifier)
+		event.Type = flow.EventType(location.ID())
+		event.EventIndex = 0
+		event.TransactionIndex = uint32(i)
+		chainEvents[i] = event
+	}
+	return chainEvents
+}
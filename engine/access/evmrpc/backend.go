@@ -0,0 +1,234 @@
+package evmrpc
+
+import (
+	"errors"
+	"fmt"
+
+	gethRPC "github.com/onflow/go-ethereum/rpc"
+
+	"github.com/onflow/flow-go/engine/access/index"
+	"github.com/onflow/flow-go/fvm/evm/events"
+	"github.com/onflow/flow-go/fvm/evm/offchain/blocks"
+	"github.com/onflow/flow-go/fvm/evm/offchain/query"
+	evmStorage "github.com/onflow/flow-go/fvm/evm/offchain/storage"
+	"github.com/onflow/flow-go/fvm/evm/offchain/sync"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	"github.com/onflow/flow-go/module/state_synchronization"
+	"github.com/onflow/flow-go/storage"
+)
+
+// errBlockNotFound is returned when the requested EVM block has not been executed yet.
+var errBlockNotFound = errors.New("block not found")
+
+// Backend provides access to the state of the EVM at the Flow blocks indexed by the node.
+// The state at the end of an EVM block is read from the registers of the Flow block which
+// committed it, and the blocks are built from the EVM block store of the on-chain EVM.
+type Backend struct {
+	chainID         flow.ChainID
+	rootAddr        flow.Address
+	headers         storage.Headers
+	registers       *execution.RegistersAsyncStore
+	eventsIndex     *index.EventsIndex
+	indexReporter   state_synchronization.IndexReporter
+	maxCallGasLimit uint64
+}
+
+// NewBackend returns a new EVM backend reading the EVM state stored in the given root account.
+func NewBackend(
+	chainID flow.ChainID,
+	rootAddr flow.Address,
+	headers storage.Headers,
+	registers *execution.RegistersAsyncStore,
+	eventsIndex *index.EventsIndex,
+	indexReporter state_synchronization.IndexReporter,
+	maxCallGasLimit uint64,
+) *Backend {
+	return &Backend{
+		chainID:         chainID,
+		rootAddr:        rootAddr,
+		headers:         headers,
+		registers:       registers,
+		eventsIndex:     eventsIndex,
+		indexReporter:   indexReporter,
+		maxCallGasLimit: maxCallGasLimit,
+	}
+}
+
+// LatestBlock returns the latest EVM block committed at the highest indexed Flow height,
+// and that height.
+//
+// No errors are expected during normal operations, however the index may not be initialized yet.
+func (b *Backend) LatestBlock() (uint64, *types.Block, error) {
+	height, err := b.indexReporter.HighestIndexedHeight()
+	if err != nil {
+		return 0, nil, fmt.Errorf("could not get highest indexed height: %w", err)
+	}
+	block, err := b.blockAt(height)
+	if err != nil {
+		return 0, nil, err
+	}
+	return height, block, nil
+}
+
+// BlockByNumber returns the EVM block with the given number and the height of the Flow
+// block whose state matches the state at the end of the EVM block.
+// The latest, pending, safe and finalized tags resolve to the latest indexed EVM block.
+//
+// Expected errors during normal operations:
+//   - errBlockNotFound if the EVM block has not been executed yet
+func (b *Backend) BlockByNumber(number gethRPC.BlockNumber) (uint64, *types.Block, error) {
+	if number < gethRPC.EarliestBlockNumber {
+		return b.LatestBlock()
+	}
+
+	lowest, err := b.indexReporter.LowestIndexedHeight()
+	if err != nil {
+		return 0, nil, fmt.Errorf("could not get lowest indexed height: %w", err)
+	}
+	highest, latest, err := b.LatestBlock()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	evmHeight := uint64(number.Int64())
+	if evmHeight > latest.Height {
+		return 0, nil, errBlockNotFound
+	}
+	lowestBlock, err := b.blockAt(lowest)
+	if err != nil {
+		return 0, nil, err
+	}
+	if evmHeight < lowestBlock.Height {
+		return 0, nil, fmt.Errorf("block %d is not indexed, the lowest indexed block is %d", evmHeight, lowestBlock.Height)
+	}
+	if evmHeight == lowestBlock.Height {
+		return lowest, lowestBlock, nil
+	}
+
+	// the EVM height is monotonic in the Flow height, so the Flow block committing the
+	// EVM block is the lowest Flow block whose latest EVM block is not lower than it.
+	// the search keeps blockAt(low).Height < evmHeight <= blockAt(high).Height
+	low, high, highBlock := lowest, highest, latest
+	for high-low > 1 {
+		mid := low + (high-low)/2
+		block, err := b.blockAt(mid)
+		if err != nil {
+			return 0, nil, err
+		}
+		if block.Height < evmHeight {
+			low = mid
+		} else {
+			high, highBlock = mid, block
+		}
+	}
+
+	if highBlock.Height != evmHeight {
+		return 0, nil, fmt.Errorf("block %d was not committed by any indexed Flow block", evmHeight)
+	}
+
+	return high, highBlock, nil
+}
+
+// BlockByNumberOrHash resolves the given block reference like BlockByNumber.
+// Blocks can not be referenced by hash, since EVM block hashes are not indexed.
+//
+// Expected errors during normal operations:
+//   - errBlockNotFound if the EVM block has not been executed yet
+func (b *Backend) BlockByNumberOrHash(ref gethRPC.BlockNumberOrHash) (uint64, *types.Block, error) {
+	number, ok := ref.Number()
+	if !ok {
+		return 0, nil, errors.New("querying blocks by hash is not supported")
+	}
+	return b.BlockByNumber(number)
+}
+
+// View returns a view of the EVM state at the end of the given Flow block.
+// Calls executed with the view are executed in the context of the latest EVM block
+// committed by the Flow block.
+//
+// No errors are expected during normal operations.
+func (b *Backend) View(height uint64) (*query.View, error) {
+	storage := evmStorage.NewEphemeralStorage(
+		evmStorage.NewReadOnlyStorage(b.snapshotAt(height)),
+	)
+	blks, err := blocks.NewLatestCommittedBlocks(b.chainID, b.rootAddr, storage)
+	if err != nil {
+		return nil, fmt.Errorf("could not read blocks at height %d: %w", height, err)
+	}
+	return query.NewView(
+		b.chainID,
+		b.rootAddr,
+		storage,
+		blks,
+		b.maxCallGasLimit,
+	), nil
+}
+
+// Transactions returns the events of the transactions executed by the given EVM block,
+// committed by the Flow block at the given height, in execution order.
+//
+// No errors are expected during normal operations.
+func (b *Backend) Transactions(height uint64, block *types.Block) ([]events.TransactionEventPayload, error) {
+	if block.Height == 0 {
+		// the genesis block contains no transactions
+		return nil, nil
+	}
+
+	blockID, err := b.headers.BlockIDByHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("could not get block ID for height %d: %w", height, err)
+	}
+
+	flowEvents, err := b.eventsIndex.ByBlockID(blockID, height)
+	if err != nil {
+		return nil, fmt.Errorf("could not get events for height %d: %w", height, err)
+	}
+
+	txEvents, blockEvent, err := sync.DecodeBlockEvents(b.chainID, flowEvents)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode EVM events: %w", err)
+	}
+	if blockEvent == nil || blockEvent.Height != block.Height {
+		return nil, fmt.Errorf("transactions of block %d are not indexed", block.Height)
+	}
+
+	return txEvents, nil
+}
+
+// MaxCallGasLimit returns the maximum gas limit of calls.
+func (b *Backend) MaxCallGasLimit() uint64 {
+	return b.maxCallGasLimit
+}
+
+// ChainID returns the Flow chain the EVM runs on.
+func (b *Backend) ChainID() flow.ChainID {
+	return b.chainID
+}
+
+// blockAt returns the latest EVM block committed at the end of the Flow block at the given height.
+func (b *Backend) blockAt(height uint64) (*types.Block, error) {
+	block, err := blocks.LatestCommittedBlock(b.chainID, b.rootAddr, b.snapshotAt(height))
+	if err != nil {
+		return nil, fmt.Errorf("could not read latest block at height %d: %w", height, err)
+	}
+	return block, nil
+}
+
+// snapshotAt returns a snapshot of the registers at the end of the Flow block at the given height.
+func (b *Backend) snapshotAt(height uint64) types.BackendStorageSnapshot {
+	return evmStorage.NewRegisterSnapshot(
+		snapshot.NewReadFuncStorageSnapshot(func(id flow.RegisterID) (flow.RegisterValue, error) {
+			values, err := b.registers.RegisterValues(flow.RegisterIDs{id}, height)
+			if err != nil {
+				if errors.Is(err, storage.ErrNotFound) {
+					return nil, nil
+				}
+				return nil, err
+			}
+			return values[0], nil
+		}),
+	)
+}
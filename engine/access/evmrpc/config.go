@@ -0,0 +1,49 @@
+package evmrpc
+
+import (
+	"time"
+)
+
+const (
+	// DefaultReadTimeout is the default read timeout for the HTTP server
+	DefaultReadTimeout = time.Second * 15
+
+	// DefaultWriteTimeout is the default write timeout for the HTTP server
+	DefaultWriteTimeout = time.Second * 30
+
+	// DefaultIdleTimeout is the default idle timeout for the HTTP server
+	DefaultIdleTimeout = time.Second * 60
+
+	// DefaultMaxRequestSize is the default maximum size of a request body in bytes.
+	DefaultMaxRequestSize = 5 << 20 // 5MB
+
+	// DefaultMaxCallGasLimit is the default maximum gas limit of calls executed by
+	// eth_call and eth_estimateGas.
+	DefaultMaxCallGasLimit = 50_000_000
+)
+
+// Config holds the configuration of the EVM JSON-RPC server.
+type Config struct {
+	// ListenAddress is the address the EVM JSON-RPC server listens on. The server is not started if empty.
+	ListenAddress  string
+	WriteTimeout   time.Duration
+	ReadTimeout    time.Duration
+	IdleTimeout    time.Duration
+	MaxRequestSize int64
+
+	// MaxCallGasLimit is the maximum gas limit of calls executed by eth_call and eth_estimateGas.
+	// It is also the gas limit used for calls which do not specify one.
+	MaxCallGasLimit uint64
+}
+
+// NewDefaultConfig returns the default EVM JSON-RPC server configuration. The server is disabled by default.
+func NewDefaultConfig() Config {
+	return Config{
+		ListenAddress:   "",
+		WriteTimeout:    DefaultWriteTimeout,
+		ReadTimeout:     DefaultReadTimeout,
+		IdleTimeout:     DefaultIdleTimeout,
+		MaxRequestSize:  DefaultMaxRequestSize,
+		MaxCallGasLimit: DefaultMaxCallGasLimit,
+	}
+}
@@ -0,0 +1,156 @@
+package evmrpc
+
+import (
+	"fmt"
+	"math/big"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/onflow/go-ethereum/common/hexutil"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	gethRLP "github.com/onflow/go-ethereum/rlp"
+
+	"github.com/onflow/flow-go/fvm/evm/events"
+	"github.com/onflow/flow-go/fvm/evm/types"
+)
+
+// Transaction is the JSON-RPC representation of a transaction included in a block.
+type Transaction struct {
+	BlockHash        gethCommon.Hash     `json:"blockHash"`
+	BlockNumber      hexutil.Uint64      `json:"blockNumber"`
+	From             gethCommon.Address  `json:"from"`
+	Gas              hexutil.Uint64      `json:"gas"`
+	GasPrice         *hexutil.Big        `json:"gasPrice"`
+	Hash             gethCommon.Hash     `json:"hash"`
+	Input            hexutil.Bytes       `json:"input"`
+	Nonce            hexutil.Uint64      `json:"nonce"`
+	To               *gethCommon.Address `json:"to"`
+	TransactionIndex hexutil.Uint64      `json:"transactionIndex"`
+	Value            *hexutil.Big        `json:"value"`
+	Type             hexutil.Uint64      `json:"type"`
+	V                *hexutil.Big        `json:"v"`
+	R                *hexutil.Big        `json:"r"`
+	S                *hexutil.Big        `json:"s"`
+}
+
+// marshalBlock returns the JSON-RPC representation of the given block, executing the
+// transactions of the given events. Fields the EVM does not track, such as the state
+// root, are set to their empty values.
+func marshalBlock(
+	block *types.Block,
+	txEvents []events.TransactionEventPayload,
+	fullTx bool,
+) (map[string]interface{}, error) {
+	hash, err := block.Hash()
+	if err != nil {
+		return nil, fmt.Errorf("could not compute block hash: %w", err)
+	}
+	encoded, err := block.ToBytes()
+	if err != nil {
+		return nil, fmt.Errorf("could not encode block: %w", err)
+	}
+
+	var bloom gethTypes.Bloom
+	transactions := make([]interface{}, len(txEvents))
+	for i, txEvent := range txEvents {
+		var logs []*gethTypes.Log
+		if len(txEvent.Logs) > 0 {
+			err := gethRLP.DecodeBytes(txEvent.Logs, &logs)
+			if err != nil {
+				return nil, fmt.Errorf("could not decode logs of transaction %s: %w", txEvent.Hash, err)
+			}
+		}
+		for _, log := range logs {
+			bloom.Add(log.Address.Bytes())
+			for _, topic := range log.Topics {
+				bloom.Add(topic.Bytes())
+			}
+		}
+
+		if !fullTx {
+			transactions[i] = txEvent.Hash
+			continue
+		}
+		tx, err := marshalTransaction(hash, block.Height, txEvent)
+		if err != nil {
+			return nil, err
+		}
+		transactions[i] = tx
+	}
+
+	return map[string]interface{}{
+		"number":           hexutil.Uint64(block.Height),
+		"hash":             hash,
+		"parentHash":       block.ParentBlockHash,
+		"nonce":            gethTypes.BlockNonce{},
+		"mixHash":          block.PrevRandao,
+		"sha3Uncles":       gethTypes.EmptyUncleHash,
+		"logsBloom":        bloom,
+		"stateRoot":        gethCommon.Hash{},
+		"miner":            types.CoinbaseAddress.ToCommon(),
+		"difficulty":       (*hexutil.Big)(big.NewInt(0)),
+		"extraData":        hexutil.Bytes{},
+		"size":             hexutil.Uint64(len(encoded)),
+		"gasLimit":         hexutil.Uint64(types.DefaultBlockLevelGasLimit),
+		"gasUsed":          hexutil.Uint64(block.TotalGasUsed),
+		"timestamp":        hexutil.Uint64(block.Timestamp),
+		"transactionsRoot": block.TransactionHashRoot,
+		"receiptsRoot":     block.ReceiptRoot,
+		"baseFeePerGas":    (*hexutil.Big)(big.NewInt(0)),
+		"transactions":     transactions,
+		"uncles":           []gethCommon.Hash{},
+	}, nil
+}
+
+// marshalTransaction returns the JSON-RPC representation of the transaction of the given event.
+// Direct calls are represented by their canonical legacy transaction.
+func marshalTransaction(
+	blockHash gethCommon.Hash,
+	blockHeight uint64,
+	txEvent events.TransactionEventPayload,
+) (*Transaction, error) {
+	var tx *gethTypes.Transaction
+	var from gethCommon.Address
+
+	if txEvent.TransactionType == types.DirectCallTxType {
+		call, err := types.DirectCallFromEncoded(txEvent.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode direct call %s: %w", txEvent.Hash, err)
+		}
+		tx = call.Transaction()
+		from = call.From.ToCommon()
+	} else {
+		tx = &gethTypes.Transaction{}
+		err := tx.UnmarshalBinary(txEvent.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode transaction %s: %w", txEvent.Hash, err)
+		}
+		var chainID *big.Int
+		if tx.Protected() {
+			chainID = tx.ChainId()
+		}
+		from, err = gethTypes.Sender(gethTypes.LatestSignerForChainID(chainID), tx)
+		if err != nil {
+			return nil, fmt.Errorf("could not recover sender of transaction %s: %w", txEvent.Hash, err)
+		}
+	}
+
+	v, r, s := tx.RawSignatureValues()
+
+	return &Transaction{
+		BlockHash:        blockHash,
+		BlockNumber:      hexutil.Uint64(blockHeight),
+		From:             from,
+		Gas:              hexutil.Uint64(tx.Gas()),
+		GasPrice:         (*hexutil.Big)(tx.GasPrice()),
+		Hash:             txEvent.Hash,
+		Input:            tx.Data(),
+		Nonce:            hexutil.Uint64(tx.Nonce()),
+		To:               tx.To(),
+		TransactionIndex: hexutil.Uint64(txEvent.Index),
+		Value:            (*hexutil.Big)(tx.Value()),
+		Type:             hexutil.Uint64(tx.Type()),
+		V:                (*hexutil.Big)(v),
+		R:                (*hexutil.Big)(r),
+		S:                (*hexutil.Big)(s),
+	}, nil
+}
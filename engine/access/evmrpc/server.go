@@ -0,0 +1,41 @@
+package evmrpc
+
+import (
+	"fmt"
+	"net/http"
+
+	gethRPC "github.com/onflow/go-ethereum/rpc"
+	"github.com/rs/cors"
+)
+
+// NewServer returns an HTTP server serving the EVM JSON-RPC API over HTTP at the root path.
+func NewServer(
+	backend *Backend,
+	config Config,
+) (*http.Server, error) {
+	rpcServer := gethRPC.NewServer()
+	rpcServer.SetHTTPBodyLimit(int(config.MaxRequestSize))
+
+	err := rpcServer.RegisterName(Namespace, NewAPI(backend))
+	if err != nil {
+		return nil, fmt.Errorf("failed to register EVM JSON-RPC API: %w", err)
+	}
+
+	c := cors.New(cors.Options{
+		AllowedOrigins: []string{"*"},
+		AllowedHeaders: []string{"*"},
+		AllowedMethods: []string{
+			http.MethodGet,
+			http.MethodPost,
+			http.MethodOptions,
+			http.MethodHead},
+	})
+
+	return &http.Server{
+		Handler:      c.Handler(rpcServer),
+		Addr:         config.ListenAddress,
+		WriteTimeout: config.WriteTimeout,
+		ReadTimeout:  config.ReadTimeout,
+		IdleTimeout:  config.IdleTimeout,
+	}, nil
+}
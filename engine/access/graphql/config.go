@@ -0,0 +1,62 @@
+package graphql
+
+import (
+	"time"
+)
+
+const (
+	// DefaultReadTimeout is the default read timeout for the HTTP server
+	DefaultReadTimeout = time.Second * 15
+
+	// DefaultWriteTimeout is the default write timeout for the HTTP server
+	DefaultWriteTimeout = time.Second * 30
+
+	// DefaultIdleTimeout is the default idle timeout for the HTTP server
+	DefaultIdleTimeout = time.Second * 60
+
+	// DefaultMaxRequestSize is the default maximum size of a request body in bytes.
+	DefaultMaxRequestSize = 2 << 20 // 2MB
+
+	// DefaultMaxQueryCost is the default maximum estimated cost of a single query.
+	DefaultMaxQueryCost = 5000
+
+	// DefaultListSize is the number of items assumed for list fields whose size is not known
+	// from the query arguments when estimating the cost of a query.
+	DefaultListSize = 10
+
+	// DefaultMaxSubscriptionsPerConnection is the default maximum number of active subscriptions
+	// on one websocket connection.
+	DefaultMaxSubscriptionsPerConnection = 20
+)
+
+// Config holds the configuration of the GraphQL server.
+type Config struct {
+	// ListenAddress is the address the GraphQL server listens on. The server is not started if empty.
+	ListenAddress  string
+	WriteTimeout   time.Duration
+	ReadTimeout    time.Duration
+	IdleTimeout    time.Duration
+	MaxRequestSize int64
+
+	// MaxQueryCost is the maximum estimated cost of a single query. Queries exceeding it are rejected
+	// before execution.
+	MaxQueryCost uint64
+	// DefaultListSize is the number of items assumed for list fields when estimating the cost of a query.
+	DefaultListSize uint64
+	// MaxSubscriptionsPerConnection is the maximum number of active subscriptions on one websocket connection.
+	MaxSubscriptionsPerConnection uint64
+}
+
+// NewDefaultConfig returns the default GraphQL server configuration. The server is disabled by default.
+func NewDefaultConfig() Config {
+	return Config{
+		ListenAddress:                 "",
+		WriteTimeout:                  DefaultWriteTimeout,
+		ReadTimeout:                   DefaultReadTimeout,
+		IdleTimeout:                   DefaultIdleTimeout,
+		MaxRequestSize:                DefaultMaxRequestSize,
+		MaxQueryCost:                  DefaultMaxQueryCost,
+		DefaultListSize:               DefaultListSize,
+		MaxSubscriptionsPerConnection: DefaultMaxSubscriptionsPerConnection,
+	}
+}
@@ -0,0 +1,181 @@
+package graphql
+
+import (
+	"math"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// queryCost estimates the cost of executing the operation before it is executed, so expensive
+// queries can be rejected upfront.
+//
+// Every object returned costs 1, since it generally requires a lookup, while scalar fields are
+// free. The cost of a list field is multiplied by the number of items in the list: for fields with
+// a height range this is the size of the range, for all others the configured default list size
+// is assumed. For subscriptions, the cost of a single payload is
+// estimated.
+//
+// The document must have been validated against the schema.
+func queryCost(
+	schema *graphql.Schema,
+	operation *ast.OperationDefinition,
+	fragments map[string]*ast.FragmentDefinition,
+	variables map[string]interface{},
+	defaultListSize uint64,
+) uint64 {
+	var rootType graphql.Type
+	switch operation.Operation {
+	case ast.OperationTypeQuery:
+		rootType = schema.QueryType()
+	case ast.OperationTypeMutation:
+		rootType = schema.MutationType()
+	case ast.OperationTypeSubscription:
+		rootType = schema.SubscriptionType()
+	}
+
+	c := &costEstimator{
+		schema:          schema,
+		fragments:       fragments,
+		variables:       variables,
+		defaultListSize: defaultListSize,
+	}
+	return c.selectionSetCost(rootType, operation.SelectionSet, map[string]bool{})
+}
+
+type costEstimator struct {
+	schema          *graphql.Schema
+	fragments       map[string]*ast.FragmentDefinition
+	variables       map[string]interface{}
+	defaultListSize uint64
+}
+
+// selectionSetCost returns the cost of the selections on a value of the given type.
+// visited holds the names of the fragments spread on the path to the selection set.
+func (c *costEstimator) selectionSetCost(parentType graphql.Type, selectionSet *ast.SelectionSet, visited map[string]bool) uint64 {
+	if selectionSet == nil {
+		return 0
+	}
+
+	cost := uint64(0)
+	for _, selection := range selectionSet.Selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			cost = addCost(cost, c.fieldCost(parentType, s, visited))
+
+		case *ast.InlineFragment:
+			fragmentType := parentType
+			if s.TypeCondition != nil {
+				fragmentType = c.schema.Type(s.TypeCondition.Name.Value)
+			}
+			cost = addCost(cost, c.selectionSetCost(fragmentType, s.SelectionSet, visited))
+
+		case *ast.FragmentSpread:
+			name := s.Name.Value
+			fragment, ok := c.fragments[name]
+			if !ok || visited[name] {
+				continue
+			}
+			visited[name] = true
+			fragmentType := c.schema.Type(fragment.TypeCondition.Name.Value)
+			cost = addCost(cost, c.selectionSetCost(fragmentType, fragment.SelectionSet, visited))
+			delete(visited, name)
+		}
+	}
+	return cost
+}
+
+func (c *costEstimator) fieldCost(parentType graphql.Type, field *ast.Field, visited map[string]bool) uint64 {
+	var fields graphql.FieldDefinitionMap
+	switch t := parentType.(type) {
+	case *graphql.Object:
+		fields = t.Fields()
+	case *graphql.Interface:
+		fields = t.Fields()
+	default:
+		return 0
+	}
+
+	def, ok := fields[field.Name.Value]
+	if !ok {
+		// meta fields, like __typename or introspection fields
+		return 0
+	}
+
+	fieldType := def.Type
+	if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+		fieldType = nonNull.OfType
+	}
+
+	multiplier := uint64(1)
+	if list, ok := fieldType.(*graphql.List); ok {
+		multiplier = c.listSize(field)
+		fieldType = list.OfType
+		if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+			fieldType = nonNull.OfType
+		}
+	}
+
+	switch fieldType.(type) {
+	case *graphql.Object, *graphql.Interface, *graphql.Union:
+	default:
+		return 0
+	}
+
+	return mulCost(multiplier, addCost(1, c.selectionSetCost(fieldType, field.SelectionSet, visited)))
+}
+
+// listSize returns the estimated number of items returned by a list field.
+func (c *costEstimator) listSize(field *ast.Field) uint64 {
+	var startHeight, endHeight *uint64
+	for _, arg := range field.Arguments {
+		switch arg.Name.Value {
+		case "startHeight":
+			startHeight = c.uint64Value(arg.Value)
+		case "endHeight":
+			endHeight = c.uint64Value(arg.Value)
+		}
+	}
+
+	if startHeight != nil && endHeight != nil && *startHeight <= *endHeight {
+		return addCost(*endHeight-*startHeight, 1)
+	}
+	return c.defaultListSize
+}
+
+// uint64Value returns the value of an unsigned integer argument, or nil if it is not valid.
+func (c *costEstimator) uint64Value(value ast.Value) *uint64 {
+	var raw interface{}
+	switch v := value.(type) {
+	case *ast.Variable:
+		raw = c.variables[v.Name.Value]
+	case *ast.StringValue:
+		raw = v.Value
+	case *ast.IntValue:
+		raw = v.Value
+	default:
+		return nil
+	}
+
+	parsed, err := parseUInt64(raw)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
+
+// addCost adds two costs, saturating at the maximum cost.
+func addCost(a, b uint64) uint64 {
+	if a > math.MaxUint64-b {
+		return math.MaxUint64
+	}
+	return a + b
+}
+
+// mulCost multiplies two costs, saturating at the maximum cost.
+func mulCost(a, b uint64) uint64 {
+	if a != 0 && b > math.MaxUint64/a {
+		return math.MaxUint64
+	}
+	return a * b
+}
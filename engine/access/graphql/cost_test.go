@@ -0,0 +1,93 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/stretchr/testify/require"
+
+	accessmock "github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	statestreammock "github.com/onflow/flow-go/engine/access/state_stream/mock"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func TestQueryCost(t *testing.T) {
+	schema, err := NewSchema(accessmock.NewAPI(t), statestreammock.NewAPI(t), flow.Testnet.Chain(), state_stream.DefaultEventFilterConfig)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		expected  uint64
+	}{
+		{
+			name:     "scalar fields are free",
+			query:    `{ networkParameters { chainId } }`,
+			expected: 1,
+		},
+		{
+			name:     "nested objects",
+			query:    `{ transaction(id: "00") { id result { status block { id } } } }`,
+			expected: 3,
+		},
+		{
+			name:     "lists use the default size",
+			query:    `{ latestBlock { collections { id } } }`,
+			expected: 1 + 10,
+		},
+		{
+			name:     "nested lists",
+			query:    `{ latestBlock { collections { transactions { id } } } }`,
+			expected: 1 + 10*(1+10),
+		},
+		{
+			name:     "height ranges use the range size",
+			query:    `{ blocks(startHeight: "10", endHeight: "14") { id } }`,
+			expected: 5,
+		},
+		{
+			name:      "height ranges from variables",
+			query:     `query Q($start: UInt64!, $end: UInt64!) { blocks(startHeight: $start, endHeight: $end) { collections { id } } }`,
+			variables: map[string]interface{}{"start": "1", "end": float64(3)},
+			expected:  3 * (1 + 10),
+		},
+		{
+			name:     "fragments",
+			query:    `{ latestBlock { ...blockFields ... on Block { collections { id } } } } fragment blockFields on Block { collections { id } }`,
+			expected: 1 + 10 + 10,
+		},
+		{
+			name:     "subscriptions",
+			query:    `subscription { blocks { collections { id } } }`,
+			expected: 1 + 10,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			document, err := parser.Parse(parser.ParseParams{Source: test.query})
+			require.NoError(t, err)
+
+			validation := graphql.ValidateDocument(&schema, document, nil)
+			require.True(t, validation.IsValid, validation.Errors)
+
+			var operation *ast.OperationDefinition
+			fragments := make(map[string]*ast.FragmentDefinition)
+			for _, definition := range document.Definitions {
+				switch def := definition.(type) {
+				case *ast.OperationDefinition:
+					operation = def
+				case *ast.FragmentDefinition:
+					fragments[def.Name.Value] = def
+				}
+			}
+
+			cost := queryCost(&schema, operation, fragments, test.variables, DefaultListSize)
+			require.Equal(t, test.expected, cost)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/access"
+)
+
+// Request is a GraphQL request.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// preparedRequest is a parsed and validated request.
+type preparedRequest struct {
+	Request
+	document  *ast.Document
+	operation *ast.OperationDefinition
+}
+
+// Handler serves GraphQL queries over HTTP, and subscriptions over websocket connections
+// using the graphql-transport-ws protocol.
+type Handler struct {
+	log    zerolog.Logger
+	api    access.API
+	schema graphql.Schema
+	config Config
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// NewHandler returns a new GraphQL handler serving the schema.
+func NewHandler(log zerolog.Logger, api access.API, schema graphql.Schema, config Config) *Handler {
+	return &Handler{
+		log:    log,
+		api:    api,
+		schema: schema,
+		config: config,
+	}
+}
+
+// ServeHTTP serves queries sent as POST requests with a JSON body, or as GET requests with query
+// parameters. Requests to upgrade the connection to a websocket connection are served by the
+// subscription protocol.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if websocket.IsWebSocketUpgrade(r) {
+		h.serveWebSocket(w, r)
+		return
+	}
+
+	var req Request
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			err := json.Unmarshal([]byte(variables), &req.Variables)
+			if err != nil {
+				h.errorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid variables: %v", err))
+				return
+			}
+		}
+
+	case http.MethodPost:
+		body := http.MaxBytesReader(w, r.Body, h.config.MaxRequestSize)
+		err := json.NewDecoder(body).Decode(&req)
+		if err != nil {
+			h.errorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+			return
+		}
+
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		h.errorResponse(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	prepared, errs := h.prepare(req)
+	if len(errs) > 0 {
+		h.jsonResponse(w, &graphql.Result{Errors: errs})
+		return
+	}
+
+	if prepared.operation.Operation == ast.OperationTypeSubscription {
+		h.errorResponse(w, http.StatusBadRequest, "subscriptions are only supported over websocket connections")
+		return
+	}
+
+	h.jsonResponse(w, h.execute(r.Context(), prepared))
+}
+
+// prepare parses and validates the request, and checks that its estimated cost does not exceed
+// the configured maximum.
+func (h *Handler) prepare(req Request) (*preparedRequest, []gqlerrors.FormattedError) {
+	if req.Query == "" {
+		return nil, gqlerrors.FormatErrors(fmt.Errorf("query must not be empty"))
+	}
+
+	document, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{
+			Body: []byte(req.Query),
+			Name: "GraphQL request",
+		}),
+	})
+	if err != nil {
+		return nil, gqlerrors.FormatErrors(err)
+	}
+
+	validation := graphql.ValidateDocument(&h.schema, document, nil)
+	if !validation.IsValid {
+		return nil, validation.Errors
+	}
+
+	var operation *ast.OperationDefinition
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, definition := range document.Definitions {
+		switch def := definition.(type) {
+		case *ast.OperationDefinition:
+			if req.OperationName == "" {
+				if operation != nil {
+					return nil, gqlerrors.FormatErrors(fmt.Errorf("operation name is required if the query contains multiple operations"))
+				}
+				operation = def
+			} else if def.Name != nil && def.Name.Value == req.OperationName {
+				operation = def
+			}
+		case *ast.FragmentDefinition:
+			fragments[def.Name.Value] = def
+		}
+	}
+	if operation == nil {
+		return nil, gqlerrors.FormatErrors(fmt.Errorf("unknown operation %q", req.OperationName))
+	}
+
+	cost := queryCost(&h.schema, operation, fragments, req.Variables, h.config.DefaultListSize)
+	if cost > h.config.MaxQueryCost {
+		return nil, []gqlerrors.FormattedError{{
+			Message: fmt.Sprintf("estimated query cost %d exceeds the maximum of %d", cost, h.config.MaxQueryCost),
+			Extensions: map[string]interface{}{
+				"code":    "QUERY_COST_EXCEEDED",
+				"cost":    cost,
+				"maxCost": h.config.MaxQueryCost,
+			},
+		}}
+	}
+
+	return &preparedRequest{
+		Request:   req,
+		document:  document,
+		operation: operation,
+	}, nil
+}
+
+// execute executes a prepared query.
+func (h *Handler) execute(ctx context.Context, req *preparedRequest) *graphql.Result {
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           req.document,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoaders(ctx, h.api),
+	})
+}
+
+// subscribe executes a prepared subscription. The returned channel is closed when the subscription
+// ends, or the context is cancelled.
+func (h *Handler) subscribe(ctx context.Context, req *preparedRequest) chan *graphql.Result {
+	return graphql.ExecuteSubscription(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           req.document,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoaders(ctx, h.api),
+	})
+}
+
+func (h *Handler) jsonResponse(w http.ResponseWriter, result *graphql.Result) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	err := json.NewEncoder(w).Encode(result)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to encode response")
+	}
+}
+
+func (h *Handler) errorResponse(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	err := json.NewEncoder(w).Encode(&graphql.Result{
+		Errors: gqlerrors.FormatErrors(fmt.Errorf("%s", message)),
+	})
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to encode error response")
+	}
+}
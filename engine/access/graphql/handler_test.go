@@ -0,0 +1,263 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	accessmock "github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	statestreammock "github.com/onflow/flow-go/engine/access/state_stream/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func newTestHandler(t *testing.T, api access.API, config Config) *Handler {
+	stateStreamAPI := statestreammock.NewAPI(t)
+	schema, err := NewSchema(api, stateStreamAPI, flow.Testnet.Chain(), state_stream.DefaultEventFilterConfig)
+	require.NoError(t, err)
+
+	return NewHandler(unittest.Logger(), api, schema, config)
+}
+
+func postQuery(t *testing.T, handler http.Handler, req Request) (int, map[string]interface{}) {
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	httpReq := httptest.NewRequest(http.MethodPost, Path, bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httpReq)
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	return rr.Code, resp
+}
+
+// TestNestedQuery tests that nested queries are resolved, and that the transactions and results of
+// all collections of a block are loaded with a single lookup each.
+func TestNestedQuery(t *testing.T) {
+	api := accessmock.NewAPI(t)
+	handler := newTestHandler(t, api, NewDefaultConfig())
+
+	collections := []flow.Collection{
+		unittest.CollectionFixture(2),
+		unittest.CollectionFixture(2),
+	}
+	guarantees := make([]*flow.CollectionGuarantee, len(collections))
+	var transactions []*flow.TransactionBody
+	var results []*access.TransactionResult
+	for i, collection := range collections {
+		guarantees[i] = unittest.CollectionGuaranteeFixture(func(g *flow.CollectionGuarantee) {
+			g.CollectionID = collection.ID()
+		})
+		for _, tx := range collection.Transactions {
+			transactions = append(transactions, tx)
+			results = append(results, &access.TransactionResult{
+				Status:        flow.TransactionStatusSealed,
+				TransactionID: tx.ID(),
+				CollectionID:  collection.ID(),
+				Events: []flow.Event{
+					unittest.EventFixture(flow.EventAccountCreated, 0, 0, tx.ID(), 0),
+				},
+			})
+		}
+	}
+	block := unittest.BlockWithGuaranteesFixture(guarantees)
+	blockID := block.ID()
+
+	api.On("GetBlockByHeight", mocktestify.Anything, block.Header.Height).
+		Return(block, flow.BlockStatusSealed, nil).Once()
+	for _, collection := range collections {
+		light := collection.Light()
+		api.On("GetCollectionByID", mocktestify.Anything, collection.ID()).
+			Return(&light, nil).Once()
+	}
+	api.On("GetTransactionsByBlockID", mocktestify.Anything, blockID).
+		Return(transactions, nil).Once()
+	api.On("GetTransactionResultsByBlockID", mocktestify.Anything, blockID, entities.EventEncodingVersion_JSON_CDC_V0).
+		Return(results, nil).Once()
+
+	code, resp := postQuery(t, handler, Request{
+		Query: `query Block($height: UInt64!) {
+			block(height: $height) {
+				id
+				height
+				status
+				collections {
+					id
+					transactions {
+						id
+						result {
+							status
+							events { type transactionId }
+						}
+					}
+				}
+			}
+		}`,
+		Variables: map[string]interface{}{"height": fmt.Sprint(block.Header.Height)},
+	})
+	require.Equal(t, http.StatusOK, code)
+	require.NotContains(t, resp, "errors")
+
+	data := resp["data"].(map[string]interface{})["block"].(map[string]interface{})
+	require.Equal(t, blockID.String(), data["id"])
+	require.Equal(t, fmt.Sprint(block.Header.Height), data["height"])
+	require.Equal(t, "SEALED", data["status"])
+
+	resolved := data["collections"].([]interface{})
+	require.Len(t, resolved, len(collections))
+	for i, collection := range collections {
+		c := resolved[i].(map[string]interface{})
+		require.Equal(t, collection.ID().String(), c["id"])
+
+		txs := c["transactions"].([]interface{})
+		require.Len(t, txs, len(collection.Transactions))
+		for j, tx := range collection.Transactions {
+			resolvedTx := txs[j].(map[string]interface{})
+			require.Equal(t, tx.ID().String(), resolvedTx["id"])
+
+			result := resolvedTx["result"].(map[string]interface{})
+			require.Equal(t, "SEALED", result["status"])
+			require.Equal(t, []interface{}{
+				map[string]interface{}{
+					"type":          string(flow.EventAccountCreated),
+					"transactionId": tx.ID().String(),
+				},
+			}, result["events"])
+		}
+	}
+}
+
+// TestBlockEventsBatching tests that the events of all blocks in a range are loaded with a single lookup.
+func TestBlockEventsBatching(t *testing.T) {
+	api := accessmock.NewAPI(t)
+	handler := newTestHandler(t, api, NewDefaultConfig())
+
+	blocks := unittest.BlockFixtures(3)
+	blockIDs := make([]flow.Identifier, len(blocks))
+	blockEvents := make([]flow.BlockEvents, len(blocks))
+	for i, block := range blocks {
+		block.Header.Height = uint64(100 + i)
+		blockIDs[i] = block.ID()
+		blockEvents[i] = flow.BlockEvents{
+			BlockID:     blockIDs[i],
+			BlockHeight: block.Header.Height,
+			Events:      []flow.Event{unittest.EventFixture(flow.EventAccountCreated, 0, 0, unittest.IdentifierFixture(), 0)},
+		}
+		api.On("GetBlockByHeight", mocktestify.Anything, block.Header.Height).
+			Return(block, flow.BlockStatusFinalized, nil).Once()
+	}
+	api.On("GetEventsForBlockIDs", mocktestify.Anything, string(flow.EventAccountCreated), blockIDs, entities.EventEncodingVersion_JSON_CDC_V0).
+		Return(blockEvents, nil).Once()
+
+	code, resp := postQuery(t, handler, Request{
+		Query: fmt.Sprintf(`{
+			blocks(startHeight: "100", endHeight: "102") {
+				height
+				events(type: "%s") { eventIndex }
+			}
+		}`, flow.EventAccountCreated),
+	})
+	require.Equal(t, http.StatusOK, code)
+	require.NotContains(t, resp, "errors")
+
+	resolved := resp["data"].(map[string]interface{})["blocks"].([]interface{})
+	require.Len(t, resolved, len(blocks))
+	for i := range blocks {
+		b := resolved[i].(map[string]interface{})
+		require.Equal(t, fmt.Sprint(100+i), b["height"])
+		require.Equal(t, []interface{}{map[string]interface{}{"eventIndex": float64(0)}}, b["events"])
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	api := accessmock.NewAPI(t)
+
+	t.Run("api error", func(t *testing.T) {
+		handler := newTestHandler(t, api, NewDefaultConfig())
+		txID := unittest.IdentifierFixture()
+
+		api.On("GetTransaction", mocktestify.Anything, txID).
+			Return(nil, status.Error(codes.NotFound, "transaction not found")).Once()
+
+		code, resp := postQuery(t, handler, Request{
+			Query: fmt.Sprintf(`{ transaction(id: "%s") { id } }`, txID),
+		})
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, map[string]interface{}{"transaction": nil}, resp["data"])
+
+		errs := resp["errors"].([]interface{})
+		require.Len(t, errs, 1)
+		require.Equal(t, "transaction not found", errs[0].(map[string]interface{})["message"])
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		handler := newTestHandler(t, api, NewDefaultConfig())
+
+		code, resp := postQuery(t, handler, Request{
+			Query: `{ block(height: "1") { unknownField } }`,
+		})
+		require.Equal(t, http.StatusOK, code)
+		require.Nil(t, resp["data"])
+		require.Contains(t, resp["errors"].([]interface{})[0].(map[string]interface{})["message"], "unknownField")
+	})
+
+	t.Run("cost exceeded", func(t *testing.T) {
+		config := NewDefaultConfig()
+		config.MaxQueryCost = 10
+		handler := newTestHandler(t, api, config)
+
+		code, resp := postQuery(t, handler, Request{
+			Query: `{ blocks(startHeight: "1", endHeight: "20") { id } }`,
+		})
+		require.Equal(t, http.StatusOK, code)
+		require.Nil(t, resp["data"])
+
+		errs := resp["errors"].([]interface{})
+		require.Len(t, errs, 1)
+		require.Equal(t, map[string]interface{}{
+			"code":    "QUERY_COST_EXCEEDED",
+			"cost":    float64(20),
+			"maxCost": float64(10),
+		}, errs[0].(map[string]interface{})["extensions"])
+	})
+
+	t.Run("subscription over http", func(t *testing.T) {
+		handler := newTestHandler(t, api, NewDefaultConfig())
+
+		code, _ := postQuery(t, handler, Request{
+			Query: `subscription { blocks { id } }`,
+		})
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func TestGetQuery(t *testing.T) {
+	api := accessmock.NewAPI(t)
+	handler := newTestHandler(t, api, NewDefaultConfig())
+
+	api.On("GetNetworkParameters", mocktestify.Anything).
+		Return(access.NetworkParameters{ChainID: flow.Testnet}).Once()
+
+	query := url.Values{}
+	query.Set("query", `{ networkParameters { chainId } }`)
+
+	req := httptest.NewRequest(http.MethodGet, Path+"?"+query.Encode(), nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, fmt.Sprintf(`{"data":{"networkParameters":{"chainId":"%s"}}}`, flow.Testnet), rr.Body.String())
+}
@@ -0,0 +1,266 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// batchFunc loads the values of all given keys at once. Keys missing from the returned map
+// fail to load with a not found error.
+type batchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// loadResult is the outcome of loading a single key.
+type loadResult[V any] struct {
+	value V
+	err   error
+	done  bool
+}
+
+// loader batches the lookups of individual keys requested by resolvers into a single call of its
+// batch function, in the style of a dataloader.
+//
+// Resolvers queue keys using load and return the resulting thunk to the executor, which only calls
+// thunks once all fields of the current level of the query were resolved. The first thunk called
+// loads all keys queued so far. Loaded values are cached for the lifetime of the loader, which is
+// a single request.
+type loader[K comparable, V any] struct {
+	batch batchFunc[K, V]
+
+	mu      sync.Mutex
+	pending []K
+	results map[K]*loadResult[V]
+}
+
+func newLoader[K comparable, V any](batch batchFunc[K, V]) *loader[K, V] {
+	return &loader[K, V]{
+		batch:   batch,
+		results: make(map[K]*loadResult[V]),
+	}
+}
+
+// load queues the key to be loaded with the next batch, and returns a thunk returning its value.
+func (l *loader[K, V]) load(ctx context.Context, key K) func() (V, error) {
+	l.mu.Lock()
+	result, ok := l.results[key]
+	if !ok {
+		result = &loadResult[V]{}
+		l.results[key] = result
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if !result.done {
+			l.dispatch(ctx)
+		}
+		return result.value, result.err
+	}
+}
+
+// dispatch loads all pending keys with a single call of the batch function.
+// Must be called while holding the lock.
+func (l *loader[K, V]) dispatch(ctx context.Context) {
+	keys := l.pending
+	l.pending = nil
+
+	values, err := l.batch(ctx, keys)
+	for _, key := range keys {
+		result := l.results[key]
+		result.done = true
+
+		if err != nil {
+			result.err = err
+			continue
+		}
+
+		value, ok := values[key]
+		if !ok {
+			result.err = fmt.Errorf("%v not found", key)
+			continue
+		}
+		result.value = value
+	}
+}
+
+// transactionKey identifies a transaction, and the block it is included in if known.
+type transactionKey struct {
+	blockID       flow.Identifier
+	transactionID flow.Identifier
+}
+
+// eventsKey identifies the events of one type emitted in a block.
+type eventsKey struct {
+	eventType string
+	blockID   flow.Identifier
+}
+
+// loaders holds the loaders used by the resolvers of a single request.
+type loaders struct {
+	api access.API
+
+	blocks       *loader[flow.Identifier, *blockValue]
+	collections  *loader[flow.Identifier, *flow.LightCollection]
+	transactions *loader[transactionKey, *flow.TransactionBody]
+	results      *loader[transactionKey, *access.TransactionResult]
+	events       *loader[eventsKey, []flow.Event]
+}
+
+func newLoaders(api access.API) *loaders {
+	l := &loaders{api: api}
+	l.reset()
+	return l
+}
+
+// reset replaces all loaders with empty ones, dropping all cached values.
+// This is used to scope the cache to a single payload of a subscription.
+func (l *loaders) reset() {
+	l.blocks = newLoader(blocksBatch(l.api))
+	l.collections = newLoader(collectionsBatch(l.api))
+	l.transactions = newLoader(transactionsBatch(l.api))
+	l.results = newLoader(resultsBatch(l.api))
+	l.events = newLoader(eventsBatch(l.api))
+}
+
+type loadersKey struct{}
+
+// withLoaders returns a copy of the context holding a new set of loaders.
+func withLoaders(ctx context.Context, api access.API) context.Context {
+	return context.WithValue(ctx, loadersKey{}, newLoaders(api))
+}
+
+// loadersFromContext returns the loaders of the request.
+// The context must have been created with withLoaders.
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func blocksBatch(api access.API) batchFunc[flow.Identifier, *blockValue] {
+	return func(ctx context.Context, blockIDs []flow.Identifier) (map[flow.Identifier]*blockValue, error) {
+		blocks := make(map[flow.Identifier]*blockValue, len(blockIDs))
+		for _, blockID := range blockIDs {
+			block, status, err := api.GetBlockByID(ctx, blockID)
+			if err != nil {
+				return nil, err
+			}
+			blocks[blockID] = &blockValue{block: block, status: status}
+		}
+		return blocks, nil
+	}
+}
+
+func collectionsBatch(api access.API) batchFunc[flow.Identifier, *flow.LightCollection] {
+	return func(ctx context.Context, collectionIDs []flow.Identifier) (map[flow.Identifier]*flow.LightCollection, error) {
+		collections := make(map[flow.Identifier]*flow.LightCollection, len(collectionIDs))
+		for _, collectionID := range collectionIDs {
+			collection, err := api.GetCollectionByID(ctx, collectionID)
+			if err != nil {
+				return nil, err
+			}
+			collections[collectionID] = collection
+		}
+		return collections, nil
+	}
+}
+
+// transactionsBatch loads the transactions of each block with a single lookup of all transactions
+// in the block. Transactions of unknown blocks are looked up individually.
+func transactionsBatch(api access.API) batchFunc[transactionKey, *flow.TransactionBody] {
+	return func(ctx context.Context, keys []transactionKey) (map[transactionKey]*flow.TransactionBody, error) {
+		transactions := make(map[transactionKey]*flow.TransactionBody, len(keys))
+
+		for blockID, blockKeys := range groupByBlock(keys) {
+			if blockID == flow.ZeroID {
+				for _, key := range blockKeys {
+					tx, err := api.GetTransaction(ctx, key.transactionID)
+					if err != nil {
+						return nil, err
+					}
+					transactions[key] = tx
+				}
+				continue
+			}
+
+			blockTransactions, err := api.GetTransactionsByBlockID(ctx, blockID)
+			if err != nil {
+				return nil, err
+			}
+			for _, tx := range blockTransactions {
+				transactions[transactionKey{blockID: blockID, transactionID: tx.ID()}] = tx
+			}
+		}
+
+		return transactions, nil
+	}
+}
+
+// resultsBatch loads the transaction results of each block with a single lookup of all results
+// in the block. Results of transactions in unknown blocks are looked up individually.
+func resultsBatch(api access.API) batchFunc[transactionKey, *access.TransactionResult] {
+	return func(ctx context.Context, keys []transactionKey) (map[transactionKey]*access.TransactionResult, error) {
+		results := make(map[transactionKey]*access.TransactionResult, len(keys))
+
+		for blockID, blockKeys := range groupByBlock(keys) {
+			if blockID == flow.ZeroID {
+				for _, key := range blockKeys {
+					result, err := api.GetTransactionResult(ctx, key.transactionID, flow.ZeroID, flow.ZeroID, entities.EventEncodingVersion_JSON_CDC_V0)
+					if err != nil {
+						return nil, err
+					}
+					results[key] = result
+				}
+				continue
+			}
+
+			blockResults, err := api.GetTransactionResultsByBlockID(ctx, blockID, entities.EventEncodingVersion_JSON_CDC_V0)
+			if err != nil {
+				return nil, err
+			}
+			for _, result := range blockResults {
+				results[transactionKey{blockID: blockID, transactionID: result.TransactionID}] = result
+			}
+		}
+
+		return results, nil
+	}
+}
+
+// eventsBatch loads the events of each type for all requested blocks with a single lookup.
+func eventsBatch(api access.API) batchFunc[eventsKey, []flow.Event] {
+	return func(ctx context.Context, keys []eventsKey) (map[eventsKey][]flow.Event, error) {
+		blockIDsByType := make(map[string][]flow.Identifier)
+		for _, key := range keys {
+			blockIDsByType[key.eventType] = append(blockIDsByType[key.eventType], key.blockID)
+		}
+
+		events := make(map[eventsKey][]flow.Event, len(keys))
+		for eventType, blockIDs := range blockIDsByType {
+			blockEvents, err := api.GetEventsForBlockIDs(ctx, eventType, blockIDs, entities.EventEncodingVersion_JSON_CDC_V0)
+			if err != nil {
+				return nil, err
+			}
+			for _, be := range blockEvents {
+				events[eventsKey{eventType: eventType, blockID: be.BlockID}] = be.Events
+			}
+		}
+
+		return events, nil
+	}
+}
+
+// groupByBlock groups the transaction keys by block ID.
+func groupByBlock(keys []transactionKey) map[flow.Identifier][]transactionKey {
+	grouped := make(map[flow.Identifier][]transactionKey)
+	for _, key := range keys {
+		grouped[key.blockID] = append(grouped[key.blockID], key)
+	}
+	return grouped
+}
@@ -0,0 +1,1083 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/state_stream/backend"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// MaxBlockRange is the maximum number of blocks which can be queried at once.
+const MaxBlockRange = request.MaxBlockRequestHeightRange
+
+// blockValue is a block together with its status.
+type blockValue struct {
+	block  *flow.Block
+	status flow.BlockStatus
+}
+
+// collectionValue is a collection, and the block it is included in if known.
+type collectionValue struct {
+	id      flow.Identifier
+	blockID flow.Identifier
+}
+
+// transactionValue is a transaction, and the block it is included in if known.
+type transactionValue struct {
+	tx      *flow.TransactionBody
+	blockID flow.Identifier
+}
+
+// resolverError is an error returned by a resolver. Errors returned by the access API are
+// reported with their status code in the error extensions.
+type resolverError struct {
+	message string
+	code    string
+}
+
+var _ error = (*resolverError)(nil)
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+func (e *resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// convertError converts an error returned by the access API to a resolver error.
+func convertError(err error) error {
+	if err == nil {
+		return nil
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	return &resolverError{
+		message: s.Message(),
+		code:    s.Code().String(),
+	}
+}
+
+// schemaBuilder builds the GraphQL schema. Its fields are used by the resolvers.
+type schemaBuilder struct {
+	api               access.API
+	stateStreamAPI    state_stream.API
+	chain             flow.Chain
+	eventFilterConfig state_stream.EventFilterConfig
+}
+
+// NewSchema returns the GraphQL schema of the access API.
+//
+// Subscriptions are only included if the state stream API is not nil.
+func NewSchema(
+	api access.API,
+	stateStreamAPI state_stream.API,
+	chain flow.Chain,
+	eventFilterConfig state_stream.EventFilterConfig,
+) (graphql.Schema, error) {
+	b := &schemaBuilder{
+		api:               api,
+		stateStreamAPI:    stateStreamAPI,
+		chain:             chain,
+		eventFilterConfig: eventFilterConfig,
+	}
+	return b.build()
+}
+
+// UInt64 is a scalar for unsigned 64 bit integers. Values are serialized as decimal strings since
+// JSON numbers can not represent all of them, and are parsed from strings or integers.
+var UInt64 = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "UInt64",
+	Description: "An unsigned 64 bit integer, serialized as a decimal string.",
+	Serialize: func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case uint64:
+			return strconv.FormatUint(v, 10)
+		case *uint64:
+			if v == nil {
+				return nil
+			}
+			return strconv.FormatUint(*v, 10)
+		}
+		return nil
+	},
+	ParseValue: func(value interface{}) interface{} {
+		v, err := parseUInt64(value)
+		if err != nil {
+			return nil
+		}
+		return v
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		var raw string
+		switch v := valueAST.(type) {
+		case *ast.StringValue:
+			raw = v.Value
+		case *ast.IntValue:
+			raw = v.Value
+		default:
+			return nil
+		}
+		v, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil
+		}
+		return v
+	},
+})
+
+// parseUInt64 parses a value decoded from a JSON request as an unsigned 64 bit integer.
+func parseUInt64(value interface{}) (uint64, error) {
+	switch v := value.(type) {
+	case string:
+		return strconv.ParseUint(v, 10, 64)
+	case float64:
+		if v < 0 || v != math.Trunc(v) || v > 1<<53 {
+			return 0, fmt.Errorf("invalid unsigned integer: %v", v)
+		}
+		return uint64(v), nil
+	case int:
+		if v < 0 {
+			return 0, fmt.Errorf("invalid unsigned integer: %v", v)
+		}
+		return uint64(v), nil
+	case uint64:
+		return v, nil
+	}
+	return 0, fmt.Errorf("invalid unsigned integer: %v", value)
+}
+
+var blockStatusEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "BlockStatus",
+	Values: graphql.EnumValueConfigMap{
+		"UNKNOWN":   {Value: flow.BlockStatusUnknown},
+		"FINALIZED": {Value: flow.BlockStatusFinalized},
+		"SEALED":    {Value: flow.BlockStatusSealed},
+	},
+})
+
+var transactionStatusEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "TransactionStatus",
+	Values: graphql.EnumValueConfigMap{
+		"UNKNOWN":   {Value: flow.TransactionStatusUnknown},
+		"PENDING":   {Value: flow.TransactionStatusPending},
+		"FINALIZED": {Value: flow.TransactionStatusFinalized},
+		"EXECUTED":  {Value: flow.TransactionStatusExecuted},
+		"SEALED":    {Value: flow.TransactionStatusSealed},
+		"EXPIRED":   {Value: flow.TransactionStatusExpired},
+	},
+})
+
+func (b *schemaBuilder) build() (graphql.Schema, error) {
+	eventType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Event",
+		Fields: graphql.Fields{
+			"type": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return string(p.Source.(flow.Event).Type), nil
+				},
+			},
+			"transactionId": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.Event).TransactionID.String(), nil
+				},
+			},
+			"transactionIndex": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(flow.Event).TransactionIndex), nil
+				},
+			},
+			"eventIndex": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(flow.Event).EventIndex), nil
+				},
+			},
+			"payload": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The JSON-CDC encoded event payload.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return string(p.Source.(flow.Event).Payload), nil
+				},
+			},
+		},
+	})
+
+	blockType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Block",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*blockValue).block.ID().String(), nil
+				},
+			},
+			"parentId": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*blockValue).block.Header.ParentID.String(), nil
+				},
+			},
+			"height": &graphql.Field{
+				Type: graphql.NewNonNull(UInt64),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*blockValue).block.Header.Height, nil
+				},
+			},
+			"view": &graphql.Field{
+				Type: graphql.NewNonNull(UInt64),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*blockValue).block.Header.View, nil
+				},
+			},
+			"timestamp": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*blockValue).block.Header.Timestamp, nil
+				},
+			},
+			"status": &graphql.Field{
+				Type: graphql.NewNonNull(blockStatusEnum),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*blockValue).status, nil
+				},
+			},
+		},
+	})
+
+	blockEventsType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "BlockEvents",
+		Fields: graphql.Fields{
+			"blockId": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.BlockEvents).BlockID.String(), nil
+				},
+			},
+			"blockHeight": &graphql.Field{
+				Type: graphql.NewNonNull(UInt64),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.BlockEvents).BlockHeight, nil
+				},
+			},
+			"blockTimestamp": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.BlockEvents).BlockTimestamp, nil
+				},
+			},
+			"block": &graphql.Field{
+				Type:    blockType,
+				Resolve: b.resolveBlockEventsBlock,
+			},
+			"events": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(eventType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.BlockEvents).Events, nil
+				},
+			},
+		},
+	})
+
+	transactionResultType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TransactionResult",
+		Fields: graphql.Fields{
+			"transactionId": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*access.TransactionResult).TransactionID.String(), nil
+				},
+			},
+			"status": &graphql.Field{
+				Type: graphql.NewNonNull(transactionStatusEnum),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*access.TransactionResult).Status, nil
+				},
+			},
+			"statusCode": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(*access.TransactionResult).StatusCode), nil
+				},
+			},
+			"errorMessage": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*access.TransactionResult).ErrorMessage, nil
+				},
+			},
+			"blockId": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*access.TransactionResult).BlockID.String(), nil
+				},
+			},
+			"blockHeight": &graphql.Field{
+				Type: graphql.NewNonNull(UInt64),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*access.TransactionResult).BlockHeight, nil
+				},
+			},
+			"collectionId": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*access.TransactionResult).CollectionID.String(), nil
+				},
+			},
+			"block": &graphql.Field{
+				Type:    blockType,
+				Resolve: b.resolveTransactionResultBlock,
+			},
+			"events": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(eventType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*access.TransactionResult).Events, nil
+				},
+			},
+		},
+	})
+
+	proposalKeyType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ProposalKey",
+		Fields: graphql.Fields{
+			"address": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.ProposalKey).Address.Hex(), nil
+				},
+			},
+			"keyIndex": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(flow.ProposalKey).KeyIndex), nil
+				},
+			},
+			"sequenceNumber": &graphql.Field{
+				Type: graphql.NewNonNull(UInt64),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.ProposalKey).SequenceNumber, nil
+				},
+			},
+		},
+	})
+
+	transactionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Transaction",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*transactionValue).tx.ID().String(), nil
+				},
+			},
+			"script": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return string(p.Source.(*transactionValue).tx.Script), nil
+				},
+			},
+			"arguments": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+				Description: "The JSON-CDC encoded arguments.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					args := p.Source.(*transactionValue).tx.Arguments
+					result := make([]string, len(args))
+					for i, arg := range args {
+						result[i] = string(arg)
+					}
+					return result, nil
+				},
+			},
+			"referenceBlockId": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*transactionValue).tx.ReferenceBlockID.String(), nil
+				},
+			},
+			"gasLimit": &graphql.Field{
+				Type: graphql.NewNonNull(UInt64),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*transactionValue).tx.GasLimit, nil
+				},
+			},
+			"proposalKey": &graphql.Field{
+				Type: graphql.NewNonNull(proposalKeyType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*transactionValue).tx.ProposalKey, nil
+				},
+			},
+			"payer": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*transactionValue).tx.Payer.Hex(), nil
+				},
+			},
+			"authorizers": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					authorizers := p.Source.(*transactionValue).tx.Authorizers
+					result := make([]string, len(authorizers))
+					for i, authorizer := range authorizers {
+						result[i] = authorizer.Hex()
+					}
+					return result, nil
+				},
+			},
+			"result": &graphql.Field{
+				Type:    transactionResultType,
+				Resolve: b.resolveTransactionResult,
+			},
+		},
+	})
+
+	collectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Collection",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*collectionValue).id.String(), nil
+				},
+			},
+			"transactions": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(transactionType))),
+				Resolve: b.resolveCollectionTransactions,
+			},
+		},
+	})
+
+	// fields referencing types defined after the block type
+	blockType.AddFieldConfig("collections", &graphql.Field{
+		Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(collectionType))),
+		Resolve: b.resolveBlockCollections,
+	})
+	blockType.AddFieldConfig("events", &graphql.Field{
+		Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(eventType))),
+		Args: graphql.FieldConfigArgument{
+			"type": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+		Resolve: b.resolveBlockEvents,
+	})
+
+	accountKeyType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "AccountKey",
+		Fields: graphql.Fields{
+			"index": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(flow.AccountPublicKey).Index), nil
+				},
+			},
+			"publicKey": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.AccountPublicKey).PublicKey.String(), nil
+				},
+			},
+			"signingAlgorithm": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.AccountPublicKey).SignAlgo.String(), nil
+				},
+			},
+			"hashingAlgorithm": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.AccountPublicKey).HashAlgo.String(), nil
+				},
+			},
+			"sequenceNumber": &graphql.Field{
+				Type: graphql.NewNonNull(UInt64),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.AccountPublicKey).SeqNumber, nil
+				},
+			},
+			"weight": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.AccountPublicKey).Weight, nil
+				},
+			},
+			"revoked": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.AccountPublicKey).Revoked, nil
+				},
+			},
+		},
+	})
+
+	contractType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Contract",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"code": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	accountType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Account",
+		Fields: graphql.Fields{
+			"address": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*flow.Account).Address.Hex(), nil
+				},
+			},
+			"balance": &graphql.Field{
+				Type: graphql.NewNonNull(UInt64),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*flow.Account).Balance, nil
+				},
+			},
+			"keys": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(accountKeyType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*flow.Account).Keys, nil
+				},
+			},
+			"contracts": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(contractType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					contracts := p.Source.(*flow.Account).Contracts
+					result := make([]map[string]interface{}, 0, len(contracts))
+					for name, code := range contracts {
+						result = append(result, map[string]interface{}{
+							"name": name,
+							"code": string(code),
+						})
+					}
+					return result, nil
+				},
+			},
+		},
+	})
+
+	networkParametersType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "NetworkParameters",
+		Fields: graphql.Fields{
+			"chainId": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(access.NetworkParameters).ChainID.String(), nil
+				},
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"networkParameters": &graphql.Field{
+				Type: graphql.NewNonNull(networkParametersType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return b.api.GetNetworkParameters(p.Context), nil
+				},
+			},
+			"latestBlock": &graphql.Field{
+				Type: graphql.NewNonNull(blockType),
+				Args: graphql.FieldConfigArgument{
+					"sealed": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+				},
+				Resolve: b.resolveLatestBlock,
+			},
+			"block": &graphql.Field{
+				Type:        blockType,
+				Description: "Returns the block with the given ID or height. Exactly one of them must be provided.",
+				Args: graphql.FieldConfigArgument{
+					"id":     &graphql.ArgumentConfig{Type: graphql.ID},
+					"height": &graphql.ArgumentConfig{Type: UInt64},
+				},
+				Resolve: b.resolveBlock,
+			},
+			"blocks": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(blockType))),
+				Description: fmt.Sprintf("Returns the blocks in the height range. At most %d blocks can be queried at once.", MaxBlockRange),
+				Args: graphql.FieldConfigArgument{
+					"startHeight": &graphql.ArgumentConfig{Type: graphql.NewNonNull(UInt64)},
+					"endHeight":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(UInt64)},
+				},
+				Resolve: b.resolveBlocks,
+			},
+			"collection": &graphql.Field{
+				Type: collectionType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := identifierArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					return &collectionValue{id: id}, nil
+				},
+			},
+			"transaction": &graphql.Field{
+				Type: transactionType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: b.resolveTransaction,
+			},
+			"transactionResult": &graphql.Field{
+				Type: transactionResultType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: b.resolveTransactionResultByID,
+			},
+			"account": &graphql.Field{
+				Type:        accountType,
+				Description: "Returns the account at the given height, or at the latest sealed block if no height is provided.",
+				Args: graphql.FieldConfigArgument{
+					"address": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"height":  &graphql.ArgumentConfig{Type: UInt64},
+				},
+				Resolve: b.resolveAccount,
+			},
+			"events": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(blockEventsType))),
+				Args: graphql.FieldConfigArgument{
+					"type":        &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"startHeight": &graphql.ArgumentConfig{Type: graphql.NewNonNull(UInt64)},
+					"endHeight":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(UInt64)},
+				},
+				Resolve: b.resolveEvents,
+			},
+			"executeScript": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "Executes a script at the given height, or at the latest sealed block if no height is provided, and returns its JSON-CDC encoded result.",
+				Args: graphql.FieldConfigArgument{
+					"script":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"arguments": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+					"height":    &graphql.ArgumentConfig{Type: UInt64},
+				},
+				Resolve: b.resolveExecuteScript,
+			},
+		},
+	})
+
+	config := graphql.SchemaConfig{
+		Query: queryType,
+	}
+
+	if b.stateStreamAPI != nil {
+		config.Subscription = graphql.NewObject(graphql.ObjectConfig{
+			Name: "Subscription",
+			Fields: graphql.Fields{
+				"blocks": &graphql.Field{
+					Type: graphql.NewNonNull(blockType),
+					Args: graphql.FieldConfigArgument{
+						"status": &graphql.ArgumentConfig{Type: blockStatusEnum, DefaultValue: flow.BlockStatusFinalized},
+					},
+					Subscribe: b.subscribeBlocks,
+					Resolve:   resolveSubscriptionPayload,
+				},
+				"events": &graphql.Field{
+					Type:        graphql.NewNonNull(blockEventsType),
+					Description: "Streams the events matching the filter, starting from the latest sealed block. Only blocks with matching events are sent.",
+					Args: graphql.FieldConfigArgument{
+						"eventTypes": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+						"addresses":  &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+						"contracts":  &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+					},
+					Subscribe: b.subscribeEvents,
+					Resolve:   resolveSubscriptionPayload,
+				},
+			},
+		})
+	}
+
+	return graphql.NewSchema(config)
+}
+
+func (b *schemaBuilder) resolveLatestBlock(p graphql.ResolveParams) (interface{}, error) {
+	sealed, _ := p.Args["sealed"].(bool)
+	block, status, err := b.api.GetLatestBlock(p.Context, sealed)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	return &blockValue{block: block, status: status}, nil
+}
+
+func (b *schemaBuilder) resolveBlock(p graphql.ResolveParams) (interface{}, error) {
+	_, hasID := p.Args["id"]
+	height, hasHeight := p.Args["height"].(uint64)
+	if hasID == hasHeight {
+		return nil, fmt.Errorf("exactly one of id or height must be provided")
+	}
+
+	if hasHeight {
+		block, status, err := b.api.GetBlockByHeight(p.Context, height)
+		if err != nil {
+			return nil, convertError(err)
+		}
+		return &blockValue{block: block, status: status}, nil
+	}
+
+	id, err := identifierArg(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+	thunk := loadersFromContext(p.Context).blocks.load(p.Context, id)
+	return func() (interface{}, error) {
+		block, err := thunk()
+		return block, convertError(err)
+	}, nil
+}
+
+func (b *schemaBuilder) resolveBlocks(p graphql.ResolveParams) (interface{}, error) {
+	startHeight, endHeight, err := heightRangeArgs(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	if endHeight-startHeight >= MaxBlockRange {
+		return nil, fmt.Errorf("height range must not exceed %d blocks", MaxBlockRange)
+	}
+
+	blocks := make([]*blockValue, 0, endHeight-startHeight+1)
+	for height := startHeight; height <= endHeight; height++ {
+		block, status, err := b.api.GetBlockByHeight(p.Context, height)
+		if err != nil {
+			return nil, convertError(err)
+		}
+		blocks = append(blocks, &blockValue{block: block, status: status})
+	}
+	return blocks, nil
+}
+
+func (b *schemaBuilder) resolveBlockCollections(p graphql.ResolveParams) (interface{}, error) {
+	block := p.Source.(*blockValue).block
+	blockID := block.ID()
+
+	collections := make([]*collectionValue, len(block.Payload.Guarantees))
+	for i, guarantee := range block.Payload.Guarantees {
+		collections[i] = &collectionValue{
+			id:      guarantee.CollectionID,
+			blockID: blockID,
+		}
+	}
+	return collections, nil
+}
+
+func (b *schemaBuilder) resolveBlockEvents(p graphql.ResolveParams) (interface{}, error) {
+	eventType, _ := p.Args["type"].(string)
+	key := eventsKey{
+		eventType: eventType,
+		blockID:   p.Source.(*blockValue).block.ID(),
+	}
+	thunk := loadersFromContext(p.Context).events.load(p.Context, key)
+	return func() (interface{}, error) {
+		events, err := thunk()
+		return events, convertError(err)
+	}, nil
+}
+
+func (b *schemaBuilder) resolveBlockEventsBlock(p graphql.ResolveParams) (interface{}, error) {
+	thunk := loadersFromContext(p.Context).blocks.load(p.Context, p.Source.(flow.BlockEvents).BlockID)
+	return func() (interface{}, error) {
+		block, err := thunk()
+		return block, convertError(err)
+	}, nil
+}
+
+// resolveCollectionTransactions resolves the transactions of a collection. The transactions of
+// collections of a known block are loaded together with all other transactions of the block.
+func (b *schemaBuilder) resolveCollectionTransactions(p graphql.ResolveParams) (interface{}, error) {
+	collection := p.Source.(*collectionValue)
+	l := loadersFromContext(p.Context)
+
+	collectionThunk := l.collections.load(p.Context, collection.id)
+	return func() (interface{}, error) {
+		light, err := collectionThunk()
+		if err != nil {
+			return nil, convertError(err)
+		}
+
+		transactions := make([]interface{}, len(light.Transactions))
+		for i, txID := range light.Transactions {
+			key := transactionKey{blockID: collection.blockID, transactionID: txID}
+			txThunk := l.transactions.load(p.Context, key)
+			transactions[i] = func() (interface{}, error) {
+				tx, err := txThunk()
+				if err != nil {
+					return nil, convertError(err)
+				}
+				return &transactionValue{tx: tx, blockID: collection.blockID}, nil
+			}
+		}
+		return transactions, nil
+	}, nil
+}
+
+func (b *schemaBuilder) resolveTransaction(p graphql.ResolveParams) (interface{}, error) {
+	id, err := identifierArg(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+	thunk := loadersFromContext(p.Context).transactions.load(p.Context, transactionKey{transactionID: id})
+	return func() (interface{}, error) {
+		tx, err := thunk()
+		if err != nil {
+			return nil, convertError(err)
+		}
+		return &transactionValue{tx: tx}, nil
+	}, nil
+}
+
+// resolveTransactionResult resolves the result of a transaction. The results of transactions of
+// a known block are loaded together with all other results of the block.
+func (b *schemaBuilder) resolveTransactionResult(p graphql.ResolveParams) (interface{}, error) {
+	tx := p.Source.(*transactionValue)
+	key := transactionKey{blockID: tx.blockID, transactionID: tx.tx.ID()}
+	thunk := loadersFromContext(p.Context).results.load(p.Context, key)
+	return func() (interface{}, error) {
+		result, err := thunk()
+		return result, convertError(err)
+	}, nil
+}
+
+func (b *schemaBuilder) resolveTransactionResultByID(p graphql.ResolveParams) (interface{}, error) {
+	id, err := identifierArg(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+	thunk := loadersFromContext(p.Context).results.load(p.Context, transactionKey{transactionID: id})
+	return func() (interface{}, error) {
+		result, err := thunk()
+		return result, convertError(err)
+	}, nil
+}
+
+func (b *schemaBuilder) resolveTransactionResultBlock(p graphql.ResolveParams) (interface{}, error) {
+	thunk := loadersFromContext(p.Context).blocks.load(p.Context, p.Source.(*access.TransactionResult).BlockID)
+	return func() (interface{}, error) {
+		block, err := thunk()
+		return block, convertError(err)
+	}, nil
+}
+
+func (b *schemaBuilder) resolveAccount(p graphql.ResolveParams) (interface{}, error) {
+	rawAddress, _ := p.Args["address"].(string)
+	address, err := request.ParseAddress(rawAddress, b.chain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+
+	var account *flow.Account
+	if height, ok := p.Args["height"].(uint64); ok {
+		account, err = b.api.GetAccountAtBlockHeight(p.Context, address, height)
+	} else {
+		account, err = b.api.GetAccountAtLatestBlock(p.Context, address)
+	}
+	if err != nil {
+		return nil, convertError(err)
+	}
+	return account, nil
+}
+
+func (b *schemaBuilder) resolveEvents(p graphql.ResolveParams) (interface{}, error) {
+	eventType, _ := p.Args["type"].(string)
+	startHeight, endHeight, err := heightRangeArgs(p.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := b.api.GetEventsForHeightRange(p.Context, eventType, startHeight, endHeight, entities.EventEncodingVersion_JSON_CDC_V0)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	return events, nil
+}
+
+func (b *schemaBuilder) resolveExecuteScript(p graphql.ResolveParams) (interface{}, error) {
+	script, _ := p.Args["script"].(string)
+
+	var arguments [][]byte
+	if rawArguments, ok := p.Args["arguments"].([]interface{}); ok {
+		arguments = make([][]byte, len(rawArguments))
+		for i, arg := range rawArguments {
+			arguments[i] = []byte(arg.(string))
+		}
+	}
+
+	var value []byte
+	var err error
+	if height, ok := p.Args["height"].(uint64); ok {
+		value, err = b.api.ExecuteScriptAtBlockHeight(p.Context, height, []byte(script), arguments)
+	} else {
+		value, err = b.api.ExecuteScriptAtLatestBlock(p.Context, []byte(script), arguments)
+	}
+	if err != nil {
+		return nil, convertError(err)
+	}
+	return string(value), nil
+}
+
+// resolveSubscriptionPayload resolves the root field of a subscription to the payload received
+// from the subscription. Each payload is resolved with its own loaders.
+func resolveSubscriptionPayload(p graphql.ResolveParams) (interface{}, error) {
+	if subErr, ok := p.Source.(*subscriptionError); ok {
+		return nil, subErr.err
+	}
+	loadersFromContext(p.Context).reset()
+	return p.Source, nil
+}
+
+func (b *schemaBuilder) subscribeBlocks(p graphql.ResolveParams) (interface{}, error) {
+	blockStatus, _ := p.Args["status"].(flow.BlockStatus)
+	if blockStatus == flow.BlockStatusUnknown {
+		return nil, fmt.Errorf("block status must be FINALIZED or SEALED")
+	}
+
+	sub := b.api.SubscribeBlocksFromLatest(p.Context, blockStatus)
+	return forwardSubscription(p.Context, sub.Channel(), sub.Err, func(v interface{}) (interface{}, bool, error) {
+		block, ok := v.(*flow.Block)
+		if !ok {
+			return nil, false, fmt.Errorf("unexpected response type: %T", v)
+		}
+		return &blockValue{block: block, status: blockStatus}, true, nil
+	}), nil
+}
+
+func (b *schemaBuilder) subscribeEvents(p graphql.ResolveParams) (interface{}, error) {
+	eventTypes := stringListArg(p.Args, "eventTypes")
+	addresses := stringListArg(p.Args, "addresses")
+	contracts := stringListArg(p.Args, "contracts")
+
+	filter, err := state_stream.NewEventFilter(b.eventFilterConfig, b.chain, eventTypes, addresses, contracts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event filter: %w", err)
+	}
+
+	sub := b.stateStreamAPI.SubscribeEventsFromLatest(p.Context, filter)
+	return forwardSubscription(p.Context, sub.Channel(), sub.Err, eventsPayload), nil
+}
+
+// forwardSubscription forwards the responses of a streaming backend subscription, converted to
+// subscription payloads, to the channel returned to the executor. Responses for which convert
+// returns false are skipped. The returned channel is closed when the subscription ends, or the
+// context is cancelled. Errors are reported to the client as the last payload of the subscription.
+func forwardSubscription(
+	ctx context.Context,
+	responses <-chan interface{},
+	subErr func() error,
+	convert func(interface{}) (interface{}, bool, error),
+) chan interface{} {
+	payloads := make(chan interface{})
+
+	go func() {
+		defer close(payloads)
+
+		for {
+			var payload interface{}
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-responses:
+				if !ok {
+					if err := subErr(); err != nil {
+						payload = &subscriptionError{err: convertError(err)}
+						break
+					}
+					return
+				}
+
+				var send bool
+				var err error
+				payload, send, err = convert(v)
+				if err != nil {
+					payload = &subscriptionError{err: err}
+				} else if !send {
+					continue
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case payloads <- payload:
+			}
+
+			if _, ok := payload.(*subscriptionError); ok {
+				return
+			}
+		}
+	}()
+
+	return payloads
+}
+
+// subscriptionError is sent as the last payload of a failed subscription.
+type subscriptionError struct {
+	err error
+}
+
+// eventsPayload converts an events response of the state stream API to a subscription payload.
+// Responses without events are skipped.
+func eventsPayload(v interface{}) (interface{}, bool, error) {
+	resp, ok := v.(*backend.EventsResponse)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected response type: %T", v)
+	}
+	if len(resp.Events) == 0 {
+		return nil, false, nil
+	}
+
+	// the backend returns CCF encoded events, and this API returns JSON-CDC events.
+	events, err := convert.CcfEventsToJsonEvents(resp.Events)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not convert events payload from CCF to JSON: %w", err)
+	}
+
+	return flow.BlockEvents{
+		BlockID:        resp.BlockID,
+		BlockHeight:    resp.Height,
+		BlockTimestamp: resp.BlockTimestamp,
+		Events:         events,
+	}, true, nil
+}
+
+func identifierArg(args map[string]interface{}, name string) (flow.Identifier, error) {
+	raw, _ := args[name].(string)
+	id, err := flow.HexStringToIdentifier(raw)
+	if err != nil {
+		return flow.ZeroID, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return id, nil
+}
+
+func heightRangeArgs(args map[string]interface{}) (uint64, uint64, error) {
+	startHeight, _ := args["startHeight"].(uint64)
+	endHeight, _ := args["endHeight"].(uint64)
+	if startHeight > endHeight {
+		return 0, 0, fmt.Errorf("start height must be less than or equal to end height")
+	}
+	return startHeight, endHeight, nil
+}
+
+func stringListArg(args map[string]interface{}, name string) []string {
+	raw, _ := args[name].([]interface{})
+	values := make([]string, len(raw))
+	for i, v := range raw {
+		values[i], _ = v.(string)
+	}
+	return values
+}
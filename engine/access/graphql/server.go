@@ -0,0 +1,56 @@
+package graphql
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/rs/cors"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/state_stream/backend"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Path is the path the GraphQL API is served at.
+const Path = "/graphql"
+
+// NewServer returns an HTTP server initialized with the GraphQL API handler.
+// Subscriptions are only supported if the state stream API is not nil.
+func NewServer(
+	serverAPI access.API,
+	config Config,
+	logger zerolog.Logger,
+	chain flow.Chain,
+	stateStreamApi state_stream.API,
+	stateStreamConfig backend.Config,
+) (*http.Server, error) {
+	logger = logger.With().Str("component", "graphql").Logger()
+
+	schema, err := NewSchema(serverAPI, stateStreamApi, chain, stateStreamConfig.EventFilterConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GraphQL schema: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(Path, NewHandler(logger, serverAPI, schema, config))
+
+	c := cors.New(cors.Options{
+		AllowedOrigins: []string{"*"},
+		AllowedHeaders: []string{"*"},
+		AllowedMethods: []string{
+			http.MethodGet,
+			http.MethodPost,
+			http.MethodOptions,
+			http.MethodHead},
+	})
+
+	return &http.Server{
+		Handler:      c.Handler(mux),
+		Addr:         config.ListenAddress,
+		WriteTimeout: config.WriteTimeout,
+		ReadTimeout:  config.ReadTimeout,
+		IdleTimeout:  config.IdleTimeout,
+	}, nil
+}
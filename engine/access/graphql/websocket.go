@@ -0,0 +1,332 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+)
+
+// The websocket connections implement the graphql-transport-ws protocol.
+// See https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md
+const (
+	// Protocol is the websocket subprotocol used for subscriptions.
+	Protocol = "graphql-transport-ws"
+
+	// ConnectionInitWait is the time a client has to initialize the connection after it was opened.
+	ConnectionInitWait = 10 * time.Second
+
+	// PingPeriod defines the interval at which ping messages are sent to the client.
+	// This value must be less than PongWait.
+	PingPeriod = (PongWait * 9) / 10
+
+	// PongWait specifies the maximum time to wait for a pong response message from the client.
+	PongWait = 10 * time.Second
+
+	// WriteWait specifies a timeout for the write operation.
+	WriteWait = 10 * time.Second
+
+	// sendBufferSize is the number of messages buffered per connection before subscriptions are blocked.
+	sendBufferSize = 100
+)
+
+// message types of the graphql-transport-ws protocol
+const (
+	messageConnectionInit = "connection_init"
+	messageConnectionAck  = "connection_ack"
+	messagePing           = "ping"
+	messagePong           = "pong"
+	messageSubscribe      = "subscribe"
+	messageNext           = "next"
+	messageError          = "error"
+	messageComplete       = "complete"
+)
+
+// close codes of the graphql-transport-ws protocol
+const (
+	closeInvalidMessage          = 4400
+	closeUnauthorized            = 4401
+	closeInitTimeout             = 4408
+	closeSubscriberAlreadyExists = 4409
+	closeTooManyInitRequests     = 4429
+)
+
+// message is a message of the graphql-transport-ws protocol.
+type message struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// serveWebSocket upgrades the request to a websocket connection and serves it until the connection
+// is closed.
+func (h *Handler) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{
+		Subprotocols: []string{Protocol},
+		// allow all origins by default, operators can override using a proxy
+		CheckOrigin: func(r *http.Request) bool {
+			return true
+		},
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader already replied to the client with an HTTP error
+		h.log.Debug().Err(err).Msg("websocket upgrade failed")
+		return
+	}
+	defer conn.Close()
+
+	if conn.Subprotocol() != Protocol {
+		closeConnection(conn, websocket.CloseProtocolError, fmt.Sprintf("subprotocol %s is required", Protocol))
+		return
+	}
+
+	c := &connection{
+		handler:       h,
+		log:           h.log.With().Str("remote_addr", r.RemoteAddr).Logger(),
+		conn:          conn,
+		send:          make(chan message, sendBufferSize),
+		subscriptions: make(map[string]context.CancelFunc),
+		initialized:   atomic.NewBool(false),
+	}
+	c.serve(r.Context())
+}
+
+// connection serves the operations of a single websocket connection.
+type connection struct {
+	handler *Handler
+	log     zerolog.Logger
+	conn    *websocket.Conn
+	send    chan message
+
+	mu            sync.Mutex
+	subscriptions map[string]context.CancelFunc
+	wg            sync.WaitGroup
+
+	initialized *atomic.Bool
+}
+
+// serve reads and handles the messages of the client until the connection is closed.
+func (c *connection) serve(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		c.wg.Wait()
+	}()
+
+	go c.writeMessages(ctx, cancel)
+
+	initTimer := time.AfterFunc(ConnectionInitWait, func() {
+		if !c.initialized.Load() {
+			closeConnection(c.conn, closeInitTimeout, "Connection initialisation timeout")
+		}
+	})
+	defer initTimer.Stop()
+
+	c.conn.SetReadLimit(c.handler.config.MaxRequestSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(ConnectionInitWait + PongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(PongWait))
+	})
+
+	for {
+		var msg message
+		err := c.conn.ReadJSON(&msg)
+		if err != nil {
+			if isJSONError(err) {
+				closeConnection(c.conn, closeInvalidMessage, "Invalid message")
+			} else if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				c.log.Debug().Err(err).Msg("failed to read message")
+			}
+			return
+		}
+
+		switch msg.Type {
+		case messageConnectionInit:
+			if c.initialized.Swap(true) {
+				closeConnection(c.conn, closeTooManyInitRequests, "Too many initialisation requests")
+				return
+			}
+			c.write(ctx, message{Type: messageConnectionAck})
+
+		case messagePing:
+			c.write(ctx, message{Type: messagePong})
+
+		case messagePong:
+
+		case messageSubscribe:
+			if !c.initialized.Load() {
+				closeConnection(c.conn, closeUnauthorized, "Unauthorized")
+				return
+			}
+			if !c.subscribe(ctx, msg) {
+				return
+			}
+
+		case messageComplete:
+			c.complete(msg.ID)
+
+		default:
+			closeConnection(c.conn, closeInvalidMessage, fmt.Sprintf("Invalid message type %q", msg.Type))
+			return
+		}
+	}
+}
+
+// subscribe starts executing the operation of a subscribe message. It returns false if the
+// connection was closed because of a protocol violation.
+func (c *connection) subscribe(ctx context.Context, msg message) bool {
+	var req Request
+	err := json.Unmarshal(msg.Payload, &req)
+	if err != nil || msg.ID == "" {
+		closeConnection(c.conn, closeInvalidMessage, "Invalid subscribe message")
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.subscriptions[msg.ID]; ok {
+		closeConnection(c.conn, closeSubscriberAlreadyExists, fmt.Sprintf("Subscriber for %s already exists", msg.ID))
+		return false
+	}
+
+	if uint64(len(c.subscriptions)) >= c.handler.config.MaxSubscriptionsPerConnection {
+		err := fmt.Errorf("maximum number of subscriptions reached: %d", c.handler.config.MaxSubscriptionsPerConnection)
+		c.writeErrors(ctx, msg.ID, gqlerrors.FormatErrors(err))
+		return true
+	}
+
+	prepared, errs := c.handler.prepare(req)
+	if len(errs) > 0 {
+		c.writeErrors(ctx, msg.ID, errs)
+		return true
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	c.subscriptions[msg.ID] = cancel
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.run(subCtx, msg.ID, prepared)
+	}()
+
+	return true
+}
+
+// run executes the operation and sends its results to the client. Queries send a single result.
+func (c *connection) run(ctx context.Context, id string, req *preparedRequest) {
+	if req.operation.Operation == ast.OperationTypeSubscription {
+		// the results channel must be drained until it is closed, even after the subscription was
+		// completed, so the executor does not block.
+		for result := range c.handler.subscribe(ctx, req) {
+			if ctx.Err() != nil {
+				continue
+			}
+			c.writeResult(ctx, id, result)
+		}
+	} else {
+		c.writeResult(ctx, id, c.handler.execute(ctx, req))
+	}
+
+	c.mu.Lock()
+	cancel, active := c.subscriptions[id]
+	delete(c.subscriptions, id)
+	c.mu.Unlock()
+
+	// only notify the client if it did not complete the subscription itself
+	if active {
+		c.write(ctx, message{ID: id, Type: messageComplete})
+		cancel()
+	}
+}
+
+// complete stops the subscription with the given ID, if it exists.
+func (c *connection) complete(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cancel, ok := c.subscriptions[id]; ok {
+		cancel()
+		delete(c.subscriptions, id)
+	}
+}
+
+func (c *connection) writeResult(ctx context.Context, id string, result interface{}) {
+	payload, err := json.Marshal(result)
+	if err != nil {
+		c.log.Error().Err(err).Msg("failed to encode result")
+		return
+	}
+	c.write(ctx, message{ID: id, Type: messageNext, Payload: payload})
+}
+
+func (c *connection) writeErrors(ctx context.Context, id string, errs []gqlerrors.FormattedError) {
+	payload, err := json.Marshal(errs)
+	if err != nil {
+		c.log.Error().Err(err).Msg("failed to encode errors")
+		return
+	}
+	c.write(ctx, message{ID: id, Type: messageError, Payload: payload})
+}
+
+// write queues the message to be sent to the client.
+func (c *connection) write(ctx context.Context, msg message) {
+	select {
+	case <-ctx.Done():
+	case c.send <- msg:
+	}
+}
+
+// writeMessages sends the queued messages to the client, and pings the client periodically.
+// The connection is cancelled if writing fails.
+func (c *connection) writeMessages(ctx context.Context, cancel context.CancelFunc) {
+	defer cancel()
+
+	ticker := time.NewTicker(PingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case msg := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(WriteWait))
+			err := c.conn.WriteJSON(msg)
+			if err != nil {
+				c.log.Debug().Err(err).Msg("failed to write message")
+				return
+			}
+
+		case <-ticker.C:
+			err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(WriteWait))
+			if err != nil {
+				c.log.Debug().Err(err).Msg("failed to write ping")
+				return
+			}
+		}
+	}
+}
+
+// isJSONError returns true if the error was returned when decoding a message.
+func isJSONError(err error) bool {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(err, &syntaxErr) || errors.As(err, &typeErr)
+}
+
+// closeConnection sends a close message with the given code and reason to the client.
+func closeConnection(conn *websocket.Conn, code int, reason string) {
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(WriteWait))
+}
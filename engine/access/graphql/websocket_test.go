@@ -0,0 +1,141 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/access"
+	accessmock "github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func dialTestServer(t *testing.T, api access.API) *websocket.Conn {
+	server := httptest.NewServer(newTestHandler(t, api, NewDefaultConfig()))
+	t.Cleanup(server.Close)
+
+	dialer := websocket.Dialer{Subprotocols: []string{Protocol}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func writeMessage(t *testing.T, conn *websocket.Conn, msg message) {
+	require.NoError(t, conn.WriteJSON(msg))
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) message {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	var msg message
+	require.NoError(t, conn.ReadJSON(&msg))
+	return msg
+}
+
+func subscribeMessage(t *testing.T, id string, query string) message {
+	payload, err := json.Marshal(Request{Query: query})
+	require.NoError(t, err)
+	return message{ID: id, Type: messageSubscribe, Payload: payload}
+}
+
+func initConnection(t *testing.T, conn *websocket.Conn) {
+	writeMessage(t, conn, message{Type: messageConnectionInit})
+	require.Equal(t, messageConnectionAck, readMessage(t, conn).Type)
+}
+
+func TestWebSocketSubscription(t *testing.T) {
+	api := accessmock.NewAPI(t)
+	conn := dialTestServer(t, api)
+
+	sub := subscription.NewSubscription(1)
+	api.On("SubscribeBlocksFromLatest", mocktestify.Anything, flow.BlockStatusSealed).
+		Return(sub).Once()
+
+	initConnection(t, conn)
+	writeMessage(t, conn, subscribeMessage(t, "1", `subscription { blocks(status: SEALED) { height status } }`))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	blocks := unittest.BlockFixtures(2)
+	for _, block := range blocks {
+		require.NoError(t, sub.Send(ctx, block, time.Second))
+
+		msg := readMessage(t, conn)
+		require.Equal(t, messageNext, msg.Type)
+		require.Equal(t, "1", msg.ID)
+		require.JSONEq(t,
+			fmt.Sprintf(`{"data":{"blocks":{"height":"%d","status":"SEALED"}}}`, block.Header.Height),
+			string(msg.Payload))
+	}
+
+	// the subscription is completed when the backend subscription ends
+	sub.Close()
+	msg := readMessage(t, conn)
+	require.Equal(t, messageComplete, msg.Type)
+	require.Equal(t, "1", msg.ID)
+}
+
+func TestWebSocketQuery(t *testing.T) {
+	api := accessmock.NewAPI(t)
+	conn := dialTestServer(t, api)
+
+	api.On("GetNetworkParameters", mocktestify.Anything).
+		Return(access.NetworkParameters{ChainID: flow.Testnet}).Once()
+
+	initConnection(t, conn)
+	writeMessage(t, conn, subscribeMessage(t, "1", `{ networkParameters { chainId } }`))
+
+	msg := readMessage(t, conn)
+	require.Equal(t, messageNext, msg.Type)
+	require.JSONEq(t, fmt.Sprintf(`{"data":{"networkParameters":{"chainId":"%s"}}}`, flow.Testnet), string(msg.Payload))
+
+	msg = readMessage(t, conn)
+	require.Equal(t, messageComplete, msg.Type)
+	require.Equal(t, "1", msg.ID)
+}
+
+func TestWebSocketProtocolErrors(t *testing.T) {
+	t.Run("subscribe before init", func(t *testing.T) {
+		conn := dialTestServer(t, accessmock.NewAPI(t))
+
+		writeMessage(t, conn, subscribeMessage(t, "1", `{ networkParameters { chainId } }`))
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		_, _, err := conn.ReadMessage()
+		require.True(t, websocket.IsCloseError(err, closeUnauthorized), err)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		conn := dialTestServer(t, accessmock.NewAPI(t))
+
+		initConnection(t, conn)
+		writeMessage(t, conn, subscribeMessage(t, "1", `subscription { unknown }`))
+
+		msg := readMessage(t, conn)
+		require.Equal(t, messageError, msg.Type)
+		require.Equal(t, "1", msg.ID)
+	})
+
+	t.Run("init twice", func(t *testing.T) {
+		conn := dialTestServer(t, accessmock.NewAPI(t))
+
+		initConnection(t, conn)
+		writeMessage(t, conn, message{Type: messageConnectionInit})
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		_, _, err := conn.ReadMessage()
+		require.True(t, websocket.IsCloseError(err, closeTooManyInitRequests), err)
+	})
+}
@@ -0,0 +1,49 @@
+package index
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// AccountTransactionsIndex implements a wrapper around `storage.AccountTransactions` ensuring that needed data has been synced and is available to the client.
+// Note: read detail how `Reporter` is working
+type AccountTransactionsIndex struct {
+	*Reporter
+	accountTransactions storage.AccountTransactions
+}
+
+func NewAccountTransactionsIndex(reporter *Reporter, accountTransactions storage.AccountTransactions) *AccountTransactionsIndex {
+	return &AccountTransactionsIndex{
+		Reporter:            reporter,
+		accountTransactions: accountTransactions,
+	}
+}
+
+// ByAddress checks data availability and returns the transactions which involved the address in blocks within
+// [startHeight, endHeight], from newest to oldest, starting at the cursor if one is provided.
+// Expected errors:
+//   - indexer.ErrIndexNotInitialized if the `AccountTransactionsIndex` has not been initialized
+//   - storage.ErrHeightNotIndexed when data is unavailable for any height within the range
+func (a *AccountTransactionsIndex) ByAddress(
+	address flow.Address,
+	startHeight uint64,
+	endHeight uint64,
+	cursor *flow.AccountTransactionCursor,
+	limit uint32,
+) ([]flow.AccountTransaction, error) {
+	if err := a.checkDataAvailability(startHeight); err != nil {
+		return nil, err
+	}
+	if err := a.checkDataAvailability(endHeight); err != nil {
+		return nil, err
+	}
+
+	transactions, err := a.accountTransactions.ByAddress(address, startHeight, endHeight, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not get account transactions: %w", err)
+	}
+
+	return transactions, nil
+}
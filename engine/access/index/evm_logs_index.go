@@ -0,0 +1,49 @@
+package index
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// EVMLogsIndex implements a wrapper around `storage.EVMLogs` ensuring that needed data has been synced and is available to the client.
+// Note: read detail how `Reporter` is working
+type EVMLogsIndex struct {
+	*Reporter
+	evmLogs storage.EVMLogs
+}
+
+func NewEVMLogsIndex(reporter *Reporter, evmLogs storage.EVMLogs) *EVMLogsIndex {
+	return &EVMLogsIndex{
+		Reporter: reporter,
+		evmLogs:  evmLogs,
+	}
+}
+
+// ByFilter checks data availability and returns the EVM logs matching the filter in blocks within
+// [startHeight, endHeight], from oldest to newest, starting at the cursor if one is provided.
+// Expected errors:
+//   - indexer.ErrIndexNotInitialized if the `EVMLogsIndex` has not been initialized
+//   - storage.ErrHeightNotIndexed when data is unavailable for any height within the range
+func (e *EVMLogsIndex) ByFilter(
+	filter flow.EVMLogFilter,
+	startHeight uint64,
+	endHeight uint64,
+	cursor *flow.EVMLogCursor,
+	limit uint32,
+) ([]flow.EVMLog, error) {
+	if err := e.checkDataAvailability(startHeight); err != nil {
+		return nil, err
+	}
+	if err := e.checkDataAvailability(endHeight); err != nil {
+		return nil, err
+	}
+
+	logs, err := e.evmLogs.ByFilter(filter, startHeight, endHeight, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not get EVM logs: %w", err)
+	}
+
+	return logs, nil
+}
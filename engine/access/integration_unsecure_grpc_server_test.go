@@ -277,6 +277,11 @@ func (suite *SameGRPCPortTestSuite) SetupTest() {
 		state_stream.DefaultRegisterIDsRequestLimit,
 		subscriptionHandler,
 		suite.executionDataTracker,
+		suite.chainID.Chain(),
+		state_stream.DefaultEventFilterConfig,
+		nil,
+		statestreambackend.DefaultEventCursorTTL,
+		statestreambackend.DefaultMaxEventCursors,
 	)
 	assert.NoError(suite.T(), err)
 
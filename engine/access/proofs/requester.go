@@ -0,0 +1,137 @@
+package proofs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/channels"
+	"github.com/onflow/flow-go/utils/logging"
+	"github.com/onflow/flow-go/utils/rand"
+)
+
+// ErrStateNotAvailable is returned when the execution node doesn't have the execution state
+// at the requested state commitment.
+var ErrStateNotAvailable = errors.New("execution state not available")
+
+// pendingRequest is a request waiting for the response of an execution node.
+type pendingRequest struct {
+	executorID flow.Identifier
+	response   chan *messages.RegisterProofResponse
+}
+
+// Requester requests batch proofs of registers from execution nodes.
+type Requester struct {
+	log zerolog.Logger
+	con network.Conduit
+
+	mu      sync.Mutex
+	pending map[uint64]*pendingRequest // by nonce
+}
+
+var _ network.MessageProcessor = (*Requester)(nil)
+
+// New creates a new register proof requester.
+func New(log zerolog.Logger, net network.EngineRegistry) (*Requester, error) {
+	r := &Requester{
+		log:     log.With().Str("engine", "register_proof_requester").Logger(),
+		pending: make(map[uint64]*pendingRequest),
+	}
+
+	con, err := net.Register(channels.RequestRegisterProofs, r)
+	if err != nil {
+		return nil, fmt.Errorf("could not register register proof requester: %w", err)
+	}
+	r.con = con
+
+	return r, nil
+}
+
+// Process processes messages from the networking layer.
+// No errors are expected during normal operation.
+func (r *Requester) Process(channel channels.Channel, originID flow.Identifier, message any) error {
+	response, ok := message.(*messages.RegisterProofResponse)
+	if !ok {
+		r.log.Warn().
+			Bool(logging.KeySuspicious, true).
+			Msgf("%v delivered unsupported message %T through %v", originID, message, channel)
+		return nil
+	}
+
+	r.mu.Lock()
+	request, ok := r.pending[response.Nonce]
+	if ok && request.executorID == originID {
+		delete(r.pending, response.Nonce)
+	}
+	r.mu.Unlock()
+
+	if !ok || request.executorID != originID {
+		r.log.Debug().
+			Hex("origin_id", logging.ID(originID)).
+			Msg("dropped register proof response, because there is no matching request")
+		return nil
+	}
+
+	// the channel is buffered, and the request is removed from pending, so this doesn't block
+	request.response <- response
+	return nil
+}
+
+// RequestRegisterProof requests the batch proof of the given registers at the state commitment from the
+// execution node, and waits for the response until the context is done. The returned proof is not verified.
+// Expected errors during normal operations:
+//   - ErrStateNotAvailable if the execution node doesn't have the execution state at the state commitment
+//   - context.Canceled or context.DeadlineExceeded if the context is done before the response is received
+func (r *Requester) RequestRegisterProof(
+	ctx context.Context,
+	executorID flow.Identifier,
+	blockID flow.Identifier,
+	commit flow.StateCommitment,
+	registerIDs []flow.RegisterID,
+) ([]byte, error) {
+	nonce, err := rand.Uint64()
+	if err != nil {
+		return nil, fmt.Errorf("could not generate nonce: %w", err)
+	}
+
+	request := &pendingRequest{
+		executorID: executorID,
+		response:   make(chan *messages.RegisterProofResponse, 1),
+	}
+
+	r.mu.Lock()
+	r.pending[nonce] = request
+	r.mu.Unlock()
+
+	defer func() {
+		r.mu.Lock()
+		delete(r.pending, nonce)
+		r.mu.Unlock()
+	}()
+
+	err = r.con.Unicast(&messages.RegisterProofRequest{
+		BlockID:         blockID,
+		StateCommitment: commit,
+		RegisterIDs:     registerIDs,
+		Nonce:           nonce,
+	}, executorID)
+	if err != nil {
+		return nil, fmt.Errorf("could not send register proof request to %v: %w", executorID, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case response := <-request.response:
+		if response.StateCommitment != commit || len(response.Proof) == 0 {
+			return nil, ErrStateNotAvailable
+		}
+		return response.Proof, nil
+	}
+}
@@ -0,0 +1,80 @@
+package proofs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/network/channels"
+	"github.com/onflow/flow-go/network/mocknetwork"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestRequestRegisterProof tests that responses are only accepted from the requested execution node,
+// and that the request fails once the context is done.
+func TestRequestRegisterProof(t *testing.T) {
+	executorID := unittest.IdentifierFixture()
+	commit := unittest.StateCommitmentFixture()
+	registerIDs := []flow.RegisterID{flow.NewRegisterID(unittest.RandomAddressFixture(), "storage")}
+
+	con := mocknetwork.NewConduit(t)
+	net := mocknetwork.NewNetwork(t)
+	net.On("Register", channels.RequestRegisterProofs, mock.Anything).Return(con, nil)
+
+	r, err := New(unittest.Logger(), net)
+	require.NoError(t, err)
+
+	requests := make(chan *messages.RegisterProofRequest, 1)
+	con.On("Unicast", mock.Anything, executorID).
+		Run(func(args mock.Arguments) {
+			requests <- args[0].(*messages.RegisterProofRequest)
+		}).
+		Return(nil)
+
+	t.Run("response from requested execution node", func(t *testing.T) {
+		go func() {
+			request := <-requests
+			// responses from other nodes, or to other requests, are dropped
+			require.NoError(t, r.Process(channels.RequestRegisterProofs, unittest.IdentifierFixture(), &messages.RegisterProofResponse{
+				StateCommitment: commit,
+				Proof:           []byte{1},
+				Nonce:           request.Nonce,
+			}))
+			require.NoError(t, r.Process(channels.RequestRegisterProofs, executorID, &messages.RegisterProofResponse{
+				StateCommitment: commit,
+				Proof:           []byte{2},
+				Nonce:           request.Nonce + 1,
+			}))
+			require.NoError(t, r.Process(channels.RequestRegisterProofs, executorID, &messages.RegisterProofResponse{
+				StateCommitment: commit,
+				Proof:           []byte{3},
+				Nonce:           request.Nonce,
+			}))
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		proof, err := r.RequestRegisterProof(ctx, executorID, unittest.IdentifierFixture(), commit, registerIDs)
+		require.NoError(t, err)
+		require.Equal(t, []byte{3}, proof)
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := r.RequestRegisterProof(ctx, executorID, unittest.IdentifierFixture(), commit, registerIDs)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		<-requests
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		require.Empty(t, r.pending)
+	})
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"encoding/hex"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func (p *AccountStateProof) Build(proof *flow.AccountStateProof) {
+	registers := make([]ProvenRegister, len(proof.Registers))
+	for i, register := range proof.Registers {
+		registers[i].Build(register)
+	}
+
+	p.Address = proof.Address.String()
+	p.BlockId = proof.BlockID.String()
+	p.BlockHeight = util.FromUint(proof.BlockHeight)
+	p.StateCommitment = hex.EncodeToString(proof.StateCommitment[:])
+	p.Registers = registers
+	p.Proof = util.ToBase64(proof.Proof)
+}
+
+func (r *ProvenRegister) Build(register flow.RegisterEntry) {
+	r.Key = hex.EncodeToString([]byte(register.Key.Key))
+	r.Value = util.ToBase64(register.Value)
+}
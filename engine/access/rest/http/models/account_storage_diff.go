@@ -0,0 +1,58 @@
+package models
+
+import (
+	"encoding/hex"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func (d *AccountStorageDiff) Build(diff *flow.AccountStorageDiff) {
+	changes := make([]RegisterChange, len(diff.Changes))
+	for i, change := range diff.Changes {
+		changes[i].Build(change)
+	}
+
+	var pathChanges []StoragePathChange
+	if len(diff.PathChanges) > 0 {
+		pathChanges = make([]StoragePathChange, len(diff.PathChanges))
+		for i, change := range diff.PathChanges {
+			pathChanges[i].Build(change)
+		}
+	}
+
+	d.Address = diff.Address.String()
+	d.FromHeight = util.FromUint(diff.FromHeight)
+	d.ToHeight = util.FromUint(diff.ToHeight)
+	d.Changes = changes
+	d.PathChanges = pathChanges
+	d.PathChangesDecoded = diff.PathChangesDecoded
+	if diff.NextCursor != nil {
+		d.NextCursor = FormatAccountStorageDiffCursor(*diff.NextCursor)
+	}
+}
+
+func (c *RegisterChange) Build(change flow.AccountRegisterChange) {
+	c.Key = hex.EncodeToString([]byte(change.ID.Key))
+	c.Type = change.Type.String()
+	c.Kind = change.Kind.String()
+	c.Name = change.Name
+	if len(change.ValueBefore) > 0 {
+		c.ValueBefore = util.ToBase64(change.ValueBefore)
+	}
+	if len(change.ValueAfter) > 0 {
+		c.ValueAfter = util.ToBase64(change.ValueAfter)
+	}
+}
+
+func (c *StoragePathChange) Build(change flow.StoragePathChange) {
+	c.Path = change.Path
+	c.Type = change.Type.String()
+	c.ValueBefore = change.ValueBefore
+	c.ValueAfter = change.ValueAfter
+}
+
+// FormatAccountStorageDiffCursor formats the cursor as the hex encoded register key.
+func FormatAccountStorageDiffCursor(cursor string) string {
+	return hex.EncodeToString([]byte(cursor))
+}
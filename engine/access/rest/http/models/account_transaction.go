@@ -0,0 +1,51 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func (t *AccountTransaction) Build(transaction flow.AccountTransaction, link LinkGenerator) error {
+	self, err := SelfLink(transaction.TransactionID, link.TransactionLink)
+	if err != nil {
+		return err
+	}
+
+	roles := make([]string, len(transaction.Roles))
+	for i, role := range transaction.Roles {
+		roles[i] = role.String()
+	}
+
+	t.TransactionId = transaction.TransactionID.String()
+	t.TransactionIndex = util.FromUint(uint64(transaction.TransactionIndex))
+	t.BlockHeight = util.FromUint(transaction.BlockHeight)
+	t.Roles = roles
+	t.Links = self
+
+	return nil
+}
+
+func (t *AccountTransactions) Build(page *access.AccountTransactionsPage, link LinkGenerator) error {
+	transactions := make([]AccountTransaction, len(page.Transactions))
+	for i, transaction := range page.Transactions {
+		err := transactions[i].Build(transaction, link)
+		if err != nil {
+			return err
+		}
+	}
+
+	t.Transactions = transactions
+	if page.NextCursor != nil {
+		t.NextCursor = FormatAccountTransactionCursor(*page.NextCursor)
+	}
+
+	return nil
+}
+
+// FormatAccountTransactionCursor formats the cursor as "<block height>:<transaction index>".
+func FormatAccountTransactionCursor(cursor flow.AccountTransactionCursor) string {
+	return fmt.Sprintf("%d:%d", cursor.BlockHeight, cursor.TransactionIndex)
+}
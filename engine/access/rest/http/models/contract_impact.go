@@ -0,0 +1,48 @@
+package models
+
+import (
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func (c *ContractImpact) Build(impact *flow.ContractImpact) {
+	dependents := make([]ContractDependent, len(impact.Dependents))
+	for i, dependent := range impact.Dependents {
+		dependents[i].Build(dependent)
+	}
+
+	unparsed := make([]ContractReference, len(impact.UnparsedContracts))
+	for i, contract := range impact.UnparsedContracts {
+		unparsed[i].Build(contract)
+	}
+
+	references := make([]StoredTypeReference, len(impact.StoredReferences))
+	for i, reference := range impact.StoredReferences {
+		references[i].Build(reference)
+	}
+
+	c.Address = impact.Contract.Address.String()
+	c.Name = impact.Contract.Name
+	c.Height = util.FromUint(impact.Height)
+	c.Dependents = dependents
+	c.UnparsedContracts = unparsed
+	c.StoredReferences = references
+	c.ScannedAccounts = util.FromUint(impact.ScannedAccounts)
+}
+
+func (c *ContractDependent) Build(dependent flow.ContractDependent) {
+	c.Address = dependent.Address.String()
+	c.Name = dependent.Name
+	c.Depth = util.FromUint(dependent.Depth)
+}
+
+func (c *ContractReference) Build(contract flow.ContractID) {
+	c.Address = contract.Address.String()
+	c.Name = contract.Name
+}
+
+func (s *StoredTypeReference) Build(reference flow.StoredTypeReference) {
+	s.Address = reference.Address.String()
+	s.Path = reference.Path
+	s.TypeIds = reference.TypeIDs
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func (c *ContractUpdateValidation) Build(validation *flow.ContractUpdateValidation) {
+	violations := make([]ContractUpdateViolation, len(validation.Violations))
+	for i, violation := range validation.Violations {
+		violations[i].Build(violation)
+	}
+
+	c.Address = validation.Address.String()
+	c.Name = validation.Name
+	c.BlockId = validation.BlockID.String()
+	c.BlockHeight = util.FromUint(validation.BlockHeight)
+	c.Valid = validation.Valid()
+	c.Violations = violations
+}
+
+func (c *ContractUpdateViolation) Build(violation flow.ContractUpdateViolation) {
+	c.Message = violation.Message
+	c.Detail = violation.Detail
+	c.Start = buildContractCodePosition(violation.Start)
+	c.End = buildContractCodePosition(violation.End)
+}
+
+func buildContractCodePosition(position *flow.ContractCodePosition) *ContractCodePosition {
+	if position == nil {
+		return nil
+	}
+	return &ContractCodePosition{
+		Line:   int32(position.Line),
+		Column: int32(position.Column),
+	}
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/onflow/go-ethereum/common/hexutil"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func (l *EvmLog) Build(log flow.EVMLog, link LinkGenerator) error {
+	self, err := SelfLink(log.TransactionID, link.TransactionLink)
+	if err != nil {
+		return err
+	}
+
+	topics := make([]string, len(log.Topics))
+	for i, topic := range log.Topics {
+		topics[i] = topic.Hex()
+	}
+
+	l.BlockHeight = util.FromUint(log.BlockHeight)
+	l.TransactionId = log.TransactionID.String()
+	l.EvmBlockHeight = util.FromUint(log.EVMBlockHeight)
+	l.EvmTransactionHash = log.EVMTransactionHash.Hex()
+	l.EvmTransactionIndex = util.FromUint(uint64(log.EVMTransactionIndex))
+	l.LogIndex = util.FromUint(uint64(log.LogIndex))
+	l.Address = log.Address.Hex()
+	l.Topics = topics
+	l.Data = hexutil.Encode(log.Data)
+	l.Links = self
+
+	return nil
+}
+
+func (l *EvmLogs) Build(page *access.EVMLogsPage, link LinkGenerator) error {
+	logs := make([]EvmLog, len(page.Logs))
+	for i, log := range page.Logs {
+		err := logs[i].Build(log, link)
+		if err != nil {
+			return err
+		}
+	}
+
+	l.Logs = logs
+	if page.NextCursor != nil {
+		l.NextCursor = FormatEVMLogCursor(*page.NextCursor)
+	}
+
+	return nil
+}
+
+// FormatEVMLogCursor formats the cursor as "<block height>:<log index>".
+func FormatEVMLogCursor(cursor flow.EVMLogCursor) string {
+	return fmt.Sprintf("%d:%d", cursor.BlockHeight, cursor.LogIndex)
+}
@@ -0,0 +1,10 @@
+package models
+
+import (
+	"github.com/onflow/flow-go/fvm/evm/debug"
+)
+
+func (t *EvmTransactionTrace) Build(trace debug.TransactionTrace) {
+	t.TxHash = trace.TxHash.Hex()
+	t.Result = trace.Result
+}
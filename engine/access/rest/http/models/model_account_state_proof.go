@@ -0,0 +1,18 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type AccountStateProof struct {
+	Address         string           `json:"address"`
+	BlockId         string           `json:"block_id"`
+	BlockHeight     string           `json:"block_height"`
+	StateCommitment string           `json:"state_commitment"`
+	Registers       []ProvenRegister `json:"registers"`
+	Proof           string           `json:"proof"`
+}
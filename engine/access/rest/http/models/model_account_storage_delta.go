@@ -0,0 +1,17 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type AccountStorageDelta struct {
+	Address           string `json:"address"`
+	StorageUsedBefore string `json:"storage_used_before"`
+	StorageUsedAfter  string `json:"storage_used_after"`
+	// Change in storage used, in bytes. Negative if storage was freed.
+	Delta string `json:"delta"`
+}
@@ -0,0 +1,19 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type AccountStorageDiff struct {
+	Address            string              `json:"address"`
+	FromHeight         string              `json:"from_height"`
+	ToHeight           string              `json:"to_height"`
+	Changes            []RegisterChange    `json:"changes"`
+	PathChanges        []StoragePathChange `json:"path_changes,omitempty"`
+	PathChangesDecoded bool                `json:"path_changes_decoded"`
+	NextCursor         string              `json:"next_cursor,omitempty"`
+}
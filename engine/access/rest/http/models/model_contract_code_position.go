@@ -0,0 +1,16 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type ContractCodePosition struct {
+	// 1-based line of the position.
+	Line int32 `json:"line"`
+	// 0-based column of the position.
+	Column int32 `json:"column"`
+}
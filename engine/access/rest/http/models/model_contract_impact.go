@@ -0,0 +1,19 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type ContractImpact struct {
+	Address           string                `json:"address"`
+	Name              string                `json:"name"`
+	Height            string                `json:"height,omitempty"`
+	Dependents        []ContractDependent   `json:"dependents"`
+	UnparsedContracts []ContractReference   `json:"unparsed_contracts"`
+	StoredReferences  []StoredTypeReference `json:"stored_references"`
+	ScannedAccounts   string                `json:"scanned_accounts"`
+}
@@ -0,0 +1,19 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type ContractUpdateValidation struct {
+	Address     string `json:"address"`
+	Name        string `json:"name,omitempty"`
+	BlockId     string `json:"block_id"`
+	BlockHeight string `json:"block_height"`
+	Valid       bool   `json:"valid"`
+	// All reasons for which the update would be rejected.
+	Violations []ContractUpdateViolation `json:"violations"`
+}
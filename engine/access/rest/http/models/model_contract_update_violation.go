@@ -0,0 +1,17 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type ContractUpdateViolation struct {
+	Message string `json:"message"`
+	// Additional information about the violation, if available.
+	Detail string                `json:"detail,omitempty"`
+	Start  *ContractCodePosition `json:"start,omitempty"`
+	End    *ContractCodePosition `json:"end,omitempty"`
+}
@@ -0,0 +1,26 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type EvmLog struct {
+	BlockHeight    string `json:"block_height"`
+	TransactionId  string `json:"transaction_id"`
+	EvmBlockHeight string `json:"evm_block_height"`
+	// 0x prefixed hex encoded EVM transaction hash.
+	EvmTransactionHash  string `json:"evm_transaction_hash"`
+	EvmTransactionIndex string `json:"evm_transaction_index"`
+	LogIndex            string `json:"log_index"`
+	// 0x prefixed hex encoded address of the contract which emitted the log.
+	Address string `json:"address"`
+	// 0x prefixed hex encoded topics of the log.
+	Topics []string `json:"topics"`
+	// 0x prefixed hex encoded data of the log.
+	Data  string `json:"data"`
+	Links *Links `json:"_links,omitempty"`
+}
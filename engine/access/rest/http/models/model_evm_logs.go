@@ -0,0 +1,14 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type EvmLogs struct {
+	Logs       []EvmLog `json:"logs"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,20 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+import (
+	"encoding/json"
+)
+
+type EvmTransactionTrace struct {
+	// 0x prefixed hex encoded EVM transaction hash.
+	TxHash string `json:"tx_hash"`
+	// Trace produced by the requested tracer.
+	Result json.RawMessage `json:"result"`
+}
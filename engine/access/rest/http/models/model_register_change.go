@@ -0,0 +1,20 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type RegisterChange struct {
+	// Hex encoded register key.
+	Key  string `json:"key"`
+	Type string `json:"type"`
+	Kind string `json:"kind"`
+	Name string `json:"name,omitempty"`
+	// Base64 encoded register values.
+	ValueBefore string `json:"value_before,omitempty"`
+	ValueAfter  string `json:"value_after,omitempty"`
+}
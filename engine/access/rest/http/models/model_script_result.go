@@ -0,0 +1,16 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type ScriptResult struct {
+	// Base64 encoded JSON-CDC value returned by the script.
+	Value           string `json:"value,omitempty"`
+	ComputationUsed string `json:"computation_used"`
+	ErrorMessage    string `json:"error_message,omitempty"`
+}
@@ -0,0 +1,16 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type StoragePathChange struct {
+	Path        string `json:"path"`
+	Type        string `json:"type"`
+	ValueBefore string `json:"value_before,omitempty"`
+	ValueAfter  string `json:"value_after,omitempty"`
+}
@@ -0,0 +1,28 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type TransactionFeeEstimate struct {
+	BlockId     string `json:"block_id"`
+	BlockHeight string `json:"block_height"`
+	StatusCode  int32  `json:"status_code"`
+	// Provided transaction error in case the transaction wasn't successful.
+	ErrorMessage     string `json:"error_message"`
+	ComputationLimit string `json:"computation_limit"`
+	ComputationUsed  string `json:"computation_used"`
+	// Metered intensity of each kind of computation, keyed by computation kind.
+	ComputationIntensities map[string]string         `json:"computation_intensities"`
+	MemoryEstimate         string                    `json:"memory_estimate"`
+	InclusionEffort        string                    `json:"inclusion_effort"`
+	ExecutionEffort        string                    `json:"execution_effort"`
+	FeeParameters          *TransactionFeeParameters `json:"fee_parameters"`
+	InclusionFee           string                    `json:"inclusion_fee"`
+	ExecutionFee           string                    `json:"execution_fee"`
+	TotalFee               string                    `json:"total_fee"`
+}
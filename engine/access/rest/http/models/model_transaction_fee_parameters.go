@@ -0,0 +1,15 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type TransactionFeeParameters struct {
+	SurgeFactor         string `json:"surge_factor"`
+	InclusionEffortCost string `json:"inclusion_effort_cost"`
+	ExecutionEffortCost string `json:"execution_effort_cost"`
+}
@@ -0,0 +1,21 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type TransactionSimulation struct {
+	BlockId     string `json:"block_id"`
+	BlockHeight string `json:"block_height"`
+	StatusCode  int32  `json:"status_code"`
+	// Provided transaction error in case the transaction wasn't successful.
+	ErrorMessage    string                `json:"error_message"`
+	ComputationUsed string                `json:"computation_used"`
+	MemoryEstimate  string                `json:"memory_estimate"`
+	Events          []Event               `json:"events"`
+	StorageDeltas   []AccountStorageDelta `json:"storage_deltas"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+)
+
+func (s *ScriptResult) Build(result access.ScriptResult) {
+	if result.Err != nil {
+		s.ErrorMessage = status.Convert(result.Err).Message()
+	} else {
+		s.Value = util.ToBase64(result.Value)
+	}
+	s.ComputationUsed = util.FromUint(result.ComputationUsed)
+}
+
+func (s *ScriptsBatchResult) Build(height uint64, results []access.ScriptResult) {
+	s.BlockHeight = util.FromUint(height)
+	s.Results = make([]ScriptResult, len(results))
+	for i, result := range results {
+		s.Results[i].Build(result)
+	}
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func (t *TransactionFeeEstimate) Build(estimate *flow.TransactionFeeEstimate) {
+	intensities := make(map[string]string, len(estimate.ComputationIntensities))
+	for kind, intensity := range estimate.ComputationIntensities {
+		intensities[kind.String()] = util.FromUint(intensity)
+	}
+
+	var params TransactionFeeParameters
+	params.Build(estimate.FeeParameters)
+
+	t.BlockId = estimate.BlockID.String()
+	t.BlockHeight = util.FromUint(estimate.BlockHeight)
+	t.StatusCode = 0
+	if estimate.Failed() {
+		t.StatusCode = 1
+	}
+	t.ErrorMessage = estimate.ErrorMessage
+	t.ComputationLimit = util.FromUint(estimate.ComputationLimit)
+	t.ComputationUsed = util.FromUint(estimate.ComputationUsed)
+	t.ComputationIntensities = intensities
+	t.MemoryEstimate = util.FromUint(estimate.MemoryEstimate)
+	t.InclusionEffort = cadence.UFix64(estimate.InclusionEffort).String()
+	t.ExecutionEffort = cadence.UFix64(estimate.ExecutionEffort).String()
+	t.FeeParameters = &params
+	t.InclusionFee = cadence.UFix64(estimate.InclusionFee).String()
+	t.ExecutionFee = cadence.UFix64(estimate.ExecutionFee).String()
+	t.TotalFee = cadence.UFix64(estimate.TotalFee).String()
+}
+
+func (t *TransactionFeeParameters) Build(params flow.TransactionFeeParameters) {
+	t.SurgeFactor = cadence.UFix64(params.SurgeFactor).String()
+	t.InclusionEffortCost = cadence.UFix64(params.InclusionEffortCost).String()
+	t.ExecutionEffortCost = cadence.UFix64(params.ExecutionEffortCost).String()
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"strconv"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Build populates the simulation from the result. Events of the result must already be JSON-CDC encoded.
+func (t *TransactionSimulation) Build(result *flow.TransactionSimulationResult) {
+	var events Events
+	events.Build(result.Events)
+
+	deltas := make([]AccountStorageDelta, len(result.StorageDeltas))
+	for i, delta := range result.StorageDeltas {
+		deltas[i].Build(delta)
+	}
+
+	t.BlockId = result.BlockID.String()
+	t.BlockHeight = util.FromUint(result.BlockHeight)
+	t.StatusCode = 0
+	if result.Failed() {
+		t.StatusCode = 1
+	}
+	t.ErrorMessage = result.ErrorMessage
+	t.ComputationUsed = util.FromUint(result.ComputationUsed)
+	t.MemoryEstimate = util.FromUint(result.MemoryEstimate)
+	t.Events = events
+	t.StorageDeltas = deltas
+}
+
+func (a *AccountStorageDelta) Build(delta flow.AccountStorageDelta) {
+	a.Address = delta.Address.Hex()
+	a.StorageUsedBefore = util.FromUint(delta.StorageUsedBefore)
+	a.StorageUsedAfter = util.FromUint(delta.StorageUsedAfter)
+	a.Delta = strconv.FormatInt(delta.Delta(), 10)
+}
@@ -0,0 +1,55 @@
+package request
+
+import (
+	"io"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+type EstimateTransactionFees struct {
+	Transaction flow.TransactionBody
+	BlockHeight uint64
+}
+
+// EstimateTransactionFeesRequest extracts necessary variables and query parameters from the provided request,
+// builds an EstimateTransactionFees instance, and validates it.
+//
+// No errors are expected during normal operation.
+func EstimateTransactionFeesRequest(r *common.Request) (EstimateTransactionFees, error) {
+	var req EstimateTransactionFees
+	err := req.Build(r)
+	return req, err
+}
+
+func (e *EstimateTransactionFees) Build(r *common.Request) error {
+	return e.Parse(
+		r.GetQueryParam(blockHeightQuery),
+		r.Body,
+		r.Chain,
+	)
+}
+
+func (e *EstimateTransactionFees) Parse(rawHeight string, rawTransaction io.Reader, chain flow.Chain) error {
+	var height Height
+	err := height.Parse(rawHeight)
+	if err != nil {
+		return err
+	}
+	e.BlockHeight = height.Flow()
+
+	// default to last sealed block
+	if e.BlockHeight == EmptyHeight {
+		e.BlockHeight = SealedHeight
+	}
+
+	// signatures are not verified when estimating fees
+	var tx Transaction
+	err = tx.ParseUnsigned(rawTransaction, chain)
+	if err != nil {
+		return err
+	}
+	e.Transaction = tx.Flow()
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package request
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+)
+
+type scriptsBatchBody struct {
+	Scripts []scriptBody `json:"scripts"`
+}
+
+type ExecuteScripts struct {
+	BlockHeight uint64
+	Scripts     []Script
+}
+
+// ExecuteScriptsRequest extracts necessary variables and query parameters from the provided request,
+// builds an ExecuteScripts instance, and validates it.
+//
+// No errors are expected during normal operation.
+func ExecuteScriptsRequest(r *common.Request) (ExecuteScripts, error) {
+	var req ExecuteScripts
+	err := req.Build(r)
+	return req, err
+}
+
+func (e *ExecuteScripts) Build(r *common.Request) error {
+	return e.Parse(
+		r.GetQueryParam(blockHeightQuery),
+		r.Body,
+	)
+}
+
+func (e *ExecuteScripts) Parse(rawHeight string, rawScripts io.Reader) error {
+	var height Height
+	err := height.Parse(rawHeight)
+	if err != nil {
+		return err
+	}
+	e.BlockHeight = height.Flow()
+
+	// default to last sealed block
+	if e.BlockHeight == EmptyHeight {
+		e.BlockHeight = SealedHeight
+	}
+
+	var body scriptsBatchBody
+	err = parseBody(rawScripts, &body)
+	if err != nil {
+		return err
+	}
+
+	if len(body.Scripts) == 0 {
+		return fmt.Errorf("at least one script must be provided")
+	}
+
+	scripts := make([]Script, len(body.Scripts))
+	for i, raw := range body.Scripts {
+		err = scripts[i].parse(raw)
+		if err != nil {
+			return fmt.Errorf("invalid script at index %d: %w", i, err)
+		}
+	}
+	e.Scripts = scripts
+
+	return nil
+}
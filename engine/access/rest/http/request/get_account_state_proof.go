@@ -0,0 +1,84 @@
+package request
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const keysQuery = "keys"
+
+// MaxAccountStateProofKeys is the maximum number of register keys proven by a single request.
+const MaxAccountStateProofKeys = 100
+
+type GetAccountStateProof struct {
+	Address flow.Address
+	Keys    []string
+	Height  uint64
+}
+
+// GetAccountStateProofRequest extracts necessary variables and query parameters from the provided request,
+// builds a GetAccountStateProof instance, and validates it.
+//
+// No errors are expected during normal operation.
+func GetAccountStateProofRequest(r *common.Request) (GetAccountStateProof, error) {
+	var req GetAccountStateProof
+	err := req.Build(r)
+	return req, err
+}
+
+func (g *GetAccountStateProof) Build(r *common.Request) error {
+	return g.Parse(
+		r.GetVar(addressVar),
+		r.GetQueryParams(keysQuery),
+		r.GetQueryParam(blockHeightQuery),
+		r.Chain,
+	)
+}
+
+func (g *GetAccountStateProof) Parse(
+	rawAddress string,
+	rawKeys []string,
+	rawHeight string,
+	chain flow.Chain,
+) error {
+	address, err := ParseAddress(rawAddress, chain)
+	if err != nil {
+		return err
+	}
+
+	if len(rawKeys) > MaxAccountStateProofKeys {
+		return fmt.Errorf("at most %d keys can be requested at a time", MaxAccountStateProofKeys)
+	}
+
+	keys := make([]string, len(rawKeys))
+	for i, rawKey := range rawKeys {
+		key, err := hex.DecodeString(rawKey)
+		if err != nil || len(key) == 0 {
+			return fmt.Errorf("invalid key format")
+		}
+		keys[i] = string(key)
+	}
+
+	var height Height
+	err = height.Parse(rawHeight)
+	if err != nil {
+		return err
+	}
+
+	g.Address = address
+	g.Keys = keys
+	g.Height = height.Flow()
+
+	switch g.Height {
+	case EmptyHeight:
+		// default to last sealed block
+		g.Height = SealedHeight
+	case FinalHeight:
+		return fmt.Errorf("only sealed heights are supported")
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package request
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const fromHeightQuery = "from_height"
+const toHeightQuery = "to_height"
+const decodePathsQuery = "decode_paths"
+
+// DefaultAccountStorageDiffLimit is the number of register changes returned if no limit is requested.
+const DefaultAccountStorageDiffLimit = 100
+
+type GetAccountStorageDiff struct {
+	Address     flow.Address
+	FromHeight  uint64
+	ToHeight    uint64
+	Cursor      *string
+	Limit       uint32
+	DecodePaths bool
+}
+
+// GetAccountStorageDiffRequest extracts necessary variables and query parameters from the provided request,
+// builds a GetAccountStorageDiff instance, and validates it.
+//
+// No errors are expected during normal operation.
+func GetAccountStorageDiffRequest(r *common.Request) (GetAccountStorageDiff, error) {
+	var req GetAccountStorageDiff
+	err := req.Build(r)
+	return req, err
+}
+
+func (g *GetAccountStorageDiff) Build(r *common.Request) error {
+	return g.Parse(
+		r.GetVar(addressVar),
+		r.GetQueryParam(fromHeightQuery),
+		r.GetQueryParam(toHeightQuery),
+		r.GetQueryParam(cursorQuery),
+		r.GetQueryParam(limitQuery),
+		r.GetQueryParam(decodePathsQuery),
+		r.Chain,
+	)
+}
+
+func (g *GetAccountStorageDiff) Parse(
+	rawAddress string,
+	rawFrom string,
+	rawTo string,
+	rawCursor string,
+	rawLimit string,
+	rawDecodePaths string,
+	chain flow.Chain,
+) error {
+	address, err := ParseAddress(rawAddress, chain)
+	if err != nil {
+		return err
+	}
+	g.Address = address
+
+	g.FromHeight, err = parseStorageDiffHeight(rawFrom)
+	if err != nil {
+		return fmt.Errorf("invalid from height: %w", err)
+	}
+	g.ToHeight, err = parseStorageDiffHeight(rawTo)
+	if err != nil {
+		return fmt.Errorf("invalid to height: %w", err)
+	}
+	if g.FromHeight > g.ToHeight {
+		return fmt.Errorf("from height must be less than or equal to to height")
+	}
+
+	g.Cursor, err = ParseAccountStorageDiffCursor(rawCursor)
+	if err != nil {
+		return err
+	}
+
+	g.Limit = DefaultAccountStorageDiffLimit
+	if rawLimit != "" {
+		limit, err := strconv.ParseUint(rawLimit, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid limit format")
+		}
+		if limit == 0 {
+			return fmt.Errorf("limit must be greater than 0")
+		}
+		g.Limit = uint32(limit)
+	}
+
+	if rawDecodePaths != "" {
+		g.DecodePaths, err = strconv.ParseBool(rawDecodePaths)
+		if err != nil {
+			return fmt.Errorf("invalid decode paths value")
+		}
+	}
+
+	return nil
+}
+
+// parseStorageDiffHeight parses a required, explicit height.
+func parseStorageDiffHeight(raw string) (uint64, error) {
+	var height Height
+	err := height.Parse(raw)
+	if err != nil {
+		return 0, err
+	}
+
+	switch height.Flow() {
+	case EmptyHeight:
+		return 0, fmt.Errorf("height is required")
+	case SealedHeight, FinalHeight:
+		return 0, fmt.Errorf("only explicit heights are supported")
+	default:
+		return height.Flow(), nil
+	}
+}
+
+// ParseAccountStorageDiffCursor parses a hex encoded register key. An empty value returns a nil cursor.
+func ParseAccountStorageDiffCursor(raw string) (*string, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	key, err := hex.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor format")
+	}
+
+	cursor := string(key)
+	return &cursor, nil
+}
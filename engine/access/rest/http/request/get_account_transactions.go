@@ -0,0 +1,137 @@
+package request
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const cursorQuery = "cursor"
+const limitQuery = "limit"
+
+// DefaultAccountTransactionsLimit is the number of transactions returned if no limit is requested.
+const DefaultAccountTransactionsLimit = 50
+
+type GetAccountTransactions struct {
+	Address     flow.Address
+	StartHeight uint64
+	EndHeight   uint64
+	Cursor      *flow.AccountTransactionCursor
+	Limit       uint32
+}
+
+// GetAccountTransactionsRequest extracts necessary variables and query parameters from the provided request,
+// builds a GetAccountTransactions instance, and validates it.
+//
+// No errors are expected during normal operation.
+func GetAccountTransactionsRequest(r *common.Request) (GetAccountTransactions, error) {
+	var req GetAccountTransactions
+	err := req.Build(r)
+	return req, err
+}
+
+func (g *GetAccountTransactions) Build(r *common.Request) error {
+	return g.Parse(
+		r.GetVar(addressVar),
+		r.GetQueryParam(startHeightQuery),
+		r.GetQueryParam(endHeightQuery),
+		r.GetQueryParam(cursorQuery),
+		r.GetQueryParam(limitQuery),
+		r.Chain,
+	)
+}
+
+func (g *GetAccountTransactions) Parse(
+	rawAddress string,
+	rawStart string,
+	rawEnd string,
+	rawCursor string,
+	rawLimit string,
+	chain flow.Chain,
+) error {
+	address, err := ParseAddress(rawAddress, chain)
+	if err != nil {
+		return err
+	}
+	g.Address = address
+
+	g.StartHeight, err = parseAccountTransactionsHeight(rawStart)
+	if err != nil {
+		return fmt.Errorf("invalid start height: %w", err)
+	}
+	g.EndHeight, err = parseAccountTransactionsHeight(rawEnd)
+	if err != nil {
+		return fmt.Errorf("invalid end height: %w", err)
+	}
+	if g.EndHeight != 0 && g.StartHeight > g.EndHeight {
+		return fmt.Errorf("start height must be less than or equal to end height")
+	}
+
+	g.Cursor, err = ParseAccountTransactionCursor(rawCursor)
+	if err != nil {
+		return err
+	}
+
+	g.Limit = DefaultAccountTransactionsLimit
+	if rawLimit != "" {
+		limit, err := strconv.ParseUint(rawLimit, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid limit format")
+		}
+		if limit == 0 {
+			return fmt.Errorf("limit must be greater than 0")
+		}
+		g.Limit = uint32(limit)
+	}
+
+	return nil
+}
+
+// parseAccountTransactionsHeight parses an optional height bound. Only explicit heights are supported,
+// an empty value is returned as 0, which selects the bound of the indexed height range.
+func parseAccountTransactionsHeight(raw string) (uint64, error) {
+	var height Height
+	err := height.Parse(raw)
+	if err != nil {
+		return 0, err
+	}
+
+	switch height.Flow() {
+	case EmptyHeight:
+		return 0, nil
+	case SealedHeight, FinalHeight:
+		return 0, fmt.Errorf("only explicit heights are supported")
+	default:
+		return height.Flow(), nil
+	}
+}
+
+// ParseAccountTransactionCursor parses a cursor of the form "<block height>:<transaction index>".
+// An empty value returns a nil cursor.
+func ParseAccountTransactionCursor(raw string) (*flow.AccountTransactionCursor, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	rawHeight, rawIndex, ok := strings.Cut(raw, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid cursor format")
+	}
+
+	height, err := strconv.ParseUint(rawHeight, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor format")
+	}
+	index, err := strconv.ParseUint(rawIndex, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor format")
+	}
+
+	return &flow.AccountTransactionCursor{
+		BlockHeight:      height,
+		TransactionIndex: uint32(index),
+	}, nil
+}
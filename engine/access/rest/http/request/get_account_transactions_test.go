@@ -0,0 +1,60 @@
+package request
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func TestGetAccountTransactions_InvalidParse(t *testing.T) {
+	var getAccountTransactions GetAccountTransactions
+
+	tests := []struct {
+		address string
+		start   string
+		end     string
+		cursor  string
+		limit   string
+		err     string
+	}{
+		{"", "", "", "", "", "invalid address"},
+		{"f8d6e0586b0a20c7", "sealed", "", "", "", "invalid start height: only explicit heights are supported"},
+		{"f8d6e0586b0a20c7", "", "final", "", "", "invalid end height: only explicit heights are supported"},
+		{"f8d6e0586b0a20c7", "foo", "", "", "", "invalid start height: invalid height format"},
+		{"f8d6e0586b0a20c7", "20", "10", "", "", "start height must be less than or equal to end height"},
+		{"f8d6e0586b0a20c7", "", "", "10", "", "invalid cursor format"},
+		{"f8d6e0586b0a20c7", "", "", "10:foo", "", "invalid cursor format"},
+		{"f8d6e0586b0a20c7", "", "", "", "foo", "invalid limit format"},
+		{"f8d6e0586b0a20c7", "", "", "", "0", "limit must be greater than 0"},
+	}
+
+	chain := flow.Localnet.Chain()
+	for i, test := range tests {
+		err := getAccountTransactions.Parse(test.address, test.start, test.end, test.cursor, test.limit, chain)
+		assert.EqualError(t, err, test.err, fmt.Sprintf("test #%d failed", i))
+	}
+}
+
+func TestGetAccountTransactions_ValidParse(t *testing.T) {
+	var getAccountTransactions GetAccountTransactions
+	chain := flow.Localnet.Chain()
+
+	err := getAccountTransactions.Parse("f8d6e0586b0a20c7", "", "", "", "", chain)
+	require.NoError(t, err)
+	assert.Equal(t, "f8d6e0586b0a20c7", getAccountTransactions.Address.String())
+	assert.Equal(t, uint64(0), getAccountTransactions.StartHeight)
+	assert.Equal(t, uint64(0), getAccountTransactions.EndHeight)
+	assert.Nil(t, getAccountTransactions.Cursor)
+	assert.Equal(t, uint32(DefaultAccountTransactionsLimit), getAccountTransactions.Limit)
+
+	err = getAccountTransactions.Parse("f8d6e0586b0a20c7", "5", "10", "8:3", "20", chain)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), getAccountTransactions.StartHeight)
+	assert.Equal(t, uint64(10), getAccountTransactions.EndHeight)
+	assert.Equal(t, &flow.AccountTransactionCursor{BlockHeight: 8, TransactionIndex: 3}, getAccountTransactions.Cursor)
+	assert.Equal(t, uint32(20), getAccountTransactions.Limit)
+}
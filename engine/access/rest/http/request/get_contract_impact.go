@@ -0,0 +1,76 @@
+package request
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const contractNameVar = "name"
+const accountsQuery = "accounts"
+
+var contractNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type GetContractImpact struct {
+	Contract flow.ContractID
+	Height   uint64
+	Accounts []flow.Address
+}
+
+// GetContractImpactRequest extracts necessary variables and query parameters from the provided request,
+// builds a GetContractImpact instance, and validates it.
+//
+// No errors are expected during normal operation.
+func GetContractImpactRequest(r *common.Request) (GetContractImpact, error) {
+	var req GetContractImpact
+	err := req.Build(r)
+	return req, err
+}
+
+func (g *GetContractImpact) Build(r *common.Request) error {
+	return g.Parse(
+		r.GetVar(addressVar),
+		r.GetVar(contractNameVar),
+		r.GetQueryParam(heightQuery),
+		r.GetQueryParams(accountsQuery),
+		r.Chain,
+	)
+}
+
+func (g *GetContractImpact) Parse(
+	rawAddress string,
+	rawName string,
+	rawHeight string,
+	rawAccounts []string,
+	chain flow.Chain,
+) error {
+	address, err := ParseAddress(rawAddress, chain)
+	if err != nil {
+		return err
+	}
+
+	if !contractNameRegex.MatchString(rawName) {
+		return fmt.Errorf("invalid contract name")
+	}
+	g.Contract = flow.ContractID{Address: address, Name: rawName}
+
+	// the impact is computed at the height of the latest contract graph if no height is given
+	if rawHeight != "" {
+		g.Height, err = parseStorageDiffHeight(rawHeight)
+		if err != nil {
+			return fmt.Errorf("invalid height: %w", err)
+		}
+	}
+
+	g.Accounts = make([]flow.Address, len(rawAccounts))
+	for i, rawAccount := range rawAccounts {
+		g.Accounts[i], err = ParseAddress(rawAccount, chain)
+		if err != nil {
+			return fmt.Errorf("invalid account: %w", err)
+		}
+	}
+
+	return nil
+}
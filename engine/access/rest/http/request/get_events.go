@@ -2,6 +2,7 @@ package request
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/onflow/flow-go/engine/access/rest/common"
 	"github.com/onflow/flow-go/model/flow"
@@ -9,6 +10,7 @@ import (
 
 const eventTypeQuery = "type"
 const blockQuery = "block_ids"
+const whereQuery = "where"
 const MaxEventRequestHeightRange = 250
 
 type GetEvents struct {
@@ -16,6 +18,8 @@ type GetEvents struct {
 	EndHeight   uint64
 	Type        string
 	BlockIDs    []flow.Identifier
+	// Where is an optional predicate on the fields of the events, see state_stream.EventExpression.
+	Where string
 }
 
 // GetEventsRequest extracts necessary variables from the provided request,
@@ -34,10 +38,11 @@ func (g *GetEvents) Build(r *common.Request) error {
 		r.GetQueryParam(startHeightQuery),
 		r.GetQueryParam(endHeightQuery),
 		r.GetQueryParams(blockQuery),
+		r.GetQueryParam(whereQuery),
 	)
 }
 
-func (g *GetEvents) Parse(rawType string, rawStart string, rawEnd string, rawBlockIDs []string) error {
+func (g *GetEvents) Parse(rawType string, rawStart string, rawEnd string, rawBlockIDs []string, rawWhere string) error {
 	var height Height
 	err := height.Parse(rawStart)
 	if err != nil {
@@ -76,6 +81,7 @@ func (g *GetEvents) Parse(rawType string, rawStart string, rawEnd string, rawBlo
 		return err
 	}
 	g.Type = eventType.Flow()
+	g.Where = strings.TrimSpace(rawWhere)
 
 	// validate start end height option
 	if g.StartHeight != EmptyHeight && g.EndHeight != EmptyHeight {
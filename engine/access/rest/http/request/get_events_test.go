@@ -29,7 +29,7 @@ func TestGetEvents_InvalidParse(t *testing.T) {
 	}
 
 	for i, test := range tests {
-		err := getEvents.Parse(test.eventType, test.start, test.end, test.ids)
+		err := getEvents.Parse(test.eventType, test.start, test.end, test.ids, "")
 		assert.EqualError(t, err, test.err, fmt.Sprintf("test #%d failed", i))
 	}
 }
@@ -38,7 +38,7 @@ func TestGetEvents_ValidParse(t *testing.T) {
 	var getEvents GetEvents
 
 	event := "A.f8d6e0586b0a20c7.Foo.Bar"
-	err := getEvents.Parse(event, "5", "10", nil)
+	err := getEvents.Parse(event, "5", "10", nil, "")
 	assert.NoError(t, err)
 	assert.Equal(t, getEvents.Type, event)
 	assert.Equal(t, getEvents.StartHeight, uint64(5))
@@ -50,7 +50,7 @@ func TestGetEvents_ValidParse(t *testing.T) {
 		"7bc42fe85d32ca513769a74f97f7e1a7bad6c9407f0d934c2aa645ef9cf613c7",
 		"7bc42fe85d32ca513769a74f97f7e1a7bad6c9407f0d934c2aa645ef9cf613c7", // intentional duplication
 		"2ab81061b12d95fb81f2923001e340bc808e67e1eaae3c62479057cc14eb57fd",
-	})
+	}, "")
 	assert.NoError(t, err)
 	assert.Equal(t, getEvents.Type, event)
 	assert.Equal(t, getEvents.StartHeight, EmptyHeight)
@@ -59,4 +59,7 @@ func TestGetEvents_ValidParse(t *testing.T) {
 	assert.Equal(t, getEvents.BlockIDs[0].String(), "7bc42fe85d32ca513769a74f97f7e1a7bad6c9407f0d934c2aa645ef9cf613c7")
 	assert.Equal(t, getEvents.BlockIDs[1].String(), "2ab81061b12d95fb81f2923001e340bc808e67e1eaae3c62479057cc14eb57fd")
 
+	err = getEvents.Parse(event, "5", "10", nil, " address == 0x01 ")
+	assert.NoError(t, err)
+	assert.Equal(t, getEvents.Where, "address == 0x01")
 }
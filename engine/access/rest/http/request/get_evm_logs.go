@@ -0,0 +1,162 @@
+package request
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const evmAddressQuery = "address"
+const evmTopicQueryPrefix = "topic"
+
+// DefaultEVMLogsLimit is the number of logs returned if no limit is requested.
+const DefaultEVMLogsLimit = 100
+
+var evmAddressRegex = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+type GetEVMLogs struct {
+	Filter      flow.EVMLogFilter
+	StartHeight uint64
+	EndHeight   uint64
+	Cursor      *flow.EVMLogCursor
+	Limit       uint32
+}
+
+// GetEVMLogsRequest extracts necessary variables and query parameters from the provided request,
+// builds a GetEVMLogs instance, and validates it.
+//
+// No errors are expected during normal operation.
+func GetEVMLogsRequest(r *common.Request) (GetEVMLogs, error) {
+	var req GetEVMLogs
+	err := req.Build(r)
+	return req, err
+}
+
+func (g *GetEVMLogs) Build(r *common.Request) error {
+	rawTopics := make([][]string, flow.EVMLogMaxTopics)
+	for i := range rawTopics {
+		rawTopics[i] = r.GetQueryParams(fmt.Sprintf("%s%d", evmTopicQueryPrefix, i))
+	}
+
+	return g.Parse(
+		r.GetQueryParams(evmAddressQuery),
+		rawTopics,
+		r.GetQueryParam(startHeightQuery),
+		r.GetQueryParam(endHeightQuery),
+		r.GetQueryParam(cursorQuery),
+		r.GetQueryParam(limitQuery),
+	)
+}
+
+func (g *GetEVMLogs) Parse(
+	rawAddresses []string,
+	rawTopics [][]string,
+	rawStart string,
+	rawEnd string,
+	rawCursor string,
+	rawLimit string,
+) error {
+	filter, err := ParseEVMLogFilter(rawAddresses, rawTopics)
+	if err != nil {
+		return err
+	}
+	g.Filter = filter
+
+	g.StartHeight, err = parseAccountTransactionsHeight(rawStart)
+	if err != nil {
+		return fmt.Errorf("invalid start height: %w", err)
+	}
+	g.EndHeight, err = parseAccountTransactionsHeight(rawEnd)
+	if err != nil {
+		return fmt.Errorf("invalid end height: %w", err)
+	}
+	if g.EndHeight != 0 && g.StartHeight > g.EndHeight {
+		return fmt.Errorf("start height must be less than or equal to end height")
+	}
+
+	g.Cursor, err = ParseEVMLogCursor(rawCursor)
+	if err != nil {
+		return err
+	}
+
+	g.Limit = DefaultEVMLogsLimit
+	if rawLimit != "" {
+		limit, err := strconv.ParseUint(rawLimit, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid limit format")
+		}
+		if limit == 0 {
+			return fmt.Errorf("limit must be greater than 0")
+		}
+		g.Limit = uint32(limit)
+	}
+
+	return nil
+}
+
+// ParseEVMLogFilter parses the hex encoded contract addresses and the topics of each position of an
+// EVM log filter. Trailing positions without topics are omitted from the filter.
+func ParseEVMLogFilter(rawAddresses []string, rawTopics [][]string) (flow.EVMLogFilter, error) {
+	var filter flow.EVMLogFilter
+
+	if len(rawTopics) > flow.EVMLogMaxTopics {
+		return filter, fmt.Errorf("at most %d topic positions are supported", flow.EVMLogMaxTopics)
+	}
+
+	for _, raw := range rawAddresses {
+		if !evmAddressRegex.MatchString(raw) {
+			return filter, fmt.Errorf("invalid EVM address %s: must be a 0x prefixed 20 bytes hex string", raw)
+		}
+		filter.Addresses = append(filter.Addresses, gethCommon.HexToAddress(raw))
+	}
+
+	topics := make([][]gethCommon.Hash, len(rawTopics))
+	last := -1
+	for i, rawPosition := range rawTopics {
+		for _, raw := range rawPosition {
+			if !evmTxHashRegex.MatchString(raw) {
+				return filter, fmt.Errorf("invalid EVM log topic %s: must be a 0x prefixed 32 bytes hex string", raw)
+			}
+			topics[i] = append(topics[i], gethCommon.HexToHash(raw))
+			last = i
+		}
+	}
+	if last >= 0 {
+		filter.Topics = topics[:last+1]
+	}
+
+	return filter, nil
+}
+
+// ParseEVMLogCursor parses a cursor of the form "<block height>:<log index>".
+// An empty value returns a nil cursor.
+func ParseEVMLogCursor(raw string) (*flow.EVMLogCursor, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	rawHeight, rawIndex, ok := strings.Cut(raw, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid cursor format")
+	}
+
+	height, err := strconv.ParseUint(rawHeight, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor format")
+	}
+	index, err := strconv.ParseUint(rawIndex, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor format")
+	}
+
+	return &flow.EVMLogCursor{
+		BlockHeight: height,
+		LogIndex:    uint32(index),
+	}, nil
+}
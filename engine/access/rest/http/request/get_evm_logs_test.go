@@ -0,0 +1,68 @@
+package request
+
+import (
+	"fmt"
+	"testing"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	testEVMAddress = "0x00000000000000000000000266a55e7a7e5d5a72"
+	testEVMTopic   = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+)
+
+func TestGetEVMLogs_InvalidParse(t *testing.T) {
+	var getEVMLogs GetEVMLogs
+
+	tests := []struct {
+		addresses []string
+		topics    [][]string
+		start     string
+		end       string
+		cursor    string
+		limit     string
+		err       string
+	}{
+		{[]string{"0x01"}, nil, "", "", "", "", "invalid EVM address 0x01: must be a 0x prefixed 20 bytes hex string"},
+		{nil, [][]string{{"0x01"}}, "", "", "", "", "invalid EVM log topic 0x01: must be a 0x prefixed 32 bytes hex string"},
+		{nil, make([][]string, 5), "", "", "", "", "at most 4 topic positions are supported"},
+		{nil, nil, "sealed", "", "", "", "invalid start height: only explicit heights are supported"},
+		{nil, nil, "20", "10", "", "", "start height must be less than or equal to end height"},
+		{nil, nil, "", "", "10", "", "invalid cursor format"},
+		{nil, nil, "", "", "", "foo", "invalid limit format"},
+		{nil, nil, "", "", "", "0", "limit must be greater than 0"},
+	}
+
+	for i, test := range tests {
+		err := getEVMLogs.Parse(test.addresses, test.topics, test.start, test.end, test.cursor, test.limit)
+		assert.EqualError(t, err, test.err, fmt.Sprintf("test #%d failed", i))
+	}
+}
+
+func TestGetEVMLogs_ValidParse(t *testing.T) {
+	var getEVMLogs GetEVMLogs
+
+	err := getEVMLogs.Parse(nil, make([][]string, 4), "", "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, flow.EVMLogFilter{}, getEVMLogs.Filter)
+	assert.Equal(t, uint64(0), getEVMLogs.StartHeight)
+	assert.Equal(t, uint64(0), getEVMLogs.EndHeight)
+	assert.Nil(t, getEVMLogs.Cursor)
+	assert.Equal(t, uint32(DefaultEVMLogsLimit), getEVMLogs.Limit)
+
+	err = getEVMLogs.Parse([]string{testEVMAddress}, [][]string{nil, {testEVMTopic}, nil, nil}, "5", "10", "8:3", "20")
+	require.NoError(t, err)
+	assert.Equal(t, flow.EVMLogFilter{
+		Addresses: []gethCommon.Address{gethCommon.HexToAddress(testEVMAddress)},
+		Topics:    [][]gethCommon.Hash{nil, {gethCommon.HexToHash(testEVMTopic)}},
+	}, getEVMLogs.Filter)
+	assert.Equal(t, uint64(5), getEVMLogs.StartHeight)
+	assert.Equal(t, uint64(10), getEVMLogs.EndHeight)
+	assert.Equal(t, &flow.EVMLogCursor{BlockHeight: 8, LogIndex: 3}, getEVMLogs.Cursor)
+	assert.Equal(t, uint32(20), getEVMLogs.Limit)
+}
@@ -0,0 +1,105 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/fvm/evm/debug"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const tracerQuery = "tracer"
+const tracerConfigQuery = "tracer_config"
+const hashVar = "hash"
+
+var evmTxHashRegex = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+
+type GetEVMBlockTraces struct {
+	BlockID flow.Identifier
+	Config  debug.TracerConfig
+}
+
+// GetEVMBlockTracesRequest extracts necessary variables and query parameters from the provided request,
+// builds a GetEVMBlockTraces instance, and validates it.
+//
+// No errors are expected during normal operation.
+func GetEVMBlockTracesRequest(r *common.Request) (GetEVMBlockTraces, error) {
+	var req GetEVMBlockTraces
+	err := req.Build(r)
+	return req, err
+}
+
+func (g *GetEVMBlockTraces) Build(r *common.Request) error {
+	return g.Parse(
+		r.GetVar(idQuery),
+		r.GetQueryParam(tracerQuery),
+		r.GetQueryParam(tracerConfigQuery),
+	)
+}
+
+func (g *GetEVMBlockTraces) Parse(rawID string, rawTracer string, rawConfig string) error {
+	var id ID
+	err := id.Parse(rawID)
+	if err != nil {
+		return err
+	}
+	if id.Flow() == flow.ZeroID {
+		return fmt.Errorf("block ID must be provided")
+	}
+	g.BlockID = id.Flow()
+
+	g.Config = debug.DefaultTracerConfig
+	if rawTracer != "" || rawConfig != "" {
+		g.Config = debug.TracerConfig{Tracer: rawTracer}
+		if rawConfig != "" {
+			if !json.Valid([]byte(rawConfig)) {
+				return fmt.Errorf("invalid tracer config: must be valid JSON")
+			}
+			g.Config.Config = json.RawMessage(rawConfig)
+		}
+	}
+
+	return nil
+}
+
+type GetEVMTransactionTrace struct {
+	GetEVMBlockTraces
+	TxHash gethCommon.Hash
+}
+
+// GetEVMTransactionTraceRequest extracts necessary variables and query parameters from the provided request,
+// builds a GetEVMTransactionTrace instance, and validates it.
+//
+// No errors are expected during normal operation.
+func GetEVMTransactionTraceRequest(r *common.Request) (GetEVMTransactionTrace, error) {
+	var req GetEVMTransactionTrace
+	err := req.Build(r)
+	return req, err
+}
+
+func (g *GetEVMTransactionTrace) Build(r *common.Request) error {
+	return g.Parse(
+		r.GetVar(idQuery),
+		r.GetVar(hashVar),
+		r.GetQueryParam(tracerQuery),
+		r.GetQueryParam(tracerConfigQuery),
+	)
+}
+
+func (g *GetEVMTransactionTrace) Parse(rawID string, rawHash string, rawTracer string, rawConfig string) error {
+	err := g.GetEVMBlockTraces.Parse(rawID, rawTracer, rawConfig)
+	if err != nil {
+		return err
+	}
+
+	if !evmTxHashRegex.MatchString(rawHash) {
+		return fmt.Errorf("invalid EVM transaction hash: must be a 0x prefixed 32 bytes hex string")
+	}
+	g.TxHash = gethCommon.HexToHash(rawHash)
+
+	return nil
+}
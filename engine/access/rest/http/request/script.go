@@ -24,6 +24,10 @@ func (s *Script) Parse(raw io.Reader) error {
 		return err
 	}
 
+	return s.parse(body)
+}
+
+func (s *Script) parse(body scriptBody) error {
 	source, err := util.FromBase64(body.Script)
 	if err != nil {
 		return fmt.Errorf("invalid script source encoding")
@@ -0,0 +1,72 @@
+package request
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const skipSignatureCheckQuery = "skip_signature_check"
+
+type SimulateTransaction struct {
+	Transaction        flow.TransactionBody
+	BlockHeight        uint64
+	SkipSignatureCheck bool
+}
+
+// SimulateTransactionRequest extracts necessary variables and query parameters from the provided request,
+// builds a SimulateTransaction instance, and validates it.
+//
+// No errors are expected during normal operation.
+func SimulateTransactionRequest(r *common.Request) (SimulateTransaction, error) {
+	var req SimulateTransaction
+	err := req.Build(r)
+	return req, err
+}
+
+func (s *SimulateTransaction) Build(r *common.Request) error {
+	return s.Parse(
+		r.GetQueryParam(blockHeightQuery),
+		r.GetQueryParam(skipSignatureCheckQuery),
+		r.Body,
+		r.Chain,
+	)
+}
+
+func (s *SimulateTransaction) Parse(rawHeight string, rawSkipSignatureCheck string, rawTransaction io.Reader, chain flow.Chain) error {
+	var height Height
+	err := height.Parse(rawHeight)
+	if err != nil {
+		return err
+	}
+	s.BlockHeight = height.Flow()
+
+	// default to last sealed block
+	if s.BlockHeight == EmptyHeight {
+		s.BlockHeight = SealedHeight
+	}
+
+	if rawSkipSignatureCheck != "" {
+		s.SkipSignatureCheck, err = strconv.ParseBool(rawSkipSignatureCheck)
+		if err != nil {
+			return fmt.Errorf("invalid value for skip signature check: %s", rawSkipSignatureCheck)
+		}
+	}
+
+	// signatures are only required if they are verified
+	var tx Transaction
+	if s.SkipSignatureCheck {
+		err = tx.ParseUnsigned(rawTransaction, chain)
+	} else {
+		err = tx.Parse(rawTransaction, chain)
+	}
+	if err != nil {
+		return err
+	}
+	s.Transaction = tx.Flow()
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package request
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+type contractUpdateBody struct {
+	Code string `json:"code,omitempty"`
+}
+
+type ValidateContractUpdate struct {
+	Address     flow.Address
+	Code        []byte
+	BlockHeight uint64
+}
+
+// ValidateContractUpdateRequest extracts necessary variables and query parameters from the provided request,
+// builds a ValidateContractUpdate instance, and validates it.
+//
+// No errors are expected during normal operation.
+func ValidateContractUpdateRequest(r *common.Request) (ValidateContractUpdate, error) {
+	var req ValidateContractUpdate
+	err := req.Build(r)
+	return req, err
+}
+
+func (v *ValidateContractUpdate) Build(r *common.Request) error {
+	return v.Parse(
+		r.GetVar(addressVar),
+		r.GetQueryParam(blockHeightQuery),
+		r.Body,
+		r.Chain,
+	)
+}
+
+func (v *ValidateContractUpdate) Parse(rawAddress string, rawHeight string, rawBody io.Reader, chain flow.Chain) error {
+	address, err := ParseAddress(rawAddress, chain)
+	if err != nil {
+		return err
+	}
+	v.Address = address
+
+	var height Height
+	err = height.Parse(rawHeight)
+	if err != nil {
+		return err
+	}
+	v.BlockHeight = height.Flow()
+
+	// default to last sealed block
+	if v.BlockHeight == EmptyHeight {
+		v.BlockHeight = SealedHeight
+	}
+
+	var body contractUpdateBody
+	err = parseBody(rawBody, &body)
+	if err != nil {
+		return err
+	}
+
+	v.Code, err = util.FromBase64(body.Code)
+	if err != nil {
+		return fmt.Errorf("invalid contract code encoding")
+	}
+	if len(v.Code) == 0 {
+		return fmt.Errorf("contract code must be provided")
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+)
+
+// GetAccountStateProof handler retrieves registers of an account along with a proof of their values in the sealed execution state.
+func GetAccountStateProof(r *common.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := request.GetAccountStateProofRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	// in case we receive the special height value 'sealed', fetch that height and overwrite request with it
+	if req.Height == request.SealedHeight {
+		header, _, err := backend.GetLatestBlockHeader(r.Context(), true)
+		if err != nil {
+			return nil, err
+		}
+		req.Height = header.Height
+	}
+
+	proof, err := backend.GetAccountStateProof(r.Context(), req.Address, req.Keys, req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.AccountStateProof
+	response.Build(proof)
+	return response, nil
+}
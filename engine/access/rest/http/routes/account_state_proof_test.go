@@ -0,0 +1,175 @@
+package routes_test
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/router"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetAccountStateProof tests local getAccountStateProof request.
+//
+// Runs the following tests:
+// 1. Get the proof of registers at a given height.
+// 2. Get the proof at the latest sealed height.
+// 3. Get the proof with invalid parameters.
+// 4. Get the proof when no execution node provides it.
+func TestGetAccountStateProof(t *testing.T) {
+	backend := mock.NewAPI(t)
+	address := unittest.AddressFixture()
+	blockID := unittest.IdentifierFixture()
+	commit := unittest.StateCommitmentFixture()
+	encodedProof := unittest.RandomBytes(64)
+
+	t.Run("get proof at height", func(t *testing.T) {
+		proof := &flow.AccountStateProof{
+			Address:         address,
+			BlockID:         blockID,
+			BlockHeight:     100,
+			StateCommitment: commit,
+			Registers: flow.RegisterEntries{
+				{Key: flow.NewRegisterID(address, "storage"), Value: []byte{1, 2, 3}},
+				{Key: flow.NewRegisterID(address, "unallocated"), Value: nil},
+			},
+			Proof: encodedProof,
+		}
+
+		backend.Mock.
+			On("GetAccountStateProof", mocktestify.Anything, address, []string{"storage", "unallocated"}, uint64(100)).
+			Return(proof, nil).
+			Once()
+
+		req := getAccountStateProofRequest(t, address.String(), encodeKeys("storage", "unallocated"), "100")
+
+		expected := fmt.Sprintf(`{
+			"address": "%s",
+			"block_id": "%s",
+			"block_height": "100",
+			"state_commitment": "%s",
+			"registers": [
+				{"key": "%s", "value": "%s"},
+				{"key": "%s", "value": ""}
+			],
+			"proof": "%s"
+		}`,
+			address,
+			blockID,
+			hex.EncodeToString(commit[:]),
+			hex.EncodeToString([]byte("storage")),
+			base64.StdEncoding.EncodeToString([]byte{1, 2, 3}),
+			hex.EncodeToString([]byte("unallocated")),
+			base64.StdEncoding.EncodeToString(encodedProof),
+		)
+
+		router.AssertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get proof at sealed height", func(t *testing.T) {
+		header := unittest.BlockHeaderFixture()
+
+		backend.Mock.
+			On("GetLatestBlockHeader", mocktestify.Anything, true).
+			Return(header, flow.BlockStatusSealed, nil).
+			Once()
+
+		backend.Mock.
+			On("GetAccountStateProof", mocktestify.Anything, address, []string{}, header.Height).
+			Return(&flow.AccountStateProof{
+				Address:         address,
+				BlockID:         header.ID(),
+				BlockHeight:     header.Height,
+				StateCommitment: commit,
+				Registers:       flow.RegisterEntries{},
+				Proof:           encodedProof,
+			}, nil).
+			Once()
+
+		req := getAccountStateProofRequest(t, address.String(), "", "sealed")
+
+		expected := fmt.Sprintf(`{
+			"address": "%s",
+			"block_id": "%s",
+			"block_height": "%d",
+			"state_commitment": "%s",
+			"registers": [],
+			"proof": "%s"
+		}`,
+			address,
+			header.ID(),
+			header.Height,
+			hex.EncodeToString(commit[:]),
+			base64.StdEncoding.EncodeToString(encodedProof),
+		)
+
+		router.AssertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get proof with invalid parameters", func(t *testing.T) {
+		tests := []struct {
+			keys   string
+			height string
+			out    string
+		}{
+			{"zz", "100", `{"code":400,"message":"invalid key format"}`},
+			{"", "final", `{"code":400,"message":"only sealed heights are supported"}`},
+			{"", "foo", `{"code":400,"message":"invalid height format"}`},
+		}
+
+		for _, test := range tests {
+			req := getAccountStateProofRequest(t, address.String(), test.keys, test.height)
+			router.AssertResponse(t, req, http.StatusBadRequest, test.out, backend)
+		}
+	})
+
+	t.Run("get proof when unavailable", func(t *testing.T) {
+		backend.Mock.
+			On("GetAccountStateProof", mocktestify.Anything, address, []string{}, uint64(100)).
+			Return(nil, status.Error(codes.Unavailable, "no execution node provided a valid register proof")).
+			Once()
+
+		req := getAccountStateProofRequest(t, address.String(), "", "100")
+
+		expected := `{"code":503, "message":"Failed to process request: no execution node provided a valid register proof"}`
+		router.AssertResponse(t, req, http.StatusServiceUnavailable, expected, backend)
+	})
+}
+
+func getAccountStateProofRequest(t *testing.T, address string, keys string, height string) *http.Request {
+	u, err := url.ParseRequestURI(fmt.Sprintf("/v1/accounts/%s/state_proof", address))
+	require.NoError(t, err)
+	q := u.Query()
+
+	if keys != "" {
+		q.Add("keys", keys)
+	}
+	if height != "" {
+		q.Add("block_height", height)
+	}
+
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	require.NoError(t, err)
+	return req
+}
+
+func encodeKeys(keys ...string) string {
+	encoded := make([]string, len(keys))
+	for i, key := range keys {
+		encoded[i] = hex.EncodeToString([]byte(key))
+	}
+	return strings.Join(encoded, ",")
+}
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+)
+
+// GetAccountStorageDiff handler retrieves a page of the changes made to the storage of an account between two heights.
+func GetAccountStorageDiff(r *common.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := request.GetAccountStorageDiffRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	diff, err := backend.GetAccountStorageDiff(
+		r.Context(),
+		req.Address,
+		req.FromHeight,
+		req.ToHeight,
+		req.Cursor,
+		req.Limit,
+		req.DecodePaths,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.AccountStorageDiff
+	response.Build(diff)
+	return response, nil
+}
@@ -0,0 +1,189 @@
+package routes_test
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/router"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetAccountStorageDiff tests local getAccountStorageDiff request.
+//
+// Runs the following tests:
+// 1. Get the first page of changes with decoded paths.
+// 2. Get a page of changes with a cursor and limit.
+// 3. Get changes with invalid parameters.
+// 4. Get changes for heights which are not indexed.
+func TestGetAccountStorageDiff(t *testing.T) {
+	backend := mock.NewAPI(t)
+	address := unittest.AddressFixture()
+
+	t.Run("get first page", func(t *testing.T) {
+		cursor := flow.ContractKey("Foo")
+		diff := &flow.AccountStorageDiff{
+			Address:    address,
+			FromHeight: 10,
+			ToHeight:   20,
+			Changes: []flow.AccountRegisterChange{
+				{
+					RegisterChange: flow.RegisterChange{
+						ID:         flow.ContractRegisterID(address, "Foo"),
+						Type:       flow.RegisterAdded,
+						ValueAfter: []byte("access(all) contract Foo {}"),
+					},
+					Kind: flow.AccountRegisterContractCode,
+					Name: "Foo",
+				},
+			},
+			NextCursor: &cursor,
+			PathChanges: []flow.StoragePathChange{
+				{
+					Path:        "/storage/answer",
+					Type:        flow.RegisterModified,
+					ValueBefore: "41",
+					ValueAfter:  "42",
+				},
+			},
+			PathChangesDecoded: true,
+		}
+
+		backend.Mock.
+			On("GetAccountStorageDiff", mocktestify.Anything, address, uint64(10), uint64(20), (*string)(nil), uint32(100), true).
+			Return(diff, nil).
+			Once()
+
+		req := getAccountStorageDiffRequest(t, address.String(), "10", "20", "", "", "true")
+
+		expected := fmt.Sprintf(`{
+			"address": "%s",
+			"from_height": "10",
+			"to_height": "20",
+			"changes": [
+				{
+					"key": "%s",
+					"type": "added",
+					"kind": "contract_code",
+					"name": "Foo",
+					"value_after": "%s"
+				}
+			],
+			"path_changes": [
+				{
+					"path": "/storage/answer",
+					"type": "modified",
+					"value_before": "41",
+					"value_after": "42"
+				}
+			],
+			"path_changes_decoded": true,
+			"next_cursor": "%s"
+		}`,
+			address,
+			hex.EncodeToString([]byte(cursor)),
+			base64.StdEncoding.EncodeToString([]byte("access(all) contract Foo {}")),
+			hex.EncodeToString([]byte(cursor)),
+		)
+
+		router.AssertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get page with cursor", func(t *testing.T) {
+		cursor := flow.ContractKey("Foo")
+
+		backend.Mock.
+			On("GetAccountStorageDiff", mocktestify.Anything, address, uint64(10), uint64(20), &cursor, uint32(2), false).
+			Return(&flow.AccountStorageDiff{Address: address, FromHeight: 10, ToHeight: 20}, nil).
+			Once()
+
+		req := getAccountStorageDiffRequest(t, address.String(), "10", "20", hex.EncodeToString([]byte(cursor)), "2", "")
+
+		expected := fmt.Sprintf(`{
+			"address": "%s",
+			"from_height": "10",
+			"to_height": "20",
+			"changes": [],
+			"path_changes_decoded": false
+		}`, address)
+
+		router.AssertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get with invalid parameters", func(t *testing.T) {
+		tests := []struct {
+			from, to, cursor, limit, decode string
+			out                             string
+		}{
+			{"", "20", "", "", "", `{"code":400,"message":"invalid from height: height is required"}`},
+			{"10", "sealed", "", "", "", `{"code":400,"message":"invalid to height: only explicit heights are supported"}`},
+			{"20", "10", "", "", "", `{"code":400,"message":"from height must be less than or equal to to height"}`},
+			{"10", "20", "zz", "", "", `{"code":400,"message":"invalid cursor format"}`},
+			{"10", "20", "", "0", "", `{"code":400,"message":"limit must be greater than 0"}`},
+			{"10", "20", "", "", "maybe", `{"code":400,"message":"invalid decode paths value"}`},
+		}
+
+		for _, test := range tests {
+			req := getAccountStorageDiffRequest(t, address.String(), test.from, test.to, test.cursor, test.limit, test.decode)
+			router.AssertResponse(t, req, http.StatusBadRequest, test.out, backend)
+		}
+	})
+
+	t.Run("get for heights not indexed", func(t *testing.T) {
+		backend.Mock.
+			On("GetAccountStorageDiff", mocktestify.Anything, address, uint64(10), uint64(2000), (*string)(nil), uint32(100), false).
+			Return(nil, status.Error(codes.OutOfRange, "registers in height range [10, 2000] are not indexed")).
+			Once()
+
+		req := getAccountStorageDiffRequest(t, address.String(), "10", "2000", "", "", "")
+
+		expected := `{"code":500, "message":"internal server error"}`
+		router.AssertResponse(t, req, http.StatusInternalServerError, expected, backend)
+	})
+}
+
+func getAccountStorageDiffRequest(
+	t *testing.T,
+	address string,
+	from string,
+	to string,
+	cursor string,
+	limit string,
+	decodePaths string,
+) *http.Request {
+	u, err := url.ParseRequestURI(fmt.Sprintf("/v1/accounts/%s/storage_diff", address))
+	require.NoError(t, err)
+	q := u.Query()
+
+	if from != "" {
+		q.Add("from_height", from)
+	}
+	if to != "" {
+		q.Add("to_height", to)
+	}
+	if cursor != "" {
+		q.Add("cursor", cursor)
+	}
+	if limit != "" {
+		q.Add("limit", limit)
+	}
+	if decodePaths != "" {
+		q.Add("decode_paths", decodePaths)
+	}
+
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	require.NoError(t, err)
+	return req
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+)
+
+// GetAccountTransactions handler retrieves a page of the transaction history of an account, newest first.
+func GetAccountTransactions(r *common.Request, backend access.API, link models.LinkGenerator) (interface{}, error) {
+	req, err := request.GetAccountTransactionsRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	page, err := backend.GetAccountTransactions(r.Context(), req.Address, req.StartHeight, req.EndHeight, req.Cursor, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.AccountTransactions
+	err = response.Build(page, link)
+	return response, err
+}
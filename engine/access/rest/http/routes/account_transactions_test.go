@@ -0,0 +1,128 @@
+package routes_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/router"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetAccountTransactions tests local getAccountTransactions request.
+//
+// Runs the following tests:
+// 1. Get the first page of transactions with the default limit.
+// 2. Get a page of transactions with height bounds, cursor and limit.
+// 3. Get transactions with an invalid cursor.
+// 4. Get transactions with a limit exceeding the maximum.
+func TestGetAccountTransactions(t *testing.T) {
+	backend := mock.NewAPI(t)
+	address := unittest.AddressFixture()
+
+	t.Run("get first page", func(t *testing.T) {
+		txID := unittest.IdentifierFixture()
+		page := &access.AccountTransactionsPage{
+			Transactions: []flow.AccountTransaction{
+				{
+					Address:          address,
+					BlockHeight:      12,
+					TransactionID:    txID,
+					TransactionIndex: 1,
+					Roles:            []flow.AccountTransactionRole{flow.AccountTransactionRolePayer, flow.AccountTransactionRoleAuthorizer},
+				},
+			},
+			NextCursor: &flow.AccountTransactionCursor{BlockHeight: 10, TransactionIndex: 0},
+		}
+
+		backend.Mock.
+			On("GetAccountTransactions", mocktestify.Anything, address, uint64(0), uint64(0), (*flow.AccountTransactionCursor)(nil), uint32(50)).
+			Return(page, nil).
+			Once()
+
+		req := getAccountTransactionsRequest(t, address.String(), "", "", "", "")
+
+		expected := fmt.Sprintf(`{
+			"transactions": [
+				{
+					"transaction_id": "%s",
+					"transaction_index": "1",
+					"block_height": "12",
+					"roles": ["payer", "authorizer"],
+					"_links": {
+						"_self": "/v1/transactions/%s"
+					}
+				}
+			],
+			"next_cursor": "10:0"
+		}`, txID, txID)
+
+		router.AssertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get page with bounds and cursor", func(t *testing.T) {
+		cursor := &flow.AccountTransactionCursor{BlockHeight: 10, TransactionIndex: 0}
+
+		backend.Mock.
+			On("GetAccountTransactions", mocktestify.Anything, address, uint64(5), uint64(20), cursor, uint32(2)).
+			Return(&access.AccountTransactionsPage{Transactions: []flow.AccountTransaction{}}, nil).
+			Once()
+
+		req := getAccountTransactionsRequest(t, address.String(), "5", "20", "10:0", "2")
+
+		router.AssertOKResponse(t, req, `{"transactions": []}`, backend)
+	})
+
+	t.Run("get with invalid cursor", func(t *testing.T) {
+		req := getAccountTransactionsRequest(t, address.String(), "", "", "foo", "")
+
+		expected := `{"code":400, "message":"invalid cursor format"}`
+		router.AssertResponse(t, req, http.StatusBadRequest, expected, backend)
+	})
+
+	t.Run("get with limit exceeding maximum", func(t *testing.T) {
+		backend.Mock.
+			On("GetAccountTransactions", mocktestify.Anything, address, uint64(0), uint64(0), (*flow.AccountTransactionCursor)(nil), uint32(5000)).
+			Return(nil, status.Error(codes.InvalidArgument, "limit must be between 1 and 1000")).
+			Once()
+
+		req := getAccountTransactionsRequest(t, address.String(), "", "", "", "5000")
+
+		expected := `{"code":400, "message":"Invalid Flow argument: limit must be between 1 and 1000"}`
+		router.AssertResponse(t, req, http.StatusBadRequest, expected, backend)
+	})
+}
+
+func getAccountTransactionsRequest(t *testing.T, address string, start string, end string, cursor string, limit string) *http.Request {
+	u, err := url.ParseRequestURI(fmt.Sprintf("/v1/accounts/%s/transactions", address))
+	require.NoError(t, err)
+	q := u.Query()
+
+	if start != "" {
+		q.Add("start_height", start)
+	}
+	if end != "" {
+		q.Add("end_height", end)
+	}
+	if cursor != "" {
+		q.Add("cursor", cursor)
+	}
+	if limit != "" {
+		q.Add("limit", limit)
+	}
+
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	require.NoError(t, err)
+	return req
+}
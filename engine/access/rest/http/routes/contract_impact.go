@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+)
+
+// GetContractImpact handler retrieves the contracts depending on a contract, and the stored values of the
+// requested accounts which reference types of the contract or its dependents.
+func GetContractImpact(r *common.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := request.GetContractImpactRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	impact, err := backend.GetContractImpact(r.Context(), req.Contract, req.Height, req.Accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.ContractImpact
+	response.Build(impact)
+	return response, nil
+}
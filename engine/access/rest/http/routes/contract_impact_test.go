@@ -0,0 +1,156 @@
+package routes_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/router"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetContractImpact tests local getContractImpact request.
+//
+// Runs the following tests:
+// 1. Get the impact of a contract with scanned accounts.
+// 2. Get the impact at the height of the latest contract graph.
+// 3. Get the impact with invalid parameters.
+// 4. Get the impact of a contract which is not deployed.
+func TestGetContractImpact(t *testing.T) {
+	backend := mock.NewAPI(t)
+	address := unittest.AddressFixture()
+	account := unittest.RandomAddressFixture()
+
+	contract := flow.ContractID{Address: address, Name: "Base"}
+	dependent := flow.ContractID{Address: address, Name: "Dependent"}
+	broken := flow.ContractID{Address: address, Name: "Broken"}
+
+	t.Run("get impact", func(t *testing.T) {
+		impact := &flow.ContractImpact{
+			Contract:          contract,
+			Height:            20,
+			Dependents:        []flow.ContractDependent{{ContractID: dependent, Depth: 1}},
+			UnparsedContracts: []flow.ContractID{broken},
+			StoredReferences: []flow.StoredTypeReference{
+				{
+					Address: account,
+					Path:    "/storage/resource",
+					TypeIDs: []string{dependent.String() + ".R"},
+				},
+			},
+			ScannedAccounts: 1,
+		}
+
+		backend.Mock.
+			On("GetContractImpact", mocktestify.Anything, contract, uint64(20), []flow.Address{account}).
+			Return(impact, nil).
+			Once()
+
+		req := getContractImpactRequest(t, address.String(), contract.Name, "20", account.String())
+
+		expected := fmt.Sprintf(`{
+			"address": "%[1]s",
+			"name": "Base",
+			"height": "20",
+			"dependents": [
+				{"address": "%[1]s", "name": "Dependent", "depth": "1"}
+			],
+			"unparsed_contracts": [
+				{"address": "%[1]s", "name": "Broken"}
+			],
+			"stored_references": [
+				{"address": "%[2]s", "path": "/storage/resource", "type_ids": ["%[3]s"]}
+			],
+			"scanned_accounts": "1"
+		}`, address, account, dependent.String()+".R")
+
+		router.AssertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get impact at latest graph", func(t *testing.T) {
+		impact := &flow.ContractImpact{
+			Contract: contract,
+			Height:   25,
+		}
+
+		backend.Mock.
+			On("GetContractImpact", mocktestify.Anything, contract, uint64(0), []flow.Address{}).
+			Return(impact, nil).
+			Once()
+
+		req := getContractImpactRequest(t, address.String(), contract.Name, "", "")
+
+		expected := fmt.Sprintf(`{
+			"address": "%s",
+			"name": "Base",
+			"height": "25",
+			"dependents": [],
+			"unparsed_contracts": [],
+			"stored_references": [],
+			"scanned_accounts": "0"
+		}`, address)
+
+		router.AssertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get with invalid parameters", func(t *testing.T) {
+		tests := []struct {
+			name, height, accounts string
+			out                    string
+		}{
+			{"Base", "sealed", "", `{"code":400,"message":"invalid height: only explicit heights are supported"}`},
+			{"1Base", "20", "", `{"code":400,"message":"invalid contract name"}`},
+			{"Base", "20", "zz", `{"code":400,"message":"invalid account: invalid address"}`},
+		}
+
+		for _, test := range tests {
+			req := getContractImpactRequest(t, address.String(), test.name, test.height, test.accounts)
+			router.AssertResponse(t, req, http.StatusBadRequest, test.out, backend)
+		}
+	})
+
+	t.Run("get contract not deployed", func(t *testing.T) {
+		backend.Mock.
+			On("GetContractImpact", mocktestify.Anything, contract, uint64(20), []flow.Address{}).
+			Return(nil, status.Error(codes.NotFound, "contract is not deployed")).
+			Once()
+
+		req := getContractImpactRequest(t, address.String(), contract.Name, "20", "")
+
+		expected := `{"code":404, "message":"Flow resource not found: contract is not deployed"}`
+		router.AssertResponse(t, req, http.StatusNotFound, expected, backend)
+	})
+}
+
+func getContractImpactRequest(
+	t *testing.T,
+	address string,
+	name string,
+	height string,
+	accounts string,
+) *http.Request {
+	u, err := url.ParseRequestURI(fmt.Sprintf("/v1/accounts/%s/contract_impact/%s", address, name))
+	require.NoError(t, err)
+	q := u.Query()
+
+	if height != "" {
+		q.Add("height", height)
+	}
+	if accounts != "" {
+		q.Add("accounts", accounts)
+	}
+
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	require.NoError(t, err)
+	return req
+}
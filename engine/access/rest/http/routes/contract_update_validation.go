@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+)
+
+// ValidateContractUpdate validates an update of a contract of the account to the code from the provided
+// payload, without submitting a transaction, and returns all reasons for which it would be rejected.
+func ValidateContractUpdate(r *common.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := request.ValidateContractUpdateRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	if req.BlockHeight == request.SealedHeight || req.BlockHeight == request.FinalHeight {
+		latest, _, err := backend.GetLatestBlockHeader(r.Context(), req.BlockHeight == request.SealedHeight)
+		if err != nil {
+			return nil, err
+		}
+		req.BlockHeight = latest.Height
+	}
+
+	validation, err := backend.ValidateContractUpdate(r.Context(), req.Address, req.Code, req.BlockHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.ContractUpdateValidation
+	response.Build(validation)
+	return response, nil
+}
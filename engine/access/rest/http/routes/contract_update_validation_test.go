@@ -0,0 +1,166 @@
+package routes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/router"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestValidateContractUpdate tests local validateContractUpdate request.
+//
+// Runs the following tests:
+// 1. Validate an update with violations at an explicit height.
+// 2. Validate an update at the latest sealed height.
+// 3. Validate an update with invalid parameters.
+// 4. Validate an update at a height which is not found.
+func TestValidateContractUpdate(t *testing.T) {
+	backend := mock.NewAPI(t)
+	address := unittest.AddressFixture()
+	code := []byte(`access(all) contract Foo {}`)
+	body := map[string]string{"code": util.ToBase64(code)}
+
+	t.Run("validate with violations", func(t *testing.T) {
+		block := unittest.BlockHeaderFixture()
+		validation := &flow.ContractUpdateValidation{
+			Address:     address,
+			Name:        "Foo",
+			BlockID:     block.ID(),
+			BlockHeight: block.Height,
+			Violations: []flow.ContractUpdateViolation{
+				{
+					Message: "mismatching field `x` in `Foo`",
+					Detail:  "incompatible type annotations. expected `Int`, found `String`",
+					Start:   &flow.ContractCodePosition{Line: 2, Column: 4},
+					End:     &flow.ContractCodePosition{Line: 2, Column: 28},
+				},
+				{
+					Message: "cannot update non-existing contract",
+				},
+			},
+		}
+
+		backend.Mock.
+			On("ValidateContractUpdate", mocktestify.Anything, address, code, block.Height).
+			Return(validation, nil).
+			Once()
+
+		req := validateContractUpdateReq(t, address.String(), body, fmt.Sprint(block.Height))
+
+		expected := fmt.Sprintf(`{
+			"address": "%s",
+			"name": "Foo",
+			"block_id": "%s",
+			"block_height": "%d",
+			"valid": false,
+			"violations": [
+				{
+					"message": "mismatching field `+"`x`"+` in `+"`Foo`"+`",
+					"detail": "incompatible type annotations. expected `+"`Int`"+`, found `+"`String`"+`",
+					"start": {"line": 2, "column": 4},
+					"end": {"line": 2, "column": 28}
+				},
+				{
+					"message": "cannot update non-existing contract"
+				}
+			]
+		}`, address, block.ID(), block.Height)
+
+		router.AssertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("validate at sealed height", func(t *testing.T) {
+		block := unittest.BlockHeaderFixture()
+
+		backend.Mock.
+			On("GetLatestBlockHeader", mocktestify.Anything, true).
+			Return(block, flow.BlockStatusSealed, nil).
+			Once()
+
+		backend.Mock.
+			On("ValidateContractUpdate", mocktestify.Anything, address, code, block.Height).
+			Return(&flow.ContractUpdateValidation{
+				Address:     address,
+				Name:        "Foo",
+				BlockID:     block.ID(),
+				BlockHeight: block.Height,
+			}, nil).
+			Once()
+
+		req := validateContractUpdateReq(t, address.String(), body, "")
+
+		expected := fmt.Sprintf(`{
+			"address": "%s",
+			"name": "Foo",
+			"block_id": "%s",
+			"block_height": "%d",
+			"valid": true,
+			"violations": []
+		}`, address, block.ID(), block.Height)
+
+		router.AssertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("validate with invalid parameters", func(t *testing.T) {
+		tests := []struct {
+			address string
+			body    map[string]string
+			height  string
+			out     string
+		}{
+			{"zz", body, "", `{"code":400,"message":"invalid address"}`},
+			{address.String(), body, "foo", `{"code":400,"message":"invalid height format"}`},
+			{address.String(), map[string]string{"code": "foo"}, "", `{"code":400,"message":"invalid contract code encoding"}`},
+			{address.String(), map[string]string{}, "", `{"code":400,"message":"contract code must be provided"}`},
+		}
+
+		for _, test := range tests {
+			req := validateContractUpdateReq(t, test.address, test.body, test.height)
+			router.AssertResponse(t, req, http.StatusBadRequest, test.out, backend)
+		}
+	})
+
+	t.Run("validate at height not found", func(t *testing.T) {
+		backend.Mock.
+			On("ValidateContractUpdate", mocktestify.Anything, address, code, uint64(20)).
+			Return(nil, status.Error(codes.NotFound, "block not found")).
+			Once()
+
+		req := validateContractUpdateReq(t, address.String(), body, "20")
+
+		expected := `{"code":404, "message":"Flow resource not found: block not found"}`
+		router.AssertResponse(t, req, http.StatusNotFound, expected, backend)
+	})
+}
+
+func validateContractUpdateReq(t *testing.T, address string, body interface{}, height string) *http.Request {
+	u, err := url.ParseRequestURI(fmt.Sprintf("/v1/accounts/%s/validate_contract_update", address))
+	require.NoError(t, err)
+	q := u.Query()
+
+	if height != "" {
+		q.Add("block_height", height)
+	}
+
+	u.RawQuery = q.Encode()
+
+	jsonBody, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewBuffer(jsonBody))
+	require.NoError(t, err)
+	return req
+}
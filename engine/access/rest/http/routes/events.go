@@ -3,10 +3,14 @@ package routes
 import (
 	"fmt"
 
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
 	entitiesproto "github.com/onflow/flow/protobuf/go/flow/entities"
 
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/model/flow"
 
 	"github.com/onflow/flow-go/engine/access/rest/http/models"
 	"github.com/onflow/flow-go/engine/access/rest/http/request"
@@ -22,6 +26,18 @@ func GetEvents(r *common.Request, backend access.API, _ models.LinkGenerator) (i
 		return nil, common.NewBadRequestError(err)
 	}
 
+	var expr *state_stream.EventExpression
+	if req.Where != "" {
+		expr, err = state_stream.ParseEventExpression(
+			state_stream.DefaultEventFilterConfig,
+			r.Chain,
+			fmt.Sprintf("%s where %s", req.Type, req.Where),
+		)
+		if err != nil {
+			return nil, common.NewBadRequestError(fmt.Errorf("invalid where clause: %w", err))
+		}
+	}
+
 	// if the request has block IDs provided then return events for block IDs
 	var blocksEvents models.BlocksEvents
 	if len(req.BlockIDs) > 0 {
@@ -35,6 +51,11 @@ func GetEvents(r *common.Request, backend access.API, _ models.LinkGenerator) (i
 			return nil, err
 		}
 
+		events, err = filterEvents(events, expr)
+		if err != nil {
+			return nil, err
+		}
+
 		blocksEvents.Build(events)
 		return blocksEvents, nil
 	}
@@ -65,6 +86,42 @@ func GetEvents(r *common.Request, backend access.API, _ models.LinkGenerator) (i
 		return nil, err
 	}
 
+	events, err = filterEvents(events, expr)
+	if err != nil {
+		return nil, err
+	}
+
 	blocksEvents.Build(events)
 	return blocksEvents, nil
 }
+
+// filterEvents removes all events not matching the expression from the JSON-CDC encoded block events.
+// All events are returned if the expression is nil.
+//
+// No errors are expected during normal operation.
+func filterEvents(blocksEvents []flow.BlockEvents, expr *state_stream.EventExpression) ([]flow.BlockEvents, error) {
+	if expr == nil {
+		return blocksEvents, nil
+	}
+
+	for i, blockEvents := range blocksEvents {
+		var filtered []flow.Event
+		for _, event := range blockEvents.Events {
+			value, err := jsoncdc.Decode(nil, event.Payload)
+			if err != nil {
+				return nil, fmt.Errorf("could not decode event payload: %w", err)
+			}
+			cdcEvent, ok := value.(cadence.Event)
+			if !ok {
+				return nil, fmt.Errorf("unexpected event payload type: %T", value)
+			}
+
+			if event.Type == expr.EventType && expr.MatchFields(cadence.FieldsMappedByName(cdcEvent)) {
+				filtered = append(filtered, event)
+			}
+		}
+		blocksEvents[i].Events = filtered
+	}
+
+	return blocksEvents, nil
+}
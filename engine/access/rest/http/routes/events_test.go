@@ -1,6 +1,7 @@
 package routes_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -9,6 +10,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
 	mocks "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
@@ -134,6 +138,88 @@ func TestGetEvents(t *testing.T) {
 
 }
 
+// TestGetEventsWhere tests that events are filtered with the predicate of the where query parameter.
+func TestGetEventsWhere(t *testing.T) {
+	backend := &mock.API{}
+
+	address := flow.Testnet.Chain().ServiceAddress()
+	eventType := flow.EventType(fmt.Sprintf("A.%s.Foo.Bar", address.Hex()))
+
+	header := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(1))
+	blockEvents := unittest.BlockEventsFixture(header, 0)
+	for i, amount := range []int{5, 50, 500} {
+		event := unittest.EventFixture(eventType, 0, uint32(i), unittest.IdentifierFixture(), 0)
+		event.Payload = jsonCDCEventPayload(t, address, amount)
+		blockEvents.Events = append(blockEvents.Events, event)
+	}
+
+	backend.Mock.
+		On("GetEventsForHeightRange", mocks.Anything, string(eventType), uint64(1), uint64(1), entities.EventEncodingVersion_JSON_CDC_V0).
+		Return(func(context.Context, string, uint64, uint64, entities.EventEncodingVersion) []flow.BlockEvents {
+			// the route filters the returned events in place
+			events := blockEvents
+			events.Events = append([]flow.Event(nil), blockEvents.Events...)
+			return []flow.BlockEvents{events}
+		}, nil)
+
+	expected := blockEvents
+	expected.Events = blockEvents.Events[1:]
+
+	testVectors := []testVector{
+		{
+			description:      "Get events matching the where clause",
+			request:          getEventWhereReq(t, string(eventType), "1", "1", "amount > 10"),
+			expectedStatus:   http.StatusOK,
+			expectedResponse: testBlockEventResponse(t, []flow.BlockEvents{expected}),
+		},
+		{
+			description:    "Get no events matching the where clause",
+			request:        getEventWhereReq(t, string(eventType), "1", "1", "amount > 1000 or missing == true"),
+			expectedStatus: http.StatusOK,
+			expectedResponse: testBlockEventResponse(t, []flow.BlockEvents{{
+				BlockID:        blockEvents.BlockID,
+				BlockHeight:    blockEvents.BlockHeight,
+				BlockTimestamp: blockEvents.BlockTimestamp,
+			}}),
+		},
+		{
+			description:      "Get invalid - invalid where clause",
+			request:          getEventWhereReq(t, string(eventType), "1", "1", "amount >"),
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"code":400,"message":"invalid where clause: invalid predicate: expected value"}`,
+		},
+	}
+
+	for _, test := range testVectors {
+		t.Run(test.description, func(t *testing.T) {
+			router.AssertResponse(t, test.request, test.expectedStatus, test.expectedResponse, backend)
+		})
+	}
+}
+
+func getEventWhereReq(t *testing.T, eventType string, start string, end string, where string) *http.Request {
+	req := getEventReq(t, eventType, start, end, nil)
+
+	q := req.URL.Query()
+	q.Add("where", where)
+	req.URL.RawQuery = q.Encode()
+
+	return req
+}
+
+// jsonCDCEventPayload returns the JSON-CDC encoded payload of a Foo.Bar event with the given amount.
+func jsonCDCEventPayload(t *testing.T, address flow.Address, amount int) []byte {
+	location := common.NewAddressLocation(nil, common.Address(address), "Foo")
+	eventType := cadence.NewEventType(location, "Foo.Bar", []cadence.Field{
+		{Identifier: "amount", Type: cadence.IntType},
+	}, nil)
+
+	payload, err := jsoncdc.Encode(cadence.NewEvent([]cadence.Value{cadence.NewInt(amount)}).WithType(eventType))
+	require.NoError(t, err)
+
+	return payload
+}
+
 func getEventReq(t *testing.T, eventType string, start string, end string, blockIDs []string) *http.Request {
 	u, _ := url.Parse("/v1/events")
 	q := u.Query()
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+)
+
+// GetEVMLogs handler retrieves a page of the EVM logs matching the requested filter, oldest first.
+func GetEVMLogs(r *common.Request, backend access.API, link models.LinkGenerator) (interface{}, error) {
+	req, err := request.GetEVMLogsRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	page, err := backend.GetEVMLogs(r.Context(), req.Filter, req.StartHeight, req.EndHeight, req.Cursor, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.EvmLogs
+	err = response.Build(page, link)
+	return response, err
+}
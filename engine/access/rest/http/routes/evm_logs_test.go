@@ -0,0 +1,139 @@
+package routes_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/router"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetEVMLogs tests local getEVMLogs request.
+//
+// Runs the following tests:
+// 1. Get the first page of logs matching an address and topic filter.
+// 2. Get a page of logs with height bounds, cursor and limit.
+// 3. Get logs with an invalid address.
+func TestGetEVMLogs(t *testing.T) {
+	backend := mock.NewAPI(t)
+
+	address := gethCommon.HexToAddress("0x00000000000000000000000266a55e7a7e5d5a72")
+	transfer := gethCommon.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	recipient := gethCommon.HexToHash("0x01")
+
+	t.Run("get first page", func(t *testing.T) {
+		txID := unittest.IdentifierFixture()
+		evmTxHash := gethCommon.HexToHash("0xaa")
+		page := &access.EVMLogsPage{
+			Logs: []flow.EVMLog{
+				{
+					BlockHeight:         12,
+					TransactionID:       txID,
+					EVMBlockHeight:      7,
+					EVMTransactionHash:  evmTxHash,
+					EVMTransactionIndex: 1,
+					LogIndex:            3,
+					Address:             address,
+					Topics:              []gethCommon.Hash{transfer, recipient},
+					Data:                []byte{0x01, 0x02},
+				},
+			},
+			NextCursor: &flow.EVMLogCursor{BlockHeight: 14, LogIndex: 0},
+		}
+
+		filter := flow.EVMLogFilter{
+			Addresses: []gethCommon.Address{address},
+			Topics:    [][]gethCommon.Hash{{transfer}, nil, {recipient}},
+		}
+		backend.Mock.
+			On("GetEVMLogs", mocktestify.Anything, filter, uint64(0), uint64(0), (*flow.EVMLogCursor)(nil), uint32(100)).
+			Return(page, nil).
+			Once()
+
+		req := getEVMLogsRequest(t, address.Hex(), []string{transfer.Hex(), "", recipient.Hex()}, "", "", "", "")
+
+		expected := fmt.Sprintf(`{
+			"logs": [
+				{
+					"block_height": "12",
+					"transaction_id": "%s",
+					"evm_block_height": "7",
+					"evm_transaction_hash": "%s",
+					"evm_transaction_index": "1",
+					"log_index": "3",
+					"address": "%s",
+					"topics": ["%s", "%s"],
+					"data": "0x0102",
+					"_links": {
+						"_self": "/v1/transactions/%s"
+					}
+				}
+			],
+			"next_cursor": "14:0"
+		}`, txID, evmTxHash.Hex(), address.Hex(), transfer.Hex(), recipient.Hex(), txID)
+
+		router.AssertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get page with bounds and cursor", func(t *testing.T) {
+		cursor := &flow.EVMLogCursor{BlockHeight: 10, LogIndex: 2}
+
+		backend.Mock.
+			On("GetEVMLogs", mocktestify.Anything, flow.EVMLogFilter{}, uint64(5), uint64(20), cursor, uint32(2)).
+			Return(&access.EVMLogsPage{Logs: []flow.EVMLog{}}, nil).
+			Once()
+
+		req := getEVMLogsRequest(t, "", nil, "5", "20", "10:2", "2")
+
+		router.AssertOKResponse(t, req, `{"logs": []}`, backend)
+	})
+
+	t.Run("get with invalid address", func(t *testing.T) {
+		req := getEVMLogsRequest(t, "0x01", nil, "", "", "", "")
+
+		expected := `{"code":400, "message":"invalid EVM address 0x01: must be a 0x prefixed 20 bytes hex string"}`
+		router.AssertResponse(t, req, http.StatusBadRequest, expected, backend)
+	})
+}
+
+func getEVMLogsRequest(t *testing.T, address string, topics []string, start string, end string, cursor string, limit string) *http.Request {
+	u, err := url.ParseRequestURI("/v1/evm/logs")
+	require.NoError(t, err)
+	q := u.Query()
+
+	if address != "" {
+		q.Add("address", address)
+	}
+	for i, topic := range topics {
+		if topic != "" {
+			q.Add(fmt.Sprintf("topic%d", i), topic)
+		}
+	}
+	if start != "" {
+		q.Add("start_height", start)
+	}
+	if end != "" {
+		q.Add("end_height", end)
+	}
+	if cursor != "" {
+		q.Add("cursor", cursor)
+	}
+	if limit != "" {
+		q.Add("limit", limit)
+	}
+
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	require.NoError(t, err)
+	return req
+}
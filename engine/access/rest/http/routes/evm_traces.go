@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+	"github.com/onflow/flow-go/fvm/evm/debug"
+)
+
+// GetEVMBlockTraces handler retrieves the traces of the EVM transactions executed by a block.
+func GetEVMBlockTraces(r *common.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := request.GetEVMBlockTracesRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	traces, err := backend.GetEVMBlockTraces(r.Context(), req.BlockID, req.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]models.EvmTransactionTrace, len(traces))
+	for i, trace := range traces {
+		response[i].Build(trace)
+	}
+	return response, nil
+}
+
+// GetEVMTransactionTrace handler retrieves the trace of an EVM transaction executed by a block.
+func GetEVMTransactionTrace(r *common.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := request.GetEVMTransactionTraceRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	trace, err := backend.GetEVMTransactionTrace(r.Context(), req.BlockID, req.TxHash, req.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.EvmTransactionTrace
+	response.Build(debug.TransactionTrace{
+		TxHash: req.TxHash,
+		Result: trace,
+	})
+	return response, nil
+}
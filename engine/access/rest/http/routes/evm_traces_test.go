@@ -0,0 +1,186 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/router"
+	"github.com/onflow/flow-go/fvm/evm/debug"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetEVMBlockTraces tests local getEVMBlockTraces request.
+//
+// Runs the following tests:
+// 1. Get the traces of a block with the default tracer.
+// 2. Get the traces of a block with a configured tracer.
+// 3. Get the traces with invalid parameters.
+// 4. Get the traces with an unsupported tracer.
+func TestGetEVMBlockTraces(t *testing.T) {
+	backend := mock.NewAPI(t)
+	blockID := unittest.IdentifierFixture()
+	txHash := gethCommon.HexToHash("0x01")
+
+	traces := []debug.TransactionTrace{
+		{
+			TxHash: txHash,
+			Result: json.RawMessage(`{"type":"CALL"}`),
+		},
+	}
+
+	t.Run("get with default tracer", func(t *testing.T) {
+		backend.Mock.
+			On("GetEVMBlockTraces", mocktestify.Anything, blockID, debug.DefaultTracerConfig).
+			Return(traces, nil).
+			Once()
+
+		req := getEVMBlockTracesRequest(t, blockID.String(), "", "")
+
+		expected := fmt.Sprintf(`[
+			{
+				"tx_hash": "%s",
+				"result": {"type":"CALL"}
+			}
+		]`, txHash.Hex())
+
+		router.AssertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get with configured tracer", func(t *testing.T) {
+		config := debug.TracerConfig{
+			Tracer: debug.PrestateTracerName,
+			Config: json.RawMessage(`{"diffMode":true}`),
+		}
+
+		backend.Mock.
+			On("GetEVMBlockTraces", mocktestify.Anything, blockID, config).
+			Return([]debug.TransactionTrace{}, nil).
+			Once()
+
+		req := getEVMBlockTracesRequest(t, blockID.String(), config.Tracer, string(config.Config))
+		router.AssertOKResponse(t, req, `[]`, backend)
+	})
+
+	t.Run("get with invalid parameters", func(t *testing.T) {
+		tests := []struct {
+			id, tracer, config string
+			out                string
+		}{
+			{"invalid", "", "", `{"code":400,"message":"invalid ID format"}`},
+			{blockID.String(), "", "{", `{"code":400,"message":"invalid tracer config: must be valid JSON"}`},
+		}
+
+		for _, test := range tests {
+			req := getEVMBlockTracesRequest(t, test.id, test.tracer, test.config)
+			router.AssertResponse(t, req, http.StatusBadRequest, test.out, backend)
+		}
+	})
+
+	t.Run("get with unsupported tracer", func(t *testing.T) {
+		config := debug.TracerConfig{Tracer: "unknown"}
+
+		backend.Mock.
+			On("GetEVMBlockTraces", mocktestify.Anything, blockID, config).
+			Return(nil, status.Error(codes.InvalidArgument, "invalid tracer: unsupported tracer: unknown")).
+			Once()
+
+		req := getEVMBlockTracesRequest(t, blockID.String(), config.Tracer, "")
+
+		expected := `{"code":400, "message":"Invalid Flow argument: invalid tracer: unsupported tracer: unknown"}`
+		router.AssertResponse(t, req, http.StatusBadRequest, expected, backend)
+	})
+}
+
+// TestGetEVMTransactionTrace tests local getEVMTransactionTrace request.
+//
+// Runs the following tests:
+// 1. Get the trace of a transaction.
+// 2. Get the trace with an invalid transaction hash.
+// 3. Get the trace of a transaction not executed by the block.
+func TestGetEVMTransactionTrace(t *testing.T) {
+	backend := mock.NewAPI(t)
+	blockID := unittest.IdentifierFixture()
+	txHash := gethCommon.HexToHash("0x01")
+
+	t.Run("get trace", func(t *testing.T) {
+		config := debug.TracerConfig{Tracer: debug.StructLoggerName}
+
+		backend.Mock.
+			On("GetEVMTransactionTrace", mocktestify.Anything, blockID, txHash, config).
+			Return(json.RawMessage(`{"gas":21000}`), nil).
+			Once()
+
+		req := getEVMTransactionTraceRequest(t, blockID.String(), txHash.Hex(), config.Tracer)
+
+		expected := fmt.Sprintf(`{
+			"tx_hash": "%s",
+			"result": {"gas":21000}
+		}`, txHash.Hex())
+
+		router.AssertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("get with invalid hash", func(t *testing.T) {
+		req := getEVMTransactionTraceRequest(t, blockID.String(), "0x01", "")
+
+		expected := `{"code":400,"message":"invalid EVM transaction hash: must be a 0x prefixed 32 bytes hex string"}`
+		router.AssertResponse(t, req, http.StatusBadRequest, expected, backend)
+	})
+
+	t.Run("get transaction not in block", func(t *testing.T) {
+		backend.Mock.
+			On("GetEVMTransactionTrace", mocktestify.Anything, blockID, txHash, debug.DefaultTracerConfig).
+			Return(nil, status.Errorf(codes.NotFound, "EVM transaction %s was not executed by block %v", txHash, blockID)).
+			Once()
+
+		req := getEVMTransactionTraceRequest(t, blockID.String(), txHash.Hex(), "")
+
+		expected := fmt.Sprintf(
+			`{"code":404, "message":"Flow resource not found: EVM transaction %s was not executed by block %v"}`,
+			txHash,
+			blockID,
+		)
+		router.AssertResponse(t, req, http.StatusNotFound, expected, backend)
+	})
+}
+
+func getEVMBlockTracesRequest(t *testing.T, blockID string, tracer string, config string) *http.Request {
+	u, err := url.ParseRequestURI(fmt.Sprintf("/v1/blocks/%s/evm_traces", blockID))
+	require.NoError(t, err)
+	u.RawQuery = tracerQuery(tracer, config).Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	require.NoError(t, err)
+	return req
+}
+
+func getEVMTransactionTraceRequest(t *testing.T, blockID string, txHash string, tracer string) *http.Request {
+	u, err := url.ParseRequestURI(fmt.Sprintf("/v1/blocks/%s/evm_traces/%s", blockID, txHash))
+	require.NoError(t, err)
+	u.RawQuery = tracerQuery(tracer, "").Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	require.NoError(t, err)
+	return req
+}
+
+func tracerQuery(tracer string, config string) url.Values {
+	q := url.Values{}
+	if tracer != "" {
+		q.Add("tracer", tracer)
+	}
+	if config != "" {
+		q.Add("tracer_config", config)
+	}
+	return q
+}
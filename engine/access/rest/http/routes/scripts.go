@@ -34,3 +34,36 @@ func ExecuteScript(r *common.Request, backend access.API, _ models.LinkGenerator
 
 	return backend.ExecuteScriptAtBlockHeight(r.Context(), req.BlockHeight, req.Script.Source, req.Script.Args)
 }
+
+// ExecuteScripts handler executes a batch of scripts from the request against the same block.
+func ExecuteScripts(r *common.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := request.ExecuteScriptsRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	if req.BlockHeight == request.SealedHeight || req.BlockHeight == request.FinalHeight {
+		latest, _, err := backend.GetLatestBlockHeader(r.Context(), req.BlockHeight == request.SealedHeight)
+		if err != nil {
+			return nil, err
+		}
+		req.BlockHeight = latest.Height
+	}
+
+	scripts := make([]access.Script, len(req.Scripts))
+	for i, script := range req.Scripts {
+		scripts[i] = access.Script{
+			Script:    script.Source,
+			Arguments: script.Args,
+		}
+	}
+
+	results, err := backend.ExecuteScriptsAtBlockHeight(r.Context(), req.BlockHeight, scripts)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.ScriptsBatchResult
+	response.Build(req.BlockHeight, results)
+	return response, nil
+}
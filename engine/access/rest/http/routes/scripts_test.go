@@ -13,10 +13,12 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/access/mock"
 	"github.com/onflow/flow-go/engine/access/rest/router"
 	"github.com/onflow/flow-go/engine/access/rest/util"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
 )
 
 func scriptReq(id string, height string, body interface{}) *http.Request {
@@ -130,3 +132,110 @@ func TestScripts(t *testing.T) {
 		}
 	})
 }
+
+func scriptsBatchReq(height string, body interface{}) *http.Request {
+	u, _ := url.ParseRequestURI("/v1/scripts/batch")
+	q := u.Query()
+
+	if height != "" {
+		q.Add("block_height", height)
+	}
+
+	u.RawQuery = q.Encode()
+
+	jsonBody, _ := json.Marshal(body)
+	req, _ := http.NewRequest("POST", u.String(), bytes.NewBuffer(jsonBody))
+
+	return req
+}
+
+func TestExecuteScriptsBatch(t *testing.T) {
+	validCode := []byte(`access(all) fun main(foo: String): String { return foo }`)
+	validArgs := []byte(`{ "type": "String", "value": "hello world" }`)
+	validBody := map[string]interface{}{
+		"scripts": []map[string]interface{}{
+			{
+				"script":    util.ToBase64(validCode),
+				"arguments": []string{util.ToBase64(validArgs)},
+			},
+			{
+				"script": util.ToBase64(validCode),
+			},
+		},
+	}
+	scripts := []access.Script{
+		{Script: validCode, Arguments: [][]byte{validArgs}},
+		{Script: validCode, Arguments: [][]byte{}},
+	}
+	results := []access.ScriptResult{
+		{Value: []byte("hello world"), ComputationUsed: 10},
+		{ComputationUsed: 3, Err: status.Error(codes.InvalidArgument, "missing argument")},
+	}
+	expected := fmt.Sprintf(`{
+		"block_height": "1337",
+		"results": [
+			{"value": "%s", "computation_used": "10"},
+			{"computation_used": "3", "error_message": "missing argument"}
+		]
+	}`, base64.StdEncoding.EncodeToString([]byte("hello world")))
+
+	t.Run("execute by height", func(t *testing.T) {
+		backend := mock.NewAPI(t)
+		backend.Mock.
+			On("ExecuteScriptsAtBlockHeight", mocks.Anything, uint64(1337), scripts).
+			Return(results, nil)
+
+		req := scriptsBatchReq("1337", validBody)
+		router.AssertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("execute by latest sealed height", func(t *testing.T) {
+		backend := mock.NewAPI(t)
+		header := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(1337))
+		backend.Mock.
+			On("GetLatestBlockHeader", mocks.Anything, true).
+			Return(header, flow.BlockStatusSealed, nil)
+		backend.Mock.
+			On("ExecuteScriptsAtBlockHeight", mocks.Anything, uint64(1337), scripts).
+			Return(results, nil)
+
+		req := scriptsBatchReq("", validBody)
+		router.AssertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("execute error", func(t *testing.T) {
+		backend := mock.NewAPI(t)
+		backend.Mock.
+			On("ExecuteScriptsAtBlockHeight", mocks.Anything, uint64(1337), scripts).
+			Return(nil, status.Error(codes.NotFound, "block not found"))
+
+		req := scriptsBatchReq("1337", validBody)
+		router.AssertResponse(
+			t,
+			req,
+			http.StatusNotFound,
+			`{"code":404, "message":"Flow resource not found: block not found"}`,
+			backend,
+		)
+	})
+
+	t.Run("execute invalid", func(t *testing.T) {
+		backend := mock.NewAPI(t)
+
+		tests := []struct {
+			height string
+			body   interface{}
+			out    string
+		}{
+			{"invalid", validBody, `{"code":400,"message":"invalid height format"}`},
+			{"1337", nil, `{"code":400,"message":"request body must not be empty"}`},
+			{"1337", map[string]interface{}{"scripts": []string{}}, `{"code":400,"message":"at least one script must be provided"}`},
+			{"1337", map[string]interface{}{"scripts": []map[string]string{{"script": "!"}}}, `{"code":400,"message":"invalid script at index 0: invalid script source encoding"}`},
+		}
+
+		for _, test := range tests {
+			req := scriptsBatchReq(test.height, test.body)
+			router.AssertResponse(t, req, http.StatusBadRequest, test.out, backend)
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/access/rest/common"
 	"github.com/onflow/flow-go/engine/access/rest/http/request"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
 
 	"github.com/onflow/flow-go/engine/access/rest/http/models"
 )
@@ -81,3 +82,60 @@ func CreateTransaction(r *common.Request, backend access.API, link models.LinkGe
 	response.Build(&req.Transaction, nil, link)
 	return response, nil
 }
+
+// SimulateTransaction executes the transaction from the provided payload without submitting it.
+func SimulateTransaction(r *common.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := request.SimulateTransactionRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	if req.BlockHeight == request.SealedHeight || req.BlockHeight == request.FinalHeight {
+		latest, _, err := backend.GetLatestBlockHeader(r.Context(), req.BlockHeight == request.SealedHeight)
+		if err != nil {
+			return nil, err
+		}
+		req.BlockHeight = latest.Height
+	}
+
+	result, err := backend.SimulateTransaction(r.Context(), &req.Transaction, req.BlockHeight, req.SkipSignatureCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	// the simulation returns CCF encoded events, while the REST API uses JSON-CDC
+	result.Events, err = convert.CcfEventsToJsonEvents(result.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.TransactionSimulation
+	response.Build(result)
+	return response, nil
+}
+
+// EstimateTransactionFees executes the transaction from the provided payload without submitting it,
+// and returns the computation it used and the fees it would be charged.
+func EstimateTransactionFees(r *common.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := request.EstimateTransactionFeesRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	if req.BlockHeight == request.SealedHeight || req.BlockHeight == request.FinalHeight {
+		latest, _, err := backend.GetLatestBlockHeader(r.Context(), req.BlockHeight == request.SealedHeight)
+		if err != nil {
+			return nil, err
+		}
+		req.BlockHeight = latest.Height
+	}
+
+	estimate, err := backend.EstimateTransactionFees(r.Context(), &req.Transaction, req.BlockHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.TransactionFeeEstimate
+	response.Build(estimate)
+	return response, nil
+}
@@ -9,7 +9,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/onflow/cadence/common"
 	mocks "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	"google.golang.org/grpc/codes"
@@ -22,8 +24,10 @@ import (
 	"github.com/onflow/flow-go/engine/access/rest/http/models"
 	"github.com/onflow/flow-go/engine/access/rest/router"
 	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/utils/unittest"
+	"github.com/onflow/flow-go/utils/unittest/generator"
 )
 
 func getTransactionReq(id string, expandResult bool, blockIdQuery string, collectionIdQuery string) *http.Request {
@@ -72,6 +76,35 @@ func createTransactionReq(body interface{}) *http.Request {
 	return req
 }
 
+func simulateTransactionReq(body interface{}, height string, skipSignatureCheck string) *http.Request {
+	u, _ := url.Parse("/v1/transactions/simulate")
+	q := u.Query()
+	if height != "" {
+		q.Add("block_height", height)
+	}
+	if skipSignatureCheck != "" {
+		q.Add("skip_signature_check", skipSignatureCheck)
+	}
+	u.RawQuery = q.Encode()
+
+	jsonBody, _ := json.Marshal(body)
+	req, _ := http.NewRequest("POST", u.String(), bytes.NewBuffer(jsonBody))
+	return req
+}
+
+func estimateTransactionFeesReq(body interface{}, height string) *http.Request {
+	u, _ := url.Parse("/v1/transactions/estimate_fees")
+	q := u.Query()
+	if height != "" {
+		q.Add("block_height", height)
+	}
+	u.RawQuery = q.Encode()
+
+	jsonBody, _ := json.Marshal(body)
+	req, _ := http.NewRequest("POST", u.String(), bytes.NewBuffer(jsonBody))
+	return req
+}
+
 func TestGetTransactions(t *testing.T) {
 	t.Run("get by ID without results", func(t *testing.T) {
 		backend := &mock.API{}
@@ -438,3 +471,253 @@ func transactionResultFixture(tx flow.Transaction) *access.TransactionResult {
 		CollectionID: cid,
 	}
 }
+
+func TestSimulateTransaction(t *testing.T) {
+	tx := unittest.TransactionBodyFixture()
+	tx.PayloadSignatures = []flow.TransactionSignature{unittest.TransactionSignatureFixture()}
+	tx.Arguments = [][]uint8{}
+
+	header := unittest.BlockHeaderFixture()
+	event := generator.EventGenerator(generator.WithEncoding(entities.EventEncodingVersion_CCF_V0)).New()
+	jsonEvent, err := convert.CcfEventToJsonEvent(event)
+	require.NoError(t, err)
+
+	address := unittest.RandomAddressFixture()
+	result := func() *flow.TransactionSimulationResult {
+		return &flow.TransactionSimulationResult{
+			BlockID:         header.ID(),
+			BlockHeight:     header.Height,
+			Events:          flow.EventsList{event},
+			ComputationUsed: 42,
+			MemoryEstimate:  1000,
+			StorageDeltas: []flow.AccountStorageDelta{{
+				Address:           address,
+				StorageUsedBefore: 200,
+				StorageUsedAfter:  100,
+			}},
+		}
+	}
+
+	expected := fmt.Sprintf(`{
+		"block_id": "%s",
+		"block_height": "%d",
+		"status_code": 0,
+		"error_message": "",
+		"computation_used": "42",
+		"memory_estimate": "1000",
+		"events": [{
+			"type": "%s",
+			"transaction_id": "%s",
+			"transaction_index": "%d",
+			"event_index": "%d",
+			"payload": "%s"
+		}],
+		"storage_deltas": [{
+			"address": "%s",
+			"storage_used_before": "200",
+			"storage_used_after": "100",
+			"delta": "-100"
+		}]
+	}`,
+		header.ID(), header.Height,
+		jsonEvent.Type, jsonEvent.TransactionID, jsonEvent.TransactionIndex, jsonEvent.EventIndex, util.ToBase64(jsonEvent.Payload),
+		address.Hex(),
+	)
+
+	t.Run("at latest sealed block", func(t *testing.T) {
+		backend := mock.NewAPI(t)
+		backend.
+			On("GetLatestBlockHeader", mocks.Anything, true).
+			Return(header, flow.BlockStatusSealed, nil)
+		backend.
+			On("SimulateTransaction", mocks.Anything, &tx, header.Height, false).
+			Return(result(), nil)
+
+		req := simulateTransactionReq(unittest.CreateSendTxHttpPayload(tx), "", "")
+		router.AssertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("at height without signatures", func(t *testing.T) {
+		unsigned := tx
+		unsigned.PayloadSignatures = nil
+		unsigned.EnvelopeSignatures = nil
+
+		payload := unittest.CreateSendTxHttpPayload(tx)
+		delete(payload, "payload_signatures")
+		delete(payload, "envelope_signatures")
+
+		backend := mock.NewAPI(t)
+		backend.
+			On("SimulateTransaction", mocks.Anything, mocks.Anything, header.Height, true).
+			Run(func(args mocks.Arguments) {
+				body := args.Get(1).(*flow.TransactionBody)
+				require.Equal(t, unsigned.Script, body.Script)
+				require.Empty(t, body.EnvelopeSignatures)
+			}).
+			Return(result(), nil)
+
+		req := simulateTransactionReq(payload, fmt.Sprintf("%d", header.Height), "true")
+		router.AssertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("failed transaction", func(t *testing.T) {
+		failed := result()
+		failed.ErrorMessage = "execution failed"
+		failed.Events = nil
+		failed.StorageDeltas = nil
+
+		backend := mock.NewAPI(t)
+		backend.
+			On("SimulateTransaction", mocks.Anything, &tx, header.Height, false).
+			Return(failed, nil)
+
+		req := simulateTransactionReq(unittest.CreateSendTxHttpPayload(tx), fmt.Sprintf("%d", header.Height), "")
+		router.AssertOKResponse(t, req, fmt.Sprintf(`{
+			"block_id": "%s",
+			"block_height": "%d",
+			"status_code": 1,
+			"error_message": "execution failed",
+			"computation_used": "42",
+			"memory_estimate": "1000",
+			"events": [],
+			"storage_deltas": []
+		}`, header.ID(), header.Height), backend)
+	})
+
+	t.Run("backend error", func(t *testing.T) {
+		backend := mock.NewAPI(t)
+		backend.
+			On("SimulateTransaction", mocks.Anything, &tx, header.Height, false).
+			Return(nil, status.Errorf(codes.NotFound, "block %d not found", header.Height))
+
+		req := simulateTransactionReq(unittest.CreateSendTxHttpPayload(tx), fmt.Sprintf("%d", header.Height), "")
+		router.AssertResponse(t, req, http.StatusNotFound, fmt.Sprintf(`{"code":404, "message":"Flow resource not found: block %d not found"}`, header.Height), backend)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		unsigned := unittest.CreateSendTxHttpPayload(tx)
+		delete(unsigned, "envelope_signatures")
+
+		tests := []struct {
+			name   string
+			body   map[string]interface{}
+			height string
+			skip   string
+			output string
+		}{
+			{"invalid height", unittest.CreateSendTxHttpPayload(tx), "foo", "", `{"code":400, "message":"invalid height format"}`},
+			{"invalid skip signature check", unittest.CreateSendTxHttpPayload(tx), "", "foo", `{"code":400, "message":"invalid value for skip signature check: foo"}`},
+			{"missing signatures", unsigned, "", "false", `{"code":400, "message":"envelope signatures not provided"}`},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				req := simulateTransactionReq(test.body, test.height, test.skip)
+				router.AssertResponse(t, req, http.StatusBadRequest, test.output, mock.NewAPI(t))
+			})
+		}
+	})
+}
+
+func TestEstimateTransactionFees(t *testing.T) {
+	tx := unittest.TransactionBodyFixture()
+	tx.PayloadSignatures = nil
+	tx.EnvelopeSignatures = nil
+	tx.Arguments = [][]uint8{}
+
+	signed := tx
+	signed.PayloadSignatures = []flow.TransactionSignature{unittest.TransactionSignatureFixture()}
+	signed.EnvelopeSignatures = []flow.TransactionSignature{unittest.TransactionSignatureFixture()}
+
+	// signatures are not required to estimate fees
+	payload := unittest.CreateSendTxHttpPayload(signed)
+	delete(payload, "payload_signatures")
+	delete(payload, "envelope_signatures")
+
+	header := unittest.BlockHeaderFixture()
+	estimate := &flow.TransactionFeeEstimate{
+		BlockID:          header.ID(),
+		BlockHeight:      header.Height,
+		ComputationLimit: 9999,
+		ComputationUsed:  15,
+		ComputationIntensities: map[common.ComputationKind]uint64{
+			common.ComputationKindLoop: 100,
+		},
+		MemoryEstimate:  2000,
+		InclusionEffort: 100_000_000,
+		ExecutionEffort: 15,
+		FeeParameters: flow.TransactionFeeParameters{
+			SurgeFactor:         100_000_000,
+			InclusionEffortCost: 1_000,
+			ExecutionEffortCost: 400_000_000,
+		},
+		InclusionFee: 1_000,
+		ExecutionFee: 60,
+		TotalFee:     1_060,
+	}
+
+	expected := fmt.Sprintf(`{
+		"block_id": "%s",
+		"block_height": "%d",
+		"status_code": 0,
+		"error_message": "",
+		"computation_limit": "9999",
+		"computation_used": "15",
+		"computation_intensities": {"%s": "100"},
+		"memory_estimate": "2000",
+		"inclusion_effort": "1.00000000",
+		"execution_effort": "0.00000015",
+		"fee_parameters": {
+			"surge_factor": "1.00000000",
+			"inclusion_effort_cost": "0.00001000",
+			"execution_effort_cost": "4.00000000"
+		},
+		"inclusion_fee": "0.00001000",
+		"execution_fee": "0.00000060",
+		"total_fee": "0.00001060"
+	}`, header.ID(), header.Height, common.ComputationKindLoop.String())
+
+	t.Run("at latest sealed block", func(t *testing.T) {
+		backend := mock.NewAPI(t)
+		backend.
+			On("GetLatestBlockHeader", mocks.Anything, true).
+			Return(header, flow.BlockStatusSealed, nil)
+		backend.
+			On("EstimateTransactionFees", mocks.Anything, &tx, header.Height).
+			Return(estimate, nil)
+
+		req := estimateTransactionFeesReq(payload, "")
+		router.AssertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("at height", func(t *testing.T) {
+		backend := mock.NewAPI(t)
+		backend.
+			On("EstimateTransactionFees", mocks.Anything, &tx, header.Height).
+			Return(estimate, nil)
+
+		req := estimateTransactionFeesReq(payload, fmt.Sprintf("%d", header.Height))
+		router.AssertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("backend error", func(t *testing.T) {
+		backend := mock.NewAPI(t)
+		backend.
+			On("EstimateTransactionFees", mocks.Anything, &tx, header.Height).
+			Return(nil, status.Errorf(codes.NotFound, "block %d not found", header.Height))
+
+		req := estimateTransactionFeesReq(payload, fmt.Sprintf("%d", header.Height))
+		router.AssertResponse(t, req, http.StatusNotFound, fmt.Sprintf(`{"code":404, "message":"Flow resource not found: block %d not found"}`, header.Height), backend)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		invalid := unittest.CreateSendTxHttpPayload(signed)
+		invalid["payer"] = "yo"
+
+		req := estimateTransactionFeesReq(invalid, "")
+		router.AssertResponse(t, req, http.StatusBadRequest, `{"code":400, "message":"invalid payer: invalid address"}`, mock.NewAPI(t))
+
+		req = estimateTransactionFeesReq(payload, "foo")
+		router.AssertResponse(t, req, http.StatusBadRequest, `{"code":400, "message":"invalid height format"}`, mock.NewAPI(t))
+	})
+}
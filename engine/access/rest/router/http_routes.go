@@ -24,6 +24,16 @@ var Routes = []route{{
 	Pattern: "/transactions",
 	Name:    "createTransaction",
 	Handler: routes.CreateTransaction,
+}, {
+	Method:  http.MethodPost,
+	Pattern: "/transactions/simulate",
+	Name:    "simulateTransaction",
+	Handler: routes.SimulateTransaction,
+}, {
+	Method:  http.MethodPost,
+	Pattern: "/transactions/estimate_fees",
+	Name:    "estimateTransactionFees",
+	Handler: routes.EstimateTransactionFees,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/transaction_results/{id}",
@@ -44,6 +54,16 @@ var Routes = []route{{
 	Pattern: "/blocks/{id}/payload",
 	Name:    "getBlockPayloadByID",
 	Handler: routes.GetBlockPayloadByID,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/blocks/{id}/evm_traces",
+	Name:    "getEVMBlockTraces",
+	Handler: routes.GetEVMBlockTraces,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/blocks/{id}/evm_traces/{hash}",
+	Name:    "getEVMTransactionTrace",
+	Handler: routes.GetEVMTransactionTrace,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/execution_results/{id}",
@@ -64,6 +84,11 @@ var Routes = []route{{
 	Pattern: "/scripts",
 	Name:    "executeScript",
 	Handler: routes.ExecuteScript,
+}, {
+	Method:  http.MethodPost,
+	Pattern: "/scripts/batch",
+	Name:    "executeScriptsBatch",
+	Handler: routes.ExecuteScripts,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/accounts/{address}",
@@ -84,11 +109,41 @@ var Routes = []route{{
 	Pattern: "/accounts/{address}/keys",
 	Name:    "getAccountKeys",
 	Handler: routes.GetAccountKeys,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/accounts/{address}/transactions",
+	Name:    "getAccountTransactions",
+	Handler: routes.GetAccountTransactions,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/accounts/{address}/storage_diff",
+	Name:    "getAccountStorageDiff",
+	Handler: routes.GetAccountStorageDiff,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/accounts/{address}/contract_impact/{name}",
+	Name:    "getContractImpact",
+	Handler: routes.GetContractImpact,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/accounts/{address}/state_proof",
+	Name:    "getAccountStateProof",
+	Handler: routes.GetAccountStateProof,
+}, {
+	Method:  http.MethodPost,
+	Pattern: "/accounts/{address}/validate_contract_update",
+	Name:    "validateContractUpdate",
+	Handler: routes.ValidateContractUpdate,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/events",
 	Name:    "getEvents",
 	Handler: routes.GetEvents,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/evm/logs",
+	Name:    "getEVMLogs",
+	Handler: routes.GetEVMLogs,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/network/parameters",
@@ -12,6 +12,8 @@ import (
 	"github.com/onflow/flow-go/engine/access/rest/common/middleware"
 	"github.com/onflow/flow-go/engine/access/rest/http"
 	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/websockets"
+	dp "github.com/onflow/flow-go/engine/access/rest/websockets/data_providers"
 	legacyws "github.com/onflow/flow-go/engine/access/rest/websockets/legacy"
 	"github.com/onflow/flow-go/engine/access/state_stream"
 	"github.com/onflow/flow-go/engine/access/state_stream/backend"
@@ -84,6 +86,37 @@ func (b *RouterBuilder) AddWsLegacyRoutes(
 	return b
 }
 
+// AddWebsocketsRoute adds the multiplexed WebSocket stream API route to the router.
+// The events and account statuses topics are only available if stateStreamApi is not nil.
+func (b *RouterBuilder) AddWebsocketsRoute(
+	accessApi access.API,
+	stateStreamApi state_stream.API,
+	chain flow.Chain,
+	config websockets.Config,
+	stateStreamConfig backend.Config,
+	maxRequestSize int64,
+) *RouterBuilder {
+	linkGenerator := models.NewLinkGeneratorImpl(b.v1SubRouter)
+	dataProviderFactory := dp.NewDataProviderFactory(
+		b.logger,
+		accessApi,
+		stateStreamApi,
+		linkGenerator,
+		chain,
+		stateStreamConfig.EventFilterConfig,
+		stateStreamConfig.HeartbeatInterval,
+	)
+
+	h := websockets.NewWebSocketHandler(b.logger, config, chain, dataProviderFactory, maxRequestSize)
+	b.v1SubRouter.
+		Methods(WSRoute.Method).
+		Path(WSRoute.Pattern).
+		Name(WSRoute.Name).
+		Handler(h)
+
+	return b
+}
+
 func (b *RouterBuilder) Build() *mux.Router {
 	return b.router
 }
@@ -98,6 +131,7 @@ func init() {
 	for _, r := range WSLegacyRoutes {
 		routeUrlMap[r.Pattern] = r.Name
 	}
+	routeUrlMap[WSRoute.Pattern] = WSRoute.Name
 }
 
 func URLToRoute(url string) (string, error) {
@@ -132,7 +166,9 @@ func normalizeURL(url string) (string, error) {
 	case 64:
 		// id based resource. e.g. /v1/blocks/1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef
 		parts = append(parts, "{id}")
-		if matches[0][5] != "" {
+		if matches[0][5] == "evm_traces" && matches[0][7] != "" {
+			parts = append(parts, "evm_traces", "{hash}")
+		} else if matches[0][5] != "" {
 			parts = append(parts, matches[0][5])
 		}
 	case 16:
@@ -140,6 +176,8 @@ func normalizeURL(url string) (string, error) {
 		parts = append(parts, "{address}")
 		if matches[0][5] == "keys" && matches[0][7] != "" {
 			parts = append(parts, "keys", "{index}")
+		} else if matches[0][5] == "contract_impact" && matches[0][7] != "" {
+			parts = append(parts, "contract_impact", "{name}")
 		} else if matches[0][5] != "" {
 			parts = append(parts, matches[0][5])
 		}
@@ -24,6 +24,16 @@ func TestParseURL(t *testing.T) {
 			url:      "/v1/transactions/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76",
 			expected: "getTransactionByID",
 		},
+		{
+			name:     "/v1/transactions/simulate",
+			url:      "/v1/transactions/simulate",
+			expected: "simulateTransaction",
+		},
+		{
+			name:     "/v1/transactions/estimate_fees",
+			url:      "/v1/transactions/estimate_fees",
+			expected: "estimateTransactionFees",
+		},
 		{
 			name:     "/v1/transaction_results/{id}",
 			url:      "/v1/transaction_results/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76",
@@ -44,6 +54,16 @@ func TestParseURL(t *testing.T) {
 			url:      "/v1/blocks/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76/payload",
 			expected: "getBlockPayloadByID",
 		},
+		{
+			name:     "/v1/blocks/{id}/evm_traces",
+			url:      "/v1/blocks/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76/evm_traces",
+			expected: "getEVMBlockTraces",
+		},
+		{
+			name:     "/v1/blocks/{id}/evm_traces/{hash}",
+			url:      "/v1/blocks/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76/evm_traces/0x3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a7653730d3f",
+			expected: "getEVMTransactionTrace",
+		},
 		{
 			name:     "/v1/execution_results/{id}",
 			url:      "/v1/execution_results/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76",
@@ -64,6 +84,11 @@ func TestParseURL(t *testing.T) {
 			url:      "/v1/scripts",
 			expected: "executeScript",
 		},
+		{
+			name:     "/v1/scripts/batch",
+			url:      "/v1/scripts/batch",
+			expected: "executeScriptsBatch",
+		},
 		{
 			name:     "/v1/accounts/{address}",
 			url:      "/v1/accounts/6a587be304c1224c",
@@ -84,6 +109,36 @@ func TestParseURL(t *testing.T) {
 			url:      "/v1/accounts/6a587be304c1224c/keys",
 			expected: "getAccountKeys",
 		},
+		{
+			name:     "/v1/accounts/{address}/transactions",
+			url:      "/v1/accounts/6a587be304c1224c/transactions",
+			expected: "getAccountTransactions",
+		},
+		{
+			name:     "/v1/evm/logs",
+			url:      "/v1/evm/logs",
+			expected: "getEVMLogs",
+		},
+		{
+			name:     "/v1/accounts/{address}/storage_diff",
+			url:      "/v1/accounts/6a587be304c1224c/storage_diff",
+			expected: "getAccountStorageDiff",
+		},
+		{
+			name:     "/v1/accounts/{address}/contract_impact/{name}",
+			url:      "/v1/accounts/6a587be304c1224c/contract_impact/FlowToken",
+			expected: "getContractImpact",
+		},
+		{
+			name:     "/v1/accounts/{address}/state_proof",
+			url:      "/v1/accounts/6a587be304c1224c/state_proof",
+			expected: "getAccountStateProof",
+		},
+		{
+			name:     "/v1/accounts/{address}/validate_contract_update",
+			url:      "/v1/accounts/6a587be304c1224c/validate_contract_update",
+			expected: "validateContractUpdate",
+		},
 		{
 			name:     "/v1/events",
 			url:      "/v1/events",
@@ -131,6 +186,16 @@ func TestBenchmarkParseURL(t *testing.T) {
 			url:      "/v1/transactions/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76",
 			expected: "getTransactionByID",
 		},
+		{
+			name:     "/v1/transactions/simulate",
+			url:      "/v1/transactions/simulate",
+			expected: "simulateTransaction",
+		},
+		{
+			name:     "/v1/transactions/estimate_fees",
+			url:      "/v1/transactions/estimate_fees",
+			expected: "estimateTransactionFees",
+		},
 		{
 			name:     "/v1/transaction_results/{id}",
 			url:      "/v1/transaction_results/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76",
@@ -151,6 +216,16 @@ func TestBenchmarkParseURL(t *testing.T) {
 			url:      "/v1/blocks/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76/payload",
 			expected: "getBlockPayloadByID",
 		},
+		{
+			name:     "/v1/blocks/{id}/evm_traces",
+			url:      "/v1/blocks/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76/evm_traces",
+			expected: "getEVMBlockTraces",
+		},
+		{
+			name:     "/v1/blocks/{id}/evm_traces/{hash}",
+			url:      "/v1/blocks/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76/evm_traces/0x3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a7653730d3f",
+			expected: "getEVMTransactionTrace",
+		},
 		{
 			name:     "/v1/execution_results/{id}",
 			url:      "/v1/execution_results/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76",
@@ -171,6 +246,11 @@ func TestBenchmarkParseURL(t *testing.T) {
 			url:      "/v1/scripts",
 			expected: "executeScript",
 		},
+		{
+			name:     "/v1/scripts/batch",
+			url:      "/v1/scripts/batch",
+			expected: "executeScriptsBatch",
+		},
 		{
 			name:     "/v1/accounts/{address}",
 			url:      "/v1/accounts/6a587be304c1224c",
@@ -191,6 +271,36 @@ func TestBenchmarkParseURL(t *testing.T) {
 			url:      "/v1/accounts/6a587be304c1224c/keys",
 			expected: "getAccountKeys",
 		},
+		{
+			name:     "/v1/accounts/{address}/transactions",
+			url:      "/v1/accounts/6a587be304c1224c/transactions",
+			expected: "getAccountTransactions",
+		},
+		{
+			name:     "/v1/evm/logs",
+			url:      "/v1/evm/logs",
+			expected: "getEVMLogs",
+		},
+		{
+			name:     "/v1/accounts/{address}/storage_diff",
+			url:      "/v1/accounts/6a587be304c1224c/storage_diff",
+			expected: "getAccountStorageDiff",
+		},
+		{
+			name:     "/v1/accounts/{address}/contract_impact/{name}",
+			url:      "/v1/accounts/6a587be304c1224c/contract_impact/FlowToken",
+			expected: "getContractImpact",
+		},
+		{
+			name:     "/v1/accounts/{address}/state_proof",
+			url:      "/v1/accounts/6a587be304c1224c/state_proof",
+			expected: "getAccountStateProof",
+		},
+		{
+			name:     "/v1/accounts/{address}/validate_contract_update",
+			url:      "/v1/accounts/6a587be304c1224c/validate_contract_update",
+			expected: "validateContractUpdate",
+		},
 		{
 			name:     "/v1/events",
 			url:      "/v1/events",
@@ -20,3 +20,16 @@ var WSLegacyRoutes = []wsLegacyRoute{{
 	Name:    "subscribeEvents",
 	Handler: routes.SubscribeEvents,
 }}
+
+type wsRoute struct {
+	Name    string
+	Method  string
+	Pattern string
+}
+
+// WSRoute is the route of the multiplexed WebSocket stream API.
+var WSRoute = wsRoute{
+	Method:  http.MethodGet,
+	Pattern: "/ws",
+	Name:    "ws",
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/access/rest/router"
+	"github.com/onflow/flow-go/engine/access/rest/websockets"
 	"github.com/onflow/flow-go/engine/access/state_stream"
 	"github.com/onflow/flow-go/engine/access/state_stream/backend"
 	"github.com/onflow/flow-go/model/flow"
@@ -32,6 +33,10 @@ type Config struct {
 	ReadTimeout    time.Duration
 	IdleTimeout    time.Duration
 	MaxRequestSize int64
+
+	// EnableWebSocketsStreamAPI enables the multiplexed WebSocket stream API served at /v1/ws.
+	EnableWebSocketsStreamAPI bool
+	WebSocketConfig           websockets.Config
 }
 
 // NewServer returns an HTTP server initialized with the REST API handler
@@ -48,6 +53,10 @@ func NewServer(serverAPI access.API,
 		builder.AddWsLegacyRoutes(stateStreamApi, chain, stateStreamConfig, config.MaxRequestSize)
 	}
 
+	if config.EnableWebSocketsStreamAPI {
+		builder.AddWebsocketsRoute(serverAPI, stateStreamApi, chain, config.WebSocketConfig, stateStreamConfig, config.MaxRequestSize)
+	}
+
 	c := cors.New(cors.Options{
 		AllowedOrigins: []string{"*"},
 		AllowedHeaders: []string{"*"},
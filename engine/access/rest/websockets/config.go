@@ -0,0 +1,51 @@
+package websockets
+
+import (
+	"time"
+)
+
+const (
+	// PingPeriod defines the interval at which ping messages are sent to the client.
+	// This value must be less than PongWait, to ensure the client receives a ping
+	// and has time to respond before the read deadline expires.
+	PingPeriod = (PongWait * 9) / 10
+
+	// PongWait specifies the maximum time to wait for a pong response message from the peer
+	// after sending a ping.
+	PongWait = 10 * time.Second
+
+	// WriteWait specifies a timeout for the write operation. If the write
+	// isn't completed within this duration, it fails with a timeout error.
+	WriteWait = 10 * time.Second
+
+	// DefaultMaxSubscriptionsPerConnection defines the default maximum number of active
+	// subscriptions a single websocket connection may hold.
+	DefaultMaxSubscriptionsPerConnection = 20
+
+	// DefaultMaxConnections defines the default maximum number of websocket connections
+	// served concurrently.
+	DefaultMaxConnections = 1000
+
+	// DefaultSendBufferSize defines the default number of responses buffered per
+	// connection before data providers are blocked.
+	DefaultSendBufferSize = 100
+)
+
+// Config holds the configuration of the websocket stream API.
+type Config struct {
+	// MaxSubscriptionsPerConnection is the maximum number of active subscriptions on one connection.
+	MaxSubscriptionsPerConnection uint64
+	// MaxConnections is the maximum number of websocket connections served concurrently.
+	MaxConnections uint64
+	// SendBufferSize is the number of responses buffered per connection.
+	SendBufferSize uint
+}
+
+// NewDefaultWebsocketConfig returns the default websocket stream API configuration.
+func NewDefaultWebsocketConfig() Config {
+	return Config{
+		MaxSubscriptionsPerConnection: DefaultMaxSubscriptionsPerConnection,
+		MaxConnections:                DefaultMaxConnections,
+		SendBufferSize:                DefaultSendBufferSize,
+	}
+}
@@ -0,0 +1,496 @@
+package websockets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	dp "github.com/onflow/flow-go/engine/access/rest/websockets/data_providers"
+	"github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// maxSubscriptionIDLength is the maximum length of a client chosen subscription ID.
+const maxSubscriptionIDLength = 64
+
+// Controller manages a single websocket connection. It multiplexes any number of subscriptions,
+// each served by its own data provider, over the connection.
+//
+// The controller runs the following routines for the lifetime of the connection:
+//   - readMessages handles subscribe, unsubscribe, list_subscriptions and ack requests from the client.
+//   - writeMessages is the only routine writing data messages to the connection. Data providers and
+//     request handlers hand their messages over through the multiplexed stream.
+//   - keepalive periodically pings the client, and the connection is closed if no pong is received
+//     within PongWait.
+//
+// Once any of the routines stops, the connection is closed and all data providers are shut down.
+//
+// Errors caused by a single request or subscription are reported to the client as error messages,
+// and the connection stays open. The connection is only closed if it fails, or the client closes it.
+type Controller struct {
+	logger zerolog.Logger
+	config Config
+	conn   *websocket.Conn
+
+	// multiplexedStream is the channel through which all messages are sent to the client.
+	multiplexedStream chan interface{}
+
+	dataProvidersLock   sync.Mutex
+	dataProviders       map[string]dp.DataProvider
+	dataProviderFactory dp.DataProviderFactory
+	dataProvidersGroup  sync.WaitGroup
+}
+
+// NewWebSocketController creates a new controller for the given connection.
+func NewWebSocketController(
+	logger zerolog.Logger,
+	config Config,
+	conn *websocket.Conn,
+	dataProviderFactory dp.DataProviderFactory,
+) *Controller {
+	return &Controller{
+		logger:              logger.With().Str("component", "websocket-controller").Logger(),
+		config:              config,
+		conn:                conn,
+		multiplexedStream:   make(chan interface{}, config.SendBufferSize),
+		dataProviders:       make(map[string]dp.DataProvider),
+		dataProviderFactory: dataProviderFactory,
+	}
+}
+
+// HandleConnection serves the connection until either the client disconnects, the connection fails,
+// or the context is canceled. All data providers are closed before it returns.
+func (c *Controller) HandleConnection(ctx context.Context) {
+	defer c.shutdownConnection()
+
+	err := c.configureKeepalive()
+	if err != nil {
+		c.logger.Error().Err(err).Msg("error configuring keepalive connection")
+		return
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		return c.readMessages(gCtx)
+	})
+	g.Go(func() error {
+		return c.keepalive(gCtx)
+	})
+	g.Go(func() error {
+		return c.writeMessages(gCtx)
+	})
+	g.Go(func() error {
+		// unblock the reader once any of the routines stopped
+		<-gCtx.Done()
+		_ = c.conn.Close()
+		return nil
+	})
+
+	err = g.Wait()
+	if err != nil && !errors.Is(err, context.Canceled) {
+		c.logger.Debug().Err(err).Msg("websocket connection closed")
+	}
+}
+
+// configureKeepalive sets the initial read deadline and extends it every time a pong is received.
+//
+// No errors are expected during normal operations.
+func (c *Controller) configureKeepalive() error {
+	err := c.conn.SetReadDeadline(time.Now().Add(PongWait))
+	if err != nil {
+		return fmt.Errorf("failed to set the initial read deadline: %w", err)
+	}
+
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(PongWait))
+	})
+
+	return nil
+}
+
+// keepalive sends a ping to the client every PingPeriod.
+//
+// Expected errors during normal operations:
+//   - context.Canceled: if the connection is being shut down.
+//   - if the ping cannot be written to the connection.
+func (c *Controller) keepalive(ctx context.Context) error {
+	ticker := time.NewTicker(PingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(WriteWait))
+			if err != nil {
+				return fmt.Errorf("failed to write ping message: %w", err)
+			}
+		}
+	}
+}
+
+// writeMessages writes all messages from the multiplexed stream to the connection.
+//
+// Expected errors during normal operations:
+//   - context.Canceled: if the connection is being shut down.
+//   - if a message cannot be written to the connection.
+func (c *Controller) writeMessages(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case message := <-c.multiplexedStream:
+			err := c.conn.SetWriteDeadline(time.Now().Add(WriteWait))
+			if err != nil {
+				return fmt.Errorf("failed to set the write deadline: %w", err)
+			}
+
+			err = c.conn.WriteJSON(message)
+			if err != nil {
+				return fmt.Errorf("failed to write message to connection: %w", err)
+			}
+		}
+	}
+}
+
+// readMessages reads and handles messages from the client until the connection is closed.
+// Malformed or invalid messages are answered with an error message.
+//
+// Expected errors during normal operations:
+//   - context.Canceled: if the connection is being shut down.
+//   - if the connection fails or is closed by the client.
+func (c *Controller) readMessages(ctx context.Context) error {
+	for {
+		_, message, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				return context.Canceled
+			}
+			return fmt.Errorf("failed to read message from connection: %w", err)
+		}
+
+		err = c.handleMessage(ctx, message)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// handleMessage parses a single client message and dispatches it to the handler of its action.
+//
+// Expected errors during normal operations:
+//   - context.Canceled: if the connection is being shut down.
+func (c *Controller) handleMessage(ctx context.Context, message json.RawMessage) error {
+	var baseMsg models.BaseMessageRequest
+	err := json.Unmarshal(message, &baseMsg)
+	if err != nil {
+		return c.sendError(ctx, "", "", http.StatusBadRequest, fmt.Errorf("invalid message: %w", err))
+	}
+
+	switch baseMsg.Action {
+	case models.SubscribeAction:
+		var subscribeMsg models.SubscribeMessageRequest
+		err = json.Unmarshal(message, &subscribeMsg)
+		if err != nil {
+			return c.sendError(ctx, baseMsg.SubscriptionID, baseMsg.Action, http.StatusBadRequest, fmt.Errorf("invalid subscribe message: %w", err))
+		}
+		return c.handleSubscribe(ctx, subscribeMsg)
+
+	case models.UnsubscribeAction:
+		var unsubscribeMsg models.UnsubscribeMessageRequest
+		err = json.Unmarshal(message, &unsubscribeMsg)
+		if err != nil {
+			return c.sendError(ctx, baseMsg.SubscriptionID, baseMsg.Action, http.StatusBadRequest, fmt.Errorf("invalid unsubscribe message: %w", err))
+		}
+		return c.handleUnsubscribe(ctx, unsubscribeMsg)
+
+	case models.ListSubscriptionsAction:
+		return c.handleListSubscriptions(ctx, baseMsg)
+
+	case models.AckAction:
+		var ackMsg models.AckMessageRequest
+		err = json.Unmarshal(message, &ackMsg)
+		if err != nil {
+			return c.sendError(ctx, baseMsg.SubscriptionID, baseMsg.Action, http.StatusBadRequest, fmt.Errorf("invalid ack message: %w", err))
+		}
+		return c.handleAck(ctx, ackMsg)
+
+	default:
+		return c.sendError(ctx, baseMsg.SubscriptionID, baseMsg.Action, http.StatusBadRequest, fmt.Errorf("unknown action: %q", baseMsg.Action))
+	}
+}
+
+// handleSubscribe creates a data provider for the requested topic and starts streaming its data.
+//
+// Expected errors during normal operations:
+//   - context.Canceled: if the connection is being shut down.
+func (c *Controller) handleSubscribe(ctx context.Context, msg models.SubscribeMessageRequest) error {
+	subscriptionID := msg.SubscriptionID
+	if subscriptionID == "" {
+		subscriptionID = uuid.New().String()
+	}
+	if len(subscriptionID) > maxSubscriptionIDLength {
+		return c.sendError(ctx, "", msg.Action, http.StatusBadRequest,
+			fmt.Errorf("subscription ID must be at most %d characters long", maxSubscriptionIDLength))
+	}
+
+	// only the reader routine adds data providers, so the checks remain valid until the provider is added
+	c.dataProvidersLock.Lock()
+	_, exists := c.dataProviders[subscriptionID]
+	activeSubscriptions := uint64(len(c.dataProviders))
+	c.dataProvidersLock.Unlock()
+
+	if exists {
+		return c.sendError(ctx, subscriptionID, msg.Action, http.StatusBadRequest,
+			fmt.Errorf("subscription ID %s is already in use", subscriptionID))
+	}
+	if activeSubscriptions >= c.config.MaxSubscriptionsPerConnection {
+		return c.sendError(ctx, subscriptionID, msg.Action, http.StatusTooManyRequests,
+			fmt.Errorf("maximum number of subscriptions per connection reached: %d", c.config.MaxSubscriptionsPerConnection))
+	}
+
+	provider, err := c.dataProviderFactory.NewDataProvider(ctx, subscriptionID, msg.Topic, msg.Arguments, c.multiplexedStream)
+	if err != nil {
+		return c.sendError(ctx, subscriptionID, msg.Action, http.StatusBadRequest,
+			fmt.Errorf("error creating data provider: %w", err))
+	}
+
+	c.dataProvidersLock.Lock()
+	c.dataProviders[subscriptionID] = provider
+	c.dataProvidersLock.Unlock()
+
+	response := &models.BaseMessageResponse{
+		SubscriptionID: subscriptionID,
+		Action:         models.SubscribeAction,
+	}
+	if acknowledger, ok := provider.(dp.Acknowledger); ok {
+		// clients learn the name of a created cursor from the confirmation
+		response.Cursor = acknowledger.Cursor()
+	}
+
+	// the response is enqueued before the data provider is started, so the client always
+	// receives the confirmation before the first data message of the subscription.
+	err = c.sendResponse(ctx, response)
+	if err != nil {
+		provider.Close()
+		return err
+	}
+
+	c.dataProvidersGroup.Add(1)
+	go func() {
+		defer c.dataProvidersGroup.Done()
+
+		err := provider.Run()
+		if err != nil {
+			c.logger.Debug().Err(err).Str("subscription_id", subscriptionID).Msg("data provider failed")
+			_ = c.sendError(ctx, subscriptionID, models.SubscribeAction, http.StatusInternalServerError,
+				fmt.Errorf("subscription failed: %w", err))
+		}
+
+		// release the resources of the subscription, e.g. the event cursor it uses
+		provider.Close()
+		c.removeDataProvider(provider)
+	}()
+
+	return nil
+}
+
+// handleUnsubscribe closes the data provider of the given subscription.
+//
+// Expected errors during normal operations:
+//   - context.Canceled: if the connection is being shut down.
+func (c *Controller) handleUnsubscribe(ctx context.Context, msg models.UnsubscribeMessageRequest) error {
+	c.dataProvidersLock.Lock()
+	provider, ok := c.dataProviders[msg.SubscriptionID]
+	if ok {
+		delete(c.dataProviders, msg.SubscriptionID)
+	}
+	c.dataProvidersLock.Unlock()
+
+	if !ok {
+		return c.sendError(ctx, msg.SubscriptionID, msg.Action, http.StatusNotFound,
+			fmt.Errorf("subscription %q not found", msg.SubscriptionID))
+	}
+
+	provider.Close()
+
+	return c.sendResponse(ctx, &models.BaseMessageResponse{
+		SubscriptionID: msg.SubscriptionID,
+		Action:         models.UnsubscribeAction,
+	})
+}
+
+// handleListSubscriptions sends the list of all active subscriptions to the client.
+//
+// Expected errors during normal operations:
+//   - context.Canceled: if the connection is being shut down.
+func (c *Controller) handleListSubscriptions(ctx context.Context, msg models.BaseMessageRequest) error {
+	c.dataProvidersLock.Lock()
+	subscriptions := make([]*models.SubscriptionEntry, 0, len(c.dataProviders))
+	for id, provider := range c.dataProviders {
+		subscriptions = append(subscriptions, &models.SubscriptionEntry{
+			SubscriptionID: id,
+			Topic:          provider.Topic(),
+			Arguments:      provider.Arguments(),
+		})
+	}
+	c.dataProvidersLock.Unlock()
+
+	return c.sendResponse(ctx, &models.ListSubscriptionsMessageResponse{
+		BaseMessageResponse: models.BaseMessageResponse{
+			SubscriptionID: msg.SubscriptionID,
+			Action:         models.ListSubscriptionsAction,
+		},
+		Subscriptions: subscriptions,
+	})
+}
+
+// handleAck acknowledges the events received by the given subscription up to the requested position.
+// Only subscriptions using a durable event cursor support acknowledgements.
+//
+// Expected errors during normal operations:
+//   - context.Canceled: if the connection is being shut down.
+func (c *Controller) handleAck(ctx context.Context, msg models.AckMessageRequest) error {
+	position, err := parseEventPosition(msg)
+	if err != nil {
+		return c.sendError(ctx, msg.SubscriptionID, msg.Action, http.StatusBadRequest,
+			fmt.Errorf("invalid ack message: %w", err))
+	}
+
+	c.dataProvidersLock.Lock()
+	provider, ok := c.dataProviders[msg.SubscriptionID]
+	c.dataProvidersLock.Unlock()
+
+	if !ok {
+		return c.sendError(ctx, msg.SubscriptionID, msg.Action, http.StatusNotFound,
+			fmt.Errorf("subscription %q not found", msg.SubscriptionID))
+	}
+
+	acknowledger, ok := provider.(dp.Acknowledger)
+	if !ok {
+		return c.sendError(ctx, msg.SubscriptionID, msg.Action, http.StatusBadRequest,
+			fmt.Errorf("subscription %q does not support acknowledgements", msg.SubscriptionID))
+	}
+
+	err = acknowledger.Acknowledge(ctx, position)
+	if err != nil {
+		code := http.StatusInternalServerError
+		switch status.Code(err) {
+		case codes.InvalidArgument, codes.FailedPrecondition:
+			code = http.StatusBadRequest
+		case codes.NotFound:
+			code = http.StatusNotFound
+		}
+		return c.sendError(ctx, msg.SubscriptionID, msg.Action, code, fmt.Errorf("could not acknowledge events: %w", err))
+	}
+
+	return c.sendResponse(ctx, &models.BaseMessageResponse{
+		SubscriptionID: msg.SubscriptionID,
+		Action:         models.AckAction,
+	})
+}
+
+// parseEventPosition parses the position acknowledged by the ack message. If the transaction and event
+// indexes are omitted, the position following all events of the block is returned.
+//
+// Expected errors during normal operations:
+//   - if a field has an invalid format, or only one of the indexes is provided.
+func parseEventPosition(msg models.AckMessageRequest) (flow.EventPosition, error) {
+	height, err := strconv.ParseUint(msg.BlockHeight, 10, 64)
+	if err != nil {
+		return flow.EventPosition{}, fmt.Errorf("invalid block height: %q", msg.BlockHeight)
+	}
+
+	if msg.TransactionIndex == "" && msg.EventIndex == "" {
+		return flow.EndOfBlockPosition(height), nil
+	}
+	if msg.TransactionIndex == "" || msg.EventIndex == "" {
+		return flow.EventPosition{}, fmt.Errorf("transaction index and event index must be provided together")
+	}
+
+	txIndex, err := strconv.ParseUint(msg.TransactionIndex, 10, 32)
+	if err != nil {
+		return flow.EventPosition{}, fmt.Errorf("invalid transaction index: %q", msg.TransactionIndex)
+	}
+	eventIndex, err := strconv.ParseUint(msg.EventIndex, 10, 32)
+	if err != nil {
+		return flow.EventPosition{}, fmt.Errorf("invalid event index: %q", msg.EventIndex)
+	}
+
+	return flow.EventPosition{
+		BlockHeight:      height,
+		TransactionIndex: uint32(txIndex),
+		EventIndex:       uint32(eventIndex),
+	}, nil
+}
+
+// removeDataProvider removes the data provider from the active subscriptions, unless it was
+// already replaced or removed.
+func (c *Controller) removeDataProvider(provider dp.DataProvider) {
+	c.dataProvidersLock.Lock()
+	defer c.dataProvidersLock.Unlock()
+
+	if current, ok := c.dataProviders[provider.ID()]; ok && current == provider {
+		delete(c.dataProviders, provider.ID())
+	}
+}
+
+// sendError sends an error message to the client.
+//
+// Expected errors during normal operations:
+//   - context.Canceled: if the connection is being shut down.
+func (c *Controller) sendError(ctx context.Context, subscriptionID string, action string, code int, err error) error {
+	return c.sendResponse(ctx, &models.BaseMessageResponse{
+		SubscriptionID: subscriptionID,
+		Action:         action,
+		Error: &models.ErrorMessage{
+			Code:    code,
+			Message: err.Error(),
+		},
+	})
+}
+
+// sendResponse hands the message over to the writer routine.
+//
+// Expected errors during normal operations:
+//   - context.Canceled: if the connection is being shut down.
+func (c *Controller) sendResponse(ctx context.Context, response interface{}) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case c.multiplexedStream <- response:
+		return nil
+	}
+}
+
+// shutdownConnection closes all data providers, waits for them to finish and closes the connection.
+func (c *Controller) shutdownConnection() {
+	c.dataProvidersLock.Lock()
+	for id, provider := range c.dataProviders {
+		provider.Close()
+		delete(c.dataProviders, id)
+	}
+	c.dataProvidersLock.Unlock()
+
+	c.dataProvidersGroup.Wait()
+
+	err := c.conn.Close()
+	if err != nil && !errors.Is(err, net.ErrClosed) {
+		c.logger.Debug().Err(err).Msg("error closing websocket connection")
+	}
+}
@@ -0,0 +1,456 @@
+package websockets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	dp "github.com/onflow/flow-go/engine/access/rest/websockets/data_providers"
+	"github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const (
+	testTopic    = "test_topic"
+	testAckTopic = "test_ack_topic"
+
+	// testMaxAckHeight is the highest height the acknowledging test data provider accepts.
+	testMaxAckHeight = 100
+
+	// testCursor is the name of the event cursor of the acknowledging test data provider.
+	testCursor = "0123456789abcdef"
+)
+
+// testDataProvider is a data provider which sends every value pushed to its data channel.
+type testDataProvider struct {
+	id        string
+	arguments models.Arguments
+	send      chan<- interface{}
+	data      chan interface{}
+	ctx       context.Context
+	cancel    context.CancelFunc
+	closed    chan struct{}
+	acks      chan flow.EventPosition
+}
+
+var _ dp.DataProvider = (*testDataProvider)(nil)
+
+func (p *testDataProvider) ID() string                  { return p.id }
+func (p *testDataProvider) Topic() string               { return testTopic }
+func (p *testDataProvider) Arguments() models.Arguments { return p.arguments }
+func (p *testDataProvider) Close()                      { p.cancel() }
+
+func (p *testDataProvider) Run() error {
+	defer close(p.closed)
+	for {
+		select {
+		case <-p.ctx.Done():
+			return nil
+		case v, ok := <-p.data:
+			if !ok {
+				return fmt.Errorf("data stream failed")
+			}
+			select {
+			case <-p.ctx.Done():
+				return nil
+			case p.send <- &models.BaseDataProvidersResponse{SubscriptionID: p.id, Topic: testTopic, Payload: v}:
+			}
+		}
+	}
+}
+
+// ackingTestDataProvider is a test data provider which supports acknowledgements.
+type ackingTestDataProvider struct {
+	*testDataProvider
+}
+
+var _ dp.Acknowledger = (*ackingTestDataProvider)(nil)
+
+func (p *ackingTestDataProvider) Cursor() string {
+	return testCursor
+}
+
+func (p *ackingTestDataProvider) Acknowledge(_ context.Context, position flow.EventPosition) error {
+	if position.BlockHeight > testMaxAckHeight {
+		return status.Errorf(codes.InvalidArgument, "position was not delivered yet")
+	}
+	p.acks <- position
+	return nil
+}
+
+// testDataProviderFactory creates test data providers for the test topic and records them.
+type testDataProviderFactory struct {
+	mu        sync.Mutex
+	providers map[string]*testDataProvider
+	created   chan *testDataProvider
+}
+
+func newTestDataProviderFactory() *testDataProviderFactory {
+	return &testDataProviderFactory{
+		providers: make(map[string]*testDataProvider),
+		created:   make(chan *testDataProvider, 10),
+	}
+}
+
+func (f *testDataProviderFactory) NewDataProvider(
+	ctx context.Context,
+	subscriptionID string,
+	topic string,
+	arguments models.Arguments,
+	ch chan<- interface{},
+) (dp.DataProvider, error) {
+	if topic != testTopic && topic != testAckTopic {
+		return nil, fmt.Errorf("unsupported topic \"%s\"", topic)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	provider := &testDataProvider{
+		id:        subscriptionID,
+		arguments: arguments,
+		send:      ch,
+		data:      make(chan interface{}),
+		ctx:       ctx,
+		cancel:    cancel,
+		closed:    make(chan struct{}),
+		acks:      make(chan flow.EventPosition, 10),
+	}
+
+	f.mu.Lock()
+	f.providers[subscriptionID] = provider
+	f.mu.Unlock()
+	f.created <- provider
+
+	if topic == testAckTopic {
+		return &ackingTestDataProvider{provider}, nil
+	}
+	return provider, nil
+}
+
+type WsControllerSuite struct {
+	suite.Suite
+
+	factory *testDataProviderFactory
+	server  *httptest.Server
+	conn    *websocket.Conn
+}
+
+func TestWsControllerSuite(t *testing.T) {
+	suite.Run(t, new(WsControllerSuite))
+}
+
+func (s *WsControllerSuite) SetupTest() {
+	s.factory = newTestDataProviderFactory()
+
+	config := NewDefaultWebsocketConfig()
+	config.MaxSubscriptionsPerConnection = 2
+
+	handler := NewWebSocketHandler(unittest.Logger(), config, flow.Testnet.Chain(), s.factory, 1024)
+	s.server = httptest.NewServer(handler)
+
+	url := "ws" + strings.TrimPrefix(s.server.URL, "http")
+	conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusSwitchingProtocols, resp.StatusCode)
+	s.conn = conn
+}
+
+func (s *WsControllerSuite) TearDownTest() {
+	_ = s.conn.Close()
+	s.server.Close()
+}
+
+// TestSubscribeAndReceiveData tests that a subscription is confirmed and its data is forwarded to the client.
+func (s *WsControllerSuite) TestSubscribeAndReceiveData() {
+	ack := s.subscribe("sub-1", testTopic)
+	s.Require().Nil(ack.Error)
+	s.Require().Equal("sub-1", ack.SubscriptionID)
+	s.Require().Equal(models.SubscribeAction, ack.Action)
+
+	provider := s.provider()
+	provider.data <- "hello"
+
+	var data models.BaseDataProvidersResponse
+	s.read(&data)
+	s.Require().Equal("sub-1", data.SubscriptionID)
+	s.Require().Equal(testTopic, data.Topic)
+	s.Require().Equal("hello", data.Payload)
+}
+
+// TestSubscribeGeneratesSubscriptionID tests that the server chooses a subscription ID if none was provided.
+func (s *WsControllerSuite) TestSubscribeGeneratesSubscriptionID() {
+	ack := s.subscribe("", testTopic)
+	s.Require().Nil(ack.Error)
+	s.Require().NotEmpty(ack.SubscriptionID)
+	s.Require().Equal(ack.SubscriptionID, s.provider().ID())
+}
+
+// TestMultiplexedSubscriptions tests that data of multiple subscriptions is sent over the same connection.
+func (s *WsControllerSuite) TestMultiplexedSubscriptions() {
+	s.Require().Nil(s.subscribe("sub-1", testTopic).Error)
+	first := s.provider()
+	s.Require().Nil(s.subscribe("sub-2", testTopic).Error)
+	second := s.provider()
+
+	second.data <- "second"
+	var data models.BaseDataProvidersResponse
+	s.read(&data)
+	s.Require().Equal("sub-2", data.SubscriptionID)
+
+	first.data <- "first"
+	s.read(&data)
+	s.Require().Equal("sub-1", data.SubscriptionID)
+}
+
+// TestSubscribeErrors tests that invalid subscribe requests are answered with error messages
+// and leave the connection open.
+func (s *WsControllerSuite) TestSubscribeErrors() {
+	s.Run("unknown topic", func() {
+		ack := s.subscribe("sub-1", "unknown")
+		s.Require().NotNil(ack.Error)
+		s.Require().Equal(http.StatusBadRequest, ack.Error.Code)
+	})
+
+	s.Run("duplicate subscription ID", func() {
+		s.Require().Nil(s.subscribe("sub-1", testTopic).Error)
+		s.provider()
+
+		ack := s.subscribe("sub-1", testTopic)
+		s.Require().NotNil(ack.Error)
+		s.Require().Equal(http.StatusBadRequest, ack.Error.Code)
+	})
+
+	s.Run("subscription limit reached", func() {
+		s.Require().Nil(s.subscribe("sub-2", testTopic).Error)
+		s.provider()
+
+		ack := s.subscribe("sub-3", testTopic)
+		s.Require().NotNil(ack.Error)
+		s.Require().Equal(http.StatusTooManyRequests, ack.Error.Code)
+	})
+
+	s.Run("invalid message", func() {
+		s.Require().NoError(s.conn.WriteMessage(websocket.TextMessage, []byte("{not json")))
+
+		var resp models.BaseMessageResponse
+		s.read(&resp)
+		s.Require().NotNil(resp.Error)
+		s.Require().Equal(http.StatusBadRequest, resp.Error.Code)
+	})
+
+	s.Run("unknown action", func() {
+		s.write(models.BaseMessageRequest{Action: "resubscribe"})
+
+		var resp models.BaseMessageResponse
+		s.read(&resp)
+		s.Require().NotNil(resp.Error)
+		s.Require().Equal(http.StatusBadRequest, resp.Error.Code)
+	})
+}
+
+// TestUnsubscribe tests that unsubscribing closes the data provider and frees its subscription slot.
+func (s *WsControllerSuite) TestUnsubscribe() {
+	s.Require().Nil(s.subscribe("sub-1", testTopic).Error)
+	provider := s.provider()
+
+	s.write(models.UnsubscribeMessageRequest{
+		BaseMessageRequest: models.BaseMessageRequest{SubscriptionID: "sub-1", Action: models.UnsubscribeAction},
+	})
+
+	var resp models.BaseMessageResponse
+	s.read(&resp)
+	s.Require().Nil(resp.Error)
+	s.Require().Equal("sub-1", resp.SubscriptionID)
+	s.Require().Equal(models.UnsubscribeAction, resp.Action)
+	unittest.RequireCloseBefore(s.T(), provider.closed, time.Second, "data provider was not closed")
+
+	// the subscription ID can be reused after unsubscribing
+	s.Require().Nil(s.subscribe("sub-1", testTopic).Error)
+	s.provider()
+
+	s.Run("unknown subscription", func() {
+		s.write(models.UnsubscribeMessageRequest{
+			BaseMessageRequest: models.BaseMessageRequest{SubscriptionID: "unknown", Action: models.UnsubscribeAction},
+		})
+
+		var resp models.BaseMessageResponse
+		s.read(&resp)
+		s.Require().NotNil(resp.Error)
+		s.Require().Equal(http.StatusNotFound, resp.Error.Code)
+	})
+}
+
+// TestListSubscriptions tests that all active subscriptions are listed.
+func (s *WsControllerSuite) TestListSubscriptions() {
+	s.Require().Nil(s.subscribe("sub-1", testTopic).Error)
+	s.provider()
+	s.Require().Nil(s.subscribe("sub-2", testTopic).Error)
+	s.provider()
+
+	s.write(models.ListSubscriptionsMessageRequest{
+		BaseMessageRequest: models.BaseMessageRequest{Action: models.ListSubscriptionsAction},
+	})
+
+	var resp models.ListSubscriptionsMessageResponse
+	s.read(&resp)
+	s.Require().Nil(resp.Error)
+	s.Require().Len(resp.Subscriptions, 2)
+
+	ids := []string{resp.Subscriptions[0].SubscriptionID, resp.Subscriptions[1].SubscriptionID}
+	s.Require().ElementsMatch([]string{"sub-1", "sub-2"}, ids)
+}
+
+// TestDataProviderFailure tests that a failing subscription is reported to the client, and the
+// connection stays usable.
+func (s *WsControllerSuite) TestDataProviderFailure() {
+	s.Require().Nil(s.subscribe("sub-1", testTopic).Error)
+	provider := s.provider()
+
+	close(provider.data)
+
+	var resp models.BaseMessageResponse
+	s.read(&resp)
+	s.Require().NotNil(resp.Error)
+	s.Require().Equal("sub-1", resp.SubscriptionID)
+	s.Require().Equal(http.StatusInternalServerError, resp.Error.Code)
+
+	// the failed subscription is removed
+	s.Require().Eventually(func() bool {
+		s.write(models.ListSubscriptionsMessageRequest{
+			BaseMessageRequest: models.BaseMessageRequest{Action: models.ListSubscriptionsAction},
+		})
+		var list models.ListSubscriptionsMessageResponse
+		s.read(&list)
+		return len(list.Subscriptions) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+// TestAck tests that acknowledgements are forwarded to data providers which support them.
+func (s *WsControllerSuite) TestAck() {
+	// the confirmation of a subscription using a cursor contains the name of the cursor
+	resp := s.subscribe("sub-1", testAckTopic)
+	s.Require().Nil(resp.Error)
+	s.Require().Equal(testCursor, resp.Cursor)
+	provider := s.provider()
+
+	resp = s.subscribe("sub-2", testTopic)
+	s.Require().Nil(resp.Error)
+	s.Require().Empty(resp.Cursor)
+	s.provider()
+
+	s.Run("event position", func() {
+		resp := s.ack(models.AckMessageRequest{BlockHeight: "10", TransactionIndex: "2", EventIndex: "3"}, "sub-1")
+		s.Require().Nil(resp.Error)
+		s.Require().Equal("sub-1", resp.SubscriptionID)
+		s.Require().Equal(models.AckAction, resp.Action)
+		s.Require().Equal(flow.EventPosition{BlockHeight: 10, TransactionIndex: 2, EventIndex: 3}, <-provider.acks)
+	})
+
+	s.Run("whole block", func() {
+		resp := s.ack(models.AckMessageRequest{BlockHeight: "11"}, "sub-1")
+		s.Require().Nil(resp.Error)
+		s.Require().Equal(flow.EndOfBlockPosition(11), <-provider.acks)
+	})
+
+	s.Run("rejected by data provider", func() {
+		resp := s.ack(models.AckMessageRequest{BlockHeight: fmt.Sprint(testMaxAckHeight + 1)}, "sub-1")
+		s.Require().NotNil(resp.Error)
+		s.Require().Equal(http.StatusBadRequest, resp.Error.Code)
+	})
+
+	s.Run("invalid position", func() {
+		for _, msg := range []models.AckMessageRequest{
+			{BlockHeight: ""},
+			{BlockHeight: "abc"},
+			{BlockHeight: "10", TransactionIndex: "1"},
+			{BlockHeight: "10", TransactionIndex: "1", EventIndex: "-1"},
+		} {
+			resp := s.ack(msg, "sub-1")
+			s.Require().NotNil(resp.Error)
+			s.Require().Equal(http.StatusBadRequest, resp.Error.Code)
+		}
+	})
+
+	s.Run("unknown subscription", func() {
+		resp := s.ack(models.AckMessageRequest{BlockHeight: "10"}, "unknown")
+		s.Require().NotNil(resp.Error)
+		s.Require().Equal(http.StatusNotFound, resp.Error.Code)
+	})
+
+	s.Run("acknowledgements not supported", func() {
+		resp := s.ack(models.AckMessageRequest{BlockHeight: "10"}, "sub-2")
+		s.Require().NotNil(resp.Error)
+		s.Require().Equal(http.StatusBadRequest, resp.Error.Code)
+	})
+
+	s.Require().Empty(provider.acks)
+}
+
+// TestConnectionClosed tests that all data providers are closed when the client disconnects.
+func (s *WsControllerSuite) TestConnectionClosed() {
+	s.Require().Nil(s.subscribe("sub-1", testTopic).Error)
+	provider := s.provider()
+
+	err := s.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	s.Require().NoError(err)
+
+	unittest.RequireCloseBefore(s.T(), provider.closed, time.Second, "data provider was not closed")
+}
+
+// subscribe sends a subscribe request and returns the response.
+func (s *WsControllerSuite) subscribe(subscriptionID string, topic string) models.BaseMessageResponse {
+	s.write(models.SubscribeMessageRequest{
+		BaseMessageRequest: models.BaseMessageRequest{SubscriptionID: subscriptionID, Action: models.SubscribeAction},
+		Topic:              topic,
+		Arguments:          models.Arguments{"key": "value"},
+	})
+
+	var resp models.BaseMessageResponse
+	s.read(&resp)
+	return resp
+}
+
+// ack sends an ack request for the given subscription and returns the response.
+func (s *WsControllerSuite) ack(msg models.AckMessageRequest, subscriptionID string) models.BaseMessageResponse {
+	msg.BaseMessageRequest = models.BaseMessageRequest{SubscriptionID: subscriptionID, Action: models.AckAction}
+	s.write(msg)
+
+	var resp models.BaseMessageResponse
+	s.read(&resp)
+	return resp
+}
+
+// provider returns the most recently created data provider.
+func (s *WsControllerSuite) provider() *testDataProvider {
+	select {
+	case provider := <-s.factory.created:
+		return provider
+	case <-time.After(time.Second):
+		s.FailNow("data provider was not created")
+		return nil
+	}
+}
+
+func (s *WsControllerSuite) write(msg interface{}) {
+	s.Require().NoError(s.conn.SetWriteDeadline(time.Now().Add(time.Second)))
+	s.Require().NoError(s.conn.WriteJSON(msg))
+}
+
+func (s *WsControllerSuite) read(msg interface{}) {
+	s.Require().NoError(s.conn.SetReadDeadline(time.Now().Add(time.Second)))
+	_, raw, err := s.conn.ReadMessage()
+	s.Require().NoError(err)
+	require.NoError(s.T(), json.Unmarshal(raw, msg))
+}
@@ -0,0 +1,163 @@
+package data_providers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+	wsmodels "github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/state_stream/backend"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// accountStatusesArguments contains the arguments accepted by the account statuses topic.
+type accountStatusesArguments struct {
+	StartBlock        startBlock
+	Filter            state_stream.AccountStatusFilter
+	HeartbeatInterval uint64
+}
+
+// parseAccountStatusesArguments validates and initializes the account statuses arguments.
+//
+// Expected errors during normal operations:
+//   - if the arguments are invalid.
+func parseAccountStatusesArguments(
+	arguments wsmodels.Arguments,
+	chain flow.Chain,
+	eventFilterConfig state_stream.EventFilterConfig,
+	defaultHeartbeatInterval uint64,
+) (accountStatusesArguments, error) {
+	var args accountStatusesArguments
+	var err error
+
+	args.StartBlock, err = parseStartBlock(arguments)
+	if err != nil {
+		return args, err
+	}
+
+	args.HeartbeatInterval, err = parseHeartbeatInterval(arguments, defaultHeartbeatInterval)
+	if err != nil {
+		return args, err
+	}
+
+	rawEventTypes, err := optionalStringArray(arguments, eventTypesArgument)
+	if err != nil {
+		return args, err
+	}
+	var eventTypes request.EventTypes
+	err = eventTypes.Parse(rawEventTypes)
+	if err != nil {
+		return args, err
+	}
+
+	accountAddresses, err := optionalStringArray(arguments, accountAddressesArgument)
+	if err != nil {
+		return args, err
+	}
+
+	args.Filter, err = state_stream.NewAccountStatusFilter(eventFilterConfig, chain, eventTypes.Flow(), accountAddresses)
+	if err != nil {
+		return args, fmt.Errorf("invalid account status filter: %w", err)
+	}
+
+	return args, nil
+}
+
+// AccountStatusesDataProvider is responsible for providing account statuses
+type AccountStatusesDataProvider struct {
+	*baseDataProvider
+
+	ctx               context.Context
+	logger            zerolog.Logger
+	heartbeatInterval uint64
+}
+
+var _ DataProvider = (*AccountStatusesDataProvider)(nil)
+
+// NewAccountStatusesDataProvider creates a new instance of AccountStatusesDataProvider.
+//
+// Expected errors during normal operations:
+//   - if the arguments are invalid.
+func NewAccountStatusesDataProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	stateStreamApi state_stream.API,
+	chain flow.Chain,
+	eventFilterConfig state_stream.EventFilterConfig,
+	defaultHeartbeatInterval uint64,
+	subscriptionID string,
+	topic string,
+	arguments wsmodels.Arguments,
+	send chan<- interface{},
+) (*AccountStatusesDataProvider, error) {
+	args, err := parseAccountStatusesArguments(arguments, chain, eventFilterConfig, defaultHeartbeatInterval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var sub subscription.Subscription
+	switch {
+	case args.StartBlock.ID != flow.ZeroID:
+		sub = stateStreamApi.SubscribeAccountStatusesFromStartBlockID(ctx, args.StartBlock.ID, args.Filter)
+	case !args.StartBlock.fromLatest():
+		sub = stateStreamApi.SubscribeAccountStatusesFromStartHeight(ctx, args.StartBlock.Height, args.Filter)
+	default:
+		sub = stateStreamApi.SubscribeAccountStatusesFromLatestBlock(ctx, args.Filter)
+	}
+
+	return &AccountStatusesDataProvider{
+		baseDataProvider:  newBaseDataProvider(subscriptionID, topic, arguments, cancel, send, sub),
+		ctx:               ctx,
+		logger:            logger.With().Str("component", "account-statuses-data-provider").Logger(),
+		heartbeatInterval: args.HeartbeatInterval,
+	}, nil
+}
+
+// Run starts processing the subscription for account statuses and handles responses.
+//
+// Responses without events are only forwarded once every heartbeat interval, so clients
+// can track the progress of the stream without receiving a message for every block.
+//
+// Expected errors during normal operations:
+//   - codes.Internal: if the subscription fails or produces an unexpected response.
+func (p *AccountStatusesDataProvider) Run() error {
+	blocksSinceLastMessage := uint64(0)
+	messageIndex := uint64(0)
+
+	return run(p.ctx, p.baseDataProvider, func(resp *backend.AccountStatusesResponse) error {
+		if len(resp.AccountEvents) == 0 {
+			blocksSinceLastMessage++
+			if blocksSinceLastMessage < p.heartbeatInterval {
+				return nil
+			}
+		}
+		blocksSinceLastMessage = 0
+
+		// the backend returns CCF encoded events, and this API returns JSON-CDC events.
+		accountEvents := make(map[string]flow.EventsList, len(resp.AccountEvents))
+		for address, events := range resp.AccountEvents {
+			converted, err := convert.CcfEventsToJsonEvents(events)
+			if err != nil {
+				return fmt.Errorf("could not convert events payload from CCF to JSON: %w", err)
+			}
+			accountEvents[address] = converted
+		}
+
+		var response wsmodels.AccountStatusesResponse
+		response.Build(&backend.AccountStatusesResponse{
+			BlockID:       resp.BlockID,
+			Height:        resp.Height,
+			AccountEvents: accountEvents,
+		}, messageIndex)
+		messageIndex++
+
+		return p.sendResponse(p.ctx, &response)
+	})
+}
@@ -0,0 +1,222 @@
+package data_providers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+	"github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	startBlockIDArgument      = "start_block_id"
+	startBlockHeightArgument  = "start_block_height"
+	blockStatusArgument       = "block_status"
+	eventTypesArgument        = "event_types"
+	addressesArgument         = "addresses"
+	contractsArgument         = "contracts"
+	expressionsArgument       = "expressions"
+	accountAddressesArgument  = "account_addresses"
+	heartbeatIntervalArgument = "heartbeat_interval"
+	cursorArgument            = "cursor"
+	createCursorArgument      = "create_cursor"
+	topicsArgument            = "topics"
+)
+
+// startBlock describes where a subscription starts. If neither the block ID nor the height is set,
+// the subscription starts from the latest block.
+type startBlock struct {
+	ID     flow.Identifier
+	Height uint64
+}
+
+// fromLatest returns true if no start block was requested.
+func (s startBlock) fromLatest() bool {
+	return s.ID == flow.ZeroID && s.Height == request.EmptyHeight
+}
+
+// parseStartBlock parses the optional start_block_id and start_block_height arguments.
+// At most one of them may be provided.
+//
+// Expected errors during normal operations:
+//   - if an argument has an invalid format, or both arguments are provided.
+func parseStartBlock(arguments models.Arguments) (startBlock, error) {
+	start := startBlock{Height: request.EmptyHeight}
+
+	rawID, err := optionalString(arguments, startBlockIDArgument)
+	if err != nil {
+		return start, err
+	}
+	if rawID != "" {
+		var id request.ID
+		err = id.Parse(rawID)
+		if err != nil {
+			return start, fmt.Errorf("invalid '%s': %w", startBlockIDArgument, err)
+		}
+		start.ID = id.Flow()
+	}
+
+	rawHeight, err := optionalString(arguments, startBlockHeightArgument)
+	if err != nil {
+		return start, err
+	}
+	if rawHeight != "" {
+		var height request.Height
+		err = height.Parse(rawHeight)
+		if err != nil {
+			return start, fmt.Errorf("invalid '%s': %w", startBlockHeightArgument, err)
+		}
+		start.Height = height.Flow()
+	}
+
+	if start.ID != flow.ZeroID && start.Height != request.EmptyHeight {
+		return start, fmt.Errorf("can only provide either '%s' or '%s'", startBlockIDArgument, startBlockHeightArgument)
+	}
+
+	return start, nil
+}
+
+// parseBlockStatus parses the required block_status argument, which must be either "finalized" or "sealed".
+//
+// Expected errors during normal operations:
+//   - if the argument is missing or has an unknown value.
+func parseBlockStatus(arguments models.Arguments) (flow.BlockStatus, error) {
+	raw, err := optionalString(arguments, blockStatusArgument)
+	if err != nil {
+		return flow.BlockStatusUnknown, err
+	}
+
+	switch raw {
+	case "finalized":
+		return flow.BlockStatusFinalized, nil
+	case "sealed":
+		return flow.BlockStatusSealed, nil
+	case "":
+		return flow.BlockStatusUnknown, fmt.Errorf("'%s' must be provided", blockStatusArgument)
+	default:
+		return flow.BlockStatusUnknown, fmt.Errorf("invalid '%s': %s, must be one of [finalized, sealed]", blockStatusArgument, raw)
+	}
+}
+
+// parseHeartbeatInterval parses the optional heartbeat_interval argument. It returns the provided default
+// if the argument is not set.
+//
+// Expected errors during normal operations:
+//   - if the argument has an invalid format.
+func parseHeartbeatInterval(arguments models.Arguments, defaultInterval uint64) (uint64, error) {
+	raw, err := optionalString(arguments, heartbeatIntervalArgument)
+	if err != nil {
+		return 0, err
+	}
+	if raw == "" {
+		return defaultInterval, nil
+	}
+
+	interval, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid '%s': %w", heartbeatIntervalArgument, err)
+	}
+	if interval == 0 {
+		return 0, fmt.Errorf("'%s' must be greater than 0", heartbeatIntervalArgument)
+	}
+	return interval, nil
+}
+
+// optionalBool returns the boolean value of the argument, or false if it is not set. Like all other
+// arguments, it is provided as a string.
+//
+// Expected errors during normal operations:
+//   - if the argument is not a string, or not a boolean.
+func optionalBool(arguments models.Arguments, name string) (bool, error) {
+	raw, err := optionalString(arguments, name)
+	if err != nil {
+		return false, err
+	}
+	if raw == "" {
+		return false, nil
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("invalid '%s': %w", name, err)
+	}
+	return value, nil
+}
+
+// optionalString returns the string value of the argument, or an empty string if it is not set.
+//
+// Expected errors during normal operations:
+//   - if the argument is not a string.
+func optionalString(arguments models.Arguments, name string) (string, error) {
+	raw, ok := arguments[name]
+	if !ok || raw == nil {
+		return "", nil
+	}
+
+	value, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("'%s' must be a string", name)
+	}
+	return value, nil
+}
+
+// optionalStringArray returns the value of the argument as a list of strings, or nil if it is not set.
+//
+// Expected errors during normal operations:
+//   - if the argument is not a list of strings.
+func optionalStringArray(arguments models.Arguments, name string) ([]string, error) {
+	raw, ok := arguments[name]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	switch values := raw.(type) {
+	case []string:
+		return values, nil
+	case []interface{}:
+		result := make([]string, len(values))
+		for i, v := range values {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("'%s' must be a list of strings", name)
+			}
+			result[i] = s
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("'%s' must be a list of strings", name)
+	}
+}
+
+// optionalStringArrays returns the value of the argument as a list of lists of strings, or nil if it is
+// not set. null elements are returned as nil lists.
+//
+// Expected errors during normal operations:
+//   - if the argument is not a list of lists of strings.
+func optionalStringArrays(arguments models.Arguments, name string) ([][]string, error) {
+	raw, ok := arguments[name]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	switch values := raw.(type) {
+	case [][]string:
+		return values, nil
+	case []interface{}:
+		result := make([][]string, len(values))
+		for i, v := range values {
+			if v == nil {
+				continue
+			}
+			element, err := optionalStringArray(models.Arguments{name: v}, name)
+			if err != nil {
+				return nil, fmt.Errorf("'%s' must be a list of lists of strings", name)
+			}
+			result[i] = element
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("'%s' must be a list of lists of strings", name)
+	}
+}
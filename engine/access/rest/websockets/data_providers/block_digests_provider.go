@@ -0,0 +1,73 @@
+package data_providers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/access"
+	wsmodels "github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// BlockDigestsDataProvider is responsible for providing block digests
+type BlockDigestsDataProvider struct {
+	*baseDataProvider
+
+	ctx    context.Context
+	logger zerolog.Logger
+}
+
+var _ DataProvider = (*BlockDigestsDataProvider)(nil)
+
+// NewBlockDigestsDataProvider creates a new instance of BlockDigestsDataProvider.
+//
+// Expected errors during normal operations:
+//   - if the arguments are invalid.
+func NewBlockDigestsDataProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	api access.API,
+	subscriptionID string,
+	topic string,
+	arguments wsmodels.Arguments,
+	send chan<- interface{},
+) (*BlockDigestsDataProvider, error) {
+	args, err := parseBlocksArguments(arguments)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var sub subscription.Subscription
+	switch {
+	case args.StartBlock.ID != flow.ZeroID:
+		sub = api.SubscribeBlockDigestsFromStartBlockID(ctx, args.StartBlock.ID, args.BlockStatus)
+	case !args.StartBlock.fromLatest():
+		sub = api.SubscribeBlockDigestsFromStartHeight(ctx, args.StartBlock.Height, args.BlockStatus)
+	default:
+		sub = api.SubscribeBlockDigestsFromLatest(ctx, args.BlockStatus)
+	}
+
+	return &BlockDigestsDataProvider{
+		baseDataProvider: newBaseDataProvider(subscriptionID, topic, arguments, cancel, send, sub),
+		ctx:              ctx,
+		logger:           logger.With().Str("component", "block-digests-data-provider").Logger(),
+	}, nil
+}
+
+// Run starts processing the subscription for block digests and handles responses.
+//
+// Expected errors during normal operations:
+//   - codes.Internal: if the subscription fails or produces an unexpected response.
+func (p *BlockDigestsDataProvider) Run() error {
+	return run(p.ctx, p.baseDataProvider, func(block *flow.BlockDigest) error {
+		var response wsmodels.BlockDigest
+		response.Build(block)
+
+		return p.sendResponse(p.ctx, &response)
+	})
+}
@@ -0,0 +1,177 @@
+package data_providers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	accessmock "github.com/onflow/flow-go/access/mock"
+	wsmodels "github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type BlockDigestsProviderSuite struct {
+	suite.Suite
+
+	api *accessmock.API
+}
+
+func TestBlockDigestsProviderSuite(t *testing.T) {
+	suite.Run(t, new(BlockDigestsProviderSuite))
+}
+
+func (s *BlockDigestsProviderSuite) SetupTest() {
+	s.api = accessmock.NewAPI(s.T())
+}
+
+// TestInvalidArguments tests that a data provider is not created for invalid arguments.
+func (s *BlockDigestsProviderSuite) TestInvalidArguments() {
+	tests := []struct {
+		name      string
+		arguments wsmodels.Arguments
+	}{
+		{
+			name:      "missing block status",
+			arguments: wsmodels.Arguments{},
+		},
+		{
+			name:      "unknown block status",
+			arguments: wsmodels.Arguments{blockStatusArgument: "executed"},
+		},
+		{
+			name: "both start block ID and height",
+			arguments: wsmodels.Arguments{
+				blockStatusArgument:      "finalized",
+				startBlockIDArgument:     unittest.IdentifierFixture().String(),
+				startBlockHeightArgument: "10",
+			},
+		},
+		{
+			name: "invalid start block ID",
+			arguments: wsmodels.Arguments{
+				blockStatusArgument:  "finalized",
+				startBlockIDArgument: "invalid",
+			},
+		},
+		{
+			name: "invalid start block height",
+			arguments: wsmodels.Arguments{
+				blockStatusArgument:      "finalized",
+				startBlockHeightArgument: "-1",
+			},
+		},
+		{
+			name: "start block height is not a string",
+			arguments: wsmodels.Arguments{
+				blockStatusArgument:      "finalized",
+				startBlockHeightArgument: 10,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			send := make(chan interface{})
+			provider, err := NewBlockDigestsDataProvider(context.Background(), unittest.Logger(), s.api, "sub", BlockDigestsTopic, test.arguments, send)
+			s.Require().Error(err)
+			s.Require().Nil(provider)
+		})
+	}
+}
+
+// TestStartBlock tests that the subscription is started at the requested block.
+func (s *BlockDigestsProviderSuite) TestStartBlock() {
+	blockID := unittest.IdentifierFixture()
+	sub := subscription.NewFailedSubscription(fmt.Errorf("not used"), "not used")
+
+	s.Run("from start block ID", func() {
+		s.api.On("SubscribeBlockDigestsFromStartBlockID", mock.Anything, blockID, flow.BlockStatusSealed).Return(sub).Once()
+
+		arguments := wsmodels.Arguments{blockStatusArgument: "sealed", startBlockIDArgument: blockID.String()}
+		_, err := NewBlockDigestsDataProvider(context.Background(), unittest.Logger(), s.api, "sub", BlockDigestsTopic, arguments, make(chan interface{}))
+		s.Require().NoError(err)
+	})
+
+	s.Run("from start block height", func() {
+		s.api.On("SubscribeBlockDigestsFromStartHeight", mock.Anything, uint64(42), flow.BlockStatusFinalized).Return(sub).Once()
+
+		arguments := wsmodels.Arguments{blockStatusArgument: "finalized", startBlockHeightArgument: "42"}
+		_, err := NewBlockDigestsDataProvider(context.Background(), unittest.Logger(), s.api, "sub", BlockDigestsTopic, arguments, make(chan interface{}))
+		s.Require().NoError(err)
+	})
+
+	s.Run("from latest", func() {
+		s.api.On("SubscribeBlockDigestsFromLatest", mock.Anything, flow.BlockStatusFinalized).Return(sub).Once()
+
+		arguments := wsmodels.Arguments{blockStatusArgument: "finalized"}
+		_, err := NewBlockDigestsDataProvider(context.Background(), unittest.Logger(), s.api, "sub", BlockDigestsTopic, arguments, make(chan interface{}))
+		s.Require().NoError(err)
+	})
+}
+
+// TestRun tests that block digests received from the subscription are sent in the websocket API format,
+// and that the data provider stops once it is closed.
+func (s *BlockDigestsProviderSuite) TestRun() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub := subscription.NewSubscription(10)
+	s.api.On("SubscribeBlockDigestsFromLatest", mock.Anything, flow.BlockStatusFinalized).Return(sub)
+
+	send := make(chan interface{}, 10)
+	provider, err := NewBlockDigestsDataProvider(ctx, unittest.Logger(), s.api, "sub", BlockDigestsTopic, wsmodels.Arguments{blockStatusArgument: "finalized"}, send)
+	s.Require().NoError(err)
+	s.Require().Equal("sub", provider.ID())
+	s.Require().Equal(BlockDigestsTopic, provider.Topic())
+
+	done := make(chan error)
+	go func() {
+		done <- provider.Run()
+	}()
+
+	blocks := unittest.BlockFixtures(3)
+	for _, block := range blocks {
+		digest := flow.NewBlockDigest(block.ID(), block.Header.Height, block.Header.Timestamp)
+		s.Require().NoError(sub.Send(ctx, digest, time.Second))
+	}
+
+	for _, block := range blocks {
+		var msg interface{}
+		unittest.RequireReturnsBefore(s.T(), func() { msg = <-send }, time.Second, "response was not sent")
+
+		response, ok := msg.(*wsmodels.BaseDataProvidersResponse)
+		s.Require().True(ok)
+		s.Require().Equal("sub", response.SubscriptionID)
+		s.Require().Equal(BlockDigestsTopic, response.Topic)
+
+		digest, ok := response.Payload.(*wsmodels.BlockDigest)
+		s.Require().True(ok)
+		s.Require().Equal(block.ID().String(), digest.BlockId)
+		s.Require().Equal(strconv.FormatUint(block.Header.Height, 10), digest.Height)
+	}
+
+	provider.Close()
+	unittest.RequireReturnsBefore(s.T(), func() {
+		require.NoError(s.T(), <-done)
+	}, time.Second, "data provider did not stop")
+}
+
+// TestRunSubscriptionFailure tests that subscription failures are returned by Run.
+func (s *BlockDigestsProviderSuite) TestRunSubscriptionFailure() {
+	sub := subscription.NewFailedSubscription(fmt.Errorf("backend failure"), "subscription failed")
+	s.api.On("SubscribeBlockDigestsFromLatest", mock.Anything, flow.BlockStatusFinalized).Return(sub)
+
+	provider, err := NewBlockDigestsDataProvider(context.Background(), unittest.Logger(), s.api, "sub", BlockDigestsTopic, wsmodels.Arguments{blockStatusArgument: "finalized"}, make(chan interface{}))
+	s.Require().NoError(err)
+
+	err = provider.Run()
+	s.Require().ErrorContains(err, "backend failure")
+}
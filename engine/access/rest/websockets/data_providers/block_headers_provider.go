@@ -0,0 +1,74 @@
+package data_providers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	wsmodels "github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// BlockHeadersDataProvider is responsible for providing block headers
+type BlockHeadersDataProvider struct {
+	*baseDataProvider
+
+	ctx    context.Context
+	logger zerolog.Logger
+}
+
+var _ DataProvider = (*BlockHeadersDataProvider)(nil)
+
+// NewBlockHeadersDataProvider creates a new instance of BlockHeadersDataProvider.
+//
+// Expected errors during normal operations:
+//   - if the arguments are invalid.
+func NewBlockHeadersDataProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	api access.API,
+	subscriptionID string,
+	topic string,
+	arguments wsmodels.Arguments,
+	send chan<- interface{},
+) (*BlockHeadersDataProvider, error) {
+	args, err := parseBlocksArguments(arguments)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var sub subscription.Subscription
+	switch {
+	case args.StartBlock.ID != flow.ZeroID:
+		sub = api.SubscribeBlockHeadersFromStartBlockID(ctx, args.StartBlock.ID, args.BlockStatus)
+	case !args.StartBlock.fromLatest():
+		sub = api.SubscribeBlockHeadersFromStartHeight(ctx, args.StartBlock.Height, args.BlockStatus)
+	default:
+		sub = api.SubscribeBlockHeadersFromLatest(ctx, args.BlockStatus)
+	}
+
+	return &BlockHeadersDataProvider{
+		baseDataProvider: newBaseDataProvider(subscriptionID, topic, arguments, cancel, send, sub),
+		ctx:              ctx,
+		logger:           logger.With().Str("component", "block-headers-data-provider").Logger(),
+	}, nil
+}
+
+// Run starts processing the subscription for block headers and handles responses.
+//
+// Expected errors during normal operations:
+//   - codes.Internal: if the subscription fails or produces an unexpected response.
+func (p *BlockHeadersDataProvider) Run() error {
+	return run(p.ctx, p.baseDataProvider, func(header *flow.Header) error {
+		var response models.BlockHeader
+		response.Build(header)
+
+		return p.sendResponse(p.ctx, &response)
+	})
+}
@@ -0,0 +1,109 @@
+package data_providers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	wsmodels "github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// blocksArguments contains the arguments accepted by the blocks, block headers and block digests topics.
+type blocksArguments struct {
+	StartBlock  startBlock
+	BlockStatus flow.BlockStatus
+}
+
+// parseBlocksArguments validates and initializes the blocks arguments.
+//
+// Expected errors during normal operations:
+//   - if the arguments are invalid.
+func parseBlocksArguments(arguments wsmodels.Arguments) (blocksArguments, error) {
+	var args blocksArguments
+	var err error
+
+	args.BlockStatus, err = parseBlockStatus(arguments)
+	if err != nil {
+		return args, err
+	}
+
+	args.StartBlock, err = parseStartBlock(arguments)
+	if err != nil {
+		return args, err
+	}
+
+	return args, nil
+}
+
+// BlocksDataProvider is responsible for providing blocks
+type BlocksDataProvider struct {
+	*baseDataProvider
+
+	ctx           context.Context
+	logger        zerolog.Logger
+	linkGenerator models.LinkGenerator
+	blockStatus   flow.BlockStatus
+}
+
+var _ DataProvider = (*BlocksDataProvider)(nil)
+
+// NewBlocksDataProvider creates a new instance of BlocksDataProvider.
+//
+// Expected errors during normal operations:
+//   - if the arguments are invalid.
+func NewBlocksDataProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	api access.API,
+	linkGenerator models.LinkGenerator,
+	subscriptionID string,
+	topic string,
+	arguments wsmodels.Arguments,
+	send chan<- interface{},
+) (*BlocksDataProvider, error) {
+	args, err := parseBlocksArguments(arguments)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var sub subscription.Subscription
+	switch {
+	case args.StartBlock.ID != flow.ZeroID:
+		sub = api.SubscribeBlocksFromStartBlockID(ctx, args.StartBlock.ID, args.BlockStatus)
+	case !args.StartBlock.fromLatest():
+		sub = api.SubscribeBlocksFromStartHeight(ctx, args.StartBlock.Height, args.BlockStatus)
+	default:
+		sub = api.SubscribeBlocksFromLatest(ctx, args.BlockStatus)
+	}
+
+	return &BlocksDataProvider{
+		baseDataProvider: newBaseDataProvider(subscriptionID, topic, arguments, cancel, send, sub),
+		ctx:              ctx,
+		logger:           logger.With().Str("component", "blocks-data-provider").Logger(),
+		linkGenerator:    linkGenerator,
+		blockStatus:      args.BlockStatus,
+	}, nil
+}
+
+// Run starts processing the subscription for blocks and handles responses.
+//
+// Expected errors during normal operations:
+//   - codes.Internal: if the subscription fails or produces an unexpected response.
+func (p *BlocksDataProvider) Run() error {
+	return run(p.ctx, p.baseDataProvider, func(block *flow.Block) error {
+		var response models.Block
+		err := response.Build(block, nil, p.linkGenerator, p.blockStatus, map[string]bool{"payload": true})
+		if err != nil {
+			return fmt.Errorf("failed to build block response: %w", err)
+		}
+
+		return p.sendResponse(p.ctx, &response)
+	})
+}
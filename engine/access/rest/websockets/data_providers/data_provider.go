@@ -0,0 +1,156 @@
+package data_providers
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// DataProvider streams the data of a single subscription made over a websocket connection.
+// Each data provider wraps one subscription of the access or state stream API, converts its
+// responses into the websocket API format and sends them to the connection.
+type DataProvider interface {
+	// ID returns the subscription ID associated with the data provider.
+	ID() string
+	// Topic returns the topic associated with the data provider.
+	Topic() string
+	// Arguments returns the arguments the data provider was created with.
+	Arguments() models.Arguments
+	// Close terminates the data provider.
+	//
+	// No errors are expected during normal operations.
+	Close()
+	// Run starts processing the subscription and handles responses.
+	// It blocks until the subscription ends or the data provider is closed.
+	//
+	// Expected errors during normal operations:
+	//   - codes.Internal: if the subscription fails or produces an unexpected response.
+	//   - context.Canceled: if the connection the data provider sends to was closed.
+	Run() error
+}
+
+// Acknowledger is implemented by data providers whose subscriptions track the progress of the client,
+// and allows the client to acknowledge the data it processed.
+type Acknowledger interface {
+	// Acknowledge records that the client processed all events up to and including the given position.
+	//
+	// Expected errors during normal operations:
+	//   - codes.FailedPrecondition: if the subscription does not support acknowledgements.
+	//   - codes.NotFound: if the cursor of the subscription does not exist anymore.
+	//   - codes.InvalidArgument: if the position is after the last event delivered to the client.
+	Acknowledge(ctx context.Context, position flow.EventPosition) error
+
+	// Cursor returns the name of the durable event cursor of the subscription, or an empty string
+	// if it does not use a cursor.
+	Cursor() string
+}
+
+// baseDataProvider holds common objects for the provider
+type baseDataProvider struct {
+	subscriptionID string
+	topic          string
+	arguments      models.Arguments
+	cancel         context.CancelFunc
+	send           chan<- interface{}
+	subscription   subscription.Subscription
+}
+
+// newBaseDataProvider creates a new instance of baseDataProvider.
+func newBaseDataProvider(
+	subscriptionID string,
+	topic string,
+	arguments models.Arguments,
+	cancel context.CancelFunc,
+	send chan<- interface{},
+	subscription subscription.Subscription,
+) *baseDataProvider {
+	return &baseDataProvider{
+		subscriptionID: subscriptionID,
+		topic:          topic,
+		arguments:      arguments,
+		cancel:         cancel,
+		send:           send,
+		subscription:   subscription,
+	}
+}
+
+// ID returns the subscription ID associated with current data provider
+func (b *baseDataProvider) ID() string {
+	return b.subscriptionID
+}
+
+// Topic returns the topic associated with the data provider.
+func (b *baseDataProvider) Topic() string {
+	return b.topic
+}
+
+// Arguments returns the arguments the data provider was created with.
+func (b *baseDataProvider) Arguments() models.Arguments {
+	return b.arguments
+}
+
+// Close terminates the data provider.
+func (b *baseDataProvider) Close() {
+	b.cancel()
+}
+
+// sendResponse wraps the payload into a data message and hands it over to the connection.
+// It blocks until the message was accepted or the context is canceled.
+//
+// Expected errors during normal operations:
+//   - context.Canceled: if the context is canceled before the message was accepted.
+func (b *baseDataProvider) sendResponse(ctx context.Context, payload interface{}) error {
+	response := &models.BaseDataProvidersResponse{
+		SubscriptionID: b.subscriptionID,
+		Topic:          b.topic,
+		Payload:        payload,
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case b.send <- response:
+		return nil
+	}
+}
+
+// run processes the subscription of the data provider, converting each response with handleResponse.
+// It returns nil once the data provider was closed, even if the subscription was not closed yet.
+//
+// Expected errors during normal operations:
+//   - codes.Internal: if the subscription fails or produces an unexpected response.
+func run[T any](ctx context.Context, b *baseDataProvider, handleResponse func(resp T) error) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case v, ok := <-b.subscription.Channel():
+			if !ok {
+				if b.subscription.Err() != nil && ctx.Err() == nil {
+					return rpc.ConvertError(b.subscription.Err(), "stream encountered an error", codes.Internal)
+				}
+				return nil
+			}
+
+			resp, ok := v.(T)
+			if !ok {
+				return status.Errorf(codes.Internal, "unexpected response type: %T", v)
+			}
+
+			err := handleResponse(resp)
+			if err != nil {
+				if ctx.Err() != nil {
+					// the data provider was closed, errors caused by the cancellation are expected
+					return nil
+				}
+				return err
+			}
+		}
+	}
+}
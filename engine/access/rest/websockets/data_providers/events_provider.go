@@ -0,0 +1,238 @@
+package data_providers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+	wsmodels "github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/state_stream/backend"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// eventsArguments contains the arguments accepted by the events topic.
+type eventsArguments struct {
+	StartBlock        startBlock
+	Filter            state_stream.EventFilter
+	HeartbeatInterval uint64
+	// Cursor is the name of the durable event cursor the subscription resumes from, or empty if no
+	// existing cursor is used.
+	Cursor string
+	// CreateCursor is true if a new durable event cursor is created for the subscription.
+	CreateCursor bool
+}
+
+// parseEventsArguments validates and initializes the events arguments.
+//
+// Expected errors during normal operations:
+//   - if the arguments are invalid.
+func parseEventsArguments(
+	arguments wsmodels.Arguments,
+	chain flow.Chain,
+	eventFilterConfig state_stream.EventFilterConfig,
+	defaultHeartbeatInterval uint64,
+) (eventsArguments, error) {
+	var args eventsArguments
+	var err error
+
+	args.StartBlock, err = parseStartBlock(arguments)
+	if err != nil {
+		return args, err
+	}
+
+	args.HeartbeatInterval, err = parseHeartbeatInterval(arguments, defaultHeartbeatInterval)
+	if err != nil {
+		return args, err
+	}
+
+	args.Cursor, err = optionalString(arguments, cursorArgument)
+	if err != nil {
+		return args, err
+	}
+	args.CreateCursor, err = optionalBool(arguments, createCursorArgument)
+	if err != nil {
+		return args, err
+	}
+	if args.Cursor != "" && args.CreateCursor {
+		return args, fmt.Errorf("'%s' can not be combined with '%s'", cursorArgument, createCursorArgument)
+	}
+	// a resumed cursor starts after its last acknowledged event
+	if args.Cursor != "" && !args.StartBlock.fromLatest() {
+		return args, fmt.Errorf("'%s' can not be combined with a start block", cursorArgument)
+	}
+	if args.CreateCursor && args.StartBlock.ID != flow.ZeroID {
+		return args, fmt.Errorf("'%s' can not be combined with '%s'", createCursorArgument, startBlockIDArgument)
+	}
+
+	rawEventTypes, err := optionalStringArray(arguments, eventTypesArgument)
+	if err != nil {
+		return args, err
+	}
+	var eventTypes request.EventTypes
+	err = eventTypes.Parse(rawEventTypes)
+	if err != nil {
+		return args, err
+	}
+
+	addresses, err := optionalStringArray(arguments, addressesArgument)
+	if err != nil {
+		return args, err
+	}
+
+	contracts, err := optionalStringArray(arguments, contractsArgument)
+	if err != nil {
+		return args, err
+	}
+
+	expressions, err := optionalStringArray(arguments, expressionsArgument)
+	if err != nil {
+		return args, err
+	}
+
+	args.Filter, err = state_stream.NewEventFilterWithExpressions(eventFilterConfig, chain, eventTypes.Flow(), addresses, contracts, expressions)
+	if err != nil {
+		return args, fmt.Errorf("invalid event filter: %w", err)
+	}
+
+	return args, nil
+}
+
+// EventsDataProvider is responsible for providing events
+type EventsDataProvider struct {
+	*baseDataProvider
+
+	ctx               context.Context
+	logger            zerolog.Logger
+	stateStreamApi    state_stream.API
+	heartbeatInterval uint64
+	cursor            string
+}
+
+var _ DataProvider = (*EventsDataProvider)(nil)
+var _ Acknowledger = (*EventsDataProvider)(nil)
+
+// NewEventsDataProvider creates a new instance of EventsDataProvider.
+//
+// If a cursor is provided, the subscription resumes right after the last acknowledged event of the
+// durable event cursor of that name. If a cursor is to be created, a new cursor is created with the
+// filter of the subscription, which starts at the requested start height, and its name is returned
+// by Cursor.
+//
+// Expected errors during normal operations:
+//   - if the arguments are invalid.
+func NewEventsDataProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	stateStreamApi state_stream.API,
+	chain flow.Chain,
+	eventFilterConfig state_stream.EventFilterConfig,
+	defaultHeartbeatInterval uint64,
+	subscriptionID string,
+	topic string,
+	arguments wsmodels.Arguments,
+	send chan<- interface{},
+) (*EventsDataProvider, error) {
+	args, err := parseEventsArguments(arguments, chain, eventFilterConfig, defaultHeartbeatInterval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if args.CreateCursor {
+		startHeight := uint64(0)
+		if !args.StartBlock.fromLatest() {
+			startHeight = args.StartBlock.Height
+		}
+		args.Cursor, err = stateStreamApi.CreateEventCursor(ctx, startHeight, args.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("could not create event cursor: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var sub subscription.Subscription
+	switch {
+	case args.Cursor != "":
+		sub = stateStreamApi.SubscribeEventsFromCursor(ctx, args.Cursor, args.Filter)
+	case args.StartBlock.ID != flow.ZeroID:
+		sub = stateStreamApi.SubscribeEventsFromStartBlockID(ctx, args.StartBlock.ID, args.Filter)
+	case !args.StartBlock.fromLatest():
+		sub = stateStreamApi.SubscribeEventsFromStartHeight(ctx, args.StartBlock.Height, args.Filter)
+	default:
+		sub = stateStreamApi.SubscribeEventsFromLatest(ctx, args.Filter)
+	}
+
+	return &EventsDataProvider{
+		baseDataProvider:  newBaseDataProvider(subscriptionID, topic, arguments, cancel, send, sub),
+		ctx:               ctx,
+		logger:            logger.With().Str("component", "events-data-provider").Logger(),
+		stateStreamApi:    stateStreamApi,
+		heartbeatInterval: args.HeartbeatInterval,
+		cursor:            args.Cursor,
+	}, nil
+}
+
+// Cursor returns the name of the durable event cursor of the subscription, or an empty string if it
+// does not use a cursor.
+func (p *EventsDataProvider) Cursor() string {
+	return p.cursor
+}
+
+// Acknowledge records that the client processed all events up to and including the given position.
+//
+// Expected errors during normal operations:
+//   - codes.FailedPrecondition: if the subscription does not use a cursor.
+//   - codes.NotFound: if the cursor of the subscription does not exist anymore.
+//   - codes.InvalidArgument: if the position is after the last event delivered to the client.
+func (p *EventsDataProvider) Acknowledge(ctx context.Context, position flow.EventPosition) error {
+	if p.cursor == "" {
+		return status.Errorf(codes.FailedPrecondition, "subscription does not use a cursor")
+	}
+	return p.stateStreamApi.AcknowledgeEvents(ctx, p.cursor, position)
+}
+
+// Run starts processing the subscription for events and handles responses.
+//
+// Responses without events are only forwarded once every heartbeat interval, so clients
+// can track the progress of the stream without receiving a message for every block.
+//
+// Expected errors during normal operations:
+//   - codes.Internal: if the subscription fails or produces an unexpected response.
+func (p *EventsDataProvider) Run() error {
+	blocksSinceLastMessage := uint64(0)
+	messageIndex := uint64(0)
+
+	return run(p.ctx, p.baseDataProvider, func(resp *backend.EventsResponse) error {
+		if len(resp.Events) == 0 {
+			blocksSinceLastMessage++
+			if blocksSinceLastMessage < p.heartbeatInterval {
+				return nil
+			}
+		}
+		blocksSinceLastMessage = 0
+
+		// the backend returns CCF encoded events, and this API returns JSON-CDC events.
+		events, err := convert.CcfEventsToJsonEvents(resp.Events)
+		if err != nil {
+			return fmt.Errorf("could not convert events payload from CCF to JSON: %w", err)
+		}
+
+		var response wsmodels.EventResponse
+		response.Build(&backend.EventsResponse{
+			BlockID:        resp.BlockID,
+			Height:         resp.Height,
+			Events:         events,
+			BlockTimestamp: resp.BlockTimestamp,
+		}, messageIndex)
+		messageIndex++
+
+		return p.sendResponse(p.ctx, &response)
+	})
+}
@@ -0,0 +1,280 @@
+package data_providers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+
+	wsmodels "github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/state_stream/backend"
+	ssmock "github.com/onflow/flow-go/engine/access/state_stream/mock"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+	"github.com/onflow/flow-go/utils/unittest/generator"
+)
+
+// TestEventsDataProvider_InvalidArguments tests that a data provider is not created for invalid arguments.
+func TestEventsDataProvider_InvalidArguments(t *testing.T) {
+	api := ssmock.NewAPI(t)
+	chain := flow.Testnet.Chain()
+
+	tests := []struct {
+		name      string
+		arguments wsmodels.Arguments
+	}{
+		{
+			name:      "invalid event type",
+			arguments: wsmodels.Arguments{eventTypesArgument: []interface{}{"invalid"}},
+		},
+		{
+			name:      "event types is not a list",
+			arguments: wsmodels.Arguments{eventTypesArgument: "flow.AccountCreated"},
+		},
+		{
+			name:      "invalid address",
+			arguments: wsmodels.Arguments{addressesArgument: []interface{}{"invalid"}},
+		},
+		{
+			name:      "invalid heartbeat interval",
+			arguments: wsmodels.Arguments{heartbeatIntervalArgument: "0"},
+		},
+		{
+			name:      "invalid expression",
+			arguments: wsmodels.Arguments{expressionsArgument: []interface{}{"flow.AccountCreated where address >"}},
+		},
+		{
+			name:      "cursor with start block ID",
+			arguments: wsmodels.Arguments{cursorArgument: "0123", startBlockIDArgument: unittest.IdentifierFixture().String()},
+		},
+		{
+			name:      "cursor is not a string",
+			arguments: wsmodels.Arguments{cursorArgument: 1},
+		},
+		{
+			name:      "cursor with start block height",
+			arguments: wsmodels.Arguments{cursorArgument: "0123", startBlockHeightArgument: "10"},
+		},
+		{
+			name:      "cursor with create cursor",
+			arguments: wsmodels.Arguments{cursorArgument: "0123", createCursorArgument: "true"},
+		},
+		{
+			name:      "create cursor with start block ID",
+			arguments: wsmodels.Arguments{createCursorArgument: "true", startBlockIDArgument: unittest.IdentifierFixture().String()},
+		},
+		{
+			name:      "invalid create cursor",
+			arguments: wsmodels.Arguments{createCursorArgument: "yes"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provider, err := NewEventsDataProvider(
+				context.Background(),
+				unittest.Logger(),
+				api,
+				chain,
+				state_stream.DefaultEventFilterConfig,
+				subscription.DefaultHeartbeatInterval,
+				"sub",
+				EventsTopic,
+				test.arguments,
+				make(chan interface{}),
+			)
+			require.Error(t, err)
+			require.Nil(t, provider)
+		})
+	}
+}
+
+// TestEventsDataProvider_Heartbeat tests that responses without events are only sent once per heartbeat
+// interval, and that responses with events are always sent.
+func TestEventsDataProvider_Heartbeat(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	api := ssmock.NewAPI(t)
+	sub := subscription.NewSubscription(10)
+	api.On("SubscribeEventsFromStartHeight", mock.Anything, uint64(10), mock.Anything).Return(sub)
+
+	send := make(chan interface{}, 10)
+	provider, err := NewEventsDataProvider(
+		ctx,
+		unittest.Logger(),
+		api,
+		flow.Testnet.Chain(),
+		state_stream.DefaultEventFilterConfig,
+		subscription.DefaultHeartbeatInterval,
+		"sub",
+		EventsTopic,
+		wsmodels.Arguments{startBlockHeightArgument: "10", heartbeatIntervalArgument: "3"},
+		send,
+	)
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		done <- provider.Run()
+	}()
+
+	events := generator.EventGenerator(generator.WithEncoding(entities.EventEncodingVersion_CCF_V0))
+
+	// heights 10 and 11 are empty and skipped, 12 is sent as heartbeat, 13 has events
+	for height := uint64(10); height <= 13; height++ {
+		resp := &backend.EventsResponse{
+			BlockID: unittest.IdentifierFixture(),
+			Height:  height,
+		}
+		if height == 13 {
+			resp.Events = flow.EventsList{events.New(), events.New()}
+		}
+		require.NoError(t, sub.Send(ctx, resp, time.Second))
+	}
+
+	expected := []struct {
+		height string
+		events int
+		index  string
+	}{
+		{height: "12", events: 0, index: "0"},
+		{height: "13", events: 2, index: "1"},
+	}
+	for _, exp := range expected {
+		var msg interface{}
+		unittest.RequireReturnsBefore(t, func() { msg = <-send }, time.Second, "response was not sent")
+
+		response, ok := msg.(*wsmodels.BaseDataProvidersResponse)
+		require.True(t, ok)
+
+		payload, ok := response.Payload.(*wsmodels.EventResponse)
+		require.True(t, ok)
+		require.Equal(t, exp.height, payload.BlockHeight)
+		require.Len(t, payload.Events, exp.events)
+		require.Equal(t, exp.index, payload.MessageIndex)
+	}
+	require.Empty(t, send)
+
+	provider.Close()
+	unittest.RequireReturnsBefore(t, func() {
+		require.NoError(t, <-done)
+	}, time.Second, "data provider did not stop")
+}
+
+// TestEventsDataProvider_Cursor tests that subscriptions with a cursor use the durable event cursor,
+// and forward acknowledgements to the state stream API.
+func TestEventsDataProvider_Cursor(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cursor := "0123456789abcdef"
+
+	api := ssmock.NewAPI(t)
+	sub := subscription.NewSubscription(10)
+	api.On("CreateEventCursor", mock.Anything, uint64(10), mock.Anything).Return(cursor, nil).Once()
+	api.On("SubscribeEventsFromCursor", mock.Anything, cursor, mock.Anything).Return(sub)
+
+	position := flow.EventPosition{BlockHeight: 10, TransactionIndex: 1, EventIndex: 2}
+	api.On("AcknowledgeEvents", mock.Anything, cursor, position).Return(nil).Once()
+
+	provider, err := NewEventsDataProvider(
+		ctx,
+		unittest.Logger(),
+		api,
+		flow.Testnet.Chain(),
+		state_stream.DefaultEventFilterConfig,
+		subscription.DefaultHeartbeatInterval,
+		"sub",
+		EventsTopic,
+		wsmodels.Arguments{startBlockHeightArgument: "10", createCursorArgument: "true"},
+		make(chan interface{}),
+	)
+	require.NoError(t, err)
+	require.Equal(t, cursor, provider.Cursor())
+
+	err = provider.Acknowledge(ctx, position)
+	require.NoError(t, err)
+
+	t.Run("created cursor starts from latest", func(t *testing.T) {
+		api.On("CreateEventCursor", mock.Anything, uint64(0), mock.Anything).Return(cursor, nil).Once()
+
+		_, err := NewEventsDataProvider(
+			ctx,
+			unittest.Logger(),
+			api,
+			flow.Testnet.Chain(),
+			state_stream.DefaultEventFilterConfig,
+			subscription.DefaultHeartbeatInterval,
+			"sub",
+			EventsTopic,
+			wsmodels.Arguments{createCursorArgument: "true"},
+			make(chan interface{}),
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("cursor is not created", func(t *testing.T) {
+		api.On("CreateEventCursor", mock.Anything, uint64(0), mock.Anything).
+			Return("", status.Error(codes.ResourceExhausted, "maximum number of event cursors reached")).Once()
+
+		_, err := NewEventsDataProvider(
+			ctx,
+			unittest.Logger(),
+			api,
+			flow.Testnet.Chain(),
+			state_stream.DefaultEventFilterConfig,
+			subscription.DefaultHeartbeatInterval,
+			"sub",
+			EventsTopic,
+			wsmodels.Arguments{createCursorArgument: "true"},
+			make(chan interface{}),
+		)
+		require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("existing cursor is resumed", func(t *testing.T) {
+		_, err := NewEventsDataProvider(
+			ctx,
+			unittest.Logger(),
+			api,
+			flow.Testnet.Chain(),
+			state_stream.DefaultEventFilterConfig,
+			subscription.DefaultHeartbeatInterval,
+			"sub",
+			EventsTopic,
+			wsmodels.Arguments{cursorArgument: cursor},
+			make(chan interface{}),
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("acknowledgements require a cursor", func(t *testing.T) {
+		api.On("SubscribeEventsFromLatest", mock.Anything, mock.Anything).Return(sub).Once()
+
+		provider, err := NewEventsDataProvider(
+			ctx,
+			unittest.Logger(),
+			api,
+			flow.Testnet.Chain(),
+			state_stream.DefaultEventFilterConfig,
+			subscription.DefaultHeartbeatInterval,
+			"sub",
+			EventsTopic,
+			wsmodels.Arguments{},
+			make(chan interface{}),
+		)
+		require.NoError(t, err)
+
+		err = provider.Acknowledge(ctx, position)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
@@ -0,0 +1,141 @@
+package data_providers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+	wsmodels "github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// evmLogsArguments contains the arguments accepted by the EVM logs topic.
+type evmLogsArguments struct {
+	StartHeight       uint64
+	Filter            flow.EVMLogFilter
+	HeartbeatInterval uint64
+}
+
+// parseEVMLogsArguments validates and initializes the EVM logs arguments.
+// The subscription starts at the latest sealed block if no start height is provided.
+//
+// Expected errors during normal operations:
+//   - if the arguments are invalid.
+func parseEVMLogsArguments(arguments wsmodels.Arguments, defaultHeartbeatInterval uint64) (evmLogsArguments, error) {
+	var args evmLogsArguments
+
+	start, err := parseStartBlock(arguments)
+	if err != nil {
+		return args, err
+	}
+	if start.ID != flow.ZeroID {
+		return args, fmt.Errorf("'%s' is not supported, use '%s'", startBlockIDArgument, startBlockHeightArgument)
+	}
+	if !start.fromLatest() {
+		if start.Height == 0 {
+			return args, fmt.Errorf("'%s' must be greater than 0", startBlockHeightArgument)
+		}
+		args.StartHeight = start.Height
+	}
+
+	args.HeartbeatInterval, err = parseHeartbeatInterval(arguments, defaultHeartbeatInterval)
+	if err != nil {
+		return args, err
+	}
+
+	addresses, err := optionalStringArray(arguments, addressesArgument)
+	if err != nil {
+		return args, err
+	}
+
+	topics, err := optionalStringArrays(arguments, topicsArgument)
+	if err != nil {
+		return args, err
+	}
+
+	args.Filter, err = request.ParseEVMLogFilter(addresses, topics)
+	if err != nil {
+		return args, fmt.Errorf("invalid EVM log filter: %w", err)
+	}
+
+	return args, nil
+}
+
+// EVMLogsDataProvider is responsible for providing the logs emitted by EVM transactions.
+type EVMLogsDataProvider struct {
+	*baseDataProvider
+
+	ctx               context.Context
+	logger            zerolog.Logger
+	linkGenerator     models.LinkGenerator
+	heartbeatInterval uint64
+}
+
+var _ DataProvider = (*EVMLogsDataProvider)(nil)
+
+// NewEVMLogsDataProvider creates a new instance of EVMLogsDataProvider.
+//
+// Expected errors during normal operations:
+//   - if the arguments are invalid.
+func NewEVMLogsDataProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	accessApi access.API,
+	linkGenerator models.LinkGenerator,
+	defaultHeartbeatInterval uint64,
+	subscriptionID string,
+	topic string,
+	arguments wsmodels.Arguments,
+	send chan<- interface{},
+) (*EVMLogsDataProvider, error) {
+	args, err := parseEVMLogsArguments(arguments, defaultHeartbeatInterval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	sub := accessApi.SubscribeEVMLogsFromStartHeight(ctx, args.StartHeight, args.Filter)
+
+	return &EVMLogsDataProvider{
+		baseDataProvider:  newBaseDataProvider(subscriptionID, topic, arguments, cancel, send, sub),
+		ctx:               ctx,
+		logger:            logger.With().Str("component", "evm-logs-data-provider").Logger(),
+		linkGenerator:     linkGenerator,
+		heartbeatInterval: args.HeartbeatInterval,
+	}, nil
+}
+
+// Run starts processing the subscription for EVM logs and handles responses.
+//
+// Responses without logs are only forwarded once every heartbeat interval, so clients
+// can track the progress of the stream without receiving a message for every block.
+//
+// Expected errors during normal operations:
+//   - codes.Internal: if the subscription fails or produces an unexpected response.
+func (p *EVMLogsDataProvider) Run() error {
+	blocksSinceLastMessage := uint64(0)
+	messageIndex := uint64(0)
+
+	return run(p.ctx, p.baseDataProvider, func(resp *access.EVMLogsResponse) error {
+		if len(resp.Logs) == 0 {
+			blocksSinceLastMessage++
+			if blocksSinceLastMessage < p.heartbeatInterval {
+				return nil
+			}
+		}
+		blocksSinceLastMessage = 0
+
+		var response wsmodels.EVMLogsResponse
+		err := response.Build(resp, p.linkGenerator, messageIndex)
+		if err != nil {
+			return fmt.Errorf("could not build EVM logs response: %w", err)
+		}
+		messageIndex++
+
+		return p.sendResponse(p.ctx, &response)
+	})
+}
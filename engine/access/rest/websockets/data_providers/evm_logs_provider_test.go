@@ -0,0 +1,160 @@
+package data_providers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/access"
+	accessmock "github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	wsmodels "github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const testEVMTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+// TestEVMLogsDataProvider_InvalidArguments tests that a data provider is not created for invalid arguments.
+func TestEVMLogsDataProvider_InvalidArguments(t *testing.T) {
+	api := accessmock.NewAPI(t)
+
+	tests := []struct {
+		name      string
+		arguments wsmodels.Arguments
+	}{
+		{
+			name:      "start block ID",
+			arguments: wsmodels.Arguments{startBlockIDArgument: unittest.IdentifierFixture().String()},
+		},
+		{
+			name:      "invalid address",
+			arguments: wsmodels.Arguments{addressesArgument: []interface{}{"0x01"}},
+		},
+		{
+			name:      "topics is not a list of lists",
+			arguments: wsmodels.Arguments{topicsArgument: []interface{}{testEVMTopic}},
+		},
+		{
+			name:      "invalid topic",
+			arguments: wsmodels.Arguments{topicsArgument: []interface{}{[]interface{}{"0x01"}}},
+		},
+		{
+			name:      "too many topics",
+			arguments: wsmodels.Arguments{topicsArgument: []interface{}{nil, nil, nil, nil, nil}},
+		},
+		{
+			name:      "invalid heartbeat interval",
+			arguments: wsmodels.Arguments{heartbeatIntervalArgument: "0"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provider, err := NewEVMLogsDataProvider(
+				context.Background(),
+				unittest.Logger(),
+				api,
+				testLinkGenerator(),
+				subscription.DefaultHeartbeatInterval,
+				"sub",
+				EVMLogsTopic,
+				test.arguments,
+				make(chan interface{}),
+			)
+			require.Error(t, err)
+			require.Nil(t, provider)
+		})
+	}
+}
+
+// TestEVMLogsDataProvider_Heartbeat tests that the filter is passed to the subscription, that responses
+// without logs are only sent once per heartbeat interval, and that responses with logs are always sent.
+func TestEVMLogsDataProvider_Heartbeat(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	filter := flow.EVMLogFilter{
+		Topics: [][]gethCommon.Hash{nil, {gethCommon.HexToHash(testEVMTopic)}},
+	}
+
+	api := accessmock.NewAPI(t)
+	sub := subscription.NewSubscription(10)
+	api.On("SubscribeEVMLogsFromStartHeight", mock.Anything, uint64(10), filter).Return(sub)
+
+	send := make(chan interface{}, 10)
+	provider, err := NewEVMLogsDataProvider(
+		ctx,
+		unittest.Logger(),
+		api,
+		testLinkGenerator(),
+		subscription.DefaultHeartbeatInterval,
+		"sub",
+		EVMLogsTopic,
+		wsmodels.Arguments{
+			startBlockHeightArgument:  "10",
+			heartbeatIntervalArgument: "3",
+			topicsArgument:            []interface{}{nil, []interface{}{testEVMTopic}},
+		},
+		send,
+	)
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		done <- provider.Run()
+	}()
+
+	// heights 10 and 11 are empty and skipped, 12 is sent as heartbeat, 13 has logs
+	for height := uint64(10); height <= 13; height++ {
+		resp := &access.EVMLogsResponse{BlockHeight: height}
+		if height == 13 {
+			resp.Logs = []flow.EVMLog{
+				{BlockHeight: height, TransactionID: unittest.IdentifierFixture(), LogIndex: 0},
+				{BlockHeight: height, TransactionID: unittest.IdentifierFixture(), LogIndex: 1},
+			}
+		}
+		require.NoError(t, sub.Send(ctx, resp, time.Second))
+	}
+
+	expected := []struct {
+		height string
+		logs   int
+		index  string
+	}{
+		{height: "12", logs: 0, index: "0"},
+		{height: "13", logs: 2, index: "1"},
+	}
+	for _, exp := range expected {
+		var msg interface{}
+		unittest.RequireReturnsBefore(t, func() { msg = <-send }, time.Second, "response was not sent")
+
+		response, ok := msg.(*wsmodels.BaseDataProvidersResponse)
+		require.True(t, ok)
+
+		payload, ok := response.Payload.(*wsmodels.EVMLogsResponse)
+		require.True(t, ok)
+		require.Equal(t, exp.height, payload.BlockHeight)
+		require.Len(t, payload.Logs, exp.logs)
+		require.Equal(t, exp.index, payload.MessageIndex)
+	}
+	require.Empty(t, send)
+
+	provider.Close()
+	unittest.RequireReturnsBefore(t, func() {
+		require.NoError(t, <-done)
+	}, time.Second, "data provider did not stop")
+}
+
+// testLinkGenerator returns a link generator for the transaction links embedded in EVM logs.
+func testLinkGenerator() models.LinkGenerator {
+	router := mux.NewRouter()
+	router.HandleFunc("/v1/transactions/{id}", nil).Name("getTransactionByID")
+	return models.NewLinkGeneratorImpl(router)
+}
@@ -0,0 +1,122 @@
+package data_providers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	wsmodels "github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Constants defining various topic names used to specify different types of
+// data providers.
+const (
+	EventsTopic                        = "events"
+	AccountStatusesTopic               = "account_statuses"
+	BlocksTopic                        = "blocks"
+	BlockHeadersTopic                  = "block_headers"
+	BlockDigestsTopic                  = "block_digests"
+	SendAndGetTransactionStatusesTopic = "send_and_get_transaction_statuses"
+	EVMLogsTopic                       = "evm_logs"
+)
+
+// DataProviderFactory defines an interface for creating data providers
+// based on specified topics. The factory abstracts the creation process
+// and ensures consistent access to required APIs.
+type DataProviderFactory interface {
+	// NewDataProvider creates a new data provider based on the specified topic
+	// and configuration parameters. The data provider sends its messages to ch.
+	//
+	// Expected errors during normal operations:
+	//   - if the topic is not supported, or the arguments are invalid for the topic.
+	NewDataProvider(ctx context.Context, subscriptionID string, topic string, arguments wsmodels.Arguments, ch chan<- interface{}) (DataProvider, error)
+}
+
+var _ DataProviderFactory = (*DataProviderFactoryImpl)(nil)
+
+// DataProviderFactoryImpl is an implementation of the DataProviderFactory interface.
+// It is responsible for creating data providers based on the
+// requested topic. It manages access to logging and relevant APIs needed to retrieve data.
+type DataProviderFactoryImpl struct {
+	logger         zerolog.Logger
+	accessApi      access.API
+	stateStreamApi state_stream.API
+	linkGenerator  models.LinkGenerator
+	chain          flow.Chain
+
+	eventFilterConfig state_stream.EventFilterConfig
+	heartbeatInterval uint64
+}
+
+// NewDataProviderFactory creates a new DataProviderFactory.
+//
+// Parameters:
+//   - logger: Used for logging within the data providers.
+//   - accessApi: API for accessing data from the Flow Access API.
+//   - stateStreamApi: API for accessing data from the Flow state stream API. The events and account
+//     statuses topics are only supported if it is not nil.
+//   - linkGenerator: Used to generate the links embedded in block and transaction responses.
+//   - chain: The chain the node is running on.
+//   - eventFilterConfig: Limits applied to event and account status filters.
+//   - heartbeatInterval: Default number of blocks after which an empty response is sent for
+//     events, account statuses and EVM logs subscriptions.
+func NewDataProviderFactory(
+	logger zerolog.Logger,
+	accessApi access.API,
+	stateStreamApi state_stream.API,
+	linkGenerator models.LinkGenerator,
+	chain flow.Chain,
+	eventFilterConfig state_stream.EventFilterConfig,
+	heartbeatInterval uint64,
+) *DataProviderFactoryImpl {
+	return &DataProviderFactoryImpl{
+		logger:            logger,
+		accessApi:         accessApi,
+		stateStreamApi:    stateStreamApi,
+		linkGenerator:     linkGenerator,
+		chain:             chain,
+		eventFilterConfig: eventFilterConfig,
+		heartbeatInterval: heartbeatInterval,
+	}
+}
+
+// NewDataProvider creates a new data provider based on the specified topic
+// and configuration parameters.
+//
+// Expected errors during normal operations:
+//   - if the topic is not supported, or the arguments are invalid for the topic.
+func (s *DataProviderFactoryImpl) NewDataProvider(
+	ctx context.Context,
+	subscriptionID string,
+	topic string,
+	arguments wsmodels.Arguments,
+	ch chan<- interface{},
+) (DataProvider, error) {
+	switch topic {
+	case BlocksTopic:
+		return NewBlocksDataProvider(ctx, s.logger, s.accessApi, s.linkGenerator, subscriptionID, topic, arguments, ch)
+	case BlockHeadersTopic:
+		return NewBlockHeadersDataProvider(ctx, s.logger, s.accessApi, subscriptionID, topic, arguments, ch)
+	case BlockDigestsTopic:
+		return NewBlockDigestsDataProvider(ctx, s.logger, s.accessApi, subscriptionID, topic, arguments, ch)
+	case SendAndGetTransactionStatusesTopic:
+		return NewSendAndGetTransactionStatusesDataProvider(ctx, s.logger, s.accessApi, s.linkGenerator, s.chain, subscriptionID, topic, arguments, ch)
+	case EVMLogsTopic:
+		return NewEVMLogsDataProvider(ctx, s.logger, s.accessApi, s.linkGenerator, s.heartbeatInterval, subscriptionID, topic, arguments, ch)
+	case EventsTopic, AccountStatusesTopic:
+		if s.stateStreamApi == nil {
+			return nil, fmt.Errorf("topic %s is not supported: state stream API is disabled", topic)
+		}
+		if topic == EventsTopic {
+			return NewEventsDataProvider(ctx, s.logger, s.stateStreamApi, s.chain, s.eventFilterConfig, s.heartbeatInterval, subscriptionID, topic, arguments, ch)
+		}
+		return NewAccountStatusesDataProvider(ctx, s.logger, s.stateStreamApi, s.chain, s.eventFilterConfig, s.heartbeatInterval, subscriptionID, topic, arguments, ch)
+	default:
+		return nil, fmt.Errorf("unsupported topic \"%s\"", topic)
+	}
+}
@@ -0,0 +1,108 @@
+package data_providers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	accessmock "github.com/onflow/flow-go/access/mock"
+	wsmodels "github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	ssmock "github.com/onflow/flow-go/engine/access/state_stream/mock"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestDataProviderFactory tests that the factory creates the data provider matching the requested topic.
+func TestDataProviderFactory(t *testing.T) {
+	sub := subscription.NewFailedSubscription(fmt.Errorf("not used"), "not used")
+
+	accessApi := accessmock.NewAPI(t)
+	accessApi.On("SubscribeBlocksFromLatest", mock.Anything, flow.BlockStatusSealed).Return(sub).Maybe()
+	accessApi.On("SubscribeBlockHeadersFromLatest", mock.Anything, flow.BlockStatusSealed).Return(sub).Maybe()
+	accessApi.On("SubscribeBlockDigestsFromLatest", mock.Anything, flow.BlockStatusSealed).Return(sub).Maybe()
+	accessApi.On("SubscribeEVMLogsFromStartHeight", mock.Anything, uint64(0), mock.Anything).Return(sub).Maybe()
+
+	stateStreamApi := ssmock.NewAPI(t)
+	stateStreamApi.On("SubscribeEventsFromLatest", mock.Anything, mock.Anything).Return(sub).Maybe()
+	stateStreamApi.On("SubscribeAccountStatusesFromLatestBlock", mock.Anything, mock.Anything).Return(sub).Maybe()
+
+	newFactory := func(stateStreamApi state_stream.API) *DataProviderFactoryImpl {
+		return NewDataProviderFactory(
+			unittest.Logger(),
+			accessApi,
+			stateStreamApi,
+			nil,
+			flow.Testnet.Chain(),
+			state_stream.DefaultEventFilterConfig,
+			subscription.DefaultHeartbeatInterval,
+		)
+	}
+	factory := newFactory(stateStreamApi)
+
+	tests := []struct {
+		topic     string
+		arguments wsmodels.Arguments
+		expected  interface{}
+	}{
+		{
+			topic:     BlocksTopic,
+			arguments: wsmodels.Arguments{blockStatusArgument: "sealed"},
+			expected:  &BlocksDataProvider{},
+		},
+		{
+			topic:     BlockHeadersTopic,
+			arguments: wsmodels.Arguments{blockStatusArgument: "sealed"},
+			expected:  &BlockHeadersDataProvider{},
+		},
+		{
+			topic:     BlockDigestsTopic,
+			arguments: wsmodels.Arguments{blockStatusArgument: "sealed"},
+			expected:  &BlockDigestsDataProvider{},
+		},
+		{
+			topic:     EventsTopic,
+			arguments: wsmodels.Arguments{},
+			expected:  &EventsDataProvider{},
+		},
+		{
+			topic:     AccountStatusesTopic,
+			arguments: wsmodels.Arguments{},
+			expected:  &AccountStatusesDataProvider{},
+		},
+		{
+			topic:     EVMLogsTopic,
+			arguments: wsmodels.Arguments{},
+			expected:  &EVMLogsDataProvider{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.topic, func(t *testing.T) {
+			provider, err := factory.NewDataProvider(context.Background(), "sub", test.topic, test.arguments, make(chan interface{}))
+			require.NoError(t, err)
+			require.IsType(t, test.expected, provider)
+			require.Equal(t, test.topic, provider.Topic())
+			provider.Close()
+		})
+	}
+
+	t.Run("unsupported topic", func(t *testing.T) {
+		provider, err := factory.NewDataProvider(context.Background(), "sub", "unknown", wsmodels.Arguments{}, make(chan interface{}))
+		require.Error(t, err)
+		require.Nil(t, provider)
+	})
+
+	t.Run("state stream API disabled", func(t *testing.T) {
+		factory := newFactory(nil)
+		for _, topic := range []string{EventsTopic, AccountStatusesTopic} {
+			provider, err := factory.NewDataProvider(context.Background(), "sub", topic, wsmodels.Arguments{}, make(chan interface{}))
+			require.Error(t, err)
+			require.Nil(t, provider)
+		}
+	})
+}
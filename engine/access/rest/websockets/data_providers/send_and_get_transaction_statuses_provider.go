@@ -0,0 +1,113 @@
+package data_providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+	wsmodels "github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// parseSendTransactionArguments parses the arguments of the send and get transaction statuses topic.
+// The arguments are the fields of a signed transaction, using the same format as the body of the
+// REST create transaction endpoint.
+//
+// Expected errors during normal operations:
+//   - if the arguments are not a valid transaction.
+func parseSendTransactionArguments(arguments wsmodels.Arguments, chain flow.Chain) (flow.TransactionBody, error) {
+	raw, err := json.Marshal(arguments)
+	if err != nil {
+		return flow.TransactionBody{}, fmt.Errorf("could not encode transaction arguments: %w", err)
+	}
+
+	var tx request.Transaction
+	err = tx.Parse(bytes.NewReader(raw), chain)
+	if err != nil {
+		return flow.TransactionBody{}, err
+	}
+
+	return tx.Flow(), nil
+}
+
+// SendAndGetTransactionStatusesDataProvider is responsible for sending a transaction and providing
+// the statuses of the sent transaction.
+type SendAndGetTransactionStatusesDataProvider struct {
+	*baseDataProvider
+
+	ctx           context.Context
+	logger        zerolog.Logger
+	linkGenerator models.LinkGenerator
+}
+
+var _ DataProvider = (*SendAndGetTransactionStatusesDataProvider)(nil)
+
+// NewSendAndGetTransactionStatusesDataProvider creates a new instance of SendAndGetTransactionStatusesDataProvider.
+// The transaction is submitted before the data provider is returned.
+//
+// Expected errors during normal operations:
+//   - if the arguments are invalid.
+//   - if the transaction could not be submitted.
+func NewSendAndGetTransactionStatusesDataProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	api access.API,
+	linkGenerator models.LinkGenerator,
+	chain flow.Chain,
+	subscriptionID string,
+	topic string,
+	arguments wsmodels.Arguments,
+	send chan<- interface{},
+) (*SendAndGetTransactionStatusesDataProvider, error) {
+	tx, err := parseSendTransactionArguments(arguments, chain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	err = api.SendTransaction(ctx, &tx)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("could not send transaction: %w", err)
+	}
+
+	sub := api.SubscribeTransactionStatuses(ctx, &tx, entities.EventEncodingVersion_JSON_CDC_V0)
+
+	return &SendAndGetTransactionStatusesDataProvider{
+		baseDataProvider: newBaseDataProvider(subscriptionID, topic, arguments, cancel, send, sub),
+		ctx:              ctx,
+		logger:           logger.With().Str("component", "send-and-get-transaction-statuses-data-provider").Logger(),
+		linkGenerator:    linkGenerator,
+	}, nil
+}
+
+// Run starts processing the subscription for transaction statuses and handles responses.
+//
+// Expected errors during normal operations:
+//   - codes.Internal: if the subscription fails or produces an unexpected response.
+func (p *SendAndGetTransactionStatusesDataProvider) Run() error {
+	messageIndex := uint64(0)
+
+	return run(p.ctx, p.baseDataProvider, func(txResults []*access.TransactionResult) error {
+		for _, txResult := range txResults {
+			var response wsmodels.TransactionStatusesResponse
+			response.Build(txResult, p.linkGenerator, messageIndex)
+			messageIndex++
+
+			err := p.sendResponse(p.ctx, &response)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
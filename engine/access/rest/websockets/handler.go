@@ -0,0 +1,79 @@
+package websockets
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	dp "github.com/onflow/flow-go/engine/access/rest/websockets/data_providers"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Handler upgrades HTTP requests to websocket connections and serves each connection
+// with its own Controller.
+type Handler struct {
+	*common.HttpHandler
+
+	logger              zerolog.Logger
+	websocketConfig     Config
+	dataProviderFactory dp.DataProviderFactory
+	activeConnections   *atomic.Uint64
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// NewWebSocketHandler creates a new websocket stream API handler.
+func NewWebSocketHandler(
+	logger zerolog.Logger,
+	config Config,
+	chain flow.Chain,
+	dataProviderFactory dp.DataProviderFactory,
+	maxRequestSize int64,
+) *Handler {
+	return &Handler{
+		HttpHandler:         common.NewHttpHandler(logger, chain, maxRequestSize),
+		logger:              logger,
+		websocketConfig:     config,
+		dataProviderFactory: dataProviderFactory,
+		activeConnections:   atomic.NewUint64(0),
+	}
+}
+
+// ServeHTTP upgrades the request to a websocket connection and serves it until the connection is closed.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger.With().Str("websocket_url", r.URL.String()).Logger()
+
+	err := h.HttpHandler.VerifyRequest(w, r)
+	if err != nil {
+		// VerifyRequest sets the response error before returning
+		return
+	}
+
+	if h.activeConnections.Inc() > h.websocketConfig.MaxConnections {
+		h.activeConnections.Dec()
+		err := fmt.Errorf("maximum number of connections reached: %d", h.websocketConfig.MaxConnections)
+		h.HttpHandler.ErrorHandler(w, common.NewRestError(http.StatusServiceUnavailable, err.Error(), err), logger)
+		return
+	}
+	defer h.activeConnections.Dec()
+
+	upgrader := websocket.Upgrader{
+		// allow all origins by default, operators can override using a proxy
+		CheckOrigin: func(r *http.Request) bool {
+			return true
+		},
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader already replied to the client with an HTTP error
+		logger.Debug().Err(err).Msg("websocket upgrade failed")
+		return
+	}
+
+	controller := NewWebSocketController(logger, h.websocketConfig, conn, h.dataProviderFactory)
+	controller.HandleConnection(r.Context())
+}
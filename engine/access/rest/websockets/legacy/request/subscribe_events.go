@@ -15,6 +15,7 @@ const eventTypesQuery = "event_types"
 const addressesQuery = "addresses"
 const contractsQuery = "contracts"
 const heartbeatIntervalQuery = "heartbeat_interval"
+const expressionsQuery = "expressions"
 
 type SubscribeEvents struct {
 	StartBlockID flow.Identifier
@@ -23,6 +24,8 @@ type SubscribeEvents struct {
 	EventTypes []string
 	Addresses  []string
 	Contracts  []string
+	// Expressions are event filter expressions, see state_stream.EventExpression.
+	Expressions []string
 
 	HeartbeatInterval uint64
 }
@@ -45,6 +48,8 @@ func (g *SubscribeEvents) Build(r *common.Request) error {
 		r.GetQueryParams(addressesQuery),
 		r.GetQueryParams(contractsQuery),
 		r.GetQueryParam(heartbeatIntervalQuery),
+		// expressions may contain commas, so each expression is passed as a separate query parameter
+		r.URL.Query()[expressionsQuery],
 	)
 }
 
@@ -55,6 +60,7 @@ func (g *SubscribeEvents) Parse(
 	rawAddresses []string,
 	rawContracts []string,
 	rawHeartbeatInterval string,
+	rawExpressions []string,
 ) error {
 	var startBlockID request.ID
 	err := startBlockID.Parse(rawStartBlockID)
@@ -89,6 +95,7 @@ func (g *SubscribeEvents) Parse(
 	g.EventTypes = eventTypes.Flow()
 	g.Addresses = rawAddresses
 	g.Contracts = rawContracts
+	g.Expressions = rawExpressions
 
 	// parse heartbeat interval
 	if rawHeartbeatInterval == "" {
@@ -21,12 +21,13 @@ func SubscribeEvents(
 		return nil, common.NewBadRequestError(err)
 	}
 	// Retrieve the filter parameters from the request, if provided
-	filter, err := state_stream.NewEventFilter(
+	filter, err := state_stream.NewEventFilterWithExpressions(
 		wsController.EventFilterConfig,
 		r.Chain,
 		req.EventTypes,
 		req.Addresses,
 		req.Contracts,
+		req.Expressions,
 	)
 	if err != nil {
 		return nil, common.NewBadRequestError(err)
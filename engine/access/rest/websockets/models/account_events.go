@@ -0,0 +1,31 @@
+package models
+
+import (
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/engine/access/state_stream/backend"
+)
+
+// AccountStatusesResponse is the payload of a message sent for an account statuses subscription.
+type AccountStatusesResponse struct {
+	BlockId       string                   `json:"block_id"`
+	Height        string                   `json:"height"`
+	AccountEvents map[string]models.Events `json:"account_events"`
+	MessageIndex  string                   `json:"message_index"`
+}
+
+// Build populates the response from an account statuses backend response. The events payload
+// must already be JSON-CDC encoded.
+func (a *AccountStatusesResponse) Build(resp *backend.AccountStatusesResponse, index uint64) {
+	accountEvents := make(map[string]models.Events, len(resp.AccountEvents))
+	for address, events := range resp.AccountEvents {
+		var converted models.Events
+		converted.Build(events)
+		accountEvents[address] = converted
+	}
+
+	a.BlockId = resp.BlockID.String()
+	a.Height = util.FromUint(resp.Height)
+	a.AccountEvents = accountEvents
+	a.MessageIndex = util.FromUint(index)
+}
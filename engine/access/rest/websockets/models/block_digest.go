@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// BlockDigest is a lightweight representation of a block.
+type BlockDigest struct {
+	BlockId   string    `json:"block_id"`
+	Height    string    `json:"height"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (b *BlockDigest) Build(block *flow.BlockDigest) {
+	b.BlockId = block.ID().String()
+	b.Height = util.FromUint(block.Height)
+	b.Timestamp = block.Timestamp
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/engine/access/state_stream/backend"
+)
+
+// EventResponse is the payload of a message sent for an events subscription.
+type EventResponse struct {
+	BlockId        string        `json:"block_id"`
+	BlockHeight    string        `json:"block_height"`
+	BlockTimestamp time.Time     `json:"block_timestamp"`
+	Events         models.Events `json:"events"`
+	MessageIndex   string        `json:"message_index"`
+}
+
+// Build populates the response from an events backend response. The events payload
+// must already be JSON-CDC encoded.
+func (e *EventResponse) Build(resp *backend.EventsResponse, index uint64) {
+	var events models.Events
+	events.Build(resp.Events)
+
+	e.BlockId = resp.BlockID.String()
+	e.BlockHeight = util.FromUint(resp.Height)
+	e.BlockTimestamp = resp.BlockTimestamp
+	e.Events = events
+	e.MessageIndex = util.FromUint(index)
+}
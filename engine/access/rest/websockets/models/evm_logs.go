@@ -0,0 +1,31 @@
+package models
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+)
+
+// EVMLogsResponse is the payload of a message sent for an EVM logs subscription.
+type EVMLogsResponse struct {
+	BlockHeight  string          `json:"block_height"`
+	Logs         []models.EvmLog `json:"logs"`
+	MessageIndex string          `json:"message_index"`
+}
+
+// Build populates the response from an EVM logs backend response.
+func (e *EVMLogsResponse) Build(resp *access.EVMLogsResponse, link models.LinkGenerator, index uint64) error {
+	logs := make([]models.EvmLog, len(resp.Logs))
+	for i, log := range resp.Logs {
+		err := logs[i].Build(log, link)
+		if err != nil {
+			return err
+		}
+	}
+
+	e.BlockHeight = util.FromUint(resp.BlockHeight)
+	e.Logs = logs
+	e.MessageIndex = util.FromUint(index)
+
+	return nil
+}
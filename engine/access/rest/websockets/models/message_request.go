@@ -0,0 +1,48 @@
+package models
+
+// Actions supported by the websocket stream API.
+const (
+	SubscribeAction         = "subscribe"
+	UnsubscribeAction       = "unsubscribe"
+	ListSubscriptionsAction = "list_subscriptions"
+	AckAction               = "ack"
+)
+
+// Arguments represents the topic specific arguments of a subscribe request.
+type Arguments map[string]interface{}
+
+// BaseMessageRequest holds the fields common to all client messages.
+type BaseMessageRequest struct {
+	// SubscriptionID is the client chosen identifier of the subscription. It is optional for
+	// subscribe requests, in which case the server generates one.
+	SubscriptionID string `json:"subscription_id,omitempty"`
+	// Action is the type of the request: subscribe, unsubscribe, list_subscriptions or ack.
+	Action string `json:"action"`
+}
+
+// SubscribeMessageRequest represents a request to subscribe to a topic.
+type SubscribeMessageRequest struct {
+	BaseMessageRequest
+	Topic     string    `json:"topic"`
+	Arguments Arguments `json:"arguments"`
+}
+
+// UnsubscribeMessageRequest represents a request to cancel an active subscription.
+type UnsubscribeMessageRequest struct {
+	BaseMessageRequest
+}
+
+// ListSubscriptionsMessageRequest represents a request to list all active subscriptions of the connection.
+type ListSubscriptionsMessageRequest struct {
+	BaseMessageRequest
+}
+
+// AckMessageRequest represents a request to acknowledge the events received by a subscription
+// using a durable event cursor. A reconnecting client resumes right after the last acknowledged event.
+// If the transaction and event indexes are omitted, all events of the block are acknowledged.
+type AckMessageRequest struct {
+	BaseMessageRequest
+	BlockHeight      string `json:"block_height"`
+	TransactionIndex string `json:"transaction_index,omitempty"`
+	EventIndex       string `json:"event_index,omitempty"`
+}
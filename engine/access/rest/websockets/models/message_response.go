@@ -0,0 +1,42 @@
+package models
+
+// BaseMessageResponse is the response sent to the client for every request it made.
+// On success, Error is nil. On failure, the connection remains open and Error
+// describes the problem.
+type BaseMessageResponse struct {
+	SubscriptionID string        `json:"subscription_id,omitempty"`
+	Action         string        `json:"action,omitempty"`
+	Error          *ErrorMessage `json:"error,omitempty"`
+	// Cursor is the name of the durable event cursor used by a new subscription, if any. The name
+	// grants access to the cursor, so the client must keep it secret.
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// ErrorMessage describes an error that occurred while handling a client message or
+// while streaming data for a subscription.
+type ErrorMessage struct {
+	// Code is an HTTP status code describing the error class.
+	Code int `json:"code"`
+	// Message is a human readable description of the error.
+	Message string `json:"message"`
+}
+
+// ListSubscriptionsMessageResponse is the response to a list_subscriptions request.
+type ListSubscriptionsMessageResponse struct {
+	BaseMessageResponse
+	Subscriptions []*SubscriptionEntry `json:"subscriptions"`
+}
+
+// SubscriptionEntry describes an active subscription.
+type SubscriptionEntry struct {
+	SubscriptionID string    `json:"subscription_id"`
+	Topic          string    `json:"topic"`
+	Arguments      Arguments `json:"arguments,omitempty"`
+}
+
+// BaseDataProvidersResponse is the envelope of every data message sent for a subscription.
+type BaseDataProvidersResponse struct {
+	SubscriptionID string      `json:"subscription_id"`
+	Topic          string      `json:"topic"`
+	Payload        interface{} `json:"payload"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+)
+
+// TransactionStatusesResponse is the payload of a message sent for a transaction statuses subscription.
+type TransactionStatusesResponse struct {
+	TransactionResult *models.TransactionResult `json:"transaction_result"`
+	MessageIndex      string                    `json:"message_index"`
+}
+
+func (t *TransactionStatusesResponse) Build(txResult *access.TransactionResult, link models.LinkGenerator, index uint64) {
+	var result models.TransactionResult
+	result.Build(txResult, txResult.TransactionID, link)
+
+	t.TransactionResult = &result
+	t.MessageIndex = util.FromUint(index)
+}
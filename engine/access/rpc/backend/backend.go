@@ -20,6 +20,7 @@ import (
 	commonrpc "github.com/onflow/flow-go/engine/common/rpc"
 	"github.com/onflow/flow-go/engine/common/version"
 	"github.com/onflow/flow-go/fvm/blueprints"
+	"github.com/onflow/flow-go/fvm/evm/debug"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/counters"
@@ -53,6 +54,13 @@ const DefaultConnectionPoolSize = 250
 // Block details related calls are handled by backendBlockDetails.
 // Event related calls are handled by backendEvents.
 // Account related calls are handled by backendAccounts.
+// Account transaction history calls are handled by backendAccountTransactions.
+// EVM log calls are handled by backendEVMLogs.
+// Transaction simulation and fee estimation calls are handled by backendTransactionSimulations.
+// Account storage diff calls are handled by backendAccountStorageDiffs.
+// Contract impact calls are handled by backendContractImpacts.
+// EVM debug tracing calls are handled by backendEVMTraces.
+// Account state proof calls are handled by backendAccountStateProofs.
 //
 // All remaining calls are handled by the base Backend in this file.
 type Backend struct {
@@ -62,6 +70,13 @@ type Backend struct {
 	backendBlockHeaders
 	backendBlockDetails
 	backendAccounts
+	backendAccountTransactions
+	backendEVMLogs
+	backendTransactionSimulations
+	backendAccountStorageDiffs
+	backendContractImpacts
+	backendEVMTraces
+	backendAccountStateProofs
 	backendExecutionResults
 	backendNetwork
 	backendSubscribeBlocks
@@ -108,10 +123,16 @@ type Params struct {
 	EventsIndex                *index.EventsIndex
 	TxResultQueryMode          IndexQueryMode
 	TxResultsIndex             *index.TransactionResultsIndex
+	AccountTransactionsIndex   *index.AccountTransactionsIndex
+	EVMLogsIndex               *index.EVMLogsIndex
+	RegistersAsyncStore        *execution.RegistersAsyncStore
+	EVMTracesStore             *debug.LocalStore
 	LastFullBlockHeight        *counters.PersistentStrictMonotonicCounter
 	IndexReporter              state_synchronization.IndexReporter
 	VersionControl             *version.VersionControl
 	ExecNodeIdentitiesProvider *commonrpc.ExecutionNodeIdentitiesProvider
+	Seals                      storage.Seals
+	RegisterProofRequester     RegisterProofRequester
 }
 
 var _ TransactionErrorMessage = (*Backend)(nil)
@@ -199,6 +220,53 @@ func New(params Params) (*Backend, error) {
 			scriptExecMode:             params.ScriptExecutionMode,
 			execNodeIdentitiesProvider: params.ExecNodeIdentitiesProvider,
 		},
+		backendAccountTransactions: backendAccountTransactions{
+			log:                      params.Log,
+			accountTransactionsIndex: params.AccountTransactionsIndex,
+			maxLimit:                 MaxAccountTransactionsLimit,
+		},
+		backendEVMLogs: backendEVMLogs{
+			log:                 params.Log,
+			evmLogsIndex:        params.EVMLogsIndex,
+			subscriptionHandler: params.SubscriptionHandler,
+			blockTracker:        params.BlockTracker,
+			maxLimit:            MaxEVMLogsLimit,
+		},
+		backendTransactionSimulations: backendTransactionSimulations{
+			log:            params.Log,
+			headers:        params.Headers,
+			state:          params.State,
+			scriptExecutor: params.ScriptExecutor,
+			scriptExecMode: params.ScriptExecutionMode,
+		},
+		backendAccountStorageDiffs: backendAccountStorageDiffs{
+			log:       params.Log,
+			registers: params.RegistersAsyncStore,
+			maxLimit:  MaxAccountStorageDiffLimit,
+		},
+		backendContractImpacts: backendContractImpacts{
+			log:         params.Log,
+			registers:   params.RegistersAsyncStore,
+			maxAccounts: MaxContractImpactAccounts,
+		},
+		backendEVMTraces: backendEVMTraces{
+			log:         params.Log,
+			chainID:     params.ChainID,
+			headers:     params.Headers,
+			eventsIndex: params.EventsIndex,
+			registers:   params.RegistersAsyncStore,
+			traces:      params.EVMTracesStore,
+		},
+		backendAccountStateProofs: backendAccountStateProofs{
+			log:                        params.Log,
+			state:                      params.State,
+			headers:                    params.Headers,
+			seals:                      params.Seals,
+			execNodeIdentitiesProvider: params.ExecNodeIdentitiesProvider,
+			requester:                  params.RegisterProofRequester,
+			requestTimeout:             DefaultRegisterProofRequestTimeout,
+			maxRegisters:               MaxAccountStateProofRegisters,
+		},
 		backendExecutionResults: backendExecutionResults{
 			executionResults: params.ExecutionResults,
 		},
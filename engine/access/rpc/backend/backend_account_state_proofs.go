@@ -0,0 +1,174 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commonrpc "github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/ledger/common/proof"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+const (
+	// MaxAccountStateProofRegisters is the maximum number of registers proven by a single account state proof.
+	MaxAccountStateProofRegisters = 100
+	// DefaultRegisterProofRequestTimeout is the default time to wait for the register proof of an execution node.
+	DefaultRegisterProofRequestTimeout = 5 * time.Second
+)
+
+// RegisterProofRequester requests batch proofs of registers from execution nodes.
+type RegisterProofRequester interface {
+	// RequestRegisterProof requests the batch proof of the given registers at the state commitment from the
+	// execution node. The returned proof is not verified.
+	RequestRegisterProof(
+		ctx context.Context,
+		executorID flow.Identifier,
+		blockID flow.Identifier,
+		commit flow.StateCommitment,
+		registerIDs []flow.RegisterID,
+	) ([]byte, error)
+}
+
+type backendAccountStateProofs struct {
+	log                        zerolog.Logger
+	state                      protocol.State
+	headers                    storage.Headers
+	seals                      storage.Seals
+	execNodeIdentitiesProvider *commonrpc.ExecutionNodeIdentitiesProvider
+	requester                  RegisterProofRequester
+	requestTimeout             time.Duration
+	maxRegisters               int
+}
+
+// GetAccountStateProof returns the values of the given registers of the account at the given sealed
+// block height, along with a batch proof of their values in the state commitment sealed for the block.
+// If no keys are given, the account status register is proven. The proof is requested from the execution
+// nodes which executed the block, and verified before it's returned.
+//
+// Expected errors during normal operations:
+// - codes.InvalidArgument: if too many keys are given.
+// - codes.OutOfRange: if the block at the height is not sealed.
+// - codes.NotFound: if the block at the height is not found.
+// - codes.Unavailable: if no execution node provided a valid proof.
+// - codes.FailedPrecondition: if register proofs are not available on this node.
+func (b *backendAccountStateProofs) GetAccountStateProof(
+	ctx context.Context,
+	address flow.Address,
+	keys []string,
+	height uint64,
+) (*flow.AccountStateProof, error) {
+	if b.requester == nil {
+		return nil, status.Error(codes.FailedPrecondition, "register proofs are not available on this node")
+	}
+
+	if len(keys) > b.maxRegisters {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d keys can be proven at once", b.maxRegisters)
+	}
+	if len(keys) == 0 {
+		keys = []string{flow.AccountStatusKey}
+	}
+
+	seen := make(map[string]struct{}, len(keys))
+	registerIDs := make([]flow.RegisterID, 0, len(keys))
+	for _, key := range keys {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		registerIDs = append(registerIDs, flow.NewRegisterID(address, key))
+	}
+
+	sealed, err := b.state.Sealed().Head()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not get latest sealed header: %v", err)
+	}
+	if height > sealed.Height {
+		return nil, status.Errorf(codes.OutOfRange, "block height %d is not sealed yet, latest sealed height is %d", height, sealed.Height)
+	}
+
+	header, err := b.headers.ByHeight(height)
+	if err != nil {
+		return nil, commonrpc.ConvertStorageError(err)
+	}
+	blockID := header.ID()
+
+	seal, err := b.seals.FinalizedSealForBlock(blockID)
+	if err != nil {
+		return nil, commonrpc.ConvertStorageError(err)
+	}
+	commit := seal.FinalState
+
+	executors, err := b.execNodeIdentitiesProvider.ExecutionNodesForBlockID(ctx, blockID)
+	if err != nil {
+		return nil, commonrpc.ConvertError(err, "failed to find execution nodes for block", codes.Internal)
+	}
+
+	lg := b.log.With().
+		Hex("block_id", blockID[:]).
+		Hex("state_commitment", commit[:]).
+		Logger()
+
+	for _, executor := range executors {
+		encodedProof, values, err := b.requestProof(ctx, executor.NodeID, blockID, commit, registerIDs)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, commonrpc.ConvertError(ctx.Err(), "failed to get register proof", codes.Internal)
+			}
+			lg.Info().Err(err).Hex("executor_id", executor.NodeID[:]).Msg("could not get register proof from execution node")
+			continue
+		}
+
+		registers := make(flow.RegisterEntries, len(registerIDs))
+		for i, id := range registerIDs {
+			registers[i] = flow.RegisterEntry{Key: id, Value: values[i]}
+		}
+
+		return &flow.AccountStateProof{
+			Address:         address,
+			BlockID:         blockID,
+			BlockHeight:     height,
+			StateCommitment: commit,
+			Registers:       registers,
+			Proof:           encodedProof,
+		}, nil
+	}
+
+	return nil, status.Errorf(codes.Unavailable, "no execution node provided a valid register proof for block %v", blockID)
+}
+
+// requestProof requests the register proof from the execution node, and verifies it against the state commitment.
+func (b *backendAccountStateProofs) requestProof(
+	ctx context.Context,
+	executorID flow.Identifier,
+	blockID flow.Identifier,
+	commit flow.StateCommitment,
+	registerIDs []flow.RegisterID,
+) ([]byte, []flow.RegisterValue, error) {
+	ctx, cancel := context.WithTimeout(ctx, b.requestTimeout)
+	defer cancel()
+
+	encodedProof, err := b.requester.RequestRegisterProof(ctx, executorID, blockID, commit, registerIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values, err := proof.VerifyRegisterBatchProof(encodedProof, commit, registerIDs)
+	if err != nil {
+		if errors.Is(err, proof.ErrInvalidRegisterProof) {
+			b.log.Warn().
+				Err(err).
+				Hex("executor_id", executorID[:]).
+				Msg("execution node provided an invalid register proof")
+		}
+		return nil, nil, err
+	}
+
+	return encodedProof, values, nil
+}
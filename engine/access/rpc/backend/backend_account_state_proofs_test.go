@@ -0,0 +1,301 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commonrpc "github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// fakeRegisterProofRequester serves register proofs from a ledger on behalf of execution nodes,
+// or fails with the configured error for an execution node.
+type fakeRegisterProofRequester struct {
+	ledger   *complete.Ledger
+	failures map[flow.Identifier]error
+	requests []flow.Identifier
+}
+
+func (r *fakeRegisterProofRequester) RequestRegisterProof(
+	_ context.Context,
+	executorID flow.Identifier,
+	_ flow.Identifier,
+	commit flow.StateCommitment,
+	registerIDs []flow.RegisterID,
+) ([]byte, error) {
+	r.requests = append(r.requests, executorID)
+	if err, ok := r.failures[executorID]; ok {
+		return nil, err
+	}
+
+	keys := make([]ledger.Key, len(registerIDs))
+	for i, id := range registerIDs {
+		keys[i] = convert.RegisterIDToLedgerKey(id)
+	}
+	query, err := ledger.NewQuery(ledger.State(commit), keys)
+	if err != nil {
+		return nil, err
+	}
+	return r.ledger.Prove(query)
+}
+
+type BackendAccountStateProofsSuite struct {
+	suite.Suite
+
+	log            zerolog.Logger
+	state          *protocol.State
+	sealedSnapshot *protocol.Snapshot
+	finalSnapshot  *protocol.Snapshot
+	params         *protocol.Params
+	headers        *storagemock.Headers
+	seals          *storagemock.Seals
+	receipts       *storagemock.ExecutionReceipts
+
+	ledger    *complete.Ledger
+	compactor *fixtures.NoopCompactor
+	requester *fakeRegisterProofRequester
+
+	executionNodes flow.IdentityList
+	block          *flow.Block
+	commit         flow.StateCommitment
+	address        flow.Address
+	registers      flow.RegisterEntries
+}
+
+func TestBackendAccountStateProofsSuite(t *testing.T) {
+	suite.Run(t, new(BackendAccountStateProofsSuite))
+}
+
+func (s *BackendAccountStateProofsSuite) SetupTest() {
+	s.log = unittest.Logger()
+	s.state = protocol.NewState(s.T())
+	s.sealedSnapshot = protocol.NewSnapshot(s.T())
+	s.finalSnapshot = protocol.NewSnapshot(s.T())
+	s.params = protocol.NewParams(s.T())
+	s.headers = storagemock.NewHeaders(s.T())
+	s.seals = storagemock.NewSeals(s.T())
+	s.receipts = storagemock.NewExecutionReceipts(s.T())
+
+	var err error
+	s.ledger, err = complete.NewLedger(&fixtures.NoopWAL{}, 100, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion)
+	s.Require().NoError(err)
+	s.compactor = fixtures.NewNoopCompactor(s.ledger)
+	<-s.compactor.Ready()
+
+	s.address = unittest.RandomAddressFixture()
+	s.registers = flow.RegisterEntries{
+		{Key: flow.AccountStatusRegisterID(s.address), Value: []byte{1, 2, 3}},
+		{Key: flow.NewRegisterID(s.address, "storage"), Value: []byte{4, 5}},
+	}
+
+	keys := make([]ledger.Key, len(s.registers))
+	values := make([]ledger.Value, len(s.registers))
+	for i, register := range s.registers {
+		keys[i] = convert.RegisterIDToLedgerKey(register.Key)
+		values[i] = register.Value
+	}
+	update, err := ledger.NewUpdate(s.ledger.InitialState(), keys, values)
+	s.Require().NoError(err)
+	state, _, err := s.ledger.Set(update)
+	s.Require().NoError(err)
+	s.commit = flow.StateCommitment(state)
+
+	block := unittest.BlockFixture()
+	s.block = &block
+	s.executionNodes = unittest.IdentityListFixture(2, unittest.WithRole(flow.RoleExecution))
+
+	s.requester = &fakeRegisterProofRequester{
+		ledger:   s.ledger,
+		failures: make(map[flow.Identifier]error),
+	}
+}
+
+func (s *BackendAccountStateProofsSuite) TearDownTest() {
+	<-s.ledger.Done()
+	<-s.compactor.Done()
+}
+
+func (s *BackendAccountStateProofsSuite) defaultBackend() *backendAccountStateProofs {
+	return &backendAccountStateProofs{
+		log:     s.log,
+		state:   s.state,
+		headers: s.headers,
+		seals:   s.seals,
+		execNodeIdentitiesProvider: commonrpc.NewExecutionNodeIdentitiesProvider(
+			s.log,
+			s.state,
+			s.receipts,
+			flow.IdentifierList{},
+			flow.IdentifierList{},
+		),
+		requester:      s.requester,
+		requestTimeout: DefaultRegisterProofRequestTimeout,
+		maxRegisters:   MaxAccountStateProofRegisters,
+	}
+}
+
+// setupSealedBlock sets up the mocks required to look up the sealed block, its seal and its execution nodes.
+func (s *BackendAccountStateProofsSuite) setupSealedBlock() {
+	s.state.On("Sealed").Return(s.sealedSnapshot)
+	s.sealedSnapshot.On("Head").Return(s.block.Header, nil)
+
+	s.headers.On("ByHeight", s.block.Header.Height).Return(s.block.Header, nil)
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(s.block), unittest.WithFinalState(s.commit))
+	s.seals.On("FinalizedSealForBlock", s.block.ID()).
+		Return(unittest.Seal.Fixture(unittest.Seal.WithResult(result)), nil)
+
+	s.params.On("FinalizedRoot").Return(unittest.BlockHeaderFixture(), nil)
+	s.state.On("Params").Return(s.params)
+	s.state.On("Final").Return(s.finalSnapshot)
+	s.finalSnapshot.On("Identities", mock.Anything).Return(s.executionNodes, nil)
+
+	// this line causes a S1021 lint error because receipts is explicitly declared. this is required
+	// to ensure the mock library handles the response type correctly
+	var receipts flow.ExecutionReceiptList //nolint:gosimple
+	receipts = unittest.ReceiptsForBlockFixture(s.block, s.executionNodes.NodeIDs())
+	s.receipts.On("ByBlockID", s.block.ID()).Return(receipts, nil)
+}
+
+// TestGetAccountStateProof tests that the verified proof of the requested registers is returned,
+// including registers which aren't allocated.
+func (s *BackendAccountStateProofsSuite) TestGetAccountStateProof() {
+	s.setupSealedBlock()
+	backend := s.defaultBackend()
+
+	proof, err := backend.GetAccountStateProof(context.Background(), s.address, []string{"storage", "unallocated", "storage"}, s.block.Header.Height)
+	s.Require().NoError(err)
+
+	s.Assert().Equal(s.address, proof.Address)
+	s.Assert().Equal(s.block.ID(), proof.BlockID)
+	s.Assert().Equal(s.block.Header.Height, proof.BlockHeight)
+	s.Assert().Equal(s.commit, proof.StateCommitment)
+	s.Assert().NotEmpty(proof.Proof)
+
+	// duplicate keys are proven once
+	s.Assert().Equal(flow.RegisterEntries{
+		s.registers[1],
+		{Key: flow.NewRegisterID(s.address, "unallocated"), Value: flow.RegisterValue{}},
+	}, proof.Registers)
+	s.Assert().Len(s.requester.requests, 1)
+}
+
+// TestGetAccountStateProof_DefaultKeys tests that the account status register is proven if no keys are given.
+func (s *BackendAccountStateProofsSuite) TestGetAccountStateProof_DefaultKeys() {
+	s.setupSealedBlock()
+	backend := s.defaultBackend()
+
+	proof, err := backend.GetAccountStateProof(context.Background(), s.address, nil, s.block.Header.Height)
+	s.Require().NoError(err)
+	s.Assert().Equal(flow.RegisterEntries{s.registers[0]}, proof.Registers)
+}
+
+// TestGetAccountStateProof_FaultyExecutionNode tests that an invalid proof, or a failed request, of an
+// execution node is skipped, and the proof of the next execution node is returned.
+func (s *BackendAccountStateProofsSuite) TestGetAccountStateProof_FaultyExecutionNode() {
+	s.setupSealedBlock()
+	backend := s.defaultBackend()
+
+	s.Run("invalid proof", func() {
+		s.requester.requests = nil
+		backend.requester = &invalidProofRequester{
+			fakeRegisterProofRequester: s.requester,
+			faulty:                     s.executionNodes[0].NodeID,
+		}
+
+		proof, err := backend.GetAccountStateProof(context.Background(), s.address, []string{"storage"}, s.block.Header.Height)
+		s.Require().NoError(err)
+		s.Assert().Equal(flow.RegisterEntries{s.registers[1]}, proof.Registers)
+	})
+
+	s.Run("failed request", func() {
+		s.requester.requests = nil
+		s.requester.failures[s.executionNodes[1].NodeID] = context.DeadlineExceeded
+		backend.requester = s.requester
+
+		proof, err := backend.GetAccountStateProof(context.Background(), s.address, []string{"storage"}, s.block.Header.Height)
+		s.Require().NoError(err)
+		s.Assert().Equal(flow.RegisterEntries{s.registers[1]}, proof.Registers)
+	})
+}
+
+// TestGetAccountStateProof_Unavailable tests that codes.Unavailable is returned if no execution node
+// provides a valid proof.
+func (s *BackendAccountStateProofsSuite) TestGetAccountStateProof_Unavailable() {
+	s.setupSealedBlock()
+	backend := s.defaultBackend()
+
+	for _, en := range s.executionNodes {
+		s.requester.failures[en.NodeID] = fmt.Errorf("state not available")
+	}
+
+	_, err := backend.GetAccountStateProof(context.Background(), s.address, nil, s.block.Header.Height)
+	s.Require().Error(err)
+	s.Assert().Equal(codes.Unavailable, status.Code(err))
+	s.Assert().Len(s.requester.requests, len(s.executionNodes))
+}
+
+// TestGetAccountStateProof_InvalidArguments tests the errors returned for invalid requests.
+func (s *BackendAccountStateProofsSuite) TestGetAccountStateProof_InvalidArguments() {
+	s.Run("too many keys", func() {
+		backend := s.defaultBackend()
+		keys := make([]string, MaxAccountStateProofRegisters+1)
+		for i := range keys {
+			keys[i] = fmt.Sprintf("key%d", i)
+		}
+
+		_, err := backend.GetAccountStateProof(context.Background(), s.address, keys, s.block.Header.Height)
+		s.Assert().Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	s.Run("height not sealed", func() {
+		s.state.On("Sealed").Return(s.sealedSnapshot).Once()
+		s.sealedSnapshot.On("Head").Return(s.block.Header, nil).Once()
+		backend := s.defaultBackend()
+
+		_, err := backend.GetAccountStateProof(context.Background(), s.address, nil, s.block.Header.Height+1)
+		s.Assert().Equal(codes.OutOfRange, status.Code(err))
+	})
+
+	s.Run("proofs not available", func() {
+		backend := s.defaultBackend()
+		backend.requester = nil
+
+		_, err := backend.GetAccountStateProof(context.Background(), s.address, nil, s.block.Header.Height)
+		s.Assert().Equal(codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+// invalidProofRequester responds with a proof of the requested registers in a different state for the
+// faulty execution node.
+type invalidProofRequester struct {
+	*fakeRegisterProofRequester
+	faulty flow.Identifier
+}
+
+func (r *invalidProofRequester) RequestRegisterProof(
+	ctx context.Context,
+	executorID flow.Identifier,
+	blockID flow.Identifier,
+	commit flow.StateCommitment,
+	registerIDs []flow.RegisterID,
+) ([]byte, error) {
+	if executorID == r.faulty {
+		commit = flow.StateCommitment(r.ledger.InitialState())
+	}
+	return r.fakeRegisterProofRequester.RequestRegisterProof(ctx, executorID, blockID, commit, registerIDs)
+}
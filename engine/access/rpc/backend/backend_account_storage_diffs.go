@@ -0,0 +1,67 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	"github.com/onflow/flow-go/storage"
+)
+
+// MaxAccountStorageDiffLimit is the maximum number of register changes returned in a single
+// page of an account storage diff.
+const MaxAccountStorageDiffLimit = 1000
+
+type backendAccountStorageDiffs struct {
+	log       zerolog.Logger
+	registers *execution.RegistersAsyncStore
+	maxLimit  uint32
+}
+
+// GetAccountStorageDiff returns the registers of the given account which were added, removed or modified
+// between the two heights, using the locally indexed registers. Where possible, the registers are
+// described by what they store, and if decodePaths is set, the values stored at the account's
+// storage paths are decoded and compared for the first page.
+// If the returned diff is not the last page, it contains the cursor for the next page.
+//
+// Expected errors during normal operations:
+// - codes.InvalidArgument: if the height range or limit is invalid.
+// - codes.OutOfRange: if the height range is not indexed.
+// - codes.FailedPrecondition: if the register index is not available.
+func (b *backendAccountStorageDiffs) GetAccountStorageDiff(
+	_ context.Context,
+	address flow.Address,
+	fromHeight uint64,
+	toHeight uint64,
+	cursor *string,
+	limit uint32,
+	decodePaths bool,
+) (*flow.AccountStorageDiff, error) {
+	if b.registers == nil {
+		return nil, status.Error(codes.FailedPrecondition, "register index is not enabled")
+	}
+
+	if limit == 0 || limit > b.maxLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", b.maxLimit)
+	}
+
+	if fromHeight > toHeight {
+		return nil, status.Errorf(codes.InvalidArgument, "from height %d must not be larger than to height %d", fromHeight, toHeight)
+	}
+
+	diff, err := b.registers.AccountStorageDiff(address, fromHeight, toHeight, cursor, int(limit), decodePaths)
+	if err != nil {
+		if errors.Is(err, storage.ErrHeightNotIndexed) {
+			return nil, status.Errorf(codes.OutOfRange, "registers in height range [%d, %d] are not indexed", fromHeight, toHeight)
+		}
+		return nil, rpc.ConvertIndexError(err, toHeight, "could not get account storage diff")
+	}
+
+	return diff, nil
+}
@@ -0,0 +1,104 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetAccountStorageDiff tests that account storage diffs are read from the register index, and that
+// height bounds and limits are validated.
+func TestGetAccountStorageDiff(t *testing.T) {
+	ctx := context.Background()
+	address := unittest.AddressFixture()
+	owner := flow.AddressToRegisterOwner(address)
+
+	changes := []flow.RegisterChange{
+		{
+			ID:          flow.AccountStatusRegisterID(address),
+			Type:        flow.RegisterModified,
+			ValueBefore: []byte{1},
+			ValueAfter:  []byte{2},
+		},
+		{
+			ID:         flow.ContractRegisterID(address, "Foo"),
+			Type:       flow.RegisterAdded,
+			ValueAfter: []byte("access(all) contract Foo {}"),
+		},
+	}
+
+	setup := func(t *testing.T) (*backendAccountStorageDiffs, *storagemock.RegisterIndex) {
+		registerIndex := storagemock.NewRegisterIndex(t)
+		registers := execution.NewRegistersAsyncStore()
+		require.NoError(t, registers.Initialize(registerIndex))
+
+		return &backendAccountStorageDiffs{
+			log:       zerolog.Nop(),
+			registers: registers,
+			maxLimit:  MaxAccountStorageDiffLimit,
+		}, registerIndex
+	}
+
+	t.Run("returns described changes and next cursor", func(t *testing.T) {
+		backend, registerIndex := setup(t)
+		registerIndex.On("RegisterChanges", owner, uint64(10), uint64(20), (*string)(nil), 2).
+			Return(changes, true, nil)
+
+		diff, err := backend.GetAccountStorageDiff(ctx, address, 10, 20, nil, 2, false)
+		require.NoError(t, err)
+
+		require.Len(t, diff.Changes, 2)
+		require.Equal(t, changes[0], diff.Changes[0].RegisterChange)
+		require.Equal(t, flow.AccountRegisterStatus, diff.Changes[0].Kind)
+		require.Equal(t, changes[1], diff.Changes[1].RegisterChange)
+		require.Equal(t, flow.AccountRegisterContractCode, diff.Changes[1].Kind)
+		require.Equal(t, "Foo", diff.Changes[1].Name)
+
+		require.NotNil(t, diff.NextCursor)
+		require.Equal(t, changes[1].ID.Key, *diff.NextCursor)
+	})
+
+	t.Run("fails when heights are not indexed", func(t *testing.T) {
+		backend, registerIndex := setup(t)
+		registerIndex.On("RegisterChanges", owner, uint64(10), uint64(20), (*string)(nil), 2).
+			Return(nil, false, fmt.Errorf("not indexed: %w", storage.ErrHeightNotIndexed))
+
+		_, err := backend.GetAccountStorageDiff(ctx, address, 10, 20, nil, 2, false)
+		require.Equal(t, codes.OutOfRange, status.Code(err))
+	})
+
+	t.Run("fails when register index is not initialized", func(t *testing.T) {
+		backend := &backendAccountStorageDiffs{
+			log:       zerolog.Nop(),
+			registers: execution.NewRegistersAsyncStore(),
+			maxLimit:  MaxAccountStorageDiffLimit,
+		}
+
+		_, err := backend.GetAccountStorageDiff(ctx, address, 10, 20, nil, 2, false)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("validates arguments", func(t *testing.T) {
+		backend, _ := setup(t)
+
+		_, err := backend.GetAccountStorageDiff(ctx, address, 10, 20, nil, 0, false)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = backend.GetAccountStorageDiff(ctx, address, 10, 20, nil, MaxAccountStorageDiffLimit+1, false)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = backend.GetAccountStorageDiff(ctx, address, 20, 10, nil, 2, false)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
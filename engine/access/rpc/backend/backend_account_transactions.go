@@ -0,0 +1,91 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/index"
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// MaxAccountTransactionsLimit is the maximum number of transactions returned in a single
+// page of the account transaction history.
+const MaxAccountTransactionsLimit = 1000
+
+type backendAccountTransactions struct {
+	log                      zerolog.Logger
+	accountTransactionsIndex *index.AccountTransactionsIndex
+	maxLimit                 uint32
+}
+
+// GetAccountTransactions returns the transactions which involved the given account as payer, proposer,
+// authorizer or through an emitted event, ordered from newest to oldest.
+//
+// A start or end height of 0 is replaced with the lowest or highest indexed height respectively.
+// If the returned page is not the last one, it contains the cursor for the next page.
+//
+// Expected errors during normal operations:
+// - codes.InvalidArgument: if the height range or limit is invalid.
+// - codes.OutOfRange: if the height range is not indexed.
+// - codes.FailedPrecondition: if the account transaction index is not available.
+func (b *backendAccountTransactions) GetAccountTransactions(
+	_ context.Context,
+	address flow.Address,
+	startHeight uint64,
+	endHeight uint64,
+	cursor *flow.AccountTransactionCursor,
+	limit uint32,
+) (*access.AccountTransactionsPage, error) {
+	if b.accountTransactionsIndex == nil {
+		return nil, status.Error(codes.FailedPrecondition, "account transaction index is not enabled")
+	}
+
+	if limit == 0 || limit > b.maxLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", b.maxLimit)
+	}
+
+	var err error
+	if startHeight == 0 {
+		startHeight, err = b.accountTransactionsIndex.LowestIndexedHeight()
+		if err != nil {
+			return nil, rpc.ConvertIndexError(err, startHeight, "could not get lowest indexed height")
+		}
+	}
+	if endHeight == 0 {
+		endHeight, err = b.accountTransactionsIndex.HighestIndexedHeight()
+		if err != nil {
+			return nil, rpc.ConvertIndexError(err, endHeight, "could not get highest indexed height")
+		}
+	}
+
+	if startHeight > endHeight {
+		return nil, status.Errorf(codes.InvalidArgument, "start height %d must not be larger than end height %d", startHeight, endHeight)
+	}
+
+	// fetch one additional entry to find the cursor of the next page
+	transactions, err := b.accountTransactionsIndex.ByAddress(address, startHeight, endHeight, cursor, limit+1)
+	if err != nil {
+		if errors.Is(err, storage.ErrHeightNotIndexed) {
+			return nil, status.Errorf(codes.OutOfRange, "blocks in height range [%d, %d] are not fully indexed", startHeight, endHeight)
+		}
+		return nil, rpc.ConvertIndexError(err, endHeight, "could not get account transactions")
+	}
+
+	page := &access.AccountTransactionsPage{
+		Transactions: transactions,
+	}
+	if uint32(len(transactions)) > limit {
+		next := transactions[limit].Cursor()
+		page.Transactions = transactions[:limit]
+		page.NextCursor = &next
+	}
+
+	return page, nil
+}
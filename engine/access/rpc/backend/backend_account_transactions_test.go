@@ -0,0 +1,100 @@
+package backend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/access/index"
+	"github.com/onflow/flow-go/model/flow"
+	syncmock "github.com/onflow/flow-go/module/state_synchronization/mock"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetAccountTransactions tests that account transactions are paged using the index, and that
+// height bounds and limits are validated.
+func TestGetAccountTransactions(t *testing.T) {
+	ctx := context.Background()
+	address := unittest.AddressFixture()
+
+	lowestHeight := uint64(10)
+	highestHeight := uint64(100)
+
+	transactions := []flow.AccountTransaction{
+		{Address: address, BlockHeight: 50, TransactionID: unittest.IdentifierFixture(), TransactionIndex: 2},
+		{Address: address, BlockHeight: 50, TransactionID: unittest.IdentifierFixture(), TransactionIndex: 0},
+		{Address: address, BlockHeight: 20, TransactionID: unittest.IdentifierFixture(), TransactionIndex: 1},
+	}
+
+	setup := func(t *testing.T) (*backendAccountTransactions, *storagemock.AccountTransactions) {
+		reporter := syncmock.NewIndexReporter(t)
+		reporter.On("LowestIndexedHeight").Return(lowestHeight, nil).Maybe()
+		reporter.On("HighestIndexedHeight").Return(highestHeight, nil).Maybe()
+
+		store := storagemock.NewAccountTransactions(t)
+		accountTransactionsIndex := index.NewAccountTransactionsIndex(index.NewReporter(), store)
+		err := accountTransactionsIndex.Initialize(reporter)
+		require.NoError(t, err)
+
+		return &backendAccountTransactions{
+			log:                      zerolog.Nop(),
+			accountTransactionsIndex: accountTransactionsIndex,
+			maxLimit:                 MaxAccountTransactionsLimit,
+		}, store
+	}
+
+	t.Run("returns next cursor when more transactions are available", func(t *testing.T) {
+		backend, store := setup(t)
+		store.On("ByAddress", address, lowestHeight, highestHeight, (*flow.AccountTransactionCursor)(nil), uint32(3)).
+			Return(transactions, nil)
+
+		page, err := backend.GetAccountTransactions(ctx, address, 0, 0, nil, 2)
+		require.NoError(t, err)
+		require.Equal(t, transactions[:2], page.Transactions)
+		require.Equal(t, &flow.AccountTransactionCursor{BlockHeight: 20, TransactionIndex: 1}, page.NextCursor)
+	})
+
+	t.Run("returns no cursor on the last page", func(t *testing.T) {
+		backend, store := setup(t)
+		cursor := &flow.AccountTransactionCursor{BlockHeight: 50, TransactionIndex: 0}
+		store.On("ByAddress", address, uint64(20), uint64(60), cursor, uint32(11)).
+			Return(transactions[1:], nil)
+
+		page, err := backend.GetAccountTransactions(ctx, address, 20, 60, cursor, 10)
+		require.NoError(t, err)
+		require.Equal(t, transactions[1:], page.Transactions)
+		require.Nil(t, page.NextCursor)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		backend, _ := setup(t)
+
+		_, err := backend.GetAccountTransactions(ctx, address, 0, 0, nil, 0)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = backend.GetAccountTransactions(ctx, address, 0, 0, nil, MaxAccountTransactionsLimit+1)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = backend.GetAccountTransactions(ctx, address, 60, 20, nil, 10)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("heights outside of the indexed range", func(t *testing.T) {
+		backend, _ := setup(t)
+
+		_, err := backend.GetAccountTransactions(ctx, address, lowestHeight, highestHeight+1, nil, 10)
+		require.Equal(t, codes.OutOfRange, status.Code(err))
+	})
+
+	t.Run("index not enabled", func(t *testing.T) {
+		backend := &backendAccountTransactions{log: zerolog.Nop(), maxLimit: MaxAccountTransactionsLimit}
+
+		_, err := backend.GetAccountTransactions(ctx, address, 0, 0, nil, 10)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
@@ -0,0 +1,146 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+)
+
+// MaxContractImpactAccounts is the maximum number of accounts whose storage is scanned for values
+// referencing the types of a contract in a single contract impact request.
+const MaxContractImpactAccounts = 100
+
+// contractGraphAtHeight is a contract graph and the height it was built at.
+type contractGraphAtHeight struct {
+	graph  *execution.ContractGraph
+	height uint64
+}
+
+type backendContractImpacts struct {
+	log         zerolog.Logger
+	registers   *execution.RegistersAsyncStore
+	maxAccounts int
+
+	// graph is the most recently built contract graph. Building a graph visits all accounts of the
+	// register index, so it is only built by RefreshContractGraph, never on the request path.
+	graph atomic.Pointer[contractGraphAtHeight]
+}
+
+// GetContractImpact returns the contracts which import the given contract, directly or transitively,
+// and the values stored by the given accounts which reference types defined by the contract or by
+// any of its dependents, using the locally indexed registers.
+//
+// Requests are served from the contract graph built in the background at the latest indexed height.
+// If height is 0, the height of the graph is used, otherwise it must match the height of the graph.
+//
+// Expected errors during normal operations:
+// - codes.InvalidArgument: if too many accounts are given.
+// - codes.NotFound: if the contract is not deployed at the height of the graph.
+// - codes.OutOfRange: if the height is not the height of the graph, or is not indexed anymore.
+// - codes.FailedPrecondition: if the register index or the contract graph is not available yet.
+// - codes.Canceled, codes.DeadlineExceeded: if the context is done while accounts are scanned.
+func (b *backendContractImpacts) GetContractImpact(
+	ctx context.Context,
+	contract flow.ContractID,
+	height uint64,
+	accounts []flow.Address,
+) (*flow.ContractImpact, error) {
+	if b.registers == nil {
+		return nil, status.Error(codes.FailedPrecondition, "register index is not enabled")
+	}
+
+	if len(accounts) > b.maxAccounts {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d accounts can be scanned", b.maxAccounts)
+	}
+
+	current := b.graph.Load()
+	if current == nil {
+		return nil, status.Error(codes.FailedPrecondition, "contract graph is not built yet")
+	}
+
+	if height == 0 {
+		height = current.height
+	}
+	if height != current.height {
+		return nil, status.Errorf(codes.OutOfRange, "contract graph is only available at height %d", current.height)
+	}
+
+	if !current.graph.Contains(contract) {
+		return nil, status.Errorf(codes.NotFound, "contract %s is not deployed at height %d", contract, height)
+	}
+
+	impact := current.graph.Impact(contract)
+	impact.Height = height
+	contracts := impact.Contracts()
+
+	for _, address := range sortedUniqueAddresses(accounts) {
+		if err := ctx.Err(); err != nil {
+			return nil, rpc.ConvertError(err, "could not scan account storage", codes.Internal)
+		}
+
+		references, err := b.registers.StoredTypeReferences(address, height, contracts)
+		if err != nil {
+			return nil, rpc.ConvertIndexError(err, height, "could not scan account storage")
+		}
+		impact.StoredReferences = append(impact.StoredReferences, references...)
+		impact.ScannedAccounts++
+	}
+
+	return impact, nil
+}
+
+// RefreshContractGraph builds the contract graph at the latest indexed height, and replaces the graph
+// used to serve contract impact requests.
+//
+// Expected errors during normal operations:
+// - indexer.ErrIndexNotInitialized if the register index is still bootstrapping
+// - storage.ErrHeightNotIndexed if the height was pruned while the graph was built
+// - context.Canceled or context.DeadlineExceeded if the context is done before the graph is built
+func (b *backendContractImpacts) RefreshContractGraph(ctx context.Context) error {
+	if b.registers == nil {
+		return nil
+	}
+
+	graph, height, err := b.registers.LatestContractGraph(ctx)
+	if err != nil {
+		return err
+	}
+
+	b.log.Debug().
+		Uint64("height", height).
+		Int("contracts", graph.Len()).
+		Msg("built contract graph")
+
+	b.graph.Store(&contractGraphAtHeight{
+		graph:  graph,
+		height: height,
+	})
+
+	return nil
+}
+
+// sortedUniqueAddresses returns the addresses in ascending order, without duplicates.
+func sortedUniqueAddresses(addresses []flow.Address) []flow.Address {
+	sorted := make([]flow.Address, len(addresses))
+	copy(sorted, addresses)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i][:], sorted[j][:]) < 0
+	})
+
+	unique := sorted[:0]
+	for i, address := range sorted {
+		if i == 0 || address != sorted[i-1] {
+			unique = append(unique, address)
+		}
+	}
+	return unique
+}
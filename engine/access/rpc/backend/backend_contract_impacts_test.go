@@ -0,0 +1,172 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/fvm/environment"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	"github.com/onflow/flow-go/module/state_synchronization/indexer"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetContractImpact tests that contract impacts are computed from the contract graph built in the
+// background at the latest indexed height, and that arguments are validated.
+func TestGetContractImpact(t *testing.T) {
+	ctx := context.Background()
+	height := uint64(20)
+	address := unittest.AddressFixture()
+	owner := flow.AddressToRegisterOwner(address)
+
+	base := flow.ContractID{Address: address, Name: "Base"}
+	dependent := flow.ContractID{Address: address, Name: "Dependent"}
+
+	contractNames, err := environment.EncodeContractNames([]string{base.Name, dependent.Name})
+	require.NoError(t, err)
+
+	setup := func(t *testing.T) (*backendContractImpacts, *storagemock.RegisterIndex) {
+		registerIndex := storagemock.NewRegisterIndex(t)
+		registerIndex.On("FirstHeight").Return(uint64(10)).Maybe()
+		registerIndex.On("LatestHeight").Return(height).Maybe()
+
+		registers := execution.NewRegistersAsyncStore()
+		require.NoError(t, registers.Initialize(registerIndex))
+
+		return &backendContractImpacts{
+			log:         zerolog.Nop(),
+			registers:   registers,
+			maxAccounts: MaxContractImpactAccounts,
+		}, registerIndex
+	}
+
+	mockContracts := func(registerIndex *storagemock.RegisterIndex) {
+		registerIndex.
+			On("ForEachOwner", mock.Anything).
+			Return(func(f func(string) error) error {
+				return f(owner)
+			}).
+			Once()
+		registerIndex.
+			On("Get", flow.ContractNamesRegisterID(address), height).
+			Return(contractNames, nil)
+		registerIndex.
+			On("Get", flow.ContractRegisterID(address, base.Name), height).
+			Return([]byte(`access(all) contract Base {}`), nil)
+		registerIndex.
+			On("Get", flow.ContractRegisterID(address, dependent.Name), height).
+			Return([]byte(fmt.Sprintf(`
+				import Base from %s
+				access(all) contract Dependent {}
+			`, address.HexWithPrefix())), nil)
+	}
+
+	// refreshed returns a backend whose contract graph was built at the latest height.
+	refreshed := func(t *testing.T) (*backendContractImpacts, *storagemock.RegisterIndex) {
+		backend, registerIndex := setup(t)
+		mockContracts(registerIndex)
+		require.NoError(t, backend.RefreshContractGraph(ctx))
+		return backend, registerIndex
+	}
+
+	t.Run("returns dependents from the refreshed graph", func(t *testing.T) {
+		backend, registerIndex := refreshed(t)
+		// the scanned account has no stored values
+		registerIndex.On("Get", mock.Anything, height).Return(nil, storage.ErrNotFound)
+
+		impact, err := backend.GetContractImpact(ctx, base, height, []flow.Address{address, address})
+		require.NoError(t, err)
+
+		require.Equal(t, base, impact.Contract)
+		require.Equal(t, height, impact.Height)
+		require.Equal(t, []flow.ContractDependent{{ContractID: dependent, Depth: 1}}, impact.Dependents)
+		require.Empty(t, impact.StoredReferences)
+		require.Equal(t, uint64(1), impact.ScannedAccounts)
+
+		// requests don't build the graph, and default to the height of the graph
+		impact, err = backend.GetContractImpact(ctx, dependent, 0, nil)
+		require.NoError(t, err)
+		require.Equal(t, height, impact.Height)
+		require.Empty(t, impact.Dependents)
+	})
+
+	t.Run("fails when contract is not deployed", func(t *testing.T) {
+		backend, _ := refreshed(t)
+
+		_, err := backend.GetContractImpact(ctx, flow.ContractID{Address: address, Name: "Missing"}, height, nil)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("fails when height is not the height of the graph", func(t *testing.T) {
+		backend, _ := refreshed(t)
+
+		_, err := backend.GetContractImpact(ctx, base, height-1, nil)
+		require.Equal(t, codes.OutOfRange, status.Code(err))
+	})
+
+	t.Run("fails when graph is not built", func(t *testing.T) {
+		backend, _ := setup(t)
+
+		_, err := backend.GetContractImpact(ctx, base, height, nil)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("fails when register index is not initialized", func(t *testing.T) {
+		backend := &backendContractImpacts{
+			log:         zerolog.Nop(),
+			registers:   execution.NewRegistersAsyncStore(),
+			maxAccounts: MaxContractImpactAccounts,
+		}
+
+		err := backend.RefreshContractGraph(ctx)
+		require.ErrorIs(t, err, indexer.ErrIndexNotInitialized)
+
+		_, err = backend.GetContractImpact(ctx, base, height, nil)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("stops refreshing when cancelled", func(t *testing.T) {
+		backend, registerIndex := setup(t)
+		// no contracts are read once the context is done
+		registerIndex.
+			On("ForEachOwner", mock.Anything).
+			Return(func(f func(string) error) error {
+				return f(owner)
+			}).
+			Once()
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		err := backend.RefreshContractGraph(cancelled)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Nil(t, backend.graph.Load())
+	})
+
+	t.Run("stops scanning when cancelled", func(t *testing.T) {
+		backend, _ := refreshed(t)
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := backend.GetContractImpact(cancelled, base, height, []flow.Address{address})
+		require.Equal(t, codes.Canceled, status.Code(err))
+	})
+
+	t.Run("validates arguments", func(t *testing.T) {
+		backend, _ := setup(t)
+
+		accounts := make([]flow.Address, MaxContractImpactAccounts+1)
+		_, err := backend.GetContractImpact(ctx, base, height, accounts)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
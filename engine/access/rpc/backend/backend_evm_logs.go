@@ -0,0 +1,167 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/index"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/state_synchronization/indexer"
+	"github.com/onflow/flow-go/storage"
+)
+
+// MaxEVMLogsLimit is the maximum number of EVM logs returned in a single page.
+const MaxEVMLogsLimit = 1000
+
+type backendEVMLogs struct {
+	log                 zerolog.Logger
+	evmLogsIndex        *index.EVMLogsIndex
+	subscriptionHandler *subscription.SubscriptionHandler
+	blockTracker        subscription.BlockTracker
+	maxLimit            uint32
+}
+
+// GetEVMLogs returns the EVM logs matching the filter, ordered from oldest to newest.
+//
+// A start or end height of 0 is replaced with the lowest or highest indexed height respectively.
+// If the returned page is not the last one, it contains the cursor for the next page.
+//
+// Expected errors during normal operations:
+// - codes.InvalidArgument: if the filter, height range or limit is invalid.
+// - codes.OutOfRange: if the height range is not indexed.
+// - codes.FailedPrecondition: if the EVM log index is not available.
+func (b *backendEVMLogs) GetEVMLogs(
+	_ context.Context,
+	filter flow.EVMLogFilter,
+	startHeight uint64,
+	endHeight uint64,
+	cursor *flow.EVMLogCursor,
+	limit uint32,
+) (*access.EVMLogsPage, error) {
+	if b.evmLogsIndex == nil {
+		return nil, status.Error(codes.FailedPrecondition, "EVM log index is not enabled")
+	}
+
+	if limit == 0 || limit > b.maxLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", b.maxLimit)
+	}
+	if len(filter.Topics) > flow.EVMLogMaxTopics {
+		return nil, status.Errorf(codes.InvalidArgument, "filter must not have more than %d topic positions", flow.EVMLogMaxTopics)
+	}
+
+	var err error
+	if startHeight == 0 {
+		startHeight, err = b.evmLogsIndex.LowestIndexedHeight()
+		if err != nil {
+			return nil, rpc.ConvertIndexError(err, startHeight, "could not get lowest indexed height")
+		}
+	}
+	if endHeight == 0 {
+		endHeight, err = b.evmLogsIndex.HighestIndexedHeight()
+		if err != nil {
+			return nil, rpc.ConvertIndexError(err, endHeight, "could not get highest indexed height")
+		}
+	}
+
+	if startHeight > endHeight {
+		return nil, status.Errorf(codes.InvalidArgument, "start height %d must not be larger than end height %d", startHeight, endHeight)
+	}
+
+	// fetch one additional entry to find the cursor of the next page
+	logs, err := b.evmLogsIndex.ByFilter(filter, startHeight, endHeight, cursor, limit+1)
+	if err != nil {
+		if errors.Is(err, storage.ErrHeightNotIndexed) {
+			return nil, status.Errorf(codes.OutOfRange, "blocks in height range [%d, %d] are not fully indexed", startHeight, endHeight)
+		}
+		return nil, rpc.ConvertIndexError(err, endHeight, "could not get EVM logs")
+	}
+
+	page := &access.EVMLogsPage{
+		Logs: logs,
+	}
+	if uint32(len(logs)) > limit {
+		next := logs[limit].Cursor()
+		page.Logs = logs[:limit]
+		page.NextCursor = &next
+	}
+
+	return page, nil
+}
+
+// SubscribeEVMLogsFromStartHeight streams the EVM logs matching the filter, starting at the requested
+// start block height, up until the latest indexed block. Once the latest is reached, the stream will
+// remain open and responses are sent for each new block as it is indexed.
+//
+// A response of type *access.EVMLogsResponse is sent for every block, including blocks without
+// matching logs. A start height of 0 starts at the latest sealed block.
+//
+// If invalid parameters will be supplied SubscribeEVMLogsFromStartHeight will return a failed subscription.
+func (b *backendEVMLogs) SubscribeEVMLogsFromStartHeight(
+	ctx context.Context,
+	startHeight uint64,
+	filter flow.EVMLogFilter,
+) subscription.Subscription {
+	if b.evmLogsIndex == nil {
+		return subscription.NewFailedSubscription(status.Error(codes.FailedPrecondition, "EVM log index is not enabled"), "EVM log index is not available")
+	}
+	if len(filter.Topics) > flow.EVMLogMaxTopics {
+		return subscription.NewFailedSubscription(
+			status.Errorf(codes.InvalidArgument, "filter must not have more than %d topic positions", flow.EVMLogMaxTopics),
+			"invalid filter",
+		)
+	}
+
+	var nextHeight uint64
+	var err error
+	if startHeight == 0 {
+		nextHeight, err = b.blockTracker.GetStartHeightFromLatest(ctx)
+	} else {
+		nextHeight, err = b.blockTracker.GetStartHeightFromHeight(startHeight)
+	}
+	if err != nil {
+		return subscription.NewFailedSubscription(err, "could not get start height")
+	}
+
+	// blocks below the lowest indexed height will never become available
+	lowestHeight, err := b.evmLogsIndex.LowestIndexedHeight()
+	if err == nil && nextHeight < lowestHeight {
+		return subscription.NewFailedSubscription(
+			status.Errorf(codes.OutOfRange, "start height %d is below the lowest indexed height %d", nextHeight, lowestHeight),
+			"invalid start height",
+		)
+	}
+
+	return b.subscriptionHandler.Subscribe(ctx, nextHeight, b.getEVMLogsResponse(filter))
+}
+
+// getEVMLogsResponse returns a GetDataByHeightFunc that retrieves the EVM logs matching the filter
+// in the block at the specified height.
+//
+// Expected errors during normal operation:
+// - subscription.ErrBlockNotReady: the block at the given height has not been indexed yet.
+func (b *backendEVMLogs) getEVMLogsResponse(filter flow.EVMLogFilter) subscription.GetDataByHeightFunc {
+	return func(_ context.Context, height uint64) (interface{}, error) {
+		logs, err := b.evmLogsIndex.ByFilter(filter, height, height, nil, math.MaxUint32)
+		if err != nil {
+			if errors.Is(err, storage.ErrHeightNotIndexed) ||
+				errors.Is(err, indexer.ErrIndexNotInitialized) {
+				return nil, subscription.ErrBlockNotReady
+			}
+			return nil, fmt.Errorf("could not get EVM logs at height %d: %w", height, err)
+		}
+
+		return &access.EVMLogsResponse{
+			BlockHeight: height,
+			Logs:        logs,
+		}, nil
+	}
+}
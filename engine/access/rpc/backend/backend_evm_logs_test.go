@@ -0,0 +1,126 @@
+package backend
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/index"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+	syncmock "github.com/onflow/flow-go/module/state_synchronization/mock"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetEVMLogs tests that EVM logs are paged using the index, and that the filter, height bounds
+// and limits are validated.
+func TestGetEVMLogs(t *testing.T) {
+	ctx := context.Background()
+	filter := flow.EVMLogFilter{
+		Addresses: []gethCommon.Address{gethCommon.HexToAddress("0x01")},
+		Topics:    [][]gethCommon.Hash{{gethCommon.HexToHash("0xaa")}},
+	}
+
+	lowestHeight := uint64(10)
+	highestHeight := uint64(100)
+
+	logs := []flow.EVMLog{
+		{BlockHeight: 20, TransactionID: unittest.IdentifierFixture(), LogIndex: 1},
+		{BlockHeight: 50, TransactionID: unittest.IdentifierFixture(), LogIndex: 0},
+		{BlockHeight: 50, TransactionID: unittest.IdentifierFixture(), LogIndex: 3},
+	}
+
+	setup := func(t *testing.T) (*backendEVMLogs, *storagemock.EVMLogs) {
+		reporter := syncmock.NewIndexReporter(t)
+		reporter.On("LowestIndexedHeight").Return(lowestHeight, nil).Maybe()
+		reporter.On("HighestIndexedHeight").Return(highestHeight, nil).Maybe()
+
+		store := storagemock.NewEVMLogs(t)
+		evmLogsIndex := index.NewEVMLogsIndex(index.NewReporter(), store)
+		err := evmLogsIndex.Initialize(reporter)
+		require.NoError(t, err)
+
+		return &backendEVMLogs{
+			log:          zerolog.Nop(),
+			evmLogsIndex: evmLogsIndex,
+			maxLimit:     MaxEVMLogsLimit,
+		}, store
+	}
+
+	t.Run("returns next cursor when more logs are available", func(t *testing.T) {
+		backend, store := setup(t)
+		store.On("ByFilter", filter, lowestHeight, highestHeight, (*flow.EVMLogCursor)(nil), uint32(3)).
+			Return(logs, nil)
+
+		page, err := backend.GetEVMLogs(ctx, filter, 0, 0, nil, 2)
+		require.NoError(t, err)
+		require.Equal(t, logs[:2], page.Logs)
+		require.Equal(t, &flow.EVMLogCursor{BlockHeight: 50, LogIndex: 3}, page.NextCursor)
+	})
+
+	t.Run("returns no cursor on the last page", func(t *testing.T) {
+		backend, store := setup(t)
+		cursor := &flow.EVMLogCursor{BlockHeight: 50, LogIndex: 0}
+		store.On("ByFilter", filter, uint64(20), uint64(60), cursor, uint32(11)).
+			Return(logs[1:], nil)
+
+		page, err := backend.GetEVMLogs(ctx, filter, 20, 60, cursor, 10)
+		require.NoError(t, err)
+		require.Equal(t, logs[1:], page.Logs)
+		require.Nil(t, page.NextCursor)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		backend, _ := setup(t)
+
+		_, err := backend.GetEVMLogs(ctx, filter, 0, 0, nil, 0)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = backend.GetEVMLogs(ctx, filter, 0, 0, nil, MaxEVMLogsLimit+1)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = backend.GetEVMLogs(ctx, filter, 60, 20, nil, 10)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = backend.GetEVMLogs(ctx, flow.EVMLogFilter{Topics: make([][]gethCommon.Hash, 5)}, 0, 0, nil, 10)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("heights outside of the indexed range", func(t *testing.T) {
+		backend, _ := setup(t)
+
+		_, err := backend.GetEVMLogs(ctx, filter, lowestHeight, highestHeight+1, nil, 10)
+		require.Equal(t, codes.OutOfRange, status.Code(err))
+	})
+
+	t.Run("index not enabled", func(t *testing.T) {
+		backend := &backendEVMLogs{log: zerolog.Nop(), maxLimit: MaxEVMLogsLimit}
+
+		_, err := backend.GetEVMLogs(ctx, filter, 0, 0, nil, 10)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("subscription responses", func(t *testing.T) {
+		backend, store := setup(t)
+		store.On("ByFilter", filter, uint64(50), uint64(50), (*flow.EVMLogCursor)(nil), uint32(math.MaxUint32)).
+			Return(logs[1:], nil)
+
+		getData := backend.getEVMLogsResponse(filter)
+
+		response, err := getData(ctx, 50)
+		require.NoError(t, err)
+		require.Equal(t, &access.EVMLogsResponse{BlockHeight: 50, Logs: logs[1:]}, response)
+
+		// blocks which are not indexed yet are not ready
+		_, err = getData(ctx, highestHeight+1)
+		require.ErrorIs(t, err, subscription.ErrBlockNotReady)
+	})
+}
@@ -0,0 +1,192 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/access/index"
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/fvm/evm"
+	"github.com/onflow/flow-go/fvm/evm/debug"
+	"github.com/onflow/flow-go/fvm/evm/events"
+	evmStorage "github.com/onflow/flow-go/fvm/evm/offchain/storage"
+	"github.com/onflow/flow-go/fvm/evm/offchain/sync"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	"github.com/onflow/flow-go/storage"
+)
+
+type backendEVMTraces struct {
+	log         zerolog.Logger
+	chainID     flow.ChainID
+	headers     storage.Headers
+	eventsIndex *index.EventsIndex
+	registers   *execution.RegistersAsyncStore
+	// traces caches the produced traces, if not nil
+	traces *debug.LocalStore
+}
+
+// GetEVMBlockTraces returns the traces of the EVM transactions executed by the given Flow block,
+// in execution order, produced by re-executing them with the given tracer on top of the locally
+// indexed registers and events.
+//
+// Expected errors during normal operations:
+// - codes.InvalidArgument: if the tracer is not supported or its configuration is invalid.
+// - codes.NotFound: if the block is not found.
+// - codes.OutOfRange: if the registers or events of the block are not indexed.
+// - codes.FailedPrecondition: if the register or events index is not available.
+func (b *backendEVMTraces) GetEVMBlockTraces(
+	_ context.Context,
+	blockID flow.Identifier,
+	config debug.TracerConfig,
+) ([]debug.TransactionTrace, error) {
+	if b.registers == nil || b.eventsIndex == nil {
+		return nil, status.Error(codes.FailedPrecondition, "register and events indexes are not enabled")
+	}
+
+	_, err := debug.NewTracer(config)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tracer: %v", err)
+	}
+
+	header, err := b.headers.ByBlockID(blockID)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(err)
+	}
+
+	flowEvents, err := b.eventsIndex.ByBlockID(blockID, header.Height)
+	if err != nil {
+		return nil, rpc.ConvertIndexError(err, header.Height, "could not get events")
+	}
+
+	txEvents, blockEvent, err := sync.DecodeBlockEvents(b.chainID, flowEvents)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not decode EVM events: %v", err)
+	}
+	if blockEvent == nil {
+		return []debug.TransactionTrace{}, nil
+	}
+
+	if traces, ok := b.cachedTraces(blockID, txEvents, config); ok {
+		return traces, nil
+	}
+
+	// transactions are executed on top of the state at the end of the parent block
+	if header.Height == 0 {
+		return nil, status.Errorf(codes.OutOfRange, "data for block height %d is not available", header.Height)
+	}
+	height := header.Height - 1
+	registers := snapshot.NewReadFuncStorageSnapshot(func(id flow.RegisterID) (flow.RegisterValue, error) {
+		values, err := b.registers.RegisterValues(flow.RegisterIDs{id}, height)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return values[0], nil
+	})
+
+	traces, err := sync.TraceBlockExecution(
+		b.chainID,
+		evm.StorageAccountAddress(b.chainID),
+		evmStorage.NewRegisterSnapshot(registers),
+		txEvents,
+		blockEvent,
+		config,
+	)
+	if err != nil {
+		return nil, rpc.ConvertIndexError(err, height, "could not trace block")
+	}
+
+	b.cacheTraces(blockID, traces, config)
+
+	return traces, nil
+}
+
+// GetEVMTransactionTrace returns the trace of the EVM transaction with the given hash, executed by
+// the given Flow block, produced by re-executing the block with the given tracer.
+//
+// Expected errors during normal operations:
+// - codes.InvalidArgument: if the tracer is not supported or its configuration is invalid.
+// - codes.NotFound: if the block is not found, or the transaction was not executed by the block.
+// - codes.OutOfRange: if the registers or events of the block are not indexed.
+// - codes.FailedPrecondition: if the register or events index is not available.
+func (b *backendEVMTraces) GetEVMTransactionTrace(
+	ctx context.Context,
+	blockID flow.Identifier,
+	txHash gethCommon.Hash,
+	config debug.TracerConfig,
+) (json.RawMessage, error) {
+	if b.traces != nil {
+		trace, err := b.traces.Download(config.TraceID(txHash, blockID))
+		if err == nil {
+			return trace, nil
+		}
+		if !errors.Is(err, debug.ErrTraceNotFound) {
+			b.log.Warn().Err(err).Str("tx_hash", txHash.String()).Msg("could not read cached EVM trace")
+		}
+	}
+
+	traces, err := b.GetEVMBlockTraces(ctx, blockID, config)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, trace := range traces {
+		if trace.TxHash == txHash {
+			return trace.Result, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.NotFound, "EVM transaction %s was not executed by block %v", txHash, blockID)
+}
+
+// cachedTraces returns the cached traces of the given transactions, if all of them are cached.
+func (b *backendEVMTraces) cachedTraces(
+	blockID flow.Identifier,
+	txEvents []events.TransactionEventPayload,
+	config debug.TracerConfig,
+) ([]debug.TransactionTrace, bool) {
+	if b.traces == nil {
+		return nil, false
+	}
+
+	traces := make([]debug.TransactionTrace, len(txEvents))
+	for i, txEvent := range txEvents {
+		trace, err := b.traces.Download(config.TraceID(txEvent.Hash, blockID))
+		if err != nil {
+			return nil, false
+		}
+		traces[i] = debug.TransactionTrace{
+			TxHash: txEvent.Hash,
+			Result: trace,
+		}
+	}
+
+	return traces, true
+}
+
+func (b *backendEVMTraces) cacheTraces(
+	blockID flow.Identifier,
+	traces []debug.TransactionTrace,
+	config debug.TracerConfig,
+) {
+	if b.traces == nil {
+		return
+	}
+
+	for _, trace := range traces {
+		err := b.traces.Upload(config.TraceID(trace.TxHash, blockID), trace.Result)
+		if err != nil {
+			b.log.Warn().Err(err).Str("tx_hash", trace.TxHash.String()).Msg("could not cache EVM trace")
+		}
+	}
+}
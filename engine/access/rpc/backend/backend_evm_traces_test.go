@@ -0,0 +1,108 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/access/index"
+	"github.com/onflow/flow-go/fvm/evm/debug"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	syncmock "github.com/onflow/flow-go/module/state_synchronization/mock"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetEVMTraces tests that EVM traces are read from the cache, or produced from the indexed data,
+// and that the tracer and index availability are validated.
+func TestGetEVMTraces(t *testing.T) {
+	ctx := context.Background()
+	header := unittest.BlockHeaderFixture()
+	blockID := header.ID()
+	txHash := gethCommon.HexToHash("0x01")
+
+	setup := func(t *testing.T) (*backendEVMTraces, *storagemock.Headers, *storagemock.Events) {
+		reporter := syncmock.NewIndexReporter(t)
+		reporter.On("LowestIndexedHeight").Return(uint64(0), nil).Maybe()
+		reporter.On("HighestIndexedHeight").Return(header.Height, nil).Maybe()
+
+		events := storagemock.NewEvents(t)
+		eventsIndex := index.NewEventsIndex(index.NewReporter(), events)
+		require.NoError(t, eventsIndex.Initialize(reporter))
+
+		registers := execution.NewRegistersAsyncStore()
+		require.NoError(t, registers.Initialize(storagemock.NewRegisterIndex(t)))
+
+		traces, err := debug.NewLocalStore(t.TempDir())
+		require.NoError(t, err)
+
+		headers := storagemock.NewHeaders(t)
+
+		return &backendEVMTraces{
+			log:         zerolog.Nop(),
+			chainID:     flow.Testnet,
+			headers:     headers,
+			eventsIndex: eventsIndex,
+			registers:   registers,
+			traces:      traces,
+		}, headers, events
+	}
+
+	t.Run("returns cached transaction trace", func(t *testing.T) {
+		backend, _, _ := setup(t)
+
+		config := debug.TracerConfig{Tracer: debug.StructLoggerName}
+		trace := json.RawMessage(`{"gas":21000}`)
+		require.NoError(t, backend.traces.Upload(config.TraceID(txHash, blockID), trace))
+
+		result, err := backend.GetEVMTransactionTrace(ctx, blockID, txHash, config)
+		require.NoError(t, err)
+		require.Equal(t, trace, result)
+	})
+
+	t.Run("returns no traces for blocks without EVM events", func(t *testing.T) {
+		backend, headers, events := setup(t)
+		headers.On("ByBlockID", blockID).Return(header, nil)
+		events.On("ByBlockID", blockID).Return(unittest.EventsFixture(2), nil)
+
+		traces, err := backend.GetEVMBlockTraces(ctx, blockID, debug.DefaultTracerConfig)
+		require.NoError(t, err)
+		require.Empty(t, traces)
+
+		_, err = backend.GetEVMTransactionTrace(ctx, blockID, txHash, debug.DefaultTracerConfig)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("fails for unknown blocks", func(t *testing.T) {
+		backend, headers, _ := setup(t)
+		headers.On("ByBlockID", blockID).Return(nil, storage.ErrNotFound)
+
+		_, err := backend.GetEVMBlockTraces(ctx, blockID, debug.DefaultTracerConfig)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("fails for unsupported tracers", func(t *testing.T) {
+		backend, _, _ := setup(t)
+
+		_, err := backend.GetEVMBlockTraces(ctx, blockID, debug.TracerConfig{Tracer: "unknown"})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("fails when indexes are not enabled", func(t *testing.T) {
+		backend := &backendEVMTraces{
+			log:     zerolog.Nop(),
+			chainID: flow.Testnet,
+		}
+
+		_, err := backend.GetEVMBlockTraces(ctx, blockID, debug.DefaultTracerConfig)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
@@ -3,6 +3,7 @@ package backend
 import (
 	"context"
 	"crypto/md5" //nolint:gosec
+	"errors"
 	"time"
 
 	lru "github.com/hashicorp/golang-lru/v2"
@@ -11,6 +12,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/access/rpc/connection"
 	"github.com/onflow/flow-go/engine/common/rpc"
 	commonrpc "github.com/onflow/flow-go/engine/common/rpc"
@@ -27,6 +29,9 @@ import (
 // uniqueScriptLoggingTimeWindow is the duration for checking the uniqueness of scripts sent for execution
 const uniqueScriptLoggingTimeWindow = 10 * time.Minute
 
+// MaxScriptsPerBatch is the maximum number of scripts which can be executed in a single batch
+const MaxScriptsPerBatch = 100
+
 type backendScripts struct {
 	log                        zerolog.Logger
 	headers                    storage.Headers
@@ -111,6 +116,73 @@ func (b *backendScripts) ExecuteScriptAtBlockHeight(
 	return b.executeScript(ctx, newScriptExecutionRequest(header.ID(), blockHeight, script, arguments))
 }
 
+// ExecuteScriptsAtBlockHeight executes a batch of scripts concurrently against the state at the given
+// block height using the locally indexed registers, and returns a result for each script in the same order.
+//
+// Expected errors during normal operations:
+// - codes.FailedPrecondition: if local script execution is not enabled.
+// - codes.InvalidArgument: if the batch is empty or contains too many scripts.
+// - codes.NotFound: if the block at the given height is not found.
+// - codes.OutOfRange: if the registers for the given height are not indexed.
+func (b *backendScripts) ExecuteScriptsAtBlockHeight(
+	ctx context.Context,
+	blockHeight uint64,
+	scripts []access.Script,
+) ([]access.ScriptResult, error) {
+	// execution nodes do not provide an API to execute scripts against a shared snapshot.
+	if b.scriptExecMode == IndexQueryModeExecutionNodesOnly {
+		return nil, status.Error(codes.FailedPrecondition, "script batches require local script execution to be enabled")
+	}
+
+	if len(scripts) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "script batch must contain at least one script")
+	}
+	if len(scripts) > MaxScriptsPerBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "script batch must contain at most %d scripts, got %d", MaxScriptsPerBatch, len(scripts))
+	}
+
+	header, err := b.headers.ByHeight(blockHeight)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(resolveHeightError(b.state.Params(), blockHeight, err))
+	}
+
+	batch := make([]execution.Script, len(scripts))
+	for i, script := range scripts {
+		batch[i] = execution.Script{
+			Code:      script.Script,
+			Arguments: script.Arguments,
+		}
+	}
+
+	execResults, err := b.scriptExecutor.ExecuteScriptsAtBlockHeight(ctx, batch, blockHeight)
+	if err != nil {
+		b.log.Debug().Err(err).
+			Hex("block_id", logging.ID(header.ID())).
+			Uint64("height", blockHeight).
+			Int("scripts", len(scripts)).
+			Msg("script batch execution failed")
+
+		return nil, convertScriptExecutionError(err, blockHeight)
+	}
+
+	results := make([]access.ScriptResult, len(execResults))
+	for i, r := range execResults {
+		results[i] = access.ScriptResult{
+			Value:           r.Value,
+			ComputationUsed: r.ComputationUsed,
+		}
+		if r.Err != nil {
+			if errors.Is(r.Err, execution.ErrScriptBatchBudgetExceeded) {
+				results[i].Err = status.Error(codes.ResourceExhausted, r.Err.Error())
+			} else {
+				results[i].Err = convertScriptExecutionError(r.Err, blockHeight)
+			}
+		}
+	}
+
+	return results, nil
+}
+
 // executeScript executes the provided script using either the local execution state or the execution
 // nodes depending on the node's configuration and the availability of the data.
 func (b *backendScripts) executeScript(
@@ -16,11 +16,13 @@ import (
 
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
 
+	flowaccess "github.com/onflow/flow-go/access"
 	access "github.com/onflow/flow-go/engine/access/mock"
 	connectionmock "github.com/onflow/flow-go/engine/access/rpc/connection/mock"
 	commonrpc "github.com/onflow/flow-go/engine/common/rpc"
 	fvmerrors "github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
 	execmock "github.com/onflow/flow-go/module/execution/mock"
 	"github.com/onflow/flow-go/module/irrecoverable"
 	"github.com/onflow/flow-go/module/metrics"
@@ -415,6 +417,92 @@ func (s *BackendScriptsSuite) TestExecuteScriptWithFailover_ReturnsENErrors() {
 
 // TestExecuteScriptAtLatestBlockFromStorage_InconsistentState tests that signaler context received error when node state is
 // inconsistent
+// TestExecuteScriptsAtBlockHeight tests that script batches are executed locally, and that errors
+// of the individual scripts are converted to the appropriate status code
+func (s *BackendScriptsSuite) TestExecuteScriptsAtBlockHeight() {
+	ctx := context.Background()
+	height := s.block.Header.Height
+
+	scripts := []flowaccess.Script{
+		{Script: s.script, Arguments: s.arguments},
+		{Script: s.failingScript},
+		{Script: s.script},
+	}
+	batch := []execution.Script{
+		{Code: s.script, Arguments: s.arguments},
+		{Code: s.failingScript},
+		{Code: s.script},
+	}
+
+	s.Run("happy path", func() {
+		scriptExecutor := execmock.NewScriptExecutor(s.T())
+		scriptExecutor.On("ExecuteScriptsAtBlockHeight", mock.Anything, batch, height).
+			Return([]execution.ScriptResult{
+				{Value: expectedResponse, ComputationUsed: 10},
+				{ComputationUsed: 5, Err: cadenceErr},
+				{Err: execution.ErrScriptBatchBudgetExceeded},
+			}, nil)
+
+		backend := s.defaultBackend()
+		backend.scriptExecMode = IndexQueryModeLocalOnly
+		backend.scriptExecutor = scriptExecutor
+
+		s.headers.On("ByHeight", height).Return(s.block.Header, nil).Once()
+
+		results, err := backend.ExecuteScriptsAtBlockHeight(ctx, height, scripts)
+		s.Require().NoError(err)
+		s.Require().Len(results, len(scripts))
+
+		s.Assert().Equal(expectedResponse, results[0].Value)
+		s.Assert().Equal(uint64(10), results[0].ComputationUsed)
+		s.Assert().NoError(results[0].Err)
+
+		s.Assert().Nil(results[1].Value)
+		s.Assert().Equal(uint64(5), results[1].ComputationUsed)
+		s.Assert().Equal(codes.InvalidArgument, status.Code(results[1].Err))
+
+		s.Assert().Equal(codes.ResourceExhausted, status.Code(results[2].Err))
+	})
+
+	s.Run("fails when local execution is disabled", func() {
+		backend := s.defaultBackend()
+		backend.scriptExecMode = IndexQueryModeExecutionNodesOnly
+
+		results, err := backend.ExecuteScriptsAtBlockHeight(ctx, height, scripts)
+		s.Require().Equal(codes.FailedPrecondition, status.Code(err))
+		s.Require().Nil(results)
+	})
+
+	s.Run("fails with invalid batch size", func() {
+		backend := s.defaultBackend()
+		backend.scriptExecMode = IndexQueryModeLocalOnly
+
+		results, err := backend.ExecuteScriptsAtBlockHeight(ctx, height, nil)
+		s.Require().Equal(codes.InvalidArgument, status.Code(err))
+		s.Require().Nil(results)
+
+		results, err = backend.ExecuteScriptsAtBlockHeight(ctx, height, make([]flowaccess.Script, MaxScriptsPerBatch+1))
+		s.Require().Equal(codes.InvalidArgument, status.Code(err))
+		s.Require().Nil(results)
+	})
+
+	s.Run("fails when height is not indexed", func() {
+		scriptExecutor := execmock.NewScriptExecutor(s.T())
+		scriptExecutor.On("ExecuteScriptsAtBlockHeight", mock.Anything, batch, height).
+			Return(nil, storage.ErrHeightNotIndexed)
+
+		backend := s.defaultBackend()
+		backend.scriptExecMode = IndexQueryModeLocalOnly
+		backend.scriptExecutor = scriptExecutor
+
+		s.headers.On("ByHeight", height).Return(s.block.Header, nil).Once()
+
+		results, err := backend.ExecuteScriptsAtBlockHeight(ctx, height, scripts)
+		s.Require().Equal(codes.OutOfRange, status.Code(err))
+		s.Require().Nil(results)
+	})
+}
+
 func (s *BackendScriptsSuite) TestExecuteScriptAtLatestBlockFromStorage_InconsistentState() {
 	scriptExecutor := execmock.NewScriptExecutor(s.T())
 
@@ -0,0 +1,146 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+type backendTransactionSimulations struct {
+	log            zerolog.Logger
+	headers        storage.Headers
+	state          protocol.State
+	scriptExecutor execution.ScriptExecutor
+	scriptExecMode IndexQueryMode
+}
+
+// SimulateTransaction executes the provided transaction against the state at the given block height
+// using the locally indexed registers, without submitting it or committing any of its changes.
+// If skipSignatureCheck is true, the transaction's signatures and sequence number are not verified.
+//
+// A failure of the transaction itself is returned as part of the result, not as an error.
+//
+// Expected errors during normal operations:
+// - codes.FailedPrecondition: if local script execution is not enabled.
+// - codes.NotFound: if the block at the given height is not found.
+// - codes.OutOfRange: if the registers for the given height are not indexed.
+// - codes.Canceled, codes.DeadlineExceeded: if the simulation was canceled or timed out.
+func (b *backendTransactionSimulations) SimulateTransaction(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	blockHeight uint64,
+	skipSignatureCheck bool,
+) (*flow.TransactionSimulationResult, error) {
+	// transactions can only be simulated using the locally indexed registers, execution nodes
+	// do not provide an API for it.
+	if b.scriptExecMode == IndexQueryModeExecutionNodesOnly {
+		return nil, status.Error(codes.FailedPrecondition, "transaction simulation requires local script execution to be enabled")
+	}
+
+	header, err := b.headers.ByHeight(blockHeight)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(resolveHeightError(b.state.Params(), blockHeight, err))
+	}
+
+	result, err := b.scriptExecutor.SimulateTransactionAtBlockHeight(ctx, tx, blockHeight, skipSignatureCheck)
+	if err != nil {
+		b.log.Debug().Err(err).
+			Hex("block_id", logging.ID(header.ID())).
+			Uint64("height", blockHeight).
+			Hex("tx_id", logging.Entity(tx)).
+			Msg("transaction simulation failed")
+
+		return nil, convertScriptExecutionError(err, blockHeight)
+	}
+
+	return result, nil
+}
+
+// EstimateTransactionFees executes the provided transaction against the state at the given block height
+// using the locally indexed registers, and returns the computation it used and the fees it would be
+// charged with the fee parameters at the block. The transaction's signatures are not verified, and its
+// computation is capped at the node's fee estimation computation limit.
+//
+// A failure of the transaction itself is returned as part of the estimate, not as an error.
+//
+// Expected errors during normal operations:
+// - codes.FailedPrecondition: if local script execution is not enabled.
+// - codes.NotFound: if the block at the given height is not found.
+// - codes.OutOfRange: if the registers for the given height are not indexed.
+// - codes.Canceled, codes.DeadlineExceeded: if the estimation was canceled or timed out.
+func (b *backendTransactionSimulations) EstimateTransactionFees(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	blockHeight uint64,
+) (*flow.TransactionFeeEstimate, error) {
+	if b.scriptExecMode == IndexQueryModeExecutionNodesOnly {
+		return nil, status.Error(codes.FailedPrecondition, "fee estimation requires local script execution to be enabled")
+	}
+
+	header, err := b.headers.ByHeight(blockHeight)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(resolveHeightError(b.state.Params(), blockHeight, err))
+	}
+
+	estimate, err := b.scriptExecutor.EstimateTransactionFeesAtBlockHeight(ctx, tx, blockHeight)
+	if err != nil {
+		b.log.Debug().Err(err).
+			Hex("block_id", logging.ID(header.ID())).
+			Uint64("height", blockHeight).
+			Hex("tx_id", logging.Entity(tx)).
+			Msg("transaction fee estimation failed")
+
+		return nil, convertScriptExecutionError(err, blockHeight)
+	}
+
+	return estimate, nil
+}
+
+// ValidateContractUpdate validates an update of a contract of the account to the provided code against
+// the state at the given block height using the locally indexed registers, without submitting a
+// transaction or committing any of its changes.
+//
+// The reasons for which the update would be rejected are returned as violations, not as an error.
+//
+// Expected errors during normal operations:
+// - codes.FailedPrecondition: if local script execution is not enabled.
+// - codes.NotFound: if the block at the given height is not found.
+// - codes.OutOfRange: if the registers for the given height are not indexed.
+// - codes.Canceled, codes.DeadlineExceeded: if the validation was canceled or timed out.
+func (b *backendTransactionSimulations) ValidateContractUpdate(
+	ctx context.Context,
+	address flow.Address,
+	code []byte,
+	blockHeight uint64,
+) (*flow.ContractUpdateValidation, error) {
+	if b.scriptExecMode == IndexQueryModeExecutionNodesOnly {
+		return nil, status.Error(codes.FailedPrecondition, "contract update validation requires local script execution to be enabled")
+	}
+
+	header, err := b.headers.ByHeight(blockHeight)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(resolveHeightError(b.state.Params(), blockHeight, err))
+	}
+
+	validation, err := b.scriptExecutor.ValidateContractUpdateAtBlockHeight(ctx, address, code, blockHeight)
+	if err != nil {
+		b.log.Debug().Err(err).
+			Hex("block_id", logging.ID(header.ID())).
+			Uint64("height", blockHeight).
+			Str("address", address.String()).
+			Msg("contract update validation failed")
+
+		return nil, convertScriptExecutionError(err, blockHeight)
+	}
+
+	return validation, nil
+}
@@ -173,6 +173,84 @@ func (s *ScriptExecutor) GetAccountKey(ctx context.Context, address flow.Address
 	return s.scriptExecutor.GetAccountKey(ctx, address, keyIndex, height)
 }
 
+// SimulateTransactionAtBlockHeight executes the provided transaction against the block height
+// without committing any of its changes.
+// Expected errors:
+//   - Script execution related errors
+//   - storage.ErrHeightNotIndexed if the ScriptExecutor is not initialized, or if the height is not indexed yet,
+//     or if the height is before the lowest indexed height.
+//   - ErrIncompatibleNodeVersion if the block height is not compatible with the node version.
+func (s *ScriptExecutor) SimulateTransactionAtBlockHeight(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	height uint64,
+	skipSignatureCheck bool,
+) (*flow.TransactionSimulationResult, error) {
+	if err := s.checkHeight(height); err != nil {
+		return nil, err
+	}
+
+	return s.scriptExecutor.SimulateTransactionAtBlockHeight(ctx, tx, height, skipSignatureCheck)
+}
+
+// EstimateTransactionFeesAtBlockHeight executes the provided transaction against the block height
+// without committing any of its changes, and returns the computation it used and the fees it
+// would be charged.
+// Expected errors:
+//   - Script execution related errors
+//   - storage.ErrHeightNotIndexed if the ScriptExecutor is not initialized, or if the height is not indexed yet,
+//     or if the height is before the lowest indexed height.
+//   - ErrIncompatibleNodeVersion if the block height is not compatible with the node version.
+func (s *ScriptExecutor) EstimateTransactionFeesAtBlockHeight(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	height uint64,
+) (*flow.TransactionFeeEstimate, error) {
+	if err := s.checkHeight(height); err != nil {
+		return nil, err
+	}
+
+	return s.scriptExecutor.EstimateTransactionFeesAtBlockHeight(ctx, tx, height)
+}
+
+// ValidateContractUpdateAtBlockHeight validates an update of a contract of the account to the
+// provided code against the block height, without committing any of its changes.
+// Expected errors:
+//   - Script execution related errors
+//   - storage.ErrHeightNotIndexed if the ScriptExecutor is not initialized, or if the height is not indexed yet,
+//     or if the height is before the lowest indexed height.
+//   - ErrIncompatibleNodeVersion if the block height is not compatible with the node version.
+func (s *ScriptExecutor) ValidateContractUpdateAtBlockHeight(
+	ctx context.Context,
+	address flow.Address,
+	code []byte,
+	height uint64,
+) (*flow.ContractUpdateValidation, error) {
+	if err := s.checkHeight(height); err != nil {
+		return nil, err
+	}
+
+	return s.scriptExecutor.ValidateContractUpdateAtBlockHeight(ctx, address, code, height)
+}
+
+// ExecuteScriptsAtBlockHeight executes the provided scripts concurrently against the block height,
+// and returns a result for each script in the same order.
+// Expected errors:
+//   - storage.ErrHeightNotIndexed if the ScriptExecutor is not initialized, or if the height is not indexed yet,
+//     or if the height is before the lowest indexed height.
+//   - ErrIncompatibleNodeVersion if the block height is not compatible with the node version.
+func (s *ScriptExecutor) ExecuteScriptsAtBlockHeight(
+	ctx context.Context,
+	scripts []execution.Script,
+	height uint64,
+) ([]execution.ScriptResult, error) {
+	if err := s.checkHeight(height); err != nil {
+		return nil, err
+	}
+
+	return s.scriptExecutor.ExecuteScriptsAtBlockHeight(ctx, scripts, height)
+}
+
 // checkHeight checks if the provided block height is within the range of indexed heights
 // and compatible with the node's version.
 //
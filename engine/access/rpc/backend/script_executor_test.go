@@ -143,6 +143,8 @@ func (s *ScriptExecutorSuite) SetupTest() {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
 		s.chain,
 		derivedChainData,
 		nil,
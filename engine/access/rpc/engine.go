@@ -7,12 +7,15 @@ import (
 	"net"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc/credentials"
 
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/engine/access/evmrpc"
+	"github.com/onflow/flow-go/engine/access/graphql"
 	"github.com/onflow/flow-go/engine/access/rest"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
 	"github.com/onflow/flow-go/engine/access/state_stream"
@@ -24,9 +27,14 @@ import (
 	"github.com/onflow/flow-go/module/grpcserver"
 	"github.com/onflow/flow-go/module/irrecoverable"
 	"github.com/onflow/flow-go/module/state_synchronization"
+	"github.com/onflow/flow-go/module/state_synchronization/indexer"
 	"github.com/onflow/flow-go/state/protocol"
 )
 
+// contractGraphRefreshInterval is the interval at which the contract graph used to serve contract impact
+// requests is rebuilt at the latest indexed height.
+const contractGraphRefreshInterval = 5 * time.Minute
+
 // Config defines the configurable options for the access node server
 // A secure GRPC server here implies a server that presents a self-signed TLS certificate and a client that authenticates
 // the server via a pre-shared public key
@@ -40,6 +48,8 @@ type Config struct {
 
 	BackendConfig  backend.Config // configurable options for creating Backend
 	RestConfig     rest.Config    // the REST server configuration
+	GraphQLConfig  graphql.Config // the GraphQL server configuration
+	EVMRPCConfig   evmrpc.Config  // the EVM JSON-RPC server configuration
 	MaxMsgSize     uint           // GRPC max message size
 	CompressorName string         // GRPC compressor name
 }
@@ -61,6 +71,8 @@ type Engine struct {
 	secureGrpcServer   *grpcserver.GrpcServer // the secure gRPC server
 	httpServer         *http.Server
 	restServer         *http.Server
+	graphqlServer      *http.Server
+	evmRPCServer       *http.Server
 	config             Config
 	chain              flow.Chain
 
@@ -71,6 +83,8 @@ type Engine struct {
 
 	stateStreamBackend state_stream.API
 	stateStreamConfig  statestreambackend.Config
+
+	evmBackend *evmrpc.Backend // the EVM state backend, required by the EVM JSON-RPC server
 }
 type Option func(*RPCEngineBuilder)
 
@@ -129,8 +143,11 @@ func NewBuilder(log zerolog.Logger,
 		}).
 		AddWorker(eng.serveGRPCWebProxyWorker).
 		AddWorker(eng.serveREST).
+		AddWorker(eng.serveGraphQL).
+		AddWorker(eng.serveEVMRPC).
 		AddWorker(finalizedCacheWorker).
 		AddWorker(backendNotifierWorker).
+		AddWorker(eng.refreshContractGraphWorker).
 		AddWorker(eng.shutdownWorker).
 		Build()
 
@@ -142,6 +159,35 @@ func NewBuilder(log zerolog.Logger,
 	return builder, nil
 }
 
+// refreshContractGraphWorker is a worker routine which periodically rebuilds the contract graph of the
+// backend at the latest indexed height, so that contract impact requests never build it.
+// Failed builds are logged, and the previous graph is served until the next refresh.
+func (e *Engine) refreshContractGraphWorker(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	ticker := time.NewTicker(contractGraphRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		err := e.backend.RefreshContractGraph(ctx)
+		switch {
+		case err == nil:
+		case ctx.Err() != nil:
+			return
+		case errors.Is(err, indexer.ErrIndexNotInitialized):
+			e.log.Debug().Msg("register index is not initialized, skipping contract graph refresh")
+		default:
+			e.log.Warn().Err(err).Msg("could not refresh contract graph")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // shutdownWorker is a worker routine which shuts down all servers when the context is cancelled.
 func (e *Engine) shutdownWorker(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
 	ready()
@@ -165,6 +211,18 @@ func (e *Engine) shutdown() {
 			e.log.Error().Err(err).Msg("error stopping http REST server")
 		}
 	}
+	if e.graphqlServer != nil {
+		err := e.graphqlServer.Shutdown(ctx)
+		if err != nil {
+			e.log.Error().Err(err).Msg("error stopping http GraphQL server")
+		}
+	}
+	if e.evmRPCServer != nil {
+		err := e.evmRPCServer.Shutdown(ctx)
+		if err != nil {
+			e.log.Error().Err(err).Msg("error stopping http EVM JSON-RPC server")
+		}
+	}
 }
 
 // OnFinalizedBlock responds to block finalization events.
@@ -277,3 +335,97 @@ func (e *Engine) serveREST(ctx irrecoverable.SignalerContext, ready component.Re
 		ctx.Throw(err)
 	}
 }
+
+// serveGraphQL is a worker routine which starts the HTTP GraphQL server.
+// The server is only started if a listen address is configured.
+func (e *Engine) serveGraphQL(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	if e.config.GraphQLConfig.ListenAddress == "" {
+		e.log.Debug().Msg("no GraphQL API address specified - not starting the server")
+		ready()
+		return
+	}
+
+	e.log.Info().Str("graphql_api_address", e.config.GraphQLConfig.ListenAddress).Msg("starting GraphQL server on address")
+
+	s, err := graphql.NewServer(e.restHandler, e.config.GraphQLConfig, e.log, e.chain, e.stateStreamBackend,
+		e.stateStreamConfig)
+	if err != nil {
+		e.log.Err(err).Msg("failed to initialize the GraphQL server")
+		ctx.Throw(err)
+		return
+	}
+	e.graphqlServer = s
+
+	e.graphqlServer.BaseContext = func(_ net.Listener) context.Context {
+		return irrecoverable.WithSignalerContext(ctx, ctx)
+	}
+
+	l, err := net.Listen("tcp", e.config.GraphQLConfig.ListenAddress)
+	if err != nil {
+		e.log.Err(err).Msg("failed to start the GraphQL server")
+		ctx.Throw(err)
+		return
+	}
+
+	e.log.Debug().Str("graphql_api_address", l.Addr().String()).Msg("listening on port")
+	ready()
+
+	err = e.graphqlServer.Serve(l) // blocking call
+	if err != nil {
+		if errors.Is(err, http.ErrServerClosed) {
+			return
+		}
+		e.log.Err(err).Msg("fatal error in GraphQL server")
+		ctx.Throw(err)
+	}
+}
+
+// serveEVMRPC is a worker routine which starts the HTTP EVM JSON-RPC server.
+// The server is only started if a listen address is configured.
+func (e *Engine) serveEVMRPC(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	if e.config.EVMRPCConfig.ListenAddress == "" {
+		e.log.Debug().Msg("no EVM JSON-RPC API address specified - not starting the server")
+		ready()
+		return
+	}
+
+	if e.evmBackend == nil {
+		err := errors.New("EVM JSON-RPC server requires an EVM backend")
+		e.log.Err(err).Msg("failed to initialize the EVM JSON-RPC server")
+		ctx.Throw(err)
+		return
+	}
+
+	e.log.Info().Str("evm_rpc_api_address", e.config.EVMRPCConfig.ListenAddress).Msg("starting EVM JSON-RPC server on address")
+
+	s, err := evmrpc.NewServer(e.evmBackend, e.config.EVMRPCConfig)
+	if err != nil {
+		e.log.Err(err).Msg("failed to initialize the EVM JSON-RPC server")
+		ctx.Throw(err)
+		return
+	}
+	e.evmRPCServer = s
+
+	e.evmRPCServer.BaseContext = func(_ net.Listener) context.Context {
+		return irrecoverable.WithSignalerContext(ctx, ctx)
+	}
+
+	l, err := net.Listen("tcp", e.config.EVMRPCConfig.ListenAddress)
+	if err != nil {
+		e.log.Err(err).Msg("failed to start the EVM JSON-RPC server")
+		ctx.Throw(err)
+		return
+	}
+
+	e.log.Debug().Str("evm_rpc_api_address", l.Addr().String()).Msg("listening on port")
+	ready()
+
+	err = e.evmRPCServer.Serve(l) // blocking call
+	if err != nil {
+		if errors.Is(err, http.ErrServerClosed) {
+			return
+		}
+		e.log.Err(err).Msg("fatal error in EVM JSON-RPC server")
+		ctx.Throw(err)
+	}
+}
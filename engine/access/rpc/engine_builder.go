@@ -8,8 +8,10 @@ import (
 	legacyaccessproto "github.com/onflow/flow/protobuf/go/flow/legacy/access"
 
 	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/access/extended"
 	legacyaccess "github.com/onflow/flow-go/access/legacy"
 	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/engine/access/evmrpc"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/state_synchronization"
 )
@@ -65,6 +67,15 @@ func (builder *RPCEngineBuilder) WithRpcHandler(handler accessproto.AccessAPISer
 	return builder
 }
 
+// WithEVMBackend specifies the EVM state backend used by the EVM JSON-RPC server.
+// It is required if the EVM JSON-RPC server is enabled.
+//
+// Returns self-reference for chaining.
+func (builder *RPCEngineBuilder) WithEVMBackend(evmBackend *evmrpc.Backend) *RPCEngineBuilder {
+	builder.evmBackend = evmBackend
+	return builder
+}
+
 // WithLegacy specifies that a legacy access API should be instantiated
 // Returns self-reference for chaining.
 func (builder *RPCEngineBuilder) WithLegacy() *RPCEngineBuilder {
@@ -108,5 +119,10 @@ func (builder *RPCEngineBuilder) Build() (*Engine, error) {
 	}
 	accessproto.RegisterAccessAPIServer(builder.unsecureGrpcServer.Server, rpcHandler)
 	accessproto.RegisterAccessAPIServer(builder.secureGrpcServer.Server, rpcHandler)
+
+	// endpoints which are not part of the AccessAPI yet are served by the local backend
+	extendedHandler := access.NewExtendedHandler(builder.Engine.backend, builder.Engine.chain, builder.stateStreamConfig.MaxGlobalStreams)
+	extended.RegisterExtendedAccessAPIServer(builder.unsecureGrpcServer.Server, extendedHandler)
+	extended.RegisterExtendedAccessAPIServer(builder.secureGrpcServer.Server, extendedHandler)
 	return builder.Engine, nil
 }
@@ -57,6 +57,12 @@ type Config struct {
 
 	// HeartbeatInterval specifies the block interval at which heartbeat messages should be sent.
 	HeartbeatInterval uint64
+
+	// EventCursorTTL is the duration after which event subscription cursors which are not used are removed.
+	EventCursorTTL time.Duration
+
+	// MaxEventCursors is the max number of stored event subscription cursors.
+	MaxEventCursors uint
 }
 
 type GetExecutionDataFunc func(context.Context, uint64) (*execution_data.BlockExecutionDataEntity, error)
@@ -67,6 +73,7 @@ type StateStreamBackend struct {
 	ExecutionDataBackend
 	EventsBackend
 	AccountStatusesBackend
+	*EventCursorsBackend
 
 	log                  zerolog.Logger
 	state                protocol.State
@@ -93,6 +100,11 @@ func New(
 	registerIDsRequestLimit int,
 	subscriptionHandler *subscription.SubscriptionHandler,
 	executionDataTracker subscription.ExecutionDataTracker,
+	chain flow.Chain,
+	eventFilterConfig state_stream.EventFilterConfig,
+	eventCursors storage.EventSubscriptionCursors,
+	eventCursorTTL time.Duration,
+	maxEventCursors uint,
 ) (*StateStreamBackend, error) {
 	logger := log.With().Str("module", "state_stream_api").Logger()
 
@@ -139,6 +151,19 @@ func New(
 		eventsRetriever:      eventsRetriever,
 	}
 
+	b.EventCursorsBackend = &EventCursorsBackend{
+		log:                  logger,
+		subscriptionHandler:  subscriptionHandler,
+		executionDataTracker: executionDataTracker,
+		eventsRetriever:      eventsRetriever,
+		chain:                chain,
+		eventFilterConfig:    eventFilterConfig,
+		cursors:              eventCursors,
+		ttl:                  eventCursorTTL,
+		maxCursors:           maxEventCursors,
+		active:               make(map[string]*flow.EventSubscriptionCursor),
+	}
+
 	return b, nil
 }
 
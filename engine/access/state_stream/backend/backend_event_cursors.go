@@ -0,0 +1,432 @@
+package backend
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+const (
+	// DefaultEventCursorTTL is the default duration after which unused event subscription cursors are removed.
+	DefaultEventCursorTTL = 24 * time.Hour
+
+	// DefaultMaxEventCursors is the default maximum number of stored event subscription cursors.
+	DefaultMaxEventCursors = 10_000
+
+	// MaxEventCursorNameLength is the maximum length of the name of an event subscription cursor.
+	MaxEventCursorNameLength = 128
+
+	// eventCursorNameBytes is the number of random bytes of the names issued for cursors, which
+	// makes them unguessable by other clients.
+	eventCursorNameBytes = 16
+)
+
+// EventCursorsBackend implements durable, named event subscriptions.
+//
+// A cursor records the filter of the subscription together with the position of the last event
+// delivered to and acknowledged by the client in the local database. A client reconnecting with the
+// name of the cursor resumes right after the last acknowledged event.
+//
+// Cursor names are random and issued by the server, so a client can only use the cursors it created.
+// Each cursor can be used by at most one subscription at a time. Cursors which are not used for
+// longer than the TTL are removed, and at most maxCursors cursors are stored.
+type EventCursorsBackend struct {
+	log zerolog.Logger
+
+	subscriptionHandler  *subscription.SubscriptionHandler
+	executionDataTracker subscription.ExecutionDataTracker
+	eventsRetriever      EventsRetriever
+	chain                flow.Chain
+	eventFilterConfig    state_stream.EventFilterConfig
+	cursors              storage.EventSubscriptionCursors
+	ttl                  time.Duration
+	maxCursors           uint
+
+	// mu protects active, and serializes all updates of stored cursors.
+	mu sync.Mutex
+	// active holds the cursors which are currently used by a subscription.
+	active map[string]*flow.EventSubscriptionCursor
+}
+
+// CreateEventCursor registers a new cursor with the provided filter, which starts at startHeight, or
+// the latest sealed block if startHeight is 0, and returns its name. The name is issued by the
+// server, and must be kept secret by the client, since it grants access to the cursor.
+//
+// Expected errors during normal operation:
+// - codes.FailedPrecondition: if event cursors are not enabled.
+// - codes.ResourceExhausted: if the maximum number of cursors is stored.
+// - codes.InvalidArgument: if the start height is invalid.
+// - codes.NotFound: if the start block is not available.
+func (b *EventCursorsBackend) CreateEventCursor(ctx context.Context, startHeight uint64, filter state_stream.EventFilter) (string, error) {
+	if b.cursors == nil {
+		return "", status.Error(codes.FailedPrecondition, "event cursors are not enabled")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	count, err := b.cursors.Count()
+	if err != nil {
+		return "", fmt.Errorf("could not count cursors: %w", err)
+	}
+	if uint(count) >= b.maxCursors {
+		return "", status.Errorf(codes.ResourceExhausted, "maximum number of event cursors reached: %d", b.maxCursors)
+	}
+
+	name, err := newEventCursorName()
+	if err != nil {
+		return "", err
+	}
+
+	cursor, err := b.newCursor(ctx, name, startHeight, filter)
+	if err != nil {
+		return "", err
+	}
+
+	cursor.LastActive = time.Now()
+	err = b.cursors.Store(cursor)
+	if err != nil {
+		return "", fmt.Errorf("could not store cursor: %w", err)
+	}
+	return name, nil
+}
+
+// SubscribeEventsFromCursor streams the events matching the filter of the named cursor, starting right
+// after the last event acknowledged on the cursor. Once the latest block is reached, the stream will
+// remain open and responses are sent for each new block as it becomes available.
+//
+// The cursor must have been created with CreateEventCursor. The filter must either be empty or
+// match the filter the cursor was created with.
+//
+// Parameters:
+// - ctx: Context for the operation.
+// - name: The name of the cursor.
+// - filter: The event filter used to filter events.
+//
+// If invalid parameters will be supplied SubscribeEventsFromCursor will return a failed subscription.
+func (b *EventCursorsBackend) SubscribeEventsFromCursor(ctx context.Context, name string, filter state_stream.EventFilter) subscription.Subscription {
+	if b.cursors == nil {
+		return subscription.NewFailedSubscription(status.Error(codes.FailedPrecondition, "event cursors are not enabled"), "could not subscribe")
+	}
+	if err := validateEventCursorName(name); err != nil {
+		return subscription.NewFailedSubscription(err, "invalid cursor name")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.active[name]; ok {
+		return subscription.NewFailedSubscription(status.Errorf(codes.FailedPrecondition, "cursor %q is already in use", name), "could not subscribe")
+	}
+
+	cursor, err := b.cursors.ByName(name)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return subscription.NewFailedSubscription(err, "could not get cursor")
+	}
+	if cursor == nil || b.expired(cursor, time.Now()) {
+		// an expired cursor is about to be removed, treat it as if it was already gone
+		return subscription.NewFailedSubscription(status.Errorf(codes.NotFound, "cursor %q not found", name), "could not subscribe")
+	}
+
+	if !filterMatchesCursor(filter, cursor) {
+		return subscription.NewFailedSubscription(
+			status.Errorf(codes.InvalidArgument, "filter does not match the filter of cursor %q", name), "could not subscribe")
+	}
+
+	cursorFilter, err := state_stream.NewEventFilterWithExpressions(b.eventFilterConfig, b.chain, cursor.EventTypes, cursor.Addresses, cursor.Contracts, cursor.Expressions)
+	if err != nil {
+		return subscription.NewFailedSubscription(err, "could not create filter of cursor")
+	}
+
+	nextHeight := cursor.StartHeight
+	if cursor.LastAcknowledged != nil {
+		nextHeight = cursor.LastAcknowledged.BlockHeight
+	}
+	nextHeight, err = b.executionDataTracker.GetStartHeightFromHeight(nextHeight)
+	if err != nil {
+		return subscription.NewFailedSubscription(err, "could not resume cursor")
+	}
+
+	cursor.LastActive = time.Now()
+	err = b.cursors.Store(cursor)
+	if err != nil {
+		return subscription.NewFailedSubscription(err, "could not store cursor")
+	}
+	b.active[name] = cursor
+
+	// release the cursor once the subscription ends
+	go func() {
+		<-ctx.Done()
+		b.release(name)
+	}()
+
+	var acknowledged *flow.EventPosition
+	if cursor.LastAcknowledged != nil {
+		position := *cursor.LastAcknowledged
+		acknowledged = &position
+	}
+
+	return b.subscriptionHandler.Subscribe(ctx, nextHeight, b.getResponseFactory(name, cursorFilter, acknowledged))
+}
+
+// AcknowledgeEvents marks all events up to and including the given position as processed by the client.
+// A subscription resuming from the cursor starts right after the last acknowledged event.
+// Acknowledging a position at or before the last acknowledged event is a no-op.
+//
+// Expected errors during normal operation:
+// - codes.FailedPrecondition: if event cursors are not enabled.
+// - codes.NotFound: if the cursor does not exist.
+// - codes.InvalidArgument: if the position is after the last event delivered to the client.
+func (b *EventCursorsBackend) AcknowledgeEvents(_ context.Context, name string, position flow.EventPosition) error {
+	if b.cursors == nil {
+		return status.Error(codes.FailedPrecondition, "event cursors are not enabled")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cursor, ok := b.active[name]
+	if !ok {
+		var err error
+		cursor, err = b.cursors.ByName(name)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return status.Errorf(codes.NotFound, "cursor %q not found", name)
+			}
+			return fmt.Errorf("could not get cursor: %w", err)
+		}
+	}
+
+	if cursor.LastDelivered == nil || cursor.LastDelivered.Before(position) {
+		return status.Errorf(codes.InvalidArgument, "cannot acknowledge events which were not delivered on cursor %q", name)
+	}
+
+	if cursor.LastAcknowledged == nil || cursor.LastAcknowledged.Before(position) {
+		cursor.LastAcknowledged = &position
+	}
+	cursor.LastActive = time.Now()
+
+	err := b.cursors.Store(cursor)
+	if err != nil {
+		return fmt.Errorf("could not store cursor: %w", err)
+	}
+	return nil
+}
+
+// DeleteEventCursor removes the cursor with the given name.
+//
+// Expected errors during normal operation:
+// - codes.FailedPrecondition: if event cursors are not enabled, or the cursor is used by a subscription.
+// - codes.NotFound: if the cursor does not exist.
+func (b *EventCursorsBackend) DeleteEventCursor(_ context.Context, name string) error {
+	if b.cursors == nil {
+		return status.Error(codes.FailedPrecondition, "event cursors are not enabled")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.active[name]; ok {
+		return status.Errorf(codes.FailedPrecondition, "cursor %q is in use", name)
+	}
+
+	_, err := b.cursors.ByName(name)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return status.Errorf(codes.NotFound, "cursor %q not found", name)
+		}
+		return fmt.Errorf("could not get cursor: %w", err)
+	}
+
+	err = b.cursors.Remove(name)
+	if err != nil {
+		return fmt.Errorf("could not remove cursor: %w", err)
+	}
+	return nil
+}
+
+// RemoveExpiredEventCursors removes all cursors which were not used for longer than the TTL.
+// Cursors used by a subscription never expire.
+//
+// No errors are expected during normal operation.
+func (b *EventCursorsBackend) RemoveExpiredEventCursors() error {
+	if b.cursors == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+
+	// refresh the cursors in use, so they are not removed
+	for _, cursor := range b.active {
+		cursor.LastActive = now
+		err := b.cursors.Store(cursor)
+		if err != nil {
+			return fmt.Errorf("could not store cursor %q: %w", cursor.Name, err)
+		}
+	}
+
+	removed, err := b.cursors.RemoveInactive(now.Add(-b.ttl))
+	if err != nil {
+		return fmt.Errorf("could not remove inactive cursors: %w", err)
+	}
+	if len(removed) > 0 {
+		b.log.Info().Strs("cursors", removed).Msg("removed expired event cursors")
+	}
+
+	return nil
+}
+
+// newCursor creates a new cursor which starts at the given height, or the latest sealed block if
+// startHeight is 0.
+//
+// Expected errors during normal operation:
+// - codes.InvalidArgument: if the start height is invalid.
+// - codes.NotFound: if the start block is not available.
+func (b *EventCursorsBackend) newCursor(ctx context.Context, name string, startHeight uint64, filter state_stream.EventFilter) (*flow.EventSubscriptionCursor, error) {
+	var err error
+	if startHeight == 0 {
+		startHeight, err = b.executionDataTracker.GetStartHeightFromLatest(ctx)
+	} else {
+		startHeight, err = b.executionDataTracker.GetStartHeightFromHeight(startHeight)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	eventTypes, addresses, contracts, expressions := filter.Criteria()
+	return &flow.EventSubscriptionCursor{
+		Name:        name,
+		EventTypes:  eventTypes,
+		Addresses:   addresses,
+		Contracts:   contracts,
+		Expressions: expressions,
+		StartHeight: startHeight,
+	}, nil
+}
+
+// release marks the cursor as no longer used, and persists its progress.
+func (b *EventCursorsBackend) release(name string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cursor, ok := b.active[name]
+	if !ok {
+		return
+	}
+	delete(b.active, name)
+
+	cursor.LastActive = time.Now()
+	err := b.cursors.Store(cursor)
+	if err != nil {
+		b.log.Error().Err(err).Str("cursor", name).Msg("could not store cursor")
+	}
+}
+
+// delivered records that all events of the block at the given height were delivered on the cursor.
+func (b *EventCursorsBackend) delivered(name string, height uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cursor, ok := b.active[name]
+	if !ok {
+		return
+	}
+
+	position := flow.EndOfBlockPosition(height)
+	cursor.LastDelivered = &position
+}
+
+// expired returns true if the cursor was not used for longer than the TTL.
+func (b *EventCursorsBackend) expired(cursor *flow.EventSubscriptionCursor, now time.Time) bool {
+	return cursor.LastActive.Before(now.Add(-b.ttl))
+}
+
+// getResponseFactory returns a function that retrieves the event response of the cursor for a given height.
+// Events at or before the acknowledged position are skipped.
+//
+// Expected errors during normal operation:
+// - subscription.ErrBlockNotReady: execution data for the given block height is not available.
+func (b *EventCursorsBackend) getResponseFactory(name string, filter state_stream.EventFilter, acknowledged *flow.EventPosition) subscription.GetDataByHeightFunc {
+	return func(ctx context.Context, height uint64) (interface{}, error) {
+		eventsResponse, err := b.eventsRetriever.GetAllEventsResponse(ctx, height)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) ||
+				errors.Is(err, storage.ErrHeightNotIndexed) {
+				return nil, subscription.ErrBlockNotReady
+			}
+			return nil, fmt.Errorf("block %d is not available yet: %w", height, subscription.ErrBlockNotReady)
+		}
+
+		eventsResponse.Events = filter.Filter(eventsResponse.Events)
+
+		if acknowledged != nil && height == acknowledged.BlockHeight {
+			var unacknowledged flow.EventsList
+			for _, event := range eventsResponse.Events {
+				if acknowledged.Before(flow.PositionOf(height, event)) {
+					unacknowledged = append(unacknowledged, event)
+				}
+			}
+			eventsResponse.Events = unacknowledged
+		}
+
+		b.delivered(name, height)
+
+		return eventsResponse, nil
+	}
+}
+
+// newEventCursorName returns a new random cursor name.
+//
+// No errors are expected during normal operation.
+func newEventCursorName() (string, error) {
+	name := make([]byte, eventCursorNameBytes)
+	_, err := rand.Read(name)
+	if err != nil {
+		return "", fmt.Errorf("could not generate cursor name: %w", err)
+	}
+	return hex.EncodeToString(name), nil
+}
+
+// validateEventCursorName checks that the name of a cursor is not empty and not too long.
+//
+// Expected errors during normal operation:
+// - codes.InvalidArgument: if the name is invalid.
+func validateEventCursorName(name string) error {
+	if name == "" {
+		return status.Error(codes.InvalidArgument, "cursor name must not be empty")
+	}
+	if len(name) > MaxEventCursorNameLength {
+		return status.Errorf(codes.InvalidArgument, "cursor name must be at most %d characters long", MaxEventCursorNameLength)
+	}
+	return nil
+}
+
+// filterMatchesCursor returns true if the filter is empty, or matches the filter of the cursor.
+func filterMatchesCursor(filter state_stream.EventFilter, cursor *flow.EventSubscriptionCursor) bool {
+	eventTypes, addresses, contracts, expressions := filter.Criteria()
+	if len(eventTypes) == 0 && len(addresses) == 0 && len(contracts) == 0 && len(expressions) == 0 {
+		return true
+	}
+
+	return slices.Equal(eventTypes, cursor.EventTypes) &&
+		slices.Equal(addresses, cursor.Addresses) &&
+		slices.Equal(contracts, cursor.Contracts) &&
+		slices.Equal(expressions, cursor.Expressions)
+}
@@ -0,0 +1,293 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// BackendEventCursorsSuite tests durable event subscriptions using named cursors.
+type BackendEventCursorsSuite struct {
+	BackendExecutionDataSuite
+
+	cursors *bstorage.EventSubscriptionCursors
+}
+
+func TestBackendEventCursorsSuite(t *testing.T) {
+	suite.Run(t, new(BackendEventCursorsSuite))
+}
+
+// SetupTest initializes the test suite.
+func (s *BackendEventCursorsSuite) SetupTest() {
+	s.BackendExecutionDataSuite.SetupTest()
+}
+
+// setupCursors configures the backend with a badger backed cursor storage, and makes all blocks available.
+func (s *BackendEventCursorsSuite) setupCursors() {
+	db, _ := unittest.TempBadgerDB(s.T())
+	s.T().Cleanup(func() {
+		require.NoError(s.T(), db.Close())
+	})
+	s.cursors = bstorage.NewEventSubscriptionCursors(db)
+	s.backend.EventCursorsBackend.cursors = s.cursors
+
+	s.highestBlockHeader = s.blocks[len(s.blocks)-1].Header
+
+	s.executionDataTracker.On(
+		"GetStartHeightFromHeight",
+		mock.AnythingOfType("uint64"),
+	).Return(func(startHeight uint64) (uint64, error) {
+		return s.executionDataTrackerReal.GetStartHeightFromHeight(startHeight)
+	}, nil).Maybe()
+}
+
+// TestSubscribeAndResume tests that a subscription resuming from a cursor starts right after the
+// last acknowledged event.
+func (s *BackendEventCursorsSuite) TestSubscribeAndResume() {
+	s.setupCursors()
+
+	name := s.createCursor(s.blocks[0].Header.Height, state_stream.EventFilter{})
+
+	// the second block contains events of 4 different transactions
+	block := s.blocks[1]
+	blockEvents := s.blockEvents[block.ID()]
+	require.Len(s.T(), blockEvents, 4)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := s.backend.SubscribeEventsFromCursor(ctx, name, state_stream.EventFilter{})
+	s.requireNextEvents(sub, s.blocks[0], s.blockEvents[s.blocks[0].ID()])
+	s.requireNextEvents(sub, block, blockEvents)
+
+	// events which were not delivered yet cannot be acknowledged
+	err := s.backend.AcknowledgeEvents(ctx, name, flow.EndOfBlockPosition(s.highestBlockHeader.Height+1))
+	require.Equal(s.T(), codes.InvalidArgument, status.Code(err))
+
+	err = s.backend.AcknowledgeEvents(ctx, name, flow.PositionOf(block.Header.Height, blockEvents[1]))
+	require.NoError(s.T(), err)
+
+	// acknowledging an earlier event is a no-op
+	err = s.backend.AcknowledgeEvents(ctx, name, flow.PositionOf(block.Header.Height, blockEvents[0]))
+	require.NoError(s.T(), err)
+
+	cancel()
+	s.requireReleased(name)
+
+	// resuming skips the acknowledged events
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	sub = s.backend.SubscribeEventsFromCursor(ctx, name, state_stream.EventFilter{})
+	s.requireNextEvents(sub, block, blockEvents[2:])
+	s.requireNextEvents(sub, s.blocks[2], s.blockEvents[s.blocks[2].ID()])
+
+	stored, err := s.cursors.ByName(name)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), flow.PositionOf(block.Header.Height, blockEvents[1]), *stored.LastAcknowledged)
+}
+
+// TestSubscribeWithFilter tests that the filter of the cursor is used when resuming, and that
+// a different filter is rejected.
+func (s *BackendEventCursorsSuite) TestSubscribeWithFilter() {
+	s.setupCursors()
+
+	filter, err := state_stream.NewEventFilter(state_stream.DefaultEventFilterConfig, chainID.Chain(), []string{string(testEventTypes[0])}, nil, nil)
+	require.NoError(s.T(), err)
+	otherFilter, err := state_stream.NewEventFilter(state_stream.DefaultEventFilterConfig, chainID.Chain(), []string{string(testEventTypes[1])}, nil, nil)
+	require.NoError(s.T(), err)
+
+	name := s.createCursor(s.blocks[0].Header.Height, filter)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := s.backend.SubscribeEventsFromCursor(ctx, name, filter)
+	s.requireNextEvents(sub, s.blocks[0], filter.Filter(s.blockEvents[s.blocks[0].ID()]))
+
+	cancel()
+	s.requireReleased(name)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	sub = s.backend.SubscribeEventsFromCursor(ctx, name, otherFilter)
+	s.requireFailed(sub, codes.InvalidArgument)
+
+	// an empty filter resumes with the filter of the cursor
+	sub = s.backend.SubscribeEventsFromCursor(ctx, name, state_stream.EventFilter{})
+	s.requireNextEvents(sub, s.blocks[0], filter.Filter(s.blockEvents[s.blocks[0].ID()]))
+}
+
+// TestCursorInUse tests that a cursor can only be used by a single subscription at a time.
+func (s *BackendEventCursorsSuite) TestCursorInUse() {
+	s.setupCursors()
+
+	name := s.createCursor(s.blocks[0].Header.Height, state_stream.EventFilter{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub := s.backend.SubscribeEventsFromCursor(ctx, name, state_stream.EventFilter{})
+	s.requireNextEvents(sub, s.blocks[0], s.blockEvents[s.blocks[0].ID()])
+
+	other := s.backend.SubscribeEventsFromCursor(ctx, name, state_stream.EventFilter{})
+	s.requireFailed(other, codes.FailedPrecondition)
+
+	err := s.backend.DeleteEventCursor(ctx, name)
+	require.Equal(s.T(), codes.FailedPrecondition, status.Code(err))
+}
+
+// TestDeleteEventCursor tests deleting cursors.
+func (s *BackendEventCursorsSuite) TestDeleteEventCursor() {
+	s.setupCursors()
+
+	name := s.createCursor(s.blocks[0].Header.Height, state_stream.EventFilter{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := s.backend.SubscribeEventsFromCursor(ctx, name, state_stream.EventFilter{})
+	s.requireNextEvents(sub, s.blocks[0], s.blockEvents[s.blocks[0].ID()])
+	cancel()
+	s.requireReleased(name)
+
+	err := s.backend.DeleteEventCursor(context.Background(), name)
+	require.NoError(s.T(), err)
+
+	_, err = s.cursors.ByName(name)
+	require.ErrorIs(s.T(), err, storage.ErrNotFound)
+
+	err = s.backend.DeleteEventCursor(context.Background(), name)
+	require.Equal(s.T(), codes.NotFound, status.Code(err))
+
+	err = s.backend.AcknowledgeEvents(context.Background(), name, flow.EndOfBlockPosition(s.blocks[0].Header.Height))
+	require.Equal(s.T(), codes.NotFound, status.Code(err))
+}
+
+// TestRemoveExpiredEventCursors tests that cursors are removed once they were not used for longer
+// than the TTL, unless they are used by a subscription.
+func (s *BackendEventCursorsSuite) TestRemoveExpiredEventCursors() {
+	s.setupCursors()
+
+	expired := &flow.EventSubscriptionCursor{
+		Name:       "expired",
+		LastActive: time.Now().Add(-2 * DefaultEventCursorTTL),
+	}
+	require.NoError(s.T(), s.cursors.Store(expired))
+
+	// an expired cursor can't be resumed, even before it's removed
+	sub := s.backend.SubscribeEventsFromCursor(context.Background(), expired.Name, state_stream.EventFilter{})
+	s.requireFailed(sub, codes.NotFound)
+
+	active := s.createCursor(s.blocks[0].Header.Height, state_stream.EventFilter{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub = s.backend.SubscribeEventsFromCursor(ctx, active, state_stream.EventFilter{})
+	s.requireNextEvents(sub, s.blocks[0], s.blockEvents[s.blocks[0].ID()])
+
+	// the active cursor was last stored long ago, but is in use
+	s.backend.EventCursorsBackend.mu.Lock()
+	s.backend.EventCursorsBackend.active[active].LastActive = time.Now().Add(-2 * DefaultEventCursorTTL)
+	s.backend.EventCursorsBackend.mu.Unlock()
+
+	err := s.backend.RemoveExpiredEventCursors()
+	require.NoError(s.T(), err)
+
+	_, err = s.cursors.ByName(expired.Name)
+	require.ErrorIs(s.T(), err, storage.ErrNotFound)
+	_, err = s.cursors.ByName(active)
+	require.NoError(s.T(), err)
+}
+
+// TestCreateEventCursor tests that cursor names are issued by the server, and that the number of
+// stored cursors is limited.
+func (s *BackendEventCursorsSuite) TestCreateEventCursor() {
+	s.setupCursors()
+	s.backend.EventCursorsBackend.maxCursors = 2
+
+	first := s.createCursor(s.blocks[0].Header.Height, state_stream.EventFilter{})
+	second := s.createCursor(s.blocks[0].Header.Height, state_stream.EventFilter{})
+	require.NotEqual(s.T(), first, second)
+	require.Len(s.T(), first, 2*eventCursorNameBytes)
+
+	// cursors which were not created can't be used
+	sub := s.backend.SubscribeEventsFromCursor(context.Background(), "wallet", state_stream.EventFilter{})
+	s.requireFailed(sub, codes.NotFound)
+
+	_, err := s.backend.CreateEventCursor(context.Background(), s.blocks[0].Header.Height, state_stream.EventFilter{})
+	require.Equal(s.T(), codes.ResourceExhausted, status.Code(err))
+
+	// deleting a cursor makes room for another one
+	require.NoError(s.T(), s.backend.DeleteEventCursor(context.Background(), first))
+	s.createCursor(s.blocks[0].Header.Height, state_stream.EventFilter{})
+}
+
+// TestEventCursorsDisabled tests that all cursor operations fail if no cursor storage is configured.
+func (s *BackendEventCursorsSuite) TestEventCursorsDisabled() {
+	s.setupCursors()
+
+	s.backend.EventCursorsBackend.cursors = nil
+
+	_, err := s.backend.CreateEventCursor(context.Background(), 0, state_stream.EventFilter{})
+	require.Equal(s.T(), codes.FailedPrecondition, status.Code(err))
+
+	sub := s.backend.SubscribeEventsFromCursor(context.Background(), "disabled", state_stream.EventFilter{})
+	s.requireFailed(sub, codes.FailedPrecondition)
+
+	err = s.backend.AcknowledgeEvents(context.Background(), "disabled", flow.EndOfBlockPosition(0))
+	require.Equal(s.T(), codes.FailedPrecondition, status.Code(err))
+
+	err = s.backend.DeleteEventCursor(context.Background(), "disabled")
+	require.Equal(s.T(), codes.FailedPrecondition, status.Code(err))
+}
+
+// createCursor creates a cursor which starts at the given height, and returns its name.
+func (s *BackendEventCursorsSuite) createCursor(startHeight uint64, filter state_stream.EventFilter) string {
+	name, err := s.backend.CreateEventCursor(context.Background(), startHeight, filter)
+	require.NoError(s.T(), err)
+	return name
+}
+
+// requireNextEvents requires the next response of the subscription to contain the expected events of the block.
+func (s *BackendEventCursorsSuite) requireNextEvents(sub subscription.Subscription, block *flow.Block, expected []flow.Event) {
+	unittest.RequireReturnsBefore(s.T(), func() {
+		v, ok := <-sub.Channel()
+		require.True(s.T(), ok, "channel closed while waiting for block %d: err: %v", block.Header.Height, sub.Err())
+
+		actual, ok := v.(*EventsResponse)
+		require.True(s.T(), ok, "unexpected response type: %T", v)
+		require.Equal(s.T(), block.ID(), actual.BlockID)
+		require.Equal(s.T(), block.Header.Height, actual.Height)
+		require.Equal(s.T(), flow.EventsList(expected), actual.Events)
+	}, time.Second, fmt.Sprintf("timed out waiting for block %d", block.Header.Height))
+}
+
+// requireFailed requires the subscription to fail with the given code.
+func (s *BackendEventCursorsSuite) requireFailed(sub subscription.Subscription, code codes.Code) {
+	unittest.RequireReturnsBefore(s.T(), func() {
+		_, ok := <-sub.Channel()
+		require.False(s.T(), ok)
+		require.Equal(s.T(), code, status.Code(sub.Err()), "unexpected error: %v", sub.Err())
+	}, time.Second, "timed out waiting for subscription to fail")
+}
+
+// requireReleased waits until the cursor is no longer used by a subscription.
+func (s *BackendEventCursorsSuite) requireReleased(name string) {
+	require.Eventually(s.T(), func() bool {
+		s.backend.EventCursorsBackend.mu.Lock()
+		defer s.backend.EventCursorsBackend.mu.Unlock()
+		_, ok := s.backend.EventCursorsBackend.active[name]
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
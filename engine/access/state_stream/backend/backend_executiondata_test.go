@@ -255,6 +255,11 @@ func (s *BackendExecutionDataSuite) SetupBackend(useEventsIndex bool) {
 			subscription.DefaultSendBufferSize,
 		),
 		s.executionDataTracker,
+		chainID.Chain(),
+		state_stream.DefaultEventFilterConfig,
+		nil,
+		DefaultEventCursorTTL,
+		DefaultMaxEventCursors,
 	)
 	require.NoError(s.T(), err)
 
@@ -1,10 +1,13 @@
 package backend
 
 import (
+	"time"
+
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow/protobuf/go/flow/executiondata"
 
+	"github.com/onflow/flow-go/access/extended"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/component"
 	"github.com/onflow/flow-go/module/executiondatasync/execution_data/cache"
@@ -13,6 +16,9 @@ import (
 	"github.com/onflow/flow-go/storage"
 )
 
+// eventCursorsCleanupInterval is the interval at which expired event subscription cursors are removed.
+const eventCursorsCleanupInterval = 10 * time.Minute
+
 // Engine exposes the server with the state stream API.
 // By default, this engine is not enabled.
 // In order to run this engine a port for the GRPC server to be served on should be specified in the run config.
@@ -55,9 +61,32 @@ func NewEng(
 			ready()
 			<-server.Done()
 		}).
+		AddWorker(e.cleanupEventCursors).
 		Build()
 
 	executiondata.RegisterExecutionDataAPIServer(server.Server, e.handler)
+	extended.RegisterExtendedExecutionDataAPIServer(server.Server, NewExtendedHandler(e.handler))
 
 	return e, nil
 }
+
+// cleanupEventCursors periodically removes event subscription cursors which were not used for longer than the TTL.
+func (e *Engine) cleanupEventCursors(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	ticker := time.NewTicker(eventCursorsCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := e.backend.RemoveExpiredEventCursors()
+			if err != nil {
+				ctx.Throw(err)
+				return
+			}
+		}
+	}
+}
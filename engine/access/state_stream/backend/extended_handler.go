@@ -0,0 +1,217 @@
+package backend
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/extended"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+)
+
+// ExtendedHandler serves the ExtendedExecutionDataAPI, the execution data endpoints which are not part of
+// the ExecutionDataAPI service of the onflow/flow protobuf definitions yet.
+// It shares the streams limit and the heartbeat configuration of the Handler it is created from.
+type ExtendedHandler struct {
+	handler *Handler
+}
+
+var _ extended.ExtendedExecutionDataAPIServer = (*ExtendedHandler)(nil)
+
+func NewExtendedHandler(handler *Handler) *ExtendedHandler {
+	return &ExtendedHandler{
+		handler: handler,
+	}
+}
+
+// CreateEventCursor creates a durable event subscription cursor, and returns its name.
+//
+// Expected errors during normal operation:
+// - codes.InvalidArgument - if invalid event filter is provided.
+// - codes.FailedPrecondition - if event cursors are not enabled.
+// - codes.ResourceExhausted - if the maximum number of cursors is reached.
+func (h *ExtendedHandler) CreateEventCursor(ctx context.Context, request *extended.CreateEventCursorRequest) (*extended.CreateEventCursorResponse, error) {
+	filter, err := h.getEventFilter(request.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := h.handler.api.CreateEventCursor(ctx, request.GetStartBlockHeight(), filter)
+	if err != nil {
+		return nil, rpc.ConvertError(err, "could not create event cursor", codes.Internal)
+	}
+
+	return &extended.CreateEventCursorResponse{Name: name}, nil
+}
+
+// SubscribeEventsFromCursor handles subscription requests for the events of a cursor, starting right after
+// the last event acknowledged on the cursor. The handler manages the subscription and sends the subscribed
+// information to the client via the provided stream.
+//
+// Responses are returned for each block containing at least one event that matches the filter, and
+// heartbeat responses are returned periodically, like for SubscribeEventsFromStartHeight.
+//
+// Expected errors during normal operation:
+// - codes.InvalidArgument   - if invalid event filter is provided, or it doesn't match the filter of the cursor.
+// - codes.NotFound          - if the cursor does not exist.
+// - codes.FailedPrecondition - if event cursors are not enabled, or the cursor is already in use.
+// - codes.ResourceExhausted - if the maximum number of streams is reached.
+// - codes.Internal          - could not convert events to entity, if stream encountered an error, if stream got unexpected response or could not send response.
+func (h *ExtendedHandler) SubscribeEventsFromCursor(request *extended.SubscribeEventsFromCursorRequest, stream extended.ExtendedExecutionDataAPI_SubscribeEventsFromCursorServer) error {
+	// check if the maximum number of streams is reached
+	if h.handler.StreamCount.Load() >= h.handler.MaxStreams {
+		return status.Errorf(codes.ResourceExhausted, "maximum number of streams reached")
+	}
+	h.handler.StreamCount.Add(1)
+	defer h.handler.StreamCount.Add(-1)
+
+	filter, err := h.getEventFilter(request.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	sub := h.handler.api.SubscribeEventsFromCursor(stream.Context(), request.GetName(), filter)
+
+	return subscription.HandleSubscription(sub, h.handler.handleEventsResponse(stream.Send, request.GetHeartbeatInterval(), request.GetEventEncodingVersion()))
+}
+
+// AcknowledgeEvents marks all events up to and including the given position as processed by the client of
+// the cursor.
+//
+// Expected errors during normal operation:
+// - codes.InvalidArgument - if no position is provided, or the position is after the last delivered event.
+// - codes.NotFound - if the cursor does not exist.
+// - codes.FailedPrecondition - if event cursors are not enabled.
+func (h *ExtendedHandler) AcknowledgeEvents(ctx context.Context, request *extended.AcknowledgeEventsRequest) (*extended.AcknowledgeEventsResponse, error) {
+	if request.GetPosition() == nil {
+		return nil, status.Error(codes.InvalidArgument, "position is required")
+	}
+
+	err := h.handler.api.AcknowledgeEvents(ctx, request.GetName(), convert.MessageToEventPosition(request.GetPosition()))
+	if err != nil {
+		return nil, rpc.ConvertError(err, "could not acknowledge events", codes.Internal)
+	}
+
+	return &extended.AcknowledgeEventsResponse{}, nil
+}
+
+// DeleteEventCursor removes the cursor.
+//
+// Expected errors during normal operation:
+// - codes.NotFound - if the cursor does not exist.
+// - codes.FailedPrecondition - if event cursors are not enabled, or the cursor is in use.
+func (h *ExtendedHandler) DeleteEventCursor(ctx context.Context, request *extended.DeleteEventCursorRequest) (*extended.DeleteEventCursorResponse, error) {
+	err := h.handler.api.DeleteEventCursor(ctx, request.GetName())
+	if err != nil {
+		return nil, rpc.ConvertError(err, "could not delete event cursor", codes.Internal)
+	}
+
+	return &extended.DeleteEventCursorResponse{}, nil
+}
+
+// SubscribeEventsFromStartBlockID handles subscription requests for events starting at the specified block ID.
+// It behaves like the ExecutionDataAPI endpoint of the same name, but its filter accepts event expressions.
+//
+// Expected errors during normal operation:
+// - codes.InvalidArgument   - if invalid start block ID or event filter is provided.
+// - codes.ResourceExhausted - if the maximum number of streams is reached.
+// - codes.Internal          - could not convert events to entity, if stream encountered an error, if stream got unexpected response or could not send response.
+func (h *ExtendedHandler) SubscribeEventsFromStartBlockID(request *extended.SubscribeEventsFromStartBlockIDRequest, stream extended.ExtendedExecutionDataAPI_SubscribeEventsFromStartBlockIDServer) error {
+	// check if the maximum number of streams is reached
+	if h.handler.StreamCount.Load() >= h.handler.MaxStreams {
+		return status.Errorf(codes.ResourceExhausted, "maximum number of streams reached")
+	}
+	h.handler.StreamCount.Add(1)
+	defer h.handler.StreamCount.Add(-1)
+
+	startBlockID, err := convert.BlockID(request.GetStartBlockId())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "could not convert start block ID: %v", err)
+	}
+
+	filter, err := h.getEventFilter(request.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	sub := h.handler.api.SubscribeEventsFromStartBlockID(stream.Context(), startBlockID, filter)
+
+	return subscription.HandleSubscription(sub, h.handler.handleEventsResponse(stream.Send, request.GetHeartbeatInterval(), request.GetEventEncodingVersion()))
+}
+
+// SubscribeEventsFromStartHeight handles subscription requests for events starting at the specified block height.
+// It behaves like the ExecutionDataAPI endpoint of the same name, but its filter accepts event expressions.
+//
+// Expected errors during normal operation:
+// - codes.InvalidArgument   - if invalid event filter is provided.
+// - codes.ResourceExhausted - if the maximum number of streams is reached.
+// - codes.Internal          - could not convert events to entity, if stream encountered an error, if stream got unexpected response or could not send response.
+func (h *ExtendedHandler) SubscribeEventsFromStartHeight(request *extended.SubscribeEventsFromStartHeightRequest, stream extended.ExtendedExecutionDataAPI_SubscribeEventsFromStartHeightServer) error {
+	// check if the maximum number of streams is reached
+	if h.handler.StreamCount.Load() >= h.handler.MaxStreams {
+		return status.Errorf(codes.ResourceExhausted, "maximum number of streams reached")
+	}
+	h.handler.StreamCount.Add(1)
+	defer h.handler.StreamCount.Add(-1)
+
+	filter, err := h.getEventFilter(request.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	sub := h.handler.api.SubscribeEventsFromStartHeight(stream.Context(), request.GetStartBlockHeight(), filter)
+
+	return subscription.HandleSubscription(sub, h.handler.handleEventsResponse(stream.Send, request.GetHeartbeatInterval(), request.GetEventEncodingVersion()))
+}
+
+// SubscribeEventsFromLatest handles subscription requests for events starting at the latest sealed block.
+// It behaves like the ExecutionDataAPI endpoint of the same name, but its filter accepts event expressions.
+//
+// Expected errors during normal operation:
+// - codes.InvalidArgument   - if invalid event filter is provided.
+// - codes.ResourceExhausted - if the maximum number of streams is reached.
+// - codes.Internal          - could not convert events to entity, if stream encountered an error, if stream got unexpected response or could not send response.
+func (h *ExtendedHandler) SubscribeEventsFromLatest(request *extended.SubscribeEventsFromLatestRequest, stream extended.ExtendedExecutionDataAPI_SubscribeEventsFromLatestServer) error {
+	// check if the maximum number of streams is reached
+	if h.handler.StreamCount.Load() >= h.handler.MaxStreams {
+		return status.Errorf(codes.ResourceExhausted, "maximum number of streams reached")
+	}
+	h.handler.StreamCount.Add(1)
+	defer h.handler.StreamCount.Add(-1)
+
+	filter, err := h.getEventFilter(request.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	sub := h.handler.api.SubscribeEventsFromLatest(stream.Context(), filter)
+
+	return subscription.HandleSubscription(sub, h.handler.handleEventsResponse(stream.Send, request.GetHeartbeatInterval(), request.GetEventEncodingVersion()))
+}
+
+// getEventFilter returns the event filter of the request. If the event filter is nil, it returns an
+// empty filter.
+//
+// Expected errors during normal operation:
+// - codes.InvalidArgument - if the provided event filter is invalid.
+func (h *ExtendedHandler) getEventFilter(eventFilter *extended.EventFilter) (state_stream.EventFilter, error) {
+	if eventFilter == nil {
+		return state_stream.EventFilter{}, nil
+	}
+	filter, err := state_stream.NewEventFilterWithExpressions(
+		h.handler.eventFilterConfig,
+		h.handler.chain,
+		eventFilter.GetEventType(),
+		eventFilter.GetAddress(),
+		eventFilter.GetContract(),
+		eventFilter.GetExpressions(),
+	)
+	if err != nil {
+		return filter, status.Errorf(codes.InvalidArgument, "invalid event filter: %v", err)
+	}
+	return filter, nil
+}
@@ -0,0 +1,242 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/onflow/flow/protobuf/go/flow/executiondata"
+
+	"github.com/onflow/flow-go/access/extended"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	ssmock "github.com/onflow/flow-go/engine/access/state_stream/mock"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestExtendedHandlerEventCursors tests the creation, acknowledgement and deletion of event cursors.
+func TestExtendedHandlerEventCursors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := flow.Localnet.Chain()
+
+	t.Run("create cursor", func(t *testing.T) {
+		api := ssmock.NewAPI(t)
+		h := NewExtendedHandler(NewHandler(api, chain, makeConfig(1)))
+
+		eventType := fmt.Sprintf("A.%s.Contract.Event", chain.ServiceAddress().Hex())
+		expectedFilter, err := state_stream.NewEventFilter(state_stream.DefaultEventFilterConfig, chain, []string{eventType}, nil, nil)
+		require.NoError(t, err)
+
+		api.On("CreateEventCursor", mock.Anything, uint64(10), expectedFilter).Return("cursor", nil).Once()
+
+		resp, err := h.CreateEventCursor(ctx, &extended.CreateEventCursorRequest{
+			StartBlockHeight: 10,
+			Filter:           &extended.EventFilter{EventType: []string{eventType}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "cursor", resp.GetName())
+	})
+
+	t.Run("create cursor with invalid filter", func(t *testing.T) {
+		h := NewExtendedHandler(NewHandler(ssmock.NewAPI(t), chain, makeConfig(1)))
+
+		_, err := h.CreateEventCursor(ctx, &extended.CreateEventCursorRequest{
+			Filter: &extended.EventFilter{Address: []string{"invalid"}},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("create cursor when maximum is reached", func(t *testing.T) {
+		api := ssmock.NewAPI(t)
+		h := NewExtendedHandler(NewHandler(api, chain, makeConfig(1)))
+
+		api.On("CreateEventCursor", mock.Anything, uint64(0), state_stream.EventFilter{}).
+			Return("", status.Error(codes.ResourceExhausted, "maximum number of event cursors reached: 1")).Once()
+
+		_, err := h.CreateEventCursor(ctx, &extended.CreateEventCursorRequest{})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("acknowledge events", func(t *testing.T) {
+		api := ssmock.NewAPI(t)
+		h := NewExtendedHandler(NewHandler(api, chain, makeConfig(1)))
+
+		position := flow.EventPosition{BlockHeight: 12, TransactionIndex: 1, EventIndex: 3}
+		api.On("AcknowledgeEvents", mock.Anything, "cursor", position).Return(nil).Once()
+
+		_, err := h.AcknowledgeEvents(ctx, &extended.AcknowledgeEventsRequest{
+			Name:     "cursor",
+			Position: convert.EventPositionToMessage(position),
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("acknowledge events without position", func(t *testing.T) {
+		h := NewExtendedHandler(NewHandler(ssmock.NewAPI(t), chain, makeConfig(1)))
+
+		_, err := h.AcknowledgeEvents(ctx, &extended.AcknowledgeEventsRequest{Name: "cursor"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("delete unknown cursor", func(t *testing.T) {
+		api := ssmock.NewAPI(t)
+		h := NewExtendedHandler(NewHandler(api, chain, makeConfig(1)))
+
+		api.On("DeleteEventCursor", mock.Anything, "unknown").
+			Return(status.Error(codes.NotFound, `cursor "unknown" not found`)).Once()
+
+		_, err := h.DeleteEventCursor(ctx, &extended.DeleteEventCursorRequest{Name: "unknown"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+// TestExtendedHandlerSubscribeEventsFromCursor tests streaming the events of a cursor.
+func TestExtendedHandlerSubscribeEventsFromCursor(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chain := flow.Localnet.Chain()
+	blockID := unittest.IdentifierFixture()
+	ccfEvents, jsonEvents := generateEvents(t, 3)
+
+	t.Run("stream events", func(t *testing.T) {
+		api := ssmock.NewAPI(t)
+		h := NewExtendedHandler(NewHandler(api, chain, makeConfig(1)))
+
+		sub := subscription.NewSubscription(1)
+		api.On("SubscribeEventsFromCursor", mock.Anything, "cursor", state_stream.EventFilter{}).Return(sub).Once()
+
+		stream := makeStreamMock[extended.SubscribeEventsFromCursorRequest, executiondata.SubscribeEventsResponse](ctx)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			err := h.SubscribeEventsFromCursor(&extended.SubscribeEventsFromCursorRequest{
+				Name:                 "cursor",
+				EventEncodingVersion: entities.EventEncodingVersion_JSON_CDC_V0,
+			}, stream)
+			require.NoError(t, err)
+		}()
+
+		err := sub.Send(ctx, &EventsResponse{BlockID: blockID, Height: 1, Events: ccfEvents}, 100*time.Millisecond)
+		require.NoError(t, err)
+		sub.Close()
+
+		resp, err := stream.RecvToClient()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), resp.GetBlockHeight())
+		assert.Equal(t, blockID, convert.MessageToIdentifier(resp.GetBlockId()))
+		assert.Equal(t, jsonEvents, convert.MessagesToEvents(resp.GetEvents()))
+
+		unittest.RequireCloseBefore(t, done, time.Second, "stream did not close")
+		close(stream.sentFromServer)
+		_, err = stream.RecvToClient()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("cursor not found", func(t *testing.T) {
+		api := ssmock.NewAPI(t)
+		h := NewExtendedHandler(NewHandler(api, chain, makeConfig(1)))
+
+		sub := subscription.NewFailedSubscription(status.Error(codes.NotFound, `cursor "unknown" not found`), "could not subscribe")
+		api.On("SubscribeEventsFromCursor", mock.Anything, "unknown", state_stream.EventFilter{}).Return(sub).Once()
+
+		stream := makeStreamMock[extended.SubscribeEventsFromCursorRequest, executiondata.SubscribeEventsResponse](ctx)
+		err := h.SubscribeEventsFromCursor(&extended.SubscribeEventsFromCursorRequest{Name: "unknown"}, stream)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("maximum number of streams reached", func(t *testing.T) {
+		h := NewExtendedHandler(NewHandler(ssmock.NewAPI(t), chain, makeConfig(0)))
+
+		stream := makeStreamMock[extended.SubscribeEventsFromCursorRequest, executiondata.SubscribeEventsResponse](ctx)
+		err := h.SubscribeEventsFromCursor(&extended.SubscribeEventsFromCursorRequest{Name: "cursor"}, stream)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+}
+
+// TestExtendedHandlerSubscribeEventsWithExpressions tests that event expressions of the filter are passed
+// to the backend, and that invalid expressions are rejected.
+func TestExtendedHandlerSubscribeEventsWithExpressions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := flow.Localnet.Chain()
+
+	expression := fmt.Sprintf("A.%s.FlowToken.TokensDeposited where amount > 100.0", chain.ServiceAddress().Hex())
+	expectedFilter, err := state_stream.NewEventFilterWithExpressions(state_stream.DefaultEventFilterConfig, chain, nil, nil, nil, []string{expression})
+	require.NoError(t, err)
+
+	filter := &extended.EventFilter{Expressions: []string{expression}}
+
+	t.Run("from start height", func(t *testing.T) {
+		api := ssmock.NewAPI(t)
+		h := NewExtendedHandler(NewHandler(api, chain, makeConfig(1)))
+
+		sub := subscription.NewSubscription(1)
+		sub.Close()
+		api.On("SubscribeEventsFromStartHeight", mock.Anything, uint64(10), expectedFilter).Return(sub).Once()
+
+		stream := makeStreamMock[extended.SubscribeEventsFromStartHeightRequest, executiondata.SubscribeEventsResponse](ctx)
+		err := h.SubscribeEventsFromStartHeight(&extended.SubscribeEventsFromStartHeightRequest{
+			StartBlockHeight: 10,
+			Filter:           filter,
+		}, stream)
+		require.NoError(t, err)
+	})
+
+	t.Run("from start block ID", func(t *testing.T) {
+		api := ssmock.NewAPI(t)
+		h := NewExtendedHandler(NewHandler(api, chain, makeConfig(1)))
+
+		blockID := unittest.IdentifierFixture()
+		sub := subscription.NewSubscription(1)
+		sub.Close()
+		api.On("SubscribeEventsFromStartBlockID", mock.Anything, blockID, expectedFilter).Return(sub).Once()
+
+		stream := makeStreamMock[extended.SubscribeEventsFromStartBlockIDRequest, executiondata.SubscribeEventsResponse](ctx)
+		err := h.SubscribeEventsFromStartBlockID(&extended.SubscribeEventsFromStartBlockIDRequest{
+			StartBlockId: blockID[:],
+			Filter:       filter,
+		}, stream)
+		require.NoError(t, err)
+	})
+
+	t.Run("from latest", func(t *testing.T) {
+		api := ssmock.NewAPI(t)
+		h := NewExtendedHandler(NewHandler(api, chain, makeConfig(1)))
+
+		sub := subscription.NewSubscription(1)
+		sub.Close()
+		api.On("SubscribeEventsFromLatest", mock.Anything, expectedFilter).Return(sub).Once()
+
+		stream := makeStreamMock[extended.SubscribeEventsFromLatestRequest, executiondata.SubscribeEventsResponse](ctx)
+		err := h.SubscribeEventsFromLatest(&extended.SubscribeEventsFromLatestRequest{Filter: filter}, stream)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		h := NewExtendedHandler(NewHandler(ssmock.NewAPI(t), chain, makeConfig(1)))
+
+		stream := makeStreamMock[extended.SubscribeEventsFromLatestRequest, executiondata.SubscribeEventsResponse](ctx)
+		err := h.SubscribeEventsFromLatest(&extended.SubscribeEventsFromLatestRequest{
+			Filter: &extended.EventFilter{Expressions: []string{"A.invalid where amount >"}},
+		}, stream)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
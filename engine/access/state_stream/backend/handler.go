@@ -413,6 +413,9 @@ func (h *Handler) handleEventsResponse(send sendSubscribeEventsResponseFunc, hea
 //
 // Expected errors during normal operation:
 // - codes.InvalidArgument - if the provided event filter is invalid.
+//
+// The EventFilter message of the onflow/flow protobuf definitions has no event expressions, filters with
+// expressions are served by the ExtendedHandler.
 func (h *Handler) getEventFilter(eventFilter *executiondata.EventFilter) (state_stream.EventFilter, error) {
 	if eventFilter == nil {
 		return state_stream.EventFilter{}, nil
@@ -0,0 +1,659 @@
+package state_stream
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	// MaxEventExpressionLength is the maximum length in bytes of a single event expression.
+	MaxEventExpressionLength = 1024
+
+	// maxEventExpressionDepth is the maximum nesting depth of parentheses and negations in a predicate.
+	maxEventExpressionDepth = 16
+
+	// whereKeyword separates the event type of an expression from its predicate.
+	whereKeyword = "where"
+)
+
+// EventExpression matches events of a single type on the values of their decoded Cadence fields.
+//
+// Expressions have the form `<event type> [where <predicate>]`, for example:
+//
+//	A.1654653399040a61.FlowToken.TokensDeposited where to == 0x01 and amount > 100.0
+//
+// A predicate combines comparisons of event fields with literals using `and`, `or`, `not` and
+// parentheses. Fields of nested structs are accessed with `.`, e.g. `vault.balance`. Supported
+// literals are numbers (e.g. `100`, `-1.5`), double quoted strings, addresses (e.g. `0x01`),
+// `true`, `false` and `nil`. Numbers and strings support the operators `==`, `!=`, `<`, `<=`, `>`
+// and `>=`, all other literals only support `==` and `!=`.
+//
+// Optional field values are unwrapped before they are compared. A comparison of a field which does
+// not exist, or whose value is of a different kind than the literal, is false.
+type EventExpression struct {
+	raw       string
+	EventType flow.EventType
+	predicate exprNode
+}
+
+// ParseEventExpression parses and validates an event expression.
+//
+// Expected errors during normal operations:
+//   - if the expression is malformed, the event type is invalid, or the expression exceeds the
+//     limits of the config.
+func ParseEventExpression(config EventFilterConfig, chain flow.Chain, raw string) (*EventExpression, error) {
+	if len(raw) > MaxEventExpressionLength {
+		return nil, fmt.Errorf("expression is too long (%d bytes). use %d or fewer", len(raw), MaxEventExpressionLength)
+	}
+
+	trimmed := strings.TrimSpace(raw)
+	rawType, rest := trimmed, ""
+	if i := strings.IndexFunc(trimmed, unicode.IsSpace); i >= 0 {
+		rawType, rest = trimmed[:i], trimmed[i:]
+	}
+	if rawType == "" {
+		return nil, fmt.Errorf("expression must start with an event type")
+	}
+
+	eventType := flow.EventType(rawType)
+	if err := validateEventType(eventType, chain); err != nil {
+		return nil, err
+	}
+
+	expr := &EventExpression{
+		raw:       trimmed,
+		EventType: eventType,
+	}
+
+	rest = strings.TrimSpace(rest)
+	if rest == "" {
+		return expr, nil
+	}
+
+	rawPredicate, ok := strings.CutPrefix(rest, whereKeyword)
+	if !ok || (rawPredicate != "" && !unicode.IsSpace(rune(rawPredicate[0]))) {
+		return nil, fmt.Errorf("expected '%s' after the event type", whereKeyword)
+	}
+
+	tokens, err := tokenize(rawPredicate)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &exprParser{
+		tokens:        tokens,
+		maxConditions: config.MaxExpressionConditions,
+	}
+	expr.predicate, err = p.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid predicate: %w", err)
+	}
+
+	return expr, nil
+}
+
+// String returns the expression in the form it was parsed from.
+func (e *EventExpression) String() string {
+	return e.raw
+}
+
+// Match returns true if the event is of the type of the expression and its CCF encoded payload
+// matches the predicate.
+func (e *EventExpression) Match(event flow.Event) bool {
+	if event.Type != e.EventType {
+		return false
+	}
+	if e.predicate == nil {
+		return true
+	}
+
+	fields, err := getEventFields(&event)
+	if err != nil {
+		return false
+	}
+	return e.MatchFields(fields)
+}
+
+// MatchFields returns true if the decoded fields of an event match the predicate of the expression.
+// The caller is responsible for checking the event type.
+func (e *EventExpression) MatchFields(fields map[string]cadence.Value) bool {
+	if e.predicate == nil {
+		return true
+	}
+	return e.predicate.eval(fields)
+}
+
+// exprNode is a node of a parsed predicate.
+type exprNode interface {
+	eval(fields map[string]cadence.Value) bool
+}
+
+type andNode struct {
+	left, right exprNode
+}
+
+func (n *andNode) eval(fields map[string]cadence.Value) bool {
+	return n.left.eval(fields) && n.right.eval(fields)
+}
+
+type orNode struct {
+	left, right exprNode
+}
+
+func (n *orNode) eval(fields map[string]cadence.Value) bool {
+	return n.left.eval(fields) || n.right.eval(fields)
+}
+
+type notNode struct {
+	operand exprNode
+}
+
+func (n *notNode) eval(fields map[string]cadence.Value) bool {
+	return !n.operand.eval(fields)
+}
+
+// comparisonNode compares the value of a field with a literal.
+type comparisonNode struct {
+	path    []string
+	op      string
+	literal literal
+}
+
+func (n *comparisonNode) eval(fields map[string]cadence.Value) bool {
+	value, ok := lookupField(fields, n.path)
+	if !ok {
+		return false
+	}
+
+	cmp, ok := n.literal.compare(value)
+	if !ok {
+		return false
+	}
+
+	switch n.op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	default:
+		return false
+	}
+}
+
+// lookupField returns the value of the field at the given path. Optional values are unwrapped,
+// except for the value of the last path element, so it can be compared with nil.
+func lookupField(fields map[string]cadence.Value, path []string) (cadence.Value, bool) {
+	value, ok := fields[path[0]]
+	if !ok {
+		return nil, false
+	}
+
+	for _, name := range path[1:] {
+		composite, ok := unwrapOptional(value).(cadence.Composite)
+		if !ok {
+			return nil, false
+		}
+		value = cadence.SearchFieldByName(composite, name)
+		if value == nil {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+// unwrapOptional returns the value wrapped by (possibly nested) optionals. Nil is returned for an
+// empty optional.
+func unwrapOptional(value cadence.Value) cadence.Value {
+	for {
+		optional, ok := value.(cadence.Optional)
+		if !ok {
+			return value
+		}
+		if optional.Value == nil {
+			return nil
+		}
+		value = optional.Value
+	}
+}
+
+// literal is a constant value of a predicate.
+type literal interface {
+	// compare compares the value with the literal, and returns a negative number if the value is
+	// less than the literal, 0 if they are equal and a positive number otherwise. It returns false
+	// if the value can not be compared with the literal.
+	compare(value cadence.Value) (int, bool)
+	// ordered returns true if the literal supports the operators <, <=, > and >=.
+	ordered() bool
+}
+
+type numberLiteral struct {
+	value *big.Rat
+}
+
+func (l numberLiteral) compare(value cadence.Value) (int, bool) {
+	number, ok := unwrapOptional(value).(cadence.NumberValue)
+	if !ok {
+		return 0, false
+	}
+	// the string representations of all Cadence numbers, including fixed point numbers, are decimals
+	v, ok := new(big.Rat).SetString(number.String())
+	if !ok {
+		return 0, false
+	}
+	return v.Cmp(l.value), true
+}
+
+func (l numberLiteral) ordered() bool { return true }
+
+type stringLiteral struct {
+	value string
+}
+
+func (l stringLiteral) compare(value cadence.Value) (int, bool) {
+	switch v := unwrapOptional(value).(type) {
+	case cadence.String:
+		return strings.Compare(string(v), l.value), true
+	case cadence.Character:
+		return strings.Compare(string(v), l.value), true
+	default:
+		return 0, false
+	}
+}
+
+func (l stringLiteral) ordered() bool { return true }
+
+type addressLiteral struct {
+	value flow.Address
+}
+
+func (l addressLiteral) compare(value cadence.Value) (int, bool) {
+	v, ok := unwrapOptional(value).(cadence.Address)
+	if !ok {
+		return 0, false
+	}
+	if flow.Address(v) == l.value {
+		return 0, true
+	}
+	return 1, true
+}
+
+func (l addressLiteral) ordered() bool { return false }
+
+type boolLiteral struct {
+	value bool
+}
+
+func (l boolLiteral) compare(value cadence.Value) (int, bool) {
+	v, ok := unwrapOptional(value).(cadence.Bool)
+	if !ok {
+		return 0, false
+	}
+	if bool(v) == l.value {
+		return 0, true
+	}
+	return 1, true
+}
+
+func (l boolLiteral) ordered() bool { return false }
+
+type nilLiteral struct{}
+
+func (l nilLiteral) compare(value cadence.Value) (int, bool) {
+	if unwrapOptional(value) == nil {
+		return 0, true
+	}
+	return 1, true
+}
+
+func (l nilLiteral) ordered() bool { return false }
+
+// tokenKind is the kind of a lexical token of a predicate.
+type tokenKind int
+
+const (
+	tokenIdentifier tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenAddress
+	tokenOperator
+	tokenDot
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+// tokenize splits a predicate into tokens.
+//
+// Expected errors during normal operations:
+//   - if the predicate contains invalid characters or unterminated strings.
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(input); {
+		c := input[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, value: "("})
+			i++
+
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, value: ")"})
+			i++
+
+		case c == '.':
+			tokens = append(tokens, token{kind: tokenDot, value: "."})
+			i++
+
+		case c == '=' || c == '!' || c == '<' || c == '>':
+			op := string(c)
+			if i+1 < len(input) && input[i+1] == '=' {
+				op += "="
+			}
+			if op == "=" || op == "!" {
+				return nil, fmt.Errorf("invalid operator %q at offset %d", op, i)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, value: op})
+			i += len(op)
+
+		case c == '"':
+			value, n, err := scanString(input[i:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at offset %d: %w", i, err)
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value})
+			i += n
+
+		case c == '0' && i+1 < len(input) && (input[i+1] == 'x' || input[i+1] == 'X'):
+			j := i + 2
+			for j < len(input) && isHexDigit(input[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenAddress, value: input[i:j]})
+			i = j
+
+		case isDigit(c) || (c == '-' && i+1 < len(input) && isDigit(input[i+1])):
+			j := i + 1
+			for j < len(input) && (isDigit(input[j]) || input[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, value: input[i:j]})
+			i = j
+
+		case isIdentifierStart(c):
+			j := i + 1
+			for j < len(input) && (isIdentifierStart(input[j]) || isDigit(input[j])) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenIdentifier, value: input[i:j]})
+			i = j
+
+		default:
+			return nil, fmt.Errorf("unexpected character %q at offset %d", c, i)
+		}
+	}
+
+	return tokens, nil
+}
+
+// scanString scans a double quoted string at the start of the input, and returns its unescaped
+// value and the number of bytes consumed. Only the escape sequences \" and \\ are supported.
+func scanString(input string) (string, int, error) {
+	var sb strings.Builder
+	for i := 1; i < len(input); i++ {
+		switch input[i] {
+		case '"':
+			return sb.String(), i + 1, nil
+		case '\\':
+			if i+1 >= len(input) || (input[i+1] != '"' && input[i+1] != '\\') {
+				return "", 0, fmt.Errorf("unsupported escape sequence")
+			}
+			i++
+			sb.WriteByte(input[i])
+		default:
+			sb.WriteByte(input[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// exprParser is a recursive descent parser for predicates with the following grammar:
+//
+//	or         := and { "or" and }
+//	and        := unary { "and" unary }
+//	unary      := "not" unary | "(" or ")" | comparison
+//	comparison := identifier { "." identifier } operator literal
+type exprParser struct {
+	tokens        []token
+	pos           int
+	depth         int
+	conditions    int
+	maxConditions int
+}
+
+// parse parses all tokens into a predicate.
+//
+// Expected errors during normal operations:
+//   - if the tokens do not form a valid predicate, or the predicate exceeds the limits.
+func (p *exprParser) parse() (exprNode, error) {
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("predicate is empty")
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos].value)
+	}
+	return node, nil
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxEventExpressionDepth {
+		return nil, fmt.Errorf("predicate is nested too deeply. use %d or fewer levels", maxEventExpressionDepth)
+	}
+
+	if p.acceptKeyword("not") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand}, nil
+	}
+
+	if p.accept(tokenLeftParen) {
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(tokenRightParen) {
+			return nil, fmt.Errorf("missing ')'")
+		}
+		return node, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	p.conditions++
+	if p.conditions > p.maxConditions {
+		return nil, fmt.Errorf("too many conditions in predicate. use %d or fewer", p.maxConditions)
+	}
+
+	field, ok := p.next()
+	if !ok || field.kind != tokenIdentifier || isKeyword(field.value) {
+		return nil, fmt.Errorf("expected field name")
+	}
+	path := []string{field.value}
+	for p.accept(tokenDot) {
+		field, ok = p.next()
+		if !ok || field.kind != tokenIdentifier {
+			return nil, fmt.Errorf("expected field name after '.'")
+		}
+		path = append(path, field.value)
+	}
+
+	op, ok := p.next()
+	if !ok || op.kind != tokenOperator {
+		return nil, fmt.Errorf("expected comparison operator after %q", strings.Join(path, "."))
+	}
+
+	lit, err := p.parseLiteral()
+	if err != nil {
+		return nil, err
+	}
+	if !lit.ordered() && op.value != "==" && op.value != "!=" {
+		return nil, fmt.Errorf("operator %q is not supported for the value compared with %q", op.value, strings.Join(path, "."))
+	}
+
+	return &comparisonNode{path: path, op: op.value, literal: lit}, nil
+}
+
+func (p *exprParser) parseLiteral() (literal, error) {
+	tok, ok := p.next()
+	if !ok {
+		return nil, fmt.Errorf("expected value")
+	}
+
+	switch tok.kind {
+	case tokenNumber:
+		value, ok := new(big.Rat).SetString(tok.value)
+		if !ok {
+			return nil, fmt.Errorf("invalid number %q", tok.value)
+		}
+		return numberLiteral{value: value}, nil
+	case tokenString:
+		return stringLiteral{value: tok.value}, nil
+	case tokenAddress:
+		raw := tok.value[2:]
+		if len(raw) == 0 || len(raw) > 2*flow.AddressLength {
+			return nil, fmt.Errorf("invalid address %q", tok.value)
+		}
+		if len(raw)%2 == 1 {
+			raw = "0" + raw
+		}
+		b, err := hex.DecodeString(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q", tok.value)
+		}
+		return addressLiteral{value: flow.BytesToAddress(b)}, nil
+	case tokenIdentifier:
+		switch tok.value {
+		case "true":
+			return boolLiteral{value: true}, nil
+		case "false":
+			return boolLiteral{value: false}, nil
+		case "nil":
+			return nilLiteral{}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid value %q", tok.value)
+}
+
+// next returns the next token and advances the parser.
+func (p *exprParser) next() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	tok := p.tokens[p.pos]
+	p.pos++
+	return tok, true
+}
+
+// accept advances the parser if the next token is of the given kind.
+func (p *exprParser) accept(kind tokenKind) bool {
+	if p.pos < len(p.tokens) && p.tokens[p.pos].kind == kind {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// acceptKeyword advances the parser if the next token is the given keyword.
+func (p *exprParser) acceptKeyword(keyword string) bool {
+	if p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenIdentifier && p.tokens[p.pos].value == keyword {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func isKeyword(value string) bool {
+	switch value {
+	case "and", "or", "not", "true", "false", "nil":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,236 @@
+package state_stream_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/encoding/ccf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const depositEventType = "A.0000000000000001.FlowToken.TokensDeposited"
+
+func TestParseEventExpression(t *testing.T) {
+	t.Parallel()
+
+	chain := flow.MonotonicEmulator.Chain()
+
+	tests := []struct {
+		name       string
+		expression string
+		err        bool
+	}{
+		{name: "event type only", expression: depositEventType},
+		{name: "comparison", expression: depositEventType + " where amount > 100.0"},
+		{name: "all operators", expression: depositEventType + ` where a == 1 and b != 2 and c < 3 and d <= 4 and e > 5 and f >= -6.5`},
+		{name: "all literals", expression: depositEventType + ` where a == 1 or b == "x \"y\"" or c == 0x01 or d == true or e == false or f == nil`},
+		{name: "nested fields and grouping", expression: depositEventType + " where not (vault.balance > 1 or to == nil) and amount >= 0"},
+		{name: "surrounding whitespace", expression: "  " + depositEventType + "\twhere\namount > 1  "},
+
+		{name: "empty", expression: "", err: true},
+		{name: "invalid event type", expression: "invalid where amount > 1", err: true},
+		{name: "missing where", expression: depositEventType + " amount > 1", err: true},
+		{name: "empty predicate", expression: depositEventType + " where", err: true},
+		{name: "missing operator", expression: depositEventType + " where amount 1", err: true},
+		{name: "missing value", expression: depositEventType + " where amount >", err: true},
+		{name: "assignment", expression: depositEventType + " where amount = 1", err: true},
+		{name: "unterminated string", expression: depositEventType + ` where name == "abc`, err: true},
+		{name: "invalid number", expression: depositEventType + " where amount == 1.2.3", err: true},
+		{name: "invalid address", expression: depositEventType + " where to == 0x", err: true},
+		{name: "address too long", expression: depositEventType + " where to == 0x000000000000000001", err: true},
+		{name: "ordered comparison of address", expression: depositEventType + " where to > 0x01", err: true},
+		{name: "ordered comparison of bool", expression: depositEventType + " where flag < true", err: true},
+		{name: "keyword as field", expression: depositEventType + " where and == 1", err: true},
+		{name: "unbalanced parentheses", expression: depositEventType + " where (amount > 1", err: true},
+		{name: "trailing tokens", expression: depositEventType + " where amount > 1 amount", err: true},
+		{name: "unexpected character", expression: depositEventType + " where amount > 1 && to == 0x01", err: true},
+		{
+			name:       "too many conditions",
+			expression: depositEventType + " where " + strings.Repeat("amount > 1 or ", state_stream.DefaultMaxExpressionConditions) + "amount > 1",
+			err:        true,
+		},
+		{
+			name:       "nested too deeply",
+			expression: depositEventType + " where " + strings.Repeat("not ", 20) + "amount > 1",
+			err:        true,
+		},
+		{
+			name:       "too long",
+			expression: depositEventType + " where name == \"" + strings.Repeat("x", state_stream.MaxEventExpressionLength) + "\"",
+			err:        true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expr, err := state_stream.ParseEventExpression(state_stream.DefaultEventFilterConfig, chain, test.expression)
+			if test.err {
+				assert.Error(t, err)
+				assert.Nil(t, expr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, flow.EventType(depositEventType), expr.EventType)
+			assert.Equal(t, strings.TrimSpace(test.expression), expr.String())
+		})
+	}
+}
+
+func TestEventExpressionMatch(t *testing.T) {
+	t.Parallel()
+
+	chain := flow.MonotonicEmulator.Chain()
+	event := depositEventFixture(t, "150.5", &flow.Address{0, 0, 0, 0, 0, 0, 0, 1})
+	noRecipient := depositEventFixture(t, "150.5", nil)
+
+	tests := []struct {
+		predicate string
+		event     flow.Event
+		match     bool
+	}{
+		{predicate: "amount > 100.0", event: event, match: true},
+		{predicate: "amount > 150.5", event: event, match: false},
+		{predicate: "amount >= 150.5", event: event, match: true},
+		{predicate: "amount == 150.50", event: event, match: true},
+		{predicate: "amount < 200", event: event, match: true},
+		{predicate: "amount != 150.5", event: event, match: false},
+		{predicate: "to == 0x01", event: event, match: true},
+		{predicate: "to == 0x0000000000000001", event: event, match: true},
+		{predicate: "to == 0x02", event: event, match: false},
+		{predicate: "to != nil", event: event, match: true},
+		{predicate: "to == nil", event: noRecipient, match: true},
+		{predicate: "to == 0x01", event: noRecipient, match: false},
+		{predicate: `note == "hello"`, event: event, match: true},
+		{predicate: `note > "abc"`, event: event, match: true},
+		{predicate: "first == true", event: event, match: true},
+		{predicate: "vault.balance == 42", event: event, match: true},
+		{predicate: "vault.balance > 42", event: event, match: false},
+		{predicate: "to == 0x01 and amount > 100.0", event: event, match: true},
+		{predicate: "to == 0x02 or amount > 100.0", event: event, match: true},
+		{predicate: "not (to == 0x02 or amount > 200.0)", event: event, match: true},
+		{predicate: "to == 0x02 or amount > 100.0 and first == false", event: event, match: false},
+
+		// comparisons of missing fields and different kinds of values are always false
+		{predicate: "missing == 1", event: event, match: false},
+		{predicate: "missing != 1", event: event, match: false},
+		{predicate: "vault.missing == 1", event: event, match: false},
+		{predicate: `amount == "150.5"`, event: event, match: false},
+		{predicate: "note == 1", event: event, match: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.predicate, func(t *testing.T) {
+			expr, err := state_stream.ParseEventExpression(state_stream.DefaultEventFilterConfig, chain, depositEventType+" where "+test.predicate)
+			require.NoError(t, err)
+			assert.Equal(t, test.match, expr.Match(test.event))
+		})
+	}
+
+	t.Run("other event type", func(t *testing.T) {
+		expr, err := state_stream.ParseEventExpression(state_stream.DefaultEventFilterConfig, chain, "A.0000000000000001.FlowToken.TokensWithdrawn")
+		require.NoError(t, err)
+		assert.False(t, expr.Match(event))
+	})
+}
+
+func TestFilterExpressions(t *testing.T) {
+	t.Parallel()
+
+	chain := flow.MonotonicEmulator.Chain()
+	large := depositEventFixture(t, "150.0", &flow.Address{0, 0, 0, 0, 0, 0, 0, 1})
+	small := depositEventFixture(t, "1.0", &flow.Address{0, 0, 0, 0, 0, 0, 0, 1})
+	other := unittest.EventFixture("A.0000000000000001.Contract1.EventA", 0, 0, unittest.IdentifierFixture(), 0)
+
+	t.Run("expressions", func(t *testing.T) {
+		filter, err := state_stream.NewEventFilterWithExpressions(
+			state_stream.DefaultEventFilterConfig,
+			chain,
+			nil,
+			nil,
+			nil,
+			[]string{depositEventType + " where amount > 100.0"},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.EventsList{large}, filter.Filter(flow.EventsList{large, small, other}))
+	})
+
+	t.Run("expressions and event types", func(t *testing.T) {
+		filter, err := state_stream.NewEventFilterWithExpressions(
+			state_stream.DefaultEventFilterConfig,
+			chain,
+			[]string{string(other.Type)},
+			nil,
+			nil,
+			[]string{depositEventType + " where amount < 100.0", depositEventType + " where amount > 1000.0"},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.EventsList{small, other}, filter.Filter(flow.EventsList{large, small, other}))
+
+		_, _, _, expressions := filter.Criteria()
+		assert.Equal(t, []string{depositEventType + " where amount < 100.0", depositEventType + " where amount > 1000.0"}, expressions)
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		_, err := state_stream.NewEventFilterWithExpressions(state_stream.DefaultEventFilterConfig, chain, nil, nil, nil, []string{"invalid"})
+		assert.Error(t, err)
+	})
+
+	t.Run("too many expressions", func(t *testing.T) {
+		expressions := make([]string, state_stream.DefaultMaxExpressions+1)
+		for i := range expressions {
+			expressions[i] = fmt.Sprintf("%s where amount > %d", depositEventType, i)
+		}
+		_, err := state_stream.NewEventFilterWithExpressions(state_stream.DefaultEventFilterConfig, chain, nil, nil, nil, expressions)
+		assert.Error(t, err)
+	})
+}
+
+// depositEventFixture returns a CCF encoded deposit event with the given amount and optional recipient.
+func depositEventFixture(t *testing.T, amount string, to *flow.Address) flow.Event {
+	location := common.NewAddressLocation(nil, common.Address{0, 0, 0, 0, 0, 0, 0, 1}, "FlowToken")
+
+	vaultType := cadence.NewStructType(location, "FlowToken.Vault", []cadence.Field{
+		{Identifier: "balance", Type: cadence.IntType},
+	}, nil)
+
+	eventType := cadence.NewEventType(location, "FlowToken.TokensDeposited", []cadence.Field{
+		{Identifier: "amount", Type: cadence.UFix64Type},
+		{Identifier: "to", Type: cadence.NewOptionalType(cadence.AddressType)},
+		{Identifier: "note", Type: cadence.StringType},
+		{Identifier: "first", Type: cadence.BoolType},
+		{Identifier: "vault", Type: vaultType},
+	}, nil)
+
+	value, err := cadence.NewUFix64(amount)
+	require.NoError(t, err)
+
+	recipient := cadence.NewOptional(nil)
+	if to != nil {
+		recipient = cadence.NewOptional(cadence.NewAddress(*to))
+	}
+
+	cdcEvent := cadence.NewEvent([]cadence.Value{
+		value,
+		recipient,
+		cadence.String("hello"),
+		cadence.Bool(true),
+		cadence.NewStruct([]cadence.Value{cadence.NewInt(42)}).WithType(vaultType),
+	}).WithType(eventType)
+
+	payload, err := ccf.Encode(cdcEvent)
+	require.NoError(t, err)
+
+	event := unittest.EventFixture(depositEventType, 0, 0, unittest.IdentifierFixture(), 0)
+	event.Payload = payload
+	return event
+}
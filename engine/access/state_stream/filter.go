@@ -6,6 +6,8 @@ import (
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/encoding/ccf"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 
 	"github.com/onflow/flow-go/model/events"
 	"github.com/onflow/flow-go/model/flow"
@@ -23,22 +25,32 @@ const (
 
 	// DefaultMaxAccountAddresses specifies limitation for possible number of accounts that could be used in filter
 	DefaultMaxAccountAddresses = 100
+
+	// DefaultMaxExpressions is the default maximum number of event expressions that can be specified in a filter
+	DefaultMaxExpressions = 20
+
+	// DefaultMaxExpressionConditions is the default maximum number of comparisons in a single event expression
+	DefaultMaxExpressionConditions = 16
 )
 
 // EventFilterConfig is used to configure the limits for EventFilters
 type EventFilterConfig struct {
-	MaxEventTypes     int
-	MaxAddresses      int
-	MaxContracts      int
-	MaxAccountAddress int
+	MaxEventTypes           int
+	MaxAddresses            int
+	MaxContracts            int
+	MaxAccountAddress       int
+	MaxExpressions          int
+	MaxExpressionConditions int
 }
 
 // DefaultEventFilterConfig is the default configuration for EventFilters
 var DefaultEventFilterConfig = EventFilterConfig{
-	MaxEventTypes:     DefaultMaxEventTypes,
-	MaxAddresses:      DefaultMaxAddresses,
-	MaxContracts:      DefaultMaxContracts,
-	MaxAccountAddress: DefaultMaxAccountAddresses,
+	MaxEventTypes:           DefaultMaxEventTypes,
+	MaxAddresses:            DefaultMaxAddresses,
+	MaxContracts:            DefaultMaxContracts,
+	MaxAccountAddress:       DefaultMaxAccountAddresses,
+	MaxExpressions:          DefaultMaxExpressions,
+	MaxExpressionConditions: DefaultMaxExpressionConditions,
 }
 
 type FieldFilter map[string]map[string]struct{}
@@ -50,6 +62,7 @@ type EventFilter struct {
 	Addresses         map[string]struct{}
 	Contracts         map[string]struct{}
 	EventFieldFilters map[flow.EventType]FieldFilter
+	Expressions       map[flow.EventType][]*EventExpression
 }
 
 func NewEventFilter(
@@ -58,6 +71,22 @@ func NewEventFilter(
 	eventTypes []string,
 	addresses []string,
 	contracts []string,
+) (EventFilter, error) {
+	return NewEventFilterWithExpressions(config, chain, eventTypes, addresses, contracts, nil)
+}
+
+// NewEventFilterWithExpressions creates a filter matching events by type, address or contract, or
+// by any of the given event expressions. See EventExpression for the syntax of expressions.
+//
+// Expected errors during normal operations:
+//   - if any of the criteria is invalid, or the filter exceeds the limits of the config.
+func NewEventFilterWithExpressions(
+	config EventFilterConfig,
+	chain flow.Chain,
+	eventTypes []string,
+	addresses []string,
+	contracts []string,
+	expressions []string,
 ) (EventFilter, error) {
 	// put some reasonable limits on the number of filters. Lookups use a map so they are fast,
 	// this just puts a cap on the memory consumed per filter.
@@ -73,11 +102,16 @@ func NewEventFilter(
 		return EventFilter{}, fmt.Errorf("too many contracts in filter (%d). use %d or fewer", len(contracts), config.MaxContracts)
 	}
 
+	if len(expressions) > config.MaxExpressions {
+		return EventFilter{}, fmt.Errorf("too many expressions in filter (%d). use %d or fewer", len(expressions), config.MaxExpressions)
+	}
+
 	f := EventFilter{
 		EventTypes:        make(map[flow.EventType]struct{}, len(eventTypes)),
 		Addresses:         make(map[string]struct{}, len(addresses)),
 		Contracts:         make(map[string]struct{}, len(contracts)),
 		EventFieldFilters: make(map[flow.EventType]FieldFilter),
+		Expressions:       make(map[flow.EventType][]*EventExpression),
 	}
 
 	// Check all of the filters to ensure they are correctly formatted. This helps avoid searching
@@ -106,10 +140,41 @@ func NewEventFilter(
 		f.Contracts[contract] = struct{}{}
 	}
 
-	f.hasFilters = len(f.EventTypes) > 0 || len(f.Addresses) > 0 || len(f.Contracts) > 0
+	for _, raw := range expressions {
+		expr, err := ParseEventExpression(config, chain, raw)
+		if err != nil {
+			return EventFilter{}, fmt.Errorf("invalid expression %q: %w", raw, err)
+		}
+		f.Expressions[expr.EventType] = append(f.Expressions[expr.EventType], expr)
+	}
+
+	f.hasFilters = len(f.EventTypes) > 0 || len(f.Addresses) > 0 || len(f.Contracts) > 0 || len(f.Expressions) > 0
 	return f, nil
 }
 
+// Criteria returns the event types, addresses, contracts and expressions of the filter, each sorted in
+// ascending order. Passing them to NewEventFilterWithExpressions creates a filter matching the same events.
+func (f *EventFilter) Criteria() (eventTypes []string, addresses []string, contracts []string, expressions []string) {
+	eventTypes = make([]string, 0, len(f.EventTypes))
+	for eventType := range f.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+	addresses = maps.Keys(f.Addresses)
+	contracts = maps.Keys(f.Contracts)
+	for _, exprs := range f.Expressions {
+		for _, expr := range exprs {
+			expressions = append(expressions, expr.String())
+		}
+	}
+
+	slices.Sort(eventTypes)
+	slices.Sort(addresses)
+	slices.Sort(contracts)
+	slices.Sort(expressions)
+
+	return eventTypes, addresses, contracts, expressions
+}
+
 // Filter applies the all filters on the provided list of events, and returns a list of events that match
 func (f *EventFilter) Filter(events flow.EventsList) flow.EventsList {
 	var filteredEvents flow.EventsList
@@ -136,6 +201,10 @@ func (f *EventFilter) Match(event flow.Event) bool {
 		return true
 	}
 
+	if exprs, ok := f.Expressions[event.Type]; ok && f.matchExpressions(&event, exprs) {
+		return true
+	}
+
 	parsed, err := events.ParseEvent(event.Type)
 	if err != nil {
 		// TODO: log this error
@@ -181,6 +250,28 @@ func (f *EventFilter) matchFieldFilter(event *flow.Event, fieldFilters FieldFilt
 	return false
 }
 
+// matchExpressions checks if the given event matches any of the provided expressions. The payload of
+// the event is only decoded once for all expressions.
+func (f *EventFilter) matchExpressions(event *flow.Event, exprs []*EventExpression) bool {
+	var fields map[string]cadence.Value
+	for _, expr := range exprs {
+		if expr.predicate == nil {
+			return true
+		}
+		if fields == nil {
+			var err error
+			fields, err = getEventFields(event)
+			if err != nil {
+				return false
+			}
+		}
+		if expr.MatchFields(fields) {
+			return true
+		}
+	}
+	return false
+}
+
 // getEventFields extracts field values and field names from the payload of a flow event.
 // It decodes the event payload into a Cadence event, retrieves the field values and fields, and returns them.
 // Parameters:
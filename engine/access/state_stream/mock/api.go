@@ -20,6 +20,70 @@ type API struct {
 	mock.Mock
 }
 
+// AcknowledgeEvents provides a mock function with given fields: ctx, name, position
+func (_m *API) AcknowledgeEvents(ctx context.Context, name string, position flow.EventPosition) error {
+	ret := _m.Called(ctx, name, position)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcknowledgeEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, flow.EventPosition) error); ok {
+		r0 = rf(ctx, name, position)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateEventCursor provides a mock function with given fields: ctx, startHeight, filter
+func (_m *API) CreateEventCursor(ctx context.Context, startHeight uint64, filter state_stream.EventFilter) (string, error) {
+	ret := _m.Called(ctx, startHeight, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEventCursor")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, state_stream.EventFilter) (string, error)); ok {
+		return rf(ctx, startHeight, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, state_stream.EventFilter) string); ok {
+		r0 = rf(ctx, startHeight, filter)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, state_stream.EventFilter) error); ok {
+		r1 = rf(ctx, startHeight, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteEventCursor provides a mock function with given fields: ctx, name
+func (_m *API) DeleteEventCursor(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEventCursor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetExecutionDataByBlockID provides a mock function with given fields: ctx, blockID
 func (_m *API) GetExecutionDataByBlockID(ctx context.Context, blockID flow.Identifier) (*execution_data.BlockExecutionData, error) {
 	ret := _m.Called(ctx, blockID)
@@ -160,6 +224,26 @@ func (_m *API) SubscribeEvents(ctx context.Context, startBlockID flow.Identifier
 	return r0
 }
 
+// SubscribeEventsFromCursor provides a mock function with given fields: ctx, name, filter
+func (_m *API) SubscribeEventsFromCursor(ctx context.Context, name string, filter state_stream.EventFilter) subscription.Subscription {
+	ret := _m.Called(ctx, name, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeEventsFromCursor")
+	}
+
+	var r0 subscription.Subscription
+	if rf, ok := ret.Get(0).(func(context.Context, string, state_stream.EventFilter) subscription.Subscription); ok {
+		r0 = rf(ctx, name, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(subscription.Subscription)
+		}
+	}
+
+	return r0
+}
+
 // SubscribeEventsFromLatest provides a mock function with given fields: ctx, filter
 func (_m *API) SubscribeEventsFromLatest(ctx context.Context, filter state_stream.EventFilter) subscription.Subscription {
 	ret := _m.Called(ctx, filter)
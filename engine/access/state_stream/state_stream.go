@@ -109,4 +109,21 @@ type API interface {
 	// SubscribeAccountStatusesFromLatestBlock subscribes to the streaming of account status changes starting from a
 	// latest sealed block, with an optional status filter.
 	SubscribeAccountStatusesFromLatestBlock(ctx context.Context, filter AccountStatusFilter) subscription.Subscription
+	// CreateEventCursor registers a new cursor with the provided filter, which starts at startHeight, or
+	// the latest sealed block if startHeight is 0, and returns its name. Cursor names are issued by the
+	// server, so a client can only use the cursors it created.
+	CreateEventCursor(ctx context.Context, startHeight uint64, filter EventFilter) (string, error)
+	// SubscribeEventsFromCursor streams the events matching the filter of the named cursor, starting right
+	// after the last event acknowledged on the cursor. Once the latest block is reached, the stream will
+	// remain open and responses are sent for each new block as it becomes available.
+	//
+	// The filter must either be empty or match the filter the cursor was created with.
+	//
+	// If invalid parameters will be supplied SubscribeEventsFromCursor will return a failed subscription.
+	SubscribeEventsFromCursor(ctx context.Context, name string, filter EventFilter) subscription.Subscription
+	// AcknowledgeEvents marks all events up to and including the given position as processed by the
+	// client of the named cursor.
+	AcknowledgeEvents(ctx context.Context, name string, position flow.EventPosition) error
+	// DeleteEventCursor removes the named cursor.
+	DeleteEventCursor(ctx context.Context, name string) error
 }
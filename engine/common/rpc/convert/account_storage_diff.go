@@ -0,0 +1,91 @@
+package convert
+
+import (
+	"github.com/onflow/flow-go/access/extended"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AccountStorageDiffToMessage converts a flow.AccountStorageDiff to a protobuf message
+func AccountStorageDiffToMessage(d *flow.AccountStorageDiff) *extended.GetAccountStorageDiffResponse {
+	changes := make([]*extended.AccountRegisterChange, len(d.Changes))
+	for i, change := range d.Changes {
+		changes[i] = &extended.AccountRegisterChange{
+			Key:         []byte(change.ID.Key),
+			Type:        extended.RegisterChangeType(change.Type),
+			ValueBefore: change.ValueBefore,
+			ValueAfter:  change.ValueAfter,
+			Kind:        extended.AccountRegisterKind(change.Kind),
+			Name:        change.Name,
+		}
+	}
+
+	var pathChanges []*extended.StoragePathChange
+	for _, change := range d.PathChanges {
+		pathChanges = append(pathChanges, &extended.StoragePathChange{
+			Path:        change.Path,
+			Type:        extended.RegisterChangeType(change.Type),
+			ValueBefore: change.ValueBefore,
+			ValueAfter:  change.ValueAfter,
+		})
+	}
+
+	var nextCursor []byte
+	if d.NextCursor != nil {
+		nextCursor = []byte(*d.NextCursor)
+	}
+
+	return &extended.GetAccountStorageDiffResponse{
+		Address:            d.Address.Bytes(),
+		FromHeight:         d.FromHeight,
+		ToHeight:           d.ToHeight,
+		Changes:            changes,
+		NextCursor:         nextCursor,
+		PathChanges:        pathChanges,
+		PathChangesDecoded: d.PathChangesDecoded,
+	}
+}
+
+// MessageToAccountStorageDiff converts a protobuf message to a flow.AccountStorageDiff
+func MessageToAccountStorageDiff(m *extended.GetAccountStorageDiffResponse) *flow.AccountStorageDiff {
+	address := flow.BytesToAddress(m.GetAddress())
+
+	changes := make([]flow.AccountRegisterChange, len(m.GetChanges()))
+	for i, change := range m.GetChanges() {
+		changes[i] = flow.AccountRegisterChange{
+			RegisterChange: flow.RegisterChange{
+				ID:          flow.NewRegisterID(address, string(change.GetKey())),
+				Type:        flow.RegisterChangeType(change.GetType()),
+				ValueBefore: change.GetValueBefore(),
+				ValueAfter:  change.GetValueAfter(),
+			},
+			Kind: flow.AccountRegisterKind(change.GetKind()),
+			Name: change.GetName(),
+		}
+	}
+
+	var pathChanges []flow.StoragePathChange
+	for _, change := range m.GetPathChanges() {
+		pathChanges = append(pathChanges, flow.StoragePathChange{
+			Path:        change.GetPath(),
+			Type:        flow.RegisterChangeType(change.GetType()),
+			ValueBefore: change.GetValueBefore(),
+			ValueAfter:  change.GetValueAfter(),
+		})
+	}
+
+	var nextCursor *string
+	if len(m.GetNextCursor()) > 0 {
+		cursor := string(m.GetNextCursor())
+		nextCursor = &cursor
+	}
+
+	return &flow.AccountStorageDiff{
+		Address:            address,
+		FromHeight:         m.GetFromHeight(),
+		ToHeight:           m.GetToHeight(),
+		Changes:            changes,
+		NextCursor:         nextCursor,
+		PathChanges:        pathChanges,
+		PathChangesDecoded: m.GetPathChangesDecoded(),
+	}
+}
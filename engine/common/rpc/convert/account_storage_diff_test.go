@@ -0,0 +1,70 @@
+package convert_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestConvertAccountStorageDiff tests that converting an account storage diff to and from a protobuf
+// message results in the same diff
+func TestConvertAccountStorageDiff(t *testing.T) {
+	t.Parallel()
+
+	address := unittest.AddressFixture()
+	cursor := "$0000000000000002"
+
+	diff := &flow.AccountStorageDiff{
+		Address:    address,
+		FromHeight: 10,
+		ToHeight:   20,
+		Changes: []flow.AccountRegisterChange{
+			{
+				RegisterChange: flow.RegisterChange{
+					ID:         flow.NewRegisterID(address, "contract_names"),
+					Type:       flow.RegisterAdded,
+					ValueAfter: []byte{1, 2, 3},
+				},
+				Kind: flow.AccountRegisterContractNames,
+			},
+			{
+				RegisterChange: flow.RegisterChange{
+					ID:          flow.NewRegisterID(address, cursor),
+					Type:        flow.RegisterModified,
+					ValueBefore: []byte{4},
+					ValueAfter:  []byte{5},
+				},
+				Kind: flow.AccountRegisterSlab,
+				Name: "2",
+			},
+		},
+		NextCursor: &cursor,
+		PathChanges: []flow.StoragePathChange{
+			{Path: "/storage/flowTokenVault", Type: flow.RegisterRemoved, ValueBefore: "A.0x1.FlowToken.Vault(balance: 1.0)"},
+		},
+		PathChangesDecoded: true,
+	}
+
+	t.Run("with next page", func(t *testing.T) {
+		msg := convert.AccountStorageDiffToMessage(diff)
+		converted := convert.MessageToAccountStorageDiff(msg)
+		assert.Equal(t, diff, converted)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		lastPage := *diff
+		lastPage.NextCursor = nil
+		lastPage.PathChanges = nil
+		lastPage.PathChangesDecoded = false
+
+		msg := convert.AccountStorageDiffToMessage(&lastPage)
+		assert.Empty(t, msg.GetNextCursor())
+
+		converted := convert.MessageToAccountStorageDiff(msg)
+		assert.Equal(t, &lastPage, converted)
+	})
+}
@@ -0,0 +1,62 @@
+package convert
+
+import (
+	"github.com/onflow/flow-go/access/extended"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AccountTransactionToMessage converts a flow.AccountTransaction to a protobuf message
+func AccountTransactionToMessage(t flow.AccountTransaction) *extended.AccountTransaction {
+	roles := make([]extended.AccountTransactionRole, len(t.Roles))
+	for i, role := range t.Roles {
+		roles[i] = extended.AccountTransactionRole(role)
+	}
+
+	return &extended.AccountTransaction{
+		Address:          t.Address.Bytes(),
+		BlockHeight:      t.BlockHeight,
+		TransactionId:    IdentifierToMessage(t.TransactionID),
+		TransactionIndex: t.TransactionIndex,
+		Roles:            roles,
+	}
+}
+
+// MessageToAccountTransaction converts a protobuf message to a flow.AccountTransaction
+func MessageToAccountTransaction(m *extended.AccountTransaction) flow.AccountTransaction {
+	roles := make([]flow.AccountTransactionRole, len(m.GetRoles()))
+	for i, role := range m.GetRoles() {
+		roles[i] = flow.AccountTransactionRole(role)
+	}
+
+	return flow.AccountTransaction{
+		Address:          flow.BytesToAddress(m.GetAddress()),
+		BlockHeight:      m.GetBlockHeight(),
+		TransactionID:    MessageToIdentifier(m.GetTransactionId()),
+		TransactionIndex: m.GetTransactionIndex(),
+		Roles:            roles,
+	}
+}
+
+// AccountTransactionCursorToMessage converts a flow.AccountTransactionCursor to a protobuf message.
+// A nil cursor is converted to a nil message.
+func AccountTransactionCursorToMessage(c *flow.AccountTransactionCursor) *extended.AccountTransactionCursor {
+	if c == nil {
+		return nil
+	}
+	return &extended.AccountTransactionCursor{
+		BlockHeight:      c.BlockHeight,
+		TransactionIndex: c.TransactionIndex,
+	}
+}
+
+// MessageToAccountTransactionCursor converts a protobuf message to a flow.AccountTransactionCursor.
+// A nil message is converted to a nil cursor.
+func MessageToAccountTransactionCursor(m *extended.AccountTransactionCursor) *flow.AccountTransactionCursor {
+	if m == nil {
+		return nil
+	}
+	return &flow.AccountTransactionCursor{
+		BlockHeight:      m.GetBlockHeight(),
+		TransactionIndex: m.GetTransactionIndex(),
+	}
+}
@@ -0,0 +1,51 @@
+package convert_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestConvertAccountTransaction tests that converting an account transaction to and from a protobuf
+// message results in the same account transaction
+func TestConvertAccountTransaction(t *testing.T) {
+	t.Parallel()
+
+	transaction := flow.AccountTransaction{
+		Address:          unittest.AddressFixture(),
+		BlockHeight:      42,
+		TransactionID:    unittest.IdentifierFixture(),
+		TransactionIndex: 3,
+		Roles: []flow.AccountTransactionRole{
+			flow.AccountTransactionRolePayer,
+			flow.AccountTransactionRoleInteraction,
+		},
+	}
+
+	msg := convert.AccountTransactionToMessage(transaction)
+	converted := convert.MessageToAccountTransaction(msg)
+
+	assert.Equal(t, transaction, converted)
+}
+
+// TestConvertAccountTransactionCursor tests that converting an account transaction cursor to and from
+// a protobuf message results in the same cursor, and that a nil cursor stays nil
+func TestConvertAccountTransactionCursor(t *testing.T) {
+	t.Parallel()
+
+	cursor := &flow.AccountTransactionCursor{
+		BlockHeight:      42,
+		TransactionIndex: 3,
+	}
+
+	msg := convert.AccountTransactionCursorToMessage(cursor)
+	converted := convert.MessageToAccountTransactionCursor(msg)
+	assert.Equal(t, cursor, converted)
+
+	assert.Nil(t, convert.AccountTransactionCursorToMessage(nil))
+	assert.Nil(t, convert.MessageToAccountTransactionCursor(nil))
+}
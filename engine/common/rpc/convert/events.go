@@ -9,6 +9,7 @@ import (
 	"github.com/onflow/cadence/encoding/ccf"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 
+	"github.com/onflow/flow-go/access/extended"
 	"github.com/onflow/flow-go/model/flow"
 
 	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
@@ -302,3 +303,21 @@ func BlockEventsToMessage(block flow.BlockEvents) (*accessproto.EventsResponse_R
 		Events:         eventMessages,
 	}, nil
 }
+
+// EventPositionToMessage converts a flow.EventPosition to a protobuf message
+func EventPositionToMessage(p flow.EventPosition) *extended.EventPosition {
+	return &extended.EventPosition{
+		BlockHeight:      p.BlockHeight,
+		TransactionIndex: p.TransactionIndex,
+		EventIndex:       p.EventIndex,
+	}
+}
+
+// MessageToEventPosition converts a protobuf message to a flow.EventPosition
+func MessageToEventPosition(m *extended.EventPosition) flow.EventPosition {
+	return flow.EventPosition{
+		BlockHeight:      m.GetBlockHeight(),
+		TransactionIndex: m.GetTransactionIndex(),
+		EventIndex:       m.GetEventIndex(),
+	}
+}
@@ -228,3 +228,20 @@ func TestConvertMessagesToBlockEvents(t *testing.T) {
 
 	assert.Equal(t, blockEvents, converted)
 }
+
+// TestConvertEventPosition tests that converting an event position to and from a protobuf message
+// results in the same position
+func TestConvertEventPosition(t *testing.T) {
+	t.Parallel()
+
+	position := flow.EventPosition{
+		BlockHeight:      42,
+		TransactionIndex: 3,
+		EventIndex:       7,
+	}
+
+	msg := convert.EventPositionToMessage(position)
+	converted := convert.MessageToEventPosition(msg)
+
+	assert.Equal(t, position, converted)
+}
@@ -0,0 +1,141 @@
+package convert
+
+import (
+	"fmt"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+
+	"github.com/onflow/flow-go/access/extended"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// EVMLogToMessage converts a flow.EVMLog to a protobuf message
+func EVMLogToMessage(l flow.EVMLog) *extended.EVMLog {
+	topics := make([][]byte, len(l.Topics))
+	for i, topic := range l.Topics {
+		topics[i] = topic.Bytes()
+	}
+
+	return &extended.EVMLog{
+		BlockHeight:         l.BlockHeight,
+		TransactionId:       IdentifierToMessage(l.TransactionID),
+		EvmBlockHeight:      l.EVMBlockHeight,
+		EvmTransactionHash:  l.EVMTransactionHash.Bytes(),
+		EvmTransactionIndex: l.EVMTransactionIndex,
+		LogIndex:            l.LogIndex,
+		Address:             l.Address.Bytes(),
+		Topics:              topics,
+		Data:                l.Data,
+	}
+}
+
+// MessageToEVMLog converts a protobuf message to a flow.EVMLog
+func MessageToEVMLog(m *extended.EVMLog) flow.EVMLog {
+	topics := make([]gethCommon.Hash, len(m.GetTopics()))
+	for i, topic := range m.GetTopics() {
+		topics[i] = gethCommon.BytesToHash(topic)
+	}
+
+	return flow.EVMLog{
+		BlockHeight:         m.GetBlockHeight(),
+		TransactionID:       MessageToIdentifier(m.GetTransactionId()),
+		EVMBlockHeight:      m.GetEvmBlockHeight(),
+		EVMTransactionHash:  gethCommon.BytesToHash(m.GetEvmTransactionHash()),
+		EVMTransactionIndex: m.GetEvmTransactionIndex(),
+		LogIndex:            m.GetLogIndex(),
+		Address:             gethCommon.BytesToAddress(m.GetAddress()),
+		Topics:              topics,
+		Data:                m.GetData(),
+	}
+}
+
+// EVMLogsToMessages converts a slice of flow.EVMLog to protobuf messages
+func EVMLogsToMessages(logs []flow.EVMLog) []*extended.EVMLog {
+	messages := make([]*extended.EVMLog, len(logs))
+	for i, log := range logs {
+		messages[i] = EVMLogToMessage(log)
+	}
+	return messages
+}
+
+// EVMLogCursorToMessage converts a flow.EVMLogCursor to a protobuf message.
+// A nil cursor is converted to a nil message.
+func EVMLogCursorToMessage(c *flow.EVMLogCursor) *extended.EVMLogCursor {
+	if c == nil {
+		return nil
+	}
+	return &extended.EVMLogCursor{
+		BlockHeight: c.BlockHeight,
+		LogIndex:    c.LogIndex,
+	}
+}
+
+// MessageToEVMLogCursor converts a protobuf message to a flow.EVMLogCursor.
+// A nil message is converted to a nil cursor.
+func MessageToEVMLogCursor(m *extended.EVMLogCursor) *flow.EVMLogCursor {
+	if m == nil {
+		return nil
+	}
+	return &flow.EVMLogCursor{
+		BlockHeight: m.GetBlockHeight(),
+		LogIndex:    m.GetLogIndex(),
+	}
+}
+
+// EVMLogFilterToMessage converts a flow.EVMLogFilter to a protobuf message
+func EVMLogFilterToMessage(f flow.EVMLogFilter) *extended.EVMLogFilter {
+	addresses := make([][]byte, len(f.Addresses))
+	for i, address := range f.Addresses {
+		addresses[i] = address.Bytes()
+	}
+
+	topics := make([]*extended.EVMLogTopics, len(f.Topics))
+	for i, position := range f.Topics {
+		topics[i] = &extended.EVMLogTopics{}
+		for _, topic := range position {
+			topics[i].Topics = append(topics[i].Topics, topic.Bytes())
+		}
+	}
+
+	return &extended.EVMLogFilter{
+		Addresses: addresses,
+		Topics:    topics,
+	}
+}
+
+// MessageToEVMLogFilter converts a protobuf message to a flow.EVMLogFilter. Trailing positions without
+// topics are omitted from the filter. A nil message is converted to an empty filter.
+//
+// Expected errors during normal operation:
+//   - if an address or topic has an invalid length, or the filter has too many topic positions.
+func MessageToEVMLogFilter(m *extended.EVMLogFilter) (flow.EVMLogFilter, error) {
+	var filter flow.EVMLogFilter
+
+	if len(m.GetTopics()) > flow.EVMLogMaxTopics {
+		return filter, fmt.Errorf("at most %d topic positions are supported", flow.EVMLogMaxTopics)
+	}
+
+	for _, address := range m.GetAddresses() {
+		if len(address) != gethCommon.AddressLength {
+			return filter, fmt.Errorf("invalid EVM address %x: must be %d bytes", address, gethCommon.AddressLength)
+		}
+		filter.Addresses = append(filter.Addresses, gethCommon.BytesToAddress(address))
+	}
+
+	topics := make([][]gethCommon.Hash, len(m.GetTopics()))
+	last := -1
+	for i, position := range m.GetTopics() {
+		for _, topic := range position.GetTopics() {
+			if len(topic) != gethCommon.HashLength {
+				return filter, fmt.Errorf("invalid EVM log topic %x: must be %d bytes", topic, gethCommon.HashLength)
+			}
+			topics[i] = append(topics[i], gethCommon.BytesToHash(topic))
+			last = i
+		}
+	}
+	if last >= 0 {
+		filter.Topics = topics[:last+1]
+	}
+
+	return filter, nil
+}
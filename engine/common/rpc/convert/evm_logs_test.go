@@ -0,0 +1,108 @@
+package convert_test
+
+import (
+	"testing"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/access/extended"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestConvertEVMLog tests that converting an EVM log to and from a protobuf message results in the
+// same log
+func TestConvertEVMLog(t *testing.T) {
+	t.Parallel()
+
+	log := flow.EVMLog{
+		BlockHeight:         42,
+		TransactionID:       unittest.IdentifierFixture(),
+		EVMBlockHeight:      7,
+		EVMTransactionHash:  gethCommon.HexToHash("0x01"),
+		EVMTransactionIndex: 2,
+		LogIndex:            5,
+		Address:             gethCommon.HexToAddress("0x02"),
+		Topics:              []gethCommon.Hash{gethCommon.HexToHash("0x03"), gethCommon.HexToHash("0x04")},
+		Data:                []byte{1, 2, 3},
+	}
+
+	msg := convert.EVMLogToMessage(log)
+	converted := convert.MessageToEVMLog(msg)
+
+	assert.Equal(t, log, converted)
+}
+
+// TestConvertEVMLogCursor tests that converting an EVM log cursor to and from a protobuf message results
+// in the same cursor, and that a nil cursor stays nil
+func TestConvertEVMLogCursor(t *testing.T) {
+	t.Parallel()
+
+	cursor := &flow.EVMLogCursor{BlockHeight: 42, LogIndex: 3}
+
+	msg := convert.EVMLogCursorToMessage(cursor)
+	converted := convert.MessageToEVMLogCursor(msg)
+	assert.Equal(t, cursor, converted)
+
+	assert.Nil(t, convert.EVMLogCursorToMessage(nil))
+	assert.Nil(t, convert.MessageToEVMLogCursor(nil))
+}
+
+// TestConvertEVMLogFilter tests that converting an EVM log filter to and from a protobuf message results
+// in the same filter, and that invalid filters are rejected
+func TestConvertEVMLogFilter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trip", func(t *testing.T) {
+		filter := flow.EVMLogFilter{
+			Addresses: []gethCommon.Address{gethCommon.HexToAddress("0x01")},
+			Topics: [][]gethCommon.Hash{
+				nil,
+				{gethCommon.HexToHash("0x02"), gethCommon.HexToHash("0x03")},
+			},
+		}
+
+		msg := convert.EVMLogFilterToMessage(filter)
+		converted, err := convert.MessageToEVMLogFilter(msg)
+		require.NoError(t, err)
+		assert.Equal(t, filter, converted)
+	})
+
+	t.Run("nil filter", func(t *testing.T) {
+		converted, err := convert.MessageToEVMLogFilter(nil)
+		require.NoError(t, err)
+		assert.Equal(t, flow.EVMLogFilter{}, converted)
+	})
+
+	t.Run("trailing positions without topics", func(t *testing.T) {
+		converted, err := convert.MessageToEVMLogFilter(&extended.EVMLogFilter{
+			Topics: []*extended.EVMLogTopics{{}, {}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, flow.EVMLogFilter{}, converted)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		_, err := convert.MessageToEVMLogFilter(&extended.EVMLogFilter{
+			Addresses: [][]byte{{1, 2, 3}},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid topic", func(t *testing.T) {
+		_, err := convert.MessageToEVMLogFilter(&extended.EVMLogFilter{
+			Topics: []*extended.EVMLogTopics{{Topics: [][]byte{{1, 2, 3}}}},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("too many topic positions", func(t *testing.T) {
+		_, err := convert.MessageToEVMLogFilter(&extended.EVMLogFilter{
+			Topics: make([]*extended.EVMLogTopics, flow.EVMLogMaxTopics+1),
+		})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,70 @@
+package convert
+
+import (
+	"github.com/onflow/cadence/common"
+
+	"github.com/onflow/flow-go/access/extended"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// TransactionFeeEstimateToMessage converts a flow.TransactionFeeEstimate to a protobuf message
+func TransactionFeeEstimateToMessage(e *flow.TransactionFeeEstimate) *extended.EstimateTransactionFeesResponse {
+	intensities := make(map[uint32]uint64, len(e.ComputationIntensities))
+	for kind, intensity := range e.ComputationIntensities {
+		intensities[uint32(kind)] = intensity
+	}
+
+	var statusCode uint32
+	if e.Failed() {
+		statusCode = 1
+	}
+
+	return &extended.EstimateTransactionFeesResponse{
+		BlockId:                IdentifierToMessage(e.BlockID),
+		BlockHeight:            e.BlockHeight,
+		StatusCode:             statusCode,
+		ErrorMessage:           e.ErrorMessage,
+		ComputationLimit:       e.ComputationLimit,
+		ComputationUsed:        e.ComputationUsed,
+		ComputationIntensities: intensities,
+		MemoryEstimate:         e.MemoryEstimate,
+		InclusionEffort:        e.InclusionEffort,
+		ExecutionEffort:        e.ExecutionEffort,
+		FeeParameters: &extended.TransactionFeeParameters{
+			SurgeFactor:         e.FeeParameters.SurgeFactor,
+			InclusionEffortCost: e.FeeParameters.InclusionEffortCost,
+			ExecutionEffortCost: e.FeeParameters.ExecutionEffortCost,
+		},
+		InclusionFee: e.InclusionFee,
+		ExecutionFee: e.ExecutionFee,
+		TotalFee:     e.TotalFee,
+	}
+}
+
+// MessageToTransactionFeeEstimate converts a protobuf message to a flow.TransactionFeeEstimate
+func MessageToTransactionFeeEstimate(m *extended.EstimateTransactionFeesResponse) *flow.TransactionFeeEstimate {
+	intensities := make(map[common.ComputationKind]uint64, len(m.GetComputationIntensities()))
+	for kind, intensity := range m.GetComputationIntensities() {
+		intensities[common.ComputationKind(kind)] = intensity
+	}
+
+	return &flow.TransactionFeeEstimate{
+		BlockID:                MessageToIdentifier(m.GetBlockId()),
+		BlockHeight:            m.GetBlockHeight(),
+		ErrorMessage:           m.GetErrorMessage(),
+		ComputationLimit:       m.GetComputationLimit(),
+		ComputationUsed:        m.GetComputationUsed(),
+		ComputationIntensities: intensities,
+		MemoryEstimate:         m.GetMemoryEstimate(),
+		InclusionEffort:        m.GetInclusionEffort(),
+		ExecutionEffort:        m.GetExecutionEffort(),
+		FeeParameters: flow.TransactionFeeParameters{
+			SurgeFactor:         m.GetFeeParameters().GetSurgeFactor(),
+			InclusionEffortCost: m.GetFeeParameters().GetInclusionEffortCost(),
+			ExecutionEffortCost: m.GetFeeParameters().GetExecutionEffortCost(),
+		},
+		InclusionFee: m.GetInclusionFee(),
+		ExecutionFee: m.GetExecutionFee(),
+		TotalFee:     m.GetTotalFee(),
+	}
+}
@@ -0,0 +1,46 @@
+package convert_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestConvertTransactionFeeEstimate tests that converting a transaction fee estimate to and from a
+// protobuf message results in the same estimate
+func TestConvertTransactionFeeEstimate(t *testing.T) {
+	t.Parallel()
+
+	estimate := &flow.TransactionFeeEstimate{
+		BlockID:          unittest.IdentifierFixture(),
+		BlockHeight:      42,
+		ComputationLimit: 9999,
+		ComputationUsed:  25,
+		ComputationIntensities: map[common.ComputationKind]uint64{
+			common.ComputationKindStatement: 10,
+			common.ComputationKindLoop:      3,
+		},
+		MemoryEstimate:  4096,
+		InclusionEffort: 100_000_000,
+		ExecutionEffort: 25_000,
+		FeeParameters: flow.TransactionFeeParameters{
+			SurgeFactor:         100_000_000,
+			InclusionEffortCost: 1_000,
+			ExecutionEffortCost: 4_999_000,
+		},
+		InclusionFee: 1_000,
+		ExecutionFee: 1_249,
+		TotalFee:     2_249,
+	}
+
+	msg := convert.TransactionFeeEstimateToMessage(estimate)
+	assert.Equal(t, uint32(0), msg.GetStatusCode())
+
+	converted := convert.MessageToTransactionFeeEstimate(msg)
+	assert.Equal(t, estimate, converted)
+}
@@ -0,0 +1,78 @@
+package convert
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+
+	"github.com/onflow/flow-go/access/extended"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// TransactionSimulationResultToMessage converts a flow.TransactionSimulationResult to a protobuf message,
+// converting the CCF encoded events of the result to the given encoding version
+func TransactionSimulationResultToMessage(
+	r *flow.TransactionSimulationResult,
+	version entities.EventEncodingVersion,
+) (*extended.SimulateTransactionResponse, error) {
+	events, err := EventsToMessagesWithEncodingConversion(r.Events, entities.EventEncodingVersion_CCF_V0, version)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert events: %w", err)
+	}
+
+	var statusCode uint32
+	if r.Failed() {
+		statusCode = 1
+	}
+
+	return &extended.SimulateTransactionResponse{
+		BlockId:         IdentifierToMessage(r.BlockID),
+		BlockHeight:     r.BlockHeight,
+		StatusCode:      statusCode,
+		ErrorMessage:    r.ErrorMessage,
+		Events:          events,
+		ComputationUsed: r.ComputationUsed,
+		MemoryEstimate:  r.MemoryEstimate,
+		StorageDeltas:   AccountStorageDeltasToMessages(r.StorageDeltas),
+	}, nil
+}
+
+// MessageToTransactionSimulationResult converts a protobuf message to a flow.TransactionSimulationResult.
+// The payloads of the events are kept in the encoding of the message
+func MessageToTransactionSimulationResult(m *extended.SimulateTransactionResponse) *flow.TransactionSimulationResult {
+	return &flow.TransactionSimulationResult{
+		BlockID:         MessageToIdentifier(m.GetBlockId()),
+		BlockHeight:     m.GetBlockHeight(),
+		ErrorMessage:    m.GetErrorMessage(),
+		Events:          MessagesToEvents(m.GetEvents()),
+		ComputationUsed: m.GetComputationUsed(),
+		MemoryEstimate:  m.GetMemoryEstimate(),
+		StorageDeltas:   MessagesToAccountStorageDeltas(m.GetStorageDeltas()),
+	}
+}
+
+// AccountStorageDeltasToMessages converts a slice of flow.AccountStorageDelta to protobuf messages
+func AccountStorageDeltasToMessages(deltas []flow.AccountStorageDelta) []*extended.AccountStorageDelta {
+	messages := make([]*extended.AccountStorageDelta, len(deltas))
+	for i, delta := range deltas {
+		messages[i] = &extended.AccountStorageDelta{
+			Address:           delta.Address.Bytes(),
+			StorageUsedBefore: delta.StorageUsedBefore,
+			StorageUsedAfter:  delta.StorageUsedAfter,
+		}
+	}
+	return messages
+}
+
+// MessagesToAccountStorageDeltas converts protobuf messages to a slice of flow.AccountStorageDelta
+func MessagesToAccountStorageDeltas(messages []*extended.AccountStorageDelta) []flow.AccountStorageDelta {
+	deltas := make([]flow.AccountStorageDelta, len(messages))
+	for i, m := range messages {
+		deltas[i] = flow.AccountStorageDelta{
+			Address:           flow.BytesToAddress(m.GetAddress()),
+			StorageUsedBefore: m.GetStorageUsedBefore(),
+			StorageUsedAfter:  m.GetStorageUsedAfter(),
+		}
+	}
+	return deltas
+}
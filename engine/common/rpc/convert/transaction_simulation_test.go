@@ -0,0 +1,54 @@
+package convert_test
+
+import (
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+	"github.com/onflow/flow-go/utils/unittest/generator"
+)
+
+// TestConvertTransactionSimulationResult tests that converting a transaction simulation result to and
+// from a protobuf message results in the same result, with the events in the requested encoding
+func TestConvertTransactionSimulationResult(t *testing.T) {
+	t.Parallel()
+
+	ccfEvents := generator.GetEventsWithEncoding(3, entities.EventEncodingVersion_CCF_V0)
+
+	result := &flow.TransactionSimulationResult{
+		BlockID:         unittest.IdentifierFixture(),
+		BlockHeight:     42,
+		ErrorMessage:    "[Error Code: 1101] cadence runtime error",
+		Events:          ccfEvents,
+		ComputationUsed: 100,
+		MemoryEstimate:  2000,
+		StorageDeltas: []flow.AccountStorageDelta{
+			{Address: unittest.AddressFixture(), StorageUsedBefore: 100, StorageUsedAfter: 150},
+		},
+	}
+
+	t.Run("ccf events", func(t *testing.T) {
+		msg, err := convert.TransactionSimulationResultToMessage(result, entities.EventEncodingVersion_CCF_V0)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1), msg.GetStatusCode())
+
+		converted := convert.MessageToTransactionSimulationResult(msg)
+		assert.Equal(t, result, converted)
+	})
+
+	t.Run("json events", func(t *testing.T) {
+		msg, err := convert.TransactionSimulationResultToMessage(result, entities.EventEncodingVersion_JSON_CDC_V0)
+		require.NoError(t, err)
+
+		jsonEvents, err := convert.CcfEventsToJsonEvents(ccfEvents)
+		require.NoError(t, err)
+
+		converted := convert.MessageToTransactionSimulationResult(msg)
+		assert.Equal(t, flow.EventsList(jsonEvents), converted.Events)
+	})
+}
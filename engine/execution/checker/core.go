@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
 
 	"github.com/onflow/flow-go/engine/execution/state"
 	"github.com/onflow/flow-go/model/flow"
@@ -14,21 +15,41 @@ import (
 )
 
 // Core is the core logic of the checker engine that checks if the execution result matches the sealed result.
+// When a mismatch is detected, a divergence report is generated and written to the report directory.
 type Core struct {
 	log       zerolog.Logger
 	state     protocol.State
 	execState state.ExecutionState
+	seals     storage.Seals
+	results   storage.ExecutionResults
+	events    storage.Events
+	txResults storage.TransactionResults
+
+	// reportDir is the directory divergence reports are written to, reports are not written if empty.
+	reportDir  string
+	lastReport *atomic.Pointer[DivergenceReport]
 }
 
 func NewCore(
 	logger zerolog.Logger,
 	state protocol.State,
 	execState state.ExecutionState,
+	seals storage.Seals,
+	results storage.ExecutionResults,
+	events storage.Events,
+	txResults storage.TransactionResults,
+	reportDir string,
 ) *Core {
 	e := &Core{
-		log:       logger.With().Str("engine", "checker").Logger(),
-		state:     state,
-		execState: execState,
+		log:        logger.With().Str("engine", "checker").Logger(),
+		state:      state,
+		execState:  execState,
+		seals:      seals,
+		results:    results,
+		events:     events,
+		txResults:  txResults,
+		reportDir:  reportDir,
+		lastReport: atomic.NewPointer[DivergenceReport](nil),
 	}
 
 	return e
@@ -71,7 +92,7 @@ func (c *Core) RunCheck() error {
 	mycommitAtLastSealed, err := c.execState.StateCommitmentByBlockID(lastSealedBlock.ID())
 	if err == nil {
 		// if last sealed block has been executed, then check if they match
-		return checkMyCommitWithSealedCommit(c.log, lastSealedBlock, mycommitAtLastSealed, seal.FinalState)
+		return c.check(lastSealedBlock, mycommitAtLastSealed, seal)
 	}
 
 	// if last sealed block has not been executed, then check if recent executed block has
@@ -94,8 +115,6 @@ func (c *Core) RunCheck() error {
 		return fmt.Errorf("could not get the last sealed block at height: %v, err: %w", lastExecutedHeight, err)
 	}
 
-	sealedCommit := seal.FinalState
-
 	mycommit, err := c.execState.StateCommitmentByBlockID(seal.BlockID)
 	if errors.Is(err, storage.ErrNotFound) {
 		// have not executed the sealed block yet
@@ -108,7 +127,18 @@ func (c *Core) RunCheck() error {
 		return fmt.Errorf("could not get my state commitment OnFinalizedBlock, blockID: %v", seal.BlockID)
 	}
 
-	return checkMyCommitWithSealedCommit(c.log, sealedExecuted, mycommit, sealedCommit)
+	return c.check(sealedExecuted, mycommit, seal)
+}
+
+// check compares my commit of the executed block with the sealed commit, and reports the
+// divergence if they mismatch.
+func (c *Core) check(executedBlock *flow.Header, myCommit flow.StateCommitment, seal *flow.Seal) error {
+	err := checkMyCommitWithSealedCommit(c.log, executedBlock, myCommit, seal.FinalState)
+	if err != nil {
+		c.reportDivergence(executedBlock, seal)
+		return err
+	}
+	return nil
 }
 
 // findLastSealedBlock finds the last sealed block
@@ -11,6 +11,7 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
 	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -18,7 +19,19 @@ func makeCore(t *testing.T) (*checker.Core, *protocol.State, *stateMock.Executio
 	logger := unittest.Logger()
 	state := protocol.NewState(t)
 	execState := stateMock.NewExecutionState(t)
-	core := checker.NewCore(logger, state, execState)
+	// divergence reports are tested separately, generating them fails for mismatches
+	results := storagemock.NewExecutionResults(t)
+	results.On("ByBlockID", mock.Anything).Return(nil, storage.ErrNotFound).Maybe()
+	core := checker.NewCore(
+		logger,
+		state,
+		execState,
+		storagemock.NewSeals(t),
+		results,
+		storagemock.NewEvents(t),
+		storagemock.NewTransactionResults(t),
+		"",
+	)
 	return core, state, execState
 }
 
@@ -0,0 +1,431 @@
+package checker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// DivergenceReport describes how our execution result of a block differs from the sealed result.
+// It is produced when the checker detects a mismatch, so that the transaction causing the
+// execution fork can be found without bisecting the chain manually.
+type DivergenceReport struct {
+	BlockID   flow.Identifier `json:"block_id"`
+	Height    uint64          `json:"height"`
+	CreatedAt time.Time       `json:"created_at"`
+
+	// Diverged is false if our result matches the sealed result, which is only possible for
+	// reports generated on demand.
+	Diverged bool `json:"diverged"`
+
+	MyResultID       flow.Identifier      `json:"my_result_id"`
+	SealedResultID   flow.Identifier      `json:"sealed_result_id"`
+	MyFinalState     flow.StateCommitment `json:"my_final_state"`
+	SealedFinalState flow.StateCommitment `json:"sealed_final_state"`
+
+	// Chunks holds the chunks which differ between the two results, ordered by chunk index.
+	Chunks []ChunkDivergence `json:"chunks,omitempty"`
+
+	// ServiceEvents is set if the service events of the two results differ.
+	ServiceEvents *ServiceEventsDivergence `json:"service_events,omitempty"`
+
+	// FirstDivergingChunk is the index of the first chunk which differs, if any.
+	FirstDivergingChunk *uint64 `json:"first_diverging_chunk,omitempty"`
+
+	// FirstTransactionIndex is the index within the block of the first transaction of the first
+	// diverging chunk. Execution results don't commit to the state after each transaction, so
+	// the divergence is known to be introduced by this transaction or one of the following
+	// transactions of the same chunk.
+	FirstTransactionIndex *uint32 `json:"first_transaction_index,omitempty"`
+
+	// Transactions holds our results and events of the transactions of the first diverging chunk.
+	Transactions []TransactionReport `json:"transactions,omitempty"`
+
+	// RegisterChanges holds the registers updated by our execution of the first diverging chunk.
+	RegisterChanges []RegisterChange `json:"register_changes,omitempty"`
+
+	// UnresolvedRegisters is the number of registers touched by the first diverging chunk which
+	// did not exist at the start of the chunk. Their IDs can't be recovered from the chunk data
+	// pack, so they are not included in RegisterChanges.
+	UnresolvedRegisters int `json:"unresolved_registers,omitempty"`
+
+	// Errors holds the reasons why parts of the report could not be generated.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ChunkSummary holds the fields of a chunk which are compared between the two results.
+type ChunkSummary struct {
+	CollectionIndex      uint                 `json:"collection_index"`
+	StartState           flow.StateCommitment `json:"start_state"`
+	EndState             flow.StateCommitment `json:"end_state"`
+	EventCollection      flow.Identifier      `json:"event_collection"`
+	NumberOfTransactions uint64               `json:"number_of_transactions"`
+	TotalComputationUsed uint64               `json:"total_computation_used"`
+}
+
+// ChunkDivergence describes a chunk which differs between our result and the sealed result.
+// Mine or Sealed is nil if the chunk only exists in the other result.
+type ChunkDivergence struct {
+	Index                    uint64        `json:"index"`
+	Mine                     *ChunkSummary `json:"mine,omitempty"`
+	Sealed                   *ChunkSummary `json:"sealed,omitempty"`
+	StartStateDiffers        bool          `json:"start_state_differs"`
+	EndStateDiffers          bool          `json:"end_state_differs"`
+	EventCollectionDiffers   bool          `json:"event_collection_differs"`
+	TransactionsCountDiffers bool          `json:"transactions_count_differs"`
+	ComputationUsedDiffers   bool          `json:"computation_used_differs"`
+}
+
+// ServiceEventsDivergence holds the service events of both results, if they differ.
+type ServiceEventsDivergence struct {
+	Mine   flow.ServiceEventList `json:"mine"`
+	Sealed flow.ServiceEventList `json:"sealed"`
+}
+
+// TransactionReport holds our result of a transaction of the diverging chunk.
+type TransactionReport struct {
+	Index           uint32          `json:"index"`
+	TransactionID   flow.Identifier `json:"transaction_id"`
+	ErrorMessage    string          `json:"error_message,omitempty"`
+	ComputationUsed uint64          `json:"computation_used"`
+	Events          []flow.Event    `json:"events"`
+}
+
+// RegisterChange is a register updated by our execution of the diverging chunk.
+type RegisterChange struct {
+	Register string `json:"register"`
+	Before   []byte `json:"before"`
+	After    []byte `json:"after"`
+}
+
+// DivergenceReport generates a report comparing our execution result of the given block with
+// the finalized seal for the block. The block must have been executed and sealed.
+//
+// Expected errors during normal operations:
+//   - storage.ErrNotFound if the block was not executed, or is not sealed yet
+func (c *Core) DivergenceReport(blockID flow.Identifier) (*DivergenceReport, error) {
+	header, err := c.state.AtBlockID(blockID).Head()
+	if err != nil {
+		return nil, fmt.Errorf("could not get block %v: %w", blockID, err)
+	}
+
+	seal, err := c.seals.FinalizedSealForBlock(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get finalized seal for block %v: %w", blockID, err)
+	}
+
+	return c.divergenceReport(header, seal)
+}
+
+// divergenceReport compares our execution result of the block with the sealed result.
+// Failures to collect the details of the diverging chunk are recorded in the report instead of
+// failing, as the comparison of the results is useful on its own.
+func (c *Core) divergenceReport(header *flow.Header, seal *flow.Seal) (*DivergenceReport, error) {
+	blockID := header.ID()
+
+	myResult, err := c.results.ByBlockID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get my execution result for block %v: %w", blockID, err)
+	}
+
+	sealedResult, err := c.results.ByID(seal.ResultID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get sealed execution result %v: %w", seal.ResultID, err)
+	}
+
+	report := &DivergenceReport{
+		BlockID:          blockID,
+		Height:           header.Height,
+		CreatedAt:        time.Now().UTC(),
+		MyResultID:       myResult.ID(),
+		SealedResultID:   sealedResult.ID(),
+		MyFinalState:     finalState(myResult),
+		SealedFinalState: seal.FinalState,
+	}
+
+	report.Chunks = compareChunks(myResult.Chunks, sealedResult.Chunks)
+	if len(report.Chunks) > 0 {
+		first := report.Chunks[0].Index
+		report.FirstDivergingChunk = &first
+	}
+
+	sameServiceEvents, err := myResult.ServiceEvents.EqualTo(sealedResult.ServiceEvents)
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("could not compare service events: %v", err))
+	} else if !sameServiceEvents {
+		report.ServiceEvents = &ServiceEventsDivergence{
+			Mine:   myResult.ServiceEvents,
+			Sealed: sealedResult.ServiceEvents,
+		}
+	}
+
+	report.Diverged = report.MyFinalState != report.SealedFinalState ||
+		len(report.Chunks) > 0 ||
+		report.ServiceEvents != nil
+
+	// the diverging chunk might not exist in our result, if the sealed result has more chunks
+	if report.FirstDivergingChunk != nil && *report.FirstDivergingChunk < uint64(len(myResult.Chunks)) {
+		c.addChunkDetails(report, header, myResult, myResult.Chunks[*report.FirstDivergingChunk])
+	}
+
+	return report, nil
+}
+
+// compareChunks returns the chunks which differ between the two lists of chunks.
+func compareChunks(mine flow.ChunkList, sealed flow.ChunkList) []ChunkDivergence {
+	var divergences []ChunkDivergence
+
+	count := len(mine)
+	if len(sealed) > count {
+		count = len(sealed)
+	}
+
+	for i := 0; i < count; i++ {
+		divergence := ChunkDivergence{Index: uint64(i)}
+		if i < len(mine) {
+			divergence.Mine = chunkSummary(mine[i])
+		}
+		if i < len(sealed) {
+			divergence.Sealed = chunkSummary(sealed[i])
+		}
+
+		if divergence.Mine == nil || divergence.Sealed == nil {
+			divergences = append(divergences, divergence)
+			continue
+		}
+
+		divergence.StartStateDiffers = divergence.Mine.StartState != divergence.Sealed.StartState
+		divergence.EndStateDiffers = divergence.Mine.EndState != divergence.Sealed.EndState
+		divergence.EventCollectionDiffers = divergence.Mine.EventCollection != divergence.Sealed.EventCollection
+		divergence.TransactionsCountDiffers = divergence.Mine.NumberOfTransactions != divergence.Sealed.NumberOfTransactions
+		divergence.ComputationUsedDiffers = divergence.Mine.TotalComputationUsed != divergence.Sealed.TotalComputationUsed
+
+		if divergence.StartStateDiffers ||
+			divergence.EndStateDiffers ||
+			divergence.EventCollectionDiffers ||
+			divergence.TransactionsCountDiffers ||
+			divergence.ComputationUsedDiffers {
+			divergences = append(divergences, divergence)
+		}
+	}
+
+	return divergences
+}
+
+func chunkSummary(chunk *flow.Chunk) *ChunkSummary {
+	return &ChunkSummary{
+		CollectionIndex:      chunk.CollectionIndex,
+		StartState:           chunk.StartState,
+		EndState:             chunk.EndState,
+		EventCollection:      chunk.EventCollection,
+		NumberOfTransactions: chunk.NumberOfTransactions,
+		TotalComputationUsed: chunk.TotalComputationUsed,
+	}
+}
+
+func finalState(result *flow.ExecutionResult) flow.StateCommitment {
+	commit, err := result.FinalStateCommitment()
+	if err != nil {
+		return flow.DummyStateCommitment
+	}
+	return commit
+}
+
+// addChunkDetails adds our transactions and register changes of the diverging chunk to the report.
+func (c *Core) addChunkDetails(
+	report *DivergenceReport,
+	header *flow.Header,
+	myResult *flow.ExecutionResult,
+	chunk *flow.Chunk,
+) {
+	firstTxIndex := uint64(0)
+	for _, previous := range myResult.Chunks[:chunk.Index] {
+		firstTxIndex += previous.NumberOfTransactions
+	}
+	first := uint32(firstTxIndex)
+	report.FirstTransactionIndex = &first
+
+	transactions, err := c.chunkTransactions(header.ID(), first, chunk.NumberOfTransactions)
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("could not get transactions of chunk %d: %v", chunk.Index, err))
+	}
+	report.Transactions = transactions
+
+	changes, unresolved, err := c.chunkRegisterChanges(header, chunk)
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("could not get register changes of chunk %d: %v", chunk.Index, err))
+	}
+	report.RegisterChanges = changes
+	report.UnresolvedRegisters = unresolved
+}
+
+// chunkTransactions returns our results and events of the given range of transactions of the block.
+func (c *Core) chunkTransactions(blockID flow.Identifier, first uint32, count uint64) ([]TransactionReport, error) {
+	txResults, err := c.txResults.ByBlockID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get transaction results: %w", err)
+	}
+
+	events, err := c.events.ByBlockID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get events: %w", err)
+	}
+
+	end := uint64(first) + count
+	if end > uint64(len(txResults)) {
+		return nil, fmt.Errorf("chunk transactions [%d, %d) out of range, block has %d transaction results", first, end, len(txResults))
+	}
+
+	transactions := make([]TransactionReport, 0, count)
+	for i := uint64(first); i < end; i++ {
+		result := txResults[i]
+		transactions = append(transactions, TransactionReport{
+			Index:           uint32(i),
+			TransactionID:   result.TransactionID,
+			ErrorMessage:    result.ErrorMessage,
+			ComputationUsed: result.ComputationUsed,
+			Events:          []flow.Event{},
+		})
+	}
+
+	for _, event := range events {
+		if event.TransactionIndex < first || uint64(event.TransactionIndex) >= end {
+			continue
+		}
+		tx := &transactions[event.TransactionIndex-first]
+		tx.Events = append(tx.Events, event)
+	}
+
+	return transactions, nil
+}
+
+// chunkRegisterChanges returns the registers updated by our execution of the chunk, and the number
+// of registers touched by the chunk which did not exist at the start of the chunk.
+//
+// The touched registers and their values at the start of the chunk are taken from the proof of the
+// chunk data pack, and their values at the end of the chunk are read from our execution state.
+// Note that with the storehouse enabled, the values are read at the end of the block instead.
+func (c *Core) chunkRegisterChanges(header *flow.Header, chunk *flow.Chunk) ([]RegisterChange, int, error) {
+	chunkDataPack, err := c.execState.ChunkDataPackByChunkID(chunk.ID())
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not get chunk data pack: %w", err)
+	}
+
+	proof, err := ledger.DecodeTrieBatchProof(chunkDataPack.Proof)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not decode chunk data pack proof: %w", err)
+	}
+
+	snapshot := c.execState.NewStorageSnapshot(chunk.EndState, header.ID(), header.Height)
+
+	var changes []RegisterChange
+	unresolved := 0
+	for _, p := range proof.Proofs {
+		if !p.Inclusion || p.Payload.IsEmpty() {
+			unresolved++
+			continue
+		}
+
+		key, err := p.Payload.Key()
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not decode payload key: %w", err)
+		}
+
+		registerID, err := convert.LedgerKeyToRegisterID(key)
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not convert payload key: %w", err)
+		}
+
+		before := []byte(p.Payload.Value())
+		after, err := snapshot.Get(registerID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not read register %v: %w", registerID, err)
+		}
+
+		if string(before) == string(after) {
+			continue
+		}
+
+		changes = append(changes, RegisterChange{
+			Register: registerID.String(),
+			Before:   before,
+			After:    after,
+		})
+	}
+
+	return changes, unresolved, nil
+}
+
+// reportDivergence generates the divergence report of the block and writes it to the report
+// directory. It is called after a mismatch was detected, so failures are only logged to not
+// hide the mismatch.
+func (c *Core) reportDivergence(header *flow.Header, seal *flow.Seal) {
+	report, err := c.divergenceReport(header, seal)
+	if err != nil {
+		c.log.Error().Err(err).
+			Uint64("height", header.Height).
+			Str("block_id", header.ID().String()).
+			Msg("could not generate divergence report")
+		return
+	}
+
+	c.lastReport.Store(report)
+
+	log := c.log.Error().
+		Uint64("height", report.Height).
+		Str("block_id", report.BlockID.String()).
+		Int("diverging_chunks", len(report.Chunks))
+	if report.FirstDivergingChunk != nil {
+		log = log.Uint64("first_diverging_chunk", *report.FirstDivergingChunk)
+	}
+	if report.FirstTransactionIndex != nil {
+		log = log.Uint32("first_transaction_index", *report.FirstTransactionIndex)
+	}
+
+	if c.reportDir == "" {
+		log.Msg("execution result diverged from the sealed result")
+		return
+	}
+
+	path, err := writeReport(c.reportDir, report)
+	if err != nil {
+		c.log.Error().Err(err).Msg("could not write divergence report")
+		log.Msg("execution result diverged from the sealed result")
+		return
+	}
+
+	log.Str("report", path).Msg("execution result diverged from the sealed result, divergence report written")
+}
+
+// LatestDivergenceReport returns the report of the latest divergence detected by the checker,
+// or nil if no divergence was detected since the node started.
+func (c *Core) LatestDivergenceReport() *DivergenceReport {
+	return c.lastReport.Load()
+}
+
+// writeReport writes the report as JSON into the directory, and returns the path of the file.
+func writeReport(dir string, report *DivergenceReport) (string, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", fmt.Errorf("could not create report directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("could not encode report: %w", err)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("divergence_%d_%v.json", report.Height, report.BlockID))
+	err = os.WriteFile(path, data, 0644)
+	if err != nil {
+		return "", fmt.Errorf("could not write report file: %w", err)
+	}
+
+	return path, nil
+}
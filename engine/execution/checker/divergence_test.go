@@ -0,0 +1,259 @@
+package checker_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution/checker"
+	stateMock "github.com/onflow/flow-go/engine/execution/state/mock"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/common/testutils"
+	"github.com/onflow/flow-go/model/flow"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type divergenceSuite struct {
+	core      *checker.Core
+	state     *protocol.State
+	execState *stateMock.ExecutionState
+	seals     *storagemock.Seals
+	results   *storagemock.ExecutionResults
+	events    *storagemock.Events
+	txResults *storagemock.TransactionResults
+}
+
+func makeDivergenceSuite(t *testing.T, reportDir string) *divergenceSuite {
+	s := &divergenceSuite{
+		state:     protocol.NewState(t),
+		execState: stateMock.NewExecutionState(t),
+		seals:     storagemock.NewSeals(t),
+		results:   storagemock.NewExecutionResults(t),
+		events:    storagemock.NewEvents(t),
+		txResults: storagemock.NewTransactionResults(t),
+	}
+	s.core = checker.NewCore(
+		unittest.Logger(),
+		s.state,
+		s.execState,
+		s.seals,
+		s.results,
+		s.events,
+		s.txResults,
+		reportDir,
+	)
+	return s
+}
+
+// divergingResults returns my result and the sealed result of the block, which diverge in the
+// events and end state of the second chunk.
+func divergingResults(blockID flow.Identifier) (*flow.ExecutionResult, *flow.ExecutionResult) {
+	mine := unittest.ExecutionResultFixture(
+		unittest.WithExecutionResultBlockID(blockID),
+		unittest.WithChunks(3),
+	)
+	for i, chunk := range mine.Chunks {
+		chunk.NumberOfTransactions = 2
+		if i > 0 {
+			chunk.StartState = mine.Chunks[i-1].EndState
+		}
+	}
+
+	sealed := *mine
+	sealed.Chunks = make(flow.ChunkList, len(mine.Chunks))
+	for i, chunk := range mine.Chunks {
+		c := *chunk
+		sealed.Chunks[i] = &c
+	}
+	sealed.Chunks[1].EndState = unittest.StateCommitmentFixture()
+	sealed.Chunks[1].EventCollection = unittest.IdentifierFixture()
+	sealed.Chunks[2].StartState = sealed.Chunks[1].EndState
+	sealed.Chunks[2].EndState = unittest.StateCommitmentFixture()
+
+	return mine, &sealed
+}
+
+// mockChunkDetails mocks the transactions and registers of the second chunk of my result.
+// It returns the register changed by the chunk.
+func (s *divergenceSuite) mockChunkDetails(t *testing.T, header *flow.Header, mine *flow.ExecutionResult) flow.RegisterID {
+	blockID := header.ID()
+
+	txResults := make([]flow.TransactionResult, 6)
+	for i := range txResults {
+		txResults[i] = flow.TransactionResult{
+			TransactionID:   unittest.IdentifierFixture(),
+			ComputationUsed: uint64(i),
+		}
+	}
+	txResults[3].ErrorMessage = "failed"
+	s.txResults.On("ByBlockID", blockID).Return(txResults, nil)
+
+	events := []flow.Event{
+		unittest.EventFixture(flow.EventAccountCreated, 1, 0, txResults[1].TransactionID, 0),
+		unittest.EventFixture(flow.EventAccountCreated, 2, 0, txResults[2].TransactionID, 0),
+		unittest.EventFixture(flow.EventAccountCreated, 2, 1, txResults[2].TransactionID, 0),
+		unittest.EventFixture(flow.EventAccountCreated, 4, 0, txResults[4].TransactionID, 0),
+	}
+	s.events.On("ByBlockID", blockID).Return(events, nil)
+
+	changed := flow.NewRegisterID(unittest.RandomAddressFixture(), "changed")
+	unchanged := flow.NewRegisterID(unittest.RandomAddressFixture(), "unchanged")
+
+	proof := ledger.NewTrieBatchProof()
+	for _, register := range []flow.RegisterID{changed, unchanged} {
+		p := ledger.NewTrieProof()
+		p.Path = testutils.PathByUint16(uint16(len(proof.Proofs)))
+		p.Payload = ledger.NewPayload(convert.RegisterIDToLedgerKey(register), []byte("before"))
+		p.Inclusion = true
+		proof.AppendProof(p)
+	}
+	// a register created by the chunk
+	created := ledger.NewTrieProof()
+	created.Path = testutils.PathByUint16(2)
+	created.Payload = ledger.EmptyPayload()
+	proof.AppendProof(created)
+
+	chunk := mine.Chunks[1]
+	s.execState.On("ChunkDataPackByChunkID", chunk.ID()).Return(
+		unittest.ChunkDataPackFixture(chunk.ID(), func(cdp *flow.ChunkDataPack) {
+			cdp.Proof = ledger.EncodeTrieBatchProof(proof)
+		}), nil)
+	s.execState.On("NewStorageSnapshot", chunk.EndState, blockID, header.Height).Return(
+		snapshot.MapStorageSnapshot{
+			changed:   []byte("after"),
+			unchanged: []byte("before"),
+		})
+
+	return changed
+}
+
+func TestDivergenceReportWrittenOnMismatch(t *testing.T) {
+	chain, _, _ := unittest.ChainFixture(10)
+	lastFinal := chain[7].Header
+	lastSealed := chain[5].Header
+	blockID := lastSealed.ID()
+
+	reportDir := t.TempDir()
+	s := makeDivergenceSuite(t, reportDir)
+
+	mine, sealed := divergingResults(blockID)
+	seal := unittest.Seal.Fixture(unittest.Seal.WithResult(sealed))
+
+	finalizedSnapshot := mockFinalizedBlock(t, s.state, lastFinal)
+	finalizedSnapshot.On("SealedResult").Return(sealed, seal, nil)
+	mockAtBlockID(t, s.state, lastSealed)
+	mockExecutedBlock(t, s.execState, lastSealed, mine)
+
+	s.results.On("ByBlockID", blockID).Return(mine, nil)
+	s.results.On("ByID", seal.ResultID).Return(sealed, nil)
+	changed := s.mockChunkDetails(t, lastSealed, mine)
+
+	require.Nil(t, s.core.LatestDivergenceReport())
+
+	err := s.core.RunCheck()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "execution result is different from the sealed result")
+
+	report := s.core.LatestDivergenceReport()
+	require.NotNil(t, report)
+	require.True(t, report.Diverged)
+	require.Equal(t, blockID, report.BlockID)
+	require.Equal(t, lastSealed.Height, report.Height)
+	require.Equal(t, mine.ID(), report.MyResultID)
+	require.Equal(t, sealed.ID(), report.SealedResultID)
+	require.Nil(t, report.ServiceEvents)
+	require.Empty(t, report.Errors)
+
+	// chunk 1 diverges in its end state and events, chunk 2 only in its states
+	require.Len(t, report.Chunks, 2)
+	require.Equal(t, uint64(1), report.Chunks[0].Index)
+	require.True(t, report.Chunks[0].EndStateDiffers)
+	require.True(t, report.Chunks[0].EventCollectionDiffers)
+	require.False(t, report.Chunks[0].StartStateDiffers)
+	require.Equal(t, uint64(2), report.Chunks[1].Index)
+	require.True(t, report.Chunks[1].StartStateDiffers)
+	require.False(t, report.Chunks[1].EventCollectionDiffers)
+
+	require.Equal(t, uint64(1), *report.FirstDivergingChunk)
+	require.Equal(t, uint32(2), *report.FirstTransactionIndex)
+
+	// transactions 2 and 3 are executed in chunk 1
+	require.Len(t, report.Transactions, 2)
+	require.Equal(t, uint32(2), report.Transactions[0].Index)
+	require.Len(t, report.Transactions[0].Events, 2)
+	require.Equal(t, uint32(3), report.Transactions[1].Index)
+	require.Equal(t, "failed", report.Transactions[1].ErrorMessage)
+	require.Empty(t, report.Transactions[1].Events)
+
+	require.Equal(t, []checker.RegisterChange{{
+		Register: changed.String(),
+		Before:   []byte("before"),
+		After:    []byte("after"),
+	}}, report.RegisterChanges)
+	require.Equal(t, 1, report.UnresolvedRegisters)
+
+	files, err := os.ReadDir(reportDir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	data, err := os.ReadFile(filepath.Join(reportDir, files[0].Name()))
+	require.NoError(t, err)
+	var written checker.DivergenceReport
+	require.NoError(t, json.Unmarshal(data, &written))
+	require.Equal(t, report.BlockID, written.BlockID)
+	require.Equal(t, report.RegisterChanges, written.RegisterChanges)
+}
+
+func TestDivergenceReportOnDemand(t *testing.T) {
+	header := unittest.BlockHeaderFixture()
+	blockID := header.ID()
+
+	t.Run("matching result", func(t *testing.T) {
+		s := makeDivergenceSuite(t, "")
+		result := unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(blockID))
+		seal := unittest.Seal.Fixture(unittest.Seal.WithResult(result))
+
+		mockAtBlockID(t, s.state, header)
+		s.seals.On("FinalizedSealForBlock", blockID).Return(seal, nil)
+		s.results.On("ByBlockID", blockID).Return(result, nil)
+		s.results.On("ByID", seal.ResultID).Return(result, nil)
+
+		report, err := s.core.DivergenceReport(blockID)
+		require.NoError(t, err)
+		require.False(t, report.Diverged)
+		require.Empty(t, report.Chunks)
+		require.Nil(t, report.FirstDivergingChunk)
+
+		// reports generated on demand are not kept as the latest report
+		require.Nil(t, s.core.LatestDivergenceReport())
+	})
+
+	t.Run("missing chunk details", func(t *testing.T) {
+		s := makeDivergenceSuite(t, "")
+		mine, sealed := divergingResults(blockID)
+		sealed.ServiceEvents = unittest.ServiceEventsFixture(1)
+		seal := unittest.Seal.Fixture(unittest.Seal.WithResult(sealed))
+
+		mockAtBlockID(t, s.state, header)
+		s.seals.On("FinalizedSealForBlock", blockID).Return(seal, nil)
+		s.results.On("ByBlockID", blockID).Return(mine, nil)
+		s.results.On("ByID", seal.ResultID).Return(sealed, nil)
+		s.txResults.On("ByBlockID", blockID).Return(nil, storage.ErrNotFound)
+		s.execState.On("ChunkDataPackByChunkID", mine.Chunks[1].ID()).Return(nil, storage.ErrNotFound)
+
+		report, err := s.core.DivergenceReport(blockID)
+		require.NoError(t, err)
+		require.True(t, report.Diverged)
+		require.NotNil(t, report.ServiceEvents)
+		require.Equal(t, uint64(1), *report.FirstDivergingChunk)
+		require.Len(t, report.Errors, 2)
+	})
+}
@@ -117,6 +117,8 @@ type blockComputer struct {
 	colResCons            []result.ExecutedCollectionConsumer
 	protocolState         protocol.State
 	maxConcurrency        int
+	conflictTracker       *ConflictTracker
+	profiler              *ExecutionProfiler
 }
 
 func SystemChunkContext(vmCtx fvm.Context, metrics module.ExecutionMetrics) fvm.Context {
@@ -148,6 +150,8 @@ func NewBlockComputer(
 	colResCons []result.ExecutedCollectionConsumer,
 	state protocol.State,
 	maxConcurrency int,
+	conflictTracker *ConflictTracker,
+	profiler *ExecutionProfiler,
 ) (BlockComputer, error) {
 	if maxConcurrency < 1 {
 		return nil, fmt.Errorf("invalid maxConcurrency: %d", maxConcurrency)
@@ -179,6 +183,8 @@ func NewBlockComputer(
 		colResCons:            colResCons,
 		protocolState:         state,
 		maxConcurrency:        maxConcurrency,
+		conflictTracker:       conflictTracker,
+		profiler:              profiler,
 	}, nil
 }
 
@@ -357,6 +363,7 @@ func (e *blockComputer) executeBlock(
 		numTxns,
 		e.colResCons,
 		baseSnapshot,
+		e.profiler,
 	)
 	defer collector.Stop()
 
@@ -397,6 +404,11 @@ func (e *blockComputer) executeBlock(
 		return nil, err
 	}
 
+	e.conflictTracker.BlockExecuted(
+		block.Block.Header,
+		numTxns,
+		database.Conflicts())
+
 	res, err := collector.Finalize(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("cannot finalize computation result: %w", err)
@@ -430,6 +442,7 @@ func (e *blockComputer) executeTransactions(
 			err := e.executeTransaction(blockSpan, database, request, attempt)
 
 			if errors.IsRetryableConflictError(err) {
+				database.RecordConflict(request, attempt, err)
 				request.ctx.Logger.Info().
 					Int("attempt", attempt).
 					Str("conflict_error", err.Error()).
@@ -200,7 +200,9 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 			prov,
 			nil,
 			testutil.ProtocolStateWithSourceFixture(nil),
-			testMaxConcurrency)
+			testMaxConcurrency,
+			nil,
+			nil)
 		require.NoError(t, err)
 
 		// create a block with 1 collection with 2 transactions
@@ -338,7 +340,9 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 			prov,
 			nil,
 			testutil.ProtocolStateWithSourceFixture(nil),
-			testMaxConcurrency)
+			testMaxConcurrency,
+			nil,
+			nil)
 		require.NoError(t, err)
 
 		// create an empty block
@@ -442,7 +446,9 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 			prov,
 			nil,
 			testutil.ProtocolStateWithSourceFixture(nil),
-			testMaxConcurrency)
+			testMaxConcurrency,
+			nil,
+			nil)
 		require.NoError(t, err)
 
 		// create an empty block
@@ -507,7 +513,9 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 			prov,
 			nil,
 			testutil.ProtocolStateWithSourceFixture(nil),
-			testMaxConcurrency)
+			testMaxConcurrency,
+			nil,
+			nil)
 		require.NoError(t, err)
 
 		collectionCount := 2
@@ -732,7 +740,9 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 				prov,
 				nil,
 				testutil.ProtocolStateWithSourceFixture(nil),
-				testMaxConcurrency)
+				testMaxConcurrency,
+				nil,
+				nil)
 			require.NoError(t, err)
 
 			result, err := exe.ExecuteBlock(
@@ -845,7 +855,9 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 			prov,
 			nil,
 			testutil.ProtocolStateWithSourceFixture(nil),
-			testMaxConcurrency)
+			testMaxConcurrency,
+			nil,
+			nil)
 		require.NoError(t, err)
 
 		const collectionCount = 2
@@ -960,7 +972,9 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 			prov,
 			nil,
 			testutil.ProtocolStateWithSourceFixture(nil),
-			testMaxConcurrency)
+			testMaxConcurrency,
+			nil,
+			nil)
 		require.NoError(t, err)
 
 		key := flow.AccountStatusRegisterID(
@@ -1005,7 +1019,9 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 			prov,
 			nil,
 			testutil.ProtocolStateWithSourceFixture(nil),
-			testMaxConcurrency)
+			testMaxConcurrency,
+			nil,
+			nil)
 		require.NoError(t, err)
 
 		collectionCount := 5
@@ -1343,7 +1359,9 @@ func Test_ExecutingSystemCollection(t *testing.T) {
 		prov,
 		nil,
 		testutil.ProtocolStateWithSourceFixture(constRandomSource),
-		testMaxConcurrency)
+		testMaxConcurrency,
+		nil,
+		nil)
 	require.NoError(t, err)
 
 	// create empty block, it will have system collection attached while executing
@@ -0,0 +1,425 @@
+package computer
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/onflow/flow-go/fvm/storage/errors"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+)
+
+const (
+	// DefaultConflictWindowSize is the default number of recently executed
+	// blocks the conflict statistics are aggregated over.
+	DefaultConflictWindowSize = 100
+
+	// hotContractsReported is the number of accounts with the most conflicts
+	// reported as metrics.
+	hotContractsReported = 10
+)
+
+// TransactionConflict is a conflict detected while validating or committing
+// a transaction executed concurrently with other transactions of the block.
+type TransactionConflict struct {
+	TxID    flow.Identifier
+	TxIndex uint32
+	Attempt int
+
+	// Register is the register read by the transaction and updated by a
+	// transaction committed in the meantime.  It is nil for conflicts on
+	// derived data, such as programs.
+	Register *flow.RegisterID
+}
+
+func newTransactionConflict(
+	request TransactionRequest,
+	attempt int,
+	err error,
+) TransactionConflict {
+	conflict := TransactionConflict{
+		TxID:    request.txnId,
+		TxIndex: request.txnIndex,
+		Attempt: attempt,
+	}
+
+	registerID, ok := errors.ConflictingRegister(err)
+	if ok {
+		conflict.Register = &registerID
+	}
+
+	return conflict
+}
+
+// RegisterConflicts is the number of conflicts caused by a register.
+type RegisterConflicts struct {
+	Owner     string `json:"owner"`
+	Key       string `json:"key"`
+	Conflicts int    `json:"conflicts"`
+}
+
+// ContractConflicts is the number of conflicts caused by the registers of an
+// account.
+type ContractConflicts struct {
+	Address   string `json:"address"`
+	Conflicts int    `json:"conflicts"`
+}
+
+// TransactionRetries is the number of times a transaction was retried
+// because of conflicts.
+type TransactionRetries struct {
+	TxID    string `json:"tx_id"`
+	TxIndex uint32 `json:"tx_index"`
+	Retries int    `json:"retries"`
+}
+
+// ConflictStats are the conflict statistics of a block, or of the recently
+// executed blocks.
+type ConflictStats struct {
+	Blocks       int    `json:"blocks"`
+	FromHeight   uint64 `json:"from_height"`
+	ToHeight     uint64 `json:"to_height"`
+	Transactions int    `json:"transactions"`
+
+	Conflicts int `json:"conflicts"`
+	// DerivedDataConflicts is the number of conflicts not caused by a register.
+	DerivedDataConflicts int `json:"derived_data_conflicts"`
+
+	RetriedTransactions int `json:"retried_transactions"`
+	MaxRetries          int `json:"max_retries"`
+	// RetriesPerTransaction maps a number of retries to the number of
+	// transactions retried that many times.
+	RetriesPerTransaction map[int]int `json:"retries_per_transaction"`
+
+	HotRegisters []RegisterConflicts `json:"hot_registers"`
+	HotContracts []ContractConflicts `json:"hot_contracts"`
+
+	// MostRetriedTransactions is only set for the statistics of a block.
+	MostRetriedTransactions []TransactionRetries `json:"most_retried_transactions,omitempty"`
+}
+
+// blockConflicts holds the aggregated conflicts of an executed block.
+type blockConflicts struct {
+	blockID      flow.Identifier
+	height       uint64
+	transactions int
+
+	conflicts            int
+	derivedDataConflicts int
+	registers            map[flow.RegisterID]int
+	contracts            map[flow.Address]int
+	retries              map[uint32]TransactionRetries
+}
+
+func newBlockConflicts(
+	header *flow.Header,
+	transactions int,
+	conflicts []TransactionConflict,
+) *blockConflicts {
+	block := &blockConflicts{
+		blockID:      header.ID(),
+		height:       header.Height,
+		transactions: transactions,
+		conflicts:    len(conflicts),
+		registers:    make(map[flow.RegisterID]int),
+		contracts:    make(map[flow.Address]int),
+		retries:      make(map[uint32]TransactionRetries),
+	}
+
+	for _, conflict := range conflicts {
+		if conflict.Register == nil {
+			block.derivedDataConflicts++
+		} else {
+			block.registers[*conflict.Register]++
+			if conflict.Register.Owner != "" {
+				block.contracts[flow.BytesToAddress([]byte(conflict.Register.Owner))]++
+			}
+		}
+
+		retries := block.retries[conflict.TxIndex]
+		retries.TxID = conflict.TxID.String()
+		retries.TxIndex = conflict.TxIndex
+		retries.Retries++
+		block.retries[conflict.TxIndex] = retries
+	}
+
+	return block
+}
+
+// ConflictTracker aggregates the conflicts between concurrently executed
+// transactions per block, and over a rolling window of recently executed
+// blocks, to find the registers and contracts hurting parallel execution.
+//
+// A nil ConflictTracker discards all conflicts.
+//
+// All methods are concurrency safe.
+type ConflictTracker struct {
+	mutex      sync.Mutex
+	metrics    module.ExecutionMetrics
+	windowSize int
+
+	window []*blockConflicts // ordered by execution, oldest first.
+
+	// totals over the window, updated as blocks enter and leave the window.
+	transactions         int
+	conflicts            int
+	derivedDataConflicts int
+	registers            map[flow.RegisterID]int
+	contracts            map[flow.Address]int
+	retries              map[int]int
+}
+
+func NewConflictTracker(
+	metrics module.ExecutionMetrics,
+	windowSize int,
+) *ConflictTracker {
+	if windowSize < 1 {
+		windowSize = 1
+	}
+
+	return &ConflictTracker{
+		metrics:    metrics,
+		windowSize: windowSize,
+		registers:  make(map[flow.RegisterID]int),
+		contracts:  make(map[flow.Address]int),
+		retries:    make(map[int]int),
+	}
+}
+
+// BlockExecuted records the conflicts detected while executing the block, and
+// reports the block and window statistics as metrics.
+func (tracker *ConflictTracker) BlockExecuted(
+	header *flow.Header,
+	transactions int,
+	conflicts []TransactionConflict,
+) {
+	if tracker == nil {
+		return
+	}
+
+	block := newBlockConflicts(header, transactions, conflicts)
+
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	if len(tracker.window) == tracker.windowSize {
+		tracker.remove(tracker.window[0])
+		tracker.window[0] = nil
+		tracker.window = tracker.window[1:]
+	}
+	tracker.window = append(tracker.window, block)
+	tracker.add(block)
+
+	tracker.metrics.ExecutionBlockTransactionConflicts(
+		block.conflicts,
+		len(block.retries))
+	tracker.metrics.ExecutionHotContractConflicts(
+		topContracts(tracker.contracts, hotContractsReported))
+}
+
+func (tracker *ConflictTracker) add(block *blockConflicts) {
+	tracker.transactions += block.transactions
+	tracker.conflicts += block.conflicts
+	tracker.derivedDataConflicts += block.derivedDataConflicts
+	for registerID, count := range block.registers {
+		tracker.registers[registerID] += count
+	}
+	for address, count := range block.contracts {
+		tracker.contracts[address] += count
+	}
+	for _, retries := range block.retries {
+		tracker.retries[retries.Retries]++
+	}
+}
+
+func (tracker *ConflictTracker) remove(block *blockConflicts) {
+	tracker.transactions -= block.transactions
+	tracker.conflicts -= block.conflicts
+	tracker.derivedDataConflicts -= block.derivedDataConflicts
+	for registerID, count := range block.registers {
+		decrement(tracker.registers, registerID, count)
+	}
+	for address, count := range block.contracts {
+		decrement(tracker.contracts, address, count)
+	}
+	for _, retries := range block.retries {
+		decrement(tracker.retries, retries.Retries, 1)
+	}
+}
+
+func decrement[K comparable](counts map[K]int, key K, count int) {
+	counts[key] -= count
+	if counts[key] <= 0 {
+		delete(counts, key)
+	}
+}
+
+// WindowStats returns the conflict statistics over the recently executed
+// blocks, with the top registers and contracts by number of conflicts.
+func (tracker *ConflictTracker) WindowStats(top int) *ConflictStats {
+	if tracker == nil {
+		return &ConflictStats{RetriesPerTransaction: map[int]int{}}
+	}
+
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	stats := &ConflictStats{
+		Blocks:                len(tracker.window),
+		Transactions:          tracker.transactions,
+		Conflicts:             tracker.conflicts,
+		DerivedDataConflicts:  tracker.derivedDataConflicts,
+		RetriesPerTransaction: make(map[int]int, len(tracker.retries)),
+		HotRegisters:          hotRegisters(tracker.registers, top),
+		HotContracts:          hotContracts(tracker.contracts, top),
+	}
+
+	for _, block := range tracker.window {
+		if stats.FromHeight == 0 || block.height < stats.FromHeight {
+			stats.FromHeight = block.height
+		}
+		if block.height > stats.ToHeight {
+			stats.ToHeight = block.height
+		}
+	}
+
+	for retries, count := range tracker.retries {
+		stats.RetriesPerTransaction[retries] = count
+		stats.RetriedTransactions += count
+		if retries > stats.MaxRetries {
+			stats.MaxRetries = retries
+		}
+	}
+
+	return stats
+}
+
+// BlockStats returns the conflict statistics of the given block, with the top
+// registers, contracts and transactions by number of conflicts.  It returns
+// false if the block is not within the window.
+func (tracker *ConflictTracker) BlockStats(
+	blockID flow.Identifier,
+	top int,
+) (
+	*ConflictStats,
+	bool,
+) {
+	if tracker == nil {
+		return nil, false
+	}
+
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	for _, block := range tracker.window {
+		if block.blockID != blockID {
+			continue
+		}
+
+		stats := &ConflictStats{
+			Blocks:                1,
+			FromHeight:            block.height,
+			ToHeight:              block.height,
+			Transactions:          block.transactions,
+			Conflicts:             block.conflicts,
+			DerivedDataConflicts:  block.derivedDataConflicts,
+			RetriedTransactions:   len(block.retries),
+			RetriesPerTransaction: make(map[int]int),
+			HotRegisters:          hotRegisters(block.registers, top),
+			HotContracts:          hotContracts(block.contracts, top),
+		}
+
+		transactions := make([]TransactionRetries, 0, len(block.retries))
+		for _, retries := range block.retries {
+			stats.RetriesPerTransaction[retries.Retries]++
+			if retries.Retries > stats.MaxRetries {
+				stats.MaxRetries = retries.Retries
+			}
+			transactions = append(transactions, retries)
+		}
+
+		sort.Slice(transactions, func(i, j int) bool {
+			if transactions[i].Retries != transactions[j].Retries {
+				return transactions[i].Retries > transactions[j].Retries
+			}
+			return transactions[i].TxIndex < transactions[j].TxIndex
+		})
+		if len(transactions) > top {
+			transactions = transactions[:top]
+		}
+		stats.MostRetriedTransactions = transactions
+
+		return stats, true
+	}
+
+	return nil, false
+}
+
+func hotRegisters(counts map[flow.RegisterID]int, top int) []RegisterConflicts {
+	registers := make([]RegisterConflicts, 0, len(counts))
+	for registerID, count := range counts {
+		registers = append(registers, RegisterConflicts{
+			Owner:     flow.BytesToAddress([]byte(registerID.Owner)).Hex(),
+			Key:       registerID.Key,
+			Conflicts: count,
+		})
+	}
+
+	sort.Slice(registers, func(i, j int) bool {
+		if registers[i].Conflicts != registers[j].Conflicts {
+			return registers[i].Conflicts > registers[j].Conflicts
+		}
+		if registers[i].Owner != registers[j].Owner {
+			return registers[i].Owner < registers[j].Owner
+		}
+		return registers[i].Key < registers[j].Key
+	})
+
+	if len(registers) > top {
+		registers = registers[:top]
+	}
+	return registers
+}
+
+func hotContracts(counts map[flow.Address]int, top int) []ContractConflicts {
+	contracts := make([]ContractConflicts, 0, len(counts))
+	for address, count := range topContracts(counts, top) {
+		contracts = append(contracts, ContractConflicts{
+			Address:   address.Hex(),
+			Conflicts: count,
+		})
+	}
+
+	sort.Slice(contracts, func(i, j int) bool {
+		if contracts[i].Conflicts != contracts[j].Conflicts {
+			return contracts[i].Conflicts > contracts[j].Conflicts
+		}
+		return contracts[i].Address < contracts[j].Address
+	})
+	return contracts
+}
+
+// topContracts returns the top accounts by number of conflicts.
+func topContracts(counts map[flow.Address]int, top int) map[flow.Address]int {
+	addresses := make([]flow.Address, 0, len(counts))
+	for address := range counts {
+		addresses = append(addresses, address)
+	}
+
+	sort.Slice(addresses, func(i, j int) bool {
+		if counts[addresses[i]] != counts[addresses[j]] {
+			return counts[addresses[i]] > counts[addresses[j]]
+		}
+		return addresses[i].Hex() < addresses[j].Hex()
+	})
+
+	if len(addresses) > top {
+		addresses = addresses[:top]
+	}
+
+	result := make(map[flow.Address]int, len(addresses))
+	for _, address := range addresses {
+		result[address] = counts[address]
+	}
+	return result
+}
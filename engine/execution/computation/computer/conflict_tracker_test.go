@@ -0,0 +1,115 @@
+package computer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	modulemock "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestConflictTracker(t *testing.T) {
+	contractA := flow.HexToAddress("0x01")
+	contractB := flow.HexToAddress("0x02")
+	registerA := flow.NewRegisterID(contractA, "a")
+	registerB := flow.NewRegisterID(contractB, "b")
+
+	conflict := func(txIndex uint32, attempt int, register *flow.RegisterID) TransactionConflict {
+		return TransactionConflict{
+			TxID:     flow.Identifier{byte(txIndex)},
+			TxIndex:  txIndex,
+			Attempt:  attempt,
+			Register: register,
+		}
+	}
+
+	metrics := modulemock.NewExecutionMetrics(t)
+	tracker := NewConflictTracker(metrics, 2)
+
+	header1 := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(1))
+	metrics.On("ExecutionBlockTransactionConflicts", 4, 2).Once()
+	metrics.On("ExecutionHotContractConflicts", map[flow.Address]int{contractA: 2, contractB: 1}).Once()
+	tracker.BlockExecuted(header1, 10, []TransactionConflict{
+		conflict(1, 1, &registerA),
+		conflict(1, 2, &registerA),
+		conflict(2, 1, &registerB),
+		conflict(2, 2, nil),
+	})
+
+	header2 := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(2))
+	metrics.On("ExecutionBlockTransactionConflicts", 1, 1).Once()
+	metrics.On("ExecutionHotContractConflicts", map[flow.Address]int{contractA: 2, contractB: 2}).Once()
+	tracker.BlockExecuted(header2, 5, []TransactionConflict{
+		conflict(3, 1, &registerB),
+	})
+
+	stats := tracker.WindowStats(1)
+	require.Equal(t, &ConflictStats{
+		Blocks:                2,
+		FromHeight:            1,
+		ToHeight:              2,
+		Transactions:          15,
+		Conflicts:             5,
+		DerivedDataConflicts:  1,
+		RetriedTransactions:   3,
+		MaxRetries:            2,
+		RetriesPerTransaction: map[int]int{1: 1, 2: 2},
+		HotRegisters:          []RegisterConflicts{{Owner: contractA.Hex(), Key: "a", Conflicts: 2}},
+		HotContracts:          []ContractConflicts{{Address: contractA.Hex(), Conflicts: 2}},
+	}, stats)
+
+	stats, ok := tracker.BlockStats(header1.ID(), 10)
+	require.True(t, ok)
+	require.Equal(t, &ConflictStats{
+		Blocks:                1,
+		FromHeight:            1,
+		ToHeight:              1,
+		Transactions:          10,
+		Conflicts:             4,
+		DerivedDataConflicts:  1,
+		RetriedTransactions:   2,
+		MaxRetries:            2,
+		RetriesPerTransaction: map[int]int{2: 2},
+		HotRegisters: []RegisterConflicts{
+			{Owner: contractA.Hex(), Key: "a", Conflicts: 2},
+			{Owner: contractB.Hex(), Key: "b", Conflicts: 1},
+		},
+		HotContracts: []ContractConflicts{
+			{Address: contractA.Hex(), Conflicts: 2},
+			{Address: contractB.Hex(), Conflicts: 1},
+		},
+		MostRetriedTransactions: []TransactionRetries{
+			{TxID: flow.Identifier{1}.String(), TxIndex: 1, Retries: 2},
+			{TxID: flow.Identifier{2}.String(), TxIndex: 2, Retries: 2},
+		},
+	}, stats)
+
+	// the first block leaves the window
+	header3 := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(3))
+	metrics.On("ExecutionBlockTransactionConflicts", 0, 0).Once()
+	metrics.On("ExecutionHotContractConflicts", map[flow.Address]int{contractB: 1}).Once()
+	tracker.BlockExecuted(header3, 1, nil)
+
+	_, ok = tracker.BlockStats(header1.ID(), 10)
+	require.False(t, ok)
+
+	stats = tracker.WindowStats(10)
+	require.Equal(t, 2, stats.Blocks)
+	require.Equal(t, uint64(2), stats.FromHeight)
+	require.Equal(t, uint64(3), stats.ToHeight)
+	require.Equal(t, 1, stats.Conflicts)
+	require.Equal(t, map[int]int{1: 1}, stats.RetriesPerTransaction)
+	require.Equal(t, []RegisterConflicts{{Owner: contractB.Hex(), Key: "b", Conflicts: 1}}, stats.HotRegisters)
+}
+
+func TestConflictTrackerNil(t *testing.T) {
+	var tracker *ConflictTracker
+
+	tracker.BlockExecuted(unittest.BlockHeaderFixture(), 1, []TransactionConflict{{}})
+
+	_, ok := tracker.BlockStats(unittest.IdentifierFixture(), 10)
+	require.False(t, ok)
+	require.Equal(t, 0, tracker.WindowStats(10).Blocks)
+}
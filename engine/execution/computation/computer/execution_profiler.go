@@ -0,0 +1,533 @@
+package computer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/pprof/profile"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// executionProfileQueueSize is the number of executed blocks whose profiles
+// can wait to be written.  Profiles of blocks executed while the queue is full
+// are dropped.
+const executionProfileQueueSize = 100
+
+// TransactionProfile is the execution profile of a transaction.
+type TransactionProfile struct {
+	BlockID           string `json:"block_id"`
+	BlockHeight       uint64 `json:"block_height"`
+	TxID              string `json:"tx_id"`
+	TxIndex           uint32 `json:"tx_index"`
+	CollectionIndex   int    `json:"collection_index"`
+	SystemTransaction bool   `json:"system_transaction"`
+	Failed            bool   `json:"failed"`
+
+	// ScriptHash identifies the transaction script, to aggregate the profiles
+	// of the transactions running the same script.
+	ScriptHash string `json:"script_hash"`
+	Payer      string `json:"payer"`
+
+	ExecutionTime time.Duration `json:"execution_time_ns"`
+
+	ComputationUsed uint64 `json:"computation_used"`
+	// ComputationIntensities maps a computation kind to its metered intensity.
+	ComputationIntensities map[string]uint `json:"computation_intensities"`
+
+	MemoryEstimate uint64 `json:"memory_estimate"`
+	// MemoryIntensities maps a memory kind to its metered intensity.
+	MemoryIntensities map[string]uint `json:"memory_intensities"`
+
+	RegisterReads        int `json:"register_reads"`
+	RegisterWrites       int `json:"register_writes"`
+	RegisterBytesWritten int `json:"register_bytes_written"`
+
+	ProgramsCacheHits   int `json:"programs_cache_hits"`
+	ProgramsCacheMisses int `json:"programs_cache_misses"`
+}
+
+func newTransactionProfile(
+	txn TransactionRequest,
+	txnExecutionSnapshot *snapshot.ExecutionSnapshot,
+	output fvm.ProcedureOutput,
+	timeSpent time.Duration,
+) TransactionProfile {
+	txProfile := TransactionProfile{
+		BlockID:                txn.blockIdStr,
+		BlockHeight:            txn.blockHeight,
+		TxID:                   txn.txnIdStr,
+		TxIndex:                txn.txnIndex,
+		CollectionIndex:        txn.collectionIndex,
+		SystemTransaction:      txn.isSystemTransaction,
+		Failed:                 output.Err != nil,
+		ScriptHash:             flow.MakeIDFromFingerPrint(txn.Transaction.Script).String(),
+		Payer:                  txn.Transaction.Payer.Hex(),
+		ExecutionTime:          timeSpent,
+		ComputationUsed:        output.ComputationUsed,
+		ComputationIntensities: make(map[string]uint, len(output.ComputationIntensities)),
+		MemoryEstimate:         output.MemoryEstimate,
+		MemoryIntensities:      make(map[string]uint),
+		RegisterReads:          len(txnExecutionSnapshot.ReadSet),
+		RegisterWrites:         len(txnExecutionSnapshot.WriteSet),
+		ProgramsCacheHits:      output.ProgramsCacheHits,
+		ProgramsCacheMisses:    output.ProgramsCacheMisses,
+	}
+
+	for kind, intensity := range output.ComputationIntensities {
+		txProfile.ComputationIntensities[kind.String()] = intensity
+	}
+
+	if txnExecutionSnapshot.Meter != nil {
+		for kind, intensity := range txnExecutionSnapshot.Meter.MemoryIntensities() {
+			txProfile.MemoryIntensities[kind.String()] = intensity
+		}
+	}
+
+	for _, value := range txnExecutionSnapshot.WriteSet {
+		txProfile.RegisterBytesWritten += len(value)
+	}
+
+	return txProfile
+}
+
+type blockProfile struct {
+	header       *flow.Header
+	transactions []TransactionProfile
+}
+
+// profileFiles are the files of the profile of a block.
+type profileFiles struct {
+	height uint64
+	paths  []string
+	size   int64
+}
+
+// ExecutionProfiler writes the execution profiles of the transactions of each
+// executed block to a directory, both as JSON lines (one transaction per line)
+// and in the pprof format, to find the hotspots of the chain traffic:
+//
+//	<dir>/<height>_<block ID>.jsonl
+//	<dir>/<height>_<block ID>.pb.gz
+//
+// The profiles are written in the background by the worker of the component.
+// Once the profiles in the directory exceed the size limit, the profiles of
+// the lowest heights are removed.  A nil ExecutionProfiler doesn't profile
+// anything.
+type ExecutionProfiler struct {
+	component.Component
+
+	log      zerolog.Logger
+	dir      string
+	maxBytes int64
+	blocks   chan blockProfile
+
+	// written are the profiles in the directory, by ascending height. They
+	// are only accessed by the worker.
+	written      []profileFiles
+	writtenBytes int64
+}
+
+// NewExecutionProfiler creates the profile directory if needed, and the
+// profiler writing the profiles of the executed blocks to it once started.
+// The profiles already in the directory count towards the size limit of
+// maxBytes. Zero means no limit.
+func NewExecutionProfiler(log zerolog.Logger, dir string, maxBytes uint64) (*ExecutionProfiler, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("could not create execution profile dir %v: %w", dir, err)
+	}
+
+	written, err := readProfileFiles(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read execution profile dir %v: %w", dir, err)
+	}
+
+	profiler := &ExecutionProfiler{
+		log:      log.With().Str("component", "execution_profiler").Logger(),
+		dir:      dir,
+		maxBytes: int64(maxBytes),
+		blocks:   make(chan blockProfile, executionProfileQueueSize),
+		written:  written,
+	}
+	for _, files := range written {
+		profiler.writtenBytes += files.size
+	}
+
+	profiler.Component = component.NewComponentManagerBuilder().
+		AddWorker(profiler.writeProfilesWorker).
+		Build()
+
+	return profiler, nil
+}
+
+// BlockExecuted queues the transaction profiles of the executed block to be
+// written.
+func (p *ExecutionProfiler) BlockExecuted(
+	header *flow.Header,
+	transactions []TransactionProfile,
+) {
+	if p == nil {
+		return
+	}
+
+	select {
+	case p.blocks <- blockProfile{header: header, transactions: transactions}:
+	default:
+		p.log.Warn().
+			Uint64("height", header.Height).
+			Hex("block_id", logging.Entity(header)).
+			Msg("execution profile queue is full, dropping block profile")
+	}
+}
+
+// writeProfilesWorker writes the queued block profiles until the component is
+// stopped. Profiles still queued at shutdown are dropped.
+func (p *ExecutionProfiler) writeProfilesWorker(
+	ctx irrecoverable.SignalerContext,
+	ready component.ReadyFunc,
+) {
+	p.removeOldProfiles()
+	ready()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case block := <-p.blocks:
+			files, err := p.writeBlockProfile(block)
+			if err != nil {
+				p.log.Warn().
+					Err(err).
+					Uint64("height", block.header.Height).
+					Hex("block_id", logging.Entity(block.header)).
+					Msg("could not write execution profile")
+			}
+
+			p.addProfileFiles(files)
+			p.removeOldProfiles()
+		}
+	}
+}
+
+// addProfileFiles adds the written files to the files in the directory,
+// keeping them ordered by height.
+func (p *ExecutionProfiler) addProfileFiles(files profileFiles) {
+	if len(files.paths) == 0 {
+		return
+	}
+
+	i := sort.Search(len(p.written), func(i int) bool {
+		return p.written[i].height > files.height
+	})
+	p.written = append(p.written, profileFiles{})
+	copy(p.written[i+1:], p.written[i:])
+	p.written[i] = files
+	p.writtenBytes += files.size
+}
+
+// removeOldProfiles removes the profiles of the lowest heights until the
+// profiles in the directory fit into the size limit.
+func (p *ExecutionProfiler) removeOldProfiles() {
+	if p.maxBytes == 0 {
+		return
+	}
+
+	removed := 0
+	for removed < len(p.written) && p.writtenBytes > p.maxBytes {
+		files := p.written[removed]
+		for _, path := range files.paths {
+			err := os.Remove(path)
+			if err != nil && !os.IsNotExist(err) {
+				p.log.Warn().Err(err).Str("path", path).Msg("could not remove execution profile")
+			}
+		}
+		p.writtenBytes -= files.size
+		removed++
+	}
+	p.written = p.written[removed:]
+}
+
+// readProfileFiles returns the block profiles in the directory, by ascending
+// height. Files which aren't named like profiles are ignored.
+func readProfileFiles(dir string) ([]profileFiles, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*profileFiles)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		name, ok := strings.CutSuffix(entry.Name(), ".jsonl")
+		if !ok {
+			name, ok = strings.CutSuffix(entry.Name(), ".pb.gz")
+		}
+		if !ok {
+			continue
+		}
+		heightStr, _, ok := strings.Cut(name, "_")
+		if !ok {
+			continue
+		}
+		height, err := strconv.ParseUint(heightStr, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		files, ok := byName[name]
+		if !ok {
+			files = &profileFiles{height: height}
+			byName[name] = files
+		}
+		files.paths = append(files.paths, filepath.Join(dir, entry.Name()))
+		files.size += info.Size()
+	}
+
+	written := make([]profileFiles, 0, len(byName))
+	for _, files := range byName {
+		written = append(written, *files)
+	}
+	sort.Slice(written, func(i, j int) bool {
+		return written[i].height < written[j].height
+	})
+
+	return written, nil
+}
+
+// writeBlockProfile writes the profile files of the block, and returns the
+// files written, even if writing failed.
+func (p *ExecutionProfiler) writeBlockProfile(block blockProfile) (profileFiles, error) {
+	name := fmt.Sprintf("%d_%s", block.header.Height, block.header.ID())
+	files := profileFiles{height: block.header.Height}
+
+	write := func(path string, write func(w *bufio.Writer) error) error {
+		size, err := writeFile(path, write)
+		if size >= 0 {
+			files.paths = append(files.paths, path)
+			files.size += size
+		}
+		return err
+	}
+
+	err := write(filepath.Join(p.dir, name+".jsonl"), func(w *bufio.Writer) error {
+		return WriteTransactionProfilesJSONL(w, block.transactions)
+	})
+	if err != nil {
+		return files, fmt.Errorf("could not write json profile: %w", err)
+	}
+
+	err = write(filepath.Join(p.dir, name+".pb.gz"), func(w *bufio.Writer) error {
+		return NewPprofProfile(block.transactions).Write(w)
+	})
+	if err != nil {
+		return files, fmt.Errorf("could not write pprof profile: %w", err)
+	}
+
+	return files, nil
+}
+
+// WriteTransactionProfilesJSONL writes the given transaction profiles as JSON
+// lines.
+func WriteTransactionProfilesJSONL(w io.Writer, transactions []TransactionProfile) error {
+	encoder := json.NewEncoder(w)
+	for _, txProfile := range transactions {
+		err := encoder.Encode(txProfile)
+		if err != nil {
+			return fmt.Errorf("could not encode profile of transaction %s: %w", txProfile.TxID, err)
+		}
+	}
+	return nil
+}
+
+// pprof sample value indexes
+const (
+	sampleComputation = iota
+	sampleMemory
+	sampleWallTime
+	sampleRegisterReads
+	sampleRegisterWrites
+	sampleProgramsCacheHits
+	sampleProgramsCacheMisses
+	sampleValues
+)
+
+// NewPprofProfile converts the given transaction profiles into a pprof profile.
+//
+// The samples of a transaction are attributed to the frame of its script, so
+// the transactions running the same script are aggregated.  The computation
+// and memory intensities are attributed to a frame per kind, called by the
+// script frame.  Samples are labeled with the transaction ID and payer.
+func NewPprofProfile(transactions []TransactionProfile) *profile.Profile {
+	builder := newPprofBuilder()
+
+	for _, tx := range transactions {
+		script := builder.location(scriptFrameName(tx))
+		labels := map[string][]string{
+			"tx_id": {tx.TxID},
+			"payer": {tx.Payer},
+		}
+		numLabels := map[string][]int64{
+			"block_height": {int64(tx.BlockHeight)},
+			"tx_index":     {int64(tx.TxIndex)},
+		}
+
+		addSample := func(kind *profile.Location, valueIndex int, value int64) {
+			values := make([]int64, sampleValues)
+			values[valueIndex] = value
+
+			builder.profile.Sample = append(builder.profile.Sample, &profile.Sample{
+				// leaf first
+				Location: []*profile.Location{kind, script},
+				Value:    values,
+				Label:    labels,
+				NumLabel: numLabels,
+			})
+		}
+
+		for _, kind := range sortedKinds(tx.ComputationIntensities) {
+			addSample(
+				builder.location("computation:"+kind),
+				sampleComputation,
+				int64(tx.ComputationIntensities[kind]))
+		}
+		for _, kind := range sortedKinds(tx.MemoryIntensities) {
+			addSample(
+				builder.location("memory:"+kind),
+				sampleMemory,
+				int64(tx.MemoryIntensities[kind]))
+		}
+
+		values := make([]int64, sampleValues)
+		values[sampleWallTime] = tx.ExecutionTime.Nanoseconds()
+		values[sampleRegisterReads] = int64(tx.RegisterReads)
+		values[sampleRegisterWrites] = int64(tx.RegisterWrites)
+		values[sampleProgramsCacheHits] = int64(tx.ProgramsCacheHits)
+		values[sampleProgramsCacheMisses] = int64(tx.ProgramsCacheMisses)
+		builder.profile.Sample = append(builder.profile.Sample, &profile.Sample{
+			Location: []*profile.Location{script},
+			Value:    values,
+			Label:    labels,
+			NumLabel: numLabels,
+		})
+	}
+
+	return builder.profile
+}
+
+func scriptFrameName(tx TransactionProfile) string {
+	if tx.SystemTransaction {
+		return "system_transaction"
+	}
+	return "script:" + tx.ScriptHash
+}
+
+func sortedKinds(intensities map[string]uint) []string {
+	kinds := make([]string, 0, len(intensities))
+	for kind := range intensities {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// pprofBuilder builds a pprof profile, with a single location and function
+// per frame name.
+type pprofBuilder struct {
+	profile   *profile.Profile
+	locations map[string]*profile.Location
+}
+
+func newPprofBuilder() *pprofBuilder {
+	return &pprofBuilder{
+		profile: &profile.Profile{
+			SampleType: []*profile.ValueType{
+				sampleComputation:         {Type: "computation", Unit: "count"},
+				sampleMemory:              {Type: "memory", Unit: "count"},
+				sampleWallTime:            {Type: "wall", Unit: "nanoseconds"},
+				sampleRegisterReads:       {Type: "register_reads", Unit: "count"},
+				sampleRegisterWrites:      {Type: "register_writes", Unit: "count"},
+				sampleProgramsCacheHits:   {Type: "programs_cache_hits", Unit: "count"},
+				sampleProgramsCacheMisses: {Type: "programs_cache_misses", Unit: "count"},
+			},
+			DefaultSampleType: "computation",
+			TimeNanos:         time.Now().UnixNano(),
+		},
+		locations: make(map[string]*profile.Location),
+	}
+}
+
+func (b *pprofBuilder) location(name string) *profile.Location {
+	location, ok := b.locations[name]
+	if ok {
+		return location
+	}
+
+	function := &profile.Function{
+		ID:   uint64(len(b.profile.Function) + 1),
+		Name: name,
+	}
+	location = &profile.Location{
+		ID:   uint64(len(b.profile.Location) + 1),
+		Line: []profile.Line{{Function: function}},
+	}
+
+	b.profile.Function = append(b.profile.Function, function)
+	b.profile.Location = append(b.profile.Location, location)
+	b.locations[name] = location
+
+	return location
+}
+
+// writeFile writes the file, and returns the number of bytes written to it.
+// The size is -1 if the file wasn't created.
+func writeFile(path string, write func(w *bufio.Writer) error) (int64, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return -1, fmt.Errorf("could not create file %v: %w", path, err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	err = write(writer)
+	if err != nil {
+		return 0, err
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return 0, fmt.Errorf("could not flush file %v: %w", path, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("could not stat file %v: %w", path, err)
+	}
+
+	return info.Size(), file.Close()
+}
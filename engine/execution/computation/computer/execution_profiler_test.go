@@ -0,0 +1,254 @@
+package computer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/pprof/profile"
+	"github.com/onflow/cadence/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/meter"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestNewTransactionProfile(t *testing.T) {
+	header := unittest.BlockHeaderFixture()
+	txBody := unittest.TransactionBodyFixture()
+
+	txn := TransactionRequest{
+		collectionInfo: collectionInfo{
+			blockId:         header.ID(),
+			blockIdStr:      header.ID().String(),
+			blockHeight:     header.Height,
+			collectionIndex: 1,
+		},
+		txnId:    txBody.ID(),
+		txnIdStr: txBody.ID().String(),
+		txnIndex: 3,
+		TransactionProcedure: &fvm.TransactionProcedure{
+			ID:          txBody.ID(),
+			Transaction: &txBody,
+			TxIndex:     3,
+		},
+	}
+
+	txMeter := meter.NewMeter(meter.DefaultParameters())
+	require.NoError(t, txMeter.MeterMemory(common.MemoryKindStringValue, 5))
+
+	written := flow.NewRegisterID(txBody.Payer, "written")
+	executionSnapshot := &snapshot.ExecutionSnapshot{
+		ReadSet: map[flow.RegisterID]struct{}{
+			flow.NewRegisterID(txBody.Payer, "a"): {},
+			flow.NewRegisterID(txBody.Payer, "b"): {},
+		},
+		WriteSet: map[flow.RegisterID]flow.RegisterValue{
+			written: []byte("value"),
+		},
+		Meter: txMeter,
+	}
+
+	output := fvm.ProcedureOutput{
+		ComputationUsed: 10,
+		ComputationIntensities: meter.MeteredComputationIntensities{
+			common.ComputationKindStatement: 7,
+		},
+		MemoryEstimate:      100,
+		ProgramsCacheHits:   2,
+		ProgramsCacheMisses: 1,
+	}
+
+	txProfile := newTransactionProfile(txn, executionSnapshot, output, time.Millisecond)
+
+	require.Equal(t, TransactionProfile{
+		BlockID:         header.ID().String(),
+		BlockHeight:     header.Height,
+		TxID:            txBody.ID().String(),
+		TxIndex:         3,
+		CollectionIndex: 1,
+		ScriptHash:      flow.MakeIDFromFingerPrint(txBody.Script).String(),
+		Payer:           txBody.Payer.Hex(),
+		ExecutionTime:   time.Millisecond,
+		ComputationUsed: 10,
+		ComputationIntensities: map[string]uint{
+			common.ComputationKindStatement.String(): 7,
+		},
+		MemoryEstimate: 100,
+		MemoryIntensities: map[string]uint{
+			common.MemoryKindStringValue.String(): 5,
+		},
+		RegisterReads:        2,
+		RegisterWrites:       1,
+		RegisterBytesWritten: 5,
+		ProgramsCacheHits:    2,
+		ProgramsCacheMisses:  1,
+	}, txProfile)
+}
+
+func TestExecutionProfiler(t *testing.T) {
+	transactions := []TransactionProfile{
+		{
+			TxID:       "tx1",
+			ScriptHash: "script1",
+			ComputationIntensities: map[string]uint{
+				"Statement": 7,
+				"Loop":      3,
+			},
+			MemoryIntensities: map[string]uint{
+				"StringValue": 5,
+			},
+			ExecutionTime:  time.Millisecond,
+			RegisterReads:  4,
+			RegisterWrites: 2,
+		},
+		{
+			TxID:       "tx2",
+			ScriptHash: "script1",
+			ComputationIntensities: map[string]uint{
+				"Statement": 1,
+			},
+		},
+		{
+			TxID:              "tx3",
+			SystemTransaction: true,
+		},
+	}
+
+	t.Run("pprof", func(t *testing.T) {
+		prof := NewPprofProfile(transactions)
+		require.NoError(t, prof.CheckValid())
+
+		// 2 computation, 1 memory and 1 transaction samples for tx1,
+		// 1 computation and 1 transaction sample for tx2, and 1 transaction sample for tx3
+		require.Len(t, prof.Sample, 7)
+
+		totals := make(map[string]int64)
+		for _, sample := range prof.Sample {
+			frame := sample.Location[0].Line[0].Function.Name
+			totals[frame] += sample.Value[sampleComputation] + sample.Value[sampleMemory]
+		}
+		require.Equal(t, map[string]int64{
+			"computation:Statement": 8,
+			"computation:Loop":      3,
+			"memory:StringValue":    5,
+			"script:script1":        0,
+			"system_transaction":    0,
+		}, totals)
+	})
+
+	t.Run("files", func(t *testing.T) {
+		dir := t.TempDir()
+		profiler, err := NewExecutionProfiler(unittest.Logger(), dir, 0)
+		require.NoError(t, err)
+
+		ctx, cancel := irrecoverable.NewMockSignalerContextWithCancel(t, context.Background())
+		profiler.Start(ctx)
+		unittest.RequireCloseBefore(t, profiler.Ready(), time.Second, "profiler did not start")
+		defer func() {
+			cancel()
+			unittest.RequireCloseBefore(t, profiler.Done(), time.Second, "profiler did not stop")
+		}()
+
+		header := unittest.BlockHeaderFixture()
+		profiler.BlockExecuted(header, transactions)
+
+		name := fmt.Sprintf("%d_%s", header.Height, header.ID())
+		jsonPath := filepath.Join(dir, name+".jsonl")
+		pprofPath := filepath.Join(dir, name+".pb.gz")
+
+		// the pprof profile is written last
+		var prof *profile.Profile
+		require.Eventually(t, func() bool {
+			data, err := os.ReadFile(pprofPath)
+			if err != nil {
+				return false
+			}
+			prof, err = profile.ParseData(data)
+			return err == nil
+		}, time.Second, 10*time.Millisecond)
+		require.Len(t, prof.Sample, 7)
+
+		jsonFile, err := os.Open(jsonPath)
+		require.NoError(t, err)
+		defer jsonFile.Close()
+
+		var txIDs []string
+		scanner := bufio.NewScanner(jsonFile)
+		for scanner.Scan() {
+			var txProfile TransactionProfile
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &txProfile))
+			txIDs = append(txIDs, txProfile.TxID)
+		}
+		require.NoError(t, scanner.Err())
+		require.Equal(t, []string{"tx1", "tx2", "tx3"}, txIDs)
+	})
+
+	t.Run("retention", func(t *testing.T) {
+		dir := t.TempDir()
+
+		// a profile left from a previous run, and an unrelated file
+		oldProfile := filepath.Join(dir, "1_old.jsonl")
+		require.NoError(t, os.WriteFile(oldProfile, make([]byte, 10), 0644))
+		unrelated := filepath.Join(dir, "notes.txt")
+		require.NoError(t, os.WriteFile(unrelated, make([]byte, 10), 0644))
+
+		headers := []*flow.Header{unittest.BlockHeaderFixture()}
+		for len(headers) < 3 {
+			headers = append(headers, unittest.BlockHeaderWithParentFixture(headers[len(headers)-1]))
+		}
+		name := func(header *flow.Header) string {
+			return fmt.Sprintf("%d_%s", header.Height, header.ID())
+		}
+
+		// measure the size of the profile of a block
+		files, err := (&ExecutionProfiler{dir: t.TempDir()}).writeBlockProfile(blockProfile{header: headers[0], transactions: transactions})
+		require.NoError(t, err)
+		require.Len(t, files.paths, 2)
+
+		// keep the profiles of 2 blocks
+		profiler, err := NewExecutionProfiler(unittest.Logger(), dir, uint64(2*files.size+10))
+		require.NoError(t, err)
+
+		ctx, cancel := irrecoverable.NewMockSignalerContextWithCancel(t, context.Background())
+		profiler.Start(ctx)
+		unittest.RequireCloseBefore(t, profiler.Ready(), time.Second, "profiler did not start")
+		defer func() {
+			cancel()
+			unittest.RequireCloseBefore(t, profiler.Done(), time.Second, "profiler did not stop")
+		}()
+
+		for _, header := range headers {
+			profiler.BlockExecuted(header, transactions)
+		}
+
+		require.Eventually(t, func() bool {
+			_, err := os.Stat(filepath.Join(dir, name(headers[2])+".pb.gz"))
+			return err == nil
+		}, time.Second, 10*time.Millisecond)
+
+		require.NoFileExists(t, oldProfile)
+		require.FileExists(t, unrelated)
+		for _, path := range []string{name(headers[0]) + ".jsonl", name(headers[0]) + ".pb.gz"} {
+			require.NoFileExists(t, filepath.Join(dir, path))
+		}
+		for _, header := range headers[1:] {
+			require.FileExists(t, filepath.Join(dir, name(header)+".jsonl"))
+			require.FileExists(t, filepath.Join(dir, name(header)+".pb.gz"))
+		}
+	})
+
+	t.Run("nil profiler", func(t *testing.T) {
+		var profiler *ExecutionProfiler
+		profiler.BlockExecuted(unittest.BlockHeaderFixture(), transactions)
+	})
+}
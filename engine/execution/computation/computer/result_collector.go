@@ -85,6 +85,9 @@ type resultCollector struct {
 	currentCollectionState           *state.ExecutionState
 	currentCollectionStats           module.CollectionExecutionResultStats
 	currentCollectionStorageSnapshot execution.ExtendableStorageSnapshot
+
+	profiler            *ExecutionProfiler
+	transactionProfiles []TransactionProfile
 }
 
 func newResultCollector(
@@ -101,6 +104,7 @@ func newResultCollector(
 	numTransactions int,
 	consumers []result.ExecutedCollectionConsumer,
 	previousBlockSnapshot snapshot.StorageSnapshot,
+	profiler *ExecutionProfiler,
 ) *resultCollector {
 	numCollections := len(block.Collections()) + 1
 	now := time.Now()
@@ -128,6 +132,7 @@ func newResultCollector(
 			previousBlockSnapshot,
 			*block.StartState,
 		),
+		profiler: profiler,
 	}
 
 	go collector.runResultProcessor()
@@ -264,6 +269,12 @@ func (collector *resultCollector) processTransactionResult(
 		numConflictRetries,
 	)
 
+	if collector.profiler != nil {
+		collector.transactionProfiles = append(
+			collector.transactionProfiles,
+			newTransactionProfile(txn, txnExecutionSnapshot, output, timeSpent))
+	}
+
 	txnResult := flow.TransactionResult{
 		TransactionID:   txn.ID,
 		ComputationUsed: output.ComputationUsed,
@@ -431,6 +442,10 @@ func (collector *resultCollector) Finalize(
 			intensity)
 	}
 
+	collector.profiler.BlockExecuted(
+		collector.result.ExecutableBlock.Block.Header,
+		collector.transactionProfiles)
+
 	return collector.result, nil
 }
 
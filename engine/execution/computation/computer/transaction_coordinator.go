@@ -32,6 +32,8 @@ type transactionCoordinator struct {
 	snapshotTime logical.Time // guarded by mutex, cond broadcast on updates.
 	abortErr     error        // guarded by mutex, cond broadcast on updates.
 
+	conflicts []TransactionConflict // guarded by mutex.
+
 	// Note: database commit and result logging must occur within the same
 	// critical section (guraded by mutex).
 	database       *storage.BlockDatabase
@@ -102,6 +104,29 @@ func (coordinator *transactionCoordinator) AbortAllOutstandingTransactions(
 	coordinator.cond.Broadcast()
 }
 
+// RecordConflict records the retryable conflict which aborted the given
+// transaction attempt.
+func (coordinator *transactionCoordinator) RecordConflict(
+	request TransactionRequest,
+	attempt int,
+	err error,
+) {
+	conflict := newTransactionConflict(request, attempt, err)
+
+	coordinator.mutex.Lock()
+	defer coordinator.mutex.Unlock()
+
+	coordinator.conflicts = append(coordinator.conflicts, conflict)
+}
+
+// Conflicts returns the conflicts recorded so far.
+func (coordinator *transactionCoordinator) Conflicts() []TransactionConflict {
+	coordinator.mutex.Lock()
+	defer coordinator.mutex.Unlock()
+
+	return coordinator.conflicts
+}
+
 func (coordinator *transactionCoordinator) NewTransaction(
 	request TransactionRequest,
 	attempt int,
@@ -10,6 +10,7 @@ import (
 
 	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/fvm/storage"
+	"github.com/onflow/flow-go/fvm/storage/errors"
 	"github.com/onflow/flow-go/fvm/storage/logical"
 	"github.com/onflow/flow-go/fvm/storage/snapshot"
 	"github.com/onflow/flow-go/model/flow"
@@ -355,3 +356,43 @@ func TestTransactionCoordinatorNonblockingWaitForAbort(t *testing.T) {
 	err = testTxn.Commit()
 	require.Equal(t, err, abortErr)
 }
+
+func TestTransactionCoordinatorRecordConflict(t *testing.T) {
+	db := newTestCoordinator(t)
+	require.Empty(t, db.Conflicts())
+
+	registerID := flow.NewRegisterID(flow.HexToAddress("0x1"), "key")
+	request := newTransactionRequest(
+		collectionInfo{},
+		fvm.NewContext(),
+		zerolog.Nop(),
+		3,
+		&flow.TransactionBody{},
+		false)
+
+	db.RecordConflict(
+		request,
+		1,
+		errors.NewRetryableRegisterConflictError(registerID, "conflict"))
+	db.RecordConflict(
+		request,
+		2,
+		errors.NewRetryableConflictError("derived data conflict"))
+
+	require.Equal(
+		t,
+		[]TransactionConflict{
+			{
+				TxID:     request.txnId,
+				TxIndex:  3,
+				Attempt:  1,
+				Register: &registerID,
+			},
+			{
+				TxID:    request.txnId,
+				TxIndex: 3,
+				Attempt: 2,
+			},
+		},
+		db.Conflicts())
+}
@@ -788,7 +788,9 @@ func executeBlockAndVerifyWithParameters(t *testing.T,
 		prov,
 		nil,
 		stateForRandomSource,
-		testVerifyMaxConcurrency)
+		testVerifyMaxConcurrency,
+		nil,
+		nil)
 	require.NoError(t, err)
 
 	executableBlock := unittest.ExecutableBlockFromTransactions(chain.ChainID(), txs)
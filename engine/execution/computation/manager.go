@@ -67,6 +67,14 @@ type ComputationConfig struct {
 	DerivedDataCacheSize uint
 	MaxConcurrency       int
 
+	// ConflictTracker aggregates the conflicts between concurrently executed
+	// transactions. Conflicts are not tracked when nil.
+	ConflictTracker *computer.ConflictTracker
+
+	// ExecutionProfiler writes the execution profiles of the transactions of
+	// the executed blocks. Transactions are not profiled when nil.
+	ExecutionProfiler *computer.ExecutionProfiler
+
 	// When NewCustomVirtualMachine is nil, the manager will create a standard
 	// fvm virtual machine via fvm.NewVirtualMachine.  Otherwise, the manager
 	// will create a virtual machine using this function.
@@ -122,6 +130,8 @@ func New(
 		nil, // TODO(ramtin): update me with proper consumers
 		protoState,
 		params.MaxConcurrency,
+		params.ConflictTracker,
+		params.ExecutionProfiler,
 	)
 
 	if err != nil {
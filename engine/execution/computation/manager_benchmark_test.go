@@ -202,7 +202,9 @@ func benchmarkComputeBlock(
 		prov,
 		nil,
 		testutil.ProtocolStateWithSourceFixture(nil),
-		maxConcurrency)
+		maxConcurrency,
+		nil,
+		nil)
 	require.NoError(b, err)
 
 	derivedChainData, err := derived.NewDerivedChainData(
@@ -145,7 +145,9 @@ func TestComputeBlockWithStorage(t *testing.T) {
 		prov,
 		nil,
 		testutil.ProtocolStateWithSourceFixture(nil),
-		testMaxConcurrency)
+		testMaxConcurrency,
+		nil,
+		nil)
 	require.NoError(t, err)
 
 	derivedChainData, err := derived.NewDerivedChainData(10)
@@ -840,7 +842,9 @@ func Test_EventEncodingFailsOnlyTxAndCarriesOn(t *testing.T) {
 		prov,
 		nil,
 		testutil.ProtocolStateWithSourceFixture(nil),
-		testMaxConcurrency)
+		testMaxConcurrency,
+		nil,
+		nil)
 	require.NoError(t, err)
 
 	derivedChainData, err := derived.NewDerivedChainData(10)
@@ -139,7 +139,9 @@ func TestPrograms_TestContractUpdates(t *testing.T) {
 		prov,
 		nil,
 		testutil.ProtocolStateWithSourceFixture(nil),
-		testMaxConcurrency)
+		testMaxConcurrency,
+		nil,
+		nil)
 	require.NoError(t, err)
 
 	derivedChainData, err := derived.NewDerivedChainData(10)
@@ -252,7 +254,9 @@ func TestPrograms_TestBlockForks(t *testing.T) {
 		prov,
 		nil,
 		testutil.ProtocolStateWithSourceFixture(nil),
-		testMaxConcurrency)
+		testMaxConcurrency,
+		nil,
+		nil)
 	require.NoError(t, err)
 
 	derivedChainData, err := derived.NewDerivedChainData(10)
@@ -4,18 +4,24 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/onflow/flow-go/fvm/errors"
 
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/blueprints"
+	"github.com/onflow/flow-go/fvm/environment"
 	"github.com/onflow/flow-go/fvm/storage/derived"
 	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/utils/debug"
@@ -26,6 +32,10 @@ const (
 	DefaultLogTimeThreshold    = 1 * time.Second
 	DefaultExecutionTimeLimit  = 10 * time.Second
 	DefaultMaxErrorMessageSize = 1000 // 1000 chars
+
+	DefaultBatchComputationLimit = 10 * fvm.DefaultComputationLimit
+	DefaultBatchMemoryLimit      = 2 * 1024 * 1024 * 1024 // 2 GiB
+	DefaultBatchParallelism      = 8
 )
 
 type Executor interface {
@@ -41,6 +51,21 @@ type Executor interface {
 		error,
 	)
 
+	ExecuteScriptWithLimits(
+		ctx context.Context,
+		script []byte,
+		arguments [][]byte,
+		blockHeader *flow.Header,
+		snapshot snapshot.StorageSnapshot,
+		computationLimit uint64,
+		memoryLimit uint64,
+	) (
+		[]byte,
+		uint64,
+		uint64,
+		error,
+	)
+
 	GetAccount(
 		ctx context.Context,
 		addr flow.Address,
@@ -91,6 +116,38 @@ type Executor interface {
 		*flow.AccountPublicKey,
 		error,
 	)
+
+	SimulateTransaction(
+		ctx context.Context,
+		tx *flow.TransactionBody,
+		header *flow.Header,
+		snapshot snapshot.StorageSnapshot,
+		skipSignatureCheck bool,
+	) (
+		*flow.TransactionSimulationResult,
+		error,
+	)
+
+	EstimateTransactionFees(
+		ctx context.Context,
+		tx *flow.TransactionBody,
+		header *flow.Header,
+		snapshot snapshot.StorageSnapshot,
+	) (
+		*flow.TransactionFeeEstimate,
+		error,
+	)
+
+	ValidateContractUpdate(
+		ctx context.Context,
+		address flow.Address,
+		code []byte,
+		header *flow.Header,
+		snapshot snapshot.StorageSnapshot,
+	) (
+		*flow.ContractUpdateValidation,
+		error,
+	)
 }
 
 type QueryConfig struct {
@@ -98,14 +155,27 @@ type QueryConfig struct {
 	ExecutionTimeLimit  time.Duration
 	ComputationLimit    uint64
 	MaxErrorMessageSize int
+	// FeeEstimationComputationLimit is the maximum computation a transaction may use when its
+	// fees are estimated.
+	FeeEstimationComputationLimit uint64
+	// BatchComputationLimit is the maximum computation all scripts of a batch may use together.
+	BatchComputationLimit uint64
+	// BatchMemoryLimit is the maximum memory all scripts of a batch may use together.
+	BatchMemoryLimit uint64
+	// BatchParallelism is the maximum number of scripts of a batch executed concurrently.
+	BatchParallelism int
 }
 
 func NewDefaultConfig() QueryConfig {
 	return QueryConfig{
-		LogTimeThreshold:    DefaultLogTimeThreshold,
-		ExecutionTimeLimit:  DefaultExecutionTimeLimit,
-		ComputationLimit:    fvm.DefaultComputationLimit,
-		MaxErrorMessageSize: DefaultMaxErrorMessageSize,
+		LogTimeThreshold:              DefaultLogTimeThreshold,
+		ExecutionTimeLimit:            DefaultExecutionTimeLimit,
+		ComputationLimit:              fvm.DefaultComputationLimit,
+		MaxErrorMessageSize:           DefaultMaxErrorMessageSize,
+		FeeEstimationComputationLimit: fvm.DefaultComputationLimit,
+		BatchComputationLimit:         DefaultBatchComputationLimit,
+		BatchMemoryLimit:              DefaultBatchMemoryLimit,
+		BatchParallelism:              DefaultBatchParallelism,
 	}
 }
 
@@ -134,6 +204,9 @@ func NewQueryExecutor(
 	if config.ComputationLimit > 0 {
 		vmCtx = fvm.NewContextFromParent(vmCtx, fvm.WithComputationLimit(config.ComputationLimit))
 	}
+	if config.FeeEstimationComputationLimit == 0 {
+		config.FeeEstimationComputationLimit = fvm.DefaultComputationLimit
+	}
 	return &QueryExecutor{
 		config:           config,
 		logger:           logger,
@@ -157,6 +230,31 @@ func (e *QueryExecutor) ExecuteScript(
 	computationUsed uint64,
 	err error,
 ) {
+	encodedValue, computationUsed, _, err = e.ExecuteScriptWithLimits(ctx, script, arguments, blockHeader, snapshot, 0, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	return encodedValue, computationUsed, nil
+}
+
+// ExecuteScriptWithLimits executes the script like ExecuteScript, but with the given computation and
+// memory limits instead of the configured ones. A limit of 0 uses the configured limit.
+//
+// The computation used and memory estimate are also returned if the script fails to execute.
+func (e *QueryExecutor) ExecuteScriptWithLimits(
+	ctx context.Context,
+	script []byte,
+	arguments [][]byte,
+	blockHeader *flow.Header,
+	snapshot snapshot.StorageSnapshot,
+	computationLimit uint64,
+	memoryLimit uint64,
+) (
+	encodedValue []byte,
+	computationUsed uint64,
+	memoryEstimate uint64,
+	err error,
+) {
 
 	startedAt := time.Now()
 	memAllocBefore := debug.GetHeapAllocsBytes()
@@ -169,7 +267,7 @@ func (e *QueryExecutor) ExecuteScript(
 		defer e.rngLock.Unlock()
 		trackerID, err := rand.Uint32()
 		if err != nil {
-			return nil, 0, fmt.Errorf("failed to generate trackerID: %w", err)
+			return nil, 0, 0, fmt.Errorf("failed to generate trackerID: %w", err)
 		}
 
 		trackedLogger := e.logger.With().Hex("script_hex", script).Uint32("trackerID", trackerID).Logger()
@@ -210,22 +308,30 @@ func (e *QueryExecutor) ExecuteScript(
 		}
 	}()
 
+	options := []fvm.Option{
+		fvm.WithBlockHeader(blockHeader),
+		fvm.WithEntropyProvider(e.entropyPerBlock.AtBlockID(blockHeader.ID())),
+		fvm.WithDerivedBlockData(
+			e.derivedChainData.NewDerivedBlockDataForScript(blockHeader.ID())),
+	}
+	if computationLimit > 0 {
+		options = append(options, fvm.WithComputationLimit(computationLimit))
+	}
+	if memoryLimit > 0 {
+		options = append(options, fvm.WithMemoryLimit(memoryLimit))
+	}
+
 	var output fvm.ProcedureOutput
 	_, output, err = e.vm.Run(
-		fvm.NewContextFromParent(
-			e.vmCtx,
-			fvm.WithBlockHeader(blockHeader),
-			fvm.WithEntropyProvider(e.entropyPerBlock.AtBlockID(blockHeader.ID())),
-			fvm.WithDerivedBlockData(
-				e.derivedChainData.NewDerivedBlockDataForScript(blockHeader.ID()))),
+		fvm.NewContextFromParent(e.vmCtx, options...),
 		fvm.NewScriptWithContextAndArgs(script, requestCtx, arguments...),
 		snapshot)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to execute script (internal error): %w", err)
+		return nil, 0, 0, fmt.Errorf("failed to execute script (internal error): %w", err)
 	}
 
 	if output.Err != nil {
-		return nil, 0, errors.NewCodedError(
+		return nil, output.ComputationUsed, output.MemoryEstimate, errors.NewCodedError(
 			output.Err.Code(),
 			"failed to execute script at block (%s): %s", blockHeader.ID(),
 			summarizeLog(output.Err.Error(), e.config.MaxErrorMessageSize),
@@ -234,7 +340,7 @@ func (e *QueryExecutor) ExecuteScript(
 
 	encodedValue, err = jsoncdc.Encode(output.Value)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to encode runtime value: %w", err)
+		return nil, 0, 0, fmt.Errorf("failed to encode runtime value: %w", err)
 	}
 
 	memAllocAfter := debug.GetHeapAllocsBytes()
@@ -244,7 +350,7 @@ func (e *QueryExecutor) ExecuteScript(
 		memAllocAfter-memAllocBefore,
 		output.MemoryEstimate)
 
-	return encodedValue, output.ComputationUsed, nil
+	return encodedValue, output.ComputationUsed, output.MemoryEstimate, nil
 }
 
 func summarizeLog(log string, limit int) string {
@@ -405,3 +511,379 @@ func (e *QueryExecutor) GetAccountKey(
 
 	return accountKey, nil
 }
+
+// SimulateTransaction executes the transaction against the given snapshot without committing any
+// of its changes. If skipSignatureCheck is true, the transaction's signatures and sequence number
+// are not verified, which allows simulating transactions before they are signed.
+//
+// The transaction's computation limit is capped at the configured fee estimation computation limit,
+// and the simulation fails if it doesn't complete within the configured execution time limit.
+//
+// A failure of the transaction itself is not returned as an error, but as part of the result.
+func (e *QueryExecutor) SimulateTransaction(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	blockHeader *flow.Header,
+	snapshot snapshot.StorageSnapshot,
+	skipSignatureCheck bool,
+) (
+	result *flow.TransactionSimulationResult,
+	err error,
+) {
+	startedAt := time.Now()
+	txID := tx.ID()
+
+	defer func() {
+		elapsed := time.Since(startedAt)
+
+		if r := recover(); r != nil {
+			e.logger.Error().
+				Hex("tx_id", txID[:]).
+				Interface("recovered", r).
+				Msg("transaction simulation caused runtime panic")
+
+			err = fmt.Errorf("cadence runtime error: %s", r)
+			return
+		}
+		if elapsed >= e.config.LogTimeThreshold {
+			e.logger.Error().
+				Hex("tx_id", txID[:]).
+				Dur("duration", elapsed).
+				Msg("transaction simulation exceeded threshold")
+		}
+	}()
+
+	requestCtx, cancel := context.WithTimeout(ctx, e.config.ExecutionTimeLimit)
+	defer cancel()
+
+	computationLimit := e.config.FeeEstimationComputationLimit
+	if tx.GasLimit > 0 && tx.GasLimit < computationLimit {
+		computationLimit = tx.GasLimit
+	}
+
+	// the transaction is never committed, so its limit can be changed without affecting anything
+	// but the simulation. If signatures are checked, they still cover the original limit.
+	limited := *tx
+	limited.GasLimit = computationLimit
+
+	// derived block data is intentionally not shared with scripts, since the programs cache must
+	// not observe changes made by a transaction which is never committed.
+	blockCtx := fvm.NewContextFromParent(
+		e.vmCtx,
+		fvm.WithBlockHeader(blockHeader),
+		fvm.WithEntropyProvider(e.entropyPerBlock.AtBlockID(blockHeader.ID())),
+		fvm.WithAuthorizationChecksEnabled(!skipSignatureCheck),
+		fvm.WithSequenceNumberCheckAndIncrementEnabled(!skipSignatureCheck))
+
+	executionSnapshot, output, err := e.runWithTimeout(requestCtx, blockCtx, fvm.Transaction(&limited, 0), snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate transaction: %w", err)
+	}
+
+	result = &flow.TransactionSimulationResult{
+		BlockID:         blockHeader.ID(),
+		BlockHeight:     blockHeader.Height,
+		Events:          output.Events,
+		ComputationUsed: output.ComputationUsed,
+		MemoryEstimate:  output.MemoryEstimate,
+	}
+
+	if output.Err != nil {
+		result.ErrorMessage = summarizeLog(output.Err.Error(), e.config.MaxErrorMessageSize)
+	}
+
+	result.StorageDeltas, err = storageDeltas(executionSnapshot, snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute storage deltas: %w", err)
+	}
+
+	return result, nil
+}
+
+// runWithTimeout runs the procedure, and fails once the context is done. Transactions can't be
+// cancelled while they run, so the procedure keeps running in the background until its
+// computation limit is reached, but the result is no longer waited for.
+func (e *QueryExecutor) runWithTimeout(
+	ctx context.Context,
+	blockCtx fvm.Context,
+	proc fvm.Procedure,
+	storageSnapshot snapshot.StorageSnapshot,
+) (
+	*snapshot.ExecutionSnapshot,
+	fvm.ProcedureOutput,
+	error,
+) {
+	type runResult struct {
+		executionSnapshot *snapshot.ExecutionSnapshot
+		output            fvm.ProcedureOutput
+		err               error
+	}
+
+	done := make(chan runResult, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- runResult{err: fmt.Errorf("cadence runtime error: %s", r)}
+			}
+		}()
+
+		executionSnapshot, output, err := e.vm.Run(blockCtx, proc, storageSnapshot)
+		if err != nil {
+			err = fmt.Errorf("internal error: %w", err)
+		}
+		done <- runResult{executionSnapshot: executionSnapshot, output: output, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, fvm.ProcedureOutput{}, fmt.Errorf("execution did not complete: %w", ctx.Err())
+	case result := <-done:
+		return result.executionSnapshot, result.output, result.err
+	}
+}
+
+// EstimateTransactionFees executes the transaction against the given snapshot without committing
+// any of its changes, and returns the computation it used and the fees it would be charged with the
+// fee parameters at the given block.
+//
+// The transaction's signatures and sequence number are not verified, and its computation limit is
+// capped at the configured fee estimation computation limit.
+//
+// A failure of the transaction itself is not returned as an error, but as part of the estimate.
+func (e *QueryExecutor) EstimateTransactionFees(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	blockHeader *flow.Header,
+	snapshot snapshot.StorageSnapshot,
+) (
+	estimate *flow.TransactionFeeEstimate,
+	err error,
+) {
+	txID := tx.ID()
+
+	defer func() {
+		if r := recover(); r != nil {
+			e.logger.Error().
+				Hex("tx_id", txID[:]).
+				Interface("recovered", r).
+				Msg("transaction fee estimation caused runtime panic")
+
+			err = fmt.Errorf("cadence runtime error: %s", r)
+		}
+	}()
+
+	computationLimit := e.config.FeeEstimationComputationLimit
+	if tx.GasLimit > 0 && tx.GasLimit < computationLimit {
+		computationLimit = tx.GasLimit
+	}
+
+	// the transaction is only metered, so its limit can be changed without affecting its validity
+	metered := *tx
+	metered.GasLimit = computationLimit
+
+	// fees are computed from the metered computation below, deducting them would require the payer
+	// to be able to cover the fees of the full computation limit.
+	blockCtx := fvm.NewContextFromParent(
+		e.vmCtx,
+		fvm.WithBlockHeader(blockHeader),
+		fvm.WithEntropyProvider(e.entropyPerBlock.AtBlockID(blockHeader.ID())),
+		fvm.WithAuthorizationChecksEnabled(false),
+		fvm.WithSequenceNumberCheckAndIncrementEnabled(false),
+		fvm.WithTransactionFeesEnabled(false))
+
+	_, output, err := e.vm.Run(blockCtx, fvm.Transaction(&metered, 0), snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate transaction fees (internal error): %w", err)
+	}
+
+	params, err := e.feeParameters(ctx, blockHeader, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	executionEffort := output.ComputationUsed
+	if executionEffort > computationLimit {
+		executionEffort = computationLimit
+	}
+
+	intensities := make(map[common.ComputationKind]uint64, len(output.ComputationIntensities))
+	for kind, intensity := range output.ComputationIntensities {
+		intensities[kind] = uint64(intensity)
+	}
+
+	estimate = &flow.TransactionFeeEstimate{
+		BlockID:                blockHeader.ID(),
+		BlockHeight:            blockHeader.Height,
+		ComputationLimit:       computationLimit,
+		ComputationUsed:        output.ComputationUsed,
+		ComputationIntensities: intensities,
+		MemoryEstimate:         output.MemoryEstimate,
+		InclusionEffort:        tx.InclusionEffort(),
+		ExecutionEffort:        executionEffort,
+		FeeParameters:          params,
+	}
+
+	if output.Err != nil {
+		estimate.ErrorMessage = summarizeLog(output.Err.Error(), e.config.MaxErrorMessageSize)
+	}
+
+	estimate.InclusionFee, estimate.ExecutionFee, estimate.TotalFee, err = params.ComputeFees(
+		estimate.InclusionEffort,
+		estimate.ExecutionEffort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute transaction fees: %w", err)
+	}
+
+	return estimate, nil
+}
+
+// ValidateContractUpdate validates an update of a contract of the account to the given code against
+// the given snapshot, without committing any of its changes.
+//
+// The reasons for which the update would be rejected are not returned as an error, but as
+// violations of the result.
+func (e *QueryExecutor) ValidateContractUpdate(
+	_ context.Context,
+	address flow.Address,
+	code []byte,
+	blockHeader *flow.Header,
+	snapshot snapshot.StorageSnapshot,
+) (
+	validation *flow.ContractUpdateValidation,
+	err error,
+) {
+	defer func() {
+		if r := recover(); r != nil {
+			e.logger.Error().
+				Str("address", address.String()).
+				Interface("recovered", r).
+				Msg("contract update validation caused runtime panic")
+
+			err = fmt.Errorf("cadence runtime error: %s", r)
+		}
+	}()
+
+	validation, err = fvm.ValidateContractUpdate(
+		e.vm,
+		fvm.NewContextFromParent(
+			e.vmCtx,
+			fvm.WithBlockHeader(blockHeader),
+			fvm.WithEntropyProvider(e.entropyPerBlock.AtBlockID(blockHeader.ID()))),
+		address,
+		code,
+		snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate contract update (internal error): %w", err)
+	}
+
+	validation.BlockID = blockHeader.ID()
+	validation.BlockHeight = blockHeader.Height
+
+	return validation, nil
+}
+
+// feeParameters reads the transaction fee parameters from the FlowFees contract.
+func (e *QueryExecutor) feeParameters(
+	ctx context.Context,
+	blockHeader *flow.Header,
+	snapshot snapshot.StorageSnapshot,
+) (flow.TransactionFeeParameters, error) {
+	requestCtx, cancel := context.WithTimeout(ctx, e.config.ExecutionTimeLimit)
+	defer cancel()
+
+	sc := systemcontracts.SystemContractsForChain(e.vmCtx.Chain.ChainID())
+
+	_, output, err := e.vm.Run(
+		fvm.NewContextFromParent(
+			e.vmCtx,
+			fvm.WithBlockHeader(blockHeader),
+			fvm.WithDerivedBlockData(
+				e.derivedChainData.NewDerivedBlockDataForScript(blockHeader.ID()))),
+		fvm.NewScriptWithContextAndArgs(blueprints.GetFeeParametersScript(sc.FlowFees.Address), requestCtx),
+		snapshot)
+	if err != nil {
+		return flow.TransactionFeeParameters{}, fmt.Errorf("failed to read fee parameters (internal error): %w", err)
+	}
+	if output.Err != nil {
+		return flow.TransactionFeeParameters{}, fmt.Errorf("failed to read fee parameters: %w", output.Err)
+	}
+
+	value, ok := output.Value.(cadence.Struct)
+	if !ok {
+		return flow.TransactionFeeParameters{}, fmt.Errorf("unexpected fee parameters type: %T", output.Value)
+	}
+
+	fields := cadence.FieldsMappedByName(value)
+	surgeFactor, ok1 := fields["surgeFactor"].(cadence.UFix64)
+	inclusionEffortCost, ok2 := fields["inclusionEffortCost"].(cadence.UFix64)
+	executionEffortCost, ok3 := fields["executionEffortCost"].(cadence.UFix64)
+	if !ok1 || !ok2 || !ok3 {
+		return flow.TransactionFeeParameters{}, fmt.Errorf("unexpected fee parameters: %s", value)
+	}
+
+	return flow.TransactionFeeParameters{
+		SurgeFactor:         uint64(surgeFactor),
+		InclusionEffortCost: uint64(inclusionEffortCost),
+		ExecutionEffortCost: uint64(executionEffortCost),
+	}, nil
+}
+
+// storageDeltas returns the change in storage used for every account whose status register was
+// updated in the given execution snapshot, ordered by address.
+func storageDeltas(
+	executionSnapshot *snapshot.ExecutionSnapshot,
+	storageSnapshot snapshot.StorageSnapshot,
+) ([]flow.AccountStorageDelta, error) {
+	var deltas []flow.AccountStorageDelta
+	for id, value := range executionSnapshot.WriteSet {
+		if id.Key != flow.AccountStatusKey {
+			continue
+		}
+
+		after, err := storageUsed(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode updated status of account %x: %w", id.Owner, err)
+		}
+
+		previous, err := storageSnapshot.Get(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read status of account %x: %w", id.Owner, err)
+		}
+
+		before, err := storageUsed(previous)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode status of account %x: %w", id.Owner, err)
+		}
+
+		if before == after {
+			continue
+		}
+
+		deltas = append(deltas, flow.AccountStorageDelta{
+			Address:           flow.BytesToAddress([]byte(id.Owner)),
+			StorageUsedBefore: before,
+			StorageUsedAfter:  after,
+		})
+	}
+
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].Address.Hex() < deltas[j].Address.Hex()
+	})
+
+	return deltas, nil
+}
+
+// storageUsed returns the storage used recorded in the encoded account status. An empty value
+// belongs to an account which does not exist, and uses no storage.
+func storageUsed(value flow.RegisterValue) (uint64, error) {
+	if len(value) == 0 {
+		return 0, nil
+	}
+
+	status, err := environment.AccountStatusFromBytes(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return status.StorageUsed(), nil
+}
@@ -16,6 +16,36 @@ type Executor struct {
 	mock.Mock
 }
 
+// EstimateTransactionFees provides a mock function with given fields: ctx, tx, header, _a3
+func (_m *Executor) EstimateTransactionFees(ctx context.Context, tx *flow.TransactionBody, header *flow.Header, _a3 snapshot.StorageSnapshot) (*flow.TransactionFeeEstimate, error) {
+	ret := _m.Called(ctx, tx, header, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateTransactionFees")
+	}
+
+	var r0 *flow.TransactionFeeEstimate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, *flow.Header, snapshot.StorageSnapshot) (*flow.TransactionFeeEstimate, error)); ok {
+		return rf(ctx, tx, header, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, *flow.Header, snapshot.StorageSnapshot) *flow.TransactionFeeEstimate); ok {
+		r0 = rf(ctx, tx, header, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TransactionFeeEstimate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody, *flow.Header, snapshot.StorageSnapshot) error); ok {
+		r1 = rf(ctx, tx, header, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecuteScript provides a mock function with given fields: ctx, script, arguments, blockHeader, _a4
 func (_m *Executor) ExecuteScript(ctx context.Context, script []byte, arguments [][]byte, blockHeader *flow.Header, _a4 snapshot.StorageSnapshot) ([]byte, uint64, error) {
 	ret := _m.Called(ctx, script, arguments, blockHeader, _a4)
@@ -53,6 +83,50 @@ func (_m *Executor) ExecuteScript(ctx context.Context, script []byte, arguments
 	return r0, r1, r2
 }
 
+// ExecuteScriptWithLimits provides a mock function with given fields: ctx, script, arguments, blockHeader, _a4, computationLimit, memoryLimit
+func (_m *Executor) ExecuteScriptWithLimits(ctx context.Context, script []byte, arguments [][]byte, blockHeader *flow.Header, _a4 snapshot.StorageSnapshot, computationLimit uint64, memoryLimit uint64) ([]byte, uint64, uint64, error) {
+	ret := _m.Called(ctx, script, arguments, blockHeader, _a4, computationLimit, memoryLimit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteScriptWithLimits")
+	}
+
+	var r0 []byte
+	var r1 uint64
+	var r2 uint64
+	var r3 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, [][]byte, *flow.Header, snapshot.StorageSnapshot, uint64, uint64) ([]byte, uint64, uint64, error)); ok {
+		return rf(ctx, script, arguments, blockHeader, _a4, computationLimit, memoryLimit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, [][]byte, *flow.Header, snapshot.StorageSnapshot, uint64, uint64) []byte); ok {
+		r0 = rf(ctx, script, arguments, blockHeader, _a4, computationLimit, memoryLimit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, [][]byte, *flow.Header, snapshot.StorageSnapshot, uint64, uint64) uint64); ok {
+		r1 = rf(ctx, script, arguments, blockHeader, _a4, computationLimit, memoryLimit)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []byte, [][]byte, *flow.Header, snapshot.StorageSnapshot, uint64, uint64) uint64); ok {
+		r2 = rf(ctx, script, arguments, blockHeader, _a4, computationLimit, memoryLimit)
+	} else {
+		r2 = ret.Get(2).(uint64)
+	}
+
+	if rf, ok := ret.Get(3).(func(context.Context, []byte, [][]byte, *flow.Header, snapshot.StorageSnapshot, uint64, uint64) error); ok {
+		r3 = rf(ctx, script, arguments, blockHeader, _a4, computationLimit, memoryLimit)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
 // GetAccount provides a mock function with given fields: ctx, addr, header, _a3
 func (_m *Executor) GetAccount(ctx context.Context, addr flow.Address, header *flow.Header, _a3 snapshot.StorageSnapshot) (*flow.Account, error) {
 	ret := _m.Called(ctx, addr, header, _a3)
@@ -199,6 +273,66 @@ func (_m *Executor) GetAccountKeys(ctx context.Context, addr flow.Address, heade
 	return r0, r1
 }
 
+// SimulateTransaction provides a mock function with given fields: ctx, tx, header, _a3, skipSignatureCheck
+func (_m *Executor) SimulateTransaction(ctx context.Context, tx *flow.TransactionBody, header *flow.Header, _a3 snapshot.StorageSnapshot, skipSignatureCheck bool) (*flow.TransactionSimulationResult, error) {
+	ret := _m.Called(ctx, tx, header, _a3, skipSignatureCheck)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SimulateTransaction")
+	}
+
+	var r0 *flow.TransactionSimulationResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, *flow.Header, snapshot.StorageSnapshot, bool) (*flow.TransactionSimulationResult, error)); ok {
+		return rf(ctx, tx, header, _a3, skipSignatureCheck)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, *flow.Header, snapshot.StorageSnapshot, bool) *flow.TransactionSimulationResult); ok {
+		r0 = rf(ctx, tx, header, _a3, skipSignatureCheck)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TransactionSimulationResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody, *flow.Header, snapshot.StorageSnapshot, bool) error); ok {
+		r1 = rf(ctx, tx, header, _a3, skipSignatureCheck)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidateContractUpdate provides a mock function with given fields: ctx, address, code, header, _a4
+func (_m *Executor) ValidateContractUpdate(ctx context.Context, address flow.Address, code []byte, header *flow.Header, _a4 snapshot.StorageSnapshot) (*flow.ContractUpdateValidation, error) {
+	ret := _m.Called(ctx, address, code, header, _a4)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateContractUpdate")
+	}
+
+	var r0 *flow.ContractUpdateValidation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, []byte, *flow.Header, snapshot.StorageSnapshot) (*flow.ContractUpdateValidation, error)); ok {
+		return rf(ctx, address, code, header, _a4)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, []byte, *flow.Header, snapshot.StorageSnapshot) *flow.ContractUpdateValidation); ok {
+		r0 = rf(ctx, address, code, header, _a4)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.ContractUpdateValidation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, []byte, *flow.Header, snapshot.StorageSnapshot) error); ok {
+		r1 = rf(ctx, address, code, header, _a4)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewExecutor creates a new instance of Executor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewExecutor(t interface {
@@ -59,6 +59,7 @@ type Core struct {
 	executor          BlockExecutor
 	collectionFetcher CollectionFetcher
 	eventConsumer     EventConsumer
+	prefetcher        execution.RegisterPrefetcher
 	metrics           module.ExecutionMetrics
 }
 
@@ -81,6 +82,7 @@ func NewCore(
 	executor BlockExecutor,
 	collectionFetcher CollectionFetcher,
 	eventConsumer EventConsumer,
+	prefetcher execution.RegisterPrefetcher,
 	metrics module.ExecutionMetrics,
 ) (*Core, error) {
 	e := &Core{
@@ -96,6 +98,7 @@ func NewCore(
 		executor:          executor,
 		collectionFetcher: collectionFetcher,
 		eventConsumer:     eventConsumer,
+		prefetcher:        prefetcher,
 		metrics:           metrics,
 	}
 
@@ -225,6 +228,7 @@ func (e *Core) onProcessableBlock(blockID flow.Identifier, height uint64) error
 		Logger()
 	lg.Debug().
 		Int("executables", len(executables)).Msgf("executeConcurrently block is executable")
+	e.prefetch(executables)
 	e.executeConcurrently(executables)
 
 	missingCount, err := e.fetch(missingColls)
@@ -353,6 +357,8 @@ func (e *Core) onBlockExecuted(
 
 	lg.Debug().Msgf("execution state saved")
 
+	e.prefetcher.OnBlockExecuted(computationResult)
+
 	// must call OnBlockExecuted AFTER saving the execution result to storage
 	// because when enqueuing a block, we rely on execState.StateCommitmentByBlockID
 	// to determine whether a block has been executed or not.
@@ -361,6 +367,9 @@ func (e *Core) onBlockExecuted(
 		return fmt.Errorf("unexpected error while marking block as executed: %w", err)
 	}
 
+	// start prefetching the registers of the child blocks while the result is broadcasted
+	e.prefetch(executables)
+
 	e.stopControl.OnBlockExecuted(block.Block.Header)
 
 	// notify event consumer so that the event consumer can do tasks
@@ -423,6 +432,9 @@ func (e *Core) onCollection(col *flow.Collection) error {
 }
 
 func (e *Core) handleCollection(colID flow.Identifier, col *flow.Collection) error {
+	// the registers read by the collection can be predicted before its block is executable
+	e.prefetcher.OnCollection(col)
+
 	// if the collection is a duplication, it's still good to add it to the block queue,
 	// because chances are the collection was stored before a restart, and
 	// is not in the queue after the restart.
@@ -438,6 +450,7 @@ func (e *Core) handleCollection(colID flow.Identifier, col *flow.Collection) err
 		Hex("collection_id", colID[:]).
 		Int("executables", len(executables)).Msgf("executeConcurrently: collection is handled, ready to execute block")
 
+	e.prefetch(executables)
 	e.executeConcurrently(executables)
 
 	return nil
@@ -459,6 +472,14 @@ func storeCollectionIfMissing(collections storage.Collections, col *flow.Collect
 	return nil
 }
 
+// prefetch starts prefetching the registers of the blocks which became executable,
+// since their parent blocks are executed.
+func (e *Core) prefetch(executables []*entity.ExecutableBlock) {
+	for _, executable := range executables {
+		e.prefetcher.Prefetch(executable)
+	}
+}
+
 // execute block concurrently
 func (e *Core) executeConcurrently(executables []*entity.ExecutableBlock) {
 	for _, executable := range executables {
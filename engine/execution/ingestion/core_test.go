@@ -17,6 +17,7 @@ import (
 	"github.com/onflow/flow-go/engine/execution/ingestion/mocks"
 	"github.com/onflow/flow-go/engine/execution/ingestion/stop"
 	stateMock "github.com/onflow/flow-go/engine/execution/state/mock"
+	"github.com/onflow/flow-go/engine/execution/storehouse"
 	"github.com/onflow/flow-go/engine/execution/testutil"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/irrecoverable"
@@ -124,7 +125,7 @@ func createCore(t *testing.T, blocks []*flow.Block) (
 	executor := &mockExecutor{t: t, consumer: consumer}
 	metrics := metrics.NewNoopCollector()
 	core, err := NewCore(unittest.Logger(), throttle, execState, stopControl, blocksDB,
-		collections, executor, collectionFetcher, consumer, metrics)
+		collections, executor, collectionFetcher, consumer, storehouse.NewNoopRegisterPrefetcher(), metrics)
 	require.NoError(t, err)
 	return core, throttle, state, collections, blocksDB, headers, collectionFetcher, consumer
 }
@@ -54,6 +54,7 @@ func NewMachine(
 	broadcaster provider.ProviderEngine,
 	uploader *uploader.Manager,
 	stopControl *stop.StopControl,
+	prefetcher execution.RegisterPrefetcher,
 ) (*Machine, *Core, error) {
 
 	e := &Machine{
@@ -85,6 +86,7 @@ func NewMachine(
 		e,
 		collectionFetcher,
 		e,
+		prefetcher,
 		metrics,
 	)
 
@@ -0,0 +1,199 @@
+package proofs
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/common/fifoqueue"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/channels"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+const (
+	// MaxRegistersPerRequest is the max number of registers proven by a single request.
+	MaxRegistersPerRequest = 100
+	// DefaultWorkers is the default number of workers serving register proof requests.
+	DefaultWorkers = 2
+	// DefaultRequestQueueCapacity is the default max number of queued register proof requests.
+	DefaultRequestQueueCapacity = 1000
+)
+
+// Engine serves batch proofs of registers in the execution state to access nodes, which expose
+// them through the access API so that clients can verify register values against the state
+// commitment of a sealed block.
+type Engine struct {
+	component.Component
+	cm *component.ComponentManager
+
+	log    zerolog.Logger
+	ledger ledger.Ledger
+	con    network.Conduit
+
+	requests *fifoqueue.FifoQueue
+	notifier engine.Notifier
+}
+
+var _ network.MessageProcessor = (*Engine)(nil)
+var _ component.Component = (*Engine)(nil)
+
+// New creates a new register proofs engine, which serves the proofs from the given ledger.
+func New(
+	log zerolog.Logger,
+	net network.EngineRegistry,
+	ledger ledger.Ledger,
+	workers uint,
+	requestQueueCapacity uint,
+) (*Engine, error) {
+	requests, err := fifoqueue.NewFifoQueue(int(requestQueueCapacity))
+	if err != nil {
+		return nil, fmt.Errorf("could not create request queue: %w", err)
+	}
+
+	e := &Engine{
+		log:      log.With().Str("engine", "register_proofs").Logger(),
+		ledger:   ledger,
+		requests: requests,
+		notifier: engine.NewNotifier(),
+	}
+
+	con, err := net.Register(channels.ProvideRegisterProofs, e)
+	if err != nil {
+		return nil, fmt.Errorf("could not register register proofs engine: %w", err)
+	}
+	e.con = con
+
+	cm := component.NewComponentManagerBuilder()
+	for i := uint(0); i < workers; i++ {
+		cm.AddWorker(e.serveRequestsWorker)
+	}
+	e.cm = cm.Build()
+	e.Component = e.cm
+
+	return e, nil
+}
+
+// Process processes messages from the networking layer.
+// No errors are expected during normal operation.
+func (e *Engine) Process(channel channels.Channel, originID flow.Identifier, message any) error {
+	request, ok := message.(*messages.RegisterProofRequest)
+	if !ok {
+		e.log.Warn().
+			Bool(logging.KeySuspicious, true).
+			Msgf("%v delivered unsupported message %T through %v", originID, message, channel)
+		return nil
+	}
+
+	if !e.requests.Push(&engine.Message{OriginID: originID, Payload: request}) {
+		e.log.Warn().
+			Hex("origin_id", logging.ID(originID)).
+			Msg("dropped register proof request, because request queue is full")
+		return nil
+	}
+	e.notifier.Notify()
+	return nil
+}
+
+// serveRequestsWorker serves the queued requests.
+// This is a worker routine which runs for the lifetime of the engine.
+func (e *Engine) serveRequestsWorker(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	done := ctx.Done()
+	wake := e.notifier.Channel()
+	for {
+		select {
+		case <-done:
+			return
+		case <-wake:
+			e.serveRequestsWhileAvailable(ctx)
+		}
+	}
+}
+
+// serveRequestsWhileAvailable serves queued requests until the queue is empty.
+func (e *Engine) serveRequestsWhileAvailable(ctx irrecoverable.SignalerContext) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		next, ok := e.requests.Pop()
+		if !ok {
+			return
+		}
+		msg := next.(*engine.Message)
+
+		err := e.onRegisterProofRequest(msg.OriginID, msg.Payload.(*messages.RegisterProofRequest))
+		if err != nil {
+			ctx.Throw(err)
+		}
+	}
+}
+
+// onRegisterProofRequest responds with the batch proof of the requested registers. The proof is
+// empty if the ledger doesn't have the execution state at the requested state commitment anymore.
+// No errors are expected during normal operation.
+func (e *Engine) onRegisterProofRequest(originID flow.Identifier, request *messages.RegisterProofRequest) error {
+	lg := e.log.With().
+		Hex("origin_id", logging.ID(originID)).
+		Hex("block_id", logging.ID(request.BlockID)).
+		Hex("state_commitment", request.StateCommitment[:]).
+		Int("registers", len(request.RegisterIDs)).
+		Logger()
+
+	if len(request.RegisterIDs) == 0 || len(request.RegisterIDs) > MaxRegistersPerRequest {
+		lg.Warn().Bool(logging.KeySuspicious, true).Msg("invalid number of registers in register proof request")
+		return nil
+	}
+
+	response := &messages.RegisterProofResponse{
+		BlockID:         request.BlockID,
+		StateCommitment: request.StateCommitment,
+		Nonce:           request.Nonce,
+	}
+
+	state := ledger.State(request.StateCommitment)
+	if e.ledger.HasState(state) {
+		keys := make([]ledger.Key, len(request.RegisterIDs))
+		for i, id := range request.RegisterIDs {
+			keys[i] = convert.RegisterIDToLedgerKey(id)
+		}
+
+		query, err := ledger.NewQuery(state, keys)
+		if err != nil {
+			return fmt.Errorf("could not create ledger query: %w", err)
+		}
+
+		// the trie might have been evicted from the ledger since
+		proof, err := e.ledger.Prove(query)
+		if err != nil {
+			lg.Info().Err(err).Msg("could not prove registers")
+		} else {
+			response.Proof = proof
+		}
+	}
+
+	if len(response.Proof) == 0 {
+		lg.Info().Msg("requested execution state is not available")
+	}
+
+	err := e.con.Unicast(response, originID)
+	if err != nil {
+		lg.Warn().Err(err).Msg("could not send register proof response")
+		return nil
+	}
+
+	lg.Debug().Int("proof_size", len(response.Proof)).Msg("sent register proof response")
+	return nil
+}
@@ -0,0 +1,219 @@
+package proofs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	accessproofs "github.com/onflow/flow-go/engine/access/proofs"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/common/proof"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/network/channels"
+	"github.com/onflow/flow-go/network/mocknetwork"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// newTestLedger creates an in-memory ledger with the given registers, and returns it along with
+// the state commitment of the registers.
+func newTestLedger(t *testing.T, registers flow.RegisterEntries) (*complete.Ledger, flow.StateCommitment) {
+	l, err := complete.NewLedger(&fixtures.NoopWAL{}, 100, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion)
+	require.NoError(t, err)
+
+	compactor := fixtures.NewNoopCompactor(l)
+	<-compactor.Ready()
+	t.Cleanup(func() {
+		<-l.Done()
+		<-compactor.Done()
+	})
+
+	keys := make([]ledger.Key, len(registers))
+	values := make([]ledger.Value, len(registers))
+	for i, register := range registers {
+		keys[i] = convert.RegisterIDToLedgerKey(register.Key)
+		values[i] = register.Value
+	}
+	update, err := ledger.NewUpdate(l.InitialState(), keys, values)
+	require.NoError(t, err)
+	state, _, err := l.Set(update)
+	require.NoError(t, err)
+
+	return l, flow.StateCommitment(state)
+}
+
+// startEngine starts the engine, and stops it when the test is done.
+func startEngine(t *testing.T, e *Engine) {
+	ctx, cancel := context.WithCancel(context.Background())
+	signalerCtx, _ := irrecoverable.WithSignaler(ctx)
+	e.Start(signalerCtx)
+	unittest.RequireCloseBefore(t, e.Ready(), time.Second, "could not start engine")
+	t.Cleanup(func() {
+		cancel()
+		unittest.RequireCloseBefore(t, e.Done(), time.Second, "could not stop engine")
+	})
+}
+
+func TestOnRegisterProofRequest(t *testing.T) {
+	owner := unittest.RandomAddressFixture()
+	registers := flow.RegisterEntries{
+		{Key: flow.AccountStatusRegisterID(owner), Value: []byte{1, 2, 3}},
+		{Key: flow.NewRegisterID(owner, "storage"), Value: []byte{4, 5}},
+	}
+	l, commit := newTestLedger(t, registers)
+	originID := unittest.IdentifierFixture()
+
+	newEngine := func(t *testing.T) (*Engine, *mocknetwork.Conduit) {
+		con := mocknetwork.NewConduit(t)
+		net := mocknetwork.NewNetwork(t)
+		net.On("Register", channels.ProvideRegisterProofs, mock.Anything).Return(con, nil)
+
+		e, err := New(unittest.Logger(), net, l, 1, DefaultRequestQueueCapacity)
+		require.NoError(t, err)
+		startEngine(t, e)
+		return e, con
+	}
+
+	t.Run("responds with proof", func(t *testing.T) {
+		e, con := newEngine(t)
+
+		request := &messages.RegisterProofRequest{
+			BlockID:         unittest.IdentifierFixture(),
+			StateCommitment: commit,
+			RegisterIDs:     []flow.RegisterID{registers[1].Key, registers[0].Key},
+			Nonce:           42,
+		}
+
+		sent := make(chan struct{})
+		con.On("Unicast", mock.Anything, originID).
+			Run(func(args mock.Arguments) {
+				defer close(sent)
+				response, ok := args[0].(*messages.RegisterProofResponse)
+				require.True(t, ok)
+				assert.Equal(t, request.BlockID, response.BlockID)
+				assert.Equal(t, request.Nonce, response.Nonce)
+
+				err := proof.VerifyRegisterValues(response.Proof, commit, flow.RegisterEntries{registers[1], registers[0]})
+				assert.NoError(t, err)
+			}).
+			Return(nil).
+			Once()
+
+		require.NoError(t, e.Process(channels.ProvideRegisterProofs, originID, request))
+		unittest.RequireCloseBefore(t, sent, time.Second, "response not sent")
+	})
+
+	t.Run("responds without proof when state is not available", func(t *testing.T) {
+		e, con := newEngine(t)
+
+		sent := make(chan struct{})
+		con.On("Unicast", mock.Anything, originID).
+			Run(func(args mock.Arguments) {
+				defer close(sent)
+				response, ok := args[0].(*messages.RegisterProofResponse)
+				require.True(t, ok)
+				assert.Empty(t, response.Proof)
+			}).
+			Return(nil).
+			Once()
+
+		err := e.Process(channels.ProvideRegisterProofs, originID, &messages.RegisterProofRequest{
+			StateCommitment: unittest.StateCommitmentFixture(),
+			RegisterIDs:     []flow.RegisterID{registers[0].Key},
+		})
+		require.NoError(t, err)
+		unittest.RequireCloseBefore(t, sent, time.Second, "response not sent")
+	})
+
+	t.Run("drops requests with invalid number of registers", func(t *testing.T) {
+		e, con := newEngine(t)
+
+		registerIDs := make([]flow.RegisterID, MaxRegistersPerRequest+1)
+		for i := range registerIDs {
+			registerIDs[i] = flow.NewRegisterID(owner, string(rune('a'+i)))
+		}
+
+		for _, ids := range [][]flow.RegisterID{nil, registerIDs} {
+			err := e.Process(channels.ProvideRegisterProofs, originID, &messages.RegisterProofRequest{
+				StateCommitment: commit,
+				RegisterIDs:     ids,
+			})
+			require.NoError(t, err)
+		}
+
+		require.Eventually(t, func() bool {
+			return e.requests.Len() == 0
+		}, time.Second, 10*time.Millisecond)
+		con.AssertNotCalled(t, "Unicast", mock.Anything, mock.Anything)
+	})
+}
+
+// TestRegisterProofRoundTrip tests requesting a register proof through the access node requester,
+// served by the engine.
+func TestRegisterProofRoundTrip(t *testing.T) {
+	owner := unittest.RandomAddressFixture()
+	registers := flow.RegisterEntries{
+		{Key: flow.NewRegisterID(owner, "storage"), Value: []byte{4, 5}},
+	}
+	l, commit := newTestLedger(t, registers)
+
+	executorID := unittest.IdentifierFixture()
+	accessID := unittest.IdentifierFixture()
+
+	var requester *accessproofs.Requester
+	var e *Engine
+
+	requesterCon := mocknetwork.NewConduit(t)
+	requesterNet := mocknetwork.NewNetwork(t)
+	requesterNet.On("Register", channels.RequestRegisterProofs, mock.Anything).Return(requesterCon, nil)
+
+	engineCon := mocknetwork.NewConduit(t)
+	engineNet := mocknetwork.NewNetwork(t)
+	engineNet.On("Register", channels.ProvideRegisterProofs, mock.Anything).Return(engineCon, nil)
+
+	requesterCon.On("Unicast", mock.Anything, executorID).
+		Run(func(args mock.Arguments) {
+			require.NoError(t, e.Process(channels.ProvideRegisterProofs, accessID, args[0]))
+		}).
+		Return(nil)
+	engineCon.On("Unicast", mock.Anything, accessID).
+		Run(func(args mock.Arguments) {
+			require.NoError(t, requester.Process(channels.RequestRegisterProofs, executorID, args[0]))
+		}).
+		Return(nil)
+
+	var err error
+	requester, err = accessproofs.New(unittest.Logger(), requesterNet)
+	require.NoError(t, err)
+	e, err = New(unittest.Logger(), engineNet, l, DefaultWorkers, DefaultRequestQueueCapacity)
+	require.NoError(t, err)
+	startEngine(t, e)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	t.Run("available state", func(t *testing.T) {
+		registerIDs := []flow.RegisterID{registers[0].Key}
+		encodedProof, err := requester.RequestRegisterProof(ctx, executorID, unittest.IdentifierFixture(), commit, registerIDs)
+		require.NoError(t, err)
+
+		values, err := proof.VerifyRegisterBatchProof(encodedProof, commit, registerIDs)
+		require.NoError(t, err)
+		assert.Equal(t, []flow.RegisterValue{registers[0].Value}, values)
+	})
+
+	t.Run("unavailable state", func(t *testing.T) {
+		_, err := requester.RequestRegisterProof(ctx, executorID, unittest.IdentifierFixture(), unittest.StateCommitmentFixture(), []flow.RegisterID{registers[0].Key})
+		require.ErrorIs(t, err, accessproofs.ErrStateNotAvailable)
+	})
+}
@@ -0,0 +1,179 @@
+package statesync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// syncRetryInterval is the time to wait before syncing the root execution state again after a failed sync.
+const syncRetryInterval = time.Minute
+
+// Bootstrapper syncs the root execution state of an execution node, which is bootstrapped without
+// the root checkpoint, from its peers. It becomes ready once the root checkpoint is in the trie
+// directory and the execution database is bootstrapped, so the components depending on the
+// execution state must be started after it.
+type Bootstrapper struct {
+	component.Component
+
+	log      zerolog.Logger
+	net      network.EngineRegistry
+	me       module.Local
+	state    protocol.State
+	config   Config
+	rootSeal *flow.Seal
+	syncDir  string
+	trieDir  string
+	fileName string
+	onSynced func() error
+}
+
+// NewBootstrapper creates a Bootstrapper, which downloads the checkpoint of the root seal's final state
+// into syncDir, moves it into trieDir as fileName, and then calls onSynced.
+func NewBootstrapper(
+	log zerolog.Logger,
+	net network.EngineRegistry,
+	me module.Local,
+	state protocol.State,
+	config Config,
+	rootSeal *flow.Seal,
+	syncDir string,
+	trieDir string,
+	fileName string,
+	onSynced func() error,
+) *Bootstrapper {
+	b := &Bootstrapper{
+		log:      log.With().Str("component", "execution_state_bootstrapper").Logger(),
+		net:      net,
+		me:       me,
+		state:    state,
+		config:   config,
+		rootSeal: rootSeal,
+		syncDir:  syncDir,
+		trieDir:  trieDir,
+		fileName: fileName,
+		onSynced: onSynced,
+	}
+
+	b.Component = component.NewComponentManagerBuilder().
+		AddWorker(b.bootstrapWorker).
+		Build()
+
+	return b
+}
+
+// bootstrapWorker syncs the root execution state until it succeeds, and then becomes ready.
+// A failed sync is retried, which resumes from the chunks downloaded so far.
+// This is a worker routine which runs until the root execution state is synced.
+func (b *Bootstrapper) bootstrapWorker(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	for {
+		err := b.sync(ctx)
+		if err == nil {
+			break
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		b.log.Warn().Err(err).Dur("retry_interval", syncRetryInterval).Msg("could not sync root execution state")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(syncRetryInterval):
+		}
+	}
+
+	err := b.onSynced()
+	if err != nil {
+		ctx.Throw(fmt.Errorf("could not bootstrap synced root execution state: %w", err))
+		return
+	}
+
+	ready()
+}
+
+// sync downloads the root checkpoint from peers, and moves it into the trie directory.
+func (b *Bootstrapper) sync(ctx irrecoverable.SignalerContext) error {
+	// the engine only syncs, so it doesn't serve the execution state
+	e, err := New(b.log, b.net, b.me, b.state, nil, nil, b.config)
+	if err != nil {
+		return fmt.Errorf("could not create execution state sync engine: %w", err)
+	}
+
+	engineCtx, cancel, errChan := irrecoverable.WithSignallerAndCancel(ctx)
+	e.Start(engineCtx)
+	go func() {
+		select {
+		case err := <-errChan:
+			ctx.Throw(err)
+		case <-e.Done():
+		}
+	}()
+
+	defer func() {
+		cancel()
+		<-e.Done()
+
+		// the channel is registered by the engine serving the execution state once bootstrapped
+		err := e.con.Close()
+		if err != nil {
+			b.log.Warn().Err(err).Msg("could not close execution state sync conduit")
+		}
+	}()
+
+	<-e.Ready()
+
+	b.log.Info().
+		Hex("block_id", logging.ID(b.rootSeal.BlockID)).
+		Hex("state_commitment", b.rootSeal.FinalState[:]).
+		Msg("syncing root execution state from execution nodes")
+
+	_, err = e.Sync(ctx, b.rootSeal.BlockID, b.rootSeal.FinalState, b.syncDir, b.fileName)
+	if err != nil {
+		return err
+	}
+
+	err = moveCheckpoint(b.syncDir, b.trieDir, b.fileName)
+	if err != nil {
+		return fmt.Errorf("could not move synced root checkpoint: %w", err)
+	}
+
+	b.log.Info().Str("trie_dir", b.trieDir).Msg("synced root execution state")
+	return nil
+}
+
+// moveCheckpoint moves the checkpoint files from one directory into another. The header file is
+// moved last, so the checkpoint is only found in the destination once all part files are there.
+func moveCheckpoint(from string, to string, fileName string) error {
+	err := os.MkdirAll(to, 0700)
+	if err != nil {
+		return fmt.Errorf("could not create dir %v: %w", to, err)
+	}
+
+	names := make([]string, 0, wal.CheckpointPartCount+1)
+	for i := 0; i < wal.CheckpointPartCount; i++ {
+		names = append(names, wal.CheckpointPartFileName(fileName, i))
+	}
+	names = append(names, fileName)
+
+	for _, name := range names {
+		err = os.Rename(filepath.Join(from, name), filepath.Join(to, name))
+		if err != nil {
+			return fmt.Errorf("could not move checkpoint file %v: %w", name, err)
+		}
+	}
+	return nil
+}
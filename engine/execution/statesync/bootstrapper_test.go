@@ -0,0 +1,96 @@
+package statesync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/metrics"
+	module "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/network/stub"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestBootstrapper tests that an execution node without the root checkpoint syncs the root execution
+// state from its peers, and that its ledger is then loaded with the root execution state.
+func TestBootstrapper(t *testing.T) {
+	n := newTestNetwork(t, 3)
+	expected := randomTrie(t)
+	n.startProvider(1, expected)
+	n.startProvider(2, expected)
+
+	commit := flow.StateCommitment(expected.RootHash())
+	blockID := n.seal(commit)
+	rootSeal := unittest.Seal.Fixture(unittest.Seal.WithBlockID(blockID))
+	rootSeal.FinalState = commit
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		syncDir := filepath.Join(dir, "state_sync")
+		trieDir := filepath.Join(dir, "execution")
+
+		nodeID := n.identities[0].NodeID
+		me := module.NewLocal(t)
+		me.On("NodeID").Return(nodeID).Maybe()
+		net := stub.NewNetwork(t, nodeID, n.hub)
+		net.StartConDev(10*time.Millisecond, true)
+		t.Cleanup(net.StopConDev)
+
+		var synced atomic.Bool
+		b := NewBootstrapper(
+			unittest.Logger(),
+			net,
+			me,
+			n.state,
+			n.config,
+			rootSeal,
+			syncDir,
+			trieDir,
+			bootstrap.FilenameWALRootCheckpoint,
+			func() error {
+				// the root checkpoint is in the trie directory when the execution database is bootstrapped
+				err := wal.CheckpointHasRootHash(unittest.Logger(), trieDir, bootstrap.FilenameWALRootCheckpoint, ledger.RootHash(commit))
+				require.NoError(t, err)
+				synced.Store(true)
+				return nil
+			},
+		)
+
+		ctx, cancel := irrecoverable.NewMockSignalerContextWithCancel(t, context.Background())
+		b.Start(ctx)
+		unittest.RequireCloseBefore(t, b.Ready(), 10*time.Second, "could not sync root execution state")
+		require.True(t, synced.Load())
+
+		cancel()
+		unittest.RequireCloseBefore(t, b.Done(), time.Second, "could not stop bootstrapper")
+
+		// no checkpoint files are left in the sync directory
+		entries, err := os.ReadDir(syncDir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+
+		// the execution state sync channel can be registered by the engine serving the execution state
+		_, err = New(unittest.Logger(), net, me, n.state, n.seals, nil, n.config)
+		require.NoError(t, err)
+
+		// the ledger of the node is loaded from the synced root checkpoint
+		diskWal, err := wal.NewDiskWAL(unittest.Logger(), nil, metrics.NewNoopCollector(), trieDir, 100, pathfinder.PathByteSize, wal.SegmentSize)
+		require.NoError(t, err)
+		led, err := complete.NewLedger(diskWal, 100, metrics.NewNoopCollector(), unittest.Logger(), complete.DefaultPathFinderVersion)
+		require.NoError(t, err)
+		unittest.RequireCloseBefore(t, led.Ready(), 10*time.Second, "could not load ledger")
+		require.True(t, led.HasState(ledger.State(commit)))
+		unittest.RequireCloseBefore(t, led.Done(), 10*time.Second, "could not stop ledger")
+	})
+}
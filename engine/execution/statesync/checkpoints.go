@@ -0,0 +1,226 @@
+package statesync
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// DefaultMaxCheckpoints is the default number of created checkpoints kept to be served.
+const DefaultMaxCheckpoints = 2
+
+// ErrCheckpointNotAvailable is returned when the execution state at the requested state
+// commitment isn't available locally.
+var ErrCheckpointNotAvailable = errors.New("checkpoint not available")
+
+// ErrCheckpointNotCreated is returned when the checkpoint of the requested state commitment
+// hasn't been created, or has been removed since.
+var ErrCheckpointNotCreated = errors.New("checkpoint not created")
+
+// Checkpoint is a checkpoint file of a single trie, which is served to peers in chunks of its part files.
+type Checkpoint struct {
+	Dir               string
+	FileName          string
+	Header            []byte                   // content of the checkpoint header file
+	PartSizes         []uint64                 // size of each part file
+	SubtrieRootHashes [][]flow.StateCommitment // hashes of the subtrie roots of each subtrie part file
+}
+
+// PartFilePath returns the path of the index-th part file of the checkpoint.
+func (c *Checkpoint) PartFilePath(index int) string {
+	return filepath.Join(c.Dir, wal.CheckpointPartFileName(c.FileName, index))
+}
+
+// Checkpoints provides the checkpoints of the execution state served to peers.
+type Checkpoints interface {
+	// Checkpoint returns the created checkpoint of the execution state at the given state commitment.
+	// It doesn't create the checkpoint, so it's cheap enough to be called when serving requests.
+	// Expected errors during normal operations:
+	//   - ErrCheckpointNotCreated if the checkpoint hasn't been created, or has been removed
+	Checkpoint(commit flow.StateCommitment) (*Checkpoint, error)
+
+	// Create creates the checkpoint of the execution state at the given state commitment, unless it
+	// has been created already. Creating a checkpoint writes the whole execution state to disk, so it
+	// must not be called when serving requests.
+	// Expected errors during normal operations:
+	//   - ErrCheckpointNotAvailable if the execution state isn't available
+	Create(commit flow.StateCommitment) (*Checkpoint, error)
+}
+
+// TrieFinder finds the trie of a state commitment, such as the ledger of the execution node.
+type TrieFinder interface {
+	// FindTrieByStateCommit returns the trie of the state commitment, or nil if there is no such trie.
+	FindTrieByStateCommit(commitment flow.StateCommitment) (*trie.MTrie, error)
+}
+
+// LedgerCheckpoints creates checkpoints from the tries of the ledger. Only the most recently
+// created checkpoints are kept, since bootstrapping execution nodes request the same recently
+// sealed state commitments. The files of older checkpoints are removed.
+type LedgerCheckpoints struct {
+	createMu sync.Mutex // only one checkpoint is created at a time
+	log      zerolog.Logger
+	tries    TrieFinder
+	dir      string
+	created  *lru.Cache[flow.StateCommitment, *Checkpoint]
+}
+
+var _ Checkpoints = (*LedgerCheckpoints)(nil)
+
+// NewLedgerCheckpoints creates LedgerCheckpoints, which stores up to maxCheckpoints checkpoints
+// in the given directory. Checkpoints created before a restart are removed.
+func NewLedgerCheckpoints(log zerolog.Logger, tries TrieFinder, dir string, maxCheckpoints uint) (*LedgerCheckpoints, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("could not create checkpoint dir %v: %w", dir, err)
+	}
+
+	stale, err := filepath.Glob(filepath.Join(dir, checkpointFilePrefix+"*"))
+	if err != nil {
+		return nil, fmt.Errorf("could not find stale checkpoints: %w", err)
+	}
+	for _, path := range stale {
+		err = os.Remove(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not remove stale checkpoint file %v: %w", path, err)
+		}
+	}
+
+	log = log.With().Str("component", "state_sync_checkpoints").Logger()
+
+	created, err := lru.NewWithEvict(int(maxCheckpoints), func(commit flow.StateCommitment, checkpoint *Checkpoint) {
+		// chunks being read from the removed files fail, and are reported as not available
+		err := removeCheckpointFiles(checkpoint.Dir, checkpoint.FileName)
+		if err != nil {
+			log.Warn().Err(err).Hex("state_commitment", commit[:]).Msg("could not remove checkpoint")
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create checkpoint cache: %w", err)
+	}
+
+	return &LedgerCheckpoints{
+		log:     log,
+		tries:   tries,
+		dir:     dir,
+		created: created,
+	}, nil
+}
+
+// Checkpoint returns the created checkpoint of the execution state at the given state commitment.
+// Expected errors during normal operations:
+//   - ErrCheckpointNotCreated if the checkpoint hasn't been created, or has been removed
+func (c *LedgerCheckpoints) Checkpoint(commit flow.StateCommitment) (*Checkpoint, error) {
+	checkpoint, ok := c.created.Get(commit)
+	if !ok {
+		return nil, ErrCheckpointNotCreated
+	}
+	return checkpoint, nil
+}
+
+// Create creates the checkpoint of the execution state at the given state commitment, unless it
+// has been created already. The least recently used checkpoint is removed if there are too many.
+// Expected errors during normal operations:
+//   - ErrCheckpointNotAvailable if the ledger doesn't have the trie of the state commitment
+func (c *LedgerCheckpoints) Create(commit flow.StateCommitment) (*Checkpoint, error) {
+	c.createMu.Lock()
+	defer c.createMu.Unlock()
+
+	checkpoint, ok := c.created.Get(commit)
+	if ok {
+		return checkpoint, nil
+	}
+
+	t, err := c.tries.FindTrieByStateCommit(commit)
+	if err != nil {
+		return nil, fmt.Errorf("could not find trie of state commitment %v: %w", commit, err)
+	}
+	if t == nil {
+		return nil, ErrCheckpointNotAvailable
+	}
+
+	fileName := checkpointFileName(commit)
+	c.log.Info().Hex("state_commitment", commit[:]).Msg("creating checkpoint for state sync")
+
+	tries := []*trie.MTrie{t}
+	err = wal.StoreCheckpointV7Concurrently(tries, c.dir, fileName, c.log)
+	if err != nil {
+		return nil, fmt.Errorf("could not store checkpoint: %w", err)
+	}
+
+	checkpoint, err = readCheckpoint(c.dir, fileName, tries)
+	if err != nil {
+		return nil, err
+	}
+
+	c.log.Info().Hex("state_commitment", commit[:]).Msg("created checkpoint for state sync")
+
+	c.created.Add(commit, checkpoint)
+	return checkpoint, nil
+}
+
+// readCheckpoint reads the header and the part file sizes of the checkpoint of the given tries.
+func readCheckpoint(dir string, fileName string, tries []*trie.MTrie) (*Checkpoint, error) {
+	header, err := os.ReadFile(filepath.Join(dir, fileName))
+	if err != nil {
+		return nil, fmt.Errorf("could not read checkpoint header: %w", err)
+	}
+
+	checkpoint := &Checkpoint{
+		Dir:       dir,
+		FileName:  fileName,
+		Header:    header,
+		PartSizes: make([]uint64, wal.CheckpointPartCount),
+	}
+
+	for i := range checkpoint.PartSizes {
+		info, err := os.Stat(checkpoint.PartFilePath(i))
+		if err != nil {
+			return nil, fmt.Errorf("could not get size of checkpoint part file: %w", err)
+		}
+		checkpoint.PartSizes[i] = uint64(info.Size())
+	}
+
+	rootHashes := wal.CheckpointSubtrieRootHashes(tries)
+	checkpoint.SubtrieRootHashes = make([][]flow.StateCommitment, len(rootHashes))
+	for i, hashes := range rootHashes {
+		checkpoint.SubtrieRootHashes[i] = make([]flow.StateCommitment, len(hashes))
+		for j, h := range hashes {
+			checkpoint.SubtrieRootHashes[i][j] = flow.StateCommitment(h)
+		}
+	}
+
+	return checkpoint, nil
+}
+
+// removeCheckpointFiles removes the header and part files of the checkpoint, if they exist.
+func removeCheckpointFiles(dir string, fileName string) error {
+	paths := []string{filepath.Join(dir, fileName)}
+	for i := 0; i < wal.CheckpointPartCount; i++ {
+		paths = append(paths, filepath.Join(dir, wal.CheckpointPartFileName(fileName, i)))
+	}
+
+	for _, p := range paths {
+		err := os.Remove(p)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("could not remove checkpoint file %v: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// checkpointFilePrefix is the prefix of the files of the created checkpoints.
+const checkpointFilePrefix = "state-sync-"
+
+// checkpointFileName returns the file name of the checkpoint of the state commitment.
+func checkpointFileName(commit flow.StateCommitment) string {
+	return fmt.Sprintf("%s%x", checkpointFilePrefix, commit[:])
+}
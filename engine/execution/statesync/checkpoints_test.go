@@ -0,0 +1,64 @@
+package statesync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestLedgerCheckpoints(t *testing.T) {
+	t1 := randomTrie(t)
+	t2 := randomTrie(t)
+	commit1 := flow.StateCommitment(t1.RootHash())
+	commit2 := flow.StateCommitment(t2.RootHash())
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		// checkpoints created before a restart are removed
+		stale := filepath.Join(dir, checkpointFileName(commit1))
+		require.NoError(t, os.WriteFile(stale, []byte("stale"), 0600))
+
+		checkpoints, err := NewLedgerCheckpoints(unittest.Logger(), tries{t1, t2}, dir, 1)
+		require.NoError(t, err)
+
+		_, err = os.Stat(stale)
+		require.ErrorIs(t, err, os.ErrNotExist)
+
+		// checkpoints are only served once created
+		_, err = checkpoints.Checkpoint(commit1)
+		require.ErrorIs(t, err, ErrCheckpointNotCreated)
+
+		_, err = checkpoints.Create(unittest.StateCommitmentFixture())
+		require.ErrorIs(t, err, ErrCheckpointNotAvailable)
+
+		checkpoint1, err := checkpoints.Create(commit1)
+		require.NoError(t, err)
+
+		served, err := checkpoints.Checkpoint(commit1)
+		require.NoError(t, err)
+		require.Equal(t, checkpoint1, served)
+
+		// creating the checkpoint again returns the created checkpoint
+		created, err := checkpoints.Create(commit1)
+		require.NoError(t, err)
+		require.Same(t, checkpoint1, created)
+
+		// the files of the least recently used checkpoint are removed
+		_, err = checkpoints.Create(commit2)
+		require.NoError(t, err)
+
+		_, err = checkpoints.Checkpoint(commit1)
+		require.ErrorIs(t, err, ErrCheckpointNotCreated)
+		for i := range checkpoint1.PartSizes {
+			_, err = os.Stat(checkpoint1.PartFilePath(i))
+			require.ErrorIs(t, err, os.ErrNotExist)
+		}
+
+		_, err = checkpoints.Checkpoint(commit2)
+		require.NoError(t, err)
+	})
+}
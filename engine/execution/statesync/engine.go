@@ -0,0 +1,603 @@
+package statesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/common/fifoqueue"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/channels"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/logging"
+	"github.com/onflow/flow-go/utils/rand"
+)
+
+const (
+	// DefaultChunkSize is the default max size of a chunk of a checkpoint part file, which
+	// must be well below the max size of unicast messages.
+	DefaultChunkSize = 4 << 20 // 4 MB
+	// DefaultRequestTimeout is the default time to wait for a response before requesting from another peer.
+	DefaultRequestTimeout = 30 * time.Second
+	// DefaultMaxInflightChunks is the default max number of chunks requested at the same time when syncing.
+	DefaultMaxInflightChunks = 8
+	// DefaultRequestRateLimit is the default max number of requests per second sent when syncing.
+	DefaultRequestRateLimit = 20
+	// DefaultServeRateLimit is the default max number of bytes per second served to a single peer.
+	DefaultServeRateLimit = 64 << 20 // 64 MB/s
+	// DefaultServeWorkers is the default number of workers serving requests of peers.
+	DefaultServeWorkers = 4
+	// DefaultRequestQueueCapacity is the default max number of queued requests of peers.
+	DefaultRequestQueueCapacity = 100
+	// DefaultBuildInterval is the default min interval between checkpoints created on behalf of a single peer.
+	DefaultBuildInterval = time.Hour
+	// DefaultBuildQueueCapacity is the default max number of checkpoints waiting to be created.
+	DefaultBuildQueueCapacity = 2
+	// DefaultManifestRetryInterval is the default time to wait before requesting the manifest again
+	// from peers which are creating the checkpoint.
+	DefaultManifestRetryInterval = 30 * time.Second
+)
+
+// maxServeDelay is the max time a request of a peer waits for the rate limit before it's dropped.
+const maxServeDelay = time.Second
+
+// limiterPruneInterval is the interval at which the rate limiters of peers which left the identity
+// table are removed.
+const limiterPruneInterval = 10 * time.Minute
+
+// manifestResponseSize is an upper bound of the size of a manifest response, which is what a
+// manifest request counts towards the rate limit of the peer.
+const manifestResponseSize = 16 << 10 // 16 KB
+
+// Config configures the execution state sync engine.
+type Config struct {
+	// ChunkSize is the max size of a chunk of a checkpoint part file.
+	ChunkSize uint64
+	// RequestTimeout is the time to wait for a response before requesting from another peer.
+	RequestTimeout time.Duration
+	// MaxInflightChunks is the max number of chunks requested at the same time when syncing.
+	MaxInflightChunks uint
+	// RequestRateLimit is the max number of requests per second sent when syncing.
+	RequestRateLimit rate.Limit
+	// ServeRateLimit is the max number of bytes per second served to a single peer.
+	ServeRateLimit rate.Limit
+	// ServeWorkers is the number of workers serving requests of peers.
+	ServeWorkers uint
+	// RequestQueueCapacity is the max number of queued requests of peers.
+	RequestQueueCapacity uint
+	// BuildRateLimit is the max number of checkpoints per second created on behalf of a single peer.
+	BuildRateLimit rate.Limit
+	// BuildQueueCapacity is the max number of checkpoints waiting to be created.
+	BuildQueueCapacity uint
+	// ManifestRetryInterval is the time to wait before requesting the manifest again from peers
+	// which are creating the checkpoint.
+	ManifestRetryInterval time.Duration
+}
+
+// DefaultConfig returns the default config of the execution state sync engine.
+func DefaultConfig() Config {
+	return Config{
+		ChunkSize:             DefaultChunkSize,
+		RequestTimeout:        DefaultRequestTimeout,
+		MaxInflightChunks:     DefaultMaxInflightChunks,
+		RequestRateLimit:      DefaultRequestRateLimit,
+		ServeRateLimit:        DefaultServeRateLimit,
+		ServeWorkers:          DefaultServeWorkers,
+		RequestQueueCapacity:  DefaultRequestQueueCapacity,
+		BuildRateLimit:        rate.Every(DefaultBuildInterval),
+		BuildQueueCapacity:    DefaultBuildQueueCapacity,
+		ManifestRetryInterval: DefaultManifestRetryInterval,
+	}
+}
+
+// Engine implements the execution state sync protocol between execution nodes.
+// It serves the checkpoints of the execution state to peers, and it syncs the execution
+// state of a bootstrapping execution node from its peers, see Sync.
+// Checkpoints are transferred in chunks of their part files, which are verified against
+// the trie root hashes before the trie is assembled.
+//
+// Only the execution state of sealed blocks is served. Checkpoints are created by a single
+// worker, since creating a checkpoint writes the whole execution state to disk. A peer
+// requesting a checkpoint which isn't created yet is told to request it again later, and
+// each peer can only have a checkpoint created once per build interval. Chunks are only
+// served from created checkpoints.
+type Engine struct {
+	component.Component
+	cm *component.ComponentManager
+
+	log         zerolog.Logger
+	me          module.Local
+	state       protocol.State
+	seals       storage.Seals
+	con         network.Conduit
+	config      Config
+	checkpoints Checkpoints
+
+	// requests of peers, served by workers
+	requests *fifoqueue.FifoQueue
+	notifier engine.Notifier
+
+	// limiters of the bytes served to each peer, and of the checkpoints created for each peer
+	limitersMu    sync.Mutex
+	limiters      map[flow.Identifier]*rate.Limiter
+	buildLimiters map[flow.Identifier]*rate.Limiter
+
+	// state commitments of the checkpoints to create, and of those queued or being created
+	builds   chan flow.StateCommitment
+	buildsMu sync.Mutex
+	building map[flow.StateCommitment]struct{}
+
+	// requestLimiter limits the requests sent when syncing
+	requestLimiter *rate.Limiter
+
+	// session is the ongoing sync, which receives the responses of peers
+	sessionMu sync.RWMutex
+	session   *session
+}
+
+var _ network.MessageProcessor = (*Engine)(nil)
+var _ component.Component = (*Engine)(nil)
+
+// New creates a new execution state sync engine. The checkpoints of sealed execution states
+// are served to peers, and can be nil if the node doesn't serve its execution state.
+func New(
+	log zerolog.Logger,
+	net network.EngineRegistry,
+	me module.Local,
+	state protocol.State,
+	seals storage.Seals,
+	checkpoints Checkpoints,
+	config Config,
+) (*Engine, error) {
+	if config.ChunkSize == 0 {
+		return nil, fmt.Errorf("chunk size must be positive")
+	}
+
+	requests, err := fifoqueue.NewFifoQueue(int(config.RequestQueueCapacity))
+	if err != nil {
+		return nil, fmt.Errorf("could not create request queue: %w", err)
+	}
+
+	e := &Engine{
+		log:            log.With().Str("engine", "execution_state_sync").Logger(),
+		me:             me,
+		state:          state,
+		seals:          seals,
+		config:         config,
+		checkpoints:    checkpoints,
+		requests:       requests,
+		notifier:       engine.NewNotifier(),
+		limiters:       make(map[flow.Identifier]*rate.Limiter),
+		buildLimiters:  make(map[flow.Identifier]*rate.Limiter),
+		builds:         make(chan flow.StateCommitment, config.BuildQueueCapacity),
+		building:       make(map[flow.StateCommitment]struct{}),
+		requestLimiter: rate.NewLimiter(config.RequestRateLimit, 1),
+	}
+
+	con, err := net.Register(channels.RequestExecutionState, e)
+	if err != nil {
+		return nil, fmt.Errorf("could not register execution state sync engine: %w", err)
+	}
+	e.con = con
+
+	cm := component.NewComponentManagerBuilder()
+	for i := uint(0); i < config.ServeWorkers; i++ {
+		cm.AddWorker(e.serveRequestsWorker)
+	}
+	if checkpoints != nil {
+		cm.AddWorker(e.createCheckpointsWorker)
+		cm.AddWorker(e.pruneLimitersWorker)
+	}
+	e.cm = cm.Build()
+	e.Component = e.cm
+
+	return e, nil
+}
+
+// Process processes messages from the networking layer.
+// No errors are expected during normal operation.
+func (e *Engine) Process(channel channels.Channel, originID flow.Identifier, message any) error {
+	switch msg := message.(type) {
+	case *messages.ExecutionStateManifestRequest, *messages.ExecutionStateChunkRequest:
+		if e.checkpoints == nil {
+			return nil
+		}
+		if !e.requests.Push(&engine.Message{OriginID: originID, Payload: msg}) {
+			e.log.Warn().
+				Hex("origin_id", logging.ID(originID)).
+				Msgf("dropped %T, because request queue is full", msg)
+			return nil
+		}
+		e.notifier.Notify()
+	case *messages.ExecutionStateManifestResponse, *messages.ExecutionStateChunkResponse:
+		e.sessionMu.RLock()
+		s := e.session
+		e.sessionMu.RUnlock()
+		if s == nil {
+			e.log.Debug().
+				Hex("origin_id", logging.ID(originID)).
+				Msgf("dropped %T, because there is no ongoing sync", msg)
+			return nil
+		}
+		s.deliver(originID, msg)
+	default:
+		e.log.Warn().
+			Bool(logging.KeySuspicious, true).
+			Msgf("%v delivered unsupported message %T through %v", originID, message, channel)
+	}
+	return nil
+}
+
+// serveRequestsWorker serves the queued requests of peers.
+// This is a worker routine which runs for the lifetime of the engine.
+func (e *Engine) serveRequestsWorker(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	done := ctx.Done()
+	wake := e.notifier.Channel()
+	for {
+		select {
+		case <-done:
+			return
+		case <-wake:
+			e.serveRequestsWhileAvailable(ctx)
+		}
+	}
+}
+
+// serveRequestsWhileAvailable serves queued requests until the queue is empty.
+func (e *Engine) serveRequestsWhileAvailable(ctx irrecoverable.SignalerContext) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		next, ok := e.requests.Pop()
+		if !ok {
+			return
+		}
+		msg := next.(*engine.Message)
+
+		var err error
+		switch request := msg.Payload.(type) {
+		case *messages.ExecutionStateManifestRequest:
+			err = e.onManifestRequest(ctx, msg.OriginID, request)
+		case *messages.ExecutionStateChunkRequest:
+			err = e.onChunkRequest(ctx, msg.OriginID, request)
+		}
+		if err != nil {
+			ctx.Throw(err)
+		}
+	}
+}
+
+// onManifestRequest responds with the manifest of the requested checkpoint, if the state commitment
+// is sealed and the checkpoint is created. Otherwise, the checkpoint is queued to be created.
+// No errors are expected during normal operation.
+func (e *Engine) onManifestRequest(ctx context.Context, originID flow.Identifier, request *messages.ExecutionStateManifestRequest) error {
+	lg := e.log.With().
+		Hex("origin_id", logging.ID(originID)).
+		Hex("block_id", logging.ID(request.BlockID)).
+		Hex("state_commitment", request.StateCommitment[:]).
+		Logger()
+
+	if !e.wait(ctx, originID, manifestResponseSize) {
+		lg.Warn().Msg("dropped manifest request, because of rate limit")
+		return nil
+	}
+
+	response := &messages.ExecutionStateManifestResponse{
+		StateCommitment: request.StateCommitment,
+	}
+
+	sealed, err := e.isSealed(request.BlockID, request.StateCommitment)
+	if err != nil {
+		return err
+	}
+
+	if !sealed {
+		lg.Info().Msg("requested execution state is not sealed")
+	} else {
+		checkpoint, err := e.checkpoints.Checkpoint(request.StateCommitment)
+		if err == nil {
+			response.Available = true
+			response.Header = checkpoint.Header
+			response.PartSizes = checkpoint.PartSizes
+			response.SubtrieRootHashes = checkpoint.SubtrieRootHashes
+		} else {
+			if !errors.Is(err, ErrCheckpointNotCreated) {
+				return fmt.Errorf("could not get checkpoint: %w", err)
+			}
+			response.Pending = e.requestBuild(lg, originID, request.StateCommitment)
+		}
+	}
+
+	nonce, err := rand.Uint64()
+	if err != nil {
+		return fmt.Errorf("could not generate nonce: %w", err)
+	}
+	response.Nonce = nonce
+
+	err = e.con.Unicast(response, originID)
+	if err != nil {
+		lg.Warn().Err(err).Msg("could not send manifest response")
+		return nil
+	}
+
+	lg.Info().
+		Bool("available", response.Available).
+		Bool("pending", response.Pending).
+		Msg("sent manifest response")
+	return nil
+}
+
+// isSealed returns true if the state commitment is the final state of the sealed execution result
+// of the block.
+// No errors are expected during normal operation.
+func (e *Engine) isSealed(blockID flow.Identifier, commit flow.StateCommitment) (bool, error) {
+	seal, err := e.seals.FinalizedSealForBlock(blockID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("could not get seal of block %v: %w", blockID, err)
+	}
+	return seal.FinalState == commit, nil
+}
+
+// requestBuild queues the checkpoint of the state commitment to be created on behalf of the peer,
+// and returns true if the checkpoint is queued or being created. The checkpoint isn't queued if the
+// peer exceeds its build rate limit, or if too many checkpoints are queued already.
+func (e *Engine) requestBuild(lg zerolog.Logger, originID flow.Identifier, commit flow.StateCommitment) bool {
+	e.buildsMu.Lock()
+	defer e.buildsMu.Unlock()
+
+	if _, ok := e.building[commit]; ok {
+		return true
+	}
+
+	// only requestBuild sends to the channel, so it can't become full meanwhile
+	if len(e.builds) == cap(e.builds) {
+		lg.Warn().Msg("not creating checkpoint, because too many checkpoints are queued")
+		return false
+	}
+
+	if !e.buildLimiter(originID).Allow() {
+		lg.Warn().Msg("not creating checkpoint, because of build rate limit")
+		return false
+	}
+
+	e.building[commit] = struct{}{}
+	e.builds <- commit
+	return true
+}
+
+// createCheckpointsWorker creates the queued checkpoints one at a time. Creating a checkpoint
+// can't be interrupted, so shutting down waits for the checkpoint being created.
+// This is a worker routine which runs for the lifetime of the engine.
+func (e *Engine) createCheckpointsWorker(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case commit := <-e.builds:
+			_, err := e.checkpoints.Create(commit)
+
+			e.buildsMu.Lock()
+			delete(e.building, commit)
+			e.buildsMu.Unlock()
+
+			if err != nil {
+				if !errors.Is(err, ErrCheckpointNotAvailable) {
+					ctx.Throw(fmt.Errorf("could not create checkpoint: %w", err))
+					return
+				}
+				e.log.Info().Hex("state_commitment", commit[:]).Msg("execution state of checkpoint is not available")
+			}
+		}
+	}
+}
+
+// onChunkRequest responds with the requested chunk of a checkpoint part file.
+// No errors are expected during normal operation.
+func (e *Engine) onChunkRequest(ctx context.Context, originID flow.Identifier, request *messages.ExecutionStateChunkRequest) error {
+	lg := e.log.With().
+		Hex("origin_id", logging.ID(originID)).
+		Hex("state_commitment", request.StateCommitment[:]).
+		Uint32("part_index", request.PartIndex).
+		Uint64("offset", request.Offset).
+		Logger()
+
+	if request.PartIndex >= wal.CheckpointPartCount {
+		lg.Warn().Bool(logging.KeySuspicious, true).Msg("invalid part index in chunk request")
+		return nil
+	}
+
+	response := &messages.ExecutionStateChunkResponse{
+		StateCommitment: request.StateCommitment,
+		PartIndex:       request.PartIndex,
+		Offset:          request.Offset,
+	}
+
+	// chunks are only served from created checkpoints, which are all sealed
+	checkpoint, err := e.checkpoints.Checkpoint(request.StateCommitment)
+	if err != nil {
+		if !errors.Is(err, ErrCheckpointNotCreated) {
+			return fmt.Errorf("could not get checkpoint: %w", err)
+		}
+		lg.Info().Msg("requested execution state is not available")
+	} else {
+		partSize := checkpoint.PartSizes[request.PartIndex]
+		if request.Offset >= partSize {
+			lg.Warn().Bool(logging.KeySuspicious, true).Msg("invalid offset in chunk request")
+			return nil
+		}
+
+		size := partSize - request.Offset
+		if size > e.config.ChunkSize {
+			size = e.config.ChunkSize
+		}
+
+		if !e.wait(ctx, originID, int(size)) {
+			lg.Warn().Msg("dropped chunk request, because of rate limit")
+			return nil
+		}
+
+		response.Data, err = readChunk(checkpoint.PartFilePath(int(request.PartIndex)), request.Offset, size)
+		if err != nil {
+			// the checkpoint might have been removed meanwhile, or its files might be damaged, in
+			// which case the peer requests the chunk from other sources
+			lg.Warn().Err(err).Msg("could not read chunk, requested execution state is not available")
+			response.Data = nil
+		}
+	}
+
+	nonce, err := rand.Uint64()
+	if err != nil {
+		return fmt.Errorf("could not generate nonce: %w", err)
+	}
+	response.Nonce = nonce
+
+	err = e.con.Unicast(response, originID)
+	if err != nil {
+		lg.Warn().Err(err).Msg("could not send chunk response")
+		return nil
+	}
+
+	lg.Debug().Int("size", len(response.Data)).Msg("sent chunk response")
+	return nil
+}
+
+// pruneLimitersWorker periodically removes the rate limiters of peers which left the identity table.
+// This is a worker routine which runs for the lifetime of the engine.
+func (e *Engine) pruneLimitersWorker(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	ticker := time.NewTicker(limiterPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := e.pruneLimiters()
+			if err != nil {
+				ctx.Throw(err)
+				return
+			}
+		}
+	}
+}
+
+// pruneLimiters removes the rate limiters of peers which are not in the identity table anymore.
+// The limiters of peers in the identity table are kept, so a peer can't reset its limits.
+// No errors are expected during normal operation.
+func (e *Engine) pruneLimiters() error {
+	identities, err := e.state.Final().Identities(filter.Any)
+	if err != nil {
+		return fmt.Errorf("could not get identities: %w", err)
+	}
+	lookup := identities.Lookup()
+
+	e.limitersMu.Lock()
+	defer e.limitersMu.Unlock()
+
+	for peer := range e.limiters {
+		if _, ok := lookup[peer]; !ok {
+			delete(e.limiters, peer)
+		}
+	}
+	for peer := range e.buildLimiters {
+		if _, ok := lookup[peer]; !ok {
+			delete(e.buildLimiters, peer)
+		}
+	}
+	return nil
+}
+
+// wait waits until the bytes can be served to the peer without exceeding the rate limit.
+// It returns false without waiting if the rate limit isn't met within maxServeDelay, or
+// if the context is canceled.
+func (e *Engine) wait(ctx context.Context, originID flow.Identifier, bytes int) bool {
+	e.limitersMu.Lock()
+	limiter, ok := e.limiters[originID]
+	if !ok {
+		// the burst allows serving a full chunk, or a manifest, at once
+		limiter = rate.NewLimiter(e.config.ServeRateLimit, max(int(e.config.ChunkSize), manifestResponseSize))
+		e.limiters[originID] = limiter
+	}
+	e.limitersMu.Unlock()
+
+	reservation := limiter.ReserveN(time.Now(), bytes)
+	if !reservation.OK() {
+		return false
+	}
+
+	delay := reservation.Delay()
+	if delay > maxServeDelay {
+		reservation.Cancel()
+		return false
+	}
+	if delay == 0 {
+		return true
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		reservation.Cancel()
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// buildLimiter returns the limiter of the checkpoints created on behalf of the peer.
+func (e *Engine) buildLimiter(originID flow.Identifier) *rate.Limiter {
+	e.limitersMu.Lock()
+	defer e.limitersMu.Unlock()
+
+	limiter, ok := e.buildLimiters[originID]
+	if !ok {
+		limiter = rate.NewLimiter(e.config.BuildRateLimit, 1)
+		e.buildLimiters[originID] = limiter
+	}
+	return limiter
+}
+
+// readChunk reads size bytes at the offset of the file.
+func readChunk(path string, offset uint64, size uint64) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open file %v: %w", path, err)
+	}
+	defer file.Close()
+
+	data := make([]byte, size)
+	_, err = file.ReadAt(data, int64(offset))
+	if err != nil {
+		return nil, fmt.Errorf("could not read file %v: %w", path, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,510 @@
+package statesync
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/testutils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	module "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/network/stub"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// tries implements TrieFinder with the given tries.
+type tries []*trie.MTrie
+
+func (ts tries) FindTrieByStateCommit(commit flow.StateCommitment) (*trie.MTrie, error) {
+	for _, t := range ts {
+		if flow.StateCommitment(t.RootHash()) == commit {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+// countingCheckpoints counts the requests served by the checkpoints, and the checkpoints created.
+type countingCheckpoints struct {
+	Checkpoints
+	mu      sync.Mutex
+	count   int
+	creates int
+}
+
+func (c *countingCheckpoints) Checkpoint(commit flow.StateCommitment) (*Checkpoint, error) {
+	c.mu.Lock()
+	c.count++
+	c.mu.Unlock()
+	return c.Checkpoints.Checkpoint(commit)
+}
+
+func (c *countingCheckpoints) Create(commit flow.StateCommitment) (*Checkpoint, error) {
+	c.mu.Lock()
+	c.creates++
+	c.mu.Unlock()
+	return c.Checkpoints.Create(commit)
+}
+
+func (c *countingCheckpoints) requests() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.count
+}
+
+func (c *countingCheckpoints) created() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.creates
+}
+
+// blockingCheckpoints blocks creating checkpoints until unblocked.
+type blockingCheckpoints struct {
+	started chan flow.StateCommitment
+	unblock chan struct{}
+}
+
+func (c *blockingCheckpoints) Checkpoint(flow.StateCommitment) (*Checkpoint, error) {
+	return nil, ErrCheckpointNotCreated
+}
+
+func (c *blockingCheckpoints) Create(commit flow.StateCommitment) (*Checkpoint, error) {
+	c.started <- commit
+	<-c.unblock
+	return nil, ErrCheckpointNotAvailable
+}
+
+// forgedCheckpoints serves the checkpoint of a different execution state for any state commitment.
+type forgedCheckpoints struct {
+	Checkpoints
+	commit flow.StateCommitment
+}
+
+func (c *forgedCheckpoints) Checkpoint(flow.StateCommitment) (*Checkpoint, error) {
+	return c.Checkpoints.Checkpoint(c.commit)
+}
+
+func (c *forgedCheckpoints) Create(flow.StateCommitment) (*Checkpoint, error) {
+	return c.Checkpoints.Create(c.commit)
+}
+
+// testNetwork is a set of execution nodes connected by the stub network.
+type testNetwork struct {
+	t          *testing.T
+	hub        *stub.Hub
+	identities flow.IdentityList
+	state      *protocol.State
+	seals      *storagemock.Seals
+	config     Config
+
+	sealedMu sync.Mutex
+	sealed   map[flow.Identifier]flow.StateCommitment
+}
+
+func newTestNetwork(t *testing.T, nodes int) *testNetwork {
+	identities := unittest.IdentityListFixture(nodes, unittest.WithRole(flow.RoleExecution))
+
+	snapshot := protocol.NewSnapshot(t)
+	snapshot.On("Identities", mock.Anything).Return(
+		func(selector flow.IdentityFilter[flow.Identity]) flow.IdentityList {
+			return identities.Filter(selector)
+		},
+		nil,
+	).Maybe()
+	state := protocol.NewState(t)
+	state.On("Final").Return(snapshot).Maybe()
+
+	config := DefaultConfig()
+	config.ChunkSize = 4 << 10
+	config.RequestTimeout = time.Second
+	config.RequestRateLimit = 1000
+	config.ManifestRetryInterval = 10 * time.Millisecond
+
+	n := &testNetwork{
+		t:          t,
+		hub:        stub.NewNetworkHub(),
+		identities: identities,
+		state:      state,
+		seals:      storagemock.NewSeals(t),
+		config:     config,
+		sealed:     make(map[flow.Identifier]flow.StateCommitment),
+	}
+
+	n.seals.On("FinalizedSealForBlock", mock.Anything).Return(
+		func(blockID flow.Identifier) (*flow.Seal, error) {
+			n.sealedMu.Lock()
+			defer n.sealedMu.Unlock()
+			commit, ok := n.sealed[blockID]
+			if !ok {
+				return nil, storage.ErrNotFound
+			}
+			seal := unittest.Seal.Fixture(unittest.Seal.WithBlockID(blockID))
+			seal.FinalState = commit
+			return seal, nil
+		},
+	).Maybe()
+
+	return n
+}
+
+// seal seals the state commitment for a new block, and returns the ID of the block.
+func (n *testNetwork) seal(commit flow.StateCommitment) flow.Identifier {
+	n.sealedMu.Lock()
+	defer n.sealedMu.Unlock()
+	blockID := unittest.IdentifierFixture()
+	n.sealed[blockID] = commit
+	return blockID
+}
+
+// start starts the engine of the index-th node, which serves the given checkpoints.
+func (n *testNetwork) start(index int, checkpoints Checkpoints) *Engine {
+	nodeID := n.identities[index].NodeID
+
+	me := module.NewLocal(n.t)
+	me.On("NodeID").Return(nodeID).Maybe()
+
+	net := stub.NewNetwork(n.t, nodeID, n.hub)
+	net.StartConDev(10*time.Millisecond, true)
+	n.t.Cleanup(net.StopConDev)
+
+	e, err := New(unittest.Logger(), net, me, n.state, n.seals, checkpoints, n.config)
+	require.NoError(n.t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	signalerCtx, _ := irrecoverable.WithSignaler(ctx)
+	e.Start(signalerCtx)
+	unittest.RequireCloseBefore(n.t, e.Ready(), time.Second, "could not start engine")
+	n.t.Cleanup(func() {
+		cancel()
+		unittest.RequireCloseBefore(n.t, e.Done(), time.Second, "could not stop engine")
+	})
+
+	return e
+}
+
+// startProvider starts the engine of the index-th node, which serves the checkpoints of the given tries.
+func (n *testNetwork) startProvider(index int, ts ...*trie.MTrie) (*Engine, *countingCheckpoints) {
+	checkpoints, err := NewLedgerCheckpoints(unittest.Logger(), tries(ts), unittest.TempDir(n.t), DefaultMaxCheckpoints)
+	require.NoError(n.t, err)
+
+	counting := &countingCheckpoints{Checkpoints: checkpoints}
+	return n.start(index, counting), counting
+}
+
+// randomTrie returns a trie which is deep enough to have all subtrie part files of a checkpoint.
+func randomTrie(t *testing.T) *trie.MTrie {
+	paths := testutils.RandomPaths(1000)
+	payloads := make([]ledger.Payload, len(paths))
+	for i, payload := range testutils.RandomPayloads(len(paths), 10, 100) {
+		payloads[i] = *payload
+	}
+	ts, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths, payloads, false)
+	require.NoError(t, err)
+	return ts
+}
+
+func TestSync(t *testing.T) {
+	n := newTestNetwork(t, 3)
+	requester := n.start(0, nil)
+	expected := randomTrie(t)
+	_, provider1 := n.startProvider(1, expected)
+	_, provider2 := n.startProvider(2, expected)
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		commit := flow.StateCommitment(expected.RootHash())
+		blockID := n.seal(commit)
+
+		// the providers create the checkpoint when it's first requested
+		synced, err := requester.Sync(context.Background(), blockID, commit, dir, "checkpoint")
+		require.NoError(t, err)
+		require.Equal(t, expected.RootHash(), synced.RootHash())
+		require.Equal(t, expected.AllocatedRegCount(), synced.AllocatedRegCount())
+		require.Equal(t, expected.AllocatedRegSize(), synced.AllocatedRegSize())
+
+		// the chunks are requested from both providers
+		require.Greater(t, provider1.requests(), 1)
+		require.Greater(t, provider2.requests(), 1)
+		require.Equal(t, 1, provider1.created())
+		require.Equal(t, 1, provider2.created())
+	})
+}
+
+func TestSyncResume(t *testing.T) {
+	n := newTestNetwork(t, 2)
+	requester := n.start(0, nil)
+	expected := randomTrie(t)
+	_, provider := n.startProvider(1, expected)
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		commit := flow.StateCommitment(expected.RootHash())
+		blockID := n.seal(commit)
+		_, err := requester.Sync(context.Background(), blockID, commit, dir, "checkpoint")
+		require.NoError(t, err)
+		requests := provider.requests()
+
+		// a partially downloaded part file, and a missing part file
+		partPath := func(index int) string {
+			return dir + "/" + wal.CheckpointPartFileName("checkpoint", index)
+		}
+		info, err := os.Stat(partPath(0))
+		require.NoError(t, err)
+		require.NoError(t, os.Truncate(partPath(0), info.Size()/2))
+		require.NoError(t, os.Remove(partPath(wal.CheckpointPartCount-1)))
+
+		synced, err := requester.Sync(context.Background(), blockID, commit, dir, "checkpoint")
+		require.NoError(t, err)
+		require.Equal(t, expected.RootHash(), synced.RootHash())
+
+		// only the missing chunks are requested, in addition to the manifest
+		require.Less(t, provider.requests()-requests, requests/2)
+
+		// the checkpoint created for the first sync is served again
+		require.Equal(t, 1, provider.created())
+	})
+}
+
+func TestSyncWithFaultyProvider(t *testing.T) {
+	n := newTestNetwork(t, 3)
+	requester := n.start(0, nil)
+	expected := randomTrie(t)
+	_, honest := n.startProvider(1, expected)
+	_, faulty := n.startProvider(2, expected)
+
+	commit := flow.StateCommitment(expected.RootHash())
+	blockID := n.seal(commit)
+
+	// both providers have created the checkpoint, so both are sources from the start
+	_, err := honest.Create(commit)
+	require.NoError(t, err)
+
+	// the faulty provider serves a checkpoint with a corrupted subtrie part file
+	checkpoint, err := faulty.Create(commit)
+	require.NoError(t, err)
+	data, err := os.ReadFile(checkpoint.PartFilePath(0))
+	require.NoError(t, err)
+	data[len(data)/2] ^= 0xff
+	require.NoError(t, os.WriteFile(checkpoint.PartFilePath(0), data, 0600))
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		synced, err := requester.Sync(context.Background(), blockID, commit, dir, "checkpoint")
+		require.NoError(t, err)
+		require.Equal(t, expected.RootHash(), synced.RootHash())
+	})
+}
+
+func TestSyncWithForgedManifest(t *testing.T) {
+	n := newTestNetwork(t, 4)
+	requester := n.start(0, nil)
+	expected := randomTrie(t)
+	_, honest := n.startProvider(1, expected)
+
+	commit := flow.StateCommitment(expected.RootHash())
+	blockID := n.seal(commit)
+	_, err := honest.Create(commit)
+	require.NoError(t, err)
+
+	// the faulty providers serve the checkpoint of a different execution state, which is
+	// consistent with the subtrie root hashes of their manifest
+	other := randomTrie(t)
+	checkpoints, err := NewLedgerCheckpoints(unittest.Logger(), tries{other}, unittest.TempDir(t), DefaultMaxCheckpoints)
+	require.NoError(t, err)
+	forged := &forgedCheckpoints{Checkpoints: checkpoints, commit: flow.StateCommitment(other.RootHash())}
+	_, err = forged.Create(commit)
+	require.NoError(t, err)
+	n.start(2, forged)
+	n.start(3, forged)
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		// the forged manifest is synced first, since more peers agree on it, but its top level
+		// trie is invalid, so the checkpoint of the honest manifest is synced then
+		synced, err := requester.Sync(context.Background(), blockID, commit, dir, "checkpoint")
+		require.NoError(t, err)
+		require.Equal(t, expected.RootHash(), synced.RootHash())
+	})
+}
+
+func TestSyncWithUnavailableState(t *testing.T) {
+	n := newTestNetwork(t, 3)
+	requester := n.start(0, nil)
+	n.startProvider(1, randomTrie(t))
+	n.startProvider(2)
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		// the sealed execution state isn't available to any provider
+		commit := unittest.StateCommitmentFixture()
+		blockID := n.seal(commit)
+		_, err := requester.Sync(context.Background(), blockID, commit, dir, "checkpoint")
+		require.ErrorContains(t, err, "no peer has the checkpoint")
+	})
+}
+
+func TestSyncWithUnsealedState(t *testing.T) {
+	n := newTestNetwork(t, 2)
+	requester := n.start(0, nil)
+	expected := randomTrie(t)
+	_, provider := n.startProvider(1, expected)
+
+	commit := flow.StateCommitment(expected.RootHash())
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		// the block isn't sealed
+		_, err := requester.Sync(context.Background(), unittest.IdentifierFixture(), commit, dir, "checkpoint")
+		require.ErrorContains(t, err, "no peer has the checkpoint")
+
+		// a different state commitment is sealed for the block
+		blockID := n.seal(unittest.StateCommitmentFixture())
+		_, err = requester.Sync(context.Background(), blockID, commit, dir, "checkpoint")
+		require.ErrorContains(t, err, "no peer has the checkpoint")
+
+		// no checkpoint is created for unsealed execution state
+		require.Zero(t, provider.created())
+	})
+}
+
+func TestSyncWithInvalidTopLevelTrie(t *testing.T) {
+	n := newTestNetwork(t, 2)
+	requester := n.start(0, nil)
+	expected := randomTrie(t)
+	_, provider := n.startProvider(1, expected)
+
+	commit := flow.StateCommitment(expected.RootHash())
+	blockID := n.seal(commit)
+
+	// the top level trie part file isn't verified until the checkpoint is assembled
+	checkpoint, err := provider.Create(commit)
+	require.NoError(t, err)
+	topPartPath := checkpoint.PartFilePath(wal.CheckpointPartCount - 1)
+	data, err := os.ReadFile(topPartPath)
+	require.NoError(t, err)
+	data[len(data)/2] ^= 0xff
+	require.NoError(t, os.WriteFile(topPartPath, data, 0600))
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		_, err := requester.Sync(context.Background(), blockID, commit, dir, "checkpoint")
+		require.ErrorContains(t, err, "invalid checkpoint")
+
+		// the invalid checkpoint is removed, so the next sync starts over
+		_, err = os.Stat(dir + "/checkpoint")
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestServeRateLimit(t *testing.T) {
+	n := newTestNetwork(t, 3)
+	n.config.ServeRateLimit = 1
+	n.config.ChunkSize = 2 * manifestResponseSize
+	e := n.start(0, nil)
+
+	peer1 := n.identities[1].NodeID
+	peer2 := n.identities[2].NodeID
+	ctx := context.Background()
+
+	// a full chunk is served at once, but the next one exceeds the rate limit
+	require.True(t, e.wait(ctx, peer1, int(n.config.ChunkSize)))
+	require.False(t, e.wait(ctx, peer1, 10))
+
+	// the rate limit is per peer
+	require.True(t, e.wait(ctx, peer2, int(n.config.ChunkSize)))
+}
+
+func TestBuildRateLimit(t *testing.T) {
+	n := newTestNetwork(t, 4)
+	n.config.BuildQueueCapacity = 1
+	checkpoints := &blockingCheckpoints{
+		started: make(chan flow.StateCommitment),
+		unblock: make(chan struct{}),
+	}
+	e := n.start(0, checkpoints)
+
+	peer1 := n.identities[1].NodeID
+	peer2 := n.identities[2].NodeID
+	peer3 := n.identities[3].NodeID
+	commit1 := unittest.StateCommitmentFixture()
+	commit2 := unittest.StateCommitmentFixture()
+	commit3 := unittest.StateCommitmentFixture()
+	lg := unittest.Logger()
+
+	require.True(t, e.requestBuild(lg, peer1, commit1))
+	unittest.RequireReturnsBefore(t, func() {
+		require.Equal(t, commit1, <-checkpoints.started)
+	}, time.Second, "checkpoint was not created")
+
+	// a checkpoint being created is pending for all peers
+	require.True(t, e.requestBuild(lg, peer2, commit1))
+
+	// each peer can only have a checkpoint created once per build interval
+	require.False(t, e.requestBuild(lg, peer1, commit2))
+	require.True(t, e.requestBuild(lg, peer2, commit2))
+
+	// the build queue is full
+	require.False(t, e.requestBuild(lg, peer3, commit3))
+
+	// the next checkpoint is created once the previous one is done
+	checkpoints.unblock <- struct{}{}
+	unittest.RequireReturnsBefore(t, func() {
+		require.Equal(t, commit2, <-checkpoints.started)
+	}, time.Second, "checkpoint was not created")
+	require.True(t, e.requestBuild(lg, peer3, commit3))
+
+	close(checkpoints.unblock)
+	unittest.RequireReturnsBefore(t, func() {
+		require.Equal(t, commit3, <-checkpoints.started)
+	}, time.Second, "checkpoint was not created")
+}
+
+func TestServeDamagedCheckpoint(t *testing.T) {
+	n := newTestNetwork(t, 2)
+	expected := randomTrie(t)
+	e, provider := n.startProvider(0, expected)
+
+	commit := flow.StateCommitment(expected.RootHash())
+	checkpoint, err := provider.Create(commit)
+	require.NoError(t, err)
+
+	// the part file is shorter than in the manifest, so the chunk can't be read
+	require.NoError(t, os.Truncate(checkpoint.PartFilePath(0), 1))
+
+	err = e.onChunkRequest(context.Background(), n.identities[1].NodeID, &messages.ExecutionStateChunkRequest{
+		StateCommitment: commit,
+		PartIndex:       0,
+		Offset:          0,
+	})
+	require.NoError(t, err)
+}
+
+func TestPruneLimiters(t *testing.T) {
+	n := newTestNetwork(t, 2)
+	e := n.start(0, nil)
+
+	peer := n.identities[1].NodeID
+	left := unittest.IdentifierFixture()
+	ctx := context.Background()
+
+	require.True(t, e.wait(ctx, peer, 1))
+	require.True(t, e.wait(ctx, left, 1))
+	e.buildLimiter(peer)
+	e.buildLimiter(left)
+
+	require.NoError(t, e.pruneLimiters())
+
+	// only the limiters of the peer which left the identity table are removed
+	e.limitersMu.Lock()
+	require.Contains(t, e.limiters, peer)
+	require.NotContains(t, e.limiters, left)
+	require.Contains(t, e.buildLimiters, peer)
+	require.NotContains(t, e.buildLimiters, left)
+	e.limitersMu.Unlock()
+}
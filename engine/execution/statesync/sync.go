@@ -0,0 +1,782 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/utils/logging"
+	"github.com/onflow/flow-go/utils/rand"
+)
+
+// maxPeerFailures is the number of consecutive timeouts after which a peer isn't requested anymore.
+const maxPeerFailures = 3
+
+// maxHeaderSize is the max size of the checkpoint header file in a manifest.
+const maxHeaderSize = 1024
+
+// response is a response of a peer, delivered to the ongoing sync.
+type response struct {
+	originID flow.Identifier
+	message  any
+}
+
+// session is an ongoing sync, which receives the responses of peers.
+type session struct {
+	log       zerolog.Logger
+	blockID   flow.Identifier
+	commit    flow.StateCommitment
+	responses chan response
+}
+
+// deliver delivers the response of a peer to the sync, or drops it if the sync is busy.
+func (s *session) deliver(originID flow.Identifier, message any) {
+	select {
+	case s.responses <- response{originID: originID, message: message}:
+	default:
+		s.log.Warn().
+			Hex("origin_id", logging.ID(originID)).
+			Msgf("dropped %T, because sync is busy", message)
+	}
+}
+
+// Sync syncs the execution state at the given state commitment, sealed for the given block, from
+// staked execution nodes. Peers which don't have the checkpoint of the execution state yet are
+// requested again until they created it.
+// The checkpoint of the execution state is downloaded into the given directory and file name.
+// Each subtrie part file is verified against the subtrie root hashes as soon as it's downloaded,
+// and the top level trie is verified against the state commitment before the trie is returned.
+// Peers responding with different manifests are grouped by manifest, and the checkpoint of the
+// manifest most peers agree on is synced first.
+// The download progress is kept in the directory, so a sync which failed or was interrupted
+// resumes from the chunks received so far. Only one sync can be ongoing at a time.
+func (e *Engine) Sync(
+	ctx context.Context,
+	blockID flow.Identifier,
+	commit flow.StateCommitment,
+	dir string,
+	fileName string,
+) (*trie.MTrie, error) {
+	lg := e.log.With().
+		Hex("block_id", logging.ID(blockID)).
+		Hex("state_commitment", commit[:]).
+		Str("checkpoint_file", filepath.Join(dir, fileName)).
+		Logger()
+
+	s := &session{
+		log:       lg,
+		blockID:   blockID,
+		commit:    commit,
+		responses: make(chan response, 2*e.config.MaxInflightChunks+wal.CheckpointPartCount),
+	}
+
+	e.sessionMu.Lock()
+	if e.session != nil {
+		e.sessionMu.Unlock()
+		return nil, fmt.Errorf("another sync is ongoing")
+	}
+	e.session = s
+	e.sessionMu.Unlock()
+
+	defer func() {
+		e.sessionMu.Lock()
+		e.session = nil
+		e.sessionMu.Unlock()
+	}()
+
+	peers, err := e.peers()
+	if err != nil {
+		return nil, fmt.Errorf("could not get execution nodes to sync from: %w", err)
+	}
+	if len(peers) == 0 {
+		return nil, fmt.Errorf("no execution node to sync from")
+	}
+
+	lg.Info().Int("peers", len(peers)).Msg("starting execution state sync")
+
+	candidates, err := e.requestManifest(ctx, s, peers)
+	if err != nil {
+		return nil, fmt.Errorf("could not get checkpoint manifest: %w", err)
+	}
+
+	lg.Info().Int("manifests", len(candidates)).Msg("received checkpoint manifests")
+
+	// a faulty peer can respond with a manifest of an invalid checkpoint, which is only detected
+	// once the checkpoint is downloaded, so the checkpoint of the next manifest is synced then.
+	for _, candidate := range candidates {
+		var t *trie.MTrie
+		t, err = e.syncCheckpoint(ctx, s, candidate, dir, fileName)
+		if err == nil {
+			lg.Info().Msg("execution state sync completed")
+			return t, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+
+		lg.Warn().
+			Err(err).
+			Int("sources", len(candidate.sources)).
+			Msg("could not sync checkpoint of manifest")
+	}
+
+	return nil, err
+}
+
+// syncCheckpoint downloads the checkpoint of the manifest from its sources, and verifies the
+// top level trie against the state commitment.
+func (e *Engine) syncCheckpoint(
+	ctx context.Context,
+	s *session,
+	candidate *manifestCandidate,
+	dir string,
+	fileName string,
+) (*trie.MTrie, error) {
+	s.log.Info().Int("sources", len(candidate.sources)).Msg("syncing checkpoint of manifest")
+
+	d, err := newDownload(s.log, dir, fileName, candidate.manifest, candidate.sources)
+	if err != nil {
+		return nil, fmt.Errorf("could not prepare checkpoint download: %w", err)
+	}
+
+	err = e.download(ctx, s, d)
+	if err != nil {
+		return nil, fmt.Errorf("could not download checkpoint: %w", err)
+	}
+
+	s.log.Info().Msg("downloaded checkpoint, verifying top level trie")
+
+	t, err := wal.LoadVerifiedCheckpoint(dir, fileName, ledger.RootHash(s.commit), s.log)
+	if err != nil {
+		// the manifest might have been invalid, so the next sync starts over
+		removeErr := removeCheckpointFiles(dir, fileName)
+		if removeErr != nil {
+			s.log.Warn().Err(removeErr).Msg("could not remove invalid checkpoint")
+		}
+		return nil, fmt.Errorf("invalid checkpoint: %w", err)
+	}
+
+	return t, nil
+}
+
+// peers returns the staked execution nodes, excluding this node.
+func (e *Engine) peers() (flow.IdentifierList, error) {
+	identities, err := e.state.Final().Identities(filter.And(
+		filter.HasRole[flow.Identity](flow.RoleExecution),
+		filter.IsValidCurrentEpochParticipant,
+		filter.HasInitialWeight[flow.Identity](true),
+		filter.Not(filter.HasNodeID[flow.Identity](e.me.NodeID())),
+	))
+	if err != nil {
+		return nil, err
+	}
+	return identities.NodeIDs(), nil
+}
+
+// manifestCandidate is a valid manifest, along with the peers which responded with it.
+type manifestCandidate struct {
+	manifest *messages.ExecutionStateManifestResponse
+	sources  flow.IdentifierList
+}
+
+// requestManifest requests the manifest of the checkpoint from all peers, and returns the valid
+// manifests, along with the peers which responded with each of them, ordered by the number of
+// peers. If no peer has the checkpoint yet, the peers which are creating it are requested again.
+func (e *Engine) requestManifest(
+	ctx context.Context,
+	s *session,
+	peers flow.IdentifierList,
+) ([]*manifestCandidate, error) {
+	for {
+		candidates, pending, err := e.requestManifestOnce(ctx, s, peers)
+		if err != nil {
+			return nil, err
+		}
+		if len(candidates) > 0 {
+			return candidates, nil
+		}
+		if len(pending) == 0 {
+			return nil, fmt.Errorf("no peer has the checkpoint")
+		}
+
+		s.log.Info().
+			Int("pending", len(pending)).
+			Dur("retry_interval", e.config.ManifestRetryInterval).
+			Msg("peers are creating the checkpoint, requesting manifest again later")
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(e.config.ManifestRetryInterval):
+		}
+		peers = pending
+	}
+}
+
+// requestManifestOnce requests the manifest of the checkpoint from the peers, and returns the
+// valid manifests, along with the peers which responded with each of them, ordered by the number
+// of peers, and the peers which are creating the checkpoint.
+func (e *Engine) requestManifestOnce(
+	ctx context.Context,
+	s *session,
+	peers flow.IdentifierList,
+) ([]*manifestCandidate, flow.IdentifierList, error) {
+	for _, peer := range peers {
+		nonce, err := rand.Uint64()
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not generate nonce: %w", err)
+		}
+
+		err = e.requestLimiter.Wait(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		err = e.con.Unicast(&messages.ExecutionStateManifestRequest{
+			BlockID:         s.blockID,
+			StateCommitment: s.commit,
+			Nonce:           nonce,
+		}, peer)
+		if err != nil {
+			s.log.Warn().Err(err).Hex("peer_id", logging.ID(peer)).Msg("could not request manifest")
+		}
+	}
+
+	timeout := time.NewTimer(e.config.RequestTimeout)
+	defer timeout.Stop()
+	timedOut := false
+
+	var candidates []*manifestCandidate
+	var pending flow.IdentifierList
+	responded := make(map[flow.Identifier]struct{})
+
+	// wait for all peers to respond, so that chunks can be requested from all sources
+	for len(responded) < len(peers) && !timedOut {
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-timeout.C:
+			timedOut = true
+		case resp := <-s.responses:
+			m, ok := resp.message.(*messages.ExecutionStateManifestResponse)
+			if !ok || !peers.Contains(resp.originID) {
+				continue
+			}
+			if _, ok := responded[resp.originID]; ok {
+				continue
+			}
+			responded[resp.originID] = struct{}{}
+
+			if !m.Available {
+				if m.Pending {
+					pending = append(pending, resp.originID)
+				}
+				continue
+			}
+
+			err := validateManifest(s.commit, m)
+			if err != nil {
+				s.log.Warn().
+					Err(err).
+					Hex("origin_id", logging.ID(resp.originID)).
+					Bool(logging.KeySuspicious, true).
+					Msg("received invalid manifest")
+				continue
+			}
+
+			candidates = addManifest(candidates, m, resp.originID)
+		}
+	}
+
+	// the manifest most peers agree on is synced first, the order of responses breaks ties
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return len(candidates[i].sources) > len(candidates[j].sources)
+	})
+
+	return candidates, pending, nil
+}
+
+// addManifest adds the peer as a source of the candidate with the same checkpoint as the manifest,
+// or adds a new candidate if no peer responded with the same checkpoint yet.
+func addManifest(candidates []*manifestCandidate, m *messages.ExecutionStateManifestResponse, peer flow.Identifier) []*manifestCandidate {
+	for _, candidate := range candidates {
+		if sameCheckpoint(candidate.manifest, m) {
+			candidate.sources = append(candidate.sources, peer)
+			return candidates
+		}
+	}
+	return append(candidates, &manifestCandidate{
+		manifest: m,
+		sources:  flow.IdentifierList{peer},
+	})
+}
+
+// validateManifest checks the manifest is well-formed. The content of the checkpoint is verified
+// when it's downloaded.
+func validateManifest(commit flow.StateCommitment, m *messages.ExecutionStateManifestResponse) error {
+	if m.StateCommitment != commit {
+		return fmt.Errorf("manifest of state commitment %v, but requested %v", m.StateCommitment, commit)
+	}
+	if len(m.Header) == 0 || len(m.Header) > maxHeaderSize {
+		return fmt.Errorf("invalid header size %v", len(m.Header))
+	}
+	if len(m.PartSizes) != wal.CheckpointPartCount {
+		return fmt.Errorf("expect %v part files, but got %v", wal.CheckpointPartCount, len(m.PartSizes))
+	}
+	for i, size := range m.PartSizes {
+		if size == 0 {
+			return fmt.Errorf("empty %v-th part file", i)
+		}
+	}
+	if len(m.SubtrieRootHashes) != wal.CheckpointPartCount-1 {
+		return fmt.Errorf("expect subtrie root hashes of %v part files, but got %v",
+			wal.CheckpointPartCount-1, len(m.SubtrieRootHashes))
+	}
+	return nil
+}
+
+// sameCheckpoint returns true if both manifests describe the same checkpoint files, and the same
+// subtrie root hashes the part files are verified against.
+func sameCheckpoint(m1 *messages.ExecutionStateManifestResponse, m2 *messages.ExecutionStateManifestResponse) bool {
+	if !bytes.Equal(m1.Header, m2.Header) {
+		return false
+	}
+	for i := range m1.PartSizes {
+		if m1.PartSizes[i] != m2.PartSizes[i] {
+			return false
+		}
+	}
+	for i := range m1.SubtrieRootHashes {
+		if len(m1.SubtrieRootHashes[i]) != len(m2.SubtrieRootHashes[i]) {
+			return false
+		}
+		for j := range m1.SubtrieRootHashes[i] {
+			if m1.SubtrieRootHashes[i][j] != m2.SubtrieRootHashes[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// partDownload is the download progress of a checkpoint part file.
+type partDownload struct {
+	index    int
+	size     uint64
+	offset   uint64
+	verified bool
+	// contributors are the peers which sent chunks of the part file
+	contributors map[flow.Identifier]struct{}
+	// pinned is true if the part file is downloaded from a single source, which is
+	// required to find the faulty source after chunks of several sources failed verification
+	pinned bool
+	source flow.Identifier
+}
+
+// chunkRequest is a chunk request waiting for a response.
+type chunkRequest struct {
+	peer     flow.Identifier
+	offset   uint64
+	deadline time.Time
+}
+
+// download is the download progress of a checkpoint.
+type download struct {
+	log        zerolog.Logger
+	dir        string
+	fileName   string
+	version    uint16
+	checksums  []uint32
+	rootHashes [][]hash.Hash
+	parts      []*partDownload
+
+	sources  flow.IdentifierList
+	next     int // index of the next source to request
+	failures map[flow.Identifier]int
+	inflight map[int]*chunkRequest // by part index
+}
+
+// newDownload prepares the download of the checkpoint of the manifest. The part files downloaded
+// by a previous sync are kept if the checkpoint header is the same.
+func newDownload(
+	log zerolog.Logger,
+	dir string,
+	fileName string,
+	manifest *messages.ExecutionStateManifestResponse,
+	sources flow.IdentifierList,
+) (*download, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("could not create dir %v: %w", dir, err)
+	}
+
+	headerPath := filepath.Join(dir, fileName)
+	header, err := os.ReadFile(headerPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("could not read checkpoint header: %w", err)
+	}
+
+	if !bytes.Equal(header, manifest.Header) {
+		// the part files of a different checkpoint can't be resumed
+		err = removeCheckpointFiles(dir, fileName)
+		if err != nil {
+			return nil, err
+		}
+		err = os.WriteFile(headerPath, manifest.Header, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("could not write checkpoint header: %w", err)
+		}
+	}
+
+	// validates the checksum of the header
+	version, checksums, err := wal.ReadCheckpointPartChecksums(dir, fileName, log)
+	if err != nil {
+		removeErr := removeCheckpointFiles(dir, fileName)
+		if removeErr != nil {
+			log.Warn().Err(removeErr).Msg("could not remove invalid checkpoint header")
+		}
+		return nil, fmt.Errorf("invalid checkpoint header: %w", err)
+	}
+
+	d := &download{
+		log:        log,
+		dir:        dir,
+		fileName:   fileName,
+		version:    version,
+		checksums:  checksums,
+		rootHashes: make([][]hash.Hash, len(manifest.SubtrieRootHashes)),
+		parts:      make([]*partDownload, len(manifest.PartSizes)),
+		sources:    sources,
+		failures:   make(map[flow.Identifier]int),
+		inflight:   make(map[int]*chunkRequest),
+	}
+
+	for i, commits := range manifest.SubtrieRootHashes {
+		d.rootHashes[i] = make([]hash.Hash, len(commits))
+		for j, commit := range commits {
+			d.rootHashes[i][j] = hash.Hash(commit)
+		}
+	}
+
+	for i, size := range manifest.PartSizes {
+		part := &partDownload{
+			index:        i,
+			size:         size,
+			contributors: make(map[flow.Identifier]struct{}),
+		}
+		d.parts[i] = part
+
+		info, err := os.Stat(d.partFilePath(i))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not get size of part file: %w", err)
+		}
+
+		part.offset = uint64(info.Size())
+		if part.offset > size {
+			err = d.reset(part)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if part.offset > 0 {
+			log.Info().Int("part_index", i).Uint64("offset", part.offset).Msg("resuming part file download")
+		}
+	}
+
+	return d, nil
+}
+
+func (d *download) partFilePath(index int) string {
+	return filepath.Join(d.dir, wal.CheckpointPartFileName(d.fileName, index))
+}
+
+// reset discards the downloaded chunks of the part file.
+func (d *download) reset(part *partDownload) error {
+	err := os.Truncate(d.partFilePath(part.index), 0)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not truncate part file: %w", err)
+	}
+	part.offset = 0
+	part.verified = false
+	part.contributors = make(map[flow.Identifier]struct{})
+	return nil
+}
+
+// verify verifies a downloaded part file. The subtrie part files are verified against the subtrie
+// root hashes of the manifest, whereas the top level trie part file is verified with the whole
+// checkpoint once all subtrie part files are verified.
+func (d *download) verify(part *partDownload) error {
+	if part.index >= len(d.rootHashes) {
+		return nil
+	}
+	return wal.VerifyCheckpointSubtriePart(d.dir, d.fileName, d.version, part.index,
+		d.checksums[part.index], d.rootHashes[part.index], d.log)
+}
+
+// onPartDownloaded verifies the downloaded part file. If it's invalid, the part file is downloaded
+// again from the other sources.
+func (d *download) onPartDownloaded(part *partDownload) error {
+	err := d.verify(part)
+	if err == nil {
+		part.verified = true
+		d.log.Info().Int("part_index", part.index).Msg("downloaded and verified part file")
+		return nil
+	}
+
+	d.log.Warn().Err(err).Int("part_index", part.index).Msg("downloaded invalid part file")
+
+	if len(part.contributors) == 1 {
+		for peer := range part.contributors {
+			d.removeSource(peer)
+		}
+	} else {
+		// any of the contributors might be faulty, so download again from a single source
+		part.pinned = true
+		part.source = flow.ZeroID
+	}
+	return d.reset(part)
+}
+
+// sourceOf returns the source to request the next chunk of the part file from.
+func (d *download) sourceOf(part *partDownload) (flow.Identifier, bool) {
+	if part.pinned && part.source != flow.ZeroID && d.sources.Contains(part.source) {
+		return part.source, true
+	}
+	source, ok := d.nextSource()
+	if ok && part.pinned {
+		if len(part.contributors) > 0 {
+			// the pinned source is gone, so the chunks of other sources must be discarded
+			err := d.reset(part)
+			if err != nil {
+				d.log.Warn().Err(err).Int("part_index", part.index).Msg("could not reset part file")
+			}
+		}
+		part.source = source
+	}
+	return source, ok
+}
+
+// nextSource returns the next source to request a chunk from.
+func (d *download) nextSource() (flow.Identifier, bool) {
+	if len(d.sources) == 0 {
+		return flow.ZeroID, false
+	}
+	d.next = d.next % len(d.sources)
+	source := d.sources[d.next]
+	d.next++
+	return source, true
+}
+
+// removeSource stops requesting chunks from the peer.
+func (d *download) removeSource(peer flow.Identifier) {
+	for i, source := range d.sources {
+		if source == peer {
+			d.log.Warn().Hex("peer_id", logging.ID(peer)).Msg("stop syncing from peer")
+			d.sources = append(d.sources[:i], d.sources[i+1:]...)
+			return
+		}
+	}
+}
+
+// onFailure records a failed request to the peer, and stops requesting the peer after too many failures.
+func (d *download) onFailure(peer flow.Identifier) {
+	d.failures[peer]++
+	if d.failures[peer] >= maxPeerFailures {
+		d.removeSource(peer)
+	}
+}
+
+// done returns true if all part files are downloaded and verified.
+func (d *download) done() bool {
+	for _, part := range d.parts {
+		if !part.verified {
+			return false
+		}
+	}
+	return true
+}
+
+// download downloads the part files of the checkpoint in chunks from the sources.
+func (e *Engine) download(ctx context.Context, s *session, d *download) error {
+	// verify the part files downloaded by a previous sync
+	for _, part := range d.parts {
+		if part.offset == part.size {
+			err := d.onPartDownloaded(part)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	ticker := time.NewTicker(e.config.RequestTimeout / 4)
+	defer ticker.Stop()
+
+	for !d.done() {
+		err := e.requestChunks(ctx, s, d)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case resp := <-s.responses:
+			chunk, ok := resp.message.(*messages.ExecutionStateChunkResponse)
+			if !ok {
+				continue
+			}
+			err = e.onChunkResponse(s, d, resp.originID, chunk)
+			if err != nil {
+				return err
+			}
+		case now := <-ticker.C:
+			for index, request := range d.inflight {
+				if now.After(request.deadline) {
+					d.log.Info().
+						Hex("peer_id", logging.ID(request.peer)).
+						Int("part_index", index).
+						Msg("chunk request timed out")
+					delete(d.inflight, index)
+					d.onFailure(request.peer)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// requestChunks requests the next chunk of the part files which are not downloaded yet, up to
+// the max number of chunks in flight.
+func (e *Engine) requestChunks(ctx context.Context, s *session, d *download) error {
+	for _, part := range d.parts {
+		if uint(len(d.inflight)) >= e.config.MaxInflightChunks {
+			return nil
+		}
+		if part.offset == part.size {
+			continue
+		}
+		if _, ok := d.inflight[part.index]; ok {
+			continue
+		}
+
+		peer, ok := d.sourceOf(part)
+		if !ok {
+			return fmt.Errorf("no peer left to sync from")
+		}
+
+		nonce, err := rand.Uint64()
+		if err != nil {
+			return fmt.Errorf("could not generate nonce: %w", err)
+		}
+
+		err = e.requestLimiter.Wait(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = e.con.Unicast(&messages.ExecutionStateChunkRequest{
+			StateCommitment: s.commit,
+			PartIndex:       uint32(part.index),
+			Offset:          part.offset,
+			Nonce:           nonce,
+		}, peer)
+		if err != nil {
+			d.log.Warn().Err(err).Hex("peer_id", logging.ID(peer)).Msg("could not request chunk")
+			d.onFailure(peer)
+			continue
+		}
+
+		d.inflight[part.index] = &chunkRequest{
+			peer:     peer,
+			offset:   part.offset,
+			deadline: time.Now().Add(e.config.RequestTimeout),
+		}
+	}
+	return nil
+}
+
+// onChunkResponse writes the received chunk into the part file, and verifies the part file once
+// all its chunks are received.
+func (e *Engine) onChunkResponse(s *session, d *download, originID flow.Identifier, chunk *messages.ExecutionStateChunkResponse) error {
+	if chunk.StateCommitment != s.commit || int(chunk.PartIndex) >= len(d.parts) {
+		return nil
+	}
+
+	request, ok := d.inflight[int(chunk.PartIndex)]
+	if !ok || request.peer != originID || request.offset != chunk.Offset {
+		// response to a request which timed out
+		return nil
+	}
+	delete(d.inflight, int(chunk.PartIndex))
+
+	part := d.parts[chunk.PartIndex]
+	lg := d.log.With().
+		Hex("peer_id", logging.ID(originID)).
+		Int("part_index", part.index).
+		Uint64("offset", chunk.Offset).
+		Logger()
+
+	if len(chunk.Data) == 0 {
+		lg.Info().Msg("peer doesn't have the checkpoint anymore")
+		d.removeSource(originID)
+		return nil
+	}
+
+	if uint64(len(chunk.Data)) > e.config.ChunkSize || chunk.Offset+uint64(len(chunk.Data)) > part.size {
+		lg.Warn().Bool(logging.KeySuspicious, true).Int("size", len(chunk.Data)).Msg("received invalid chunk")
+		d.removeSource(originID)
+		return nil
+	}
+
+	err := writeChunk(d.partFilePath(part.index), chunk.Offset, chunk.Data)
+	if err != nil {
+		return err
+	}
+
+	part.offset += uint64(len(chunk.Data))
+	part.contributors[originID] = struct{}{}
+	d.failures[originID] = 0
+
+	if part.offset == part.size {
+		return d.onPartDownloaded(part)
+	}
+	return nil
+}
+
+// writeChunk writes the data at the offset of the file.
+func writeChunk(path string, offset uint64, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("could not open file %v: %w", path, err)
+	}
+
+	_, err = file.WriteAt(data, int64(offset))
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("could not write file %v: %w", path, err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("could not close file %v: %w", path, err)
+	}
+	return nil
+}
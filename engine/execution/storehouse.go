@@ -4,6 +4,7 @@ import (
 	"github.com/onflow/flow-go/fvm/storage/snapshot"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/finalizedreader"
+	"github.com/onflow/flow-go/module/mempool/entity"
 	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/storage/pebble"
 )
@@ -57,6 +58,23 @@ type RegisterStore interface {
 	IsBlockExecuted(height uint64, blockID flow.Identifier) (bool, error)
 }
 
+// RegisterPrefetcher warms the register store with the registers a block is predicted to read,
+// before the block is executed. None of its methods block on the prefetching.
+// see implementation in engine/execution/storehouse/register_prefetcher.go
+type RegisterPrefetcher interface {
+	// OnCollection starts predicting the registers read by the transactions of the received
+	// collection, and prefetching them at the latest executed block.
+	OnCollection(collection *flow.Collection)
+
+	// Prefetch starts prefetching the registers the given block is predicted to read, at the end
+	// state of its parent block. The parent block must be executed.
+	Prefetch(block *entity.ExecutableBlock)
+
+	// OnBlockExecuted keeps the registers prefetched at the parent of the executed block cached for
+	// the executed block, and learns the registers it read, to predict the reads of the following blocks.
+	OnBlockExecuted(result *ComputationResult)
+}
+
 // RegisterStoreNotifier is the interface for register store to notify when a block is finalized and executed
 type RegisterStoreNotifier interface {
 	OnFinalizedAndExecutedHeightUpdated(height uint64)
@@ -0,0 +1,518 @@
+package storehouse
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/parser"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/mempool/entity"
+)
+
+const (
+	// DefaultPrefetchWorkers is the default number of registers read concurrently when prefetching
+	// the registers of a block.
+	DefaultPrefetchWorkers = 16
+
+	// prefetchedBlocks is the number of blocks the prefetched registers are kept for.
+	prefetchedBlocks = 16
+
+	// predictedCollections is the number of collections the predicted registers are kept for.
+	predictedCollections = 1_000
+
+	// parsedScripts is the number of transaction scripts the imported contracts are kept for.
+	parsedScripts = 10_000
+
+	// learnedContracts is the number of contracts the registers read by their previous execution
+	// are kept for.
+	learnedContracts = 10_000
+
+	// maxLearnedRegisters is the maximum number of registers learned per contract.
+	maxLearnedRegisters = 1_000
+
+	// prefetchQueueSize is the number of collections and blocks which can wait for the worker.
+	// Jobs queued while the queue is full are dropped.
+	prefetchQueueSize = 1_000
+)
+
+type contractLocation struct {
+	address flow.Address
+	name    string
+}
+
+// prediction holds the registers predicted to be read by the transactions of a collection.
+type prediction struct {
+	registers []flow.RegisterID
+	contracts []contractLocation
+}
+
+// prefetchedRegisters holds the registers prefetched at the end state of a block.
+type prefetchedRegisters struct {
+	mutex  sync.RWMutex
+	values map[flow.RegisterID]flow.RegisterValue
+}
+
+func newPrefetchedRegisters(values map[flow.RegisterID]flow.RegisterValue) *prefetchedRegisters {
+	return &prefetchedRegisters{values: values}
+}
+
+func (p *prefetchedRegisters) get(id flow.RegisterID) (flow.RegisterValue, bool) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	value, ok := p.values[id]
+	return value, ok
+}
+
+func (p *prefetchedRegisters) set(id flow.RegisterID, value flow.RegisterValue) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.values[id] = value
+}
+
+// RegisterPrefetcher wraps a register store, and reads the registers a block is predicted to read
+// into a cache before the block is executed, so that the execution doesn't stall on cold storage.
+//
+// The reads of a block are predicted from:
+//   - the account status and keys of the payer, proposer and authorizers of its transactions,
+//   - the code of the contracts imported by its transactions,
+//   - the contract registers read by the previous execution of the same contracts.
+//
+// The reads of a collection are predicted as soon as it's received, and prefetched at the latest
+// executed block. When a block is executed, the registers prefetched at its parent are carried over
+// to it, with the values it wrote, so registers prefetched ahead remain cached for the following
+// blocks. Once the parent of a block is executed, the predicted registers which aren't cached yet
+// are prefetched at the parent.
+//
+// All the work is done by the worker of the component, so the callers don't wait for it.
+//
+// Register reads at the end state of a prefetched block are served from the cache, and reported
+// as hits or misses.
+type RegisterPrefetcher struct {
+	execution.RegisterStore
+	component.Component
+
+	log     zerolog.Logger
+	metrics module.ExecutionMetrics
+	workers int
+	jobs    chan func()
+
+	// prefetched registers by the ID of the block they are read at,
+	// which is the parent of the block being executed
+	blocks *lru.Cache[flow.Identifier, *prefetchedRegisters]
+
+	// predicted registers by the ID of the collection reading them
+	predicted *lru.Cache[flow.Identifier, *prediction]
+
+	// contracts imported by the transaction script, by the hash of the script
+	imports *lru.Cache[flow.Identifier, []contractLocation]
+
+	// registers owned by the contract account, read by the previous execution of the contract
+	learned *lru.Cache[contractLocation, []flow.RegisterID]
+
+	// lastExecuted is the latest executed block, only accessed by the worker
+	lastExecuted *flow.Header
+}
+
+var _ execution.RegisterStore = (*RegisterPrefetcher)(nil)
+var _ execution.RegisterPrefetcher = (*RegisterPrefetcher)(nil)
+
+func NewRegisterPrefetcher(
+	store execution.RegisterStore,
+	log zerolog.Logger,
+	metrics module.ExecutionMetrics,
+	workers int,
+) (*RegisterPrefetcher, error) {
+	if workers < 1 {
+		return nil, fmt.Errorf("invalid number of prefetch workers: %d", workers)
+	}
+
+	blocks, err := lru.New[flow.Identifier, *prefetchedRegisters](prefetchedBlocks)
+	if err != nil {
+		return nil, fmt.Errorf("could not create prefetched blocks cache: %w", err)
+	}
+
+	predicted, err := lru.New[flow.Identifier, *prediction](predictedCollections)
+	if err != nil {
+		return nil, fmt.Errorf("could not create predicted collections cache: %w", err)
+	}
+
+	imports, err := lru.New[flow.Identifier, []contractLocation](parsedScripts)
+	if err != nil {
+		return nil, fmt.Errorf("could not create parsed scripts cache: %w", err)
+	}
+
+	learned, err := lru.New[contractLocation, []flow.RegisterID](learnedContracts)
+	if err != nil {
+		return nil, fmt.Errorf("could not create learned contracts cache: %w", err)
+	}
+
+	r := &RegisterPrefetcher{
+		RegisterStore: store,
+		log:           log.With().Str("module", "register-prefetcher").Logger(),
+		metrics:       metrics,
+		workers:       workers,
+		jobs:          make(chan func(), prefetchQueueSize),
+		blocks:        blocks,
+		predicted:     predicted,
+		imports:       imports,
+		learned:       learned,
+	}
+
+	r.Component = component.NewComponentManagerBuilder().
+		AddWorker(r.processJobsWorker).
+		Build()
+
+	return r, nil
+}
+
+// GetRegister returns the prefetched register value if the register was prefetched at the given
+// block, otherwise it reads the register from the register store.
+// see RegisterStore.GetRegister for the returned errors.
+func (r *RegisterPrefetcher) GetRegister(height uint64, blockID flow.Identifier, register flow.RegisterID) (flow.RegisterValue, error) {
+	prefetched, ok := r.blocks.Get(blockID)
+	if !ok {
+		return r.RegisterStore.GetRegister(height, blockID, register)
+	}
+
+	value, hit := prefetched.get(register)
+	r.metrics.ExecutionPrefetchedRegisterRead(hit)
+	if hit {
+		return value, nil
+	}
+
+	return r.RegisterStore.GetRegister(height, blockID, register)
+}
+
+// OnCollection queues predicting the registers read by the transactions of the received collection,
+// and prefetching them at the latest executed block.
+func (r *RegisterPrefetcher) OnCollection(collection *flow.Collection) {
+	r.enqueue(func() {
+		predicted := r.predict(collection.ID(), collection.Transactions)
+		if r.lastExecuted == nil {
+			return
+		}
+
+		r.prefetchAt(r.lastExecuted.Height, r.lastExecuted.ID(), r.expand(predicted))
+	})
+}
+
+// Prefetch queues prefetching the registers the given block is predicted to read, which aren't
+// cached yet, at the end state of its parent block.
+func (r *RegisterPrefetcher) Prefetch(block *entity.ExecutableBlock) {
+	r.enqueue(func() {
+		var registers []flow.RegisterID
+		for _, collection := range block.Collections() {
+			predicted := r.predict(collection.Guarantee.CollectionID, collection.Transactions)
+			registers = append(registers, r.expand(predicted)...)
+		}
+
+		r.prefetchAt(block.Block.Header.Height-1, block.Block.Header.ParentID, registers)
+	})
+}
+
+// OnBlockExecuted queues carrying the registers prefetched at the parent block over to the executed
+// block, and learning the contract registers read by the executed block.
+func (r *RegisterPrefetcher) OnBlockExecuted(result *execution.ComputationResult) {
+	r.enqueue(func() {
+		r.carryOver(result)
+		r.learn(result)
+		r.lastExecuted = result.ExecutableBlock.Block.Header
+	})
+}
+
+// enqueue queues the job for the worker, or drops it if the queue is full.
+// Prefetching is best effort, so dropping a job only costs cache misses.
+func (r *RegisterPrefetcher) enqueue(job func()) {
+	select {
+	case r.jobs <- job:
+	default:
+		r.log.Debug().Msg("prefetch queue is full, dropping job")
+	}
+}
+
+// processJobsWorker runs the queued jobs in order until the component is stopped.
+func (r *RegisterPrefetcher) processJobsWorker(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-r.jobs:
+			job()
+		}
+	}
+}
+
+// prefetchAt reads the given registers which aren't cached yet at the end state of the given block.
+func (r *RegisterPrefetcher) prefetchAt(height uint64, blockID flow.Identifier, registers []flow.RegisterID) {
+	startedAt := time.Now()
+
+	prefetched, ok := r.blocks.Get(blockID)
+	if !ok {
+		prefetched = newPrefetchedRegisters(make(map[flow.RegisterID]flow.RegisterValue))
+		r.blocks.Add(blockID, prefetched)
+	}
+
+	missing := make([]flow.RegisterID, 0, len(registers))
+	seen := make(map[flow.RegisterID]struct{}, len(registers))
+	for _, id := range registers {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		if _, ok := prefetched.get(id); !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	count := r.prefetch(height, blockID, missing, prefetched)
+
+	r.metrics.ExecutionRegistersPrefetched(count, time.Since(startedAt))
+	r.log.Debug().
+		Hex("block_id", blockID[:]).
+		Uint64("height", height).
+		Int("predicted", len(seen)).
+		Int("prefetched", count).
+		Dur("duration", time.Since(startedAt)).
+		Msg("registers prefetched")
+}
+
+// prefetch reads the registers at the given block concurrently, and returns the number of
+// registers read.
+func (r *RegisterPrefetcher) prefetch(
+	height uint64,
+	blockID flow.Identifier,
+	registers []flow.RegisterID,
+	prefetched *prefetchedRegisters,
+) int {
+	ids := make(chan flow.RegisterID, len(registers))
+	for _, id := range registers {
+		ids <- id
+	}
+	close(ids)
+
+	var count int
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	wg.Add(r.workers)
+	for i := 0; i < r.workers; i++ {
+		go func() {
+			defer wg.Done()
+			for id := range ids {
+				value, err := r.RegisterStore.GetRegister(height, blockID, id)
+				if err != nil {
+					// prefetching is best effort, the register is read again during execution
+					r.log.Debug().Err(err).Str("register", id.String()).Msg("could not prefetch register")
+					continue
+				}
+
+				prefetched.set(id, value)
+				mutex.Lock()
+				count++
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return count
+}
+
+// predict returns the registers the transactions of the given collection are predicted to read,
+// except for the learned contract registers, which change as contracts are executed.
+func (r *RegisterPrefetcher) predict(collectionID flow.Identifier, transactions []*flow.TransactionBody) *prediction {
+	predicted, ok := r.predicted.Get(collectionID)
+	if ok {
+		return predicted
+	}
+
+	registers := make(map[flow.RegisterID]struct{})
+	add := func(id flow.RegisterID) {
+		registers[id] = struct{}{}
+	}
+	contracts := make(map[contractLocation]struct{})
+
+	for _, tx := range transactions {
+		add(flow.AccountStatusRegisterID(tx.Payer))
+		add(flow.AccountStatusRegisterID(tx.ProposalKey.Address))
+		add(flow.PublicKeyRegisterID(tx.ProposalKey.Address, tx.ProposalKey.KeyIndex))
+		for _, authorizer := range tx.Authorizers {
+			add(flow.AccountStatusRegisterID(authorizer))
+		}
+		for _, signature := range tx.PayloadSignatures {
+			add(flow.PublicKeyRegisterID(signature.Address, signature.KeyIndex))
+		}
+		for _, signature := range tx.EnvelopeSignatures {
+			add(flow.PublicKeyRegisterID(signature.Address, signature.KeyIndex))
+		}
+
+		for _, contract := range r.importedContracts(tx.Script) {
+			add(flow.ContractNamesRegisterID(contract.address))
+			add(flow.ContractRegisterID(contract.address, contract.name))
+			contracts[contract] = struct{}{}
+		}
+	}
+
+	predicted = &prediction{
+		registers: make([]flow.RegisterID, 0, len(registers)),
+		contracts: make([]contractLocation, 0, len(contracts)),
+	}
+	for id := range registers {
+		predicted.registers = append(predicted.registers, id)
+	}
+	for contract := range contracts {
+		predicted.contracts = append(predicted.contracts, contract)
+	}
+
+	r.predicted.Add(collectionID, predicted)
+	return predicted
+}
+
+// expand returns the predicted registers, and the registers learned for the predicted contracts.
+func (r *RegisterPrefetcher) expand(predicted *prediction) []flow.RegisterID {
+	registers := make([]flow.RegisterID, 0, len(predicted.registers))
+	registers = append(registers, predicted.registers...)
+	for _, contract := range predicted.contracts {
+		learned, ok := r.learned.Get(contract)
+		if !ok {
+			continue
+		}
+		registers = append(registers, learned...)
+	}
+	return registers
+}
+
+// carryOver caches the registers prefetched at the parent of the executed block at the executed
+// block, with the values written by the executed block.
+func (r *RegisterPrefetcher) carryOver(result *execution.ComputationResult) {
+	parent, ok := r.blocks.Get(result.ExecutableBlock.Block.Header.ParentID)
+	if !ok {
+		return
+	}
+
+	parent.mutex.RLock()
+	values := make(map[flow.RegisterID]flow.RegisterValue, len(parent.values))
+	for id, value := range parent.values {
+		values[id] = value
+	}
+	parent.mutex.RUnlock()
+
+	// the snapshots are in the order of execution, so the last write of a register wins
+	for _, snapshot := range result.AllExecutionSnapshots() {
+		if snapshot == nil {
+			continue
+		}
+		for id, value := range snapshot.WriteSet {
+			if _, ok := values[id]; ok {
+				values[id] = value
+			}
+		}
+	}
+
+	r.blocks.Add(result.ExecutableBlock.ID(), newPrefetchedRegisters(values))
+}
+
+// learn learns the registers read by each collection of the executed block, which are owned by
+// the contracts loaded by the collection. The contracts loaded are the contract code registers
+// in the read set, which includes the reads of programs loaded from the programs cache, so the
+// transaction scripts don't need to be parsed again.
+func (r *RegisterPrefetcher) learn(result *execution.ComputationResult) {
+	for _, snapshot := range result.AllExecutionSnapshots() {
+		if snapshot == nil {
+			continue
+		}
+
+		// names of the contracts loaded by the collection, by owner
+		loaded := make(map[string][]string)
+		for id := range snapshot.ReadSet {
+			if flow.IsContractKey(id.Key) {
+				loaded[id.Owner] = append(loaded[id.Owner], flow.KeyContractName(id.Key))
+			}
+		}
+		if len(loaded) == 0 {
+			continue
+		}
+
+		learned := make(map[string][]flow.RegisterID, len(loaded))
+		for id := range snapshot.ReadSet {
+			if _, ok := loaded[id.Owner]; !ok || len(learned[id.Owner]) == maxLearnedRegisters {
+				continue
+			}
+			learned[id.Owner] = append(learned[id.Owner], id)
+		}
+
+		for owner, names := range loaded {
+			address := flow.BytesToAddress([]byte(owner))
+			for _, name := range names {
+				r.learned.Add(contractLocation{address: address, name: name}, learned[owner])
+			}
+		}
+	}
+}
+
+// importedContracts returns the contracts imported by address in the given script, which are
+// cached by the hash of the script.
+func (r *RegisterPrefetcher) importedContracts(script []byte) []contractLocation {
+	scriptHash := flow.MakeIDFromFingerPrint(script)
+	contracts, ok := r.imports.Get(scriptHash)
+	if ok {
+		return contracts
+	}
+
+	contracts = importedContracts(script)
+	r.imports.Add(scriptHash, contracts)
+	return contracts
+}
+
+// importedContracts returns the contracts imported by address in the given script.
+// It returns nothing if the script can't be parsed.
+func importedContracts(script []byte) []contractLocation {
+	program, err := parser.ParseProgram(nil, script, parser.Config{})
+	if err != nil {
+		return nil
+	}
+
+	var contracts []contractLocation
+	for _, declaration := range program.ImportDeclarations() {
+		location, ok := declaration.Location.(common.AddressLocation)
+		if !ok {
+			continue
+		}
+
+		address := flow.ConvertAddress(location.Address)
+		for _, identifier := range declaration.Identifiers {
+			contracts = append(contracts, contractLocation{
+				address: address,
+				name:    identifier.Identifier,
+			})
+		}
+	}
+
+	return contracts
+}
+
+// NoopRegisterPrefetcher doesn't prefetch any register.
+type NoopRegisterPrefetcher struct{}
+
+var _ execution.RegisterPrefetcher = (*NoopRegisterPrefetcher)(nil)
+
+func NewNoopRegisterPrefetcher() *NoopRegisterPrefetcher { return &NoopRegisterPrefetcher{} }
+
+func (*NoopRegisterPrefetcher) OnCollection(*flow.Collection) {}
+
+func (*NoopRegisterPrefetcher) Prefetch(*entity.ExecutableBlock) {}
+
+func (*NoopRegisterPrefetcher) OnBlockExecuted(*execution.ComputationResult) {}
@@ -0,0 +1,200 @@
+package storehouse_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution"
+	executionMock "github.com/onflow/flow-go/engine/execution/mock"
+	"github.com/onflow/flow-go/engine/execution/storehouse"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/mempool/entity"
+	modulemock "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// readCountingStore is a register store returning a value for any register,
+// and counting the reads of each register.
+type readCountingStore struct {
+	*executionMock.RegisterStore
+
+	mutex sync.Mutex
+	reads map[flow.RegisterID]int
+}
+
+func (s *readCountingStore) GetRegister(_ uint64, _ flow.Identifier, id flow.RegisterID) (flow.RegisterValue, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.reads[id]++
+	return flow.RegisterValue(id.Key), nil
+}
+
+func (s *readCountingStore) readsOf(id flow.RegisterID) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.reads[id]
+}
+
+func TestRegisterPrefetcher(t *testing.T) {
+	payer := unittest.RandomAddressFixture()
+	contract := flow.HexToAddress("0x01")
+
+	tx := unittest.TransactionBodyFixture(func(tb *flow.TransactionBody) {
+		tb.Script = []byte(`
+			import Foo from 0x01
+
+			transaction {}
+		`)
+		tb.Payer = payer
+		tb.ProposalKey = flow.ProposalKey{Address: payer, KeyIndex: 2}
+		tb.Authorizers = []flow.Address{payer}
+		tb.PayloadSignatures = nil
+		tb.EnvelopeSignatures = []flow.TransactionSignature{{Address: payer, KeyIndex: 2}}
+	})
+
+	parent := unittest.BlockHeaderFixture()
+	block := executableBlockWithParent(parent, &tx)
+
+	store := &readCountingStore{reads: make(map[flow.RegisterID]int)}
+	metrics := modulemock.NewExecutionMetrics(t)
+	prefetcher, err := storehouse.NewRegisterPrefetcher(store, unittest.Logger(), metrics, 4)
+	require.NoError(t, err)
+
+	ctx, cancel := irrecoverable.NewMockSignalerContextWithCancel(t, context.Background())
+	prefetcher.Start(ctx)
+	unittest.RequireCloseBefore(t, prefetcher.Ready(), time.Second, "prefetcher did not start")
+	defer func() {
+		cancel()
+		unittest.RequireCloseBefore(t, prefetcher.Done(), time.Second, "prefetcher did not stop")
+	}()
+
+	// waitPrefetched waits for the given number of registers to be prefetched by the job queued by queue
+	waitPrefetched := func(expected int, queue func()) {
+		done := make(chan struct{})
+		metrics.On("ExecutionRegistersPrefetched", expected, mock.Anything).
+			Run(func(mock.Arguments) { close(done) }).
+			Once()
+		queue()
+		unittest.RequireCloseBefore(t, done, time.Second, "registers were not prefetched")
+	}
+
+	predicted := []flow.RegisterID{
+		flow.AccountStatusRegisterID(payer),
+		flow.PublicKeyRegisterID(payer, 2),
+		flow.ContractNamesRegisterID(contract),
+		flow.ContractRegisterID(contract, "Foo"),
+	}
+
+	waitPrefetched(len(predicted), func() { prefetcher.Prefetch(block) })
+	for _, id := range predicted {
+		require.Equal(t, 1, store.readsOf(id))
+	}
+
+	// prefetching the same block again doesn't read the cached registers again
+	waitPrefetched(0, func() { prefetcher.Prefetch(block) })
+
+	// predicted registers are served from the cache
+	metrics.On("ExecutionPrefetchedRegisterRead", true)
+	for _, id := range predicted {
+		value, err := prefetcher.GetRegister(parent.Height, parent.ID(), id)
+		require.NoError(t, err)
+		require.Equal(t, flow.RegisterValue(id.Key), value)
+		require.Equal(t, 1, store.readsOf(id))
+	}
+
+	// other registers are read from the store
+	contractState := flow.NewRegisterID(contract, "state")
+	metrics.On("ExecutionPrefetchedRegisterRead", false).Once()
+	value, err := prefetcher.GetRegister(parent.Height, parent.ID(), contractState)
+	require.NoError(t, err)
+	require.Equal(t, flow.RegisterValue("state"), value)
+	require.Equal(t, 1, store.readsOf(contractState))
+
+	// reads at blocks which are not prefetched are not reported
+	_, err = prefetcher.GetRegister(block.Height(), block.ID(), contractState)
+	require.NoError(t, err)
+	require.Equal(t, 2, store.readsOf(contractState))
+
+	// the executed block loaded the contract, and read a contract register and a payer register
+	payerStatus := flow.AccountStatusRegisterID(payer)
+	result := execution.NewEmptyComputationResult(block)
+	result.CollectionExecutionResultAt(0).UpdateExecutionSnapshot(&snapshot.ExecutionSnapshot{
+		ReadSet: map[flow.RegisterID]struct{}{
+			flow.ContractRegisterID(contract, "Foo"): {},
+			contractState:                            {},
+			flow.NewRegisterID(payer, "state"):       {},
+		},
+		WriteSet: map[flow.RegisterID]flow.RegisterValue{
+			payerStatus: flow.RegisterValue("updated"),
+		},
+	})
+	prefetcher.OnBlockExecuted(result)
+
+	// the registers prefetched at the parent are carried over to the executed block, so only the
+	// contract register learned from the executed block is prefetched for the child block
+	child := executableBlockWithParent(block.Block.Header, &tx)
+	waitPrefetched(1, func() { prefetcher.Prefetch(child) })
+	require.Equal(t, 3, store.readsOf(contractState))
+	require.Equal(t, 0, store.readsOf(flow.NewRegisterID(payer, "state")))
+	for _, id := range predicted {
+		require.Equal(t, 1, store.readsOf(id))
+	}
+
+	// the carried over registers have the values written by the executed block
+	value, err = prefetcher.GetRegister(block.Height(), block.ID(), payerStatus)
+	require.NoError(t, err)
+	require.Equal(t, flow.RegisterValue("updated"), value)
+	value, err = prefetcher.GetRegister(block.Height(), block.ID(), flow.ContractRegisterID(contract, "Foo"))
+	require.NoError(t, err)
+	require.Equal(t, flow.RegisterValue(flow.ContractRegisterID(contract, "Foo").Key), value)
+
+	// the registers of a received collection are prefetched at the latest executed block
+	otherPayer := unittest.RandomAddressFixture()
+	otherTx := unittest.TransactionBodyFixture(func(tb *flow.TransactionBody) {
+		tb.Script = []byte(`
+			import Foo from 0x01
+
+			transaction {}
+		`)
+		tb.Payer = otherPayer
+		tb.ProposalKey = flow.ProposalKey{Address: otherPayer, KeyIndex: 0}
+		tb.Authorizers = []flow.Address{otherPayer}
+		tb.PayloadSignatures = nil
+		tb.EnvelopeSignatures = []flow.TransactionSignature{{Address: otherPayer, KeyIndex: 0}}
+	})
+	collection := &flow.Collection{Transactions: []*flow.TransactionBody{&otherTx}}
+
+	waitPrefetched(2, func() { prefetcher.OnCollection(collection) })
+	for _, id := range []flow.RegisterID{
+		flow.AccountStatusRegisterID(otherPayer),
+		flow.PublicKeyRegisterID(otherPayer, 0),
+	} {
+		require.Equal(t, 1, store.readsOf(id))
+		_, err = prefetcher.GetRegister(block.Height(), block.ID(), id)
+		require.NoError(t, err)
+		require.Equal(t, 1, store.readsOf(id))
+	}
+}
+
+func executableBlockWithParent(parent *flow.Header, txs ...*flow.TransactionBody) *entity.ExecutableBlock {
+	collection := unittest.CompleteCollectionFromTransactions(txs)
+	block := unittest.BlockWithParentFixture(parent)
+	block.Payload.Guarantees = []*flow.CollectionGuarantee{collection.Guarantee}
+	block.Header.PayloadHash = block.Payload.Hash()
+
+	return &entity.ExecutableBlock{
+		Block: block,
+		CompleteCollections: map[flow.Identifier]*entity.CompleteCollection{
+			collection.Guarantee.CollectionID: collection,
+		},
+	}
+}
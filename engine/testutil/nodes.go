@@ -743,6 +743,7 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity bootstrap.NodeInfo, ide
 		pusherEngine,
 		uploader,
 		stopControl,
+		storehouse.NewNoopRegisterPrefetcher(),
 	)
 	require.NoError(t, err)
 	node.ProtocolEvents.AddConsumer(stopControl)
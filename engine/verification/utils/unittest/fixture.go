@@ -302,7 +302,9 @@ func ExecutionResultFixture(t *testing.T,
 			prov,
 			nil,
 			testutil.ProtocolStateWithSourceFixture(source),
-			testMaxConcurrency)
+			testMaxConcurrency,
+			nil,
+			nil)
 		require.NoError(t, err)
 
 		completeColls := make(map[flow.Identifier]*entity.CompleteCollection)
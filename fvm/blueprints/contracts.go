@@ -32,6 +32,9 @@ var setIsContractDeploymentRestrictedTransactionTemplate string
 //go:embed scripts/deployContractTransactionTemplate.cdc
 var DeployContractTransactionTemplate []byte
 
+//go:embed scripts/updateContractTransactionTemplate.cdc
+var UpdateContractTransactionTemplate []byte
+
 // SetContractDeploymentAuthorizersTransaction returns a transaction for updating list of authorized accounts allowed to deploy/update contracts
 func SetContractDeploymentAuthorizersTransaction(serviceAccount flow.Address, authorized []flow.Address) (*flow.TransactionBody, error) {
 	return setContractAuthorizersTransaction(ContractDeploymentAuthorizedAddressesPath, serviceAccount, authorized)
@@ -98,3 +101,12 @@ func DeployContractTransaction(address flow.Address, contract []byte, contractNa
 		AddArgument(jsoncdc.MustEncode(cadence.String(contract))).
 		AddAuthorizer(address)
 }
+
+// UpdateContractTransaction returns a transaction updating the contract with the given name in the account.
+func UpdateContractTransaction(address flow.Address, contract []byte, contractName string) *flow.TransactionBody {
+	return flow.NewTransactionBody().
+		SetScript(UpdateContractTransactionTemplate).
+		AddArgument(jsoncdc.MustEncode(cadence.String(contractName))).
+		AddArgument(jsoncdc.MustEncode(cadence.String(contract))).
+		AddAuthorizer(address)
+}
@@ -41,6 +41,9 @@ var setupStorageForAccountTemplate string
 //go:embed scripts/setupFeesTransactionTemplate.cdc
 var setupFeesTransactionTemplate string
 
+//go:embed scripts/getFeeParametersScript.cdc
+var getFeeParametersScript string
+
 //go:embed scripts/setExecutionMemoryLimit.cdc
 var setExecutionMemoryLimit string
 
@@ -161,6 +164,16 @@ func SetupFeesTransaction(
 		AddAuthorizer(service)
 }
 
+// GetFeeParametersScript returns a script which reads the transaction fee parameters
+// set up by SetupFeesTransaction.
+func GetFeeParametersScript(flowFees flow.Address) []byte {
+	return []byte(templates.ReplaceAddresses(getFeeParametersScript,
+		templates.Environment{
+			FlowFeesAddress: flowFees.Hex(),
+		}),
+	)
+}
+
 // SetExecutionEffortWeightsTransaction creates a transaction that sets up weights for the weighted Meter.
 func SetExecutionEffortWeightsTransaction(
 	service flow.Address,
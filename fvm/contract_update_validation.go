@@ -0,0 +1,137 @@
+package fvm
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	cadenceErrors "github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/parser"
+	"github.com/onflow/cadence/sema"
+	"github.com/onflow/cadence/stdlib"
+
+	"github.com/onflow/flow-go/fvm/blueprints"
+	"github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ValidateContractUpdate validates an update of a contract of the account to the given code, by
+// executing the update against the storage snapshot without committing any of its changes.
+//
+// All reasons for which the update would be rejected are returned as violations of the result.
+// The update is executed without authorization, sequence number and fee checks, so it is only
+// rejected for reasons related to the contract. The block fields of the result are not set.
+func ValidateContractUpdate(
+	vm VM,
+	ctx Context,
+	address flow.Address,
+	code []byte,
+	storageSnapshot snapshot.StorageSnapshot,
+) (
+	*flow.ContractUpdateValidation,
+	error,
+) {
+	validation := &flow.ContractUpdateValidation{
+		Address: address,
+	}
+
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		validation.Violations = contractUpdateViolations(err, "")
+		return validation, nil
+	}
+
+	var names []string
+	for _, declaration := range program.CompositeDeclarations() {
+		if declaration.CompositeKind == common.CompositeKindContract {
+			names = append(names, declaration.Identifier.Identifier)
+		}
+	}
+	for _, declaration := range program.InterfaceDeclarations() {
+		if declaration.CompositeKind == common.CompositeKindContract {
+			names = append(names, declaration.Identifier.Identifier)
+		}
+	}
+	if len(names) != 1 {
+		validation.Violations = []flow.ContractUpdateViolation{{
+			Message: "code must declare exactly one contract or contract interface",
+		}}
+		return validation, nil
+	}
+	validation.Name = names[0]
+
+	tx := blueprints.UpdateContractTransaction(address, code, validation.Name).
+		SetComputeLimit(flow.DefaultMaxTransactionGasLimit).
+		SetPayer(address).
+		SetProposalKey(address, 0, 0)
+
+	_, output, err := vm.Run(
+		NewContextFromParent(
+			ctx,
+			WithAuthorizationChecksEnabled(false),
+			WithSequenceNumberCheckAndIncrementEnabled(false),
+			WithTransactionFeesEnabled(false)),
+		Transaction(tx, 0),
+		storageSnapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	if output.Err != nil {
+		var deploymentErr *stdlib.InvalidContractDeploymentError
+		if errors.As(output.Err, &deploymentErr) {
+			validation.Violations = contractUpdateViolations(deploymentErr.Err, "")
+		} else {
+			// the update was rejected outside of the contract validation, e.g. if the account
+			// is not allowed to update contracts.
+			validation.Violations = []flow.ContractUpdateViolation{{
+				Message: output.Err.Error(),
+			}}
+		}
+	}
+
+	return validation, nil
+}
+
+// contractUpdateViolations flattens the error into the violations it consists of.
+// A non-empty program names the program other than the new code the error is reported for,
+// e.g. the existing code or an imported program. Such violations have no positions.
+func contractUpdateViolations(err error, program string) []flow.ContractUpdateViolation {
+	if parentErr, ok := err.(cadenceErrors.ParentError); ok {
+		switch err := err.(type) {
+		case *sema.ImportedProgramError:
+			program = fmt.Sprintf("imported program %s", err.Location)
+		case *stdlib.OldProgramError:
+			program = "existing code"
+		}
+
+		var violations []flow.ContractUpdateViolation
+		for _, childErr := range parentErr.ChildErrors() {
+			violations = append(violations, contractUpdateViolations(childErr, program)...)
+		}
+		if len(violations) > 0 {
+			return violations
+		}
+	}
+
+	violation := flow.ContractUpdateViolation{
+		Message: err.Error(),
+	}
+	if program != "" {
+		violation.Message = fmt.Sprintf("%s: %s", program, violation.Message)
+	}
+
+	if secondaryErr, ok := err.(cadenceErrors.SecondaryError); ok {
+		violation.Detail = secondaryErr.SecondaryError()
+	}
+
+	if positioned, ok := err.(ast.HasPosition); ok && program == "" {
+		start := positioned.StartPosition()
+		end := positioned.EndPosition(nil)
+		violation.Start = &flow.ContractCodePosition{Line: start.Line, Column: start.Column}
+		violation.End = &flow.ContractCodePosition{Line: end.Line, Column: end.Column}
+	}
+
+	return []flow.ContractUpdateViolation{violation}
+}
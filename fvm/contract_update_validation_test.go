@@ -0,0 +1,209 @@
+package fvm_test
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution/testutil"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestValidateContractUpdate(t *testing.T) {
+	t.Parallel()
+
+	contract := `
+		access(all) contract Foo {
+			access(all) let x: Int
+
+			access(all) resource R {}
+
+			init() {
+				self.x = 1
+			}
+		}
+	`
+
+	deploy := func(
+		t *testing.T,
+		vm fvm.VM,
+		chain flow.Chain,
+		ctx fvm.Context,
+		snapshotTree snapshot.SnapshotTree,
+	) (snapshot.SnapshotTree, flow.Address) {
+		privateKeys, err := testutil.GenerateAccountPrivateKeys(1)
+		require.NoError(t, err)
+
+		snapshotTree, accounts, err := testutil.CreateAccounts(vm, snapshotTree, privateKeys, chain)
+		require.NoError(t, err)
+		account := accounts[0]
+
+		txBody := flow.NewTransactionBody().SetScript([]byte(fmt.Sprintf(`
+			transaction {
+				prepare(signer: auth(AddContract) &Account, service: &Account) {
+					signer.contracts.add(name: "Foo", code: "%s".decodeHex())
+				}
+			}
+		`, hex.EncodeToString([]byte(contract))))).
+			AddAuthorizer(account).
+			AddAuthorizer(chain.ServiceAddress()).
+			SetPayer(chain.ServiceAddress()).
+			SetProposalKey(chain.ServiceAddress(), 0, 0)
+
+		_ = testutil.SignPayload(txBody, account, privateKeys[0])
+		_ = testutil.SignEnvelope(txBody, chain.ServiceAddress(), unittest.ServiceAccountPrivateKey)
+
+		executionSnapshot, output, err := vm.Run(ctx, fvm.Transaction(txBody, 0), snapshotTree)
+		require.NoError(t, err)
+		require.NoError(t, output.Err)
+
+		return snapshotTree.Append(executionSnapshot), account
+	}
+
+	t.Run("valid update",
+		newVMTest().run(
+			func(t *testing.T, vm fvm.VM, chain flow.Chain, ctx fvm.Context, snapshotTree snapshot.SnapshotTree) {
+				snapshotTree, account := deploy(t, vm, chain, ctx, snapshotTree)
+
+				code := `
+					access(all) contract Foo {
+						access(all) let x: Int
+
+						access(all) resource R {}
+
+						access(all) fun double(): Int {
+							return self.x * 2
+						}
+
+						init() {
+							self.x = 1
+						}
+					}
+				`
+
+				validation, err := fvm.ValidateContractUpdate(vm, ctx, account, []byte(code), snapshotTree)
+				require.NoError(t, err)
+				require.True(t, validation.Valid(), "%v", validation.Violations)
+				require.Equal(t, account, validation.Address)
+				require.Equal(t, "Foo", validation.Name)
+
+				// the update is not committed, so the contract cannot be updated with a changed field
+				// if the validation changed the state
+				code = `
+					access(all) contract Foo {
+						access(all) let x: String
+
+						access(all) resource R {}
+
+						init() {
+							self.x = ""
+						}
+					}
+				`
+				validation, err = fvm.ValidateContractUpdate(vm, ctx, account, []byte(code), snapshotTree)
+				require.NoError(t, err)
+				require.False(t, validation.Valid())
+			},
+		),
+	)
+
+	t.Run("incompatible update reports all violations",
+		newVMTest().run(
+			func(t *testing.T, vm fvm.VM, chain flow.Chain, ctx fvm.Context, snapshotTree snapshot.SnapshotTree) {
+				snapshotTree, account := deploy(t, vm, chain, ctx, snapshotTree)
+
+				code := `
+access(all) contract Foo {
+    access(all) let x: String
+
+    access(all) struct R {}
+
+    init() {
+        self.x = ""
+    }
+}
+`
+
+				validation, err := fvm.ValidateContractUpdate(vm, ctx, account, []byte(code), snapshotTree)
+				require.NoError(t, err)
+				require.Equal(t, "Foo", validation.Name)
+				require.Len(t, validation.Violations, 2)
+
+				fieldViolation := validation.Violations[0]
+				require.Contains(t, fieldViolation.Message, "mismatching field `x`")
+				require.NotNil(t, fieldViolation.Start)
+				require.Equal(t, 3, fieldViolation.Start.Line)
+
+				kindViolation := validation.Violations[1]
+				require.Contains(t, kindViolation.Message, "trying to convert resource `R` to a structure")
+				require.NotNil(t, kindViolation.Start)
+				require.Equal(t, 5, kindViolation.Start.Line)
+			},
+		),
+	)
+
+	t.Run("type errors",
+		newVMTest().run(
+			func(t *testing.T, vm fvm.VM, chain flow.Chain, ctx fvm.Context, snapshotTree snapshot.SnapshotTree) {
+				snapshotTree, account := deploy(t, vm, chain, ctx, snapshotTree)
+
+				code := `
+access(all) contract Foo {
+    access(all) let x: Int
+
+    access(all) resource R {}
+
+    init() {
+        self.x = "one"
+    }
+}
+`
+
+				validation, err := fvm.ValidateContractUpdate(vm, ctx, account, []byte(code), snapshotTree)
+				require.NoError(t, err)
+				require.Len(t, validation.Violations, 1)
+				require.Contains(t, validation.Violations[0].Message, "mismatched types")
+				require.NotEmpty(t, validation.Violations[0].Detail)
+				require.Equal(t, &flow.ContractCodePosition{Line: 8, Column: 17}, validation.Violations[0].Start)
+			},
+		),
+	)
+
+	t.Run("parsing errors",
+		newVMTest().run(
+			func(t *testing.T, vm fvm.VM, chain flow.Chain, ctx fvm.Context, snapshotTree snapshot.SnapshotTree) {
+				snapshotTree, account := deploy(t, vm, chain, ctx, snapshotTree)
+
+				validation, err := fvm.ValidateContractUpdate(vm, ctx, account, []byte(`access(all) contract Foo {`), snapshotTree)
+				require.NoError(t, err)
+				require.Empty(t, validation.Name)
+				require.Len(t, validation.Violations, 1)
+				require.NotNil(t, validation.Violations[0].Start)
+
+				validation, err = fvm.ValidateContractUpdate(vm, ctx, account, []byte(`access(all) fun foo() {}`), snapshotTree)
+				require.NoError(t, err)
+				require.Empty(t, validation.Name)
+				require.Len(t, validation.Violations, 1)
+			},
+		),
+	)
+
+	t.Run("contract is not deployed",
+		newVMTest().run(
+			func(t *testing.T, vm fvm.VM, chain flow.Chain, ctx fvm.Context, snapshotTree snapshot.SnapshotTree) {
+				snapshotTree, account := deploy(t, vm, chain, ctx, snapshotTree)
+
+				validation, err := fvm.ValidateContractUpdate(vm, ctx, account, []byte(`access(all) contract Bar {}`), snapshotTree)
+				require.NoError(t, err)
+				require.Equal(t, "Bar", validation.Name)
+				require.Len(t, validation.Violations, 1)
+				require.Nil(t, validation.Violations[0].Start)
+			},
+		),
+	)
+}
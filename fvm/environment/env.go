@@ -29,6 +29,9 @@ type Environment interface {
 	LoggerProvider
 	Logs() []string
 
+	// Programs
+	ProgramsCacheStats() (hits int, misses int)
+
 	// EventEmitter
 	Events() flow.EventsList
 	ServiceEvents() flow.EventsList
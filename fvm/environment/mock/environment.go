@@ -1411,6 +1411,34 @@ func (_m *Environment) ProgramLog(_a0 string) error {
 	return r0
 }
 
+// ProgramsCacheStats provides a mock function with given fields:
+func (_m *Environment) ProgramsCacheStats() (int, int) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProgramsCacheStats")
+	}
+
+	var r0 int
+	var r1 int
+	if rf, ok := ret.Get(0).(func() (int, int)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func() int); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	return r0, r1
+}
+
 // RandomSourceHistory provides a mock function with given fields:
 func (_m *Environment) RandomSourceHistory() ([]byte, error) {
 	ret := _m.Called()
@@ -55,6 +55,10 @@ type Programs struct {
 
 	// dependencyStack tracks programs currently being loaded and their dependencies.
 	dependencyStack *dependencyStack
+
+	// number of address programs loaded from / missing in the cache
+	cacheHits   int
+	cacheMisses int
 }
 
 // NewPrograms constructs a new ProgramHandler
@@ -207,13 +211,22 @@ func (programs *Programs) DecodeArgument(
 }
 
 func (programs *Programs) cacheHit() {
+	programs.cacheHits++
 	programs.metrics.RuntimeTransactionProgramsCacheHit()
 }
 
 func (programs *Programs) cacheMiss() {
+	programs.cacheMisses++
 	programs.metrics.RuntimeTransactionProgramsCacheMiss()
 }
 
+// ProgramsCacheStats returns the number of address programs loaded from the
+// programs cache, and the number of address programs missing in the cache.
+// The stats are not reset by Reset.
+func (programs *Programs) ProgramsCacheStats() (hits int, misses int) {
+	return programs.cacheHits, programs.cacheMisses
+}
+
 // programLoader is used to load a program from a location.
 type programLoader struct {
 	loadFunc        func() (*interpreter.Program, error)
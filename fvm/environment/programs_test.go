@@ -714,6 +714,10 @@ func Test_ProgramsDoubleCounting(t *testing.T) {
 			),
 			output.ComputationIntensities[environment.ComputationKindGetCode])
 
+		// the metrics are reset before calling C
+		require.Equal(t, metrics.CacheHits, output.ProgramsCacheHits)
+		require.Equal(t, metrics.CacheMisses, output.ProgramsCacheMisses)
+
 		entryA := derivedBlockData.GetProgramForTestingOnly(contractALocation)
 		entryA2 := derivedBlockData.GetProgramForTestingOnly(contractA2Location)
 		entryB := derivedBlockData.GetProgramForTestingOnly(contractBLocation)
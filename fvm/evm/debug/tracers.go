@@ -0,0 +1,271 @@
+package debug
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/onflow/go-ethereum/core/tracing"
+	"github.com/onflow/go-ethereum/core/types"
+	"github.com/onflow/go-ethereum/eth/tracers"
+	"github.com/onflow/go-ethereum/eth/tracers/logger"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	// CallTracerName is the name of the tracer returning the tree of calls made by a transaction.
+	CallTracerName = "callTracer"
+	// PrestateTracerName is the name of the tracer returning the state accessed by a transaction,
+	// before its execution, or the state changes made by it in diff mode.
+	PrestateTracerName = "prestateTracer"
+	// StructLoggerName is the name of the tracer returning the opcodes executed by a transaction.
+	StructLoggerName = "structLogger"
+
+	// MaxStructLogs is the max number of opcodes logged by the struct logger, which bounds the
+	// size of its result. A larger or no limit requested by the client is lowered to it.
+	MaxStructLogs = 10_000
+)
+
+// ErrUnsupportedTracer is returned when the requested tracer is not supported.
+var ErrUnsupportedTracer = errors.New("unsupported tracer")
+
+// DefaultTracerConfig is the configuration of the tracer used while executing transactions,
+// whose traces are collected by the CallTracer.
+var DefaultTracerConfig = TracerConfig{
+	Tracer: tracerName,
+	Config: json.RawMessage(tracerConfig),
+}
+
+// TracerConfig selects the tracer used to trace transactions and its configuration,
+// following the tracer options of the debug_trace* Ethereum JSON-RPC methods.
+type TracerConfig struct {
+	// Tracer is the name of the tracer, the call tracer is used if empty.
+	Tracer string `json:"tracer,omitempty"`
+	// Config is the JSON encoded configuration of the tracer, if any.
+	Config json.RawMessage `json:"tracerConfig,omitempty"`
+}
+
+// TraceID returns the ID of the trace of the given transaction produced by the tracer.
+// Traces produced by the default tracer have the same ID as the traces collected
+// while executing transactions.
+func (c TracerConfig) TraceID(txID gethCommon.Hash, blockID flow.Identifier) string {
+	id := TraceID(txID, blockID)
+	if c.isDefault() {
+		return id
+	}
+
+	hash := sha256.Sum256(c.Config)
+	return fmt.Sprintf("%s-%s-%s", id, c.name(), hex.EncodeToString(hash[:8]))
+}
+
+func (c TracerConfig) name() string {
+	if c.Tracer == "" {
+		return CallTracerName
+	}
+	return c.Tracer
+}
+
+func (c TracerConfig) isDefault() bool {
+	return c.name() == DefaultTracerConfig.Tracer && bytes.Equal(c.Config, DefaultTracerConfig.Config)
+}
+
+// NewTracer creates a new transaction tracer from the given configuration.
+// The struct logger logs at most MaxStructLogs opcodes, and doesn't capture memory or return data,
+// since its result would not be bounded otherwise.
+//
+// Expected errors during normal operations:
+//   - ErrUnsupportedTracer if the tracer is not supported.
+//   - any error returned for an invalid tracer configuration.
+func NewTracer(config TracerConfig) (*tracers.Tracer, error) {
+	switch name := config.name(); name {
+	case CallTracerName, PrestateTracerName:
+		return tracers.DefaultDirectory.New(name, &tracers.Context{}, config.Config)
+	case StructLoggerName:
+		var loggerConfig logger.Config
+		if len(config.Config) > 0 {
+			err := json.Unmarshal(config.Config, &loggerConfig)
+			if err != nil {
+				return nil, fmt.Errorf("invalid struct logger config: %w", err)
+			}
+		}
+		if loggerConfig.EnableMemory || loggerConfig.EnableReturnData {
+			return nil, fmt.Errorf("invalid struct logger config: memory and return data capture are not supported")
+		}
+		if loggerConfig.Limit <= 0 || loggerConfig.Limit > MaxStructLogs {
+			loggerConfig.Limit = MaxStructLogs
+		}
+		structLogger := logger.NewStructLogger(&loggerConfig)
+		return &tracers.Tracer{
+			Hooks:     structLogger.Hooks(),
+			GetResult: structLogger.GetResult,
+			Stop:      structLogger.Stop,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedTracer, name)
+	}
+}
+
+// TransactionTrace is the trace of a transaction, as returned by debug_traceBlock.
+type TransactionTrace struct {
+	TxHash gethCommon.Hash `json:"txHash"`
+	Result json.RawMessage `json:"result"`
+}
+
+// BlockTracer traces each transaction of a block with a new tracer created from
+// the same configuration, and collects the trace of each transaction.
+// It is not safe for concurrent use, transactions have to be executed sequentially.
+type BlockTracer struct {
+	config  TracerConfig
+	current *tracers.Tracer
+	txHash  gethCommon.Hash
+	traces  []TransactionTrace
+	err     error
+}
+
+// NewBlockTracer creates a new block tracer.
+//
+// Expected errors during normal operations:
+//   - ErrUnsupportedTracer if the tracer is not supported.
+//   - any error returned for an invalid tracer configuration.
+func NewBlockTracer(config TracerConfig) (*BlockTracer, error) {
+	// check the configuration before execution
+	_, err := NewTracer(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlockTracer{config: config}, nil
+}
+
+// Tracer returns the tracer to execute the transactions of the block with.
+func (t *BlockTracer) Tracer() *tracers.Tracer {
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnTxStart: t.onTxStart,
+			OnTxEnd:   t.onTxEnd,
+			OnEnter: func(depth int, typ byte, from, to gethCommon.Address, input []byte, gas uint64, value *big.Int) {
+				if hooks := t.hooks(); hooks != nil && hooks.OnEnter != nil {
+					hooks.OnEnter(depth, typ, from, to, input, gas, value)
+				}
+			},
+			OnExit: func(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+				if hooks := t.hooks(); hooks != nil && hooks.OnExit != nil {
+					hooks.OnExit(depth, output, gasUsed, err, reverted)
+				}
+			},
+			OnOpcode: func(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+				if hooks := t.hooks(); hooks != nil && hooks.OnOpcode != nil {
+					hooks.OnOpcode(pc, op, gas, cost, scope, rData, depth, err)
+				}
+			},
+			OnFault: func(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, depth int, err error) {
+				if hooks := t.hooks(); hooks != nil && hooks.OnFault != nil {
+					hooks.OnFault(pc, op, gas, cost, scope, depth, err)
+				}
+			},
+			OnGasChange: func(old, new uint64, reason tracing.GasChangeReason) {
+				if hooks := t.hooks(); hooks != nil && hooks.OnGasChange != nil {
+					hooks.OnGasChange(old, new, reason)
+				}
+			},
+			OnBalanceChange: func(addr gethCommon.Address, prev, new *big.Int, reason tracing.BalanceChangeReason) {
+				if hooks := t.hooks(); hooks != nil && hooks.OnBalanceChange != nil {
+					hooks.OnBalanceChange(addr, prev, new, reason)
+				}
+			},
+			OnNonceChange: func(addr gethCommon.Address, prev, new uint64) {
+				if hooks := t.hooks(); hooks != nil && hooks.OnNonceChange != nil {
+					hooks.OnNonceChange(addr, prev, new)
+				}
+			},
+			OnCodeChange: func(addr gethCommon.Address, prevCodeHash gethCommon.Hash, prevCode []byte, codeHash gethCommon.Hash, code []byte) {
+				if hooks := t.hooks(); hooks != nil && hooks.OnCodeChange != nil {
+					hooks.OnCodeChange(addr, prevCodeHash, prevCode, codeHash, code)
+				}
+			},
+			OnStorageChange: func(addr gethCommon.Address, slot gethCommon.Hash, prev, new gethCommon.Hash) {
+				if hooks := t.hooks(); hooks != nil && hooks.OnStorageChange != nil {
+					hooks.OnStorageChange(addr, slot, prev, new)
+				}
+			},
+			OnLog: func(log *types.Log) {
+				if hooks := t.hooks(); hooks != nil && hooks.OnLog != nil {
+					hooks.OnLog(log)
+				}
+			},
+		},
+		GetResult: func() (json.RawMessage, error) {
+			return nil, fmt.Errorf("the results of a block tracer are returned by Traces")
+		},
+		Stop: func(err error) {
+			if t.current != nil {
+				t.current.Stop(err)
+			}
+		},
+	}
+}
+
+// Traces returns the traces of the transactions executed so far, in execution order.
+//
+// Any error is an exception, returned if a trace could not be produced.
+func (t *BlockTracer) Traces() ([]TransactionTrace, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	return t.traces, nil
+}
+
+func (t *BlockTracer) hooks() *tracing.Hooks {
+	if t.current == nil {
+		return nil
+	}
+	return t.current.Hooks
+}
+
+func (t *BlockTracer) onTxStart(vm *tracing.VMContext, tx *types.Transaction, from gethCommon.Address) {
+	tracer, err := NewTracer(t.config)
+	if err != nil {
+		t.err = fmt.Errorf("could not create tracer: %w", err)
+		return
+	}
+
+	t.current = tracer
+	t.txHash = tx.Hash()
+	if tracer.OnTxStart != nil {
+		tracer.OnTxStart(vm, tx, from)
+	}
+}
+
+func (t *BlockTracer) onTxEnd(receipt *types.Receipt, err error) {
+	tracer := t.current
+	if tracer == nil {
+		return
+	}
+	t.current = nil
+
+	if tracer.OnTxEnd != nil {
+		tracer.OnTxEnd(receipt, err)
+	}
+
+	txHash := t.txHash
+	if receipt != nil {
+		txHash = receipt.TxHash
+	}
+
+	result, err := tracer.GetResult()
+	if err != nil {
+		t.err = fmt.Errorf("could not get the trace of transaction %s: %w", txHash, err)
+		return
+	}
+
+	t.traces = append(t.traces, TransactionTrace{
+		TxHash: txHash,
+		Result: result,
+	})
+}
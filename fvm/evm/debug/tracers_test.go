@@ -0,0 +1,107 @@
+package debug_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm/evm/debug"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func Test_TracerConfig(t *testing.T) {
+	txID := gethCommon.HexToHash("0x01")
+	blockID := flow.Identifier{0x02}
+
+	t.Run("default config has the id of the collected traces", func(t *testing.T) {
+		require.Equal(t, debug.TraceID(txID, blockID), debug.DefaultTracerConfig.TraceID(txID, blockID))
+	})
+
+	t.Run("other configs have distinct ids", func(t *testing.T) {
+		configs := []debug.TracerConfig{
+			{},
+			{Tracer: debug.CallTracerName},
+			{Tracer: debug.PrestateTracerName},
+			{Tracer: debug.PrestateTracerName, Config: json.RawMessage(`{"diffMode":true}`)},
+			{Tracer: debug.StructLoggerName},
+		}
+
+		ids := map[string]struct{}{
+			debug.DefaultTracerConfig.TraceID(txID, blockID): {},
+		}
+		for _, config := range configs {
+			ids[config.TraceID(txID, blockID)] = struct{}{}
+		}
+		// the empty config and the call tracer without config are the same
+		require.Len(t, ids, len(configs))
+	})
+
+	t.Run("unsupported tracer", func(t *testing.T) {
+		_, err := debug.NewTracer(debug.TracerConfig{Tracer: "unknown"})
+		require.ErrorIs(t, err, debug.ErrUnsupportedTracer)
+
+		_, err = debug.NewBlockTracer(debug.TracerConfig{Tracer: "unknown"})
+		require.ErrorIs(t, err, debug.ErrUnsupportedTracer)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := debug.NewTracer(debug.TracerConfig{
+			Tracer: debug.StructLoggerName,
+			Config: json.RawMessage(`[]`),
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("unbounded struct logger config", func(t *testing.T) {
+		for _, config := range []string{`{"enableMemory":true}`, `{"enableReturnData":true}`} {
+			_, err := debug.NewTracer(debug.TracerConfig{
+				Tracer: debug.StructLoggerName,
+				Config: json.RawMessage(config),
+			})
+			require.Error(t, err, config)
+		}
+
+		// the limit is lowered to the max instead
+		_, err := debug.NewTracer(debug.TracerConfig{
+			Tracer: debug.StructLoggerName,
+			Config: json.RawMessage(`{"limit":1000000000}`),
+		})
+		require.NoError(t, err)
+	})
+}
+
+func Test_BlockTracer(t *testing.T) {
+	for _, name := range []string{debug.CallTracerName, debug.StructLoggerName} {
+		t.Run(name, func(t *testing.T) {
+			tracer, err := debug.NewBlockTracer(debug.TracerConfig{Tracer: name})
+			require.NoError(t, err)
+
+			from := gethCommon.HexToAddress("0x01")
+			to := gethCommon.HexToAddress("0x02")
+
+			tr := tracer.Tracer()
+			var txHashes []gethCommon.Hash
+			for nonce := uint64(0); nonce < 3; nonce++ {
+				tx := gethTypes.NewTransaction(nonce, to, big.NewInt(1), 100, big.NewInt(10), nil)
+				txHashes = append(txHashes, tx.Hash())
+
+				tr.OnTxStart(nil, tx, from)
+				tr.OnEnter(0, 0, from, to, nil, 100, big.NewInt(1))
+				tr.OnExit(0, nil, 50, nil, false)
+				tr.OnTxEnd(&gethTypes.Receipt{TxHash: tx.Hash(), GasUsed: 50}, nil)
+			}
+
+			traces, err := tracer.Traces()
+			require.NoError(t, err)
+			require.Len(t, traces, len(txHashes))
+			for i, trace := range traces {
+				require.Equal(t, txHashes[i], trace.TxHash)
+				require.True(t, json.Valid(trace.Result))
+			}
+		})
+	}
+}
@@ -3,7 +3,10 @@ package debug
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	"cloud.google.com/go/storage"
@@ -69,3 +72,68 @@ type NoopUploader struct{}
 func (np *NoopUploader) Upload(id string, data json.RawMessage) error {
 	return nil
 }
+
+var _ Uploader = &LocalStore{}
+
+// ErrTraceNotFound is returned when a trace is not stored.
+var ErrTraceNotFound = errors.New("trace not found")
+
+// LocalStore stores traces as files in a local directory, one file per trace ID.
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore creates the trace directory if needed, and returns a store of the traces in it.
+func NewLocalStore(dir string) (*LocalStore, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create trace directory %s: %w", dir, err)
+	}
+
+	return &LocalStore{
+		dir: dir,
+	}, nil
+}
+
+// Upload stores the traces for the given id, replacing any trace stored for it.
+// The traces are written to a temporary file first, so a trace is either fully stored or not at all.
+func (l *LocalStore) Upload(id string, data json.RawMessage) error {
+	file, err := os.CreateTemp(l.dir, id+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("cannot create trace file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(data)
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("cannot write trace file: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("cannot close trace file: %w", err)
+	}
+
+	return os.Rename(file.Name(), l.path(id))
+}
+
+// Download returns the traces stored for the given id.
+//
+// Expected errors during normal operations:
+//   - ErrTraceNotFound if no trace is stored for the id.
+func (l *LocalStore) Download(id string) (json.RawMessage, error) {
+	data, err := os.ReadFile(l.path(id))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrTraceNotFound, id)
+		}
+		return nil, fmt.Errorf("cannot read trace file: %w", err)
+	}
+
+	return data, nil
+}
+
+func (l *LocalStore) path(id string) string {
+	return filepath.Join(l.dir, id+".json")
+}
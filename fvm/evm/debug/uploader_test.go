@@ -103,3 +103,30 @@ func Test_TracerUploaderIntegration(t *testing.T) {
 		require.Equal(t, []byte(traces), readBytes)
 	})
 }
+
+func Test_LocalStore(t *testing.T) {
+	store, err := debug.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	const testID = "test_p"
+
+	_, err = store.Download(testID)
+	require.ErrorIs(t, err, debug.ErrTraceNotFound)
+
+	data := json.RawMessage(`{ "test": 1 }`)
+	err = store.Upload(testID, data)
+	require.NoError(t, err)
+
+	stored, err := store.Download(testID)
+	require.NoError(t, err)
+	require.Equal(t, data, stored)
+
+	// uploading again replaces the stored trace
+	data = json.RawMessage(`{ "test": 2 }`)
+	err = store.Upload(testID, data)
+	require.NoError(t, err)
+
+	stored, err = store.Download(testID)
+	require.NoError(t, err)
+	require.Equal(t, data, stored)
+}
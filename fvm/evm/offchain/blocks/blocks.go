@@ -147,3 +147,51 @@ func (b *Blocks) loadBlockMetaData() (*Meta, error) {
 	}
 	return MetaFromEncoded(data)
 }
+
+// NewLatestCommittedBlocks constructs a blocks type for the latest block committed
+// by the on-chain EVM to the given storage, so calls can be executed on top of
+// the state of a Flow block. The meta data of the latest block is written to the storage.
+func NewLatestCommittedBlocks(
+	chainID flow.ChainID,
+	rootAddress flow.Address,
+	storage types.BackendStorage,
+) (*Blocks, error) {
+	block, err := LatestCommittedBlock(chainID, rootAddress, storage)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := NewBlocks(chainID, rootAddress, storage)
+	if err != nil {
+		return nil, err
+	}
+	err = blocks.storeBlockMetaData(
+		NewMeta(
+			block.Height,
+			block.Timestamp,
+			block.PrevRandao,
+		))
+	if err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// LatestCommittedBlock returns the latest block committed by the on-chain EVM
+// to the given storage, or the genesis block if no block has been committed.
+func LatestCommittedBlock(
+	chainID flow.ChainID,
+	rootAddress flow.Address,
+	storage types.BackendStorageSnapshot,
+) (*types.Block, error) {
+	data, err := storage.GetValue(
+		rootAddress[:],
+		[]byte(handler.BlockStoreLatestBlockKey),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return types.GenesisBlock(chainID), nil
+	}
+	return types.NewBlockFromBytes(data)
+}
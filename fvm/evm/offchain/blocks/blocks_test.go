@@ -1,10 +1,12 @@
 package blocks_test
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/fvm/evm/handler"
 	"github.com/onflow/flow-go/fvm/evm/offchain/blocks"
 	"github.com/onflow/flow-go/fvm/evm/testutils"
 	"github.com/onflow/flow-go/fvm/evm/types"
@@ -53,3 +55,48 @@ func TestBlocks(t *testing.T) {
 	require.Equal(t, timestamp, bm.Timestamp)
 	require.Equal(t, random, bm.Random)
 }
+
+func TestLatestCommittedBlocks(t *testing.T) {
+	storage := testutils.GetSimpleValueStore()
+	chainID := flow.Emulator.Chain().ChainID()
+	rootAddr := flow.Address{1, 2, 3, 4}
+
+	// no committed block - genesis block
+	block, err := blocks.LatestCommittedBlock(chainID, rootAddr, storage)
+	require.NoError(t, err)
+	require.Equal(t, types.GenesisBlock(chainID), block)
+
+	// commit a block
+	committed := types.NewBlock(
+		testutils.RandomCommonHash(t),
+		5,
+		6,
+		big.NewInt(7),
+		testutils.RandomCommonHash(t),
+	)
+	encoded, err := committed.ToBytes()
+	require.NoError(t, err)
+	err = storage.SetValue(rootAddr[:], []byte(handler.BlockStoreLatestBlockKey), encoded)
+	require.NoError(t, err)
+
+	block, err = blocks.LatestCommittedBlock(chainID, rootAddr, storage)
+	require.NoError(t, err)
+	require.Equal(t, committed.Height, block.Height)
+	require.Equal(t, committed.Timestamp, block.Timestamp)
+	require.Equal(t, committed.PrevRandao, block.PrevRandao)
+
+	blks, err := blocks.NewLatestCommittedBlocks(chainID, rootAddr, storage)
+	require.NoError(t, err)
+
+	bm, err := blks.LatestBlock()
+	require.NoError(t, err)
+	require.Equal(t, committed.Height, bm.Height)
+	require.Equal(t, committed.Timestamp, bm.Timestamp)
+	require.Equal(t, committed.PrevRandao, bm.Random)
+
+	ctx, err := blks.BlockContext()
+	require.NoError(t, err)
+	require.Equal(t, committed.Height, ctx.BlockNumber)
+	require.Equal(t, committed.Timestamp, ctx.BlockTimestamp)
+	require.Equal(t, committed.PrevRandao, ctx.Random)
+}
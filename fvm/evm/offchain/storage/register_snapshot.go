@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// RegisterSnapshot adapts a snapshot of the Flow registers to a backend storage snapshot,
+// so the EVM state of a Flow block can be read from the storage of the node.
+type RegisterSnapshot struct {
+	snapshot snapshot.StorageSnapshot
+}
+
+var _ types.BackendStorageSnapshot = &RegisterSnapshot{}
+
+// NewRegisterSnapshot constructs a new RegisterSnapshot using the given register snapshot
+func NewRegisterSnapshot(snapshot snapshot.StorageSnapshot) *RegisterSnapshot {
+	return &RegisterSnapshot{
+		snapshot: snapshot,
+	}
+}
+
+// GetValue reads a register value
+func (s *RegisterSnapshot) GetValue(owner []byte, key []byte) ([]byte, error) {
+	return s.snapshot.Get(flow.RegisterID{
+		Owner: string(owner),
+		Key:   string(key),
+	})
+}
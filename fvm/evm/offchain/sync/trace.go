@@ -0,0 +1,128 @@
+package sync
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence/common"
+
+	"github.com/onflow/flow-go/fvm/evm/debug"
+	"github.com/onflow/flow-go/fvm/evm/events"
+	"github.com/onflow/flow-go/fvm/evm/offchain/blocks"
+	"github.com/onflow/flow-go/fvm/evm/offchain/storage"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// DecodeBlockEvents decodes the EVM events emitted by a Flow block, and returns
+// the transaction events sorted in execution order, and the block event.
+// The block event is nil if no EVM block was executed by the Flow block.
+func DecodeBlockEvents(
+	chainID flow.ChainID,
+	flowEvents []flow.Event,
+) (
+	[]events.TransactionEventPayload,
+	*events.BlockEventPayload,
+	error,
+) {
+	evmContract := common.Address(systemcontracts.SystemContractsForChain(chainID).EVMContract.Address)
+	txEventType := flow.EventType(common.NewAddressLocation(nil, evmContract, string(events.EventTypeTransactionExecuted)).ID())
+	blockEventType := flow.EventType(common.NewAddressLocation(nil, evmContract, string(events.EventTypeBlockExecuted)).ID())
+
+	sorted := make([]flow.Event, len(flowEvents))
+	copy(sorted, flowEvents)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].TransactionIndex == sorted[j].TransactionIndex {
+			return sorted[i].EventIndex < sorted[j].EventIndex
+		}
+		return sorted[i].TransactionIndex < sorted[j].TransactionIndex
+	})
+
+	var txEvents []events.TransactionEventPayload
+	var blockEvent *events.BlockEventPayload
+	for _, event := range sorted {
+		switch event.Type {
+		case txEventType:
+			cadenceEvent, err := events.FlowEventToCadenceEvent(event)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to decode transaction event: %w", err)
+			}
+			payload, err := events.DecodeTransactionEventPayload(cadenceEvent)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to decode transaction event payload: %w", err)
+			}
+			txEvents = append(txEvents, *payload)
+		case blockEventType:
+			cadenceEvent, err := events.FlowEventToCadenceEvent(event)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to decode block event: %w", err)
+			}
+			blockEvent, err = events.DecodeBlockEventPayload(cadenceEvent)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to decode block event payload: %w", err)
+			}
+		}
+	}
+
+	return txEvents, blockEvent, nil
+}
+
+// TraceBlockExecution re-executes the transactions of an EVM block on top of
+// the given snapshot of the storage at the start of the block, and returns the
+// trace of each transaction, produced by a new tracer created from the given
+// configuration. The results of the re-execution are validated against the events.
+//
+// Expected errors during normal operations:
+//   - debug.ErrUnsupportedTracer if the tracer is not supported.
+//   - any error returned for an invalid tracer configuration.
+func TraceBlockExecution(
+	chainID flow.ChainID,
+	rootAddr flow.Address,
+	snapshot types.BackendStorageSnapshot,
+	transactionEvents []events.TransactionEventPayload,
+	blockEvent *events.BlockEventPayload,
+	config debug.TracerConfig,
+) ([]debug.TransactionTrace, error) {
+	if blockEvent == nil {
+		return nil, fmt.Errorf("nil block event has been passed")
+	}
+
+	tracer, err := debug.NewBlockTracer(config)
+	if err != nil {
+		return nil, err
+	}
+
+	state := storage.NewEphemeralStorage(storage.NewReadOnlyStorage(snapshot))
+
+	bp, err := blocks.NewBasicProvider(chainID, state, rootAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create block provider: %w", err)
+	}
+
+	err = bp.OnBlockReceived(blockEvent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive block %d: %w", blockEvent.Height, err)
+	}
+
+	bs, err := bp.GetSnapshotAt(blockEvent.Height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block snapshot at %d: %w", blockEvent.Height, err)
+	}
+
+	err = ReplayBlockExecution(
+		chainID,
+		rootAddr,
+		state,
+		bs,
+		tracer.Tracer(),
+		transactionEvents,
+		blockEvent,
+		true,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay block %d: %w", blockEvent.Height, err)
+	}
+
+	return tracer.Traces()
+}
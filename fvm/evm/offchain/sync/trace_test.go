@@ -0,0 +1,116 @@
+package sync_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/onflow/cadence/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm/evm/debug"
+	"github.com/onflow/flow-go/fvm/evm/events"
+	"github.com/onflow/flow-go/fvm/evm/offchain/sync"
+	. "github.com/onflow/flow-go/fvm/evm/testutils"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func TestTraceBlockExecution(t *testing.T) {
+
+	const chainID = flow.Emulator
+	RunWithTestBackend(t, func(backend *TestBackend) {
+		RunWithTestFlowEVMRootAddress(t, backend, func(rootAddr flow.Address) {
+			RunWithDeployedContract(t,
+				GetStorageTestContract(t), backend, rootAddr, func(testContract *TestContract) {
+					RunWithEOATestAccount(t, backend, rootAddr, func(testAccount *EOATestAccount) {
+						handler := SetupHandler(chainID, backend, rootAddr)
+
+						// clone state before apply transactions
+						snapshot := backend.Clone()
+						gasFeeCollector := RandomAddress(t)
+
+						for i := 0; i < 3; i++ {
+							tx := testAccount.PrepareSignAndEncodeTx(t,
+								testContract.DeployedAt.ToCommon(),
+								testContract.MakeCallData(t, "store", big.NewInt(int64(i))),
+								big.NewInt(0),
+								uint64(100_000),
+								big.NewInt(1),
+							)
+							rs := handler.Run(tx, gasFeeCollector)
+							require.Equal(t, types.ErrorCode(0), rs.ErrorCode)
+						}
+						handler.CommitBlockProposal()
+
+						// the events emitted by the test backend are relabeled with the
+						// types of the events emitted by the EVM contract of the chain,
+						// and reversed to check they are sorted in execution order
+						flowEvents := chainEvents(t, chainID, backend.Events())
+						for i, j := 0, len(flowEvents)-1; i < j; i, j = i+1, j-1 {
+							flowEvents[i], flowEvents[j] = flowEvents[j], flowEvents[i]
+						}
+
+						txEvents, blockEvent, err := sync.DecodeBlockEvents(chainID, flowEvents)
+						require.NoError(t, err)
+						require.NotNil(t, blockEvent)
+						// one for each tx, one for each gas refund
+						require.Len(t, txEvents, 6)
+						for i, txEvent := range txEvents {
+							require.Equal(t, uint16(i), txEvent.Index)
+						}
+
+						configs := []debug.TracerConfig{
+							debug.DefaultTracerConfig,
+							{Tracer: debug.PrestateTracerName},
+							{Tracer: debug.StructLoggerName},
+						}
+						for _, config := range configs {
+							traces, err := sync.TraceBlockExecution(
+								chainID,
+								rootAddr,
+								snapshot.Clone(),
+								txEvents,
+								blockEvent,
+								config,
+							)
+							require.NoError(t, err)
+							require.Len(t, traces, len(txEvents))
+							for i, trace := range traces {
+								require.Equal(t, txEvents[i].Hash, trace.TxHash)
+								require.True(t, json.Valid(trace.Result))
+							}
+						}
+
+						_, err = sync.TraceBlockExecution(
+							chainID,
+							rootAddr,
+							snapshot.Clone(),
+							txEvents,
+							blockEvent,
+							debug.TracerConfig{Tracer: "unknown"},
+						)
+						require.ErrorIs(t, err, debug.ErrUnsupportedTracer)
+					})
+				})
+		})
+	})
+}
+
+func chainEvents(t *testing.T, chainID flow.ChainID, allEvents flow.EventsList) []flow.Event {
+	evmContract := common.Address(systemcontracts.SystemContractsForChain(chainID).EVMContract.Address)
+
+	chainEvents := make([]flow.Event, len(allEvents))
+	for i, event := range allEvents {
+		cadenceEvent, err := events.FlowEventToCadenceEvent(event)
+		require.NoError(t, err)
+
+		location := common.NewAddressLocation(nil, evmContract, cadenceEvent.EventType.QualifiedIdentifier)
+		event.Type = flow.EventType(location.ID())
+		event.EventIndex = 0
+		event.TransactionIndex = uint32(i)
+		chainEvents[i] = event
+	}
+	return chainEvents
+}
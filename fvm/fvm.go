@@ -34,6 +34,8 @@ type ProcedureOutput struct {
 	ComputationUsed        uint64
 	ComputationIntensities meter.MeteredComputationIntensities
 	MemoryEstimate         uint64
+	ProgramsCacheHits      int
+	ProgramsCacheMisses    int
 	Err                    errors.CodedError
 
 	// Output only by script.
@@ -58,6 +60,7 @@ func (output *ProcedureOutput) PopulateEnvironmentValues(
 	output.MemoryEstimate = memoryUsed
 
 	output.ComputationIntensities = env.ComputationIntensities()
+	output.ProgramsCacheHits, output.ProgramsCacheMisses = env.ProgramsCacheStats()
 
 	// if tx failed this will only contain fee deduction events
 	output.Events = env.Events()
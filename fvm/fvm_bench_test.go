@@ -236,7 +236,9 @@ func NewBasicBlockExecutor(tb testing.TB, chain flow.Chain, logger zerolog.Logge
 		prov,
 		nil,
 		testutil.ProtocolStateWithSourceFixture(nil),
-		1) // We're interested in fvm's serial execution time
+		1, // We're interested in fvm's serial execution time
+		nil,
+		nil)
 	require.NoError(tb, err)
 
 	activeSnapshot := snapshot.NewSnapshotTree(
@@ -3,6 +3,8 @@ package errors
 import (
 	stdErrors "errors"
 	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
 )
 
 type Unwrappable interface {
@@ -56,3 +58,36 @@ func (retryableConflictError) IsRetryableConflict() bool {
 func (err *retryableConflictError) Unwrap() error {
 	return err.error
 }
+
+type registerConflictError struct {
+	retryableConflictError
+
+	registerID flow.RegisterID
+}
+
+// NewRetryableRegisterConflictError returns a retryable conflict error caused
+// by the given register.
+func NewRetryableRegisterConflictError(
+	registerID flow.RegisterID,
+	msg string,
+	vals ...interface{},
+) error {
+	return &registerConflictError{
+		retryableConflictError: retryableConflictError{
+			error: fmt.Errorf(msg, vals...),
+		},
+		registerID: registerID,
+	}
+}
+
+// ConflictingRegister returns the register which caused the retryable
+// conflict error, if any.  Conflicts on derived data (e.g. programs) are not
+// caused by a register.
+func ConflictingRegister(err error) (flow.RegisterID, bool) {
+	var conflict *registerConflictError
+	if !stdErrors.As(err, &conflict) {
+		return flow.RegisterID{}, false
+	}
+
+	return conflict.registerID, true
+}
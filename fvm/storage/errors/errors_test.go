@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
 )
 
 func TestIsRetryablelConflictError(t *testing.T) {
@@ -15,3 +17,16 @@ func TestIsRetryablelConflictError(t *testing.T) {
 
 	require.True(t, IsRetryableConflictError(fmt.Errorf("wrapped: %w", err)))
 }
+
+func TestConflictingRegister(t *testing.T) {
+	_, ok := ConflictingRegister(NewRetryableConflictError("no register"))
+	require.False(t, ok)
+
+	registerID := flow.NewRegisterID(flow.HexToAddress("0x1"), "key")
+	err := NewRetryableRegisterConflictError(registerID, "bad %s", "conflict")
+	require.True(t, IsRetryableConflictError(err))
+
+	conflict, ok := ConflictingRegister(fmt.Errorf("wrapped: %w", err))
+	require.True(t, ok)
+	require.Equal(t, registerID, conflict)
+}
@@ -151,7 +151,8 @@ func (txn *TransactionData) validate(
 	for i, writeSet := range updates {
 		hasConflict, registerId := intersect(writeSet, readSet)
 		if hasConflict {
-			return errors.NewRetryableConflictError(
+			return errors.NewRetryableRegisterConflictError(
+				registerId,
 				conflictErrorTemplate,
 				validatedSnapshotTime+logical.Time(i),
 				txn.executionTime,
@@ -373,6 +373,10 @@ func testBlockDataValidateRejectConflict(
 			conflictRegisterId))
 	require.True(t, errors.IsRetryableConflictError(err))
 
+	registerId, ok := errors.ConflictingRegister(err)
+	require.True(t, ok)
+	require.Equal(t, conflictRegisterId, registerId)
+
 	// Validate should not rebase the snapshot tree on error
 	require.Equal(t, baseSnapshotTime, testTxn.SnapshotTime())
 }
@@ -58,6 +58,8 @@ type ContainerConfig struct {
 	AdditionalFlags     []string
 	Debug               bool
 	EnableMetricsServer bool
+	// WithoutRootCheckpoint removes the root checkpoint from the bootstrap folder of an execution node
+	WithoutRootCheckpoint bool
 }
 
 func (c ContainerConfig) WriteKeyFiles(bootstrapDir string, machineAccountAddr sdk.Address, machineAccountKey encodable.MachineAccountPrivKey, role flow.Role) error {
@@ -107,14 +109,15 @@ func NewContainerConfig(nodeName string, conf NodeConfig, networkKey, stakingKey
 	)
 
 	containerConf := ContainerConfig{
-		NodeInfo:            info,
-		ContainerName:       nodeName,
-		LogLevel:            conf.LogLevel,
-		Ghost:               conf.Ghost,
-		AdditionalFlags:     conf.AdditionalFlags,
-		Debug:               conf.Debug,
-		EnableMetricsServer: conf.EnableMetricsServer,
-		Corrupted:           conf.Corrupted,
+		NodeInfo:              info,
+		ContainerName:         nodeName,
+		LogLevel:              conf.LogLevel,
+		Ghost:                 conf.Ghost,
+		AdditionalFlags:       conf.AdditionalFlags,
+		Debug:                 conf.Debug,
+		EnableMetricsServer:   conf.EnableMetricsServer,
+		Corrupted:             conf.Corrupted,
+		WithoutRootCheckpoint: conf.WithoutRootCheckpoint,
 	}
 
 	return containerConf
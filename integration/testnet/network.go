@@ -875,6 +875,12 @@ func (net *FlowNetwork) AddNode(t *testing.T, bootstrapDir string, nodeConf Cont
 	err := io.CopyDirectory(bootstrapDir, nodeBootstrapDir)
 	require.NoError(t, err)
 
+	if nodeConf.WithoutRootCheckpoint {
+		// the root execution state is synced from other execution nodes
+		err = os.RemoveAll(filepath.Join(nodeBootstrapDir, bootstrap.DirnameExecutionState))
+		require.NoError(t, err)
+	}
+
 	// Bind the host directory to the container's database directory
 	// Bind the common bootstrap directory to the container
 	// NOTE: I did this using the approach from:
@@ -1336,14 +1342,15 @@ func setupKeys(networkConf NetworkConfig) ([]ContainerConfig, error) {
 		)
 
 		containerConf := ContainerConfig{
-			NodeInfo:            info,
-			ContainerName:       name,
-			LogLevel:            conf.LogLevel,
-			Ghost:               conf.Ghost,
-			AdditionalFlags:     conf.AdditionalFlags,
-			Debug:               conf.Debug,
-			Corrupted:           conf.Corrupted,
-			EnableMetricsServer: conf.EnableMetricsServer,
+			NodeInfo:              info,
+			ContainerName:         name,
+			LogLevel:              conf.LogLevel,
+			Ghost:                 conf.Ghost,
+			AdditionalFlags:       conf.AdditionalFlags,
+			Debug:                 conf.Debug,
+			Corrupted:             conf.Corrupted,
+			EnableMetricsServer:   conf.EnableMetricsServer,
+			WithoutRootCheckpoint: conf.WithoutRootCheckpoint,
 		}
 
 		confs = append(confs, containerConf)
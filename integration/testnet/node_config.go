@@ -28,6 +28,8 @@ type NodeConfig struct {
 	AdditionalFlags     []string
 	Debug               bool
 	EnableMetricsServer bool
+	// WithoutRootCheckpoint removes the root checkpoint from the bootstrap folder of an execution node
+	WithoutRootCheckpoint bool
 }
 
 func (n NodeConfigs) Filter(filters ...NodeConfigFilter) NodeConfigs {
@@ -156,3 +158,10 @@ func WithMetricsServer() func(config *NodeConfig) {
 		config.EnableMetricsServer = true
 	}
 }
+
+// WithoutRootCheckpoint bootstraps an execution node without the root checkpoint
+func WithoutRootCheckpoint() func(config *NodeConfig) {
+	return func(config *NodeConfig) {
+		config.WithoutRootCheckpoint = true
+	}
+}
@@ -0,0 +1,71 @@
+package execution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	sdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-go/integration/testnet"
+	"github.com/onflow/flow-go/integration/tests/lib"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestStateSyncBootstrap(t *testing.T) {
+	suite.Run(t, new(StateSyncBootstrapSuite))
+}
+
+// StateSyncBootstrapSuite tests an execution node which is bootstrapped without the root checkpoint,
+// and syncs the root execution state from another execution node.
+type StateSyncBootstrapSuite struct {
+	Suite
+	providerID flow.Identifier
+	syncedID   flow.Identifier
+}
+
+func (s *StateSyncBootstrapSuite) SetupTest() {
+	s.providerID = unittest.IdentifierFixture()
+	s.syncedID = unittest.IdentifierFixture()
+
+	s.nodeConfigs = append(s.nodeConfigs,
+		testnet.NewNodeConfig(flow.RoleExecution,
+			testnet.WithID(s.providerID),
+			testnet.WithLogLevel(zerolog.InfoLevel),
+			testnet.WithAdditionalFlag("--enable-state-sync-serving=true"),
+		),
+		testnet.NewNodeConfig(flow.RoleExecution,
+			testnet.WithID(s.syncedID),
+			testnet.WithLogLevel(zerolog.InfoLevel),
+			testnet.WithoutRootCheckpoint(),
+			testnet.WithAdditionalFlag("--enable-state-sync-bootstrap=true"),
+		),
+	)
+
+	s.Suite.SetupTest()
+}
+
+func (s *StateSyncBootstrapSuite) TestBootstrapFromExecutionNodes() {
+	// wait for next height finalized (potentially first height), called blockA
+	currentFinalized := s.BlockState.HighestFinalizedHeight()
+	blockA := s.BlockState.WaitForHighestFinalizedProgress(s.T(), currentFinalized)
+	s.T().Logf("got blockA height %v ID %v\n", blockA.Header.Height, blockA.Header.ID())
+
+	// send transaction
+	tx, err := s.AccessClient().DeployContract(context.Background(), sdk.Identifier(s.net.Root().ID()), lib.CounterContract)
+	require.NoError(s.T(), err, "could not deploy counter")
+
+	txResult, err := s.AccessClient().WaitForExecuted(context.Background(), tx.ID())
+	require.NoError(s.T(), err, "could not wait for tx to be executed")
+	require.NoError(s.T(), txResult.Error)
+
+	// the node which synced the root execution state executes the block with the same result
+	blockID := flow.Identifier(txResult.BlockID)
+	expected := s.ReceiptState.WaitForReceiptFrom(s.T(), blockID, s.providerID)
+	synced := s.ReceiptState.WaitForReceiptFrom(s.T(), blockID, s.syncedID)
+	require.Equal(s.T(), expected.ExecutionResult.ID(), synced.ExecutionResult.ID())
+}
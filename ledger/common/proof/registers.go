@@ -0,0 +1,110 @@
+package proof
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// RegisterPathFinderVersion is the path finder version used by the execution state ledger.
+const RegisterPathFinderVersion = 1
+
+// ErrInvalidRegisterProof is returned when a register proof doesn't prove the registers
+// in the state commitment.
+var ErrInvalidRegisterProof = errors.New("invalid register proof")
+
+// VerifyRegisterBatchProof verifies the encoded batch proof against the state commitment, and returns
+// the proven values of the given registers in the same order. The value of a register which isn't
+// allocated is empty.
+// This only requires the state commitment to be trusted, such as the final state commitment of a
+// sealed execution result, so the registers can be read from an untrusted node.
+// Expected errors:
+//   - ErrInvalidRegisterProof if the proof can't be decoded, is invalid, or doesn't include every register
+func VerifyRegisterBatchProof(
+	encodedProof []byte,
+	commit flow.StateCommitment,
+	registerIDs []flow.RegisterID,
+) ([]flow.RegisterValue, error) {
+	batchProof, err := ledger.DecodeTrieBatchProof(encodedProof)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode proof: %v: %w", err, ErrInvalidRegisterProof)
+	}
+
+	if !VerifyTrieBatchProof(batchProof, ledger.State(commit)) {
+		return nil, fmt.Errorf("proof doesn't match state commitment %v: %w", commit, ErrInvalidRegisterProof)
+	}
+
+	proofs := make(map[ledger.Path]*ledger.TrieProof, len(batchProof.Proofs))
+	for _, p := range batchProof.Proofs {
+		proofs[p.Path] = p
+	}
+
+	values := make([]flow.RegisterValue, len(registerIDs))
+	for i, id := range registerIDs {
+		key := convert.RegisterIDToLedgerKey(id)
+		path, err := pathfinder.KeyToPath(key, RegisterPathFinderVersion)
+		if err != nil {
+			return nil, fmt.Errorf("could not get path of register %v: %w", id, err)
+		}
+
+		p, ok := proofs[path]
+		if !ok {
+			return nil, fmt.Errorf("missing proof of register %v: %w", id, ErrInvalidRegisterProof)
+		}
+
+		// unallocated registers are proven with an empty payload
+		value := p.Payload.Value()
+		if len(value) == 0 {
+			values[i] = flow.RegisterValue{}
+			continue
+		}
+
+		if !p.Inclusion {
+			return nil, fmt.Errorf("non-inclusion proof of register %v has a value: %w", id, ErrInvalidRegisterProof)
+		}
+
+		provenKey, err := p.Payload.Key()
+		if err != nil {
+			return nil, fmt.Errorf("could not decode key of register %v: %v: %w", id, err, ErrInvalidRegisterProof)
+		}
+		if !provenKey.Equals(&key) {
+			return nil, fmt.Errorf("proof of register %v is for key %v: %w", id, provenKey.String(), ErrInvalidRegisterProof)
+		}
+
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+// VerifyRegisterValues verifies that the registers have the given values in the state commitment,
+// according to the encoded batch proof. An empty value means the register isn't allocated.
+// Expected errors:
+//   - ErrInvalidRegisterProof if the proof is invalid, or the values don't match the proven values
+func VerifyRegisterValues(
+	encodedProof []byte,
+	commit flow.StateCommitment,
+	registers flow.RegisterEntries,
+) error {
+	registerIDs := make([]flow.RegisterID, len(registers))
+	for i, register := range registers {
+		registerIDs[i] = register.Key
+	}
+
+	values, err := VerifyRegisterBatchProof(encodedProof, commit, registerIDs)
+	if err != nil {
+		return err
+	}
+
+	for i, register := range registers {
+		if !bytes.Equal(register.Value, values[i]) {
+			return fmt.Errorf("value of register %v doesn't match the proven value: %w", register.Key, ErrInvalidRegisterProof)
+		}
+	}
+	return nil
+}
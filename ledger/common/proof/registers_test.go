@@ -0,0 +1,105 @@
+package proof_test
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/common/proof"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// Test_VerifyRegisterBatchProof tests verifying the values of registers proven by the ledger
+func Test_VerifyRegisterBatchProof(t *testing.T) {
+	owner := unittest.RandomAddressFixture()
+	registers := flow.RegisterEntries{
+		{Key: flow.AccountStatusRegisterID(owner), Value: []byte{1, 2, 3}},
+		{Key: flow.NewRegisterID(owner, "storage"), Value: []byte{4, 5}},
+		{Key: flow.NewRegisterID(unittest.RandomAddressFixture(), "storage"), Value: []byte{6}},
+	}
+	unallocated := flow.NewRegisterID(owner, "unallocated")
+
+	l, err := complete.NewLedger(&fixtures.NoopWAL{}, 100, &metrics.NoopCollector{}, zerolog.Logger{}, complete.DefaultPathFinderVersion)
+	require.NoError(t, err)
+
+	compactor := fixtures.NewNoopCompactor(l)
+	<-compactor.Ready()
+	defer func() {
+		<-l.Done()
+		<-compactor.Done()
+	}()
+
+	keys := make([]ledger.Key, len(registers))
+	values := make([]ledger.Value, len(registers))
+	for i, register := range registers {
+		keys[i] = convert.RegisterIDToLedgerKey(register.Key)
+		values[i] = register.Value
+	}
+	update, err := ledger.NewUpdate(l.InitialState(), keys, values)
+	require.NoError(t, err)
+	state, _, err := l.Set(update)
+	require.NoError(t, err)
+	commit := flow.StateCommitment(state)
+
+	registerIDs := []flow.RegisterID{registers[1].Key, unallocated, registers[0].Key}
+	query, err := ledger.NewQuery(state, []ledger.Key{
+		convert.RegisterIDToLedgerKey(registerIDs[0]),
+		convert.RegisterIDToLedgerKey(registerIDs[1]),
+		convert.RegisterIDToLedgerKey(registerIDs[2]),
+	})
+	require.NoError(t, err)
+	encodedProof, err := l.Prove(query)
+	require.NoError(t, err)
+
+	t.Run("valid proof", func(t *testing.T) {
+		proven, err := proof.VerifyRegisterBatchProof(encodedProof, commit, registerIDs)
+		require.NoError(t, err)
+		require.Equal(t, []flow.RegisterValue{registers[1].Value, {}, registers[0].Value}, proven)
+
+		err = proof.VerifyRegisterValues(encodedProof, commit, flow.RegisterEntries{
+			{Key: registerIDs[0], Value: registers[1].Value},
+			{Key: unallocated, Value: nil},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("wrong value", func(t *testing.T) {
+		err := proof.VerifyRegisterValues(encodedProof, commit, flow.RegisterEntries{
+			{Key: registerIDs[0], Value: []byte{9}},
+		})
+		require.ErrorIs(t, err, proof.ErrInvalidRegisterProof)
+
+		// an allocated register can't be claimed as unallocated
+		err = proof.VerifyRegisterValues(encodedProof, commit, flow.RegisterEntries{
+			{Key: registerIDs[2], Value: nil},
+		})
+		require.ErrorIs(t, err, proof.ErrInvalidRegisterProof)
+	})
+
+	t.Run("wrong state commitment", func(t *testing.T) {
+		_, err := proof.VerifyRegisterBatchProof(encodedProof, unittest.StateCommitmentFixture(), registerIDs)
+		require.ErrorIs(t, err, proof.ErrInvalidRegisterProof)
+	})
+
+	t.Run("register not in proof", func(t *testing.T) {
+		_, err := proof.VerifyRegisterBatchProof(encodedProof, commit, []flow.RegisterID{registers[2].Key})
+		require.ErrorIs(t, err, proof.ErrInvalidRegisterProof)
+	})
+
+	t.Run("corrupted proof", func(t *testing.T) {
+		corrupted := append([]byte{}, encodedProof...)
+		corrupted[len(corrupted)-1] ^= 0xff
+		_, err := proof.VerifyRegisterBatchProof(corrupted, commit, registerIDs)
+		require.ErrorIs(t, err, proof.ErrInvalidRegisterProof)
+
+		_, err = proof.VerifyRegisterBatchProof(corrupted[:len(corrupted)/2], commit, registerIDs)
+		require.ErrorIs(t, err, proof.ErrInvalidRegisterProof)
+	})
+}
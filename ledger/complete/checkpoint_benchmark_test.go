@@ -32,9 +32,17 @@ func BenchmarkStoreCheckpointV6Concurrently(b *testing.B) {
 	benchmarkStoreCheckpoint(b, 6, true)
 }
 
+func BenchmarkStoreCheckpointV7(b *testing.B) {
+	benchmarkStoreCheckpoint(b, 7, false)
+}
+
+func BenchmarkStoreCheckpointV7Concurrently(b *testing.B) {
+	benchmarkStoreCheckpoint(b, 7, true)
+}
+
 func benchmarkStoreCheckpoint(b *testing.B, version int, concurrent bool) {
-	if version != 5 && version != 6 {
-		b.Fatalf("checkpoint file version must be 5 or 6, version %d isn't supported", version)
+	if version != 5 && version != 6 && version != 7 {
+		b.Fatalf("checkpoint file version must be 5, 6 or 7, version %d isn't supported", version)
 	}
 
 	log := zerolog.Nop()
@@ -76,6 +84,12 @@ func benchmarkStoreCheckpoint(b *testing.B, version int, concurrent bool) {
 		} else {
 			err = wal.StoreCheckpointV6SingleThread(tries, outputDir, fileName, log)
 		}
+	case 7:
+		if concurrent {
+			err = wal.StoreCheckpointV7Concurrently(tries, outputDir, fileName, log)
+		} else {
+			err = wal.StoreCheckpointV7SingleThread(tries, outputDir, fileName, log)
+		}
 	}
 
 	b.StopTimer()
@@ -86,6 +100,15 @@ func benchmarkStoreCheckpoint(b *testing.B, version int, concurrent bool) {
 	}
 
 	b.ReportMetric(float64(elapsed/time.Millisecond), fmt.Sprintf("storecheckpoint_v%d_time_(ms)", version))
+
+	if version != 5 {
+		size, err := wal.ReadCheckpointFileSize(outputDir, fileName)
+		if err != nil {
+			b.Fatalf("cannot read checkpoint file size: %s", err)
+		}
+		b.ReportMetric(float64(size), fmt.Sprintf("storecheckpoint_v%d_size_(bytes)", version))
+	}
+
 	b.ReportAllocs()
 }
 
@@ -114,3 +137,75 @@ func BenchmarkLoadCheckpoint(b *testing.B) {
 	b.ReportMetric(float64(elapsed/time.Millisecond), "loadcheckpoint_time_(ms)")
 	b.ReportAllocs()
 }
+
+// BenchmarkLoadCheckpointV6 and BenchmarkLoadCheckpointV7 convert the input checkpoint
+// into the given version before loading it, to compare the load time of both versions.
+func BenchmarkLoadCheckpointV6(b *testing.B) {
+	benchmarkLoadCheckpoint(b, 6)
+}
+
+func BenchmarkLoadCheckpointV7(b *testing.B) {
+	benchmarkLoadCheckpoint(b, 7)
+}
+
+func benchmarkLoadCheckpoint(b *testing.B, version int) {
+	// Check if input checkpoint file exists
+	_, err := os.Stat(*checkpointFile)
+	if errors.Is(err, os.ErrNotExist) {
+		b.Fatalf("input checkpoint file %s doesn't exist", *checkpointFile)
+	}
+
+	log := zerolog.Nop()
+
+	dir, fileName := filepath.Split(*checkpointFile)
+	subdir := strconv.FormatInt(time.Now().UnixNano(), 10)
+	outputDir := filepath.Join(dir, subdir)
+	err = os.Mkdir(outputDir, 0755)
+	if err != nil {
+		b.Fatalf("cannot create output dir %s: %s", outputDir, err)
+	}
+	defer func() {
+		// Remove output directory and its contents.
+		os.RemoveAll(outputDir)
+	}()
+
+	// Convert checkpoint to the given version
+	tries, err := wal.LoadCheckpoint(*checkpointFile, log)
+	if err != nil {
+		b.Fatalf("cannot load checkpoint: %s", err)
+	}
+
+	switch version {
+	case 6:
+		err = wal.StoreCheckpointV6Concurrently(tries, outputDir, fileName, log)
+	case 7:
+		err = wal.StoreCheckpointV7Concurrently(tries, outputDir, fileName, log)
+	default:
+		b.Fatalf("checkpoint file version must be 6 or 7, version %d isn't supported", version)
+	}
+	if err != nil {
+		b.Fatalf("cannot store checkpoint: %s", err)
+	}
+
+	size, err := wal.ReadCheckpointFileSize(outputDir, fileName)
+	if err != nil {
+		b.Fatalf("cannot read checkpoint file size: %s", err)
+	}
+
+	start := time.Now()
+	b.ResetTimer()
+
+	// Load checkpoint
+	_, err = wal.LoadCheckpoint(filepath.Join(outputDir, fileName), log)
+
+	b.StopTimer()
+	elapsed := time.Since(start)
+
+	if err != nil {
+		b.Fatalf("cannot load checkpoint : %s", err)
+	}
+
+	b.ReportMetric(float64(elapsed/time.Millisecond), fmt.Sprintf("loadcheckpoint_v%d_time_(ms)", version))
+	b.ReportMetric(float64(size), fmt.Sprintf("loadcheckpoint_v%d_size_(bytes)", version))
+	b.ReportAllocs()
+}
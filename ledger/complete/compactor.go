@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -25,6 +27,28 @@ type WALTrieUpdate struct {
 	TrieCh   <-chan *trie.MTrie // TrieCh channel is used to send new trie from Ledger to Compactor.
 }
 
+// DeltaCheckpointPolicy configures when Compactor creates delta checkpoints, which only store the
+// trie nodes created since the previous checkpoint, instead of full checkpoints.
+// Delta checkpoints are folded into a new full checkpoint when either limit is reached.
+type DeltaCheckpointPolicy struct {
+	// MaxDeltas is the max number of delta checkpoints created on top of a full checkpoint.
+	// Delta checkpoints are disabled if MaxDeltas is 0.
+	MaxDeltas uint
+	// MaxDeltaSizeRatio is the max total size of the delta checkpoints on top of a full checkpoint,
+	// relative to the size of the full checkpoint. There is no size limit if MaxDeltaSizeRatio is 0.
+	MaxDeltaSizeRatio float64
+}
+
+// checkpointChain is the chain of checkpoints created by Compactor since the last full checkpoint.
+type checkpointChain struct {
+	base       int           // number of the full checkpoint
+	baseSize   uint64        // size of the full checkpoint
+	last       int           // number of the last checkpoint in the chain
+	lastTries  []*trie.MTrie // tries of the last checkpoint in the chain
+	deltaCount uint          // number of delta checkpoints in the chain
+	deltaSize  uint64        // total size of the delta checkpoints in the chain
+}
+
 // checkpointResult is a message to communicate checkpointing number and error if any.
 type checkpointResult struct {
 	num int
@@ -59,6 +83,11 @@ type Compactor struct {
 	trieUpdateCh                         <-chan *WALTrieUpdate
 	triggerCheckpointOnNextSegmentFinish *atomic.Bool // to trigger checkpoint manually
 	metrics                              module.WALMetrics
+	deltaPolicy                          DeltaCheckpointPolicy
+	// chain is only accessed when checkpointing, which is limited to one at a time.
+	// chain is nil until the first full checkpoint is created, because tries of
+	// existing checkpoints are unknown.
+	chain *checkpointChain
 }
 
 // NewCompactor creates new Compactor which writes WAL record and triggers
@@ -121,6 +150,12 @@ func NewCompactor(
 	}, nil
 }
 
+// SetDeltaCheckpointPolicy enables delta checkpoints with the given policy.
+// It must be called before Compactor is started.
+func (c *Compactor) SetDeltaCheckpointPolicy(policy DeltaCheckpointPolicy) {
+	c.deltaPolicy = policy
+}
+
 // Subscribe subscribes observer to Compactor.
 func (c *Compactor) Subscribe(observer observable.Observer) {
 	var void struct{}
@@ -190,6 +225,13 @@ func (c *Compactor) run() {
 		lastCheckpointNum = -1
 	}
 
+	deltaCheckpoints, err := c.checkpointer.DeltaCheckpoints()
+	if err != nil {
+		c.logger.Error().Err(err).Msg("compactor failed to get delta checkpoint numbers")
+	} else if len(deltaCheckpoints) > 0 && deltaCheckpoints[len(deltaCheckpoints)-1] > lastCheckpointNum {
+		lastCheckpointNum = deltaCheckpoints[len(deltaCheckpoints)-1]
+	}
+
 	// Compute next checkpoint number.
 	// nextCheckpointNum is updated when checkpointing starts, fails to start, or fails.
 	// NOTE: next checkpoint number must >= active segment num.
@@ -292,9 +334,30 @@ Loop:
 // Since this function is only for checkpointing, Compactor isn't affected by returned error.
 func (c *Compactor) checkpoint(ctx context.Context, tries []*trie.MTrie, checkpointNum int) error {
 
-	err := createCheckpoint(c.checkpointer, c.logger, tries, checkpointNum, c.metrics)
-	if err != nil {
-		return &createCheckpointError{num: checkpointNum, err: err}
+	if c.shouldCreateDeltaCheckpoint() {
+		size, err := createDeltaCheckpoint(c.checkpointer, c.logger, c.chain, tries, checkpointNum)
+		if err != nil {
+			return &createCheckpointError{num: checkpointNum, err: err}
+		}
+
+		c.chain.last = checkpointNum
+		c.chain.lastTries = tries
+		c.chain.deltaCount++
+		c.chain.deltaSize += size
+	} else {
+		size, err := createCheckpoint(c.checkpointer, c.logger, tries, checkpointNum, c.metrics)
+		if err != nil {
+			return &createCheckpointError{num: checkpointNum, err: err}
+		}
+
+		if c.deltaPolicy.MaxDeltas > 0 {
+			c.chain = &checkpointChain{
+				base:      checkpointNum,
+				baseSize:  size,
+				last:      checkpointNum,
+				lastTries: tries,
+			}
+		}
 	}
 
 	// Return if context is canceled.
@@ -304,7 +367,7 @@ func (c *Compactor) checkpoint(ctx context.Context, tries []*trie.MTrie, checkpo
 	default:
 	}
 
-	err = cleanupCheckpoints(c.checkpointer, int(c.checkpointsToKeep))
+	err := cleanupCheckpoints(c.checkpointer, int(c.checkpointsToKeep))
 	if err != nil {
 		return &removeCheckpointError{err: err}
 	}
@@ -326,10 +389,34 @@ func (c *Compactor) checkpoint(ctx context.Context, tries []*trie.MTrie, checkpo
 	return nil
 }
 
+// shouldCreateDeltaCheckpoint returns true if the next checkpoint should be a delta checkpoint
+// according to the delta checkpoint policy, and false if it should be a full checkpoint.
+func (c *Compactor) shouldCreateDeltaCheckpoint() bool {
+	if c.deltaPolicy.MaxDeltas == 0 || c.chain == nil {
+		return false
+	}
+
+	if c.chain.deltaCount >= c.deltaPolicy.MaxDeltas {
+		c.logger.Info().Msgf("folding %d delta checkpoints based on checkpoint %d into full checkpoint",
+			c.chain.deltaCount, c.chain.base)
+		return false
+	}
+
+	if c.deltaPolicy.MaxDeltaSizeRatio > 0 &&
+		float64(c.chain.deltaSize) > c.deltaPolicy.MaxDeltaSizeRatio*float64(c.chain.baseSize) {
+		c.logger.Info().Msgf("folding delta checkpoints based on checkpoint %d of total size %d into full checkpoint, "+
+			"size of checkpoint %d is %d", c.chain.base, c.chain.deltaSize, c.chain.base, c.chain.baseSize)
+		return false
+	}
+
+	return true
+}
+
 // createCheckpoint creates checkpoint with given checkpointNum and tries.
+// It returns the size of the checkpoint.
 // Errors indicate that checkpoint file can't be created.
 // Caller should handle returned errors by retrying checkpointing when appropriate.
-func createCheckpoint(checkpointer *realWAL.Checkpointer, logger zerolog.Logger, tries []*trie.MTrie, checkpointNum int, metrics module.WALMetrics) error {
+func createCheckpoint(checkpointer *realWAL.Checkpointer, logger zerolog.Logger, tries []*trie.MTrie, checkpointNum int, metrics module.WALMetrics) (uint64, error) {
 
 	logger.Info().Msgf("serializing checkpoint %d with %v tries", checkpointNum, len(tries))
 
@@ -338,12 +425,12 @@ func createCheckpoint(checkpointer *realWAL.Checkpointer, logger zerolog.Logger,
 	fileName := realWAL.NumberToFilename(checkpointNum)
 	err := realWAL.StoreCheckpointV6SingleThread(tries, checkpointer.Dir(), fileName, logger)
 	if err != nil {
-		return fmt.Errorf("error serializing checkpoint (%d): %w", checkpointNum, err)
+		return 0, fmt.Errorf("error serializing checkpoint (%d): %w", checkpointNum, err)
 	}
 
 	size, err := realWAL.ReadCheckpointFileSize(checkpointer.Dir(), fileName)
 	if err != nil {
-		return fmt.Errorf("error reading checkpoint file size (%d): %w", checkpointNum, err)
+		return 0, fmt.Errorf("error reading checkpoint file size (%d): %w", checkpointNum, err)
 	}
 
 	metrics.ExecutionCheckpointSize(size)
@@ -351,7 +438,39 @@ func createCheckpoint(checkpointer *realWAL.Checkpointer, logger zerolog.Logger,
 	duration := time.Since(startTime)
 	logger.Info().Float64("total_time_s", duration.Seconds()).Msgf("created checkpoint %d", checkpointNum)
 
-	return nil
+	return size, nil
+}
+
+// createDeltaCheckpoint creates delta checkpoint with given checkpointNum and tries,
+// based on the last checkpoint of the given chain.
+// It returns the size of the delta checkpoint.
+// Errors indicate that delta checkpoint file can't be created.
+// Caller should handle returned errors by retrying checkpointing when appropriate.
+func createDeltaCheckpoint(checkpointer *realWAL.Checkpointer, logger zerolog.Logger, chain *checkpointChain, tries []*trie.MTrie, checkpointNum int) (uint64, error) {
+
+	logger.Info().Msgf("serializing delta checkpoint %d with %v tries based on checkpoint %d",
+		checkpointNum, len(tries), chain.last)
+
+	startTime := time.Now()
+
+	fileName := realWAL.NumberToDeltaFilename(checkpointNum)
+	err := realWAL.StoreDeltaCheckpoint(checkpointer.Dir(), fileName, logger, chain.base, chain.last, chain.lastTries, tries)
+	if err != nil {
+		return 0, fmt.Errorf("error serializing delta checkpoint (%d): %w", checkpointNum, err)
+	}
+
+	fileInfo, err := os.Stat(path.Join(checkpointer.Dir(), fileName))
+	if err != nil {
+		return 0, fmt.Errorf("error reading delta checkpoint file size (%d): %w", checkpointNum, err)
+	}
+
+	duration := time.Since(startTime)
+	logger.Info().
+		Float64("total_time_s", duration.Seconds()).
+		Int64("size", fileInfo.Size()).
+		Msgf("created delta checkpoint %d", checkpointNum)
+
+	return uint64(fileInfo.Size()), nil
 }
 
 // cleanupCheckpoints deletes prior checkpoint files if needed.
@@ -375,7 +494,35 @@ func cleanupCheckpoints(checkpointer *realWAL.Checkpointer, checkpointsToKeep in
 				return fmt.Errorf("cannot remove checkpoint %d: %w", checkpoint, err)
 			}
 		}
+		checkpoints = checkpoints[len(checkpointsToRemove):]
 	}
+
+	// remove delta checkpoints which can't be loaded anymore, because their base has been removed
+	deltaCheckpoints, err := checkpointer.DeltaCheckpoints()
+	if err != nil {
+		return fmt.Errorf("cannot list delta checkpoints: %w", err)
+	}
+
+	keptCheckpoints := make(map[int]struct{}, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		keptCheckpoints[checkpoint] = struct{}{}
+	}
+
+	for _, deltaCheckpoint := range deltaCheckpoints {
+		header, err := checkpointer.ReadDeltaCheckpointHeader(deltaCheckpoint)
+		if err == nil {
+			if _, ok := keptCheckpoints[header.Base]; ok {
+				continue
+			}
+		}
+
+		// delta checkpoints which can't be read are removed as well
+		err = checkpointer.RemoveDeltaCheckpoint(deltaCheckpoint)
+		if err != nil {
+			return fmt.Errorf("cannot remove delta checkpoint %d: %w", deltaCheckpoint, err)
+		}
+	}
+
 	return nil
 }
 
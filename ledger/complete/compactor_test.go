@@ -284,6 +284,193 @@ func TestCompactorCreation(t *testing.T) {
 	})
 }
 
+// TestCompactorDeltaCheckpoints tests that Compactor creates delta checkpoints based on
+// full checkpoints, folds them into a new full checkpoint according to the policy, and
+// that the ledger can be recovered from a delta chain, even if the chain is broken.
+func TestCompactorDeltaCheckpoints(t *testing.T) {
+	const (
+		numInsPerStep      = 2
+		pathByteSize       = 32
+		minPayloadByteSize = 2 << 15 // 64  KB
+		maxPayloadByteSize = 2 << 16 // 128 KB
+		size               = 12
+		checkpointDistance = 2
+		checkpointsToKeep  = 1
+		maxDeltas          = 2
+		forestCapacity     = size * 10
+		segmentSize        = 32 * 1024 // 32 KB
+	)
+
+	metricsCollector := &metrics.NoopCollector{}
+
+	unittest.RunWithTempDir(t, func(dir string) {
+
+		// saved data after updates
+		savedData := make(map[ledger.RootHash]map[string]*ledger.Payload)
+
+		var latestDelta int
+
+		t.Run("creates delta checkpoints", func(t *testing.T) {
+
+			wal, err := realWAL.NewDiskWAL(unittest.Logger(), nil, metrics.NewNoopCollector(), dir, forestCapacity, pathByteSize, segmentSize)
+			require.NoError(t, err)
+
+			l, err := NewLedger(wal, forestCapacity, metricsCollector, unittest.Logger(), DefaultPathFinderVersion)
+			require.NoError(t, err)
+
+			compactor, err := NewCompactor(l, wal, unittest.Logger(), forestCapacity, checkpointDistance, checkpointsToKeep, atomic.NewBool(false), metrics.NewNoopCollector())
+			require.NoError(t, err)
+			compactor.SetDeltaCheckpointPolicy(DeltaCheckpointPolicy{MaxDeltas: maxDeltas})
+
+			// checkpoints are triggered at segment 1, 3, 5, 7, 9, which are
+			// full, delta, delta, full, delta checkpoints.
+			co := CompactorObserver{fromBound: 9, done: make(chan struct{})}
+			compactor.Subscribe(&co)
+
+			<-compactor.Ready()
+
+			rootState := l.InitialState()
+
+			for i := 0; i < size; i++ {
+				// slow down updating the ledger, because running too fast would cause the previous checkpoint
+				// to not finish and get delayed
+				time.Sleep(LedgerUpdateDelay)
+
+				payloads := testutils.RandomPayloads(numInsPerStep, minPayloadByteSize, maxPayloadByteSize)
+
+				keys := make([]ledger.Key, len(payloads))
+				values := make([]ledger.Value, len(payloads))
+				for i, p := range payloads {
+					k, err := p.Key()
+					require.NoError(t, err)
+					keys[i] = k
+					values[i] = p.Value()
+				}
+
+				update, err := ledger.NewUpdate(rootState, keys, values)
+				require.NoError(t, err)
+
+				newState, _, err := l.Set(update)
+				require.NoError(t, err)
+
+				data := make(map[string]*ledger.Payload, len(keys))
+				for j, k := range keys {
+					data[string(k.CanonicalForm())] = payloads[j]
+				}
+
+				savedData[ledger.RootHash(newState)] = data
+
+				rootState = newState
+			}
+
+			select {
+			case <-co.done:
+				// continue
+			case <-time.After(60 * time.Second):
+				assert.FailNow(t, "timed out")
+			}
+
+			<-l.Done()
+			<-compactor.Done()
+
+			checkpointer, err := wal.NewCheckpointer()
+			require.NoError(t, err)
+
+			checkpoints, err := checkpointer.Checkpoints()
+			require.NoError(t, err)
+			require.Len(t, checkpoints, checkpointsToKeep)
+
+			deltas, err := checkpointer.DeltaCheckpoints()
+			require.NoError(t, err)
+			require.NotEmpty(t, deltas)
+			require.LessOrEqual(t, len(deltas), maxDeltas)
+
+			// deltas based on removed full checkpoints are removed as well
+			for _, delta := range deltas {
+				header, err := checkpointer.ReadDeltaCheckpointHeader(delta)
+				require.NoError(t, err)
+				require.Contains(t, checkpoints, header.Base)
+				require.Greater(t, delta, header.Base)
+			}
+
+			latestDelta = deltas[len(deltas)-1]
+
+			// tries loaded from delta chain match tries replayed from segments
+			triesFromLoadingChain, err := checkpointer.LoadCheckpointChain(latestDelta)
+			require.NoError(t, err)
+
+			triesFromReplayingSegments, err := triesUpToSegment(dir, latestDelta, len(triesFromLoadingChain))
+			require.NoError(t, err)
+
+			require.Equal(t, len(triesFromReplayingSegments), len(triesFromLoadingChain))
+			for i := range triesFromReplayingSegments {
+				require.Equal(t, triesFromReplayingSegments[i].RootHash(), triesFromLoadingChain[i].RootHash())
+			}
+		})
+
+		time.Sleep(2 * time.Second)
+
+		requireLedgerData := func(t *testing.T) {
+			wal, err := realWAL.NewDiskWAL(unittest.Logger(), nil, metrics.NewNoopCollector(), dir, forestCapacity, pathByteSize, segmentSize)
+			require.NoError(t, err)
+
+			l, err := NewLedger(wal, forestCapacity, metricsCollector, unittest.Logger(), DefaultPathFinderVersion)
+			require.NoError(t, err)
+
+			for rootHash, data := range savedData {
+				keys := make([]ledger.Key, 0, len(data))
+				for _, p := range data {
+					k, err := p.Key()
+					require.NoError(t, err)
+					keys = append(keys, k)
+				}
+
+				q, err := ledger.NewQuery(ledger.State(rootHash), keys)
+				require.NoError(t, err)
+
+				values, err := l.Get(q)
+				require.NoError(t, err)
+
+				for i, k := range keys {
+					require.Equal(t, data[string(k.CanonicalForm())].Value(), values[i])
+				}
+			}
+
+			<-wal.Done()
+		}
+
+		t.Run("load data from delta chain and WAL", func(t *testing.T) {
+			requireLedgerData(t)
+		})
+
+		t.Run("load data after crash partway through delta chain", func(t *testing.T) {
+			// truncate the latest delta checkpoint, as if it was partially written
+			filePath := path.Join(dir, realWAL.NumberToDeltaFilename(latestDelta))
+			fileInfo, err := os.Stat(filePath)
+			require.NoError(t, err)
+			require.NoError(t, os.Truncate(filePath, fileInfo.Size()/2))
+
+			requireLedgerData(t)
+		})
+
+		t.Run("load data after losing delta chain", func(t *testing.T) {
+			wal, err := realWAL.NewDiskWAL(unittest.Logger(), nil, metrics.NewNoopCollector(), dir, forestCapacity, pathByteSize, segmentSize)
+			require.NoError(t, err)
+
+			checkpointer, err := wal.NewCheckpointer()
+			require.NoError(t, err)
+
+			deltas, err := checkpointer.DeltaCheckpoints()
+			require.NoError(t, err)
+			for _, delta := range deltas {
+				require.NoError(t, checkpointer.RemoveDeltaCheckpoint(delta))
+			}
+
+			requireLedgerData(t)
+		})
+	})
+}
+
 // TestCompactorSkipCheckpointing tests that only one
 // checkpointing is running at a time.
 func TestCompactorSkipCheckpointing(t *testing.T) {
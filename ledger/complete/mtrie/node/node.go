@@ -189,6 +189,16 @@ func (n *Node) VerifyCachedHash() bool {
 	return verifyCachedHashRecursive(n)
 }
 
+// VerifyCachedHashOfNode verifies the hash of a node is valid, given the cached hashes
+// of its children. Contrary to VerifyCachedHash, it doesn't verify the sub-trie, which
+// is useful for verifying the nodes of a trie one at a time in descendants-first order.
+func (n *Node) VerifyCachedHashOfNode() bool {
+	if n == nil {
+		return true
+	}
+	return n.hashValue == n.computeHash()
+}
+
 // Hash returns the Node's hash value.
 // Do NOT MODIFY returned slice!
 func (n *Node) Hash() hash.Hash {
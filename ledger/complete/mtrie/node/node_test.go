@@ -114,6 +114,19 @@ func Test_VerifyCachedHash(t *testing.T) {
 	require.True(t, n5.VerifyCachedHash())
 }
 
+// Test_VerifyCachedHashOfNode verifies that only the hash of the node itself is verified,
+// given the cached hashes of its children.
+func Test_VerifyCachedHashOfNode(t *testing.T) {
+	path := testutils.PathByUint16(1)
+	payload := testutils.LightPayload(2, 3)
+	invalidLeaf := node.NewNode(0, nil, nil, path, payload, hash.DummyHash)
+	n1 := node.NewLeaf(path, payload, 0)
+	n2 := node.NewInterimNode(1, n1, invalidLeaf)
+	require.False(t, invalidLeaf.VerifyCachedHashOfNode())
+	require.True(t, n2.VerifyCachedHashOfNode())
+	require.False(t, n2.VerifyCachedHash())
+}
+
 // Test_Compactify_EmptySubtrie tests constructing an interim node
 // with pruning/compactification, where both children are empty. We expect
 // the compactified node to be nil, as it represents a completely empty subtrie
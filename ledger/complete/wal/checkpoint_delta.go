@@ -0,0 +1,456 @@
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	utilsio "github.com/onflow/flow-go/utils/io"
+)
+
+// Delta checkpoints store only the trie nodes created since the checkpoint they are based on
+// (their parent), which is either a full checkpoint or another delta checkpoint. The chain of a
+// delta checkpoint always starts with a full checkpoint (its base), and loading a delta checkpoint
+// requires loading its base and all deltas in the chain.
+//
+// A delta checkpoint file contains:
+//   - magic bytes and version
+//   - base checkpoint number
+//   - parent checkpoint number
+//   - node count of the parent checkpoint
+//   - nodes created since the parent checkpoint
+//   - all tries of the checkpoint
+//   - footer with node count and trie count
+//   - CRC32 checksum
+//
+// Nodes are referenced by index. Index 0 means nil, indices from 1 to the node count of the parent
+// checkpoint reference the nodes of the parent checkpoint, and the following indices reference the
+// nodes stored in the delta checkpoint. The nodes of the parent checkpoint are indexed by iterating
+// the unique nodes of its tries in order, see indexForestNodes.
+const deltaCheckpointFilenamePrefix = "checkpoint-delta."
+
+const MagicBytesCheckpointDelta uint16 = 0x2139
+
+const VersionDeltaV1 uint16 = 0x01
+
+const (
+	encCheckpointNumSize = 8
+	deltaHeaderSize      = headerSize + 2*encCheckpointNumSize + encNodeCountSize
+)
+
+// DeltaCheckpointHeader describes the position of a delta checkpoint in its chain.
+type DeltaCheckpointHeader struct {
+	Base            int    // number of the full checkpoint the chain starts with
+	Parent          int    // number of the checkpoint the delta checkpoint is based on
+	ParentNodeCount uint64 // number of unique nodes in the parent checkpoint
+}
+
+func NumberToDeltaFilename(n int) string {
+	return fmt.Sprintf("%s%s", deltaCheckpointFilenamePrefix, NumberToFilenamePart(n))
+}
+
+// DeltaCheckpoints returns the numbers of all delta checkpoint files in the given directory in asc order.
+func DeltaCheckpoints(dir string) ([]int, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list directory [%s] content: %w", dir, err)
+	}
+
+	list := make([]int, 0)
+	for _, fn := range files {
+		fname := fn.Name()
+		if !strings.HasPrefix(fname, deltaCheckpointFilenamePrefix) {
+			continue
+		}
+		k, err := strconv.Atoi(fname[len(deltaCheckpointFilenamePrefix):])
+		if err != nil {
+			continue
+		}
+		list = append(list, k)
+	}
+
+	sort.Ints(list)
+
+	return list, nil
+}
+
+// DeltaCheckpoints returns the numbers of all delta checkpoint files in asc order.
+func (c *Checkpointer) DeltaCheckpoints() ([]int, error) {
+	return DeltaCheckpoints(c.dir)
+}
+
+// HasDeltaCheckpoint returns true if a delta checkpoint with the given number exists.
+func (c *Checkpointer) HasDeltaCheckpoint(checkpoint int) bool {
+	return utilsio.FileExists(path.Join(c.dir, NumberToDeltaFilename(checkpoint)))
+}
+
+// RemoveDeltaCheckpoint removes the delta checkpoint with the given number.
+func (c *Checkpointer) RemoveDeltaCheckpoint(checkpoint int) error {
+	return os.Remove(path.Join(c.dir, NumberToDeltaFilename(checkpoint)))
+}
+
+// ReadDeltaCheckpointHeader reads the header of the delta checkpoint with the given number.
+func (c *Checkpointer) ReadDeltaCheckpointHeader(checkpoint int) (DeltaCheckpointHeader, error) {
+	return ReadDeltaCheckpointHeader(path.Join(c.dir, NumberToDeltaFilename(checkpoint)))
+}
+
+// LoadCheckpointChain loads the checkpoint with the given number. If only a delta checkpoint exists
+// with the number, the tries are rebuilt from the base checkpoint of the delta and all delta
+// checkpoints in its chain.
+func (c *Checkpointer) LoadCheckpointChain(checkpoint int) ([]*trie.MTrie, error) {
+	if utilsio.FileExists(path.Join(c.dir, NumberToFilename(checkpoint))) || !c.HasDeltaCheckpoint(checkpoint) {
+		return c.LoadCheckpoint(checkpoint)
+	}
+
+	// collect the chain of delta checkpoints, from the given delta back to the base
+	chain := make([]int, 0)
+	header, err := c.ReadDeltaCheckpointHeader(checkpoint)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read delta checkpoint %d: %w", checkpoint, err)
+	}
+	chain = append(chain, checkpoint)
+	base := header.Base
+	for header.Parent != base {
+		parent := header.Parent
+		header, err = c.ReadDeltaCheckpointHeader(parent)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read delta checkpoint %d in chain of %d: %w", parent, checkpoint, err)
+		}
+		if header.Base != base {
+			return nil, fmt.Errorf("delta checkpoint %d has base %d, but chain of %d has base %d",
+				parent, header.Base, checkpoint, base)
+		}
+		chain = append(chain, parent)
+	}
+
+	c.wal.log.Info().Int("base", base).Ints("deltas", chain).Msgf("loading delta checkpoint %d", checkpoint)
+
+	tries, err := c.LoadCheckpoint(base)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load base checkpoint %d of delta checkpoint %d: %w", base, checkpoint, err)
+	}
+
+	parent := base
+	for i := len(chain) - 1; i >= 0; i-- {
+		delta := chain[i]
+		tries, err = LoadDeltaCheckpoint(path.Join(c.dir, NumberToDeltaFilename(delta)), parent, tries, c.wal.log)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load delta checkpoint %d: %w", delta, err)
+		}
+		parent = delta
+	}
+
+	return tries, nil
+}
+
+// indexForestNodes returns the index of each unique node of the given tries, in the order
+// in which they would be stored in a checkpoint file. Index 0 is a special case with nil node.
+// It returns the indices and the number of unique nodes.
+func indexForestNodes(tries []*trie.MTrie) (map[*node.Node]uint64, uint64) {
+	indices := make(map[*node.Node]uint64)
+	indices[nil] = 0
+
+	nodeCounter := uint64(1)
+	for _, t := range tries {
+		for itr := flattener.NewUniqueNodeIterator(t.RootNode(), indices); itr.Next(); {
+			indices[itr.Value()] = nodeCounter
+			nodeCounter++
+		}
+	}
+
+	return indices, nodeCounter - 1
+}
+
+// StoreDeltaCheckpoint writes the given tries to a delta checkpoint file, based on the checkpoint
+// with number parent and the given parent tries. Only the nodes which are not part of the parent
+// tries are stored, nodes of the parent tries are referenced by index.
+// The caller must ensure that parentTries are the tries stored in the parent checkpoint, in the same order.
+func StoreDeltaCheckpoint(
+	dir string,
+	fileName string,
+	logger zerolog.Logger,
+	base int,
+	parent int,
+	parentTries []*trie.MTrie,
+	tries []*trie.MTrie,
+) (
+	errToReturn error,
+) {
+	writer, err := CreateCheckpointWriterForFile(dir, fileName, logger)
+	if err != nil {
+		return fmt.Errorf("could not create writer: %w", err)
+	}
+	defer func() {
+		errToReturn = closeAndMergeError(writer, errToReturn)
+	}()
+
+	crc32Writer := NewCRC32Writer(writer)
+
+	scratch := make([]byte, 1024*4)
+
+	// the nodes of the parent tries are indexed first, so that nodes shared
+	// with the parent tries are skipped when storing the nodes of the tries
+	visitedNodes, parentNodeCount := indexForestNodes(parentTries)
+
+	header := scratch[:deltaHeaderSize]
+	binary.BigEndian.PutUint16(header, MagicBytesCheckpointDelta)
+	binary.BigEndian.PutUint16(header[encMagicSize:], VersionDeltaV1)
+	binary.BigEndian.PutUint64(header[headerSize:], uint64(base))
+	binary.BigEndian.PutUint64(header[headerSize+encCheckpointNumSize:], uint64(parent))
+	binary.BigEndian.PutUint64(header[headerSize+2*encCheckpointNumSize:], parentNodeCount)
+
+	_, err = crc32Writer.Write(header)
+	if err != nil {
+		return fmt.Errorf("cannot write delta checkpoint header: %w", err)
+	}
+
+	nodeCounter := parentNodeCount + 1
+	for _, t := range tries {
+		root := t.RootNode()
+		if root == nil {
+			continue
+		}
+		nodeCounter, err = storeUniqueNodes(root, visitedNodes, nodeCounter, scratch, crc32Writer, func(uint64) {})
+		if err != nil {
+			return fmt.Errorf("fail to store nodes for root trie %v: %w", root.Hash(), err)
+		}
+	}
+
+	for _, t := range tries {
+		rootNode := t.RootNode()
+		if !t.IsEmpty() && rootNode.Height() != ledger.NodeMaxHeight {
+			return fmt.Errorf("height of root node must be %d, but is %d",
+				ledger.NodeMaxHeight, rootNode.Height())
+		}
+
+		rootIndex, found := visitedNodes[rootNode]
+		if !found {
+			rootHash := t.RootHash()
+			return fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(rootHash[:]))
+		}
+
+		encTrie := flattener.EncodeTrie(t, rootIndex, scratch)
+		_, err = crc32Writer.Write(encTrie)
+		if err != nil {
+			return fmt.Errorf("cannot serialize trie: %w", err)
+		}
+	}
+
+	nodeCount := nodeCounter - 1 - parentNodeCount // -1 to account for 0 node meaning nil
+	footer := scratch[:encNodeCountSize+encTrieCountSize]
+	binary.BigEndian.PutUint64(footer, nodeCount)
+	binary.BigEndian.PutUint16(footer[encNodeCountSize:], uint16(len(tries)))
+
+	_, err = crc32Writer.Write(footer)
+	if err != nil {
+		return fmt.Errorf("cannot write delta checkpoint footer: %w", err)
+	}
+
+	crc32buf := scratch[:crc32SumSize]
+	binary.BigEndian.PutUint32(crc32buf, crc32Writer.Crc32())
+
+	_, err = writer.Write(crc32buf)
+	if err != nil {
+		return fmt.Errorf("cannot write CRC32: %w", err)
+	}
+
+	logger.Info().
+		Int("base", base).
+		Int("parent", parent).
+		Uint64("node_count", nodeCount).
+		Uint64("parent_node_count", parentNodeCount).
+		Msgf("stored delta checkpoint with %d tries", len(tries))
+
+	return nil
+}
+
+// ReadDeltaCheckpointHeader reads the header of the delta checkpoint file with the given path.
+func ReadDeltaCheckpointHeader(filepath string) (
+	header DeltaCheckpointHeader,
+	errToReturn error,
+) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return DeltaCheckpointHeader{}, fmt.Errorf("cannot open delta checkpoint file %s: %w", filepath, err)
+	}
+	defer func() {
+		errToReturn = closeAndMergeError(file, errToReturn)
+	}()
+
+	return readDeltaCheckpointHeader(file)
+}
+
+func readDeltaCheckpointHeader(reader io.Reader) (DeltaCheckpointHeader, error) {
+	buf := make([]byte, deltaHeaderSize)
+	_, err := io.ReadFull(reader, buf)
+	if err != nil {
+		return DeltaCheckpointHeader{}, fmt.Errorf("cannot read delta checkpoint header: %w", err)
+	}
+
+	magic, version, err := decodeVersion(buf[:headerSize])
+	if err != nil {
+		return DeltaCheckpointHeader{}, err
+	}
+	if magic != MagicBytesCheckpointDelta {
+		return DeltaCheckpointHeader{}, fmt.Errorf("wrong magic bytes, expect %#x, bot got: %#x", MagicBytesCheckpointDelta, magic)
+	}
+	if version != VersionDeltaV1 {
+		return DeltaCheckpointHeader{}, fmt.Errorf("unsupported delta checkpoint version %x", version)
+	}
+
+	return DeltaCheckpointHeader{
+		Base:            int(binary.BigEndian.Uint64(buf[headerSize:])),
+		Parent:          int(binary.BigEndian.Uint64(buf[headerSize+encCheckpointNumSize:])),
+		ParentNodeCount: binary.BigEndian.Uint64(buf[headerSize+2*encCheckpointNumSize:]),
+	}, nil
+}
+
+// LoadDeltaCheckpoint reads the delta checkpoint file with the given path, and rebuilds its tries
+// from the given tries of its parent checkpoint with number parent.
+func LoadDeltaCheckpoint(filepath string, parent int, parentTries []*trie.MTrie, logger zerolog.Logger) (
+	triesToReturn []*trie.MTrie,
+	errToReturn error,
+) {
+	errToReturn = withFile(logger, filepath, func(file *os.File) error {
+		tries, err := readDeltaCheckpoint(file, parent, parentTries, logger)
+		if err != nil {
+			return err
+		}
+		triesToReturn = tries
+		return nil
+	})
+	return triesToReturn, errToReturn
+}
+
+func readDeltaCheckpoint(f *os.File, parent int, parentTries []*trie.MTrie, logger zerolog.Logger) ([]*trie.MTrie, error) {
+	scratch := make([]byte, 1024*4) // must not be less than 1024
+
+	// Read footer to get node count and trie count
+	const footerOffset = encNodeCountSize + encTrieCountSize + crc32SumSize
+	const footerSize = encNodeCountSize + encTrieCountSize // footer doesn't include crc32 sum
+
+	_, err := f.Seek(-footerOffset, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("cannot seek to footer: %w", err)
+	}
+
+	footer := scratch[:footerSize]
+	_, err = io.ReadFull(f, footer)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read footer: %w", err)
+	}
+
+	nodesCount := binary.BigEndian.Uint64(footer)
+	triesCount := binary.BigEndian.Uint16(footer[encNodeCountSize:])
+
+	// every serialized node takes at least one byte, so a larger node count means the
+	// footer is corrupted, for instance if the file was truncated.
+	fileInfo, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get file info: %w", err)
+	}
+	if nodesCount > uint64(fileInfo.Size()) {
+		return nil, fmt.Errorf("delta checkpoint node count %d exceeds file size %d", nodesCount, fileInfo.Size())
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, fmt.Errorf("cannot seek to start of file: %w", err)
+	}
+
+	crcReader := NewCRC32Reader(bufio.NewReaderSize(f, defaultBufioReadSize))
+	var reader io.Reader = crcReader
+
+	header, err := readDeltaCheckpointHeader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if header.Parent != parent {
+		return nil, fmt.Errorf("delta checkpoint is based on checkpoint %d, but got tries of checkpoint %d",
+			header.Parent, parent)
+	}
+
+	parentIndices, parentNodeCount := indexForestNodes(parentTries)
+	if parentNodeCount != header.ParentNodeCount {
+		return nil, fmt.Errorf("delta checkpoint expects %d nodes in parent checkpoint %d, but got %d",
+			header.ParentNodeCount, parent, parentNodeCount)
+	}
+
+	// nodes's element at index 0 is a special, meaning nil, followed by the nodes of
+	// the parent checkpoint and the nodes of the delta checkpoint.
+	nodes := make([]*node.Node, 1+parentNodeCount+nodesCount)
+	for n, index := range parentIndices {
+		nodes[index] = n
+	}
+
+	logger.Info().
+		Int("base", header.Base).
+		Int("parent", header.Parent).
+		Msgf("reading %v delta checkpoint nodes based on %v nodes", nodesCount, parentNodeCount)
+
+	for i := parentNodeCount + 1; i < uint64(len(nodes)); i++ {
+		n, err := flattener.ReadNode(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+			if nodeIndex >= i {
+				return nil, fmt.Errorf("sequence of serialized nodes does not satisfy Descendents-First-Relationship")
+			}
+			return nodes[nodeIndex], nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot read node %d: %w", i, err)
+		}
+		nodes[i] = n
+	}
+
+	tries := make([]*trie.MTrie, triesCount)
+	for i := uint16(0); i < triesCount; i++ {
+		trie, err := flattener.ReadTrie(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+			if nodeIndex >= uint64(len(nodes)) {
+				return nil, fmt.Errorf("sequence of stored nodes doesn't contain node")
+			}
+			return nodes[nodeIndex], nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot read trie %d: %w", i, err)
+		}
+		tries[i] = trie
+	}
+
+	// Read footer again for crc32 computation
+	_, err = io.ReadFull(reader, footer)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read footer: %w", err)
+	}
+
+	calculatedCrc32 := crcReader.Crc32()
+
+	readCrc32, err := readCRC32Sum(reader)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read CRC32: %w", err)
+	}
+
+	if calculatedCrc32 != readCrc32 {
+		return nil, fmt.Errorf("delta checkpoint checksum failed! File contains %x but calculated crc32 is %x",
+			readCrc32, calculatedCrc32)
+	}
+
+	err = ensureReachedEOF(reader)
+	if err != nil {
+		return nil, fmt.Errorf("fail to read delta checkpoint file: %w", err)
+	}
+
+	return tries, nil
+}
@@ -0,0 +1,202 @@
+package wal
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// nextCheckpointTries returns the tries of the next checkpoint, by updating the last of the given tries
+// n times and dropping the oldest tries, as the trie queue of the compactor does.
+func nextCheckpointTries(t *testing.T, tries []*trie.MTrie, n int) []*trie.MTrie {
+	next := make([]*trie.MTrie, 0, len(tries)+n)
+	next = append(next, tries[n:]...)
+
+	activeTrie := tries[len(tries)-1]
+	for i := 0; i < n; i++ {
+		paths, payloads := randNPathPayloads(10)
+		var err error
+		activeTrie, _, err = trie.NewTrieWithUpdatedRegisters(activeTrie, paths, payloads, false)
+		require.NoError(t, err, "update registers")
+		next = append(next, activeTrie)
+	}
+	return next
+}
+
+func TestWriteAndReadDeltaCheckpointChain(t *testing.T) {
+	RunWithWALCheckpointerWithFiles(t, func(t *testing.T, wal *DiskWAL, checkpointer *Checkpointer) {
+		logger := unittest.Logger()
+		dir := checkpointer.Dir()
+
+		baseTries := createMultipleRandomTries(t)
+		require.NoError(t, StoreCheckpointV6SingleThread(baseTries, dir, NumberToFilename(10), logger))
+
+		delta1Tries := nextCheckpointTries(t, baseTries, 5)
+		require.NoError(t, StoreDeltaCheckpoint(dir, NumberToDeltaFilename(11), logger, 10, 10, baseTries, delta1Tries))
+
+		delta2Tries := nextCheckpointTries(t, delta1Tries, 5)
+		require.NoError(t, StoreDeltaCheckpoint(dir, NumberToDeltaFilename(12), logger, 10, 11, delta1Tries, delta2Tries))
+
+		deltas, err := checkpointer.DeltaCheckpoints()
+		require.NoError(t, err)
+		require.Equal(t, []int{11, 12}, deltas)
+
+		// delta checkpoints are not listed as full checkpoints
+		checkpoints, err := checkpointer.Checkpoints()
+		require.NoError(t, err)
+		require.Equal(t, []int{10}, checkpoints)
+
+		header, err := checkpointer.ReadDeltaCheckpointHeader(12)
+		require.NoError(t, err)
+		require.Equal(t, 10, header.Base)
+		require.Equal(t, 11, header.Parent)
+
+		decoded, err := checkpointer.LoadCheckpointChain(12)
+		require.NoError(t, err)
+		requireTriesEqual(t, delta2Tries, decoded)
+
+		decoded, err = checkpointer.LoadCheckpointChain(11)
+		require.NoError(t, err)
+		requireTriesEqual(t, delta1Tries, decoded)
+
+		decoded, err = checkpointer.LoadCheckpointChain(10)
+		require.NoError(t, err)
+		requireTriesEqual(t, baseTries, decoded)
+
+		// delta checkpoints only store the new nodes
+		baseSize, err := ReadCheckpointFileSize(dir, NumberToFilename(10))
+		require.NoError(t, err)
+		deltaInfo, err := os.Stat(path.Join(dir, NumberToDeltaFilename(12)))
+		require.NoError(t, err)
+		require.Less(t, uint64(deltaInfo.Size()), baseSize)
+	})
+}
+
+func TestDeltaCheckpointOfEmptyTrie(t *testing.T) {
+	RunWithWALCheckpointerWithFiles(t, func(t *testing.T, wal *DiskWAL, checkpointer *Checkpointer) {
+		logger := unittest.Logger()
+		dir := checkpointer.Dir()
+
+		baseTries := []*trie.MTrie{trie.NewEmptyMTrie()}
+		require.NoError(t, StoreCheckpointV6SingleThread(baseTries, dir, NumberToFilename(1), logger))
+
+		deltaTries := append(baseTries, createSimpleTrie(t)...)
+		require.NoError(t, StoreDeltaCheckpoint(dir, NumberToDeltaFilename(2), logger, 1, 1, baseTries, deltaTries))
+
+		decoded, err := checkpointer.LoadCheckpointChain(2)
+		require.NoError(t, err)
+		requireTriesEqual(t, deltaTries, decoded)
+	})
+}
+
+func TestReadDeltaCheckpointWithWrongParent(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+
+		baseTries := createMultipleRandomTries(t)
+		deltaTries := nextCheckpointTries(t, baseTries, 5)
+		fileName := NumberToDeltaFilename(11)
+		require.NoError(t, StoreDeltaCheckpoint(dir, fileName, logger, 10, 10, baseTries, deltaTries))
+
+		// parent checkpoint number doesn't match
+		_, err := LoadDeltaCheckpoint(path.Join(dir, fileName), 9, baseTries, logger)
+		require.Error(t, err)
+
+		// parent tries don't match
+		_, err = LoadDeltaCheckpoint(path.Join(dir, fileName), 10, deltaTries, logger)
+		require.Error(t, err)
+
+		decoded, err := LoadDeltaCheckpoint(path.Join(dir, fileName), 10, baseTries, logger)
+		require.NoError(t, err)
+		requireTriesEqual(t, deltaTries, decoded)
+	})
+}
+
+func TestReadDeltaCheckpointChainBroken(t *testing.T) {
+	setup := func(t *testing.T, checkpointer *Checkpointer) []*trie.MTrie {
+		logger := unittest.Logger()
+		dir := checkpointer.Dir()
+
+		baseTries := createMultipleRandomTries(t)
+		require.NoError(t, StoreCheckpointV6SingleThread(baseTries, dir, NumberToFilename(10), logger))
+
+		delta1Tries := nextCheckpointTries(t, baseTries, 5)
+		require.NoError(t, StoreDeltaCheckpoint(dir, NumberToDeltaFilename(11), logger, 10, 10, baseTries, delta1Tries))
+
+		delta2Tries := nextCheckpointTries(t, delta1Tries, 5)
+		require.NoError(t, StoreDeltaCheckpoint(dir, NumberToDeltaFilename(12), logger, 10, 11, delta1Tries, delta2Tries))
+
+		delta3Tries := nextCheckpointTries(t, delta2Tries, 5)
+		require.NoError(t, StoreDeltaCheckpoint(dir, NumberToDeltaFilename(13), logger, 10, 12, delta2Tries, delta3Tries))
+
+		return delta1Tries
+	}
+
+	t.Run("missing delta in chain", func(t *testing.T) {
+		RunWithWALCheckpointerWithFiles(t, func(t *testing.T, wal *DiskWAL, checkpointer *Checkpointer) {
+			delta1Tries := setup(t, checkpointer)
+			require.NoError(t, checkpointer.RemoveDeltaCheckpoint(12))
+
+			_, err := checkpointer.LoadCheckpointChain(13)
+			require.Error(t, err)
+
+			decoded, err := checkpointer.LoadCheckpointChain(11)
+			require.NoError(t, err)
+			requireTriesEqual(t, delta1Tries, decoded)
+		})
+	})
+
+	t.Run("corrupted delta in chain", func(t *testing.T) {
+		RunWithWALCheckpointerWithFiles(t, func(t *testing.T, wal *DiskWAL, checkpointer *Checkpointer) {
+			delta1Tries := setup(t, checkpointer)
+
+			// flip a byte in the middle of the nodes
+			filePath := path.Join(checkpointer.Dir(), NumberToDeltaFilename(12))
+			file, err := os.OpenFile(filePath, os.O_RDWR, 0644)
+			require.NoError(t, err)
+			fileInfo, err := file.Stat()
+			require.NoError(t, err)
+
+			b := make([]byte, 1)
+			offset := fileInfo.Size() / 2
+			_, err = file.ReadAt(b, offset)
+			require.NoError(t, err)
+			b[0] ^= 0xff
+			_, err = file.WriteAt(b, offset)
+			require.NoError(t, err)
+			require.NoError(t, file.Close())
+
+			_, err = checkpointer.LoadCheckpointChain(13)
+			require.Error(t, err)
+
+			_, err = checkpointer.LoadCheckpointChain(12)
+			require.Error(t, err)
+
+			decoded, err := checkpointer.LoadCheckpointChain(11)
+			require.NoError(t, err)
+			requireTriesEqual(t, delta1Tries, decoded)
+		})
+	})
+
+	t.Run("missing base", func(t *testing.T) {
+		RunWithWALCheckpointerWithFiles(t, func(t *testing.T, wal *DiskWAL, checkpointer *Checkpointer) {
+			setup(t, checkpointer)
+			require.NoError(t, checkpointer.RemoveCheckpoint(10))
+
+			_, err := checkpointer.LoadCheckpointChain(11)
+			require.Error(t, err)
+		})
+	})
+}
+
+func TestMergeCheckpointNumbers(t *testing.T) {
+	require.Equal(t, []int{}, mergeCheckpointNumbers(nil, nil))
+	require.Equal(t, []int{1, 3}, mergeCheckpointNumbers([]int{1, 3}, nil))
+	require.Equal(t, []int{1, 2, 3, 4, 5}, mergeCheckpointNumbers([]int{1, 5}, []int{2, 3, 4}))
+	require.Equal(t, []int{1, 2, 3}, mergeCheckpointNumbers([]int{1, 2}, []int{2, 3}))
+}
@@ -0,0 +1,170 @@
+package wal
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+)
+
+// CheckpointPartCount is the number of part files of a checkpoint file of version 6 or 7:
+// the subtrie part files followed by the top level trie part file.
+const CheckpointPartCount = subtrieCount + 1
+
+// CheckpointPartFileName returns the file name of the index-th part file of the checkpoint file.
+func CheckpointPartFileName(fileName string, index int) string {
+	return partFileName(fileName, index)
+}
+
+// ReadCheckpointPartChecksums reads the header file of the checkpoint file, and returns the
+// checkpoint version and the checksums of all part files, in the order of the part files.
+func ReadCheckpointPartChecksums(dir string, fileName string, logger zerolog.Logger) (uint16, []uint32, error) {
+	version, subtrieChecksums, topTrieChecksum, err := readCheckpointHeader(filePathCheckpointHeader(dir, fileName), logger)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if len(subtrieChecksums) != subtrieCount {
+		return 0, nil, fmt.Errorf("expect %v subtrie checksums in checkpoint header, but got %v",
+			subtrieCount, len(subtrieChecksums))
+	}
+
+	return version, append(subtrieChecksums, topTrieChecksum), nil
+}
+
+// CheckpointSubtrieRootHashes returns the hashes of the subtrie roots stored in each subtrie
+// part file of the checkpoint of the given tries. Empty subtries are not stored, so they are skipped.
+func CheckpointSubtrieRootHashes(tries []*trie.MTrie) [][]hash.Hash {
+	subtrieRoots := createSubTrieRoots(tries)
+
+	rootHashes := make([][]hash.Hash, subtrieCount)
+	for i, roots := range subtrieRoots {
+		seen := make(map[*node.Node]struct{}, len(roots))
+		rootHashes[i] = make([]hash.Hash, 0, len(roots))
+		for _, root := range roots {
+			if root == nil {
+				continue
+			}
+			if _, ok := seen[root]; ok {
+				continue
+			}
+			seen[root] = struct{}{}
+			rootHashes[i] = append(rootHashes[i], root.Hash())
+		}
+	}
+	return rootHashes
+}
+
+// VerifyCheckpointSubtriePart verifies the index-th subtrie part file of the checkpoint file
+// independently of the other part files: the checksum of the part file must match the given
+// checksum, the hash of every node must match its payload or the hashes of its children, and
+// the subtrie roots must match the given root hashes.
+func VerifyCheckpointSubtriePart(
+	dir string,
+	fileName string,
+	version uint16,
+	index int,
+	checksum uint32,
+	expectedRootHashes []hash.Hash,
+	logger zerolog.Logger,
+) error {
+	rootHashes := make(map[hash.Hash]struct{})
+
+	err := processCheckpointSubTrie(dir, fileName, version, index, checksum, logger,
+		func(reader io.Reader, nodesCount uint64) error {
+			scratch := make([]byte, 1024*4) // must not be less than 1024
+
+			nodes := make([]*node.Node, nodesCount+1) //+1 for 0 index meaning nil
+			hasParent := make([]bool, nodesCount+1)
+			for i := uint64(1); i <= nodesCount; i++ {
+				n, err := flattener.ReadNode(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+					if nodeIndex >= i {
+						return nil, fmt.Errorf("sequence of serialized nodes does not satisfy Descendents-First-Relationship")
+					}
+					hasParent[nodeIndex] = true
+					return nodes[nodeIndex], nil
+				})
+				if err != nil {
+					return fmt.Errorf("cannot read node %d: %w", i, err)
+				}
+				if !n.VerifyCachedHashOfNode() {
+					return fmt.Errorf("invalid hash of node %d", i)
+				}
+				nodes[i] = n
+			}
+
+			for i := uint64(1); i <= nodesCount; i++ {
+				if !hasParent[i] {
+					rootHashes[nodes[i].Hash()] = struct{}{}
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return fmt.Errorf("invalid %v-th subtrie part file: %w", index, err)
+	}
+
+	expected := make(map[hash.Hash]struct{}, len(expectedRootHashes))
+	for _, h := range expectedRootHashes {
+		expected[h] = struct{}{}
+	}
+
+	if len(expected) != len(rootHashes) {
+		return fmt.Errorf("invalid %v-th subtrie part file: expect %v subtrie roots, but got %v",
+			index, len(expected), len(rootHashes))
+	}
+	for h := range expected {
+		if _, ok := rootHashes[h]; !ok {
+			return fmt.Errorf("invalid %v-th subtrie part file: subtrie root %v not found", index, h)
+		}
+	}
+
+	return nil
+}
+
+// LoadVerifiedCheckpoint loads the trie of the given root hash from the checkpoint file, and
+// verifies the hashes of its top level nodes. The subtrie part files must have been verified
+// with VerifyCheckpointSubtriePart beforehand, so that all nodes of the trie are verified.
+func LoadVerifiedCheckpoint(dir string, fileName string, rootHash ledger.RootHash, logger zerolog.Logger) (*trie.MTrie, error) {
+	tries, err := OpenAndReadCheckpointV6(dir, fileName, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not read checkpoint: %w", err)
+	}
+
+	for _, t := range tries {
+		if t.RootHash() != rootHash {
+			continue
+		}
+
+		err = verifyTopLevelNodes(t.RootNode(), 0)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trie %v in checkpoint: %w", rootHash, err)
+		}
+		return t, nil
+	}
+
+	return nil, fmt.Errorf("checkpoint doesn't contain trie %v", rootHash)
+}
+
+// verifyTopLevelNodes verifies the hashes of the nodes above the subtrie level.
+func verifyTopLevelNodes(n *node.Node, level uint) error {
+	if n == nil || level >= subtrieLevel {
+		return nil
+	}
+
+	if !n.VerifyCachedHashOfNode() {
+		return fmt.Errorf("invalid hash of node %v at level %v", n.Hash(), level)
+	}
+
+	err := verifyTopLevelNodes(n.LeftChild(), level+1)
+	if err != nil {
+		return err
+	}
+	return verifyTopLevelNodes(n.RightChild(), level+1)
+}
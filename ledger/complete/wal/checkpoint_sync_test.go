@@ -0,0 +1,88 @@
+package wal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/common/testutils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestVerifyCheckpointParts(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		fileName := "checkpoint"
+		logger := unittest.Logger()
+		require.NoErrorf(t, StoreCheckpointV7Concurrently(tries, dir, fileName, logger), "fail to store checkpoint")
+
+		version, checksums, err := ReadCheckpointPartChecksums(dir, fileName, logger)
+		require.NoError(t, err)
+		require.Equal(t, VersionV7, version)
+		require.Len(t, checksums, CheckpointPartCount)
+
+		rootHashes := CheckpointSubtrieRootHashes(tries)
+		require.Len(t, rootHashes, subtrieCount)
+		for i := 0; i < subtrieCount; i++ {
+			require.NoError(t, VerifyCheckpointSubtriePart(dir, fileName, version, i, checksums[i], rootHashes[i], logger))
+		}
+
+		last := tries[len(tries)-1]
+		verified, err := LoadVerifiedCheckpoint(dir, fileName, last.RootHash(), logger)
+		require.NoError(t, err)
+		require.Equal(t, last.RootHash(), verified.RootHash())
+		require.Equal(t, last.AllocatedRegCount(), verified.AllocatedRegCount())
+
+		_, err = LoadVerifiedCheckpoint(dir, fileName, ledger.RootHash(unittest.StateCommitmentFixture()), logger)
+		require.Error(t, err)
+	})
+}
+
+func TestVerifyCheckpointSubtriePartWithWrongRootHashes(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		fileName := "checkpoint"
+		logger := unittest.Logger()
+		require.NoErrorf(t, StoreCheckpointV6Concurrently(tries, dir, fileName, logger), "fail to store checkpoint")
+
+		version, checksums, err := ReadCheckpointPartChecksums(dir, fileName, logger)
+		require.NoError(t, err)
+
+		rootHashes := CheckpointSubtrieRootHashes(tries)
+
+		// root hashes of another part file
+		err = VerifyCheckpointSubtriePart(dir, fileName, version, 0, checksums[0], rootHashes[1], logger)
+		require.Error(t, err)
+
+		// missing root hash
+		err = VerifyCheckpointSubtriePart(dir, fileName, version, 0, checksums[0], rootHashes[0][1:], logger)
+		require.Error(t, err)
+
+		// checksum of another part file
+		err = VerifyCheckpointSubtriePart(dir, fileName, version, 0, checksums[1], rootHashes[0], logger)
+		require.Error(t, err)
+	})
+}
+
+func TestLoadVerifiedCheckpointWithInvalidNodeHash(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		// a trie with a single leaf, whose hash doesn't match its payload
+		path := testutils.PathByUint8(0)
+		payload := testutils.LightPayload8('A', 'a')
+		invalidHash := hash.Hash(unittest.StateCommitmentFixture())
+		root := node.NewNode(ledger.NodeMaxHeight, nil, nil, path, payload, invalidHash)
+		invalidTrie, err := trie.NewMTrie(root, 1, uint64(payload.Size()))
+		require.NoError(t, err)
+
+		fileName := "checkpoint"
+		logger := unittest.Logger()
+		require.NoErrorf(t, StoreCheckpointV6Concurrently([]*trie.MTrie{invalidTrie}, dir, fileName, logger), "fail to store checkpoint")
+
+		_, err = LoadVerifiedCheckpoint(dir, fileName, invalidTrie.RootHash(), logger)
+		require.ErrorContains(t, err, "invalid hash")
+	})
+}
@@ -2,6 +2,7 @@ package wal
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/rs/zerolog"
@@ -57,7 +58,7 @@ func OpenAndReadLeafNodesFromCheckpointV6(
 		errToReturn = closeAndMergeError(file, errToReturn)
 	}(f)
 
-	subtrieChecksums, _, err := readCheckpointHeader(filepath, logger)
+	version, subtrieChecksums, _, err := readCheckpointHeader(filepath, logger)
 	if err != nil {
 		return fmt.Errorf("could not read header: %w", err)
 	}
@@ -71,7 +72,7 @@ func OpenAndReadLeafNodesFromCheckpointV6(
 
 	// push leaf nodes to allLeafNodesCh
 	for i, checksum := range subtrieChecksums {
-		err := readCheckpointSubTrieLeafNodes(allLeafNodesCh, dir, fileName, i, checksum, logger)
+		err := readCheckpointSubTrieLeafNodes(allLeafNodesCh, dir, fileName, version, i, checksum, logger)
 		if err != nil {
 			return fmt.Errorf("fail to read checkpoint leaf nodes from %v-th subtrie file: %w", i, err)
 		}
@@ -80,9 +81,9 @@ func OpenAndReadLeafNodesFromCheckpointV6(
 	return nil
 }
 
-func readCheckpointSubTrieLeafNodes(leafNodesCh chan<- *LeafNode, dir string, fileName string, index int, checksum uint32, logger zerolog.Logger) error {
-	return processCheckpointSubTrie(dir, fileName, index, checksum, logger,
-		func(reader *Crc32Reader, nodesCount uint64) error {
+func readCheckpointSubTrieLeafNodes(leafNodesCh chan<- *LeafNode, dir string, fileName string, version uint16, index int, checksum uint32, logger zerolog.Logger) error {
+	return processCheckpointSubTrie(dir, fileName, version, index, checksum, logger,
+		func(reader io.Reader, nodesCount uint64) error {
 			scratch := make([]byte, 1024*4) // must not be less than 1024
 
 			logging := logProgress(fmt.Sprintf("reading %v-th sub trie roots", index), int(nodesCount), logger)
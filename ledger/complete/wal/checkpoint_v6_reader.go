@@ -34,7 +34,7 @@ func ReadTriesRootHash(logger zerolog.Logger, dir string, fileName string) (
 var CheckpointHasRootHash = checkpointHasRootHash
 var CheckpointHasSingleRootHash = checkpointHasSingleRootHash
 
-// readCheckpointV6 reads checkpoint file from a main file and 17 file parts.
+// readCheckpointV6 reads checkpoint file of version 6 or 7 from a main file and 17 file parts.
 // the main file stores:
 //   - version
 //   - checksum of each part file (17 in total)
@@ -52,13 +52,14 @@ func readCheckpointV6(headerFile *os.File, logger zerolog.Logger) ([]*trie.MTrie
 	dir, fileName := filepath.Split(headerPath)
 
 	lg := logger.With().Str("checkpoint_file", headerPath).Logger()
-	lg.Info().Msgf("reading v6 checkpoint file")
 
-	subtrieChecksums, topTrieChecksum, err := readCheckpointHeader(headerPath, logger)
+	version, subtrieChecksums, topTrieChecksum, err := readCheckpointHeader(headerPath, logger)
 	if err != nil {
 		return nil, fmt.Errorf("could not read header: %w", err)
 	}
 
+	lg.Info().Msgf("reading v%d checkpoint file", version)
+
 	// ensure all checkpoint part file exists, might return os.ErrNotExist error
 	// if a file is missing
 	err = allPartFileExist(dir, fileName, len(subtrieChecksums))
@@ -68,15 +69,15 @@ func readCheckpointV6(headerFile *os.File, logger zerolog.Logger) ([]*trie.MTrie
 
 	// TODO making number of goroutine configable for reading subtries, which can help us
 	// test the code on machines that don't have as much RAM as EN by using fewer goroutines.
-	subtrieNodes, err := readSubTriesConcurrently(dir, fileName, subtrieChecksums, lg)
+	subtrieNodes, err := readSubTriesConcurrently(dir, fileName, version, subtrieChecksums, lg)
 	if err != nil {
 		return nil, fmt.Errorf("could not read subtrie from dir: %w", err)
 	}
 
 	lg.Info().Uint32("topsum", topTrieChecksum).
-		Msg("finish reading all v6 subtrie files, start reading top level tries")
+		Msgf("finish reading all v%d subtrie files, start reading top level tries", version)
 
-	tries, err := readTopLevelTries(dir, fileName, subtrieNodes, topTrieChecksum, lg)
+	tries, err := readTopLevelTries(dir, fileName, version, subtrieNodes, topTrieChecksum, lg)
 	if err != nil {
 		return nil, fmt.Errorf("could not read top level nodes or tries: %w", err)
 	}
@@ -90,7 +91,7 @@ func readCheckpointV6(headerFile *os.File, logger zerolog.Logger) ([]*trie.MTrie
 			Uint64("first_reg_count", first.AllocatedRegCount()).
 			Str("last_hash", last.RootHash().String()).
 			Uint64("last_reg_count", last.AllocatedRegCount()).
-			Int("version", 6).
+			Uint16("version", version).
 			Msg("checkpoint tries roots")
 	}
 
@@ -169,16 +170,17 @@ func filePathPattern(dir string, fileName string) string {
 	return fmt.Sprintf("%v*", filePathCheckpointHeader(dir, fileName))
 }
 
-// readCheckpointHeader takes a file path and returns subtrieChecksums and topTrieChecksum
+// readCheckpointHeader takes a file path and returns the checkpoint version, subtrieChecksums and topTrieChecksum
 // any error returned are exceptions
 func readCheckpointHeader(filepath string, logger zerolog.Logger) (
+	checkpointVersion uint16,
 	checksumsOfSubtries []uint32,
 	checksumOfTopTrie uint32,
 	errToReturn error,
 ) {
 	closable, err := os.Open(filepath)
 	if err != nil {
-		return nil, 0, fmt.Errorf("could not open header file: %w", err)
+		return 0, nil, 0, fmt.Errorf("could not open header file: %w", err)
 	}
 
 	defer func(file *os.File) {
@@ -193,22 +195,22 @@ func readCheckpointHeader(filepath string, logger zerolog.Logger) (
 	var bufReader io.Reader = bufio.NewReaderSize(closable, defaultBufioReadSize)
 	reader := NewCRC32Reader(bufReader)
 	// read the magic bytes and check version
-	err = validateFileHeader(MagicBytesCheckpointHeader, VersionV6, reader)
+	version, err := validatePartitionedFileHeader(MagicBytesCheckpointHeader, reader)
 	if err != nil {
-		return nil, 0, err
+		return 0, nil, 0, err
 	}
 
 	// read the subtrie count
 	subtrieCount, err := readSubtrieCount(reader)
 	if err != nil {
-		return nil, 0, err
+		return 0, nil, 0, err
 	}
 
 	subtrieChecksums := make([]uint32, subtrieCount)
 	for i := uint16(0); i < subtrieCount; i++ {
 		sum, err := readCRC32Sum(reader)
 		if err != nil {
-			return nil, 0, fmt.Errorf("could not read %v-th subtrie checksum from checkpoint header: %w", i, err)
+			return 0, nil, 0, fmt.Errorf("could not read %v-th subtrie checksum from checkpoint header: %w", i, err)
 		}
 		subtrieChecksums[i] = sum
 	}
@@ -216,7 +218,7 @@ func readCheckpointHeader(filepath string, logger zerolog.Logger) (
 	// read top level trie checksum
 	topTrieChecksum, err := readCRC32Sum(reader)
 	if err != nil {
-		return nil, 0, fmt.Errorf("could not read checkpoint top level trie checksum in chechpoint summary: %w", err)
+		return 0, nil, 0, fmt.Errorf("could not read checkpoint top level trie checksum in chechpoint summary: %w", err)
 	}
 
 	// calculate the actual checksum
@@ -225,20 +227,20 @@ func readCheckpointHeader(filepath string, logger zerolog.Logger) (
 	// read the stored checksum, and compare with the actual sum
 	expectedSum, err := readCRC32Sum(reader)
 	if err != nil {
-		return nil, 0, fmt.Errorf("could not read checkpoint header checksum: %w", err)
+		return 0, nil, 0, fmt.Errorf("could not read checkpoint header checksum: %w", err)
 	}
 
 	if actualSum != expectedSum {
-		return nil, 0, fmt.Errorf("invalid checksum in checkpoint header, expected %v, actual %v",
+		return 0, nil, 0, fmt.Errorf("invalid checksum in checkpoint header, expected %v, actual %v",
 			expectedSum, actualSum)
 	}
 
 	err = ensureReachedEOF(reader)
 	if err != nil {
-		return nil, 0, fmt.Errorf("fail to read checkpoint header file: %w", err)
+		return 0, nil, 0, fmt.Errorf("fail to read checkpoint header file: %w", err)
 	}
 
-	return subtrieChecksums, topTrieChecksum, nil
+	return version, subtrieChecksums, topTrieChecksum, nil
 }
 
 // allPartFileExist check if all the part files of the checkpoint file exist
@@ -322,7 +324,7 @@ type resultReadSubTrie struct {
 	Err   error
 }
 
-func readSubTriesConcurrently(dir string, fileName string, subtrieChecksums []uint32, logger zerolog.Logger) ([][]*node.Node, error) {
+func readSubTriesConcurrently(dir string, fileName string, version uint16, subtrieChecksums []uint32, logger zerolog.Logger) ([][]*node.Node, error) {
 
 	numOfSubTries := len(subtrieChecksums)
 	jobs := make(chan jobReadSubtrie, numOfSubTries)
@@ -345,7 +347,7 @@ func readSubTriesConcurrently(dir string, fileName string, subtrieChecksums []ui
 	for i := 0; i < nWorker; i++ {
 		go func() {
 			for job := range jobs {
-				nodes, err := readCheckpointSubTrie(dir, fileName, job.Index, job.Checksum, logger)
+				nodes, err := readCheckpointSubTrie(dir, fileName, version, job.Index, job.Checksum, logger)
 				job.Result <- &resultReadSubTrie{
 					Nodes: nodes,
 					Err:   err,
@@ -369,13 +371,13 @@ func readSubTriesConcurrently(dir string, fileName string, subtrieChecksums []ui
 	return nodesGroups, nil
 }
 
-func readCheckpointSubTrie(dir string, fileName string, index int, checksum uint32, logger zerolog.Logger) (
+func readCheckpointSubTrie(dir string, fileName string, version uint16, index int, checksum uint32, logger zerolog.Logger) (
 	[]*node.Node,
 	error,
 ) {
 	var nodes []*node.Node
-	err := processCheckpointSubTrie(dir, fileName, index, checksum, logger,
-		func(reader *Crc32Reader, nodesCount uint64) error {
+	err := processCheckpointSubTrie(dir, fileName, version, index, checksum, logger,
+		func(reader io.Reader, nodesCount uint64) error {
 			scratch := make([]byte, 1024*4) // must not be less than 1024
 
 			nodes = make([]*node.Node, nodesCount+1) //+1 for 0 index meaning nil
@@ -408,16 +410,17 @@ func readCheckpointSubTrie(dir string, fileName string, index int, checksum uint
 
 // subtrie file contains:
 // 1. checkpoint version
-// 2. nodes
+// 2. nodes (compressed in v7)
 // 3. node count
 // 4. checksum
 func processCheckpointSubTrie(
 	dir string,
 	fileName string,
+	version uint16,
 	index int,
 	checksum uint32,
 	logger zerolog.Logger,
-	processNode func(*Crc32Reader, uint64) error,
+	processNode func(io.Reader, uint64) error,
 ) error {
 
 	filepath, _, err := filePathSubTries(dir, fileName, index)
@@ -426,7 +429,7 @@ func processCheckpointSubTrie(
 	}
 	return withFile(logger, filepath, func(f *os.File) error {
 		// valite the magic bytes and version
-		err := validateFileHeader(MagicBytesCheckpointSubtrie, VersionV6, f)
+		err := validateFileHeader(MagicBytesCheckpointSubtrie, version, f)
 		if err != nil {
 			return err
 		}
@@ -441,6 +444,13 @@ func processCheckpointSubTrie(
 				"match with the checksum in subtrie file %v", checksum, expectedSum)
 		}
 
+		// the nodes are stored between the version and the footer
+		fileSize, err := f.Seek(0, io.SeekEnd)
+		if err != nil {
+			return fmt.Errorf("cannot seek to end of file: %w", err)
+		}
+		nodesSize := fileSize - headerSize - (encNodeCountSize + crc32SumSize)
+
 		// restart from the beginning of the file, make sure Crc32Reader has seen all the bytes
 		// in order to compute the correct checksum
 		_, err = f.Seek(0, io.SeekStart)
@@ -456,13 +466,22 @@ func processCheckpointSubTrie(
 			return fmt.Errorf("could not read version again for subtrie: %w", err)
 		}
 
-		// read file part index and verify
+		nodesReader, err := newPartNodesReader(version, reader, nodesSize)
+		if err != nil {
+			return fmt.Errorf("could not create reader for subtrie nodes: %w", err)
+		}
+		defer nodesReader.close()
 
-		err = processNode(reader, nodesCount)
+		err = processNode(nodesReader, nodesCount)
 		if err != nil {
 			return err
 		}
 
+		err = nodesReader.finish()
+		if err != nil {
+			return fmt.Errorf("fail to read nodes of %v-th subtrie file: %w", index, err)
+		}
+
 		scratch := make([]byte, 1024)
 		// read footer and discard, since we only care about checksum
 		_, err = io.ReadFull(reader, scratch[:encNodeCountSize])
@@ -525,12 +544,12 @@ func readSubTriesFooter(f *os.File) (uint64, uint32, error) {
 // 17th part file contains:
 // 1. checkpoint version
 // 2. subtrieNodeCount
-// 3. top level nodes
+// 3. top level nodes (compressed in v7)
 // 4. trie roots
 // 5. node count
 // 6. trie count
 // 7. checksum
-func readTopLevelTries(dir string, fileName string, subtrieNodes [][]*node.Node, topTrieChecksum uint32, logger zerolog.Logger) (
+func readTopLevelTries(dir string, fileName string, version uint16, subtrieNodes [][]*node.Node, topTrieChecksum uint32, logger zerolog.Logger) (
 	rootTriesToReturn []*trie.MTrie,
 	errToReturn error,
 ) {
@@ -538,7 +557,7 @@ func readTopLevelTries(dir string, fileName string, subtrieNodes [][]*node.Node,
 	filepath, _ := filePathTopTries(dir, fileName)
 	errToReturn = withFile(logger, filepath, func(file *os.File) error {
 		// read and validate magic bytes and version
-		err := validateFileHeader(MagicBytesCheckpointToptrie, VersionV6, file)
+		err := validateFileHeader(MagicBytesCheckpointToptrie, version, file)
 		if err != nil {
 			return err
 		}
@@ -554,6 +573,15 @@ func readTopLevelTries(dir string, fileName string, subtrieNodes [][]*node.Node,
 				topTrieChecksum, expectedSum)
 		}
 
+		// the top level nodes are stored between the subtrie node count and the trie roots
+		fileSize, err := file.Seek(0, io.SeekEnd)
+		if err != nil {
+			return fmt.Errorf("could not seek to end of file: %w", err)
+		}
+		nodesSize := fileSize - headerSize - encNodeCountSize -
+			int64(flattener.EncodedTrieSize)*int64(triesCount) -
+			(encNodeCountSize + encTrieCountSize + crc32SumSize)
+
 		// restart from the beginning of the file, make sure CRC32Reader has seen all the bytes
 		// in order to compute the correct checksum
 		_, err = file.Seek(0, io.SeekStart)
@@ -597,9 +625,15 @@ func readTopLevelTries(dir string, fileName string, subtrieNodes [][]*node.Node,
 		// be large enough to handle almost all payloads and 100% of interim nodes.
 		scratch := make([]byte, 1024*4) // must not be less than 1024
 
+		nodesReader, err := newPartNodesReader(version, reader, nodesSize)
+		if err != nil {
+			return fmt.Errorf("could not create reader for top level nodes: %w", err)
+		}
+		defer nodesReader.close()
+
 		// read the nodes from subtrie level to the root level
 		for i := uint64(1); i <= topLevelNodesCount; i++ {
-			node, err := flattener.ReadNode(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+			node, err := flattener.ReadNode(nodesReader, scratch, func(nodeIndex uint64) (*node.Node, error) {
 				if nodeIndex >= i+uint64(totalSubTrieNodeCount) {
 					return nil, fmt.Errorf("sequence of serialized nodes does not satisfy Descendents-First-Relationship")
 				}
@@ -613,6 +647,11 @@ func readTopLevelTries(dir string, fileName string, subtrieNodes [][]*node.Node,
 			topLevelNodes[i] = node
 		}
 
+		err = nodesReader.finish()
+		if err != nil {
+			return fmt.Errorf("fail to read top level nodes: %w", err)
+		}
+
 		// read the trie root nodes
 		for i := uint16(0); i < triesCount; i++ {
 			trie, err := flattener.ReadTrie(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
@@ -665,7 +704,7 @@ func readTriesRootHash(logger zerolog.Logger, dir string, fileName string) (
 		var err error
 
 		// read and validate magic bytes and version
-		err = validateFileHeader(MagicBytesCheckpointToptrie, VersionV6, file)
+		_, err = validatePartitionedFileHeader(MagicBytesCheckpointToptrie, file)
 		if err != nil {
 			return err
 		}
@@ -768,6 +807,25 @@ func validateFileHeader(expectedMagic uint16, expectedVersion uint16, reader io.
 	return nil
 }
 
+// validatePartitionedFileHeader validates the magic bytes of a checkpoint file stored in a main file
+// and 17 file parts, and returns its version.
+func validatePartitionedFileHeader(expectedMagic uint16, reader io.Reader) (uint16, error) {
+	magic, version, err := readFileHeader(reader)
+	if err != nil {
+		return 0, err
+	}
+
+	if magic != expectedMagic {
+		return 0, fmt.Errorf("wrong magic bytes, expect %#x, bot got: %#x", expectedMagic, magic)
+	}
+
+	if !isPartitionedCheckpointVersion(version) {
+		return 0, fmt.Errorf("wrong version, expect %v or %v, bot got: %v", VersionV6, VersionV7, version)
+	}
+
+	return version, nil
+}
+
 func readSubtrieCount(reader io.Reader) (uint16, error) {
 	bytes := make([]byte, encSubtrieCountSize)
 	_, err := io.ReadFull(reader, bytes)
@@ -880,7 +938,7 @@ func ensureReachedEOF(reader io.Reader) error {
 func validateCheckpointFile(logger zerolog.Logger, dir, fileName string) error {
 	headerPath := filePathCheckpointHeader(dir, fileName)
 	// validate header file
-	subtrieChecksums, topTrieChecksum, err := readCheckpointHeader(headerPath, logger)
+	_, subtrieChecksums, topTrieChecksum, err := readCheckpointHeader(headerPath, logger)
 	if err != nil {
 		return err
 	}
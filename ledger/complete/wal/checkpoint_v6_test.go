@@ -196,7 +196,7 @@ func TestEncodeSubTrie(t *testing.T) {
 	for index, roots := range subtrieRoots {
 		unittest.RunWithTempDir(t, func(dir string) {
 			uniqueIndices, nodeCount, checksum, err := storeCheckpointSubTrie(
-				index, roots, estimatedSubtrieNodeCount, dir, file, logger)
+				index, roots, estimatedSubtrieNodeCount, dir, file, logger, VersionV6)
 			require.NoError(t, err)
 
 			// subtrie roots might have duplciates, that why we group the them,
@@ -231,7 +231,7 @@ func TestEncodeSubTrie(t *testing.T) {
 				uniqueIndices, nodeCount, checksum)
 
 			// all the nodes
-			nodes, err := readCheckpointSubTrie(dir, file, index, checksum, logger)
+			nodes, err := readCheckpointSubTrie(dir, file, VersionV6, index, checksum, logger)
 			require.NoError(t, err)
 
 			for _, root := range roots {
@@ -51,7 +51,14 @@ func StoreCheckpointV6Concurrently(tries []*trie.MTrie, outputDir string, output
 // nWorker specifies how many workers to encode subtrie concurrently, valid range [1,16]
 func StoreCheckpointV6(
 	tries []*trie.MTrie, outputDir string, outputFile string, logger zerolog.Logger, nWorker uint) error {
-	err := storeCheckpointV6(tries, outputDir, outputFile, logger, nWorker)
+	return storeCheckpointWithCleanup(tries, outputDir, outputFile, logger, nWorker, VersionV6)
+}
+
+// storeCheckpointWithCleanup stores checkpoint file of the given version into a main file and 17 file parts,
+// and removes all written files if storing fails.
+func storeCheckpointWithCleanup(
+	tries []*trie.MTrie, outputDir string, outputFile string, logger zerolog.Logger, nWorker uint, version uint16) error {
+	err := storeCheckpoint(tries, outputDir, outputFile, logger, nWorker, version)
 	if err != nil {
 		cleanupErr := deleteCheckpointFiles(outputDir, outputFile)
 		if cleanupErr != nil {
@@ -63,8 +70,8 @@ func StoreCheckpointV6(
 	return nil
 }
 
-func storeCheckpointV6(
-	tries []*trie.MTrie, outputDir string, outputFile string, logger zerolog.Logger, nWorker uint) error {
+func storeCheckpoint(
+	tries []*trie.MTrie, outputDir string, outputFile string, logger zerolog.Logger, nWorker uint, version uint16) error {
 	if len(tries) == 0 {
 		logger.Info().Msg("no tries to be checkpointed")
 		return nil
@@ -72,7 +79,7 @@ func storeCheckpointV6(
 
 	first, last := tries[0], tries[len(tries)-1]
 	lg := logger.With().
-		Int("version", 6).
+		Uint16("version", version).
 		Int("trie_count", len(tries)).
 		Str("checkpoint_file", path.Join(outputDir, outputFile)).
 		Logger()
@@ -108,6 +115,7 @@ func storeCheckpointV6(
 		outputFile,
 		lg,
 		nWorker,
+		version,
 	)
 	if err != nil {
 		return fmt.Errorf("could not store sub trie: %w", err)
@@ -116,12 +124,12 @@ func storeCheckpointV6(
 	lg.Info().Msgf("subtrie have been stored. sub trie node count: %v", subTriesNodeCount)
 
 	topTrieChecksum, err := storeTopLevelNodesAndTrieRoots(
-		tries, subTrieRootIndices, subTriesNodeCount, outputDir, outputFile, lg)
+		tries, subTrieRootIndices, subTriesNodeCount, outputDir, outputFile, lg, version)
 	if err != nil {
 		return fmt.Errorf("could not store top level tries: %w", err)
 	}
 
-	err = storeCheckpointHeader(subTrieChecksums, topTrieChecksum, outputDir, outputFile, lg)
+	err = storeCheckpointHeader(subTrieChecksums, topTrieChecksum, outputDir, outputFile, lg, version)
 	if err != nil {
 		return fmt.Errorf("could not store checkpoint header: %w", err)
 	}
@@ -140,6 +148,7 @@ func storeCheckpointHeader(
 	outputDir string,
 	outputFile string,
 	logger zerolog.Logger,
+	version uint16,
 ) (
 	errToReturn error,
 ) {
@@ -160,7 +169,7 @@ func storeCheckpointHeader(
 	writer := NewCRC32Writer(closable)
 
 	// write version
-	_, err = writer.Write(encodeVersion(MagicBytesCheckpointHeader, VersionV6))
+	_, err = writer.Write(encodeVersion(MagicBytesCheckpointHeader, version))
 	if err != nil {
 		return fmt.Errorf("cannot write version into checkpoint header: %w", err)
 	}
@@ -199,7 +208,7 @@ var createWriterForCheckpointHeader = createClosableWriter
 // 17th part file contains:
 // 1. checkpoint version
 // 2. subtrieNodeCount
-// 3. top level nodes
+// 3. top level nodes (compressed in v7)
 // 4. trie roots
 // 5. node count
 // 6. trie count
@@ -211,6 +220,7 @@ func storeTopLevelNodesAndTrieRoots(
 	outputDir string,
 	outputFile string,
 	logger zerolog.Logger,
+	version uint16,
 ) (
 	checksumOfTopTriePartFile uint32,
 	errToReturn error,
@@ -227,7 +237,7 @@ func storeTopLevelNodesAndTrieRoots(
 	writer := NewCRC32Writer(closable)
 
 	// write version
-	_, err = writer.Write(encodeVersion(MagicBytesCheckpointToptrie, VersionV6))
+	_, err = writer.Write(encodeVersion(MagicBytesCheckpointToptrie, version))
 	if err != nil {
 		return 0, fmt.Errorf("cannot write version into checkpoint header: %w", err)
 	}
@@ -240,18 +250,29 @@ func storeTopLevelNodesAndTrieRoots(
 
 	scratch := make([]byte, 1024*4)
 
+	nodesWriter, err := newPartNodesWriter(version, writer)
+	if err != nil {
+		return 0, fmt.Errorf("could not create writer for top level nodes: %w", err)
+	}
+	defer nodesWriter.Close()
+
 	// write top level nodes
 	topLevelNodeIndices, topLevelNodesCount, err := storeTopLevelNodes(
 		scratch,
 		tries,
 		subTrieRootIndices,
 		subTriesNodeCount+1, // the counter is 1 more than the node count, because the first item is nil
-		writer)
+		nodesWriter)
 
 	if err != nil {
 		return 0, fmt.Errorf("could not store top level nodes: %w", err)
 	}
 
+	err = nodesWriter.Close()
+	if err != nil {
+		return 0, fmt.Errorf("could not flush top level nodes: %w", err)
+	}
+
 	logger.Info().Msgf("top level nodes have been stored. top level node count: %v", topLevelNodesCount)
 
 	// write tries
@@ -324,6 +345,7 @@ func storeSubTrieConcurrently(
 	outputFile string,
 	logger zerolog.Logger,
 	nWorker uint,
+	version uint16,
 ) (
 	map[*node.Node]uint64, // node indices
 	uint64, // node count
@@ -357,7 +379,7 @@ func storeSubTrieConcurrently(
 		go func() {
 			for job := range jobs {
 				roots, nodeCount, checksum, err := storeCheckpointSubTrie(
-					job.Index, job.Roots, estimatedSubtrieNodeCount, outputDir, outputFile, logger)
+					job.Index, job.Roots, estimatedSubtrieNodeCount, outputDir, outputFile, logger, version)
 
 				job.Result <- &resultStoringSubTrie{
 					Index:     job.Index,
@@ -441,7 +463,7 @@ func createClosableWriter(dir string, fileName string, logger zerolog.Logger) (i
 // the subtrie part file at index i
 // subtrie file contains:
 // 1. checkpoint version
-// 2. nodes
+// 2. nodes (compressed in v7)
 // 3. node count
 // 4. checksum
 func storeCheckpointSubTrie(
@@ -451,6 +473,7 @@ func storeCheckpointSubTrie(
 	outputDir string,
 	outputFile string,
 	logger zerolog.Logger,
+	version uint16,
 ) (
 	rootNodesOfAllSubtries map[*node.Node]uint64, // the stored position of each unique root node
 	totalSubtrieNodeCount uint64,
@@ -472,11 +495,17 @@ func storeCheckpointSubTrie(
 	writer := NewCRC32Writer(closable)
 
 	// write version
-	_, err = writer.Write(encodeVersion(MagicBytesCheckpointSubtrie, VersionV6))
+	_, err = writer.Write(encodeVersion(MagicBytesCheckpointSubtrie, version))
 	if err != nil {
 		return nil, 0, 0, fmt.Errorf("cannot write version into checkpoint subtrie file: %w", err)
 	}
 
+	nodesWriter, err := newPartNodesWriter(version, writer)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("could not create writer for subtrie nodes: %w", err)
+	}
+	defer nodesWriter.Close()
+
 	// subtrieRootNodes unique subtrie root nodes, the uint64 value is the index of each root node
 	// stored in the part file.
 	subtrieRootNodes := make(map[*node.Node]uint64, len(roots))
@@ -499,7 +528,7 @@ func storeCheckpointSubTrie(
 		// into the checkpoint file. Therefore, it has to be reused when iterating each subtrie.
 		// storeUniqueNodes will add the unique visited node into traversedSubtrieNodes with key as the node
 		// itself, and value as n-th node being seralized in the checkpoint file.
-		nodeCounter, err = storeUniqueNodes(root, traversedSubtrieNodes, nodeCounter, scratch, writer, logging)
+		nodeCounter, err = storeUniqueNodes(root, traversedSubtrieNodes, nodeCounter, scratch, nodesWriter, logging)
 		if err != nil {
 			return nil, 0, 0, fmt.Errorf("fail to store nodes in step 1 for subtrie root %v: %w", root.Hash(), err)
 		}
@@ -510,6 +539,11 @@ func storeCheckpointSubTrie(
 		subtrieRootNodes[root] = traversedSubtrieNodes[root]
 	}
 
+	err = nodesWriter.Close()
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("could not flush subtrie nodes: %w", err)
+	}
+
 	// -1 to account for 0 node meaning nil
 	totalNodeCount := nodeCounter - 1
 
@@ -0,0 +1,164 @@
+package wal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+)
+
+// StoreCheckpointV7SingleThread stores checkpoint file in v7 in a single threaded manner.
+func StoreCheckpointV7SingleThread(tries []*trie.MTrie, outputDir string, outputFile string, logger zerolog.Logger) error {
+	return StoreCheckpointV7(tries, outputDir, outputFile, logger, 1)
+}
+
+// StoreCheckpointV7Concurrently stores checkpoint file in v7 in max workers.
+func StoreCheckpointV7Concurrently(tries []*trie.MTrie, outputDir string, outputFile string, logger zerolog.Logger) error {
+	return StoreCheckpointV7(tries, outputDir, outputFile, logger, 16)
+}
+
+// StoreCheckpointV7 stores checkpoint file into a main file and 17 file parts, in the same layout as v6.
+// The difference to v6 is that the nodes stored in each part file are compressed with zstd.
+// The version, footer, trie roots and checksum of each part file are not compressed, and the CRC32
+// checksum of a part file is computed over the bytes stored on disk.
+//
+// nWorker specifies how many workers to encode subtrie concurrently, valid range [1,16]
+func StoreCheckpointV7(
+	tries []*trie.MTrie, outputDir string, outputFile string, logger zerolog.Logger, nWorker uint) error {
+	return storeCheckpointWithCleanup(tries, outputDir, outputFile, logger, nWorker, VersionV7)
+}
+
+// isPartitionedCheckpointVersion returns true if checkpoints of the version are stored in a main
+// file and 17 part files.
+func isPartitionedCheckpointVersion(version uint16) bool {
+	return version == VersionV6 || version == VersionV7
+}
+
+// nopWriteCloser writes nodes of a part file without compression.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// compressedWriteCloser compresses nodes of a part file. Close flushes the compressed
+// nodes to the underlying writer, and can be called multiple times.
+type compressedWriteCloser struct {
+	encoder *zstd.Encoder
+	closed  bool
+}
+
+func (w *compressedWriteCloser) Write(p []byte) (int, error) {
+	return w.encoder.Write(p)
+}
+
+func (w *compressedWriteCloser) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.encoder.Close()
+}
+
+// newPartNodesWriter returns a writer for the nodes of a part file of the given version, which writes
+// to the given writer. The returned writer must be closed before anything else is written to the
+// part file.
+func newPartNodesWriter(version uint16, writer io.Writer) (io.WriteCloser, error) {
+	if version != VersionV7 {
+		return nopWriteCloser{Writer: writer}, nil
+	}
+
+	// subtries are written concurrently, so each encoder uses a single goroutine
+	encoder, err := zstd.NewWriter(writer, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, fmt.Errorf("could not create zstd encoder: %w", err)
+	}
+	return &compressedWriteCloser{encoder: encoder}, nil
+}
+
+// partNodesReader reads the nodes of a part file.
+type partNodesReader interface {
+	io.Reader
+
+	// finish verifies that all nodes of the part file have been read, and positions the
+	// underlying reader after the nodes.
+	finish() error
+
+	// close releases the resources of the reader.
+	close()
+}
+
+// uncompressedReader reads nodes of a part file stored without compression.
+type uncompressedReader struct {
+	io.Reader
+}
+
+func (uncompressedReader) finish() error {
+	return nil
+}
+
+func (uncompressedReader) close() {}
+
+// compressedReader reads nodes of a part file compressed with zstd.
+type compressedReader struct {
+	*bufio.Reader
+	compressed *io.LimitedReader
+	decoder    *zstd.Decoder
+}
+
+func (r *compressedReader) finish() error {
+	err := ensureReachedEOF(r.Reader)
+	if err != nil {
+		return fmt.Errorf("compressed nodes contain more data than expected: %w", err)
+	}
+
+	// the checksum of the part file is computed over all bytes stored on disk,
+	// so they must all be consumed even if the decoder doesn't need them.
+	remaining, err := io.Copy(io.Discard, r.compressed)
+	if err != nil {
+		return fmt.Errorf("could not read compressed nodes: %w", err)
+	}
+	if remaining != 0 {
+		return fmt.Errorf("found %d unexpected bytes after compressed nodes", remaining)
+	}
+
+	return nil
+}
+
+func (r *compressedReader) close() {
+	r.decoder.Close()
+}
+
+// newPartNodesReader returns a reader for the nodes of a part file of the given version, which reads
+// from the given reader. size is the number of bytes the nodes are stored in.
+// The returned reader must be closed after use.
+func newPartNodesReader(version uint16, reader io.Reader, size int64) (partNodesReader, error) {
+	if version != VersionV7 {
+		return uncompressedReader{Reader: reader}, nil
+	}
+
+	if size < 0 {
+		return nil, fmt.Errorf("invalid size of compressed nodes: %d", size)
+	}
+
+	// limit the decoder to the compressed nodes, so it doesn't read ahead into the rest of the file
+	compressed := &io.LimitedReader{R: reader, N: size}
+
+	// subtries are read concurrently, so each decoder uses a single goroutine
+	decoder, err := zstd.NewReader(compressed, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, fmt.Errorf("could not create zstd decoder: %w", err)
+	}
+
+	return &compressedReader{
+		Reader:     bufio.NewReaderSize(decoder, defaultBufioReadSize),
+		compressed: compressed,
+		decoder:    decoder,
+	}, nil
+}
@@ -0,0 +1,194 @@
+package wal
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestWriteAndReadCheckpointV7EmptyTrie(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := []*trie.MTrie{trie.NewEmptyMTrie()}
+		fileName := "checkpoint-empty-trie"
+		logger := unittest.Logger()
+		require.NoErrorf(t, StoreCheckpointV7Concurrently(tries, dir, fileName, logger), "fail to store checkpoint")
+		decoded, err := OpenAndReadCheckpointV6(dir, fileName, logger)
+		require.NoErrorf(t, err, "fail to read checkpoint %v/%v", dir, fileName)
+		requireTriesEqual(t, tries, decoded)
+	})
+}
+
+func TestWriteAndReadCheckpointV7SimpleTrie(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createSimpleTrie(t)
+		fileName := "checkpoint"
+		logger := unittest.Logger()
+		require.NoErrorf(t, StoreCheckpointV7Concurrently(tries, dir, fileName, logger), "fail to store checkpoint")
+		decoded, err := OpenAndReadCheckpointV6(dir, fileName, logger)
+		require.NoErrorf(t, err, "fail to read checkpoint %v/%v", dir, fileName)
+		requireTriesEqual(t, tries, decoded)
+	})
+}
+
+func TestWriteAndReadCheckpointV7MultipleTries(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		fileName := "checkpoint-multi-file"
+		logger := unittest.Logger()
+		require.NoErrorf(t, StoreCheckpointV7Concurrently(tries, dir, fileName, logger), "fail to store checkpoint")
+		decoded, err := OpenAndReadCheckpointV6(dir, fileName, logger)
+		require.NoErrorf(t, err, "fail to read checkpoint %v/%v", dir, fileName)
+		requireTriesEqual(t, tries, decoded)
+	})
+}
+
+func TestWriteAndReadCheckpointV7SingleThread(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		fileName := "checkpoint-multi-file"
+		logger := unittest.Logger()
+		require.NoErrorf(t, StoreCheckpointV7SingleThread(tries, dir, fileName, logger), "fail to store checkpoint")
+		decoded, err := LoadCheckpoint(path.Join(dir, fileName), logger)
+		require.NoErrorf(t, err, "fail to read checkpoint %v/%v", dir, fileName)
+		requireTriesEqual(t, tries, decoded)
+	})
+}
+
+// test running checkpointing twice will produce the same checkpoint file
+func TestCheckpointV7IsDeterminstic(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		logger := unittest.Logger()
+		require.NoErrorf(t, StoreCheckpointV7Concurrently(tries, dir, "checkpoint1", logger), "fail to store checkpoint")
+		require.NoErrorf(t, StoreCheckpointV7SingleThread(tries, dir, "checkpoint2", logger), "fail to store checkpoint")
+		partFiles1 := filePaths(dir, "checkpoint1", subtrieLevel)
+		partFiles2 := filePaths(dir, "checkpoint2", subtrieLevel)
+		for i, partFile1 := range partFiles1 {
+			partFile2 := partFiles2[i]
+			require.NoError(t, compareFiles(
+				partFile1, partFile2),
+				"found difference in checkpoint files")
+		}
+	})
+}
+
+func TestWriteAndReadCheckpointV7LeafSimpleTrie(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createSimpleTrie(t)
+		fileName := "checkpoint"
+		logger := unittest.Logger()
+		require.NoErrorf(t, StoreCheckpointV7Concurrently(tries, dir, fileName, logger), "fail to store checkpoint")
+		bufSize := 1
+		leafNodesCh := make(chan *LeafNode, bufSize)
+
+		go func() {
+			err := OpenAndReadLeafNodesFromCheckpointV6(leafNodesCh, dir, fileName, tries[0].RootHash(), logger)
+			require.NoErrorf(t, err, "fail to read checkpoint %v/%v", dir, fileName)
+		}()
+		resultPayloads := make([]*ledger.Payload, 0)
+		for leafNode := range leafNodesCh {
+			// avoid dummy payload from empty trie
+			if leafNode.Payload != nil {
+				resultPayloads = append(resultPayloads, leafNode.Payload)
+			}
+		}
+		require.EqualValues(t, tries[0].AllPayloads(), resultPayloads)
+	})
+}
+
+// test converting a checkpoint from v6 to v7 and back produces the same v6 checkpoint file
+func TestWriteAndReadCheckpointV7ThenBackToV6(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		logger := unittest.Logger()
+
+		// store tries into v7 then read back, then store into v6
+		require.NoErrorf(t, StoreCheckpointV7Concurrently(tries, dir, "checkpoint-v7", logger), "fail to store checkpoint")
+		decoded, err := OpenAndReadCheckpointV6(dir, "checkpoint-v7", logger)
+		require.NoErrorf(t, err, "fail to read checkpoint %v/checkpoint-v7", dir)
+		require.NoErrorf(t, StoreCheckpointV6Concurrently(decoded, dir, "checkpoint-v7-v6", logger), "fail to store checkpoint")
+
+		// store tries directly into v6 checkpoint
+		require.NoErrorf(t, StoreCheckpointV6Concurrently(tries, dir, "checkpoint-v6", logger), "fail to store checkpoint")
+
+		// compare the two v6 checkpoint files should be identical
+		partFiles1 := filePaths(dir, "checkpoint-v6", subtrieLevel)
+		partFiles2 := filePaths(dir, "checkpoint-v7-v6", subtrieLevel)
+		for i, partFile1 := range partFiles1 {
+			require.NoError(t, compareFiles(
+				partFile1, partFiles2[i]),
+				"found difference in checkpoint files")
+		}
+	})
+}
+
+func TestReadCheckpointV7RootHash(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		fileName := "checkpoint"
+		logger := unittest.Logger()
+		require.NoErrorf(t, StoreCheckpointV7Concurrently(tries, dir, fileName, logger), "fail to store checkpoint")
+
+		trieRoots, err := ReadTriesRootHash(logger, dir, fileName)
+		require.NoError(t, err)
+		require.Equal(t, len(tries), len(trieRoots))
+		for i, root := range trieRoots {
+			require.Equal(t, tries[i].RootHash(), root)
+		}
+	})
+}
+
+func TestReadCheckpointV7CorruptedNodes(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		fileName := "checkpoint"
+		logger := unittest.Logger()
+		require.NoErrorf(t, StoreCheckpointV7Concurrently(tries, dir, fileName, logger), "fail to store checkpoint")
+
+		// flip a byte in the middle of the compressed nodes of a subtrie file
+		subTrieFilePath, _, err := filePathSubTries(dir, fileName, 0)
+		require.NoError(t, err)
+		file, err := os.OpenFile(subTrieFilePath, os.O_RDWR, 0644)
+		require.NoError(t, err)
+
+		fileInfo, err := file.Stat()
+		require.NoError(t, err)
+		offset := headerSize + (fileInfo.Size()-headerSize-encNodeCountSize-crc32SumSize)/2
+
+		b := make([]byte, 1)
+		_, err = file.ReadAt(b, offset)
+		require.NoError(t, err)
+		b[0] ^= 0xff
+		_, err = file.WriteAt(b, offset)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		_, err = OpenAndReadCheckpointV6(dir, fileName, logger)
+		require.Error(t, err)
+	})
+}
+
+func TestReadCheckpointV7WithV6PartFile(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createSimpleTrie(t)
+		logger := unittest.Logger()
+		require.NoErrorf(t, StoreCheckpointV6Concurrently(tries, dir, "checkpoint-v6", logger), "fail to store checkpoint")
+		require.NoErrorf(t, StoreCheckpointV7Concurrently(tries, dir, "checkpoint-v7", logger), "fail to store checkpoint")
+
+		// replace a part file of the v7 checkpoint with the part file of the v6 checkpoint
+		v6PartFile, _, err := filePathSubTries(dir, "checkpoint-v6", 0)
+		require.NoError(t, err)
+		v7PartFile, _, err := filePathSubTries(dir, "checkpoint-v7", 0)
+		require.NoError(t, err)
+		require.NoError(t, os.Rename(v6PartFile, v7PartFile))
+
+		_, err = OpenAndReadCheckpointV6(dir, "checkpoint-v7", logger)
+		require.ErrorContains(t, err, "wrong version")
+	})
+}
@@ -0,0 +1,901 @@
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path"
+	"sort"
+
+	prometheusWAL "github.com/onflow/wal/wal"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+// maxNodeDamagesPerFile is the max number of nodes with an invalid hash reported for a single file.
+// The ancestors of a node with a damaged hash have an invalid hash as well, so reporting all of
+// them doesn't help locating the damage.
+const maxNodeDamagesPerFile = 10
+
+// CheckpointDamage is a damaged byte range of a file of a checkpoint.
+type CheckpointDamage struct {
+	// File is the path of the damaged file.
+	File string
+	// Offset is the offset of the first damaged byte in the file, and Length is the number of
+	// damaged bytes. The nodes of v7 part files are compressed, so a damaged node is reported
+	// with the byte range of all compressed nodes of the part file.
+	Offset int64
+	Length int64
+	Reason string
+}
+
+func (d CheckpointDamage) String() string {
+	return fmt.Sprintf("%v [%d, %d): %v", d.File, d.Offset, d.Offset+d.Length, d.Reason)
+}
+
+// CheckpointVerification is the result of verifying a checkpoint with VerifyCheckpoint.
+type CheckpointVerification struct {
+	Version uint16
+	// Damages are the damaged byte ranges found in the files of the checkpoint.
+	Damages []CheckpointDamage
+	// HeaderDamaged is true if the header file of a v6 or v7 checkpoint, or the file of a v5
+	// checkpoint, is damaged.
+	HeaderDamaged bool
+	// DamagedParts are the indexes of the damaged part files of a v6 or v7 checkpoint, the top
+	// level trie part file having index CheckpointPartCount-1.
+	DamagedParts []int
+	// RootHashes are the root hashes of the tries of the checkpoint, recomputed from their
+	// payloads. They are only set if the checkpoint isn't damaged.
+	RootHashes []ledger.RootHash
+}
+
+// Valid returns true if no damage was found in the checkpoint.
+func (v *CheckpointVerification) Valid() bool {
+	return len(v.Damages) == 0
+}
+
+// VerifyCheckpoint verifies every file of the checkpoint file of version 5, 6 or 7: the header, the
+// footers, the checksums, the encoding and hash of every node, and the root hashes of the tries.
+// Unlike loading the checkpoint, it doesn't stop at the first damage, but reports every damaged file
+// with the damaged byte range, as precisely as it can be located.
+// It returns an error if the checkpoint can't be verified at all, such as when the checkpoint file
+// doesn't exist or its version can't be determined.
+func VerifyCheckpoint(dir string, fileName string, logger zerolog.Logger) (*CheckpointVerification, error) {
+	version, err := readCheckpointVersion(dir, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	lg := logger.With().Str("checkpoint_file", filePathCheckpointHeader(dir, fileName)).Logger()
+	lg.Info().Msgf("verifying v%d checkpoint file", version)
+
+	var result *CheckpointVerification
+	if version == VersionV5 {
+		result, err = verifyCheckpointV5(dir, fileName, lg)
+	} else {
+		result, err = verifyCheckpointV6(dir, fileName, version, lg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !result.Valid() {
+		result.RootHashes = nil
+	}
+	return result, nil
+}
+
+// readCheckpointVersion returns the version of the checkpoint file. If the header of the checkpoint
+// file is damaged, the version is read from the top level trie part file if it exists.
+func readCheckpointVersion(dir string, fileName string) (uint16, error) {
+	headerPath := filePathCheckpointHeader(dir, fileName)
+	magic, version, err := readFileHeaderAt(headerPath)
+	if err != nil {
+		return 0, fmt.Errorf("cannot read checkpoint file: %w", err)
+	}
+	if magic == MagicBytesCheckpointHeader &&
+		(version == VersionV5 || isPartitionedCheckpointVersion(version)) {
+		return version, nil
+	}
+
+	topTriesPath, _ := filePathTopTries(dir, fileName)
+	magic, version, err = readFileHeaderAt(topTriesPath)
+	if err == nil && magic == MagicBytesCheckpointToptrie && isPartitionedCheckpointVersion(version) {
+		return version, nil
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		// without part files, the checkpoint is stored in a single file
+		return VersionV5, nil
+	}
+
+	return 0, fmt.Errorf("cannot determine version of checkpoint file %v, its header is damaged", headerPath)
+}
+
+// readFileHeaderAt reads the magic bytes and version of the file.
+func readFileHeaderAt(filePath string) (uint16, uint16, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+
+	return readFileHeader(f)
+}
+
+// verifyCheckpointV6 verifies the header file and the part files of a v6 or v7 checkpoint.
+// The part files are verified one at a time, and only the subtrie roots of each subtrie part
+// file are retained, to verify the top level nodes.
+func verifyCheckpointV6(dir string, fileName string, version uint16, logger zerolog.Logger) (*CheckpointVerification, error) {
+	result := &CheckpointVerification{Version: version}
+
+	checksums, damages, err := verifyCheckpointHeaderFile(filePathCheckpointHeader(dir, fileName), version)
+	if err != nil {
+		return nil, err
+	}
+	if len(damages) > 0 {
+		result.HeaderDamaged = true
+		result.Damages = append(result.Damages, damages...)
+	}
+
+	checksumOfPart := func(index int) *uint32 {
+		if checksums == nil {
+			return nil
+		}
+		return &checksums[index]
+	}
+
+	// subtrieRoots are the subtrie roots of all subtrie part files by their node index in the
+	// checkpoint, which is nil if the nodes of any subtrie part file can't be read
+	subtrieRoots := make(map[uint64]*node.Node)
+	subtrieNodesCount := uint64(0)
+	for i := 0; i < subtrieCount; i++ {
+		roots, nodesCount, damages, err := verifyCheckpointSubtrieFile(dir, fileName, version, i, checksumOfPart(i), logger)
+		if err != nil {
+			return nil, fmt.Errorf("could not verify %v-th subtrie part file: %w", i, err)
+		}
+		if len(damages) > 0 {
+			result.DamagedParts = append(result.DamagedParts, i)
+			result.Damages = append(result.Damages, damages...)
+		}
+
+		logger.Info().Int("damages", len(damages)).Msgf("verified %v-th subtrie part file", i)
+
+		if roots == nil || subtrieRoots == nil {
+			subtrieRoots = nil
+			continue
+		}
+		for index, root := range roots {
+			subtrieRoots[subtrieNodesCount+index] = root
+		}
+		subtrieNodesCount += nodesCount
+	}
+
+	rootHashes, damages, err := verifyCheckpointTopTriesFile(dir, fileName, version, checksumOfPart(subtrieCount),
+		subtrieRoots, subtrieNodesCount, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not verify top level trie part file: %w", err)
+	}
+	if len(damages) > 0 {
+		result.DamagedParts = append(result.DamagedParts, subtrieCount)
+		result.Damages = append(result.Damages, damages...)
+	}
+	result.RootHashes = rootHashes
+
+	logger.Info().Int("damages", len(damages)).Msg("verified top level trie part file")
+
+	return result, nil
+}
+
+// verifyCheckpointHeaderFile verifies the header file of a v6 or v7 checkpoint, and returns the checksums
+// of the part files. The checksums are nil if the header file is damaged.
+func verifyCheckpointHeaderFile(filePath string, version uint16) ([]uint32, []CheckpointDamage, error) {
+	v := &checkpointFileVerifier{path: filePath}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			v.damage(0, 0, "file is missing")
+			return nil, v.damages, nil
+		}
+		return nil, nil, fmt.Errorf("could not read header file: %w", err)
+	}
+
+	const expectedSize = headerSize + encSubtrieCountSize + crc32SumSize*CheckpointPartCount + crc32SumSize
+	if len(data) != expectedSize {
+		v.damage(0, int64(len(data)), "file has %d bytes, but %d are expected", len(data), expectedSize)
+		return nil, v.damages, nil
+	}
+
+	magic, fileVersion, _ := decodeVersion(data[:headerSize])
+	if magic != MagicBytesCheckpointHeader {
+		v.damage(0, encMagicSize, "wrong magic bytes %#x, expected %#x", magic, MagicBytesCheckpointHeader)
+	}
+	if fileVersion != version {
+		v.damage(encMagicSize, encVersionSize, "wrong version %d, expected %d", fileVersion, version)
+	}
+
+	count, _ := decodeSubtrieCount(data[headerSize : headerSize+encSubtrieCountSize])
+	if count != subtrieCount {
+		v.damage(headerSize, encSubtrieCountSize, "wrong subtrie count %d, expected %d", count, subtrieCount)
+	}
+
+	contentSize := len(data) - crc32SumSize
+	actualSum := crc32.Checksum(data[:contentSize], crc32Table)
+	expectedSum := binary.BigEndian.Uint32(data[contentSize:])
+	if actualSum != expectedSum && len(v.damages) == 0 {
+		v.damage(0, int64(contentSize), "checksum mismatch, computed %#x, but file has %#x", actualSum, expectedSum)
+	}
+
+	if len(v.damages) > 0 {
+		return nil, v.damages, nil
+	}
+
+	checksums := make([]uint32, CheckpointPartCount)
+	pos := headerSize + encSubtrieCountSize
+	for i := range checksums {
+		checksums[i] = binary.BigEndian.Uint32(data[pos:])
+		pos += crc32SumSize
+	}
+	return checksums, nil, nil
+}
+
+// verifyCheckpointSubtrieFile verifies the index-th subtrie part file. checksum is the checksum of the
+// part file stored in the header file, or nil if the header file is damaged.
+// It returns the subtrie roots of the part file by node index, without their descendants, and the
+// node count. The roots are nil if the nodes of the part file can't all be decoded.
+func verifyCheckpointSubtrieFile(
+	dir string,
+	fileName string,
+	version uint16,
+	index int,
+	checksum *uint32,
+	logger zerolog.Logger,
+) (map[uint64]*node.Node, uint64, []CheckpointDamage, error) {
+	filePath, _, err := filePathSubTries(dir, fileName, index)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	var roots map[uint64]*node.Node
+	var nodesCount uint64
+
+	damages, err := verifyCheckpointPartFile(filePath, MagicBytesCheckpointSubtrie, version, encNodeCountSize, checksum, logger,
+		func(v *checkpointFileVerifier, content io.Reader, contentSize int64, footer []byte) error {
+			count, _ := decodeNodeCount(footer)
+
+			nodes := make([]*node.Node, 0)
+			hasParent := make([]bool, 0)
+			ok, err := v.verifyNodes(version, content, headerSize, contentSize, count,
+				func(i uint64, childIndex uint64) (*node.Node, error) {
+					if childIndex >= i {
+						return nil, fmt.Errorf("sequence of serialized nodes does not satisfy Descendents-First-Relationship")
+					}
+					if childIndex == 0 {
+						return nil, nil
+					}
+					hasParent[childIndex-1] = true
+					return nodes[childIndex-1], nil
+				},
+				func(_ uint64, n *node.Node) {
+					nodes = append(nodes, n)
+					hasParent = append(hasParent, false)
+				})
+			if err != nil || !ok {
+				return err
+			}
+
+			roots = make(map[uint64]*node.Node)
+			for i, n := range nodes {
+				if !hasParent[i] {
+					roots[uint64(i)+1] = withoutDescendants(n)
+				}
+			}
+			nodesCount = count
+			return nil
+		})
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	return roots, nodesCount, damages, nil
+}
+
+// verifyCheckpointTopTriesFile verifies the top level trie part file, and returns the root hashes of the
+// tries. checksum is the checksum of the part file stored in the header file, or nil if the header file
+// is damaged. The top level nodes are only decoded and verified if the subtrie roots are given, since
+// they are the children of the top level nodes.
+func verifyCheckpointTopTriesFile(
+	dir string,
+	fileName string,
+	version uint16,
+	checksum *uint32,
+	subtrieRoots map[uint64]*node.Node,
+	subtrieNodesCount uint64,
+	logger zerolog.Logger,
+) ([]ledger.RootHash, []CheckpointDamage, error) {
+	filePath, _ := filePathTopTries(dir, fileName)
+
+	var rootHashes []ledger.RootHash
+
+	damages, err := verifyCheckpointPartFile(filePath, MagicBytesCheckpointToptrie, version, encNodeCountSize+encTrieCountSize, checksum, logger,
+		func(v *checkpointFileVerifier, content io.Reader, contentSize int64, footer []byte) error {
+			topLevelNodesCount, triesCount, _ := decodeTopLevelNodesAndTriesFooter(footer)
+
+			triesSize := int64(flattener.EncodedTrieSize) * int64(triesCount)
+			nodesSize := contentSize - encNodeCountSize - triesSize
+			if nodesSize < 0 {
+				v.damage(v.size-crc32SumSize-int64(len(footer)), int64(len(footer)),
+					"footer has %d tries, which don't fit in the file", triesCount)
+				return nil
+			}
+
+			buf := make([]byte, encNodeCountSize)
+			_, err := io.ReadFull(content, buf)
+			if err != nil {
+				return fmt.Errorf("could not read subtrie node count: %w", err)
+			}
+			count, _ := decodeNodeCount(buf)
+			if subtrieRoots != nil && count != subtrieNodesCount {
+				v.damage(headerSize, encNodeCountSize, "subtrie node count is %d, but subtrie part files have %d nodes",
+					count, subtrieNodesCount)
+				return nil
+			}
+
+			if subtrieRoots == nil {
+				// the top level nodes can't be verified without the subtrie roots
+				return nil
+			}
+
+			topLevelNodes := make([]*node.Node, 0)
+			getNode := func(nodeIndex uint64) (*node.Node, error) {
+				if nodeIndex == 0 {
+					return nil, nil
+				}
+				if nodeIndex <= subtrieNodesCount {
+					root, ok := subtrieRoots[nodeIndex]
+					if !ok {
+						return nil, fmt.Errorf("node %d is not a subtrie root", nodeIndex)
+					}
+					return root, nil
+				}
+				i := nodeIndex - subtrieNodesCount - 1
+				if i >= uint64(len(topLevelNodes)) {
+					return nil, fmt.Errorf("node %d not found", nodeIndex)
+				}
+				return topLevelNodes[i], nil
+			}
+
+			nodesOffset := int64(headerSize + encNodeCountSize)
+			ok, err := v.verifyNodes(version, io.LimitReader(content, nodesSize), nodesOffset, nodesSize, topLevelNodesCount,
+				func(i uint64, childIndex uint64) (*node.Node, error) {
+					if childIndex >= i+subtrieNodesCount {
+						return nil, fmt.Errorf("sequence of serialized nodes does not satisfy Descendents-First-Relationship")
+					}
+					return getNode(childIndex)
+				},
+				func(_ uint64, n *node.Node) {
+					topLevelNodes = append(topLevelNodes, n)
+				})
+			if err != nil || !ok {
+				return err
+			}
+
+			hashes := make([]ledger.RootHash, 0, triesCount)
+			scratch := make([]byte, flattener.EncodedTrieSize)
+			offset := nodesOffset + nodesSize
+			for i := uint16(0); i < triesCount; i++ {
+				encodedTrie, err := flattener.ReadEncodedTrie(content, scratch)
+				if err != nil {
+					return fmt.Errorf("could not read trie %d: %w", i, err)
+				}
+
+				rootHash, err := verifyEncodedTrie(encodedTrie, getNode)
+				if err != nil {
+					v.damage(offset, flattener.EncodedTrieSize, "invalid trie %d: %v", i, err)
+				} else {
+					hashes = append(hashes, rootHash)
+				}
+				offset += flattener.EncodedTrieSize
+			}
+
+			if len(hashes) == int(triesCount) {
+				rootHashes = hashes
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rootHashes, damages, nil
+}
+
+// verifyCheckpointV5 verifies the single file of a v5 checkpoint.
+func verifyCheckpointV5(dir string, fileName string, logger zerolog.Logger) (*CheckpointVerification, error) {
+	result := &CheckpointVerification{Version: VersionV5}
+
+	filePath := filePathCheckpointHeader(dir, fileName)
+	damages, err := verifyCheckpointPartFile(filePath, MagicBytesCheckpointHeader, VersionV5, encNodeCountSize+encTrieCountSize, nil, logger,
+		func(v *checkpointFileVerifier, content io.Reader, contentSize int64, footer []byte) error {
+			nodesCount, triesCount, _ := decodeTopLevelNodesAndTriesFooter(footer)
+
+			triesSize := int64(flattener.EncodedTrieSize) * int64(triesCount)
+			nodesSize := contentSize - triesSize
+			if nodesSize < 0 {
+				v.damage(v.size-crc32SumSize-int64(len(footer)), int64(len(footer)),
+					"footer has %d tries, which don't fit in the file", triesCount)
+				return nil
+			}
+
+			nodes := make([]*node.Node, 0)
+			getNode := func(nodeIndex uint64) (*node.Node, error) {
+				if nodeIndex == 0 {
+					return nil, nil
+				}
+				if nodeIndex > uint64(len(nodes)) {
+					return nil, fmt.Errorf("node %d not found", nodeIndex)
+				}
+				return nodes[nodeIndex-1], nil
+			}
+
+			ok, err := v.verifyNodes(VersionV5, io.LimitReader(content, nodesSize), headerSize, nodesSize, nodesCount,
+				func(i uint64, childIndex uint64) (*node.Node, error) {
+					if childIndex >= i {
+						return nil, fmt.Errorf("sequence of serialized nodes does not satisfy Descendents-First-Relationship")
+					}
+					return getNode(childIndex)
+				},
+				func(_ uint64, n *node.Node) {
+					nodes = append(nodes, n)
+				})
+			if err != nil || !ok {
+				return err
+			}
+
+			hashes := make([]ledger.RootHash, 0, triesCount)
+			scratch := make([]byte, flattener.EncodedTrieSize)
+			offset := headerSize + nodesSize
+			for i := uint16(0); i < triesCount; i++ {
+				encodedTrie, err := flattener.ReadEncodedTrie(content, scratch)
+				if err != nil {
+					return fmt.Errorf("could not read trie %d: %w", i, err)
+				}
+
+				rootHash, err := verifyEncodedTrie(encodedTrie, getNode)
+				if err != nil {
+					v.damage(offset, flattener.EncodedTrieSize, "invalid trie %d: %v", i, err)
+				} else {
+					hashes = append(hashes, rootHash)
+				}
+				offset += flattener.EncodedTrieSize
+			}
+
+			if len(hashes) == int(triesCount) {
+				result.RootHashes = hashes
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(damages) > 0 {
+		result.HeaderDamaged = true
+		result.Damages = damages
+	}
+	return result, nil
+}
+
+// verifyEncodedTrie returns the root hash of the encoded trie, after checking that its root node has
+// the root hash of the encoded trie.
+func verifyEncodedTrie(encodedTrie flattener.EncodedTrie, getNode func(nodeIndex uint64) (*node.Node, error)) (ledger.RootHash, error) {
+	root, err := getNode(encodedTrie.RootIndex)
+	if err != nil {
+		return ledger.RootHash{}, fmt.Errorf("could not find root node: %w", err)
+	}
+
+	rootHash := trie.EmptyTrieRootHash()
+	if root != nil {
+		rootHash = ledger.RootHash(root.Hash())
+	}
+	if rootHash != ledger.RootHash(encodedTrie.RootHash) {
+		return ledger.RootHash{}, fmt.Errorf("root hash %v doesn't match hash %v of the root node",
+			ledger.RootHash(encodedTrie.RootHash), rootHash)
+	}
+	return rootHash, nil
+}
+
+// withoutDescendants returns a copy of the node which doesn't reference its descendants,
+// so that they can be garbage collected once their hashes have been verified.
+func withoutDescendants(n *node.Node) *node.Node {
+	if n.IsLeaf() {
+		return n
+	}
+	return node.NewNode(n.Height(), nil, nil, ledger.DummyPath, nil, n.Hash())
+}
+
+// checkpointFileVerifier collects the damages found in a file of a checkpoint.
+type checkpointFileVerifier struct {
+	path    string
+	size    int64
+	damages []CheckpointDamage
+}
+
+func (v *checkpointFileVerifier) damage(offset int64, length int64, format string, args ...any) {
+	v.damages = append(v.damages, CheckpointDamage{
+		File:   v.path,
+		Offset: offset,
+		Length: length,
+		Reason: fmt.Sprintf(format, args...),
+	})
+}
+
+// verifyCheckpointPartFile verifies the header, footer and checksum of a file of a checkpoint, and
+// passes the content between the header and the footer to verifyContent, along with the footer.
+// checksum is the expected checksum of the file stored in the checkpoint header file, if any.
+// verifyContent reports the damages of the content, and doesn't need to read the whole content.
+// It returns an error only if the file can't be read.
+func verifyCheckpointPartFile(
+	filePath string,
+	magic uint16,
+	version uint16,
+	footerSize int64,
+	checksum *uint32,
+	logger zerolog.Logger,
+	verifyContent func(v *checkpointFileVerifier, content io.Reader, contentSize int64, footer []byte) error,
+) (damagesToReturn []CheckpointDamage, errToReturn error) {
+	v := &checkpointFileVerifier{path: filePath}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			v.damage(0, 0, "file is missing")
+			return v.damages, nil
+		}
+		return nil, fmt.Errorf("could not open file: %w", err)
+	}
+	defer func() {
+		evictErr := evictFileFromLinuxPageCache(f, false, logger)
+		if evictErr != nil {
+			logger.Warn().Msgf("failed to evict file %s from Linux page cache: %s", filePath, evictErr)
+		}
+		errToReturn = closeAndMergeError(f, errToReturn)
+	}()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("could not get file info: %w", err)
+	}
+	v.size = info.Size()
+
+	if v.size < headerSize+footerSize+crc32SumSize {
+		v.damage(0, v.size, "file is truncated to %d bytes", v.size)
+		return v.damages, nil
+	}
+
+	footer := make([]byte, footerSize+crc32SumSize)
+	_, err = f.ReadAt(footer, v.size-int64(len(footer)))
+	if err != nil {
+		return nil, fmt.Errorf("could not read footer: %w", err)
+	}
+	storedSum, _ := decodeCRC32Sum(footer[footerSize:])
+	footer = footer[:footerSize]
+
+	if checksum != nil && *checksum != storedSum {
+		v.damage(v.size-crc32SumSize, crc32SumSize, "checksum %#x doesn't match checksum %#x in checkpoint header file",
+			storedSum, *checksum)
+	}
+
+	// the checksum is computed over all bytes of the file before the checksum
+	reader := NewCRC32Reader(bufio.NewReaderSize(f, defaultBufioReadSize))
+	checksummed := io.LimitReader(reader, v.size-crc32SumSize)
+
+	fileMagic, fileVersion, err := readFileHeader(checksummed)
+	if err != nil {
+		return nil, fmt.Errorf("could not read header: %w", err)
+	}
+	if fileMagic != magic {
+		v.damage(0, encMagicSize, "wrong magic bytes %#x, expected %#x", fileMagic, magic)
+	}
+	if fileVersion != version {
+		v.damage(encMagicSize, encVersionSize, "wrong version %d, expected %d", fileVersion, version)
+	}
+
+	contentSize := v.size - headerSize - footerSize - crc32SumSize
+	err = verifyContent(v, io.LimitReader(checksummed, contentSize), contentSize, footer)
+	if err != nil {
+		return nil, err
+	}
+
+	// read the rest of the file, which the content verification might not have read
+	_, err = io.Copy(io.Discard, checksummed)
+	if err != nil {
+		return nil, fmt.Errorf("could not read file: %w", err)
+	}
+
+	actualSum := reader.Crc32()
+	if actualSum != storedSum && len(v.damages) == 0 {
+		// the damage doesn't affect the decoding of the nodes, such as a damaged payload key
+		v.damage(0, v.size-crc32SumSize, "checksum mismatch, computed %#x, but file has %#x", actualSum, storedSum)
+	}
+
+	return v.damages, nil
+}
+
+// verifyNodes reads count nodes from the reader, and verifies the hash of each node given the hashes
+// of its children. The nodes are stored at the given offset of the file in size bytes, compressed in v7
+// part files. getChild returns the child of the i-th node, and onNode is called with each node read.
+// Nodes with an invalid hash are reported with their byte range, and decoding stops at the first node
+// which can't be decoded. Compressed nodes are reported as a whole, at the first damaged node.
+// It returns false if not all nodes could be decoded.
+func (v *checkpointFileVerifier) verifyNodes(
+	version uint16,
+	reader io.Reader,
+	offset int64,
+	size int64,
+	count uint64,
+	getChild func(i uint64, childIndex uint64) (*node.Node, error),
+	onNode func(i uint64, n *node.Node),
+) (bool, error) {
+	// the offsets of compressed nodes are only known in the decompressed nodes, so the compressed
+	// nodes are reported as a whole, once
+	compressedDamaged := false
+	nodeDamage := func(nodeOffset int64, nodeLength int64, format string, args ...any) {
+		reason := fmt.Sprintf(format, args...)
+		if version == VersionV7 {
+			if !compressedDamaged {
+				compressedDamaged = true
+				v.damage(offset, size, "%v, at offset %d of decompressed nodes", reason, nodeOffset)
+			}
+			return
+		}
+		if nodeLength < 0 {
+			nodeLength = size - nodeOffset
+		}
+		v.damage(offset+nodeOffset, nodeLength, "%v", reason)
+	}
+
+	nodesReader, err := newPartNodesReader(version, reader, size)
+	if err != nil {
+		return false, fmt.Errorf("could not create nodes reader: %w", err)
+	}
+	defer nodesReader.close()
+
+	counter := &countingReader{reader: nodesReader}
+	scratch := make([]byte, 1024*4) // must not be less than 1024
+	damaged := 0
+	for i := uint64(1); i <= count; i++ {
+		start := counter.count
+		n, err := flattener.ReadNode(counter, scratch, func(childIndex uint64) (*node.Node, error) {
+			return getChild(i, childIndex)
+		})
+		if err != nil {
+			nodeDamage(start, -1, "cannot decode node %d: %v", i, err)
+			return false, nil
+		}
+
+		if !n.VerifyCachedHashOfNode() && damaged < maxNodeDamagesPerFile {
+			damaged++
+			nodeDamage(start, counter.count-start, "hash of node %d doesn't match its content", i)
+		}
+		onNode(i, n)
+	}
+
+	err = nodesReader.finish()
+	if err != nil {
+		nodeDamage(counter.count, -1, "found data after the last node: %v", err)
+		return false, nil
+	}
+	if version != VersionV7 && counter.count != size {
+		nodeDamage(counter.count, -1, "found %d bytes after the last node", size-counter.count)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// countingReader counts the bytes read from the reader.
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
+// RebuildCheckpointTries rebuilds the tries of a checkpoint from the tries of an earlier checkpoint,
+// by replaying the updates of the WAL segments from `from` to `to` on them, like the checkpointer does
+// when creating a checkpoint. The forest capacity must be at least the number of tries of the checkpoint.
+func RebuildCheckpointTries(
+	baseTries []*trie.MTrie,
+	walDir string,
+	from int,
+	to int,
+	forestCapacity int,
+	logger zerolog.Logger,
+) ([]*trie.MTrie, error) {
+	forest, err := mtrie.NewForest(forestCapacity, &metrics.NoopCollector{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create forest: %w", err)
+	}
+
+	err = forest.AddTries(baseTries)
+	if err != nil {
+		return nil, fmt.Errorf("cannot add tries of base checkpoint: %w", err)
+	}
+
+	if from <= to {
+		logger.Info().Msgf("replaying segments from %d to %d", from, to)
+
+		sr, err := prometheusWAL.NewSegmentsRangeReader(logger, prometheusWAL.SegmentRange{
+			Dir:   walDir,
+			First: from,
+			Last:  to,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot create segment reader: %w", err)
+		}
+		defer sr.Close()
+
+		reader := prometheusWAL.NewReader(sr)
+		for reader.Next() {
+			operation, _, update, err := Decode(reader.Record())
+			if err != nil {
+				return nil, fmt.Errorf("cannot decode LedgerWAL record: %w", err)
+			}
+
+			// deletions aren't applied by the checkpointer either
+			if operation != WALUpdate {
+				continue
+			}
+
+			_, err = forest.Update(update)
+			if err != nil {
+				return nil, fmt.Errorf("cannot apply LedgerWAL update: %w", err)
+			}
+		}
+
+		err = reader.Err()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read LedgerWAL: %w", err)
+		}
+	}
+
+	return forest.GetTries()
+}
+
+// RepairCheckpointParts replaces the given damaged part files of the v6 or v7 checkpoint with part files
+// rebuilt from the given tries, such as the tries rebuilt with RebuildCheckpointTries.
+// The header file of the checkpoint must not be damaged: a part file is only replaced if the rebuilt
+// part file has the checksum stored in the header file, so that the repaired part file is identical to
+// the original one. The tries are stored in the order of the root hashes of the checkpoint, if they can
+// still be read from the top level trie part file.
+// It returns the indexes of the repaired part files.
+func RepairCheckpointParts(
+	dir string,
+	fileName string,
+	tries []*trie.MTrie,
+	parts []int,
+	nWorker uint,
+	logger zerolog.Logger,
+) ([]int, error) {
+	version, checksums, err := ReadCheckpointPartChecksums(dir, fileName, logger)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read checksums of part files from checkpoint header: %w", err)
+	}
+
+	rootHashes, err := readTriesRootHash(logger, dir, fileName)
+	if err == nil {
+		var ordered []*trie.MTrie
+		ordered, err = triesInOrder(tries, rootHashes)
+		if err == nil {
+			tries = ordered
+		}
+	}
+	if err != nil {
+		logger.Warn().Err(err).Msg("could not order tries by the root hashes of the checkpoint")
+	}
+
+	tmpDir, err := os.MkdirTemp(dir, "checkpoint-repair-")
+	if err != nil {
+		return nil, fmt.Errorf("cannot create temporary directory: %w", err)
+	}
+	defer func() {
+		removeErr := os.RemoveAll(tmpDir)
+		if removeErr != nil {
+			logger.Warn().Err(removeErr).Msgf("could not remove temporary directory %v", tmpDir)
+		}
+	}()
+
+	logger.Info().Msgf("rebuilding v%d checkpoint with %d tries in %v", version, len(tries), tmpDir)
+
+	switch version {
+	case VersionV6:
+		err = StoreCheckpointV6(tries, tmpDir, fileName, logger, nWorker)
+	case VersionV7:
+		err = StoreCheckpointV7(tries, tmpDir, fileName, logger, nWorker)
+	default:
+		return nil, fmt.Errorf("unsupported checkpoint version %d", version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot store rebuilt checkpoint: %w", err)
+	}
+
+	repaired := make([]int, 0, len(parts))
+	for _, index := range parts {
+		if index < 0 || index >= CheckpointPartCount {
+			return nil, fmt.Errorf("invalid part file index %d", index)
+		}
+
+		rebuiltPath := path.Join(tmpDir, partFileName(fileName, index))
+		sum, err := readPartFileChecksum(rebuiltPath)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read checksum of rebuilt %v-th part file: %w", index, err)
+		}
+
+		if sum != checksums[index] {
+			logger.Warn().
+				Int("part", index).
+				Msgf("rebuilt part file has checksum %#x, but checkpoint header has %#x, it can't be repaired",
+					sum, checksums[index])
+			continue
+		}
+
+		err = os.Rename(rebuiltPath, path.Join(dir, partFileName(fileName, index)))
+		if err != nil {
+			return nil, fmt.Errorf("cannot replace %v-th part file: %w", index, err)
+		}
+
+		logger.Info().Int("part", index).Msg("repaired part file")
+		repaired = append(repaired, index)
+	}
+
+	sort.Ints(repaired)
+	return repaired, nil
+}
+
+// triesInOrder returns the tries with the given root hashes, in the order of the root hashes.
+func triesInOrder(tries []*trie.MTrie, rootHashes []ledger.RootHash) ([]*trie.MTrie, error) {
+	byRootHash := make(map[ledger.RootHash]*trie.MTrie, len(tries))
+	for _, t := range tries {
+		byRootHash[t.RootHash()] = t
+	}
+
+	ordered := make([]*trie.MTrie, len(rootHashes))
+	for i, rootHash := range rootHashes {
+		t, ok := byRootHash[rootHash]
+		if !ok {
+			return nil, fmt.Errorf("trie %v of the checkpoint is not found in the rebuilt tries", rootHash)
+		}
+		ordered[i] = t
+	}
+	return ordered, nil
+}
+
+// readPartFileChecksum reads the checksum stored at the end of the part file.
+func readPartFileChecksum(filePath string) (uint32, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	_, err = f.Seek(-crc32SumSize, io.SeekEnd)
+	if err != nil {
+		return 0, fmt.Errorf("cannot seek to checksum: %w", err)
+	}
+	return readCRC32Sum(f)
+}
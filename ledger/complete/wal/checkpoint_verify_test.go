@@ -0,0 +1,272 @@
+package wal
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func rootHashesOf(tries []*trie.MTrie) []ledger.RootHash {
+	rootHashes := make([]ledger.RootHash, len(tries))
+	for i, t := range tries {
+		rootHashes[i] = t.RootHash()
+	}
+	return rootHashes
+}
+
+// flipByte flips the bits of the byte at the given offset of the file.
+func flipByte(t *testing.T, filePath string, offset int64) {
+	file, err := os.OpenFile(filePath, os.O_RDWR, 0644)
+	require.NoError(t, err)
+	defer file.Close()
+
+	buf := make([]byte, 1)
+	_, err = file.ReadAt(buf, offset)
+	require.NoError(t, err)
+
+	buf[0] ^= 0xff
+	_, err = file.WriteAt(buf, offset)
+	require.NoError(t, err)
+}
+
+func TestVerifyValidCheckpoint(t *testing.T) {
+	stores := map[uint16]func(tries []*trie.MTrie, dir string, fileName string) error{
+		VersionV5: func(tries []*trie.MTrie, dir string, fileName string) error {
+			return StoreCheckpointV5(dir, fileName, unittest.Logger(), tries...)
+		},
+		VersionV6: func(tries []*trie.MTrie, dir string, fileName string) error {
+			return StoreCheckpointV6Concurrently(tries, dir, fileName, unittest.Logger())
+		},
+		VersionV7: func(tries []*trie.MTrie, dir string, fileName string) error {
+			return StoreCheckpointV7Concurrently(tries, dir, fileName, unittest.Logger())
+		},
+	}
+
+	for version, store := range stores {
+		unittest.RunWithTempDir(t, func(dir string) {
+			tries := createMultipleRandomTries(t)
+			fileName := "checkpoint"
+			require.NoError(t, store(tries, dir, fileName))
+
+			result, err := VerifyCheckpoint(dir, fileName, unittest.Logger())
+			require.NoError(t, err)
+			require.Equal(t, version, result.Version)
+			require.True(t, result.Valid(), "damages: %v", result.Damages)
+			require.Equal(t, rootHashesOf(tries), result.RootHashes)
+		})
+	}
+}
+
+func TestVerifyCheckpointWithDamagedNodeHash(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		fileName := "checkpoint"
+		logger := unittest.Logger()
+		require.NoError(t, StoreCheckpointV6Concurrently(tries, dir, fileName, logger))
+
+		// the first node of a subtrie part file is a leaf, its hash follows the node type and height
+		partPath, _, err := filePathSubTries(dir, fileName, 3)
+		require.NoError(t, err)
+		flipByte(t, partPath, headerSize+4)
+
+		result, err := VerifyCheckpoint(dir, fileName, logger)
+		require.NoError(t, err)
+		require.False(t, result.Valid())
+		require.False(t, result.HeaderDamaged)
+		require.Equal(t, []int{3}, result.DamagedParts)
+		require.Nil(t, result.RootHashes)
+
+		// the damaged leaf is reported first, followed by its ancestors
+		damage := result.Damages[0]
+		require.Equal(t, partPath, damage.File)
+		require.Equal(t, int64(headerSize), damage.Offset)
+		require.Greater(t, damage.Length, int64(0))
+	})
+}
+
+func TestVerifyCheckpointWithDamagedCompressedNodes(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		fileName := "checkpoint"
+		logger := unittest.Logger()
+		require.NoError(t, StoreCheckpointV7Concurrently(tries, dir, fileName, logger))
+
+		partPath, _, err := filePathSubTries(dir, fileName, 7)
+		require.NoError(t, err)
+		info, err := os.Stat(partPath)
+		require.NoError(t, err)
+		flipByte(t, partPath, info.Size()/2)
+
+		result, err := VerifyCheckpoint(dir, fileName, logger)
+		require.NoError(t, err)
+		require.False(t, result.Valid())
+		require.Equal(t, []int{7}, result.DamagedParts)
+
+		// compressed nodes are reported as a whole
+		nodesSize := info.Size() - headerSize - encNodeCountSize - crc32SumSize
+		require.Len(t, result.Damages, 1)
+		require.Equal(t, partPath, result.Damages[0].File)
+		require.Equal(t, int64(headerSize), result.Damages[0].Offset)
+		require.Equal(t, nodesSize, result.Damages[0].Length)
+	})
+}
+
+func TestVerifyCheckpointWithDamagedFiles(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		fileName := "checkpoint"
+		logger := unittest.Logger()
+		require.NoError(t, StoreCheckpointV6Concurrently(tries, dir, fileName, logger))
+
+		// damaged checksum of the top level trie part file in the header file
+		headerPath := filePathCheckpointHeader(dir, fileName)
+		flipByte(t, headerPath, headerSize+encSubtrieCountSize+subtrieCount*crc32SumSize)
+
+		partPath, _, err := filePathSubTries(dir, fileName, 12)
+		require.NoError(t, err)
+		require.NoError(t, os.Remove(partPath))
+
+		result, err := VerifyCheckpoint(dir, fileName, logger)
+		require.NoError(t, err)
+		require.False(t, result.Valid())
+		require.True(t, result.HeaderDamaged)
+		require.Equal(t, []int{12}, result.DamagedParts)
+
+		require.Len(t, result.Damages, 2)
+		require.Equal(t, headerPath, result.Damages[0].File)
+		require.Equal(t, partPath, result.Damages[1].File)
+		require.Equal(t, "file is missing", result.Damages[1].Reason)
+	})
+}
+
+func TestVerifyCheckpointV5WithDamagedTrie(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		fileName := "checkpoint"
+		logger := unittest.Logger()
+		require.NoError(t, StoreCheckpointV5(dir, fileName, logger, tries...))
+
+		// the root hash of the last trie is stored before the footer
+		filePath := filePathCheckpointHeader(dir, fileName)
+		info, err := os.Stat(filePath)
+		require.NoError(t, err)
+		flipByte(t, filePath, info.Size()-crc32SumSize-encTrieCountSize-encNodeCountSize-1)
+
+		result, err := VerifyCheckpoint(dir, fileName, logger)
+		require.NoError(t, err)
+		require.False(t, result.Valid())
+		require.True(t, result.HeaderDamaged)
+
+		trieOffset := info.Size() - crc32SumSize - encTrieCountSize - encNodeCountSize - flattener.EncodedTrieSize
+		require.Len(t, result.Damages, 1)
+		require.Equal(t, trieOffset, result.Damages[0].Offset)
+		require.Equal(t, int64(flattener.EncodedTrieSize), result.Damages[0].Length)
+	})
+}
+
+func TestRepairCheckpointParts(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		const forestCapacity = 100
+		logger := unittest.Logger()
+
+		forest, err := mtrie.NewForest(forestCapacity, &metrics.NoopCollector{}, nil)
+		require.NoError(t, err)
+
+		diskWAL, err := NewDiskWAL(logger, nil, metrics.NewNoopCollector(), dir, forestCapacity, 32, 32*1024)
+		require.NoError(t, err)
+
+		rootHash := forest.GetEmptyRootHash()
+		for i := 0; i < 30; i++ {
+			paths, payloads := randNPathPayloads(100)
+			update := &ledger.TrieUpdate{RootHash: rootHash, Paths: paths, Payloads: make([]*ledger.Payload, len(payloads))}
+			for j := range payloads {
+				update.Payloads[j] = &payloads[j]
+			}
+
+			_, _, err = diskWAL.RecordUpdate(update)
+			require.NoError(t, err)
+			rootHash, err = forest.Update(update)
+			require.NoError(t, err)
+		}
+		<-diskWAL.Done()
+
+		diskWAL, err = NewDiskWAL(logger, nil, metrics.NewNoopCollector(), dir, forestCapacity, 32, 32*1024)
+		require.NoError(t, err)
+		_, last, err := diskWAL.Segments()
+		require.NoError(t, err)
+		require.Greater(t, last, 2)
+
+		checkpointer, err := diskWAL.NewCheckpointer()
+		require.NoError(t, err)
+		base := last / 2
+		require.NoError(t, checkpointer.Checkpoint(base))
+		require.NoError(t, checkpointer.Checkpoint(last))
+		<-diskWAL.Done()
+
+		fileName := NumberToFilename(last)
+		partPath, _, err := filePathSubTries(dir, fileName, 5)
+		require.NoError(t, err)
+		info, err := os.Stat(partPath)
+		require.NoError(t, err)
+		flipByte(t, partPath, info.Size()/2)
+
+		result, err := VerifyCheckpoint(dir, fileName, logger)
+		require.NoError(t, err)
+		require.Equal(t, []int{5}, result.DamagedParts)
+
+		baseTries, err := LoadCheckpoint(path.Join(dir, NumberToFilename(base)), logger)
+		require.NoError(t, err)
+
+		t.Run("rebuilt from other tries", func(t *testing.T) {
+			// the tries of the base checkpoint don't match the checksum of the damaged part file
+			repaired, err := RepairCheckpointParts(dir, fileName, baseTries, result.DamagedParts, 4, logger)
+			require.NoError(t, err)
+			require.Empty(t, repaired)
+		})
+
+		t.Run("rebuilt from base checkpoint and segments", func(t *testing.T) {
+			tries, err := RebuildCheckpointTries(baseTries, dir, base+1, last, forestCapacity, logger)
+			require.NoError(t, err)
+
+			repaired, err := RepairCheckpointParts(dir, fileName, tries, result.DamagedParts, 4, logger)
+			require.NoError(t, err)
+			require.Equal(t, []int{5}, repaired)
+
+			result, err := VerifyCheckpoint(dir, fileName, logger)
+			require.NoError(t, err)
+			require.True(t, result.Valid(), "damages: %v", result.Damages)
+
+			rootHashes, err := ReadTriesRootHash(logger, dir, fileName)
+			require.NoError(t, err)
+			require.Equal(t, rootHashes, result.RootHashes)
+		})
+
+		// no temporary files are left behind
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		for _, entry := range entries {
+			require.False(t, entry.IsDir(), "unexpected directory %v", entry.Name())
+		}
+	})
+}
+
+func TestFilenameToNumber(t *testing.T) {
+	n, err := FilenameToNumber(NumberToFilename(10))
+	require.NoError(t, err)
+	require.Equal(t, 10, n)
+
+	_, err = FilenameToNumber("root.checkpoint")
+	require.Error(t, err)
+
+	_, err = FilenameToNumber("checkpoint.abc")
+	require.Error(t, err)
+}
@@ -60,9 +60,14 @@ const VersionV5 uint16 = 0x05
 //     file name extension
 const VersionV6 uint16 = 0x06
 
+// Version 7 includes these changes:
+//   - trie nodes in each of the 17 checkpoint part files are compressed with zstd,
+//     the file layout, footers and checksums are the same as Version 6
+const VersionV7 uint16 = 0x07
+
 // MaxVersion is the latest checkpoint version we support.
 // Need to update MaxVersion when creating a newer version.
-const MaxVersion = VersionV6
+const MaxVersion = VersionV7
 
 const (
 	encMagicSize        = 2
@@ -277,6 +282,19 @@ func NumberToFilename(n int) string {
 	return fmt.Sprintf("%s%s", checkpointFilenamePrefix, NumberToFilenamePart(n))
 }
 
+// FilenameToNumber returns the number of the checkpoint file with the given name, such as
+// 10 for "checkpoint.00000010".
+func FilenameToNumber(fileName string) (int, error) {
+	if !strings.HasPrefix(fileName, checkpointFilenamePrefix) {
+		return 0, fmt.Errorf("%v is not a checkpoint file name", fileName)
+	}
+	n, err := strconv.Atoi(fileName[len(checkpointFilenamePrefix):])
+	if err != nil {
+		return 0, fmt.Errorf("%v is not a checkpoint file name: %w", fileName, err)
+	}
+	return n, nil
+}
+
 func (c *Checkpointer) CheckpointWriter(to int) (io.WriteCloser, error) {
 	return CreateCheckpointWriterForFile(c.dir, NumberToFilename(to), c.wal.log)
 }
@@ -695,7 +713,7 @@ func readCheckpoint(f *os.File, logger zerolog.Logger) ([]*trie.MTrie, error) {
 		return readCheckpointV4(f)
 	case VersionV5:
 		return readCheckpointV5(f, logger)
-	case VersionV6:
+	case VersionV6, VersionV7:
 		return readCheckpointV6(f, logger)
 	default:
 		return nil, fmt.Errorf("unsupported file version %x", version)
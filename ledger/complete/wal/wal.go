@@ -170,6 +170,14 @@ func (w *DiskWAL) replay(
 			return fmt.Errorf("cannot get list of checkpoints: %w", err)
 		}
 
+		// delta checkpoints can be loaded as well, together with their base and the other
+		// deltas in their chain
+		deltaCheckpoints, err := checkpointer.DeltaCheckpoints()
+		if err != nil {
+			return fmt.Errorf("cannot get list of delta checkpoints: %w", err)
+		}
+		allCheckpoints = mergeCheckpointNumbers(allCheckpoints, deltaCheckpoints)
+
 		var availableCheckpoints []int
 
 		// if there are no checkpoints already, don't bother
@@ -187,7 +195,7 @@ func (w *DiskWAL) replay(
 
 			w.log.Info().Int("checkpoint", latestCheckpoint).Msg("loading checkpoint")
 
-			forestSequencing, err := checkpointer.LoadCheckpoint(latestCheckpoint)
+			forestSequencing, err := checkpointer.LoadCheckpointChain(latestCheckpoint)
 			if err != nil {
 				w.log.Warn().Int("checkpoint", latestCheckpoint).Err(err).
 					Msg("checkpoint loading failed")
@@ -306,6 +314,24 @@ func (w *DiskWAL) replay(
 	return nil
 }
 
+// mergeCheckpointNumbers merges the given sorted lists of checkpoint numbers into a sorted list
+// without duplicates.
+func mergeCheckpointNumbers(checkpoints []int, deltaCheckpoints []int) []int {
+	merged := make([]int, 0, len(checkpoints)+len(deltaCheckpoints))
+	merged = append(merged, checkpoints...)
+	merged = append(merged, deltaCheckpoints...)
+	sort.Ints(merged)
+
+	unique := merged[:0]
+	for i, checkpoint := range merged {
+		if i > 0 && checkpoint == merged[i-1] {
+			continue
+		}
+		unique = append(unique, checkpoint)
+	}
+	return unique
+}
+
 func getPossibleCheckpoints(allCheckpoints []int, from, to int) []int {
 	// list of checkpoints is sorted
 	indexFrom := sort.SearchInts(allCheckpoints, from)
@@ -0,0 +1,17 @@
+package flow
+
+// AccountStateProof is a set of registers of an account in the execution state of a sealed block,
+// along with a batch proof of their values in the state commitment sealed for the block.
+// Given a trusted state commitment, the values can be verified without trusting the node which
+// served them, see VerifyRegisterValues of the ledger/common/proof package.
+type AccountStateProof struct {
+	Address         Address
+	BlockID         Identifier
+	BlockHeight     uint64
+	StateCommitment StateCommitment
+	// Registers are the proven registers of the account. The value of a register which isn't
+	// allocated is empty.
+	Registers RegisterEntries
+	// Proof is the encoded batch proof of the registers.
+	Proof []byte
+}
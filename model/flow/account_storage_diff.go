@@ -0,0 +1,111 @@
+package flow
+
+// RegisterChangeType describes how a register changed between two heights.
+type RegisterChangeType uint8
+
+const (
+	// RegisterAdded indicates the register did not exist at the first height, but exists at the second.
+	RegisterAdded RegisterChangeType = iota + 1
+	// RegisterRemoved indicates the register existed at the first height, but not at the second.
+	RegisterRemoved
+	// RegisterModified indicates the register exists at both heights, with different values.
+	RegisterModified
+)
+
+// String returns the string representation of the change type.
+func (t RegisterChangeType) String() string {
+	switch t {
+	case RegisterAdded:
+		return "added"
+	case RegisterRemoved:
+		return "removed"
+	case RegisterModified:
+		return "modified"
+	default:
+		return "unknown"
+	}
+}
+
+// RegisterChange describes the change of a register's value between two heights.
+// A register which does not exist at a height has a nil value.
+type RegisterChange struct {
+	ID          RegisterID
+	Type        RegisterChangeType
+	ValueBefore RegisterValue
+	ValueAfter  RegisterValue
+}
+
+// AccountRegisterKind describes what an account register stores.
+type AccountRegisterKind uint8
+
+const (
+	AccountRegisterUnknown AccountRegisterKind = iota
+	// AccountRegisterStatus is the account status register.
+	AccountRegisterStatus
+	// AccountRegisterPublicKey is a register storing one of the account's public keys.
+	AccountRegisterPublicKey
+	// AccountRegisterContractNames is the register storing the names of the account's contracts.
+	AccountRegisterContractNames
+	// AccountRegisterContractCode is a register storing the code of one of the account's contracts.
+	AccountRegisterContractCode
+	// AccountRegisterStorageDomain is a register storing the root of one of the account's Cadence storage domains.
+	AccountRegisterStorageDomain
+	// AccountRegisterSlab is a register storing an atree slab of the account's Cadence storage.
+	AccountRegisterSlab
+)
+
+// String returns the string representation of the register kind.
+func (k AccountRegisterKind) String() string {
+	switch k {
+	case AccountRegisterStatus:
+		return "account_status"
+	case AccountRegisterPublicKey:
+		return "public_key"
+	case AccountRegisterContractNames:
+		return "contract_names"
+	case AccountRegisterContractCode:
+		return "contract_code"
+	case AccountRegisterStorageDomain:
+		return "storage_domain"
+	case AccountRegisterSlab:
+		return "slab"
+	default:
+		return "unknown"
+	}
+}
+
+// AccountRegisterChange is a change of one of an account's registers, together with a description
+// of what the register stores.
+type AccountRegisterChange struct {
+	RegisterChange
+	Kind AccountRegisterKind
+	// Name identifies the register within its kind: the contract name for contract code, the
+	// domain for storage domains, the key index for public keys and the slab index for slabs.
+	Name string
+}
+
+// StoragePathChange describes the change of the value stored at a Cadence storage path between two heights.
+// Values are given in their Cadence string representation, and are empty if no value is stored at the path.
+type StoragePathChange struct {
+	Path        string
+	Type        RegisterChangeType
+	ValueBefore string
+	ValueAfter  string
+}
+
+// AccountStorageDiff describes the changes made to the storage of an account between two heights.
+type AccountStorageDiff struct {
+	Address    Address
+	FromHeight uint64
+	ToHeight   uint64
+	// Changes are the changed registers of the account ordered by key.
+	Changes []AccountRegisterChange
+	// NextCursor is the key of the register to continue listing changes after. It is nil if there
+	// are no more changes.
+	NextCursor *string
+	// PathChanges are the changes of the values stored at the account's storage paths, ordered by path.
+	// They are only decoded if requested, and only for the first page of changes.
+	PathChanges []StoragePathChange
+	// PathChangesDecoded is true if the account's storage paths were decoded.
+	PathChangesDecoded bool
+}
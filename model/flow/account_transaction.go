@@ -0,0 +1,58 @@
+package flow
+
+// AccountTransactionRole describes how an account was involved in a transaction.
+type AccountTransactionRole uint8
+
+const (
+	// AccountTransactionRolePayer is set if the account paid the fees of the transaction.
+	AccountTransactionRolePayer AccountTransactionRole = iota + 1
+	// AccountTransactionRoleProposer is set if the account provided the proposal key of the transaction.
+	AccountTransactionRoleProposer
+	// AccountTransactionRoleAuthorizer is set if the account authorized the transaction.
+	AccountTransactionRoleAuthorizer
+	// AccountTransactionRoleInteraction is set if the account appeared in an event emitted by the transaction.
+	AccountTransactionRoleInteraction
+)
+
+// String returns the string representation of the role.
+func (r AccountTransactionRole) String() string {
+	switch r {
+	case AccountTransactionRolePayer:
+		return "payer"
+	case AccountTransactionRoleProposer:
+		return "proposer"
+	case AccountTransactionRoleAuthorizer:
+		return "authorizer"
+	case AccountTransactionRoleInteraction:
+		return "interaction"
+	default:
+		return "unknown"
+	}
+}
+
+// AccountTransaction is an entry of the account transaction index, recording that a transaction
+// executed in a sealed block involved the account.
+type AccountTransaction struct {
+	Address          Address
+	BlockHeight      uint64
+	TransactionID    Identifier
+	TransactionIndex uint32
+	// Roles lists all the ways the account was involved in the transaction, without duplicates.
+	Roles []AccountTransactionRole
+}
+
+// Cursor returns the cursor pointing at this entry of the account transaction index.
+func (t AccountTransaction) Cursor() AccountTransactionCursor {
+	return AccountTransactionCursor{
+		BlockHeight:      t.BlockHeight,
+		TransactionIndex: t.TransactionIndex,
+	}
+}
+
+// AccountTransactionCursor identifies a position in the transaction history of an account.
+// Account transactions are returned newest first, so the cursor points at the newest entry
+// of the next page.
+type AccountTransactionCursor struct {
+	BlockHeight      uint64
+	TransactionIndex uint32
+}
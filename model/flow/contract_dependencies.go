@@ -0,0 +1,63 @@
+package flow
+
+import (
+	"fmt"
+)
+
+// ContractID identifies a contract deployed to an account.
+type ContractID struct {
+	Address Address
+	Name    string
+}
+
+// String returns the Cadence location ID of the contract, e.g. A.1654653399040a61.FlowToken
+func (c ContractID) String() string {
+	return fmt.Sprintf("A.%s.%s", c.Address.Hex(), c.Name)
+}
+
+// ContractDependent is a contract which imports another contract, directly or transitively.
+type ContractDependent struct {
+	ContractID
+	// Depth is the length of the shortest import chain from the dependent to the contract,
+	// 1 for contracts importing it directly.
+	Depth uint32
+}
+
+// StoredTypeReference is a value stored in an account's storage whose type references types
+// defined by the analyzed contracts.
+type StoredTypeReference struct {
+	Address Address
+	// Path is the storage path of the value, e.g. /storage/flowTokenVault
+	Path string
+	// TypeIDs are the IDs of the referenced types, sorted.
+	TypeIDs []string
+}
+
+// ContractImpact describes what may be affected by an upgrade of a contract.
+type ContractImpact struct {
+	Contract ContractID
+	// Height is the height of the execution state the impact was computed from.
+	Height uint64
+	// Dependents are the contracts importing the contract directly or transitively, ordered by
+	// depth, address and name.
+	Dependents []ContractDependent
+	// UnparsedContracts are the contracts whose imports could not be determined because their code
+	// could not be parsed. They may depend on the contract.
+	UnparsedContracts []ContractID
+	// StoredReferences are the stored values referencing types defined by the contract or by any
+	// of its dependents, ordered by address and path. Only the storage of the scanned accounts is
+	// included.
+	StoredReferences []StoredTypeReference
+	// ScannedAccounts is the number of accounts whose storage was scanned for stored references.
+	ScannedAccounts uint64
+}
+
+// Contracts returns the contract and all its dependents.
+func (c *ContractImpact) Contracts() []ContractID {
+	contracts := make([]ContractID, 0, len(c.Dependents)+1)
+	contracts = append(contracts, c.Contract)
+	for _, dependent := range c.Dependents {
+		contracts = append(contracts, dependent.ContractID)
+	}
+	return contracts
+}
@@ -0,0 +1,42 @@
+package flow
+
+// ContractCodePosition is a position in the code of a contract.
+type ContractCodePosition struct {
+	// Line is the 1-based line of the position.
+	Line int
+	// Column is the 0-based column of the position.
+	Column int
+}
+
+// ContractUpdateViolation is a reason for which an update of a contract would be rejected.
+type ContractUpdateViolation struct {
+	// Message describes the violation.
+	Message string
+	// Detail provides additional information about the violation, if available.
+	Detail string
+	// Start and End delimit the range of the new code the violation is reported for.
+	// They are nil if the violation is not tied to a location in the new code.
+	Start *ContractCodePosition
+	End   *ContractCodePosition
+}
+
+// ContractUpdateValidation is the result of validating an update of a contract against the state
+// at a given block, without committing the update.
+type ContractUpdateValidation struct {
+	// Address is the address of the account the contract is deployed to.
+	Address Address
+	// Name is the name of the contract declared by the new code. It is empty if the code
+	// could not be parsed or does not declare exactly one contract.
+	Name string
+	// BlockID is the ID of the block whose state the update was validated against.
+	BlockID Identifier
+	// BlockHeight is the height of the block whose state the update was validated against.
+	BlockHeight uint64
+	// Violations are all reasons for which the update would be rejected.
+	Violations []ContractUpdateViolation
+}
+
+// Valid returns true if the update would be accepted.
+func (v *ContractUpdateValidation) Valid() bool {
+	return len(v.Violations) == 0
+}
@@ -0,0 +1,69 @@
+package flow
+
+import (
+	"math"
+	"time"
+)
+
+// EventPosition identifies the position of an event within the chain. Events are ordered by block
+// height, then by the index of the transaction within the block and then by the index of the event
+// within the transaction.
+type EventPosition struct {
+	BlockHeight      uint64
+	TransactionIndex uint32
+	EventIndex       uint32
+}
+
+// EndOfBlockPosition returns the position following all events of the block at the given height.
+func EndOfBlockPosition(height uint64) EventPosition {
+	return EventPosition{
+		BlockHeight:      height,
+		TransactionIndex: math.MaxUint32,
+		EventIndex:       math.MaxUint32,
+	}
+}
+
+// PositionOf returns the position of the given event emitted in the block at the given height.
+func PositionOf(height uint64, event Event) EventPosition {
+	return EventPosition{
+		BlockHeight:      height,
+		TransactionIndex: event.TransactionIndex,
+		EventIndex:       event.EventIndex,
+	}
+}
+
+// Before returns true if the position p is strictly before the position other.
+func (p EventPosition) Before(other EventPosition) bool {
+	if p.BlockHeight != other.BlockHeight {
+		return p.BlockHeight < other.BlockHeight
+	}
+	if p.TransactionIndex != other.TransactionIndex {
+		return p.TransactionIndex < other.TransactionIndex
+	}
+	return p.EventIndex < other.EventIndex
+}
+
+// EventSubscriptionCursor is a durable, named event subscription. It records the filter of the
+// subscription and the progress of the client, so a client reconnecting with the same name resumes
+// right after the last event it acknowledged.
+type EventSubscriptionCursor struct {
+	Name string
+
+	// EventTypes, Addresses, Contracts and Expressions are the criteria of the event filter of the subscription.
+	EventTypes  []string
+	Addresses   []string
+	Contracts   []string
+	Expressions []string
+
+	// StartHeight is the height the subscription starts at, until the first event is acknowledged.
+	StartHeight uint64
+	// LastDelivered is the position of the last event sent to the client, or nil if nothing was sent yet.
+	LastDelivered *EventPosition
+	// LastAcknowledged is the position of the last event acknowledged by the client, or nil if
+	// nothing was acknowledged yet.
+	LastAcknowledged *EventPosition
+
+	// LastActive is the time the cursor was last used. Cursors which are not used for longer than
+	// the configured TTL are removed.
+	LastActive time.Time
+}
@@ -0,0 +1,76 @@
+package flow
+
+import (
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"golang.org/x/exp/slices"
+)
+
+// EVMLogMaxTopics is the maximum number of topics of an EVM log.
+const EVMLogMaxTopics = 4
+
+// EVMLog is an entry of the EVM log index, holding a log emitted by an EVM transaction
+// executed in a sealed block.
+type EVMLog struct {
+	BlockHeight uint64
+	// TransactionID is the ID of the Flow transaction which executed the EVM transaction.
+	TransactionID       Identifier
+	EVMBlockHeight      uint64
+	EVMTransactionHash  gethCommon.Hash
+	EVMTransactionIndex uint32
+	// LogIndex is the position of the log among all EVM logs emitted in the block.
+	LogIndex uint32
+	Address  gethCommon.Address
+	Topics   []gethCommon.Hash
+	Data     []byte
+}
+
+// Cursor returns the cursor pointing at this entry of the EVM log index.
+func (l EVMLog) Cursor() EVMLogCursor {
+	return EVMLogCursor{
+		BlockHeight: l.BlockHeight,
+		LogIndex:    l.LogIndex,
+	}
+}
+
+// EVMLogCursor identifies a position in the EVM log index. Logs are returned oldest first,
+// so the cursor points at the oldest entry of the next page.
+type EVMLogCursor struct {
+	BlockHeight uint64
+	LogIndex    uint32
+}
+
+// EVMLogFilter selects EVM logs by emitting contract and topics, with the semantics of eth_getLogs:
+//   - a log matches if it was emitted by any of the addresses, or if no addresses are given.
+//   - a log matches if, for every position, its topic at that position is any of the topics
+//     of the position. A position without topics matches any topic, including no topic.
+type EVMLogFilter struct {
+	Addresses []gethCommon.Address
+	Topics    [][]gethCommon.Hash
+}
+
+// Match returns true if the log matches the filter.
+func (f EVMLogFilter) Match(log *EVMLog) bool {
+	if len(f.Addresses) > 0 && !slices.Contains(f.Addresses, log.Address) {
+		return false
+	}
+
+	if len(f.Topics) > len(log.Topics) {
+		// trailing positions without topics match logs without topic at the position
+		for _, topics := range f.Topics[len(log.Topics):] {
+			if len(topics) > 0 {
+				return false
+			}
+		}
+	}
+
+	for i, topics := range f.Topics {
+		if i >= len(log.Topics) {
+			break
+		}
+		if len(topics) > 0 && !slices.Contains(topics, log.Topics[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,102 @@
+package flow
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/onflow/cadence/common"
+)
+
+// TransactionFeeParameters are the on-chain parameters used to compute transaction fees.
+// All values are UFix64 fixed point numbers.
+type TransactionFeeParameters struct {
+	SurgeFactor         uint64
+	InclusionEffortCost uint64
+	ExecutionEffortCost uint64
+}
+
+// TransactionFeeEstimate contains the computation and fees needed to execute a transaction against
+// the state at a given block.
+// All fees are UFix64 fixed point numbers denominated in FLOW.
+type TransactionFeeEstimate struct {
+	// BlockID is the ID of the block whose state the transaction was executed against.
+	BlockID Identifier
+	// BlockHeight is the height of the block whose state the transaction was executed against.
+	BlockHeight uint64
+	// ErrorMessage contains the error message of any error that occurred when the transaction was
+	// executed. It is empty if the transaction succeeded.
+	ErrorMessage string
+	// ComputationLimit is the computation limit the transaction was executed with.
+	ComputationLimit uint64
+	// ComputationUsed is the amount of computation used while executing the transaction.
+	ComputationUsed uint64
+	// ComputationIntensities contains the metered intensity of each kind of computation.
+	ComputationIntensities map[common.ComputationKind]uint64
+	// MemoryEstimate is the estimated amount of memory used while executing the transaction.
+	MemoryEstimate uint64
+	// InclusionEffort is the effort charged for including the transaction in a block, as a UFix64.
+	InclusionEffort uint64
+	// ExecutionEffort is the effort charged for executing the transaction, as a UFix64.
+	ExecutionEffort uint64
+	// FeeParameters are the fee parameters at the block.
+	FeeParameters TransactionFeeParameters
+	// InclusionFee is the part of the fees charged for the inclusion effort.
+	InclusionFee uint64
+	// ExecutionFee is the part of the fees charged for the execution effort.
+	ExecutionFee uint64
+	// TotalFee is the fee charged for the transaction.
+	TotalFee uint64
+}
+
+// Failed returns true if the transaction's execution failed resulting in an error.
+func (e *TransactionFeeEstimate) Failed() bool {
+	return e.ErrorMessage != ""
+}
+
+// ufix64Factor is the scaling factor of UFix64 fixed point numbers.
+var ufix64Factor = big.NewInt(100_000_000)
+
+// ComputeFees computes the transaction fees for the given inclusion and execution efforts the same
+// way the FlowFees contract does: surgeFactor * (inclusionEffort * inclusionEffortCost + executionEffort * executionEffortCost).
+// All values are UFix64 fixed point numbers.
+//
+// Returns an error if any intermediate value overflows a UFix64.
+func (p TransactionFeeParameters) ComputeFees(inclusionEffort uint64, executionEffort uint64) (inclusionFee uint64, executionFee uint64, totalFee uint64, err error) {
+	inclusionCost, err := mulUFix64(inclusionEffort, p.InclusionEffortCost)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to compute inclusion cost: %w", err)
+	}
+	executionCost, err := mulUFix64(executionEffort, p.ExecutionEffortCost)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to compute execution cost: %w", err)
+	}
+	if inclusionCost > math.MaxUint64-executionCost {
+		return 0, 0, 0, fmt.Errorf("total cost overflows UFix64")
+	}
+
+	inclusionFee, err = mulUFix64(p.SurgeFactor, inclusionCost)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to compute inclusion fee: %w", err)
+	}
+	executionFee, err = mulUFix64(p.SurgeFactor, executionCost)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to compute execution fee: %w", err)
+	}
+	totalFee, err = mulUFix64(p.SurgeFactor, inclusionCost+executionCost)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to compute total fee: %w", err)
+	}
+
+	return inclusionFee, executionFee, totalFee, nil
+}
+
+// mulUFix64 multiplies two UFix64 fixed point numbers, truncating the result like Cadence does.
+func mulUFix64(a uint64, b uint64) (uint64, error) {
+	result := new(big.Int).Mul(new(big.Int).SetUint64(a), new(big.Int).SetUint64(b))
+	result.Quo(result, ufix64Factor)
+	if !result.IsUint64() {
+		return 0, fmt.Errorf("multiplication overflows UFix64")
+	}
+	return result.Uint64(), nil
+}
@@ -0,0 +1,50 @@
+package flow_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func TestTransactionFeeParameters_ComputeFees(t *testing.T) {
+	t.Run("fees", func(t *testing.T) {
+		params := flow.TransactionFeeParameters{
+			SurgeFactor:         200_000_000, // 2.0
+			InclusionEffortCost: 1_000,       // 0.00001
+			ExecutionEffortCost: 5_000_000,   // 0.05
+		}
+
+		// inclusion effort 1.0, execution effort 0.00000150
+		inclusionFee, executionFee, totalFee, err := params.ComputeFees(100_000_000, 150)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2_000), inclusionFee)
+		// 0.0000015 * 0.05 = 0.000000075 is truncated to 0.00000007, doubled by the surge factor
+		assert.Equal(t, uint64(14), executionFee)
+		assert.Equal(t, uint64(2_014), totalFee)
+	})
+
+	t.Run("zero parameters", func(t *testing.T) {
+		inclusionFee, executionFee, totalFee, err := flow.TransactionFeeParameters{}.ComputeFees(100_000_000, 1_000)
+		require.NoError(t, err)
+		assert.Zero(t, inclusionFee)
+		assert.Zero(t, executionFee)
+		assert.Zero(t, totalFee)
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		params := flow.TransactionFeeParameters{
+			SurgeFactor:         100_000_000,
+			InclusionEffortCost: math.MaxUint64,
+			ExecutionEffortCost: math.MaxUint64,
+		}
+		_, _, _, err := params.ComputeFees(200_000_000, 0)
+		assert.Error(t, err)
+
+		_, _, _, err = params.ComputeFees(100_000_000, 100_000_000)
+		assert.Error(t, err)
+	})
+}
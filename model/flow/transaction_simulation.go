@@ -0,0 +1,39 @@
+package flow
+
+// TransactionSimulationResult contains the artifacts generated by executing a transaction against
+// the state at a given block without committing any of its changes.
+type TransactionSimulationResult struct {
+	// BlockID is the ID of the block whose state the transaction was executed against.
+	BlockID Identifier
+	// BlockHeight is the height of the block whose state the transaction was executed against.
+	BlockHeight uint64
+	// ErrorMessage contains the error message of any error that occurred when the transaction was
+	// executed. It is empty if the transaction succeeded.
+	ErrorMessage string
+	// Events are the CCF encoded events emitted by the transaction.
+	Events EventsList
+	// ComputationUsed is the amount of computation used while executing the transaction.
+	ComputationUsed uint64
+	// MemoryEstimate is the estimated amount of memory used while executing the transaction.
+	MemoryEstimate uint64
+	// StorageDeltas contains the change in storage used for every account whose storage was
+	// modified by the transaction, ordered by address.
+	StorageDeltas []AccountStorageDelta
+}
+
+// Failed returns true if the transaction's execution failed resulting in an error.
+func (r *TransactionSimulationResult) Failed() bool {
+	return r.ErrorMessage != ""
+}
+
+// AccountStorageDelta describes the change in storage used by an account.
+type AccountStorageDelta struct {
+	Address           Address
+	StorageUsedBefore uint64
+	StorageUsedAfter  uint64
+}
+
+// Delta returns the number of bytes added to (positive) or removed from (negative) the account's storage.
+func (d AccountStorageDelta) Delta() int64 {
+	return int64(d.StorageUsedAfter) - int64(d.StorageUsedBefore)
+}
@@ -17,3 +17,71 @@ type ChunkDataResponse struct {
 	ChunkDataPack flow.ChunkDataPack
 	Nonce         uint64 // so that we aren't deduplicated by the network layer
 }
+
+// ExecutionStateManifestRequest represents a request for the manifest of the checkpoint
+// of the execution state at the given state commitment. Only the execution state of sealed
+// blocks is served, so the state commitment must be sealed for the given block.
+type ExecutionStateManifestRequest struct {
+	BlockID         flow.Identifier
+	StateCommitment flow.StateCommitment
+	Nonce           uint64 // so that we aren't deduplicated by the network layer
+}
+
+// ExecutionStateManifestResponse is the response to an execution state manifest request.
+// It describes the checkpoint of the execution state, whose part files are transferred
+// in chunks by execution state chunk requests.
+type ExecutionStateManifestResponse struct {
+	StateCommitment flow.StateCommitment
+	// Available is false if the execution state isn't available at the responder,
+	// in which case the other fields are empty.
+	Available bool
+	// Pending is true if the responder is creating the checkpoint, in which case the
+	// manifest should be requested again later.
+	Pending bool
+	// Header is the content of the checkpoint header file, which includes the checksums
+	// of the part files.
+	Header []byte
+	// PartSizes is the size of each part file of the checkpoint.
+	PartSizes []uint64
+	// SubtrieRootHashes are the hashes of the subtrie roots stored in each subtrie part file.
+	SubtrieRootHashes [][]flow.StateCommitment
+	Nonce             uint64 // so that we aren't deduplicated by the network layer
+}
+
+// ExecutionStateChunkRequest represents a request for a chunk of a part file of the
+// checkpoint of the execution state at the given state commitment, starting at the offset.
+type ExecutionStateChunkRequest struct {
+	StateCommitment flow.StateCommitment
+	PartIndex       uint32
+	Offset          uint64
+	Nonce           uint64 // so that we aren't deduplicated by the network layer
+}
+
+// ExecutionStateChunkResponse is the response to an execution state chunk request.
+// Data is empty if the chunk isn't available at the responder.
+type ExecutionStateChunkResponse struct {
+	StateCommitment flow.StateCommitment
+	PartIndex       uint32
+	Offset          uint64
+	Data            []byte
+	Nonce           uint64 // so that we aren't deduplicated by the network layer
+}
+
+// RegisterProofRequest represents a request for a batch proof of the given registers
+// in the execution state of the block, at the given state commitment.
+type RegisterProofRequest struct {
+	BlockID         flow.Identifier
+	StateCommitment flow.StateCommitment
+	RegisterIDs     []flow.RegisterID
+	Nonce           uint64 // so that we aren't deduplicated by the network layer
+}
+
+// RegisterProofResponse is the response to a register proof request.
+// Proof is the encoded batch proof of the requested registers, which is empty if the
+// execution state at the state commitment isn't available at the responder.
+type RegisterProofResponse struct {
+	BlockID         flow.Identifier
+	StateCommitment flow.StateCommitment
+	Proof           []byte
+	Nonce           uint64 // nonce of the request, to match the response with the request
+}
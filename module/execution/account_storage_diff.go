@@ -0,0 +1,278 @@
+package execution
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/atree"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/stdlib"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// MaxDecodedStoragePaths is the maximum number of storage paths an account may have at either height
+// of a storage diff for its paths to be decoded.
+const MaxDecodedStoragePaths = 1000
+
+// storageDomains are the domains of the Cadence storage maps stored in an account's registers.
+var storageDomains = map[string]struct{}{
+	common.PathDomainStorage.Identifier():    {},
+	common.PathDomainPrivate.Identifier():    {},
+	common.PathDomainPublic.Identifier():     {},
+	runtime.StorageDomainContract:            {},
+	stdlib.InboxStorageDomain:                {},
+	stdlib.CapabilityControllerStorageDomain: {},
+	stdlib.PathCapabilityStorageDomain:       {},
+	stdlib.AccountCapabilityStorageDomain:    {},
+}
+
+// AccountStorageDiff returns the changes made to the registers of the account between the two heights,
+// ordered by register key and starting after the cursor if it is not nil. At most limit changes are returned.
+//
+// If decodePaths is true and cursor is nil, the values stored at the account's storage paths at both
+// heights are decoded and compared as well. Paths are not decoded if the account has more than
+// MaxDecodedStoragePaths paths at either height.
+//
+// Expected errors:
+// - storage.ErrHeightNotIndexed if either height is not indexed.
+func AccountStorageDiff(
+	registers storage.RegisterIndex,
+	address flow.Address,
+	fromHeight uint64,
+	toHeight uint64,
+	cursor *string,
+	limit int,
+	decodePaths bool,
+) (*flow.AccountStorageDiff, error) {
+	owner := flow.AddressToRegisterOwner(address)
+
+	changes, more, err := registers.RegisterChanges(owner, fromHeight, toHeight, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get register changes: %w", err)
+	}
+
+	diff := &flow.AccountStorageDiff{
+		Address:    address,
+		FromHeight: fromHeight,
+		ToHeight:   toHeight,
+		Changes:    make([]flow.AccountRegisterChange, len(changes)),
+	}
+
+	for i, change := range changes {
+		kind, name := accountRegisterKind(change.ID.Key)
+		diff.Changes[i] = flow.AccountRegisterChange{
+			RegisterChange: change,
+			Kind:           kind,
+			Name:           name,
+		}
+	}
+
+	if more && len(changes) > 0 {
+		next := changes[len(changes)-1].ID.Key
+		diff.NextCursor = &next
+	}
+
+	if decodePaths && cursor == nil {
+		pathChanges, ok, err := storagePathChanges(registers, address, fromHeight, toHeight)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode storage paths: %w", err)
+		}
+		diff.PathChanges = pathChanges
+		diff.PathChangesDecoded = ok
+	}
+
+	return diff, nil
+}
+
+// accountRegisterKind returns the kind of the account register with the given key, and the name
+// identifying it within its kind.
+func accountRegisterKind(key string) (flow.AccountRegisterKind, string) {
+	switch {
+	case key == flow.AccountStatusKey:
+		return flow.AccountRegisterStatus, ""
+	case key == flow.ContractNamesKey:
+		return flow.AccountRegisterContractNames, ""
+	case flow.IsContractKey(key):
+		return flow.AccountRegisterContractCode, flow.KeyContractName(key)
+	case strings.HasPrefix(key, flow.PublicKeyKeyPrefix):
+		return flow.AccountRegisterPublicKey, strings.TrimPrefix(key, flow.PublicKeyKeyPrefix)
+	case flow.IsSlabIndexKey(key):
+		return flow.AccountRegisterSlab, strconv.FormatUint(binary.BigEndian.Uint64([]byte(key[1:])), 10)
+	}
+
+	if _, ok := storageDomains[key]; ok {
+		return flow.AccountRegisterStorageDomain, key
+	}
+
+	return flow.AccountRegisterUnknown, ""
+}
+
+// storagePathChanges compares the values stored at the storage paths of the account at both heights.
+// It returns false if the account has too many paths to be decoded.
+//
+// Expected errors:
+// - storage.ErrHeightNotIndexed if either height is not indexed.
+func storagePathChanges(
+	registers storage.RegisterIndex,
+	address flow.Address,
+	fromHeight uint64,
+	toHeight uint64,
+) ([]flow.StoragePathChange, bool, error) {
+	before, ok, err := storagePathValues(registers, address, fromHeight)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+
+	after, ok, err := storagePathValues(registers, address, toHeight)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+
+	var changes []flow.StoragePathChange
+	for path, valueBefore := range before {
+		valueAfter, exists := after[path]
+		switch {
+		case !exists:
+			changes = append(changes, flow.StoragePathChange{
+				Path:        path,
+				Type:        flow.RegisterRemoved,
+				ValueBefore: valueBefore,
+			})
+		case valueBefore != valueAfter:
+			changes = append(changes, flow.StoragePathChange{
+				Path:        path,
+				Type:        flow.RegisterModified,
+				ValueBefore: valueBefore,
+				ValueAfter:  valueAfter,
+			})
+		}
+	}
+	for path, valueAfter := range after {
+		if _, exists := before[path]; !exists {
+			changes = append(changes, flow.StoragePathChange{
+				Path:       path,
+				Type:       flow.RegisterAdded,
+				ValueAfter: valueAfter,
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes, true, nil
+}
+
+// storagePathValues returns the string representation of the values stored at the storage paths of
+// the account at the given height, keyed by path. It returns false if the account has too many paths.
+//
+// Expected errors:
+// - storage.ErrHeightNotIndexed if the height is not indexed.
+func storagePathValues(
+	registers storage.RegisterIndex,
+	address flow.Address,
+	height uint64,
+) (values map[string]string, ok bool, err error) {
+	ledger := &registersAtHeightLedger{
+		registers: registers,
+		height:    height,
+	}
+	cadenceStorage := runtime.NewStorage(ledger, nil)
+
+	inter, err := interpreter.NewInterpreter(nil, nil, &interpreter.Config{
+		Storage: cadenceStorage,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create interpreter: %w", err)
+	}
+
+	// storage maps panic on errors of the underlying ledger
+	defer func() {
+		if r := recover(); r != nil {
+			if ledger.err != nil {
+				err = ledger.err
+				return
+			}
+			err = fmt.Errorf("failed to read account storage: %v", r)
+		}
+	}()
+
+	owner := common.Address(address)
+	storageMaps := make(map[common.PathDomain]*interpreter.StorageMap, len(common.AllPathDomains))
+	count := uint64(0)
+	for _, domain := range common.AllPathDomains {
+		storageMap := cadenceStorage.GetStorageMap(owner, domain.Identifier(), false)
+		if storageMap == nil {
+			continue
+		}
+		storageMaps[domain] = storageMap
+		count += storageMap.Count()
+	}
+	if count > MaxDecodedStoragePaths {
+		return nil, false, nil
+	}
+
+	values = make(map[string]string, count)
+	for domain, storageMap := range storageMaps {
+		iter := storageMap.Iterator(nil)
+		for key, value := iter.Next(); key != nil; key, value = iter.Next() {
+			identifier, ok := key.(interpreter.StringAtreeValue)
+			if !ok {
+				continue
+			}
+			path := fmt.Sprintf("/%s/%s", domain.Identifier(), string(identifier))
+			values[path] = value.MeteredString(inter, interpreter.SeenReferences{}, interpreter.EmptyLocationRange)
+		}
+	}
+
+	return values, true, nil
+}
+
+// registersAtHeightLedger is a read-only atree.Ledger reading the registers at a height.
+type registersAtHeightLedger struct {
+	registers storage.RegisterIndex
+	height    uint64
+	// err is the first error returned by the register index
+	err error
+}
+
+var _ atree.Ledger = (*registersAtHeightLedger)(nil)
+
+func (l *registersAtHeightLedger) GetValue(owner, key []byte) ([]byte, error) {
+	value, err := l.registers.Get(flow.RegisterID{Owner: string(owner), Key: string(key)}, l.height)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		if l.err == nil {
+			l.err = err
+		}
+		return nil, err
+	}
+	return value, nil
+}
+
+func (l *registersAtHeightLedger) ValueExists(owner, key []byte) (bool, error) {
+	value, err := l.GetValue(owner, key)
+	if err != nil {
+		return false, err
+	}
+	return len(value) > 0, nil
+}
+
+func (l *registersAtHeightLedger) SetValue(_, _, _ []byte) error {
+	return fmt.Errorf("account storage is read-only")
+}
+
+func (l *registersAtHeightLedger) AllocateSlabIndex(_ []byte) (atree.SlabIndex, error) {
+	return atree.SlabIndex{}, fmt.Errorf("account storage is read-only")
+}
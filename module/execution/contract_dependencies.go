@@ -0,0 +1,393 @@
+package execution
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/onflow/atree"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/parser"
+	"github.com/onflow/cadence/runtime"
+
+	"github.com/onflow/flow-go/fvm/environment"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// contractTypeIDPattern matches the IDs of types defined by contracts deployed to an address,
+// e.g. A.1654653399040a61.FlowToken.Vault, within the ID of a possibly composed type.
+var contractTypeIDPattern = regexp.MustCompile(`A\.[0-9a-f]{16}\.[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*`)
+
+// ContractGraph is the import graph of the contracts deployed in an execution state.
+//
+// A contract depends on every contract it imports from an address. An import of an address without
+// identifiers imports all contracts deployed to the address.
+//
+// ContractGraph is not safe for concurrent use while contracts are added.
+type ContractGraph struct {
+	contracts map[flow.ContractID]*contractImports
+	// names holds the names of the contracts deployed to each address
+	names map[flow.Address][]string
+}
+
+// contractImports are the imports declared by a contract.
+type contractImports struct {
+	contracts []flow.ContractID
+	// addresses are the addresses all contracts are imported from
+	addresses []flow.Address
+	// parsed is false if the code of the contract could not be parsed
+	parsed bool
+}
+
+func NewContractGraph() *ContractGraph {
+	return &ContractGraph{
+		contracts: make(map[flow.ContractID]*contractImports),
+		names:     make(map[flow.Address][]string),
+	}
+}
+
+// AddContract adds the contract with the given code to the graph. If the code cannot be parsed,
+// the contract is added without imports and reported as unparsed.
+func (g *ContractGraph) AddContract(contract flow.ContractID, code []byte) {
+	if _, ok := g.contracts[contract]; !ok {
+		g.names[contract.Address] = append(g.names[contract.Address], contract.Name)
+	}
+
+	imports := &contractImports{}
+	g.contracts[contract] = imports
+
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return
+	}
+	imports.parsed = true
+
+	for _, declaration := range program.ImportDeclarations() {
+		location, ok := declaration.Location.(common.AddressLocation)
+		if !ok {
+			// identifier and string imports do not refer to deployed contracts
+			continue
+		}
+
+		address := flow.Address(location.Address)
+		if len(declaration.Identifiers) == 0 {
+			imports.addresses = append(imports.addresses, address)
+			continue
+		}
+		for _, identifier := range declaration.Identifiers {
+			imports.contracts = append(imports.contracts, flow.ContractID{
+				Address: address,
+				Name:    identifier.Identifier,
+			})
+		}
+	}
+}
+
+// Len returns the number of contracts in the graph.
+func (g *ContractGraph) Len() int {
+	return len(g.contracts)
+}
+
+// Contains returns true if the contract is in the graph.
+func (g *ContractGraph) Contains(contract flow.ContractID) bool {
+	_, ok := g.contracts[contract]
+	return ok
+}
+
+// Imports returns the contracts directly imported by the contract, sorted by address and name.
+func (g *ContractGraph) Imports(contract flow.ContractID) []flow.ContractID {
+	imports, ok := g.contracts[contract]
+	if !ok {
+		return nil
+	}
+
+	unique := make(map[flow.ContractID]struct{}, len(imports.contracts))
+	for _, imported := range imports.contracts {
+		unique[imported] = struct{}{}
+	}
+	for _, address := range imports.addresses {
+		for _, name := range g.names[address] {
+			unique[flow.ContractID{Address: address, Name: name}] = struct{}{}
+		}
+	}
+	delete(unique, contract)
+
+	result := make([]flow.ContractID, 0, len(unique))
+	for imported := range unique {
+		result = append(result, imported)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return lessContractID(result[i], result[j])
+	})
+
+	return result
+}
+
+// Dependents returns the contracts importing the contract directly or transitively, ordered by
+// depth, address and name.
+func (g *ContractGraph) Dependents(contract flow.ContractID) []flow.ContractDependent {
+	importedBy := make(map[flow.ContractID][]flow.ContractID)
+	for importer := range g.contracts {
+		for _, imported := range g.Imports(importer) {
+			importedBy[imported] = append(importedBy[imported], importer)
+		}
+	}
+
+	// breadth-first search, so each dependent is found at its shortest import chain
+	depths := map[flow.ContractID]uint32{contract: 0}
+	queue := []flow.ContractID{contract}
+	var dependents []flow.ContractDependent
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, importer := range importedBy[current] {
+			if _, ok := depths[importer]; ok {
+				continue
+			}
+			depths[importer] = depths[current] + 1
+			dependents = append(dependents, flow.ContractDependent{
+				ContractID: importer,
+				Depth:      depths[importer],
+			})
+			queue = append(queue, importer)
+		}
+	}
+
+	sort.Slice(dependents, func(i, j int) bool {
+		if dependents[i].Depth != dependents[j].Depth {
+			return dependents[i].Depth < dependents[j].Depth
+		}
+		return lessContractID(dependents[i].ContractID, dependents[j].ContractID)
+	})
+
+	return dependents
+}
+
+// Unparsed returns the contracts whose code could not be parsed, sorted by address and name.
+func (g *ContractGraph) Unparsed() []flow.ContractID {
+	var unparsed []flow.ContractID
+	for contract, imports := range g.contracts {
+		if !imports.parsed {
+			unparsed = append(unparsed, contract)
+		}
+	}
+	sort.Slice(unparsed, func(i, j int) bool {
+		return lessContractID(unparsed[i], unparsed[j])
+	})
+	return unparsed
+}
+
+// Impact returns the contracts depending on the contract, and the contracts whose dependencies
+// are unknown. Stored references are not included, see StoredTypeReferences.
+func (g *ContractGraph) Impact(contract flow.ContractID) *flow.ContractImpact {
+	impact := &flow.ContractImpact{
+		Contract:   contract,
+		Dependents: g.Dependents(contract),
+	}
+	for _, unparsed := range g.Unparsed() {
+		if unparsed != contract {
+			impact.UnparsedContracts = append(impact.UnparsedContracts, unparsed)
+		}
+	}
+	return impact
+}
+
+func lessContractID(a, b flow.ContractID) bool {
+	if c := bytes.Compare(a.Address[:], b.Address[:]); c != 0 {
+		return c < 0
+	}
+	return a.Name < b.Name
+}
+
+// ContractGraphAtHeight builds the import graph of all contracts deployed at the given height.
+// All accounts of the register index are visited, which is expensive for large states, so the
+// build stops as soon as the context is done.
+//
+// Expected errors:
+// - storage.ErrHeightNotIndexed if the height is not indexed.
+// - context.Canceled or context.DeadlineExceeded if the context is done before the graph is built.
+func ContractGraphAtHeight(ctx context.Context, registers storage.RegisterIndex, height uint64) (*ContractGraph, error) {
+	if height < registers.FirstHeight() || height > registers.LatestHeight() {
+		return nil, fmt.Errorf("height %d not indexed, indexed range: [%d-%d], %w",
+			height, registers.FirstHeight(), registers.LatestHeight(), storage.ErrHeightNotIndexed)
+	}
+
+	graph := NewContractGraph()
+	err := registers.ForEachOwner(func(owner string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		address := flow.BytesToAddress([]byte(owner))
+
+		encodedNames, err := registerAtHeight(registers, flow.ContractNamesRegisterID(address), height)
+		if err != nil {
+			return err
+		}
+		names, err := environment.DecodeContractNames(encodedNames)
+		if err != nil {
+			return fmt.Errorf("failed to decode contract names of account %s: %w", address, err)
+		}
+
+		for _, name := range names {
+			code, err := registerAtHeight(registers, flow.ContractRegisterID(address, name), height)
+			if err != nil {
+				return err
+			}
+			if len(code) == 0 {
+				continue
+			}
+			graph.AddContract(flow.ContractID{Address: address, Name: name}, code)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contracts: %w", err)
+	}
+
+	return graph, nil
+}
+
+// registerAtHeight returns the value of the register at the height, or nil if it does not exist.
+func registerAtHeight(registers storage.RegisterIndex, id flow.RegisterID, height uint64) (flow.RegisterValue, error) {
+	value, err := registers.Get(id, height)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get register %s: %w", id, err)
+	}
+	return value, nil
+}
+
+// StoredTypeReferencesAtHeight returns the values stored at the storage paths of the account at the
+// given height which reference types defined by any of the given contracts, ordered by path.
+//
+// Expected errors:
+// - storage.ErrHeightNotIndexed if the height is not indexed.
+func StoredTypeReferencesAtHeight(
+	registers storage.RegisterIndex,
+	address flow.Address,
+	height uint64,
+	contracts []flow.ContractID,
+) ([]flow.StoredTypeReference, error) {
+	return StoredTypeReferences(
+		&registersAtHeightLedger{
+			registers: registers,
+			height:    height,
+		},
+		address,
+		contracts,
+	)
+}
+
+// StoredTypeReferences returns the values stored at the storage paths of the account which
+// reference types defined by any of the given contracts, ordered by path.
+// A value references a type if it or any value it contains is of a type composed of the type,
+// or if it is a type value of such a type.
+func StoredTypeReferences(
+	ledger atree.Ledger,
+	address flow.Address,
+	contracts []flow.ContractID,
+) (references []flow.StoredTypeReference, err error) {
+	locations := make(map[string]struct{}, len(contracts))
+	for _, contract := range contracts {
+		locations[contract.String()] = struct{}{}
+	}
+
+	cadenceStorage := runtime.NewStorage(ledger, nil)
+
+	inter, err := interpreter.NewInterpreter(nil, nil, &interpreter.Config{
+		Storage: cadenceStorage,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create interpreter: %w", err)
+	}
+
+	// storage maps panic on errors of the underlying ledger
+	defer func() {
+		if r := recover(); r != nil {
+			if l, ok := ledger.(*registersAtHeightLedger); ok && l.err != nil {
+				err = l.err
+				return
+			}
+			err = fmt.Errorf("failed to read storage of account %s: %v", address, r)
+		}
+	}()
+
+	owner := common.Address(address)
+	for _, domain := range common.AllPathDomains {
+		storageMap := cadenceStorage.GetStorageMap(owner, domain.Identifier(), false)
+		if storageMap == nil {
+			continue
+		}
+
+		iter := storageMap.Iterator(nil)
+		for key, value := iter.Next(); key != nil; key, value = iter.Next() {
+			identifier, ok := key.(interpreter.StringAtreeValue)
+			if !ok {
+				continue
+			}
+
+			typeIDs := referencedTypeIDs(inter, value, locations)
+			if len(typeIDs) == 0 {
+				continue
+			}
+			references = append(references, flow.StoredTypeReference{
+				Address: address,
+				Path:    fmt.Sprintf("/%s/%s", domain.Identifier(), string(identifier)),
+				TypeIDs: typeIDs,
+			})
+		}
+	}
+
+	sort.Slice(references, func(i, j int) bool {
+		return references[i].Path < references[j].Path
+	})
+
+	return references, nil
+}
+
+// referencedTypeIDs returns the sorted IDs of the types defined at the given locations which are
+// referenced by the value or any value it contains.
+func referencedTypeIDs(inter *interpreter.Interpreter, value interpreter.Value, locations map[string]struct{}) []string {
+	found := make(map[string]struct{})
+
+	addTypeIDs := func(staticType interpreter.StaticType) {
+		if staticType == nil {
+			return
+		}
+		for _, typeID := range contractTypeIDPattern.FindAllString(string(staticType.ID()), -1) {
+			// the location of a type is the address and the contract name, its first three parts
+			parts := strings.SplitN(typeID, ".", 4)
+			if _, ok := locations[strings.Join(parts[:3], ".")]; ok {
+				found[typeID] = struct{}{}
+			}
+		}
+	}
+
+	var walk func(value interpreter.Value)
+	walk = func(value interpreter.Value) {
+		addTypeIDs(value.StaticType(inter))
+		if typeValue, ok := value.(interpreter.TypeValue); ok {
+			addTypeIDs(typeValue.Type)
+		}
+		value.Walk(inter, walk, interpreter.EmptyLocationRange)
+	}
+	walk(value)
+
+	typeIDs := make([]string, 0, len(found))
+	for typeID := range found {
+		typeIDs = append(typeIDs, typeID)
+	}
+	sort.Strings(typeIDs)
+
+	return typeIDs
+}
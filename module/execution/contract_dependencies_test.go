@@ -0,0 +1,82 @@
+package execution
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func TestContractGraph(t *testing.T) {
+	t.Parallel()
+
+	first := flow.HexToAddress("01")
+	second := flow.HexToAddress("02")
+
+	base := flow.ContractID{Address: first, Name: "Base"}
+	other := flow.ContractID{Address: first, Name: "Other"}
+	direct := flow.ContractID{Address: second, Name: "Direct"}
+	wildcard := flow.ContractID{Address: second, Name: "Wildcard"}
+	transitive := flow.ContractID{Address: second, Name: "Transitive"}
+	broken := flow.ContractID{Address: second, Name: "Broken"}
+
+	graph := NewContractGraph()
+	graph.AddContract(base, []byte(`access(all) contract Base {}`))
+	graph.AddContract(other, []byte(`
+		import Crypto
+		access(all) contract Other {}
+	`))
+	graph.AddContract(direct, []byte(fmt.Sprintf(`
+		import Base, Other from %s
+		access(all) contract Direct {}
+	`, first.HexWithPrefix())))
+	graph.AddContract(wildcard, []byte(fmt.Sprintf(`
+		import %s
+		access(all) contract Wildcard {}
+	`, first.HexWithPrefix())))
+	graph.AddContract(transitive, []byte(fmt.Sprintf(`
+		import Direct from %s
+		import Base from %s
+		access(all) contract Transitive {}
+	`, second.HexWithPrefix(), first.HexWithPrefix())))
+	graph.AddContract(broken, []byte(`access(all) contract Broken {`))
+
+	t.Run("imports", func(t *testing.T) {
+		assert.Empty(t, graph.Imports(base))
+		assert.Empty(t, graph.Imports(other))
+		assert.Equal(t, []flow.ContractID{base, other}, graph.Imports(direct))
+		assert.Equal(t, []flow.ContractID{base, other}, graph.Imports(wildcard))
+		assert.Equal(t, []flow.ContractID{base, direct}, graph.Imports(transitive))
+	})
+
+	t.Run("dependents", func(t *testing.T) {
+		assert.Equal(t, []flow.ContractDependent{
+			{ContractID: direct, Depth: 1},
+			{ContractID: transitive, Depth: 1},
+			{ContractID: wildcard, Depth: 1},
+		}, graph.Dependents(base))
+
+		assert.Equal(t, []flow.ContractDependent{
+			{ContractID: direct, Depth: 1},
+			{ContractID: wildcard, Depth: 1},
+			{ContractID: transitive, Depth: 2},
+		}, graph.Dependents(other))
+
+		assert.Empty(t, graph.Dependents(transitive))
+	})
+
+	t.Run("impact", func(t *testing.T) {
+		impact := graph.Impact(other)
+		assert.Equal(t, other, impact.Contract)
+		assert.Equal(t, []flow.ContractID{broken}, impact.UnparsedContracts)
+		assert.Equal(t, []flow.ContractID{other, direct, wildcard, transitive}, impact.Contracts())
+	})
+
+	t.Run("contains", func(t *testing.T) {
+		assert.Equal(t, 6, graph.Len())
+		assert.True(t, graph.Contains(broken))
+		assert.False(t, graph.Contains(flow.ContractID{Address: first, Name: "Missing"}))
+	})
+}
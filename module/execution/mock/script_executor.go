@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	flow "github.com/onflow/flow-go/model/flow"
+	execution "github.com/onflow/flow-go/module/execution"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -15,6 +16,36 @@ type ScriptExecutor struct {
 	mock.Mock
 }
 
+// EstimateTransactionFeesAtBlockHeight provides a mock function with given fields: ctx, tx, height
+func (_m *ScriptExecutor) EstimateTransactionFeesAtBlockHeight(ctx context.Context, tx *flow.TransactionBody, height uint64) (*flow.TransactionFeeEstimate, error) {
+	ret := _m.Called(ctx, tx, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateTransactionFeesAtBlockHeight")
+	}
+
+	var r0 *flow.TransactionFeeEstimate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, uint64) (*flow.TransactionFeeEstimate, error)); ok {
+		return rf(ctx, tx, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, uint64) *flow.TransactionFeeEstimate); ok {
+		r0 = rf(ctx, tx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TransactionFeeEstimate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody, uint64) error); ok {
+		r1 = rf(ctx, tx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecuteAtBlockHeight provides a mock function with given fields: ctx, script, arguments, height
 func (_m *ScriptExecutor) ExecuteAtBlockHeight(ctx context.Context, script []byte, arguments [][]byte, height uint64) ([]byte, error) {
 	ret := _m.Called(ctx, script, arguments, height)
@@ -45,6 +76,36 @@ func (_m *ScriptExecutor) ExecuteAtBlockHeight(ctx context.Context, script []byt
 	return r0, r1
 }
 
+// ExecuteScriptsAtBlockHeight provides a mock function with given fields: ctx, scripts, height
+func (_m *ScriptExecutor) ExecuteScriptsAtBlockHeight(ctx context.Context, scripts []execution.Script, height uint64) ([]execution.ScriptResult, error) {
+	ret := _m.Called(ctx, scripts, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteScriptsAtBlockHeight")
+	}
+
+	var r0 []execution.ScriptResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []execution.Script, uint64) ([]execution.ScriptResult, error)); ok {
+		return rf(ctx, scripts, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []execution.Script, uint64) []execution.ScriptResult); ok {
+		r0 = rf(ctx, scripts, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]execution.ScriptResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []execution.Script, uint64) error); ok {
+		r1 = rf(ctx, scripts, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAccountAtBlockHeight provides a mock function with given fields: ctx, address, height
 func (_m *ScriptExecutor) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
 	ret := _m.Called(ctx, address, height)
@@ -191,6 +252,66 @@ func (_m *ScriptExecutor) GetAccountKeys(ctx context.Context, address flow.Addre
 	return r0, r1
 }
 
+// SimulateTransactionAtBlockHeight provides a mock function with given fields: ctx, tx, height, skipSignatureCheck
+func (_m *ScriptExecutor) SimulateTransactionAtBlockHeight(ctx context.Context, tx *flow.TransactionBody, height uint64, skipSignatureCheck bool) (*flow.TransactionSimulationResult, error) {
+	ret := _m.Called(ctx, tx, height, skipSignatureCheck)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SimulateTransactionAtBlockHeight")
+	}
+
+	var r0 *flow.TransactionSimulationResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, uint64, bool) (*flow.TransactionSimulationResult, error)); ok {
+		return rf(ctx, tx, height, skipSignatureCheck)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, uint64, bool) *flow.TransactionSimulationResult); ok {
+		r0 = rf(ctx, tx, height, skipSignatureCheck)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TransactionSimulationResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody, uint64, bool) error); ok {
+		r1 = rf(ctx, tx, height, skipSignatureCheck)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidateContractUpdateAtBlockHeight provides a mock function with given fields: ctx, address, code, height
+func (_m *ScriptExecutor) ValidateContractUpdateAtBlockHeight(ctx context.Context, address flow.Address, code []byte, height uint64) (*flow.ContractUpdateValidation, error) {
+	ret := _m.Called(ctx, address, code, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateContractUpdateAtBlockHeight")
+	}
+
+	var r0 *flow.ContractUpdateValidation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, []byte, uint64) (*flow.ContractUpdateValidation, error)); ok {
+		return rf(ctx, address, code, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, []byte, uint64) *flow.ContractUpdateValidation); ok {
+		r0 = rf(ctx, address, code, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.ContractUpdateValidation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, []byte, uint64) error); ok {
+		r1 = rf(ctx, address, code, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewScriptExecutor creates a new instance of ScriptExecutor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewScriptExecutor(t interface {
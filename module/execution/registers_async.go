@@ -1,6 +1,7 @@
 package execution
 
 import (
+	"context"
 	"fmt"
 
 	"go.uber.org/atomic"
@@ -58,6 +59,71 @@ func (r *RegistersAsyncStore) RegisterValues(ids flow.RegisterIDs, height uint64
 	return result, nil
 }
 
+// AccountStorageDiff returns the changes made to the storage of the account between the two heights
+// from the underlying storage.RegisterIndex. See AccountStorageDiff for details.
+// Expected errors:
+//   - indexer.ErrIndexNotInitialized if the store is still bootstrapping
+//   - storage.ErrHeightNotIndexed if either height is not indexed
+func (r *RegistersAsyncStore) AccountStorageDiff(
+	address flow.Address,
+	fromHeight uint64,
+	toHeight uint64,
+	cursor *string,
+	limit int,
+	decodePaths bool,
+) (*flow.AccountStorageDiff, error) {
+	registerStore, err := r.getRegisterStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return AccountStorageDiff(registerStore, address, fromHeight, toHeight, cursor, limit, decodePaths)
+}
+
+// LatestContractGraph builds the import graph of all contracts deployed at the latest indexed height
+// of the underlying storage.RegisterIndex, and returns it with its height. See ContractGraphAtHeight
+// for details.
+// Expected errors:
+//   - indexer.ErrIndexNotInitialized if the store is still bootstrapping
+//   - storage.ErrHeightNotIndexed if the height was pruned while the graph was built
+//   - context.Canceled or context.DeadlineExceeded if the context is done before the graph is built
+func (r *RegistersAsyncStore) LatestContractGraph(ctx context.Context) (*ContractGraph, uint64, error) {
+	registerStore, err := r.getRegisterStore()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	height := registerStore.LatestHeight()
+	graph, err := ContractGraphAtHeight(ctx, registerStore, height)
+	if err != nil {
+		return nil, 0, err
+	}
+	return graph, height, nil
+}
+
+// StoredTypeReferences returns the values stored by the account at the given height which reference
+// types defined by any of the given contracts, from the underlying storage.RegisterIndex.
+// See StoredTypeReferences for details.
+// Expected errors:
+//   - indexer.ErrIndexNotInitialized if the store is still bootstrapping
+//   - storage.ErrHeightNotIndexed if the height is not indexed
+func (r *RegistersAsyncStore) StoredTypeReferences(
+	address flow.Address,
+	height uint64,
+	contracts []flow.ContractID,
+) ([]flow.StoredTypeReference, error) {
+	registerStore, err := r.getRegisterStore()
+	if err != nil {
+		return nil, err
+	}
+
+	if height > registerStore.LatestHeight() || height < registerStore.FirstHeight() {
+		return nil, storage.ErrHeightNotIndexed
+	}
+
+	return StoredTypeReferencesAtHeight(registerStore, address, height, contracts)
+}
+
 func (r *RegistersAsyncStore) getRegisterStore() (storage.RegisterIndex, error) {
 	registerStore := r.registerIndex.Load()
 	if registerStore == nil {
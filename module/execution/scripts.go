@@ -2,8 +2,11 @@ package execution
 
 import (
 	"context"
+	"errors"
+	"sync"
 
 	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/onflow/flow-go/engine/execution/computation"
 	"github.com/onflow/flow-go/engine/execution/computation/query"
@@ -23,6 +26,28 @@ import (
 // - storage.ErrHeightNotIndexed if the given height was not indexed yet or lower than the first indexed height.
 type RegisterAtHeight func(ID flow.RegisterID, height uint64) (flow.RegisterValue, error)
 
+// ErrScriptBatchBudgetExceeded is returned for scripts of a batch which were not executed, because
+// the scripts executed before them used up the batch's computation or memory budget.
+var ErrScriptBatchBudgetExceeded = errors.New("script batch computation or memory budget exceeded")
+
+// Script is a script executed as part of a batch.
+type Script struct {
+	Code      []byte
+	Arguments [][]byte
+}
+
+// ScriptResult is the result of a script executed as part of a batch.
+type ScriptResult struct {
+	// Value is the JSON-CDC encoded value returned by the script. It is nil if the script failed.
+	Value []byte
+	// ComputationUsed is the amount of computation used by the script.
+	ComputationUsed uint64
+	// MemoryEstimate is the estimated amount of memory used by the script.
+	MemoryEstimate uint64
+	// Err is the error the script failed with, if any.
+	Err error
+}
+
 type ScriptExecutor interface {
 	// ExecuteAtBlockHeight executes provided script against the block height.
 	// A result value is returned encoded as byte array. An error will be returned if script
@@ -37,6 +62,21 @@ type ScriptExecutor interface {
 		height uint64,
 	) ([]byte, error)
 
+	// ExecuteScriptsAtBlockHeight executes the provided scripts concurrently against the block height,
+	// and returns a result for each script in the same order. A script failing to execute does not
+	// fail the batch, its error is returned in its result.
+	// All scripts of the batch share a computation and memory budget. A script waits while the
+	// budget is held by running scripts, and fails with ErrScriptBatchBudgetExceeded if the budget
+	// was used up by the scripts executed before it.
+	// Expected errors:
+	// - storage.ErrNotFound if block at height was not found.
+	// - storage.ErrHeightNotIndexed if the data for the block height is not available
+	ExecuteScriptsAtBlockHeight(
+		ctx context.Context,
+		scripts []Script,
+		height uint64,
+	) ([]ScriptResult, error)
+
 	// GetAccountAtBlockHeight returns a Flow account by the provided address and block height.
 	// Expected errors:
 	// - storage.ErrHeightNotIndexed if the data for the block height is not available
@@ -61,6 +101,46 @@ type ScriptExecutor interface {
 	// Expected errors:
 	// - storage.ErrHeightNotIndexed if the data for the block height is not available
 	GetAccountKey(ctx context.Context, address flow.Address, keyIndex uint32, height uint64) (*flow.AccountPublicKey, error)
+
+	// SimulateTransactionAtBlockHeight executes the provided transaction against the block height
+	// without committing any of its changes. If skipSignatureCheck is true, the transaction's
+	// signatures and sequence number are not verified.
+	// A failure of the transaction itself is returned as part of the result, not as an error.
+	// Expected errors:
+	// - storage.ErrNotFound if block or register value at height was not found.
+	// - storage.ErrHeightNotIndexed if the data for the block height is not available
+	SimulateTransactionAtBlockHeight(
+		ctx context.Context,
+		tx *flow.TransactionBody,
+		height uint64,
+		skipSignatureCheck bool,
+	) (*flow.TransactionSimulationResult, error)
+
+	// EstimateTransactionFeesAtBlockHeight executes the provided transaction against the block height
+	// without committing any of its changes, and returns the computation it used and the fees it
+	// would be charged.
+	// A failure of the transaction itself is returned as part of the estimate, not as an error.
+	// Expected errors:
+	// - storage.ErrNotFound if block or register value at height was not found.
+	// - storage.ErrHeightNotIndexed if the data for the block height is not available
+	EstimateTransactionFeesAtBlockHeight(
+		ctx context.Context,
+		tx *flow.TransactionBody,
+		height uint64,
+	) (*flow.TransactionFeeEstimate, error)
+
+	// ValidateContractUpdateAtBlockHeight validates an update of a contract of the account to the
+	// provided code against the block height, without committing any of its changes.
+	// The reasons for which the update would be rejected are returned as violations, not as an error.
+	// Expected errors:
+	// - storage.ErrNotFound if block or register value at height was not found.
+	// - storage.ErrHeightNotIndexed if the data for the block height is not available
+	ValidateContractUpdateAtBlockHeight(
+		ctx context.Context,
+		address flow.Address,
+		code []byte,
+		height uint64,
+	) (*flow.ContractUpdateValidation, error)
 }
 
 var _ ScriptExecutor = (*Scripts)(nil)
@@ -69,6 +149,7 @@ type Scripts struct {
 	executor         *query.QueryExecutor
 	headers          storage.Headers
 	registerAtHeight RegisterAtHeight
+	queryConf        query.QueryConfig
 }
 
 func NewScripts(
@@ -89,6 +170,16 @@ func NewScripts(
 	options = append(options, fvm.WithBlocks(blocks)) // add blocks for getBlocks calls in scripts
 	options = append(options, fvm.WithMetricsReporter(metrics))
 	options = append(options, fvm.WithAllowProgramCacheWritesInScriptsEnabled(enableProgramCacheWrites))
+	// storage limits and fees only apply to transactions, and are configured the same way as on
+	// execution nodes so simulated transactions behave like executed ones.
+	options = append(options, fvm.WithAccountStorageLimit(true))
+	switch chainID {
+	case flow.Testnet,
+		flow.Sandboxnet,
+		flow.Previewnet,
+		flow.Mainnet:
+		options = append(options, fvm.WithTransactionFeesEnabled(true))
+	}
 	vmCtx := fvm.NewContext(options...)
 
 	queryExecutor := query.NewQueryExecutor(
@@ -105,6 +196,7 @@ func NewScripts(
 		executor:         queryExecutor,
 		headers:          header,
 		registerAtHeight: registerAtHeight,
+		queryConf:        queryConf,
 	}
 }
 
@@ -132,6 +224,150 @@ func (s *Scripts) ExecuteAtBlockHeight(
 	return value, err
 }
 
+// ExecuteScriptsAtBlockHeight executes the provided scripts concurrently against the block height,
+// and returns a result for each script in the same order.
+//
+// Every script is executed with at most the configured computation limit and its share of the
+// batch's memory limit, but never with more computation or memory than remains of the batch's
+// budget. The budget reserved for a script is returned to the batch once it completed, minus what
+// the script used. A script waits for running scripts to return their budget before it is executed
+// with less than its limits.
+//
+// Expected errors:
+// - storage.ErrHeightNotIndexed if the data for the block height is not available
+func (s *Scripts) ExecuteScriptsAtBlockHeight(
+	ctx context.Context,
+	scripts []Script,
+	height uint64,
+) ([]ScriptResult, error) {
+	snap, header, err := s.snapshotWithBlock(height)
+	if err != nil {
+		return nil, err
+	}
+
+	computationLimit := s.queryConf.ComputationLimit
+	if computationLimit == 0 {
+		computationLimit = fvm.DefaultComputationLimit
+	}
+
+	parallelism := s.queryConf.BatchParallelism
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+
+	// every script which may run concurrently gets an equal share of the memory budget
+	memoryLimit := max(s.queryConf.BatchMemoryLimit/uint64(parallelism), 1)
+
+	budget := newScriptBatchBudget(s.queryConf.BatchComputationLimit, s.queryConf.BatchMemoryLimit)
+	stop := context.AfterFunc(ctx, budget.cancel)
+	defer stop()
+
+	results := make([]ScriptResult, len(scripts))
+
+	g := new(errgroup.Group)
+	g.SetLimit(parallelism)
+	for i, script := range scripts {
+		i, script := i, script
+		g.Go(func() error {
+			computation, memory, err := budget.reserve(ctx, computationLimit, memoryLimit)
+			if err != nil {
+				results[i].Err = err
+				return nil
+			}
+
+			value, computationUsed, memoryEstimate, err := s.executor.ExecuteScriptWithLimits(
+				ctx,
+				script.Code,
+				script.Arguments,
+				header,
+				snap,
+				computation,
+				memory,
+			)
+			budget.release(computation-min(computationUsed, computation), memory-min(memoryEstimate, memory))
+
+			results[i] = ScriptResult{
+				Value:           value,
+				ComputationUsed: computationUsed,
+				MemoryEstimate:  memoryEstimate,
+				Err:             err,
+			}
+			return nil
+		})
+	}
+	_ = g.Wait() // scripts never return an error, they are recorded in the results
+
+	return results, nil
+}
+
+// scriptBatchBudget tracks the computation and memory remaining for the scripts of a batch.
+type scriptBatchBudget struct {
+	mu          sync.Mutex
+	cond        *sync.Cond
+	computation uint64
+	memory      uint64
+	// reserved is the number of scripts holding a part of the budget.
+	reserved int
+}
+
+func newScriptBatchBudget(computation uint64, memory uint64) *scriptBatchBudget {
+	b := &scriptBatchBudget{
+		computation: computation,
+		memory:      memory,
+	}
+	b.cond = sync.NewCond(&b.mu)
+	return b
+}
+
+// reserve reserves up to the given computation and memory from the budget. If less remains, it
+// waits until the scripts holding the budget released it, and then reserves what remains.
+// Expected errors:
+// - ErrScriptBatchBudgetExceeded if the budget is used up
+// - the context's error if the context is done while waiting
+func (b *scriptBatchBudget) reserve(ctx context.Context, computation uint64, memory uint64) (uint64, uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for (b.computation < computation || b.memory < memory) && b.reserved > 0 && ctx.Err() == nil {
+		b.cond.Wait()
+	}
+
+	if ctx.Err() != nil {
+		return 0, 0, ctx.Err()
+	}
+	if b.computation == 0 || b.memory == 0 {
+		return 0, 0, ErrScriptBatchBudgetExceeded
+	}
+
+	computation = min(computation, b.computation)
+	memory = min(memory, b.memory)
+	b.computation -= computation
+	b.memory -= memory
+	b.reserved++
+
+	return computation, memory, nil
+}
+
+// release returns unused computation and memory to the budget, and wakes up the scripts waiting
+// for it.
+func (b *scriptBatchBudget) release(computation uint64, memory uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.computation += computation
+	b.memory += memory
+	b.reserved--
+	b.cond.Broadcast()
+}
+
+// cancel wakes up the scripts waiting for the budget, so they observe that their context is done.
+func (b *scriptBatchBudget) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.cond.Broadcast()
+}
+
 // GetAccountAtBlockHeight returns a Flow account by the provided address and block height.
 // Expected errors:
 // - Script execution related errors
@@ -197,6 +433,63 @@ func (s *Scripts) GetAccountKey(ctx context.Context, address flow.Address, keyIn
 	return s.executor.GetAccountKey(ctx, address, keyIndex, header, snap)
 }
 
+// SimulateTransactionAtBlockHeight executes the provided transaction against the block height
+// without committing any of its changes.
+// Expected errors:
+// - Script execution related errors
+// - storage.ErrHeightNotIndexed if the data for the block height is not available
+func (s *Scripts) SimulateTransactionAtBlockHeight(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	height uint64,
+	skipSignatureCheck bool,
+) (*flow.TransactionSimulationResult, error) {
+	snap, header, err := s.snapshotWithBlock(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.executor.SimulateTransaction(ctx, tx, header, snap, skipSignatureCheck)
+}
+
+// EstimateTransactionFeesAtBlockHeight executes the provided transaction against the block height
+// without committing any of its changes, and returns the computation it used and the fees it
+// would be charged.
+// Expected errors:
+// - Script execution related errors
+// - storage.ErrHeightNotIndexed if the data for the block height is not available
+func (s *Scripts) EstimateTransactionFeesAtBlockHeight(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	height uint64,
+) (*flow.TransactionFeeEstimate, error) {
+	snap, header, err := s.snapshotWithBlock(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.executor.EstimateTransactionFees(ctx, tx, header, snap)
+}
+
+// ValidateContractUpdateAtBlockHeight validates an update of a contract of the account to the
+// provided code against the block height, without committing any of its changes.
+// Expected errors:
+// - Script execution related errors
+// - storage.ErrHeightNotIndexed if the data for the block height is not available
+func (s *Scripts) ValidateContractUpdateAtBlockHeight(
+	ctx context.Context,
+	address flow.Address,
+	code []byte,
+	height uint64,
+) (*flow.ContractUpdateValidation, error) {
+	snap, header, err := s.snapshotWithBlock(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.executor.ValidateContractUpdate(ctx, address, code, header, snap)
+}
+
 // snapshotWithBlock is a common function for executing scripts and get account functionality.
 // It creates a storage snapshot that is needed by the FVM to execute scripts.
 func (s *Scripts) snapshotWithBlock(height uint64) (snapshot.StorageSnapshot, *flow.Header, error) {
@@ -3,10 +3,12 @@ package execution
 import (
 	"context"
 	"fmt"
+	"math"
 	"os"
 	"testing"
 
 	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
 	"github.com/onflow/cadence/encoding/ccf"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/cadence/stdlib"
@@ -19,6 +21,7 @@ import (
 	"github.com/onflow/flow-go/engine/execution/computation/query/mock"
 	"github.com/onflow/flow-go/engine/execution/testutil"
 	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/blueprints"
 	"github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/fvm/storage/derived"
 	"github.com/onflow/flow-go/fvm/storage/snapshot"
@@ -112,6 +115,233 @@ func (s *scriptTestSuite) TestScriptExecution() {
 	})
 }
 
+func (s *scriptTestSuite) TestExecuteScriptsAtBlockHeight() {
+	s.Run("Batch Execution", func() {
+		arg, err := jsoncdc.Encode(cadence.NewInt(2))
+		s.Require().NoError(err)
+
+		scripts := []Script{
+			{Code: []byte("access(all) fun main(): Int { return 42 }")},
+			{Code: []byte("access(all) fun main(foo: Int): Int { return foo }"), Arguments: [][]byte{arg}},
+			{Code: []byte("access(all) fun main() { panic(\"boom\") }")},
+		}
+
+		results, err := s.scripts.ExecuteScriptsAtBlockHeight(context.Background(), scripts, s.height)
+		s.Require().NoError(err)
+		s.Require().Len(results, len(scripts))
+
+		s.Require().NoError(results[0].Err)
+		val, err := jsoncdc.Decode(nil, results[0].Value)
+		s.Require().NoError(err)
+		s.Assert().Equal(int64(42), val.(cadence.Int).Value.Int64())
+
+		s.Require().NoError(results[1].Err)
+		val, err = jsoncdc.Decode(nil, results[1].Value)
+		s.Require().NoError(err)
+		s.Assert().Equal(int64(2), val.(cadence.Int).Value.Int64())
+
+		// a failing script does not fail the batch
+		s.Assert().ErrorContains(results[2].Err, "boom")
+		s.Assert().Nil(results[2].Value)
+	})
+
+	s.Run("Concurrent Scripts", func() {
+		s.scripts.queryConf.BatchParallelism = 4
+
+		scripts := make([]Script, 16)
+		for i := range scripts {
+			scripts[i] = Script{Code: []byte(fmt.Sprintf("access(all) fun main(): Int { return %d }", i))}
+		}
+
+		results, err := s.scripts.ExecuteScriptsAtBlockHeight(context.Background(), scripts, s.height)
+		s.Require().NoError(err)
+		s.Require().Len(results, len(scripts))
+
+		// every script is executed with its share of the memory budget
+		for i, result := range results {
+			s.Require().NoError(result.Err)
+			val, err := jsoncdc.Decode(nil, result.Value)
+			s.Require().NoError(err)
+			s.Assert().Equal(int64(i), val.(cadence.Int).Value.Int64())
+		}
+	})
+
+	s.Run("Budget Held By Running Scripts", func() {
+		s.scripts.queryConf.ComputationLimit = 100
+		s.scripts.queryConf.BatchComputationLimit = 100
+		s.scripts.queryConf.BatchParallelism = 4
+
+		scripts := make([]Script, 4)
+		for i := range scripts {
+			scripts[i] = Script{Code: []byte("access(all) fun main(): Int { return 42 }")}
+		}
+
+		results, err := s.scripts.ExecuteScriptsAtBlockHeight(context.Background(), scripts, s.height)
+		s.Require().NoError(err)
+		s.Require().Len(results, len(scripts))
+
+		// only one script can hold the budget at a time, the others wait for it to be released
+		for _, result := range results {
+			s.Require().NoError(result.Err)
+		}
+	})
+
+	s.Run("Cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		scripts := []Script{{Code: []byte("access(all) fun main(): Int { return 42 }")}}
+		results, err := s.scripts.ExecuteScriptsAtBlockHeight(ctx, scripts, s.height)
+		s.Require().NoError(err)
+		s.Assert().ErrorIs(results[0].Err, context.Canceled)
+	})
+
+	s.Run("Budget Exceeded", func() {
+		s.scripts.queryConf.BatchComputationLimit = 10
+		s.scripts.queryConf.BatchParallelism = 1
+
+		loop := []byte(`access(all) fun main() {
+			var i = 0
+			while i < 1000 { i = i + 1 }
+		}`)
+		scripts := []Script{
+			{Code: loop},
+			{Code: []byte("access(all) fun main(): Int { return 42 }")},
+		}
+
+		results, err := s.scripts.ExecuteScriptsAtBlockHeight(context.Background(), scripts, s.height)
+		s.Require().NoError(err)
+		s.Require().Len(results, len(scripts))
+
+		// the first script uses up the budget, so the second is not executed
+		s.Assert().ErrorContains(results[0].Err, "computation exceeds limit (10)")
+		s.Assert().ErrorIs(results[1].Err, ErrScriptBatchBudgetExceeded)
+	})
+}
+
+func (s *scriptTestSuite) TestAccountStorageDiff() {
+	address := s.createAccount()
+	fromHeight := s.height
+
+	saveValueTx := flow.NewTransactionBody().
+		SetScript([]byte(`
+			transaction {
+				prepare(signer: auth(Storage) &Account) {
+					signer.storage.save(42, to: /storage/answer)
+				}
+			}`)).
+		AddAuthorizer(address)
+	s.executeTransaction(saveValueTx)
+	toHeight := s.height
+
+	s.Run("register changes", func() {
+		diff, err := AccountStorageDiff(s.registerIndex, address, fromHeight, toHeight, nil, 100, false)
+		s.Require().NoError(err)
+
+		s.Assert().Equal(address, diff.Address)
+		s.Assert().Nil(diff.NextCursor)
+		s.Assert().False(diff.PathChangesDecoded)
+
+		kinds := make(map[flow.AccountRegisterKind]flow.AccountRegisterChange)
+		for _, change := range diff.Changes {
+			kinds[change.Kind] = change
+		}
+		s.Require().Contains(kinds, flow.AccountRegisterStatus)
+		s.Assert().Equal(flow.RegisterModified, kinds[flow.AccountRegisterStatus].Type)
+		// the storage domain already exists, the value is added to its slab
+		s.Require().Contains(kinds, flow.AccountRegisterSlab)
+		s.Assert().Equal(flow.RegisterModified, kinds[flow.AccountRegisterSlab].Type)
+	})
+
+	s.Run("paginated", func() {
+		all, err := AccountStorageDiff(s.registerIndex, address, fromHeight, toHeight, nil, 100, false)
+		s.Require().NoError(err)
+		s.Require().Greater(len(all.Changes), 1)
+
+		var changes []flow.AccountRegisterChange
+		var cursor *string
+		for {
+			page, err := AccountStorageDiff(s.registerIndex, address, fromHeight, toHeight, cursor, 1, false)
+			s.Require().NoError(err)
+			changes = append(changes, page.Changes...)
+			if page.NextCursor == nil {
+				break
+			}
+			cursor = page.NextCursor
+		}
+		s.Assert().Equal(all.Changes, changes)
+	})
+
+	s.Run("decoded paths", func() {
+		diff, err := AccountStorageDiff(s.registerIndex, address, fromHeight, toHeight, nil, 100, true)
+		s.Require().NoError(err)
+
+		s.Assert().True(diff.PathChangesDecoded)
+		s.Assert().Equal([]flow.StoragePathChange{
+			{
+				Path:       "/storage/answer",
+				Type:       flow.RegisterAdded,
+				ValueAfter: "42",
+			},
+		}, diff.PathChanges)
+	})
+
+	s.Run("height not indexed", func() {
+		_, err := AccountStorageDiff(s.registerIndex, address, fromHeight, toHeight+1, nil, 100, false)
+		s.Assert().ErrorIs(err, storage.ErrHeightNotIndexed)
+	})
+}
+
+func (s *scriptTestSuite) TestContractImpact() {
+	address := s.createAccount()
+	sc := systemcontracts.SystemContractsForChain(s.chain.ChainID())
+	fungibleToken := flow.ContractID{Address: sc.FungibleToken.Address, Name: sc.FungibleToken.Name}
+	flowToken := flow.ContractID{Address: sc.FlowToken.Address, Name: sc.FlowToken.Name}
+
+	graph, err := ContractGraphAtHeight(context.Background(), s.registerIndex, s.height)
+	s.Require().NoError(err)
+
+	s.Run("dependents", func() {
+		s.Require().True(graph.Contains(fungibleToken))
+		s.Assert().Contains(graph.Imports(flowToken), fungibleToken)
+
+		impact := graph.Impact(fungibleToken)
+		s.Assert().Equal(fungibleToken, impact.Contract)
+		s.Assert().Contains(impact.Dependents, flow.ContractDependent{ContractID: flowToken, Depth: 1})
+		s.Assert().Empty(impact.UnparsedContracts)
+	})
+
+	s.Run("stored references", func() {
+		impact := graph.Impact(fungibleToken)
+		references, err := StoredTypeReferencesAtHeight(s.registerIndex, address, s.height, impact.Contracts())
+		s.Require().NoError(err)
+
+		vaultTypeID := flowToken.String() + ".Vault"
+		var vault *flow.StoredTypeReference
+		for i, reference := range references {
+			s.Assert().Equal(address, reference.Address)
+			if reference.Path == "/storage/flowTokenVault" {
+				vault = &references[i]
+			}
+		}
+		s.Require().NotNil(vault)
+		s.Assert().Contains(vault.TypeIDs, vaultTypeID)
+	})
+
+	s.Run("height not indexed", func() {
+		_, err := ContractGraphAtHeight(context.Background(), s.registerIndex, s.height+1)
+		s.Assert().ErrorIs(err, storage.ErrHeightNotIndexed)
+	})
+
+	s.Run("cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := ContractGraphAtHeight(ctx, s.registerIndex, s.height)
+		s.Assert().ErrorIs(err, context.Canceled)
+	})
+}
+
 func (s *scriptTestSuite) TestGetAccount() {
 	s.Run("Get Service Account", func() {
 		address := s.chain.ServiceAddress()
@@ -154,6 +384,242 @@ func (s *scriptTestSuite) TestGetAccountKeys() {
 
 }
 
+func (s *scriptTestSuite) TestSimulateTransaction() {
+	address := s.createAccount()
+	s.transferTokens(address, 100000000)
+
+	s.Run("Stores Value", func() {
+		tx := flow.NewTransactionBody().
+			SetScript([]byte(`
+				transaction {
+				  prepare(signer: auth(Storage) &Account) {
+					signer.storage.save("simulated", to: /storage/simulated)
+				  }
+				}`)).
+			SetPayer(address).
+			SetProposalKey(address, 0, 0).
+			AddAuthorizer(address)
+
+		result, err := s.scripts.SimulateTransactionAtBlockHeight(context.Background(), tx, s.height, true)
+		s.Require().NoError(err)
+		s.Require().False(result.Failed(), result.ErrorMessage)
+		s.Assert().Equal(s.height, result.BlockHeight)
+		s.Assert().NotZero(result.ComputationUsed)
+
+		s.Require().Len(result.StorageDeltas, 1)
+		s.Assert().Equal(address, result.StorageDeltas[0].Address)
+		s.Assert().Positive(result.StorageDeltas[0].Delta())
+
+		// the changes were not committed
+		code := []byte(fmt.Sprintf(`access(all) fun main(): String? {
+			return getAuthAccount<auth(Storage) &Account>(0x%s).storage.copy<String>(from: /storage/simulated)
+		}`, address.Hex()))
+		value, err := s.scripts.ExecuteAtBlockHeight(context.Background(), code, nil, s.height)
+		s.Require().NoError(err)
+		decoded, err := jsoncdc.Decode(nil, value)
+		s.Require().NoError(err)
+		s.Assert().Equal(cadence.NewOptional(nil), decoded)
+	})
+
+	s.Run("Emits Events", func() {
+		tx := transferTokensTx(s.chain).
+			AddArgument(jsoncdc.MustEncode(cadence.UFix64(1))).
+			AddArgument(jsoncdc.MustEncode(cadence.Address(s.chain.ServiceAddress()))).
+			SetPayer(address).
+			SetProposalKey(address, 0, 0).
+			AddAuthorizer(address)
+
+		result, err := s.scripts.SimulateTransactionAtBlockHeight(context.Background(), tx, s.height, true)
+		s.Require().NoError(err)
+		s.Require().False(result.Failed(), result.ErrorMessage)
+		s.Assert().NotEmpty(result.Events)
+	})
+
+	s.Run("Failed Transaction", func() {
+		tx := flow.NewTransactionBody().
+			SetScript([]byte(`transaction { prepare() { panic("failed") } }`)).
+			SetPayer(address).
+			SetProposalKey(address, 0, 0)
+
+		result, err := s.scripts.SimulateTransactionAtBlockHeight(context.Background(), tx, s.height, true)
+		s.Require().NoError(err)
+		s.Assert().True(result.Failed())
+		s.Assert().Contains(result.ErrorMessage, "failed")
+	})
+
+	s.Run("Missing Signatures", func() {
+		tx := flow.NewTransactionBody().
+			SetScript([]byte(`transaction {}`)).
+			SetPayer(address).
+			SetProposalKey(address, 0, 0)
+
+		result, err := s.scripts.SimulateTransactionAtBlockHeight(context.Background(), tx, s.height, false)
+		s.Require().NoError(err)
+		s.Assert().True(result.Failed())
+	})
+
+	loopTx := func() *flow.TransactionBody {
+		return flow.NewTransactionBody().
+			SetScript([]byte(`
+				transaction {
+				  prepare() {
+					var i = 0
+					while true {
+						i = i + 1
+					}
+				  }
+				}`)).
+			SetComputeLimit(math.MaxUint64).
+			SetPayer(address).
+			SetProposalKey(address, 0, 0)
+	}
+
+	s.Run("Computation Limit", func() {
+		result, err := s.scripts.SimulateTransactionAtBlockHeight(context.Background(), loopTx(), s.height, true)
+		s.Require().NoError(err)
+		s.Assert().True(result.Failed())
+		s.Assert().Contains(result.ErrorMessage, fmt.Sprintf("computation exceeds limit (%d)", fvm.DefaultComputationLimit))
+	})
+
+	s.Run("Cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := s.scripts.SimulateTransactionAtBlockHeight(ctx, loopTx(), s.height, true)
+		s.Require().ErrorIs(err, context.Canceled)
+	})
+}
+
+func (s *scriptTestSuite) TestEstimateTransactionFees() {
+	address := s.createAccount()
+
+	s.Run("Fees", func() {
+		tx := flow.NewTransactionBody().
+			SetScript([]byte(`
+				transaction {
+				  prepare(signer: auth(Storage) &Account) {
+					var i = 0
+					while i < 100 {
+						i = i + 1
+					}
+				  }
+				}`)).
+			SetPayer(address).
+			SetProposalKey(address, 0, 0).
+			AddAuthorizer(address)
+
+		estimate, err := s.scripts.EstimateTransactionFeesAtBlockHeight(context.Background(), tx, s.height)
+		s.Require().NoError(err)
+		s.Require().False(estimate.Failed(), estimate.ErrorMessage)
+
+		s.Assert().Equal(s.height, estimate.BlockHeight)
+		s.Assert().Equal(uint64(fvm.DefaultComputationLimit), estimate.ComputationLimit)
+		s.Assert().NotZero(estimate.ComputationUsed)
+		s.Assert().NotZero(estimate.ComputationIntensities[common.ComputationKindLoop])
+		s.Assert().NotZero(estimate.MemoryEstimate)
+		s.Assert().Equal(tx.InclusionEffort(), estimate.InclusionEffort)
+		s.Assert().Equal(estimate.ComputationUsed, estimate.ExecutionEffort)
+
+		s.Assert().Equal(flow.TransactionFeeParameters{
+			SurgeFactor:         uint64(testFeeParameters.SurgeFactor),
+			InclusionEffortCost: uint64(testFeeParameters.InclusionEffortCost),
+			ExecutionEffortCost: uint64(testFeeParameters.ExecutionEffortCost),
+		}, estimate.FeeParameters)
+
+		inclusionFee, executionFee, totalFee, err := estimate.FeeParameters.ComputeFees(estimate.InclusionEffort, estimate.ExecutionEffort)
+		s.Require().NoError(err)
+		s.Assert().NotZero(executionFee)
+		s.Assert().Equal(inclusionFee, estimate.InclusionFee)
+		s.Assert().Equal(executionFee, estimate.ExecutionFee)
+		s.Assert().Equal(totalFee, estimate.TotalFee)
+	})
+
+	s.Run("Computation Limit", func() {
+		tx := flow.NewTransactionBody().
+			SetScript([]byte(`
+				transaction {
+				  prepare() {
+					var i = 0
+					while true {
+						i = i + 1
+					}
+				  }
+				}`)).
+			SetComputeLimit(100).
+			SetPayer(address).
+			SetProposalKey(address, 0, 0)
+
+		estimate, err := s.scripts.EstimateTransactionFeesAtBlockHeight(context.Background(), tx, s.height)
+		s.Require().NoError(err)
+		s.Assert().True(estimate.Failed())
+		s.Assert().Equal(uint64(100), estimate.ComputationLimit)
+		s.Assert().Equal(uint64(100), estimate.ExecutionEffort)
+	})
+}
+
+func (s *scriptTestSuite) TestValidateContractUpdate() {
+	// contract deployments are restricted to the service account on this chain
+	address := s.chain.ServiceAddress()
+
+	tx := blueprints.DeployContractTransaction(address, []byte(`
+		access(all) contract Foo {
+			access(all) let x: Int
+
+			init() {
+				self.x = 1
+			}
+		}
+	`), "Foo").
+		SetPayer(address).
+		SetProposalKey(address, 0, 0)
+	s.executeTransaction(tx)
+
+	s.Run("valid update", func() {
+		validation, err := s.scripts.ValidateContractUpdateAtBlockHeight(context.Background(), address, []byte(`
+			access(all) contract Foo {
+				access(all) let x: Int
+
+				access(all) fun y(): Int {
+					return self.x
+				}
+
+				init() {
+					self.x = 1
+				}
+			}
+		`), s.height)
+		s.Require().NoError(err)
+		s.Assert().True(validation.Valid(), "%v", validation.Violations)
+		s.Assert().Equal("Foo", validation.Name)
+		s.Assert().Equal(s.height, validation.BlockHeight)
+	})
+
+	s.Run("invalid update", func() {
+		validation, err := s.scripts.ValidateContractUpdateAtBlockHeight(context.Background(), address, []byte(`
+			access(all) contract Foo {
+				access(all) let x: String
+
+				init() {
+					self.x = ""
+				}
+			}
+		`), s.height)
+		s.Require().NoError(err)
+		s.Require().Len(validation.Violations, 1)
+		s.Assert().Contains(validation.Violations[0].Message, "mismatching field `x`")
+	})
+
+	s.Run("height not indexed", func() {
+		_, err := s.scripts.ValidateContractUpdateAtBlockHeight(
+			context.Background(),
+			address,
+			[]byte(`access(all) contract Foo {}`),
+			s.height+1,
+		)
+		s.Assert().ErrorIs(err, storage.ErrHeightNotIndexed)
+	})
+}
+
 func (s *scriptTestSuite) SetupTest() {
 	logger := unittest.LoggerForTest(s.Suite.T(), zerolog.InfoLevel)
 	entropyProvider := testutil.EntropyProviderFixture(nil)
@@ -195,6 +661,8 @@ func (s *scriptTestSuite) SetupTest() {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
 		flow.Testnet.Chain(),
 		derivedChainData,
 		nil,
@@ -223,6 +691,9 @@ func (s *scriptTestSuite) TearDownTest() {
 func (s *scriptTestSuite) bootstrap() {
 	bootstrapOpts := []fvm.BootstrapProcedureOption{
 		fvm.WithInitialTokenSupply(unittest.GenesisTokenSupply),
+		// give accounts storage capacity, so simulated transactions pass the storage limit check
+		fvm.WithStorageMBPerFLOW(fvm.DefaultStorageMBPerFLOW),
+		fvm.WithTransactionFee(testFeeParameters),
 	}
 
 	executionSnapshot, out, err := s.vm.Run(
@@ -307,6 +778,22 @@ func (s *scriptTestSuite) transferTokens(accountAddress flow.Address, amount uin
 	s.snapshot = s.snapshot.Append(executionSnapshot)
 }
 
+func (s *scriptTestSuite) executeTransaction(txBody *flow.TransactionBody) {
+	executionSnapshot, output, err := s.vm.Run(
+		s.vmCtx,
+		fvm.Transaction(txBody, 0),
+		s.snapshot,
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(output.Err)
+
+	s.height++
+	err = s.registerIndex.Store(executionSnapshot.UpdatedRegisters(), s.height)
+	s.Require().NoError(err)
+
+	s.snapshot = s.snapshot.Append(executionSnapshot)
+}
+
 type accountKeyAPIVersion string
 
 const (
@@ -385,6 +872,12 @@ func newAccountKey(
 	return publicKey, encodedCadencePublicKey
 }
 
+var testFeeParameters = fvm.BootstrapProcedureFeeParameters{
+	SurgeFactor:         cadence.UFix64(200_000_000),
+	InclusionEffortCost: cadence.UFix64(1_000),
+	ExecutionEffortCost: cadence.UFix64(5_000_000),
+}
+
 func newBlockHeadersStorage(blocks []*flow.Block) storage.Headers {
 	blocksByHeight := make(map[uint64]*flow.Block)
 	for _, b := range blocks {
@@ -874,6 +874,17 @@ type ExecutionDataPrunerMetrics interface {
 	Pruned(height uint64, duration time.Duration)
 }
 
+type RegisterDBPrunerMetrics interface {
+	// Pruned records the height up to which the register db was pruned and the duration of the pruning operation.
+	Pruned(height uint64, duration time.Duration)
+
+	// NumberOfRowsPruned records the number of register versions deleted during a pruning operation.
+	NumberOfRowsPruned(rows uint64)
+
+	// ElementVisited records that a register version was inspected by the pruner.
+	ElementVisited()
+}
+
 type RestMetrics interface {
 	// Example recorder taken from:
 	// https://github.com/slok/go-http-metrics/blob/master/metrics/prometheus/prometheus.go
@@ -995,6 +1006,14 @@ type ExecutionMetrics interface {
 	// ExecutionLastFinalizedExecutedBlockHeight reports last finalized and executed block height
 	ExecutionLastFinalizedExecutedBlockHeight(height uint64)
 
+	// ExecutionRegistersPrefetched reports the number of registers prefetched for a block before its
+	// execution, and the time spent prefetching them
+	ExecutionRegistersPrefetched(registers int, dur time.Duration)
+
+	// ExecutionPrefetchedRegisterRead reports whether a register read while executing a block
+	// had been prefetched (hit) or not (miss)
+	ExecutionPrefetchedRegisterRead(hit bool)
+
 	// ExecutionBlockExecuted reports the total time and computation spent on executing a block
 	ExecutionBlockExecuted(dur time.Duration, stats BlockExecutionResultStats)
 
@@ -1004,6 +1023,14 @@ type ExecutionMetrics interface {
 	// ExecutionBlockCachedPrograms reports the number of cached programs at the end of a block
 	ExecutionBlockCachedPrograms(programs int)
 
+	// ExecutionBlockTransactionConflicts reports the number of conflicts between concurrently executed
+	// transactions of a block, and the number of transactions retried because of them
+	ExecutionBlockTransactionConflicts(conflicts int, retriedTransactions int)
+
+	// ExecutionHotContractConflicts reports the number of transaction conflicts per contract account
+	// over the recently executed blocks. Accounts missing from the map are no longer reported.
+	ExecutionHotContractConflicts(conflicts map[flow.Address]int)
+
 	// ExecutionCollectionExecuted reports the total time and computation spent on executing a collection
 	ExecutionCollectionExecuted(dur time.Duration, stats CollectionExecutionResultStats)
 
@@ -20,6 +20,10 @@ type ExecutionCollector struct {
 	totalFailedTransactionsCounter          prometheus.Counter
 	lastExecutedBlockHeightGauge            prometheus.Gauge
 	lastFinalizedExecutedBlockHeightGauge   prometheus.Gauge
+	registersPrefetched                     prometheus.Histogram
+	registerPrefetchTime                    prometheus.Histogram
+	registerPrefetchHits                    prometheus.Counter
+	registerPrefetchMisses                  prometheus.Counter
 	stateStorageDiskTotal                   prometheus.Gauge
 	storageStateCommitment                  prometheus.Gauge
 	checkpointSize                          prometheus.Gauge
@@ -43,6 +47,9 @@ type ExecutionCollector struct {
 	blockComputationUsed                    prometheus.Histogram
 	blockComputationVector                  *prometheus.GaugeVec
 	blockCachedPrograms                     prometheus.Gauge
+	blockTransactionConflicts               prometheus.Histogram
+	blockRetriedTransactions                prometheus.Histogram
+	hotContractConflicts                    *prometheus.GaugeVec
 	blockMemoryUsed                         prometheus.Histogram
 	blockEventCounts                        prometheus.Histogram
 	blockEventSize                          prometheus.Histogram
@@ -277,6 +284,29 @@ func NewExecutionCollector(tracer module.Tracer) *ExecutionCollector {
 		Help:      "Number of cached programs at the end of block execution",
 	})
 
+	blockTransactionConflicts := promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespaceExecution,
+		Subsystem: subsystemRuntime,
+		Name:      "block_transaction_conflicts",
+		Help:      "the number of conflicts between concurrently executed transactions per block",
+		Buckets:   []float64{0, 1, 2, 5, 10, 20, 50, 100, 200, 500},
+	})
+
+	blockRetriedTransactions := promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespaceExecution,
+		Subsystem: subsystemRuntime,
+		Name:      "block_retried_transactions",
+		Help:      "the number of transactions retried at least once because of conflicts per block",
+		Buckets:   []float64{0, 1, 2, 5, 10, 20, 50, 100, 200, 500},
+	})
+
+	hotContractConflicts := promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespaceExecution,
+		Subsystem: subsystemRuntime,
+		Name:      "hot_contract_conflicts",
+		Help:      "the number of transaction conflicts on registers of the account over the recently executed blocks, for the accounts with the most conflicts",
+	}, []string{LabelAccountAddress})
+
 	blockTransactionCounts := promauto.NewHistogram(prometheus.HistogramOpts{
 		Namespace: namespaceExecution,
 		Subsystem: subsystemRuntime,
@@ -567,6 +597,9 @@ func NewExecutionCollector(tracer module.Tracer) *ExecutionCollector {
 		blockComputationUsed:                    blockComputationUsed,
 		blockComputationVector:                  blockComputationVector,
 		blockCachedPrograms:                     blockCachedPrograms,
+		blockTransactionConflicts:               blockTransactionConflicts,
+		blockRetriedTransactions:                blockRetriedTransactions,
+		hotContractConflicts:                    hotContractConflicts,
 		blockMemoryUsed:                         blockMemoryUsed,
 		blockEventCounts:                        blockEventCounts,
 		blockEventSize:                          blockEventSize,
@@ -653,6 +686,36 @@ func NewExecutionCollector(tracer module.Tracer) *ExecutionCollector {
 			Help:      "the last height that was finalized and executed",
 		}),
 
+		registersPrefetched: promauto.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemStateStorage,
+			Name:      "block_prefetched_registers",
+			Help:      "the number of registers prefetched for a block before its execution",
+			Buckets:   prometheus.ExponentialBuckets(16, 2, 12),
+		}),
+
+		registerPrefetchTime: promauto.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemStateStorage,
+			Name:      "block_register_prefetch_time_milliseconds",
+			Help:      "the time spent prefetching the registers of a block in milliseconds",
+			Buckets:   []float64{1, 5, 10, 50, 100, 200, 500, 1000, 2000},
+		}),
+
+		registerPrefetchHits: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemStateStorage,
+			Name:      "prefetched_register_hits_total",
+			Help:      "the number of register reads during block execution that were prefetched",
+		}),
+
+		registerPrefetchMisses: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemStateStorage,
+			Name:      "prefetched_register_misses_total",
+			Help:      "the number of register reads during block execution that were not prefetched",
+		}),
+
 		stateStorageDiskTotal: promauto.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespaceExecution,
 			Subsystem: subsystemStateStorage,
@@ -831,6 +894,18 @@ func (ec *ExecutionCollector) ExecutionBlockCachedPrograms(programs int) {
 	ec.blockCachedPrograms.Set(float64(programs))
 }
 
+func (ec *ExecutionCollector) ExecutionBlockTransactionConflicts(conflicts int, retriedTransactions int) {
+	ec.blockTransactionConflicts.Observe(float64(conflicts))
+	ec.blockRetriedTransactions.Observe(float64(retriedTransactions))
+}
+
+func (ec *ExecutionCollector) ExecutionHotContractConflicts(conflicts map[flow.Address]int) {
+	ec.hotContractConflicts.Reset()
+	for address, count := range conflicts {
+		ec.hotContractConflicts.With(prometheus.Labels{LabelAccountAddress: address.Hex()}).Set(float64(count))
+	}
+}
+
 // ExecutionTransactionExecuted reports stats for executing a transaction
 func (ec *ExecutionCollector) ExecutionTransactionExecuted(
 	dur time.Duration,
@@ -892,6 +967,21 @@ func (ec *ExecutionCollector) ExecutionLastFinalizedExecutedBlockHeight(height u
 	ec.lastFinalizedExecutedBlockHeightGauge.Set(float64(height))
 }
 
+// ExecutionRegistersPrefetched reports the number of registers prefetched for a block
+func (ec *ExecutionCollector) ExecutionRegistersPrefetched(registers int, dur time.Duration) {
+	ec.registersPrefetched.Observe(float64(registers))
+	ec.registerPrefetchTime.Observe(float64(dur.Milliseconds()))
+}
+
+// ExecutionPrefetchedRegisterRead reports whether a register read was prefetched
+func (ec *ExecutionCollector) ExecutionPrefetchedRegisterRead(hit bool) {
+	if hit {
+		ec.registerPrefetchHits.Inc()
+	} else {
+		ec.registerPrefetchMisses.Inc()
+	}
+}
+
 // ForestApproxMemorySize records approximate memory usage of forest (all in-memory trees)
 func (ec *ExecutionCollector) ForestApproxMemorySize(bytes uint64) {
 	ec.forestApproxMemorySize.Set(float64(bytes))
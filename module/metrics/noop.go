@@ -165,12 +165,16 @@ func (nc *NoopCollector) ExecutionStorageStateCommitment(bytes int64)
 func (nc *NoopCollector) ExecutionCheckpointSize(bytes uint64)                                 {}
 func (nc *NoopCollector) ExecutionLastExecutedBlockHeight(height uint64)                       {}
 func (nc *NoopCollector) ExecutionLastFinalizedExecutedBlockHeight(height uint64)              {}
+func (nc *NoopCollector) ExecutionRegistersPrefetched(_ int, _ time.Duration)                  {}
+func (nc *NoopCollector) ExecutionPrefetchedRegisterRead(_ bool)                               {}
 func (nc *NoopCollector) ExecutionBlockExecuted(_ time.Duration, _ module.BlockExecutionResultStats) {
 }
 func (nc *NoopCollector) ExecutionCollectionExecuted(_ time.Duration, _ module.CollectionExecutionResultStats) {
 }
 func (nc *NoopCollector) ExecutionBlockExecutionEffortVectorComponent(_ string, _ uint) {}
 func (nc *NoopCollector) ExecutionBlockCachedPrograms(programs int)                     {}
+func (nc *NoopCollector) ExecutionBlockTransactionConflicts(_ int, _ int)               {}
+func (nc *NoopCollector) ExecutionHotContractConflicts(_ map[flow.Address]int)          {}
 func (nc *NoopCollector) ExecutionTransactionExecuted(_ time.Duration, _ module.TransactionExecutionResultStats, _ module.TransactionExecutionResultInfo) {
 }
 func (nc *NoopCollector) ExecutionChunkDataPackGenerated(_, _ int)                              {}
@@ -370,6 +374,11 @@ func (nc *NoopCollector) BlockIndexed(uint64, time.Duration, int, int, int) {}
 func (nc *NoopCollector) BlockReindexed()                                   {}
 func (nc *NoopCollector) InitializeLatestHeight(height uint64)              {}
 
+var _ module.RegisterDBPrunerMetrics = (*NoopCollector)(nil)
+
+func (nc *NoopCollector) NumberOfRowsPruned(rows uint64) {}
+func (nc *NoopCollector) ElementVisited()                {}
+
 var _ module.GossipSubScoringRegistryMetrics = (*NoopCollector)(nil)
 
 func (nc *NoopCollector) DuplicateMessagePenalties(penalty float64) {}
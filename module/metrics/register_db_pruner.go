@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/onflow/flow-go/module"
+)
+
+var _ module.RegisterDBPrunerMetrics = (*RegisterDBPrunerCollector)(nil)
+
+type RegisterDBPrunerCollector struct {
+	pruneDurations     prometheus.Histogram
+	latestHeightPruned prometheus.Gauge
+	numberOfRowsPruned prometheus.Counter
+	elementsVisited    prometheus.Counter
+}
+
+func NewRegisterDBPrunerCollector() *RegisterDBPrunerCollector {
+	return &RegisterDBPrunerCollector{
+		pruneDurations: promauto.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespaceAccess,
+			Subsystem: subsystemRegisterDBPruner,
+			Name:      "prune_durations_ms",
+			Help:      "the durations of register db pruning operations in milliseconds",
+			Buckets:   prometheus.ExponentialBuckets(100, 2, 16), // 100ms to ~55min
+		}),
+		latestHeightPruned: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceAccess,
+			Subsystem: subsystemRegisterDBPruner,
+			Name:      "latest_height_pruned",
+			Help:      "the height up to which the register db was last pruned",
+		}),
+		numberOfRowsPruned: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceAccess,
+			Subsystem: subsystemRegisterDBPruner,
+			Name:      "rows_pruned_total",
+			Help:      "the number of register versions deleted by the pruner",
+		}),
+		elementsVisited: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceAccess,
+			Subsystem: subsystemRegisterDBPruner,
+			Name:      "elements_visited_total",
+			Help:      "the number of register versions inspected by the pruner",
+		}),
+	}
+}
+
+// Pruned records the height up to which the register db was pruned and the duration of the pruning operation.
+func (c *RegisterDBPrunerCollector) Pruned(height uint64, duration time.Duration) {
+	c.pruneDurations.Observe(float64(duration.Milliseconds()))
+	c.latestHeightPruned.Set(float64(height))
+}
+
+// NumberOfRowsPruned records the number of register versions deleted during a pruning operation.
+func (c *RegisterDBPrunerCollector) NumberOfRowsPruned(rows uint64) {
+	c.numberOfRowsPruned.Add(float64(rows))
+}
+
+// ElementVisited records that a register version was inspected by the pruner.
+func (c *RegisterDBPrunerCollector) ElementVisited() {
+	c.elementsVisited.Inc()
+}
@@ -56,6 +56,11 @@ func (_m *ExecutionMetrics) ExecutionBlockExecutionEffortVectorComponent(_a0 str
 	_m.Called(_a0, _a1)
 }
 
+// ExecutionBlockTransactionConflicts provides a mock function with given fields: conflicts, retriedTransactions
+func (_m *ExecutionMetrics) ExecutionBlockTransactionConflicts(conflicts int, retriedTransactions int) {
+	_m.Called(conflicts, retriedTransactions)
+}
+
 // ExecutionCheckpointSize provides a mock function with given fields: bytes
 func (_m *ExecutionMetrics) ExecutionCheckpointSize(bytes uint64) {
 	_m.Called(bytes)
@@ -86,6 +91,11 @@ func (_m *ExecutionMetrics) ExecutionComputationResultUploaded() {
 	_m.Called()
 }
 
+// ExecutionHotContractConflicts provides a mock function with given fields: conflicts
+func (_m *ExecutionMetrics) ExecutionHotContractConflicts(conflicts map[flow.Address]int) {
+	_m.Called(conflicts)
+}
+
 // ExecutionLastExecutedBlockHeight provides a mock function with given fields: height
 func (_m *ExecutionMetrics) ExecutionLastExecutedBlockHeight(height uint64) {
 	_m.Called(height)
@@ -96,6 +106,16 @@ func (_m *ExecutionMetrics) ExecutionLastFinalizedExecutedBlockHeight(height uin
 	_m.Called(height)
 }
 
+// ExecutionPrefetchedRegisterRead provides a mock function with given fields: hit
+func (_m *ExecutionMetrics) ExecutionPrefetchedRegisterRead(hit bool) {
+	_m.Called(hit)
+}
+
+// ExecutionRegistersPrefetched provides a mock function with given fields: registers, dur
+func (_m *ExecutionMetrics) ExecutionRegistersPrefetched(registers int, dur time.Duration) {
+	_m.Called(registers, dur)
+}
+
 // ExecutionScriptExecuted provides a mock function with given fields: dur, compUsed, memoryUsed, memoryEstimate
 func (_m *ExecutionMetrics) ExecutionScriptExecuted(dur time.Duration, compUsed uint64, memoryUsed uint64, memoryEstimate uint64) {
 	_m.Called(dur, compUsed, memoryUsed, memoryEstimate)
@@ -0,0 +1,157 @@
+package indexer
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/ccf"
+	"golang.org/x/exp/slices"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+)
+
+// accountTransactionKey identifies an entry of the account transaction index within a block.
+type accountTransactionKey struct {
+	address flow.Address
+	txIndex uint32
+}
+
+// accountTransactionsBuilder collects the accounts involved in the transactions of a block.
+type accountTransactionsBuilder struct {
+	height  uint64
+	entries map[accountTransactionKey]*flow.AccountTransaction
+}
+
+func newAccountTransactionsBuilder(height uint64) *accountTransactionsBuilder {
+	return &accountTransactionsBuilder{
+		height:  height,
+		entries: make(map[accountTransactionKey]*flow.AccountTransaction),
+	}
+}
+
+// add records that the account was involved in the transaction with the given role.
+func (b *accountTransactionsBuilder) add(address flow.Address, txIndex uint32, txID flow.Identifier, role flow.AccountTransactionRole) {
+	key := accountTransactionKey{address: address, txIndex: txIndex}
+
+	entry, ok := b.entries[key]
+	if !ok {
+		entry = &flow.AccountTransaction{
+			Address:          address,
+			BlockHeight:      b.height,
+			TransactionID:    txID,
+			TransactionIndex: txIndex,
+		}
+		b.entries[key] = entry
+	}
+
+	if !slices.Contains(entry.Roles, role) {
+		entry.Roles = append(entry.Roles, role)
+	}
+}
+
+// build returns all collected entries, ordered by address and transaction index.
+func (b *accountTransactionsBuilder) build() []flow.AccountTransaction {
+	transactions := make([]flow.AccountTransaction, 0, len(b.entries))
+	for _, entry := range b.entries {
+		sort.Slice(entry.Roles, func(i, j int) bool { return entry.Roles[i] < entry.Roles[j] })
+		transactions = append(transactions, *entry)
+	}
+
+	sort.Slice(transactions, func(i, j int) bool {
+		if transactions[i].Address == transactions[j].Address {
+			return transactions[i].TransactionIndex < transactions[j].TransactionIndex
+		}
+		return bytes.Compare(transactions[i].Address[:], transactions[j].Address[:]) < 0
+	})
+
+	return transactions
+}
+
+// findAccountTransactions returns the account transaction index entries for all transactions of the block.
+// An account is involved in a transaction if it is the payer, proposer or an authorizer of the transaction,
+// or if its address appears in any of the events emitted by the transaction.
+//
+// Events which cannot be decoded are skipped, and passed to onInvalidEvent.
+func findAccountTransactions(
+	height uint64,
+	chunks []*execution_data.ChunkExecutionData,
+	onInvalidEvent func(event flow.Event, err error),
+) []flow.AccountTransaction {
+	builder := newAccountTransactionsBuilder(height)
+
+	// transaction indexes are assigned in execution order across all collections of the block
+	txIndex := uint32(0)
+	for _, chunk := range chunks {
+		if chunk.Collection == nil {
+			continue
+		}
+		for _, tx := range chunk.Collection.Transactions {
+			txID := tx.ID()
+			builder.add(tx.Payer, txIndex, txID, flow.AccountTransactionRolePayer)
+			builder.add(tx.ProposalKey.Address, txIndex, txID, flow.AccountTransactionRoleProposer)
+			for _, authorizer := range tx.Authorizers {
+				builder.add(authorizer, txIndex, txID, flow.AccountTransactionRoleAuthorizer)
+			}
+			txIndex++
+		}
+	}
+
+	for _, chunk := range chunks {
+		for _, event := range chunk.Events {
+			addresses, err := eventAddresses(event)
+			if err != nil {
+				onInvalidEvent(event, err)
+				continue
+			}
+			for _, address := range addresses {
+				builder.add(address, event.TransactionIndex, event.TransactionID, flow.AccountTransactionRoleInteraction)
+			}
+		}
+	}
+
+	return builder.build()
+}
+
+// eventAddresses returns all addresses contained in the CCF encoded payload of the event.
+// No errors are expected during normal operation and indicate an undecodable event payload.
+func eventAddresses(event flow.Event) ([]flow.Address, error) {
+	payload, err := ccf.Decode(nil, event.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal event payload: %w", err)
+	}
+
+	var addresses []flow.Address
+	collectAddresses(payload, &addresses)
+	return addresses, nil
+}
+
+// collectAddresses appends all addresses contained in the value, including addresses nested in
+// optionals, collections and composite fields.
+func collectAddresses(value cadence.Value, addresses *[]flow.Address) {
+	switch v := value.(type) {
+	case cadence.Address:
+		*addresses = append(*addresses, flow.Address(v))
+	case cadence.Optional:
+		if v.Value != nil {
+			collectAddresses(v.Value, addresses)
+		}
+	case cadence.Array:
+		for _, element := range v.Values {
+			collectAddresses(element, addresses)
+		}
+	case cadence.Dictionary:
+		for _, pair := range v.Pairs {
+			collectAddresses(pair.Key, addresses)
+			collectAddresses(pair.Value, addresses)
+		}
+	case cadence.Capability:
+		*addresses = append(*addresses, flow.Address(v.Address))
+	case cadence.Composite:
+		for _, field := range cadence.FieldsMappedByName(v) {
+			collectAddresses(field, addresses)
+		}
+	}
+}
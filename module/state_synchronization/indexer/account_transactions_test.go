@@ -0,0 +1,158 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/encoding/ccf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestFindAccountTransactions tests that payers, proposers, authorizers and addresses emitted in events
+// are all indexed with the correct transaction and roles.
+func TestFindAccountTransactions(t *testing.T) {
+	t.Parallel()
+
+	payer := unittest.RandomAddressFixture()
+	authorizer := unittest.RandomAddressFixture()
+	recipient := unittest.RandomAddressFixture()
+	listed := unittest.RandomAddressFixture()
+
+	// payer is also the proposer and an authorizer of the first transaction
+	tx1 := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+		tx.Payer = payer
+		tx.ProposalKey.Address = payer
+		tx.Authorizers = []flow.Address{payer, authorizer}
+	})
+	tx2 := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+		tx.Payer = payer
+		tx.ProposalKey.Address = authorizer
+		tx.Authorizers = []flow.Address{}
+	})
+
+	chunks := []*execution_data.ChunkExecutionData{
+		{
+			Collection: &flow.Collection{Transactions: []*flow.TransactionBody{&tx1}},
+			Events: []flow.Event{
+				addressEventFixture(t, tx1.ID(), 0, &recipient, nil),
+				// events without payload are skipped
+				unittest.EventFixture("A.0x1.Foo.Bar", 0, 1, tx1.ID(), 0),
+			},
+		},
+		{
+			Collection: &flow.Collection{Transactions: []*flow.TransactionBody{&tx2}},
+			Events: []flow.Event{
+				addressEventFixture(t, tx2.ID(), 1, nil, []flow.Address{listed, payer}),
+			},
+		},
+	}
+
+	skipped := 0
+	actual := findAccountTransactions(42, chunks, func(flow.Event, error) { skipped++ })
+	assert.Equal(t, 1, skipped)
+
+	expected := []flow.AccountTransaction{
+		{
+			Address:          payer,
+			BlockHeight:      42,
+			TransactionID:    tx1.ID(),
+			TransactionIndex: 0,
+			Roles: []flow.AccountTransactionRole{
+				flow.AccountTransactionRolePayer,
+				flow.AccountTransactionRoleProposer,
+				flow.AccountTransactionRoleAuthorizer,
+			},
+		},
+		{
+			Address:          payer,
+			BlockHeight:      42,
+			TransactionID:    tx2.ID(),
+			TransactionIndex: 1,
+			Roles: []flow.AccountTransactionRole{
+				flow.AccountTransactionRolePayer,
+				flow.AccountTransactionRoleInteraction,
+			},
+		},
+		{
+			Address:          authorizer,
+			BlockHeight:      42,
+			TransactionID:    tx1.ID(),
+			TransactionIndex: 0,
+			Roles:            []flow.AccountTransactionRole{flow.AccountTransactionRoleAuthorizer},
+		},
+		{
+			Address:          authorizer,
+			BlockHeight:      42,
+			TransactionID:    tx2.ID(),
+			TransactionIndex: 1,
+			Roles:            []flow.AccountTransactionRole{flow.AccountTransactionRoleProposer},
+		},
+		{
+			Address:          recipient,
+			BlockHeight:      42,
+			TransactionID:    tx1.ID(),
+			TransactionIndex: 0,
+			Roles:            []flow.AccountTransactionRole{flow.AccountTransactionRoleInteraction},
+		},
+		{
+			Address:          listed,
+			BlockHeight:      42,
+			TransactionID:    tx2.ID(),
+			TransactionIndex: 1,
+			Roles:            []flow.AccountTransactionRole{flow.AccountTransactionRoleInteraction},
+		},
+	}
+
+	assert.ElementsMatch(t, expected, actual)
+}
+
+// addressEventFixture returns a CCF encoded event with an optional address field and an address array field.
+func addressEventFixture(t *testing.T, txID flow.Identifier, txIndex uint32, to *flow.Address, addresses []flow.Address) flow.Event {
+	location := common.NewAddressLocation(nil, common.Address{0x1}, "Test")
+	eventType := cadence.NewEventType(
+		location,
+		"Test.Transferred",
+		[]cadence.Field{
+			{
+				Identifier: "to",
+				Type:       cadence.NewOptionalType(cadence.AddressType),
+			},
+			{
+				Identifier: "addresses",
+				Type:       cadence.NewVariableSizedArrayType(cadence.AddressType),
+			},
+		},
+		nil,
+	)
+
+	toValue := cadence.NewOptional(nil)
+	if to != nil {
+		toValue = cadence.NewOptional(cadence.NewAddress(*to))
+	}
+
+	addressValues := make([]cadence.Value, len(addresses))
+	for i, address := range addresses {
+		addressValues[i] = cadence.NewAddress(address)
+	}
+
+	event := cadence.NewEvent([]cadence.Value{
+		toValue,
+		cadence.NewArray(addressValues).WithType(cadence.NewVariableSizedArrayType(cadence.AddressType)),
+	}).WithType(eventType)
+
+	payload, err := ccf.Encode(event)
+	require.NoError(t, err)
+
+	return flow.Event{
+		Type:             flow.EventType(eventType.ID()),
+		TransactionID:    txID,
+		TransactionIndex: txIndex,
+		Payload:          payload,
+	}
+}
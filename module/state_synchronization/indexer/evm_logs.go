@@ -0,0 +1,98 @@
+package indexer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence/common"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	gethRLP "github.com/onflow/go-ethereum/rlp"
+
+	"github.com/onflow/flow-go/fvm/evm/events"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+)
+
+// evmTransactionEventType returns the type of the events emitted for executed EVM transactions on the given chain.
+func evmTransactionEventType(chainID flow.ChainID) flow.EventType {
+	evmContract := common.Address(systemcontracts.SystemContractsForChain(chainID).EVMContract.Address)
+	return flow.EventType(common.NewAddressLocation(nil, evmContract, string(events.EventTypeTransactionExecuted)).ID())
+}
+
+// findEVMLogs returns the EVM log index entries for all EVM transactions executed in the block.
+// The logs are decoded from the EVM transaction executed events of the given type, and are
+// assigned log indexes in execution order across the whole block.
+//
+// Events which cannot be decoded are skipped, and passed to onInvalidEvent.
+func findEVMLogs(
+	height uint64,
+	eventType flow.EventType,
+	chunks []*execution_data.ChunkExecutionData,
+	onInvalidEvent func(event flow.Event, err error),
+) []flow.EVMLog {
+	var txEvents []flow.Event
+	for _, chunk := range chunks {
+		for _, event := range chunk.Events {
+			if event.Type == eventType {
+				txEvents = append(txEvents, event)
+			}
+		}
+	}
+
+	sort.SliceStable(txEvents, func(i, j int) bool {
+		if txEvents[i].TransactionIndex == txEvents[j].TransactionIndex {
+			return txEvents[i].EventIndex < txEvents[j].EventIndex
+		}
+		return txEvents[i].TransactionIndex < txEvents[j].TransactionIndex
+	})
+
+	logs := make([]flow.EVMLog, 0)
+	for _, event := range txEvents {
+		payload, evmLogs, err := decodeEVMTransactionEvent(event)
+		if err != nil {
+			onInvalidEvent(event, err)
+			continue
+		}
+
+		for _, log := range evmLogs {
+			logs = append(logs, flow.EVMLog{
+				BlockHeight:         height,
+				TransactionID:       event.TransactionID,
+				EVMBlockHeight:      payload.BlockHeight,
+				EVMTransactionHash:  payload.Hash,
+				EVMTransactionIndex: uint32(payload.Index),
+				LogIndex:            uint32(len(logs)),
+				Address:             log.Address,
+				Topics:              log.Topics,
+				Data:                log.Data,
+			})
+		}
+	}
+
+	return logs
+}
+
+// decodeEVMTransactionEvent decodes the payload and the logs of an EVM transaction executed event.
+// No errors are expected during normal operation and indicate an undecodable event payload.
+func decodeEVMTransactionEvent(event flow.Event) (*events.TransactionEventPayload, []*gethTypes.Log, error) {
+	cadenceEvent, err := events.FlowEventToCadenceEvent(event)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not decode event: %w", err)
+	}
+
+	payload, err := events.DecodeTransactionEventPayload(cadenceEvent)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not decode transaction event payload: %w", err)
+	}
+
+	var logs []*gethTypes.Log
+	if len(payload.Logs) > 0 {
+		err = gethRLP.DecodeBytes(payload.Logs, &logs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not decode logs: %w", err)
+		}
+	}
+
+	return payload, logs, nil
+}
@@ -0,0 +1,120 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence/encoding/ccf"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm/evm/events"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestFindEVMLogs tests that the logs of all EVM transactions of a block are indexed in execution
+// order, with the Flow transaction which executed them.
+func TestFindEVMLogs(t *testing.T) {
+	t.Parallel()
+
+	chainID := flow.Testnet
+	eventType := evmTransactionEventType(chainID)
+
+	token := gethCommon.HexToAddress("0x01")
+	transfer := gethCommon.HexToHash("0xaa")
+	approval := gethCommon.HexToHash("0xbb")
+
+	txID1 := unittest.IdentifierFixture()
+	txID2 := unittest.IdentifierFixture()
+
+	logs1 := []*gethTypes.Log{
+		{Address: token, Topics: []gethCommon.Hash{transfer}, Data: []byte{1}},
+		{Address: token, Topics: []gethCommon.Hash{approval}, Data: []byte{2}},
+	}
+	logs2 := []*gethTypes.Log{
+		{Address: token, Topics: []gethCommon.Hash{transfer}, Data: []byte{3}},
+	}
+
+	hash1 := gethCommon.HexToHash("0x11")
+	hash2 := gethCommon.HexToHash("0x22")
+	hash3 := gethCommon.HexToHash("0x33")
+
+	chunks := []*execution_data.ChunkExecutionData{
+		{
+			Events: []flow.Event{
+				// events are sorted by transaction index, so logs of the second chunk come first
+				evmTransactionEventFixture(t, chainID, txID2, 1, 0, hash3, 2, logs2),
+			},
+		},
+		{
+			Events: []flow.Event{
+				evmTransactionEventFixture(t, chainID, txID1, 0, 0, hash1, 0, logs1),
+				// transactions without logs are indexed without entries
+				evmTransactionEventFixture(t, chainID, txID1, 0, 1, hash2, 1, nil),
+				// events of other types are ignored
+				unittest.EventFixture("A.0x1.Foo.Bar", 0, 2, txID1, 0),
+				// undecodable events are skipped
+				unittest.EventFixture(eventType, 0, 3, txID1, 0),
+			},
+		},
+	}
+
+	skipped := 0
+	actual := findEVMLogs(42, eventType, chunks, func(flow.Event, error) { skipped++ })
+	assert.Equal(t, 1, skipped)
+
+	expected := []flow.EVMLog{
+		evmLog(42, txID1, hash1, 0, 0, logs1[0]),
+		evmLog(42, txID1, hash1, 0, 1, logs1[1]),
+		evmLog(42, txID2, hash3, 2, 2, logs2[0]),
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func evmTransactionEventFixture(
+	t *testing.T,
+	chainID flow.ChainID,
+	txID flow.Identifier,
+	txIndex uint32,
+	eventIndex uint32,
+	hash gethCommon.Hash,
+	evmTxIndex uint16,
+	logs []*gethTypes.Log,
+) flow.Event {
+	result := &types.Result{
+		TxHash: hash,
+		Index:  evmTxIndex,
+		Logs:   logs,
+	}
+	cadenceEvent, err := events.NewTransactionEvent(result, []byte{}, 7).Payload.ToCadence(chainID)
+	require.NoError(t, err)
+
+	payload, err := ccf.Encode(cadenceEvent)
+	require.NoError(t, err)
+
+	return flow.Event{
+		Type:             flow.EventType(cadenceEvent.EventType.ID()),
+		TransactionID:    txID,
+		TransactionIndex: txIndex,
+		EventIndex:       eventIndex,
+		Payload:          payload,
+	}
+}
+
+func evmLog(height uint64, txID flow.Identifier, hash gethCommon.Hash, evmTxIndex uint32, logIndex uint32, log *gethTypes.Log) flow.EVMLog {
+	return flow.EVMLog{
+		BlockHeight:         height,
+		TransactionID:       txID,
+		EVMBlockHeight:      7,
+		EVMTransactionHash:  hash,
+		EVMTransactionIndex: evmTxIndex,
+		LogIndex:            logIndex,
+		Address:             log.Address,
+		Topics:              log.Topics,
+		Data:                log.Data,
+	}
+}
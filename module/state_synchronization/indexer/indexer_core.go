@@ -32,6 +32,13 @@ type IndexerCore struct {
 	results      storage.LightTransactionResults
 	batcher      bstorage.BatchBuilder
 
+	// accountTransactions is optional, the account transaction index is not maintained if it is nil
+	accountTransactions storage.AccountTransactions
+
+	// evmLogs is optional, the EVM log index is not maintained if it is nil
+	evmLogs                 storage.EVMLogs
+	evmTransactionEventType flow.EventType
+
 	collectionExecutedMetric module.CollectionExecutedMetric
 
 	derivedChainData *derived.DerivedChainData
@@ -41,6 +48,8 @@ type IndexerCore struct {
 // New execution state indexer used to ingest block execution data and index it by height.
 // The passed RegisterIndex storage must be populated to include the first and last height otherwise the indexer
 // won't be initialized to ensure we have bootstrapped the storage first.
+// The account transaction index is only maintained if accountTransactions is not nil, and the
+// EVM log index is only maintained if evmLogs is not nil.
 func New(
 	log zerolog.Logger,
 	metrics module.ExecutionStateIndexerMetrics,
@@ -51,6 +60,8 @@ func New(
 	collections storage.Collections,
 	transactions storage.Transactions,
 	results storage.LightTransactionResults,
+	accountTransactions storage.AccountTransactions,
+	evmLogs storage.EVMLogs,
 	chain flow.Chain,
 	derivedChainData *derived.DerivedChainData,
 	collectionExecutedMetric module.CollectionExecutedMetric,
@@ -76,6 +87,9 @@ func New(
 		serviceAddress:   chain.ServiceAddress(),
 		derivedChainData: derivedChainData,
 
+		accountTransactions:      accountTransactions,
+		evmLogs:                  evmLogs,
+		evmTransactionEventType:  evmTransactionEventType(chain.ChainID()),
 		collectionExecutedMetric: collectionExecutedMetric,
 	}, nil
 }
@@ -157,6 +171,34 @@ func (c *IndexerCore) IndexBlockData(data *execution_data.BlockExecutionDataEnti
 			return fmt.Errorf("could not index transaction results at height %d: %w", header.Height, err)
 		}
 
+		if c.accountTransactions != nil {
+			accountTransactions := findAccountTransactions(header.Height, data.ChunkExecutionDatas, func(event flow.Event, err error) {
+				lg.Debug().Err(err).
+					Hex("transaction_id", logging.ID(event.TransactionID)).
+					Str("event_type", string(event.Type)).
+					Msg("skipping undecodable event in account transaction index")
+			})
+
+			err = c.accountTransactions.BatchStore(header.Height, accountTransactions, batch)
+			if err != nil {
+				return fmt.Errorf("could not index account transactions at height %d: %w", header.Height, err)
+			}
+		}
+
+		if c.evmLogs != nil {
+			evmLogs := findEVMLogs(header.Height, c.evmTransactionEventType, data.ChunkExecutionDatas, func(event flow.Event, err error) {
+				lg.Debug().Err(err).
+					Hex("transaction_id", logging.ID(event.TransactionID)).
+					Str("event_type", string(event.Type)).
+					Msg("skipping undecodable event in EVM log index")
+			})
+
+			err = c.evmLogs.BatchStore(header.Height, evmLogs, batch)
+			if err != nil {
+				return fmt.Errorf("could not index EVM logs at height %d: %w", header.Height, err)
+			}
+		}
+
 		batch.Flush()
 		if err != nil {
 			return fmt.Errorf("batch flush error: %w", err)
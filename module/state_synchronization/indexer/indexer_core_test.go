@@ -228,6 +228,8 @@ func (i *indexCoreTest) initIndexer() *indexCoreTest {
 		i.collections,
 		i.transactions,
 		i.results,
+		nil,
+		nil,
 		flow.Testnet.Chain(),
 		derivedChainData,
 		collectionExecutedMetric,
@@ -706,6 +708,8 @@ func TestIndexerIntegration_StoreAndGet(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
 				flow.Testnet.Chain(),
 				derivedChainData,
 				nil,
@@ -740,6 +744,8 @@ func TestIndexerIntegration_StoreAndGet(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
 				flow.Testnet.Chain(),
 				derivedChainData,
 				nil,
@@ -767,6 +773,8 @@ func TestIndexerIntegration_StoreAndGet(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
 				flow.Testnet.Chain(),
 				derivedChainData,
 				nil,
@@ -811,6 +819,8 @@ func TestIndexerIntegration_StoreAndGet(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
 				flow.Testnet.Chain(),
 				derivedChainData,
 				nil,
@@ -139,6 +139,8 @@ const (
 	RequestChunks            = Channel("request-chunks")
 	RequestReceiptsByBlockID = Channel("request-receipts-by-block-id")
 	RequestApprovalsByChunk  = Channel("request-approvals-by-chunk")
+	RequestExecutionState    = Channel("request-execution-state")
+	RequestRegisterProofs    = Channel("request-register-proofs")
 
 	// Channel aliases to make the code more readable / more robust to errors
 	ReceiveTransactions = PushTransactions
@@ -151,6 +153,8 @@ const (
 	ProvideChunks            = RequestChunks
 	ProvideReceiptsByBlockID = RequestReceiptsByBlockID
 	ProvideApprovalsByChunk  = RequestApprovalsByChunk
+	ProvideExecutionState    = RequestExecutionState
+	ProvideRegisterProofs    = RequestRegisterProofs
 
 	// Public network channels
 	PublicPushBlocks           = Channel("public-push-blocks")
@@ -197,6 +201,8 @@ func initializeChannelRoleMap() {
 	channelRoleMap[RequestChunks] = flow.RoleList{flow.RoleExecution, flow.RoleVerification}
 	channelRoleMap[RequestReceiptsByBlockID] = flow.RoleList{flow.RoleConsensus, flow.RoleExecution}
 	channelRoleMap[RequestApprovalsByChunk] = flow.RoleList{flow.RoleConsensus, flow.RoleVerification}
+	channelRoleMap[RequestExecutionState] = flow.RoleList{flow.RoleExecution}
+	channelRoleMap[RequestRegisterProofs] = flow.RoleList{flow.RoleExecution, flow.RoleAccess}
 
 	// Channel aliases to make the code more readable / more robust to errors
 	channelRoleMap[ReceiveGuarantees] = flow.RoleList{flow.RoleCollection, flow.RoleConsensus}
@@ -210,6 +216,7 @@ func initializeChannelRoleMap() {
 	channelRoleMap[ProvideChunks] = flow.RoleList{flow.RoleExecution, flow.RoleVerification}
 	channelRoleMap[ProvideReceiptsByBlockID] = flow.RoleList{flow.RoleConsensus, flow.RoleExecution}
 	channelRoleMap[ProvideApprovalsByChunk] = flow.RoleList{flow.RoleConsensus, flow.RoleVerification}
+	channelRoleMap[ProvideExecutionState] = flow.RoleList{flow.RoleExecution}
 
 	clusterChannelPrefixRoleMap = make(map[string]flow.RoleList)
 
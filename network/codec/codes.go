@@ -62,6 +62,16 @@ const (
 	// DKG
 	CodeDKGMessage
 
+	// execution state sync
+	CodeExecutionStateManifestRequest
+	CodeExecutionStateManifestResponse
+	CodeExecutionStateChunkRequest
+	CodeExecutionStateChunkResponse
+
+	// register proofs
+	CodeRegisterProofRequest
+	CodeRegisterProofResponse
+
 	CodeMax
 )
 
@@ -139,6 +149,22 @@ func MessageCodeFromInterface(v interface{}) (MessageCode, string, error) {
 	case *messages.DKGMessage:
 		return CodeDKGMessage, s, nil
 
+	// execution state sync
+	case *messages.ExecutionStateManifestRequest:
+		return CodeExecutionStateManifestRequest, s, nil
+	case *messages.ExecutionStateManifestResponse:
+		return CodeExecutionStateManifestResponse, s, nil
+	case *messages.ExecutionStateChunkRequest:
+		return CodeExecutionStateChunkRequest, s, nil
+	case *messages.ExecutionStateChunkResponse:
+		return CodeExecutionStateChunkResponse, s, nil
+
+	// register proofs
+	case *messages.RegisterProofRequest:
+		return CodeRegisterProofRequest, s, nil
+	case *messages.RegisterProofResponse:
+		return CodeRegisterProofResponse, s, nil
+
 	default:
 		return 0, "", fmt.Errorf("invalid encode type (%T)", v)
 	}
@@ -216,6 +242,22 @@ func InterfaceFromMessageCode(code MessageCode) (interface{}, string, error) {
 	case CodeDKGMessage:
 		return &messages.DKGMessage{}, what(&messages.DKGMessage{}), nil
 
+	// execution state sync
+	case CodeExecutionStateManifestRequest:
+		return &messages.ExecutionStateManifestRequest{}, what(&messages.ExecutionStateManifestRequest{}), nil
+	case CodeExecutionStateManifestResponse:
+		return &messages.ExecutionStateManifestResponse{}, what(&messages.ExecutionStateManifestResponse{}), nil
+	case CodeExecutionStateChunkRequest:
+		return &messages.ExecutionStateChunkRequest{}, what(&messages.ExecutionStateChunkRequest{}), nil
+	case CodeExecutionStateChunkResponse:
+		return &messages.ExecutionStateChunkResponse{}, what(&messages.ExecutionStateChunkResponse{}), nil
+
+	// register proofs
+	case CodeRegisterProofRequest:
+		return &messages.RegisterProofRequest{}, what(&messages.RegisterProofRequest{}), nil
+	case CodeRegisterProofResponse:
+		return &messages.RegisterProofResponse{}, what(&messages.RegisterProofResponse{}), nil
+
 	// test messages
 	case CodeEcho:
 		return &message.TestMessage{}, what(&message.TestMessage{}), nil
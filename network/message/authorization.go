@@ -396,6 +396,82 @@ func initializeMessageAuthConfigsMap() {
 			},
 		},
 	}
+
+	// execution state sync
+	authorizationConfigs[ExecutionStateManifestRequest] = MsgAuthConfig{
+		Name: ExecutionStateManifestRequest,
+		Type: func() interface{} {
+			return new(messages.ExecutionStateManifestRequest)
+		},
+		Config: map[channels.Channel]ChannelAuthConfig{
+			channels.RequestExecutionState: {
+				AuthorizedRoles:  flow.RoleList{flow.RoleExecution},
+				AllowedProtocols: Protocols{ProtocolTypeUnicast},
+			}, // channel alias RequestExecutionState = ProvideExecutionState
+		},
+	}
+	authorizationConfigs[ExecutionStateManifestResponse] = MsgAuthConfig{
+		Name: ExecutionStateManifestResponse,
+		Type: func() interface{} {
+			return new(messages.ExecutionStateManifestResponse)
+		},
+		Config: map[channels.Channel]ChannelAuthConfig{
+			channels.ProvideExecutionState: {
+				AuthorizedRoles:  flow.RoleList{flow.RoleExecution},
+				AllowedProtocols: Protocols{ProtocolTypeUnicast},
+			}, // channel alias RequestExecutionState = ProvideExecutionState
+		},
+	}
+	authorizationConfigs[ExecutionStateChunkRequest] = MsgAuthConfig{
+		Name: ExecutionStateChunkRequest,
+		Type: func() interface{} {
+			return new(messages.ExecutionStateChunkRequest)
+		},
+		Config: map[channels.Channel]ChannelAuthConfig{
+			channels.RequestExecutionState: {
+				AuthorizedRoles:  flow.RoleList{flow.RoleExecution},
+				AllowedProtocols: Protocols{ProtocolTypeUnicast},
+			}, // channel alias RequestExecutionState = ProvideExecutionState
+		},
+	}
+	authorizationConfigs[ExecutionStateChunkResponse] = MsgAuthConfig{
+		Name: ExecutionStateChunkResponse,
+		Type: func() interface{} {
+			return new(messages.ExecutionStateChunkResponse)
+		},
+		Config: map[channels.Channel]ChannelAuthConfig{
+			channels.ProvideExecutionState: {
+				AuthorizedRoles:  flow.RoleList{flow.RoleExecution},
+				AllowedProtocols: Protocols{ProtocolTypeUnicast},
+			}, // channel alias RequestExecutionState = ProvideExecutionState
+		},
+	}
+
+	// register proofs
+	authorizationConfigs[RegisterProofRequest] = MsgAuthConfig{
+		Name: RegisterProofRequest,
+		Type: func() interface{} {
+			return new(messages.RegisterProofRequest)
+		},
+		Config: map[channels.Channel]ChannelAuthConfig{
+			channels.RequestRegisterProofs: {
+				AuthorizedRoles:  flow.RoleList{flow.RoleAccess},
+				AllowedProtocols: Protocols{ProtocolTypeUnicast},
+			}, // channel alias RequestRegisterProofs = ProvideRegisterProofs
+		},
+	}
+	authorizationConfigs[RegisterProofResponse] = MsgAuthConfig{
+		Name: RegisterProofResponse,
+		Type: func() interface{} {
+			return new(messages.RegisterProofResponse)
+		},
+		Config: map[channels.Channel]ChannelAuthConfig{
+			channels.ProvideRegisterProofs: {
+				AuthorizedRoles:  flow.RoleList{flow.RoleExecution},
+				AllowedProtocols: Protocols{ProtocolTypeUnicast},
+			}, // channel alias RequestRegisterProofs = ProvideRegisterProofs
+		},
+	}
 }
 
 // GetMessageAuthConfig checks the underlying type and returns the correct
@@ -472,6 +548,22 @@ func GetMessageAuthConfig(v interface{}) (MsgAuthConfig, error) {
 	case *messages.DKGMessage:
 		return authorizationConfigs[DKGMessage], nil
 
+	// execution state sync
+	case *messages.ExecutionStateManifestRequest:
+		return authorizationConfigs[ExecutionStateManifestRequest], nil
+	case *messages.ExecutionStateManifestResponse:
+		return authorizationConfigs[ExecutionStateManifestResponse], nil
+	case *messages.ExecutionStateChunkRequest:
+		return authorizationConfigs[ExecutionStateChunkRequest], nil
+	case *messages.ExecutionStateChunkResponse:
+		return authorizationConfigs[ExecutionStateChunkResponse], nil
+
+	// register proofs
+	case *messages.RegisterProofRequest:
+		return authorizationConfigs[RegisterProofRequest], nil
+	case *messages.RegisterProofResponse:
+		return authorizationConfigs[RegisterProofResponse], nil
+
 	default:
 		return MsgAuthConfig{}, NewUnknownMsgTypeErr(v)
 	}
@@ -68,4 +68,12 @@ const (
 	EntityResponse       = "EntityResponse"
 	TestMessage          = "TestMessage"
 	DKGMessage           = "DKGMessage"
+
+	ExecutionStateManifestRequest  = "ExecutionStateManifestRequest"
+	ExecutionStateManifestResponse = "ExecutionStateManifestResponse"
+	ExecutionStateChunkRequest     = "ExecutionStateChunkRequest"
+	ExecutionStateChunkResponse    = "ExecutionStateChunkResponse"
+
+	RegisterProofRequest  = "RegisterProofRequest"
+	RegisterProofResponse = "RegisterProofResponse"
 )
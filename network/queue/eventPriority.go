@@ -97,6 +97,22 @@ func getPriorityByType(message interface{}) Priority {
 	case *messages.EntityResponse:
 		return LowPriority
 
+	// execution state sync
+	case *messages.ExecutionStateManifestRequest:
+		return LowPriority
+	case *messages.ExecutionStateManifestResponse:
+		return LowPriority
+	case *messages.ExecutionStateChunkRequest:
+		return LowPriority
+	case *messages.ExecutionStateChunkResponse:
+		return LowPriority
+
+	// register proofs
+	case *messages.RegisterProofRequest:
+		return MediumPriority
+	case *messages.RegisterProofResponse:
+		return MediumPriority
+
 	// test message
 	case *libp2pmessage.TestMessage:
 		return LowPriority
@@ -0,0 +1,27 @@
+package storage
+
+import "github.com/onflow/flow-go/model/flow"
+
+// AccountTransactions represents persistent storage for the account transaction index, which maps
+// account addresses to the transactions that involved them.
+type AccountTransactions interface {
+
+	// BatchStore inserts the account transactions of a block into a batch.
+	//
+	// No errors are expected during normal operation.
+	BatchStore(blockHeight uint64, transactions []flow.AccountTransaction, batch BatchStorage) error
+
+	// ByAddress returns the transactions which involved the given address in blocks within
+	// [startHeight, endHeight], ordered from newest to oldest. If a cursor is provided, iteration
+	// starts at the cursor (inclusive) instead of the end height. At most limit entries are returned.
+	// Returns an empty slice if no transactions are found.
+	//
+	// No errors are expected during normal operation.
+	ByAddress(
+		address flow.Address,
+		startHeight uint64,
+		endHeight uint64,
+		cursor *flow.AccountTransactionCursor,
+		limit uint32,
+	) ([]flow.AccountTransaction, error)
+}
@@ -0,0 +1,68 @@
+package badger
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+var _ storage.AccountTransactions = (*AccountTransactions)(nil)
+
+// AccountTransactions implements the account transaction index on top of badger.
+// Entries are keyed by address, block height and transaction index, so the history of
+// an account can be read with a single range scan.
+type AccountTransactions struct {
+	db *badger.DB
+}
+
+func NewAccountTransactions(db *badger.DB) *AccountTransactions {
+	return &AccountTransactions{
+		db: db,
+	}
+}
+
+// BatchStore inserts the account transactions of a block into a batch.
+//
+// No errors are expected during normal operation.
+func (a *AccountTransactions) BatchStore(blockHeight uint64, transactions []flow.AccountTransaction, batch storage.BatchStorage) error {
+	writeBatch := batch.GetWriter()
+
+	for i := range transactions {
+		if transactions[i].BlockHeight != blockHeight {
+			return fmt.Errorf("account transaction height %d does not match block height %d", transactions[i].BlockHeight, blockHeight)
+		}
+
+		err := operation.BatchIndexAccountTransaction(&transactions[i])(writeBatch)
+		if err != nil {
+			return fmt.Errorf("cannot batch index account transaction: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ByAddress returns the transactions which involved the given address in blocks within
+// [startHeight, endHeight], ordered from newest to oldest. If a cursor is provided, iteration
+// starts at the cursor (inclusive) instead of the end height. At most limit entries are returned.
+// Returns an empty slice if no transactions are found.
+//
+// No errors are expected during normal operation.
+func (a *AccountTransactions) ByAddress(
+	address flow.Address,
+	startHeight uint64,
+	endHeight uint64,
+	cursor *flow.AccountTransactionCursor,
+	limit uint32,
+) ([]flow.AccountTransaction, error) {
+	var transactions []flow.AccountTransaction
+	err := a.db.View(operation.LookupAccountTransactions(address, startHeight, endHeight, cursor, limit, &transactions))
+	if err != nil {
+		return nil, fmt.Errorf("could not lookup account transactions: %w", err)
+	}
+
+	return transactions, nil
+}
@@ -0,0 +1,106 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestAccountTransactions(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewAccountTransactions(db)
+
+		address := unittest.RandomAddressFixture()
+		other := unittest.RandomAddressFixture()
+
+		// index 2 transactions of the address in each block from height 10 to 19, and one
+		// transaction of another address in each block
+		var expected []flow.AccountTransaction
+		for height := uint64(10); height < 20; height++ {
+			txs := []flow.AccountTransaction{
+				accountTransactionFixture(address, height, 0, flow.AccountTransactionRolePayer),
+				accountTransactionFixture(address, height, 3, flow.AccountTransactionRoleAuthorizer, flow.AccountTransactionRoleInteraction),
+				accountTransactionFixture(other, height, 1, flow.AccountTransactionRoleProposer),
+			}
+
+			writeBatch := bstorage.NewBatch(db)
+			err := store.BatchStore(height, txs, writeBatch)
+			require.NoError(t, err)
+			require.NoError(t, writeBatch.Flush())
+
+			// newest first
+			expected = append([]flow.AccountTransaction{txs[1], txs[0]}, expected...)
+		}
+
+		t.Run("all transactions", func(t *testing.T) {
+			actual, err := store.ByAddress(address, 0, 100, nil, 100)
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		})
+
+		t.Run("height bounds", func(t *testing.T) {
+			actual, err := store.ByAddress(address, 12, 13, nil, 100)
+			require.NoError(t, err)
+			assert.Equal(t, expected[12:16], actual)
+		})
+
+		t.Run("paginate with cursor", func(t *testing.T) {
+			var actual []flow.AccountTransaction
+			var cursor *flow.AccountTransactionCursor
+			for {
+				// request one extra entry to find the cursor of the next page
+				page, err := store.ByAddress(address, 11, 18, cursor, 4)
+				require.NoError(t, err)
+
+				if len(page) < 4 {
+					actual = append(actual, page...)
+					break
+				}
+				actual = append(actual, page[:3]...)
+				next := page[3].Cursor()
+				cursor = &next
+			}
+			assert.Equal(t, expected[2:18], actual)
+		})
+
+		t.Run("cursor outside of bounds", func(t *testing.T) {
+			// a cursor above the end height is ignored
+			actual, err := store.ByAddress(address, 10, 10, &flow.AccountTransactionCursor{BlockHeight: 15}, 100)
+			require.NoError(t, err)
+			assert.Equal(t, expected[18:], actual)
+
+			// a cursor below the start height returns no results
+			actual, err = store.ByAddress(address, 15, 19, &flow.AccountTransactionCursor{BlockHeight: 14}, 100)
+			require.NoError(t, err)
+			assert.Empty(t, actual)
+		})
+
+		t.Run("unknown address", func(t *testing.T) {
+			actual, err := store.ByAddress(unittest.RandomAddressFixture(), 0, 100, nil, 100)
+			require.NoError(t, err)
+			assert.Empty(t, actual)
+		})
+
+		t.Run("mismatching height", func(t *testing.T) {
+			writeBatch := bstorage.NewBatch(db)
+			err := store.BatchStore(21, []flow.AccountTransaction{accountTransactionFixture(address, 20, 0)}, writeBatch)
+			require.Error(t, err)
+		})
+	})
+}
+
+func accountTransactionFixture(address flow.Address, height uint64, txIndex uint32, roles ...flow.AccountTransactionRole) flow.AccountTransaction {
+	return flow.AccountTransaction{
+		Address:          address,
+		BlockHeight:      height,
+		TransactionID:    unittest.IdentifierFixture(),
+		TransactionIndex: txIndex,
+		Roles:            roles,
+	}
+}
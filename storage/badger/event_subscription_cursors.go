@@ -0,0 +1,108 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+var _ storage.EventSubscriptionCursors = (*EventSubscriptionCursors)(nil)
+
+// EventSubscriptionCursors implements persistent storage for named event subscription cursors on top of badger.
+type EventSubscriptionCursors struct {
+	db *badger.DB
+}
+
+func NewEventSubscriptionCursors(db *badger.DB) *EventSubscriptionCursors {
+	return &EventSubscriptionCursors{
+		db: db,
+	}
+}
+
+// Store inserts the cursor, or replaces the cursor with the same name if one exists.
+//
+// No errors are expected during normal operation.
+func (c *EventSubscriptionCursors) Store(cursor *flow.EventSubscriptionCursor) error {
+	err := operation.RetryOnConflict(c.db.Update, operation.UpsertEventSubscriptionCursor(cursor))
+	if err != nil {
+		return fmt.Errorf("could not store event subscription cursor %q: %w", cursor.Name, err)
+	}
+	return nil
+}
+
+// ByName returns the cursor with the given name.
+//
+// Expected errors during normal operation:
+// - storage.ErrNotFound if no cursor with the given name exists.
+func (c *EventSubscriptionCursors) ByName(name string) (*flow.EventSubscriptionCursor, error) {
+	var cursor flow.EventSubscriptionCursor
+	err := c.db.View(operation.RetrieveEventSubscriptionCursor(name, &cursor))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve event subscription cursor %q: %w", name, err)
+	}
+	return &cursor, nil
+}
+
+// Remove removes the cursor with the given name. It is a no-op if the cursor does not exist.
+//
+// No errors are expected during normal operation.
+func (c *EventSubscriptionCursors) Remove(name string) error {
+	err := operation.RetryOnConflict(c.db.Update, operation.RemoveEventSubscriptionCursor(name))
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not remove event subscription cursor %q: %w", name, err)
+	}
+	return nil
+}
+
+// Count returns the number of stored cursors.
+//
+// No errors are expected during normal operation.
+func (c *EventSubscriptionCursors) Count() (int, error) {
+	count := 0
+	err := c.db.View(operation.TraverseEventSubscriptionCursors(func(*flow.EventSubscriptionCursor) error {
+		count++
+		return nil
+	}))
+	if err != nil {
+		return 0, fmt.Errorf("could not traverse event subscription cursors: %w", err)
+	}
+	return count, nil
+}
+
+// RemoveInactive removes all cursors which were last active before the given time, and returns
+// the names of the removed cursors.
+//
+// No errors are expected during normal operation.
+func (c *EventSubscriptionCursors) RemoveInactive(before time.Time) ([]string, error) {
+	var removed []string
+	err := operation.RetryOnConflict(c.db.Update, func(tx *badger.Txn) error {
+		removed = nil
+		err := operation.TraverseEventSubscriptionCursors(func(cursor *flow.EventSubscriptionCursor) error {
+			if cursor.LastActive.Before(before) {
+				removed = append(removed, cursor.Name)
+			}
+			return nil
+		})(tx)
+		if err != nil {
+			return fmt.Errorf("could not traverse event subscription cursors: %w", err)
+		}
+
+		for _, name := range removed {
+			err = operation.RemoveEventSubscriptionCursor(name)(tx)
+			if err != nil {
+				return fmt.Errorf("could not remove event subscription cursor %q: %w", name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
@@ -0,0 +1,87 @@
+package badger_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestEventSubscriptionCursors(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewEventSubscriptionCursors(db)
+		now := time.Now().UTC().Truncate(time.Millisecond)
+
+		cursor := &flow.EventSubscriptionCursor{
+			Name:        "wallet",
+			EventTypes:  []string{"flow.AccountCreated"},
+			Addresses:   []string{unittest.RandomAddressFixture().String()},
+			StartHeight: 10,
+			LastActive:  now,
+		}
+
+		t.Run("missing cursor", func(t *testing.T) {
+			_, err := store.ByName(cursor.Name)
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+		})
+
+		t.Run("store and update", func(t *testing.T) {
+			require.NoError(t, store.Store(cursor))
+
+			actual, err := store.ByName(cursor.Name)
+			require.NoError(t, err)
+			assert.Equal(t, cursor.EventTypes, actual.EventTypes)
+			assert.Equal(t, cursor.Addresses, actual.Addresses)
+			assert.Equal(t, cursor.StartHeight, actual.StartHeight)
+			assert.Nil(t, actual.LastAcknowledged)
+			assert.True(t, cursor.LastActive.Equal(actual.LastActive))
+
+			cursor.LastDelivered = &flow.EventPosition{BlockHeight: 12, TransactionIndex: 1, EventIndex: 3}
+			cursor.LastAcknowledged = &flow.EventPosition{BlockHeight: 12, TransactionIndex: 1, EventIndex: 2}
+			require.NoError(t, store.Store(cursor))
+
+			actual, err = store.ByName(cursor.Name)
+			require.NoError(t, err)
+			assert.Equal(t, cursor.LastDelivered, actual.LastDelivered)
+			assert.Equal(t, cursor.LastAcknowledged, actual.LastAcknowledged)
+
+			// updating a cursor does not add another one
+			count, err := store.Count()
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+		})
+
+		t.Run("remove inactive", func(t *testing.T) {
+			inactive := &flow.EventSubscriptionCursor{
+				Name:       "abandoned",
+				LastActive: now.Add(-time.Hour),
+			}
+			require.NoError(t, store.Store(inactive))
+
+			removed, err := store.RemoveInactive(now.Add(-time.Minute))
+			require.NoError(t, err)
+			assert.Equal(t, []string{inactive.Name}, removed)
+
+			_, err = store.ByName(inactive.Name)
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+			_, err = store.ByName(cursor.Name)
+			assert.NoError(t, err)
+		})
+
+		t.Run("remove", func(t *testing.T) {
+			require.NoError(t, store.Remove(cursor.Name))
+			_, err := store.ByName(cursor.Name)
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+
+			// removing a missing cursor is a no-op
+			require.NoError(t, store.Remove(cursor.Name))
+		})
+	})
+}
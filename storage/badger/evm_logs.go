@@ -0,0 +1,68 @@
+package badger
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+var _ storage.EVMLogs = (*EVMLogs)(nil)
+
+// EVMLogs implements the EVM log index on top of badger.
+// Logs are keyed by block height and log index, with secondary indexes by contract address
+// and by topic, so filtered queries only scan the entries of the filtered address or topic.
+type EVMLogs struct {
+	db *badger.DB
+}
+
+func NewEVMLogs(db *badger.DB) *EVMLogs {
+	return &EVMLogs{
+		db: db,
+	}
+}
+
+// BatchStore inserts the EVM logs of a block into a batch.
+//
+// No errors are expected during normal operation.
+func (e *EVMLogs) BatchStore(blockHeight uint64, logs []flow.EVMLog, batch storage.BatchStorage) error {
+	writeBatch := batch.GetWriter()
+
+	for i := range logs {
+		if logs[i].BlockHeight != blockHeight {
+			return fmt.Errorf("EVM log height %d does not match block height %d", logs[i].BlockHeight, blockHeight)
+		}
+
+		err := operation.BatchInsertEVMLog(&logs[i])(writeBatch)
+		if err != nil {
+			return fmt.Errorf("cannot batch insert EVM log: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ByFilter returns the EVM logs matching the filter in blocks within [startHeight, endHeight],
+// ordered from oldest to newest. If a cursor is provided, iteration starts at the cursor
+// (inclusive) instead of the start height. At most limit entries are returned.
+// Returns an empty slice if no logs are found.
+//
+// No errors are expected during normal operation.
+func (e *EVMLogs) ByFilter(
+	filter flow.EVMLogFilter,
+	startHeight uint64,
+	endHeight uint64,
+	cursor *flow.EVMLogCursor,
+	limit uint32,
+) ([]flow.EVMLog, error) {
+	var logs []flow.EVMLog
+	err := e.db.View(operation.LookupEVMLogs(filter, startHeight, endHeight, cursor, limit, &logs))
+	if err != nil {
+		return nil, fmt.Errorf("could not lookup EVM logs: %w", err)
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,156 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestEVMLogs(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewEVMLogs(db)
+
+		token := gethCommon.HexToAddress("0x01")
+		other := gethCommon.HexToAddress("0x02")
+		transfer := gethCommon.HexToHash("0xaa")
+		approval := gethCommon.HexToHash("0xbb")
+		alice := gethCommon.HexToHash("0xa1")
+		bob := gethCommon.HexToHash("0xb0")
+
+		// index a transfer and an approval of the token and a transfer of another contract
+		// in each block from height 10 to 19
+		var transfers, approvals, others []flow.EVMLog
+		for height := uint64(10); height < 20; height++ {
+			logs := []flow.EVMLog{
+				evmLogFixture(height, 0, token, transfer, alice, bob),
+				evmLogFixture(height, 1, other, transfer, bob, alice),
+				evmLogFixture(height, 2, token, approval, alice),
+			}
+
+			writeBatch := bstorage.NewBatch(db)
+			err := store.BatchStore(height, logs, writeBatch)
+			require.NoError(t, err)
+			require.NoError(t, writeBatch.Flush())
+
+			transfers = append(transfers, logs[0])
+			others = append(others, logs[1])
+			approvals = append(approvals, logs[2])
+		}
+
+		t.Run("by address", func(t *testing.T) {
+			actual, err := store.ByFilter(flow.EVMLogFilter{Addresses: []gethCommon.Address{other}}, 0, 100, nil, 100)
+			require.NoError(t, err)
+			assert.Equal(t, others, actual)
+		})
+
+		t.Run("by address and topic", func(t *testing.T) {
+			filter := flow.EVMLogFilter{
+				Addresses: []gethCommon.Address{token},
+				Topics:    [][]gethCommon.Hash{{approval}},
+			}
+			actual, err := store.ByFilter(filter, 12, 13, nil, 100)
+			require.NoError(t, err)
+			assert.Equal(t, approvals[2:4], actual)
+		})
+
+		t.Run("by topic", func(t *testing.T) {
+			// transfers to alice
+			filter := flow.EVMLogFilter{
+				Topics: [][]gethCommon.Hash{nil, nil, {alice}},
+			}
+			actual, err := store.ByFilter(filter, 0, 100, nil, 100)
+			require.NoError(t, err)
+			assert.Equal(t, others, actual)
+
+			// logs with alice or bob as first argument
+			filter = flow.EVMLogFilter{
+				Topics: [][]gethCommon.Hash{nil, {alice, bob}},
+			}
+			actual, err = store.ByFilter(filter, 10, 10, nil, 100)
+			require.NoError(t, err)
+			assert.Equal(t, []flow.EVMLog{transfers[0], others[0], approvals[0]}, actual)
+		})
+
+		t.Run("without filter", func(t *testing.T) {
+			actual, err := store.ByFilter(flow.EVMLogFilter{}, 19, 100, nil, 100)
+			require.NoError(t, err)
+			assert.Equal(t, []flow.EVMLog{transfers[9], others[9], approvals[9]}, actual)
+		})
+
+		t.Run("paginate with cursor", func(t *testing.T) {
+			filter := flow.EVMLogFilter{
+				Addresses: []gethCommon.Address{token, other},
+				Topics:    [][]gethCommon.Hash{{transfer}},
+			}
+
+			var actual []flow.EVMLog
+			var cursor *flow.EVMLogCursor
+			for {
+				// request one extra entry to find the cursor of the next page
+				page, err := store.ByFilter(filter, 11, 18, cursor, 4)
+				require.NoError(t, err)
+
+				if len(page) < 4 {
+					actual = append(actual, page...)
+					break
+				}
+				actual = append(actual, page[:3]...)
+				next := page[3].Cursor()
+				cursor = &next
+			}
+
+			var expected []flow.EVMLog
+			for i := 1; i < 9; i++ {
+				expected = append(expected, transfers[i], others[i])
+			}
+			assert.Equal(t, expected, actual)
+		})
+
+		t.Run("cursor outside of bounds", func(t *testing.T) {
+			filter := flow.EVMLogFilter{Addresses: []gethCommon.Address{other}}
+
+			// a cursor below the start height is ignored
+			actual, err := store.ByFilter(filter, 19, 19, &flow.EVMLogCursor{BlockHeight: 15}, 100)
+			require.NoError(t, err)
+			assert.Equal(t, others[9:], actual)
+
+			// a cursor above the end height returns no results
+			actual, err = store.ByFilter(filter, 10, 14, &flow.EVMLogCursor{BlockHeight: 15}, 100)
+			require.NoError(t, err)
+			assert.Empty(t, actual)
+		})
+
+		t.Run("unknown address", func(t *testing.T) {
+			actual, err := store.ByFilter(flow.EVMLogFilter{Addresses: []gethCommon.Address{gethCommon.HexToAddress("0x03")}}, 0, 100, nil, 100)
+			require.NoError(t, err)
+			assert.Empty(t, actual)
+		})
+
+		t.Run("mismatching height", func(t *testing.T) {
+			writeBatch := bstorage.NewBatch(db)
+			err := store.BatchStore(21, []flow.EVMLog{evmLogFixture(20, 0, token)}, writeBatch)
+			require.Error(t, err)
+		})
+	})
+}
+
+func evmLogFixture(height uint64, logIndex uint32, address gethCommon.Address, topics ...gethCommon.Hash) flow.EVMLog {
+	return flow.EVMLog{
+		BlockHeight:         height,
+		TransactionID:       unittest.IdentifierFixture(),
+		EVMBlockHeight:      height - 1,
+		EVMTransactionHash:  gethCommon.BytesToHash(unittest.RandomBytes(32)),
+		EVMTransactionIndex: logIndex,
+		LogIndex:            logIndex,
+		Address:             address,
+		Topics:              topics,
+		Data:                unittest.RandomBytes(32),
+	}
+}
@@ -0,0 +1,84 @@
+package operation
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/vmihailenco/msgpack/v4"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+)
+
+// BatchIndexAccountTransaction indexes the transaction under the account address, block height and transaction index.
+func BatchIndexAccountTransaction(transaction *flow.AccountTransaction) func(batch *badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeAccountTransaction, transaction.Address, transaction.BlockHeight, transaction.TransactionIndex), transaction)
+}
+
+// LookupAccountTransactions retrieves the indexed transactions of the given address in blocks within
+// [startHeight, endHeight], from newest to oldest. If cursor is not nil, the lookup starts at the
+// cursor (inclusive). At most limit entries are retrieved.
+// No errors are expected during normal operation.
+func LookupAccountTransactions(
+	address flow.Address,
+	startHeight uint64,
+	endHeight uint64,
+	cursor *flow.AccountTransactionCursor,
+	limit uint32,
+	transactions *[]flow.AccountTransaction,
+) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		*transactions = make([]flow.AccountTransaction, 0)
+		if limit == 0 || startHeight > endHeight {
+			return nil
+		}
+
+		prefix := makePrefix(codeAccountTransaction, address)
+
+		// reverse iteration seeks to the last key lower than or equal to the seek key
+		seek := makePrefix(codeAccountTransaction, address, endHeight, uint32(math.MaxUint32))
+		if cursor != nil {
+			if cursor.BlockHeight < startHeight {
+				return nil
+			}
+			if cursor.BlockHeight <= endHeight {
+				seek = makePrefix(codeAccountTransaction, address, cursor.BlockHeight, cursor.TransactionIndex)
+			}
+		}
+
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+		opts.Reverse = true
+
+		it := tx.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(seek); it.Valid() && uint32(len(*transactions)) < limit; it.Next() {
+			item := it.Item()
+
+			// keys are [code][address][height][txIndex]
+			height := binary.BigEndian.Uint64(item.Key()[len(prefix):])
+			if height < startHeight {
+				break
+			}
+
+			var transaction flow.AccountTransaction
+			err := item.Value(func(val []byte) error {
+				err := msgpack.Unmarshal(val, &transaction)
+				if err != nil {
+					return irrecoverable.NewExceptionf("could not decode account transaction: %w", err)
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("could not process value: %w", err)
+			}
+
+			*transactions = append(*transactions, transaction)
+		}
+
+		return nil
+	}
+}
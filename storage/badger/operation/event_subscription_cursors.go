@@ -0,0 +1,45 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// UpsertEventSubscriptionCursor inserts the cursor, or replaces the cursor with the same name.
+// No errors are expected during normal operation.
+func UpsertEventSubscriptionCursor(cursor *flow.EventSubscriptionCursor) func(*badger.Txn) error {
+	return upsert(makePrefix(codeEventSubscriptionCursor, cursor.Name), cursor)
+}
+
+// RetrieveEventSubscriptionCursor retrieves the cursor with the given name.
+// Expected errors during normal operation:
+// - storage.ErrNotFound if no cursor with the given name exists.
+func RetrieveEventSubscriptionCursor(name string, cursor *flow.EventSubscriptionCursor) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEventSubscriptionCursor, name), cursor)
+}
+
+// RemoveEventSubscriptionCursor removes the cursor with the given name.
+// Expected errors during normal operation:
+// - storage.ErrNotFound if no cursor with the given name exists.
+func RemoveEventSubscriptionCursor(name string) func(*badger.Txn) error {
+	return remove(makePrefix(codeEventSubscriptionCursor, name))
+}
+
+// TraverseEventSubscriptionCursors calls the handler for every stored cursor.
+// No errors are expected during normal operation, errors returned by the handler are propagated.
+func TraverseEventSubscriptionCursors(handler func(cursor *flow.EventSubscriptionCursor) error) func(*badger.Txn) error {
+	return traverse(makePrefix(codeEventSubscriptionCursor), func() (checkFunc, createFunc, handleFunc) {
+		var cursor flow.EventSubscriptionCursor
+		check := func(key []byte) bool {
+			return true
+		}
+		create := func() interface{} {
+			return &cursor
+		}
+		handle := func() error {
+			return handler(&cursor)
+		}
+		return check, create, handle
+	})
+}
@@ -0,0 +1,206 @@
+package operation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/vmihailenco/msgpack/v4"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+)
+
+// evmLogPositionLength is the length of the block height and log index suffix of EVM log keys.
+const evmLogPositionLength = 8 + 4
+
+// BatchInsertEVMLog inserts the EVM log under its block height and log index, and indexes it
+// under its address and each of its topics.
+func BatchInsertEVMLog(log *flow.EVMLog) func(batch *badger.WriteBatch) error {
+	return func(batch *badger.WriteBatch) error {
+		err := batchWrite(makePrefix(codeEVMLog, log.BlockHeight, log.LogIndex), log)(batch)
+		if err != nil {
+			return fmt.Errorf("could not insert EVM log: %w", err)
+		}
+
+		// index entries hold no value, the log is looked up by the height and log index of the key
+		err = batchWrite(makePrefix(codeEVMLogByAddress, log.Address.Bytes(), log.BlockHeight, log.LogIndex), nil)(batch)
+		if err != nil {
+			return fmt.Errorf("could not index EVM log by address: %w", err)
+		}
+
+		for i, topic := range log.Topics {
+			err = batchWrite(makePrefix(codeEVMLogByTopic, uint8(i), topic.Bytes(), log.BlockHeight, log.LogIndex), nil)(batch)
+			if err != nil {
+				return fmt.Errorf("could not index EVM log by topic: %w", err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// LookupEVMLogs retrieves the EVM logs matching the filter in blocks within [startHeight, endHeight],
+// from oldest to newest. If cursor is not nil, the lookup starts at the cursor (inclusive).
+// At most limit entries are retrieved.
+//
+// Candidates are read from the address index if the filter has addresses, otherwise from the
+// index of the first topic position with topics, otherwise from all logs of the range.
+// Candidates are then matched against the whole filter.
+// No errors are expected during normal operation.
+func LookupEVMLogs(
+	filter flow.EVMLogFilter,
+	startHeight uint64,
+	endHeight uint64,
+	cursor *flow.EVMLogCursor,
+	limit uint32,
+	logs *[]flow.EVMLog,
+) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		*logs = make([]flow.EVMLog, 0)
+		if limit == 0 || startHeight > endHeight {
+			return nil
+		}
+
+		start := flow.EVMLogCursor{BlockHeight: startHeight}
+		if cursor != nil {
+			if cursor.BlockHeight > endHeight {
+				return nil
+			}
+			if cursor.BlockHeight >= startHeight {
+				start = *cursor
+			}
+		}
+
+		var prefixes [][]byte
+		indexed := true
+		switch {
+		case len(filter.Addresses) > 0:
+			for _, address := range filter.Addresses {
+				prefixes = append(prefixes, makePrefix(codeEVMLogByAddress, address.Bytes()))
+			}
+		case firstTopicPosition(filter) >= 0:
+			position := firstTopicPosition(filter)
+			for _, topic := range filter.Topics[position] {
+				prefixes = append(prefixes, makePrefix(codeEVMLogByTopic, uint8(position), topic.Bytes()))
+			}
+		default:
+			prefixes = [][]byte{makePrefix(codeEVMLog)}
+			indexed = false
+		}
+
+		iterators := make([]*evmLogIterator, 0, len(prefixes))
+		for _, prefix := range prefixes {
+			it := newEVMLogIterator(tx, prefix, start, endHeight, !indexed)
+			defer it.Close()
+			iterators = append(iterators, it)
+		}
+
+		// merge the candidates of all iterators in ascending order, skipping duplicates
+		for uint32(len(*logs)) < limit {
+			var next *evmLogIterator
+			for _, it := range iterators {
+				if it.Valid() && (next == nil || bytes.Compare(it.Position(), next.Position()) < 0) {
+					next = it
+				}
+			}
+			if next == nil {
+				break
+			}
+			position := bytes.Clone(next.Position())
+
+			var log flow.EVMLog
+			var err error
+			if indexed {
+				err = retrieve(append(makePrefix(codeEVMLog), position...), &log)(tx)
+			} else {
+				err = next.Value(&log)
+			}
+			if err != nil {
+				return fmt.Errorf("could not retrieve EVM log: %w", err)
+			}
+			if filter.Match(&log) {
+				*logs = append(*logs, log)
+			}
+
+			for _, it := range iterators {
+				if it.Valid() && bytes.Equal(it.Position(), position) {
+					it.Next()
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// firstTopicPosition returns the first topic position of the filter with topics, or -1 if there is none.
+func firstTopicPosition(filter flow.EVMLogFilter) int {
+	for i, topics := range filter.Topics {
+		if len(topics) > 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// evmLogIterator iterates in ascending order over the keys with the given prefix whose
+// position suffix is within [start, endHeight].
+type evmLogIterator struct {
+	it     *badger.Iterator
+	prefix []byte
+	end    uint64
+}
+
+func newEVMLogIterator(tx *badger.Txn, prefix []byte, start flow.EVMLogCursor, endHeight uint64, prefetch bool) *evmLogIterator {
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = prefix
+	opts.PrefetchValues = prefetch
+
+	it := tx.NewIterator(opts)
+	seek := append(bytes.Clone(prefix), b(start.BlockHeight)...)
+	it.Seek(append(seek, b(start.LogIndex)...))
+
+	return &evmLogIterator{
+		it:     it,
+		prefix: prefix,
+		end:    endHeight,
+	}
+}
+
+// Valid returns true if the iterator points at a key within the range.
+func (i *evmLogIterator) Valid() bool {
+	if !i.it.Valid() {
+		return false
+	}
+	key := i.it.Item().Key()
+	if len(key) != len(i.prefix)+evmLogPositionLength {
+		return false
+	}
+	return binary.BigEndian.Uint64(i.Position()) <= i.end
+}
+
+// Position returns the block height and log index suffix of the current key.
+func (i *evmLogIterator) Position() []byte {
+	return i.it.Item().Key()[len(i.prefix):]
+}
+
+// Value decodes the value of the current key into the given entity.
+func (i *evmLogIterator) Value(entity interface{}) error {
+	return i.it.Item().Value(func(val []byte) error {
+		err := msgpack.Unmarshal(val, entity)
+		if err != nil {
+			return irrecoverable.NewExceptionf("could not decode entity: %w", err)
+		}
+		return nil
+	})
+}
+
+func (i *evmLogIterator) Next() {
+	i.it.Next()
+}
+
+func (i *evmLogIterator) Close() {
+	i.it.Close()
+}
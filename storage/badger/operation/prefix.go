@@ -101,6 +101,11 @@ const (
 	codeLightTransactionResultIndex        = 109
 	codeTransactionResultErrorMessage      = 110
 	codeTransactionResultErrorMessageIndex = 111
+	codeAccountTransaction                 = 112 // index mapping account address and block height to transactions
+	codeEventSubscriptionCursor            = 113 // named event subscription cursors of the state stream API
+	codeEVMLog                             = 114 // EVM logs by block height and log index
+	codeEVMLogByAddress                    = 115 // index mapping EVM contract address and block height to EVM logs
+	codeEVMLogByTopic                      = 116 // index mapping EVM log topic position, topic and block height to EVM logs
 	codeIndexCollection                    = 200
 	codeIndexExecutionResultByBlock        = 202
 	codeIndexCollectionByTransaction       = 203
@@ -137,10 +142,14 @@ func b(v interface{}) []byte {
 		return b
 	case string:
 		return []byte(i)
+	case []byte:
+		return i
 	case flow.Role:
 		return []byte{byte(i)}
 	case flow.Identifier:
 		return i[:]
+	case flow.Address:
+		return i[:]
 	case flow.ChainID:
 		return []byte(i)
 	default:
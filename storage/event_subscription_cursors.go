@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// EventSubscriptionCursors represents persistent storage for named event subscription cursors.
+type EventSubscriptionCursors interface {
+
+	// Store inserts the cursor, or replaces the cursor with the same name if one exists.
+	//
+	// No errors are expected during normal operation.
+	Store(cursor *flow.EventSubscriptionCursor) error
+
+	// ByName returns the cursor with the given name.
+	//
+	// Expected errors during normal operation:
+	// - storage.ErrNotFound if no cursor with the given name exists.
+	ByName(name string) (*flow.EventSubscriptionCursor, error)
+
+	// Remove removes the cursor with the given name. It is a no-op if the cursor does not exist.
+	//
+	// No errors are expected during normal operation.
+	Remove(name string) error
+
+	// Count returns the number of stored cursors.
+	//
+	// No errors are expected during normal operation.
+	Count() (int, error)
+
+	// RemoveInactive removes all cursors which were last active before the given time, and returns
+	// the names of the removed cursors.
+	//
+	// No errors are expected during normal operation.
+	RemoveInactive(before time.Time) ([]string, error)
+}
@@ -0,0 +1,27 @@
+package storage
+
+import "github.com/onflow/flow-go/model/flow"
+
+// EVMLogs represents persistent storage for the EVM log index, which holds the logs emitted
+// by EVM transactions and indexes them by contract address and topics.
+type EVMLogs interface {
+
+	// BatchStore inserts the EVM logs of a block into a batch.
+	//
+	// No errors are expected during normal operation.
+	BatchStore(blockHeight uint64, logs []flow.EVMLog, batch BatchStorage) error
+
+	// ByFilter returns the EVM logs matching the filter in blocks within [startHeight, endHeight],
+	// ordered from oldest to newest. If a cursor is provided, iteration starts at the cursor
+	// (inclusive) instead of the start height. At most limit entries are returned.
+	// Returns an empty slice if no logs are found.
+	//
+	// No errors are expected during normal operation.
+	ByFilter(
+		filter flow.EVMLogFilter,
+		startHeight uint64,
+		endHeight uint64,
+		cursor *flow.EVMLogCursor,
+		limit uint32,
+	) ([]flow.EVMLog, error)
+}
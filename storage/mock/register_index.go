@@ -30,6 +30,24 @@ func (_m *RegisterIndex) FirstHeight() uint64 {
 	return r0
 }
 
+// ForEachOwner provides a mock function with given fields: f
+func (_m *RegisterIndex) ForEachOwner(f func(string) error) error {
+	ret := _m.Called(f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForEachOwner")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(func(string) error) error); ok {
+		r0 = rf(f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ID, height
 func (_m *RegisterIndex) Get(ID flow.RegisterID, height uint64) ([]byte, error) {
 	ret := _m.Called(ID, height)
@@ -78,6 +96,43 @@ func (_m *RegisterIndex) LatestHeight() uint64 {
 	return r0
 }
 
+// RegisterChanges provides a mock function with given fields: owner, fromHeight, toHeight, after, limit
+func (_m *RegisterIndex) RegisterChanges(owner string, fromHeight uint64, toHeight uint64, after *string, limit int) ([]flow.RegisterChange, bool, error) {
+	ret := _m.Called(owner, fromHeight, toHeight, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterChanges")
+	}
+
+	var r0 []flow.RegisterChange
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, uint64, uint64, *string, int) ([]flow.RegisterChange, bool, error)); ok {
+		return rf(owner, fromHeight, toHeight, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, uint64, uint64, *string, int) []flow.RegisterChange); ok {
+		r0 = rf(owner, fromHeight, toHeight, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]flow.RegisterChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, uint64, uint64, *string, int) bool); ok {
+		r1 = rf(owner, fromHeight, toHeight, after, limit)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, uint64, uint64, *string, int) error); ok {
+		r2 = rf(owner, fromHeight, toHeight, after, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Store provides a mock function with given fields: entries, height
 func (_m *RegisterIndex) Store(entries flow.RegisterEntries, height uint64) error {
 	ret := _m.Called(entries, height)
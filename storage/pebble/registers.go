@@ -1,6 +1,7 @@
 package pebble
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"math"
@@ -11,13 +12,14 @@ import (
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/pebble/registers"
 )
 
 // Registers library that implements pebble storage for registers
 // given a pebble instance with root block and root height populated
 type Registers struct {
 	db             *pebble.DB
-	firstHeight    uint64
+	firstHeight    *atomic.Uint64
 	latestHeight   *atomic.Uint64
 	pruneThreshold uint64
 }
@@ -46,7 +48,7 @@ func NewRegisters(db *pebble.DB, pruneThreshold uint64) (*Registers, error) {
 	// All registers between firstHeight and lastHeight have been indexed
 	return &Registers{
 		db:             db,
-		firstHeight:    firstHeight,
+		firstHeight:    atomic.NewUint64(firstHeight),
 		latestHeight:   atomic.NewUint64(latestHeight),
 		pruneThreshold: pruneThreshold,
 	}, nil
@@ -74,7 +76,247 @@ func (s *Registers) Get(
 		return nil, fmt.Errorf("height %d not indexed, indexed range: [%d-%d], %w", height, firstHeight, latestHeight, storage.ErrHeightNotIndexed)
 	}
 	key := newLookupKey(height, reg)
-	return s.lookupRegister(key.Bytes())
+	value, err := s.lookupRegister(key.Bytes())
+
+	// the pruner might have removed the version of the register while it was read
+	prunedErr := s.checkNotPruned(height)
+	if prunedErr != nil {
+		return nil, prunedErr
+	}
+
+	return value, err
+}
+
+// RegisterChanges returns the registers of the given owner whose value at toHeight differs from
+// their value at fromHeight, ordered by key.
+//
+// If after is not nil, only registers with a key greater than *after are returned. At most limit
+// changes are returned, and the returned bool is true if there may be more changes after the last
+// one returned.
+//
+// Expected errors:
+// - storage.ErrHeightNotIndexed if either height is out of the range of stored heights
+func (s *Registers) RegisterChanges(
+	owner string,
+	fromHeight uint64,
+	toHeight uint64,
+	after *string,
+	limit int,
+) ([]flow.RegisterChange, bool, error) {
+	if fromHeight > toHeight {
+		return nil, false, fmt.Errorf("from height %d must not be greater than to height %d", fromHeight, toHeight)
+	}
+
+	latestHeight := s.LatestHeight()
+	firstHeight := s.calculateFirstHeight(latestHeight)
+	if fromHeight < firstHeight || toHeight > latestHeight {
+		return nil, false, fmt.Errorf("heights [%d-%d] not indexed, indexed range: [%d-%d], %w",
+			fromHeight, toHeight, firstHeight, latestHeight, storage.ErrHeightNotIndexed)
+	}
+
+	// all versions of the owner's registers are stored under the prefix [code] [owner] /
+	prefix := make([]byte, 0, len(owner)+2)
+	prefix = append(prefix, codeRegister)
+	prefix = append(prefix, owner...)
+	prefix = append(prefix, '/')
+
+	upperBound := make([]byte, len(prefix))
+	copy(upperBound, prefix)
+	upperBound[len(upperBound)-1]++
+
+	iter, err := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: upperBound,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	if after == nil {
+		iter.First()
+	} else {
+		// the oldest possible version of the register sorts after all its other versions
+		iter.SeekGE(newLookupKey(0, flow.RegisterID{Owner: owner, Key: *after}).Bytes())
+	}
+
+	var (
+		changes       []flow.RegisterChange
+		currentPrefix []byte
+		current       *registerVersions
+	)
+
+	// appendChange finishes the versions of the current register, and records it if it changed.
+	appendChange := func() {
+		if current == nil {
+			return
+		}
+		if change, ok := current.change(); ok {
+			changes = append(changes, change)
+		}
+		current = nil
+	}
+
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()
+		if len(key) < len(prefix)+1+registers.HeightSuffixLen {
+			return nil, false, fmt.Errorf("invalid register key length %d", len(key))
+		}
+
+		registerPrefix := key[:len(key)-registers.HeightSuffixLen]
+		if !bytes.Equal(registerPrefix, currentPrefix) {
+			appendChange()
+			if len(changes) >= limit {
+				err := s.checkNotPruned(fromHeight)
+				if err != nil {
+					return nil, false, err
+				}
+				return changes, true, nil
+			}
+
+			currentPrefix = append(currentPrefix[:0], registerPrefix...)
+			registerKey := string(key[len(prefix) : len(registerPrefix)-1])
+			if after != nil && registerKey <= *after {
+				continue
+			}
+			current = &registerVersions{
+				id:         flow.RegisterID{Owner: owner, Key: registerKey},
+				fromHeight: fromHeight,
+				toHeight:   toHeight,
+			}
+		}
+
+		if current == nil || current.complete() {
+			continue
+		}
+
+		value, err := iter.ValueAndErr()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get value: %w", err)
+		}
+		height := ^binary.BigEndian.Uint64(key[len(key)-registers.HeightSuffixLen:])
+		current.add(height, value)
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, false, fmt.Errorf("failed to iterate registers: %w", err)
+	}
+
+	appendChange()
+
+	// the pruner might have removed versions of the registers while they were read
+	err = s.checkNotPruned(fromHeight)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return changes, false, nil
+}
+
+// ForEachOwner calls f with the owner of each account which has registers stored at any indexed
+// height, in ascending order. Registers without an owner are skipped.
+//
+// No errors are expected during normal operation, other than errors returned by f.
+func (s *Registers) ForEachOwner(f func(owner string) error) error {
+	iter, err := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: []byte{codeRegister},
+		UpperBound: []byte{codeRegister + 1},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	// the registers of an owner are stored under the prefix [code] [owner] /, so once an owner
+	// is visited, the iterator skips all its registers by seeking past the prefix
+	ownerPrefixLen := 1 + flow.AddressLength + 1
+	next := make([]byte, ownerPrefixLen)
+
+	for valid := iter.First(); valid; {
+		key := iter.Key()
+		if len(key) < ownerPrefixLen || key[ownerPrefixLen-1] != '/' {
+			valid = iter.Next()
+			continue
+		}
+
+		err := f(string(key[1 : ownerPrefixLen-1]))
+		if err != nil {
+			return err
+		}
+
+		copy(next, key[:ownerPrefixLen])
+		next[ownerPrefixLen-1]++
+		valid = iter.SeekGE(next)
+	}
+
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("failed to iterate registers: %w", err)
+	}
+
+	return nil
+}
+
+// registerVersions collects the values of a register at two heights from its versions, which
+// are visited from newest to oldest.
+type registerVersions struct {
+	id         flow.RegisterID
+	fromHeight uint64
+	toHeight   uint64
+
+	before, after           flow.RegisterValue
+	foundBefore, foundAfter bool
+}
+
+// add records the version of the register stored at the given height.
+func (r *registerVersions) add(height uint64, value []byte) {
+	if !r.foundAfter && height <= r.toHeight {
+		r.after = copyValue(value)
+		r.foundAfter = true
+	}
+	if !r.foundBefore && height <= r.fromHeight {
+		r.before = copyValue(value)
+		r.foundBefore = true
+	}
+}
+
+// complete returns true if the values at both heights were found.
+func (r *registerVersions) complete() bool {
+	return r.foundBefore && r.foundAfter
+}
+
+// change returns the change of the register between the two heights, and false if it did not change.
+// Registers with an empty value do not exist.
+func (r *registerVersions) change() (flow.RegisterChange, bool) {
+	change := flow.RegisterChange{ID: r.id}
+
+	switch {
+	case len(r.before) == 0 && len(r.after) == 0:
+		return change, false
+	case len(r.before) == 0:
+		change.Type = flow.RegisterAdded
+		change.ValueAfter = r.after
+	case len(r.after) == 0:
+		change.Type = flow.RegisterRemoved
+		change.ValueBefore = r.before
+	case bytes.Equal(r.before, r.after):
+		return change, false
+	default:
+		change.Type = flow.RegisterModified
+		change.ValueBefore = r.before
+		change.ValueAfter = r.after
+	}
+
+	return change, true
+}
+
+// copyValue copies a value returned by an iterator, which is only valid until the iterator is moved.
+func copyValue(value []byte) flow.RegisterValue {
+	if len(value) == 0 {
+		return nil
+	}
+	valueCopy := make([]byte, len(value))
+	copy(valueCopy, value)
+	return valueCopy
 }
 
 func (s *Registers) lookupRegister(key []byte) (flow.RegisterValue, error) {
@@ -172,18 +414,61 @@ func (s *Registers) FirstHeight() uint64 {
 // Returns:
 // - The first indexed height, either as the initialized height or adjusted for pruning.
 func (s *Registers) calculateFirstHeight(latestHeight uint64) uint64 {
+	firstHeight := s.firstHeight.Load()
 	if latestHeight < s.pruneThreshold {
-		return s.firstHeight
+		return firstHeight
 	}
 
 	pruneHeight := latestHeight - s.pruneThreshold
-	if pruneHeight < s.firstHeight {
-		return s.firstHeight
+	if pruneHeight < firstHeight {
+		return firstHeight
 	}
 
 	return pruneHeight
 }
 
+// checkNotPruned checks that the given height wasn't pruned, after register versions of the height
+// were read. The pruner advances the first height before it removes register versions, so a reader
+// which checked the first height before the read might still have read partially pruned versions.
+//
+// Expected errors:
+// - storage.ErrHeightNotIndexed if the first height was advanced past the given height
+func (s *Registers) checkNotPruned(height uint64) error {
+	firstHeight := s.FirstHeight()
+	if height < firstHeight {
+		return fmt.Errorf("height %d was pruned while reading, first height: %d, %w", height, firstHeight, storage.ErrHeightNotIndexed)
+	}
+	return nil
+}
+
+// updateFirstHeight persists the new first indexed height and makes it visible to readers.
+// Once this function returns, no reads below the given height are served, and reads which were
+// already ongoing fail with storage.ErrHeightNotIndexed when they complete, so register versions
+// older than the new first height may safely be removed from the database.
+//
+// CAUTION: the height must not decrease and must not exceed the latest height.
+//
+// No errors are expected during normal operations.
+func (s *Registers) updateFirstHeight(height uint64) error {
+	if height <= s.firstHeight.Load() {
+		return nil
+	}
+
+	latestHeight := s.LatestHeight()
+	if height > latestHeight {
+		return fmt.Errorf("first height %d cannot exceed latest height %d", height, latestHeight)
+	}
+
+	err := s.db.Set(firstHeightKey, encodedUint64(height), pebble.Sync)
+	if err != nil {
+		return fmt.Errorf("failed to update first height %d: %w", height, err)
+	}
+
+	s.firstHeight.Store(height)
+
+	return nil
+}
+
 func firstStoredHeight(db *pebble.DB) (uint64, error) {
 	return heightLookup(db, firstHeightKey)
 }
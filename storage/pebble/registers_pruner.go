@@ -0,0 +1,306 @@
+package pebble
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/executiondatasync/pruner"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/storage/pebble/registers"
+)
+
+const (
+	// DefaultPruneThrottleDelay is the default delay between two batches of deletions,
+	// which limits the IO pressure the pruner puts on the database.
+	DefaultPruneThrottleDelay = 10 * time.Millisecond
+
+	// DefaultPruneTickerInterval is the default interval at which the pruner checks
+	// whether pruning should be performed.
+	DefaultPruneTickerInterval = 10 * time.Minute
+)
+
+// pruneDeleteBatchSize is the number of register versions deleted in a single batch.
+const pruneDeleteBatchSize = 1000
+
+// RegisterPruner is a component that removes register versions which are no longer
+// reachable from the retained height range of the register db.
+//
+// The retained range is [latestHeight - pruneThreshold, latestHeight]. For every register,
+// all versions stored at or above the new first height are kept, together with the newest
+// version stored below it, so that Registers.Get still returns the correct value for every
+// height within the retained range. All older versions are deleted.
+//
+// The first height of the register db is advanced before any data is removed, and readers check
+// the first height again after reading, so they never return values of a partially pruned height.
+// Pruning runs concurrently with Registers.Store, since both only ever touch disjoint keys.
+type RegisterPruner struct {
+	component.Component
+	cm *component.ComponentManager
+
+	logger    zerolog.Logger
+	registers *Registers
+	metrics   module.RegisterDBPrunerMetrics
+
+	// pruneThreshold is the number of blocks below the latest height to keep
+	pruneThreshold uint64
+	// pruneThrottleDelay is a pause between batches of deletions
+	pruneThrottleDelay time.Duration
+	// pruneTickerInterval defines how frequently pruning is attempted
+	pruneTickerInterval time.Duration
+}
+
+type PrunerOption func(*RegisterPruner)
+
+// WithPruneThreshold is used to configure the pruner with a custom threshold.
+func WithPruneThreshold(threshold uint64) PrunerOption {
+	return func(p *RegisterPruner) {
+		p.pruneThreshold = threshold
+	}
+}
+
+// WithPruneThrottleDelay is used to configure the pruner with a custom throttle delay.
+func WithPruneThrottleDelay(throttleDelay time.Duration) PrunerOption {
+	return func(p *RegisterPruner) {
+		p.pruneThrottleDelay = throttleDelay
+	}
+}
+
+// WithPruneTickerInterval is used to configure the pruner with a custom ticker interval.
+func WithPruneTickerInterval(interval time.Duration) PrunerOption {
+	return func(p *RegisterPruner) {
+		p.pruneTickerInterval = interval
+	}
+}
+
+// NewRegisterPruner creates a new RegisterPruner for the given register storage.
+//
+// No errors are expected during normal operations.
+func NewRegisterPruner(
+	logger zerolog.Logger,
+	registers *Registers,
+	metrics module.RegisterDBPrunerMetrics,
+	opts ...PrunerOption,
+) (*RegisterPruner, error) {
+	p := &RegisterPruner{
+		logger:              logger.With().Str("component", "register_db_pruner").Logger(),
+		registers:           registers,
+		metrics:             metrics,
+		pruneThreshold:      pruner.DefaultThreshold,
+		pruneThrottleDelay:  DefaultPruneThrottleDelay,
+		pruneTickerInterval: DefaultPruneTickerInterval,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if p.pruneThreshold == 0 || p.pruneThreshold == PruningDisabled {
+		return nil, fmt.Errorf("invalid prune threshold: %d", p.pruneThreshold)
+	}
+
+	p.cm = component.NewComponentManagerBuilder().
+		AddWorker(p.loop).
+		Build()
+	p.Component = p.cm
+
+	return p, nil
+}
+
+// loop is the main worker of the pruner, it periodically checks whether the retained
+// height range was exceeded and prunes the register db if so.
+func (p *RegisterPruner) loop(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	ticker := time.NewTicker(p.pruneTickerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := p.checkPrune(ctx)
+			if err != nil {
+				ctx.Throw(err)
+			}
+		}
+	}
+}
+
+// checkPrune prunes the register db if the latest height exceeds the first height
+// by more than the prune threshold.
+//
+// No errors are expected during normal operations.
+func (p *RegisterPruner) checkPrune(ctx context.Context) error {
+	firstHeight := p.registers.firstHeight.Load()
+	latestHeight := p.registers.LatestHeight()
+
+	if latestHeight <= firstHeight+p.pruneThreshold {
+		return nil
+	}
+
+	pruneHeight := latestHeight - p.pruneThreshold
+
+	p.logger.Info().
+		Uint64("first_height", firstHeight).
+		Uint64("latest_height", latestHeight).
+		Uint64("prune_height", pruneHeight).
+		Msg("pruning register db")
+
+	start := time.Now()
+
+	err := p.pruneUpToHeight(ctx, pruneHeight)
+	if err != nil {
+		// the pruner stops early when shutting down, the remaining data is removed on the next run
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("failed to prune register db up to height %d: %w", pruneHeight, err)
+	}
+
+	duration := time.Since(start)
+	p.metrics.Pruned(pruneHeight, duration)
+
+	p.logger.Info().
+		Uint64("prune_height", pruneHeight).
+		Dur("duration", duration).
+		Msg("pruned register db")
+
+	return nil
+}
+
+// pruneUpToHeight makes pruneHeight the first height of the register db and deletes every
+// register version that is not needed to serve reads at or above pruneHeight.
+//
+// Keys are sorted by register ID first and then by descending height, so all versions of a
+// register are visited from newest to oldest. The first version at or below pruneHeight is
+// kept, and all versions following it are deleted.
+//
+// No errors are expected during normal operations.
+func (p *RegisterPruner) pruneUpToHeight(ctx context.Context, pruneHeight uint64) error {
+	// advance the first height before deleting anything, so that readers stop requesting
+	// heights whose data is about to be removed.
+	err := p.registers.updateFirstHeight(pruneHeight)
+	if err != nil {
+		return err
+	}
+
+	db := p.registers.db
+
+	iter, err := db.NewIter(&pebble.IterOptions{
+		LowerBound: []byte{codeRegister},
+		UpperBound: []byte{codeRegister + 1},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	batch := db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+
+	var (
+		currentPrefix []byte
+		keptVersion   bool
+		pending       int
+		pruned        uint64
+	)
+
+	for iter.First(); iter.Valid(); iter.Next() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		key := iter.Key()
+		p.metrics.ElementVisited()
+
+		// the height is decoded from the fixed size suffix, since the encoded height might contain
+		// the separator of the key
+		if len(key) < MinLookupKeyLen {
+			return fmt.Errorf("invalid register key length %d", len(key))
+		}
+		height := ^binary.BigEndian.Uint64(key[len(key)-registers.HeightSuffixLen:])
+
+		prefix := key[:len(key)-registers.HeightSuffixLen]
+		if !bytes.Equal(prefix, currentPrefix) {
+			currentPrefix = append(currentPrefix[:0], prefix...)
+			keptVersion = false
+		}
+
+		if height > pruneHeight {
+			continue
+		}
+
+		// the newest version at or below the prune height is still needed to serve reads
+		// for heights between pruneHeight and the next version of the register.
+		if !keptVersion {
+			keptVersion = true
+			continue
+		}
+
+		err = batch.Delete(key, nil)
+		if err != nil {
+			return fmt.Errorf("failed to add register key to delete batch: %w", err)
+		}
+		pending++
+
+		if pending >= pruneDeleteBatchSize {
+			err = p.commitBatch(batch)
+			if err != nil {
+				return err
+			}
+			pruned += uint64(pending)
+			p.metrics.NumberOfRowsPruned(uint64(pending))
+			pending = 0
+			batch.Reset()
+
+			// throttle to leave room for indexing and reads
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(p.pruneThrottleDelay):
+			}
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("failed to iterate registers: %w", err)
+	}
+
+	if pending > 0 {
+		err = p.commitBatch(batch)
+		if err != nil {
+			return err
+		}
+		pruned += uint64(pending)
+		p.metrics.NumberOfRowsPruned(uint64(pending))
+	}
+
+	p.logger.Debug().
+		Uint64("prune_height", pruneHeight).
+		Uint64("pruned_rows", pruned).
+		Msg("finished deleting pruned register versions")
+
+	return nil
+}
+
+// commitBatch commits the given batch of deletions.
+//
+// No errors are expected during normal operations.
+func (p *RegisterPruner) commitBatch(batch *pebble.Batch) error {
+	err := batch.Commit(pebble.NoSync)
+	if err != nil {
+		return fmt.Errorf("failed to commit delete batch: %w", err)
+	}
+	return nil
+}
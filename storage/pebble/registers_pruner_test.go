@@ -0,0 +1,284 @@
+package pebble
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/pebble/registers"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestRegisterPruner_PruneUpToHeight tests that pruning removes all register versions that are not
+// needed to serve reads within the retained height range, and keeps all others.
+func TestRegisterPruner_PruneUpToHeight(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		key1 := flow.RegisterID{Owner: "owner", Key: "key1"}
+		key2 := flow.RegisterID{Owner: "owner", Key: "key2"}
+		key3 := flow.RegisterID{Owner: "", Key: "key3"}
+
+		// key1 is updated at every height, key2 only at height 2 and key3 at heights 3 and 9
+		for height := uint64(2); height <= 10; height++ {
+			entries := flow.RegisterEntries{
+				{Key: key1, Value: []byte(fmt.Sprintf("v1-%d", height))},
+			}
+			if height == 2 {
+				entries = append(entries, flow.RegisterEntry{Key: key2, Value: []byte("v2-2")})
+			}
+			if height == 3 || height == 9 {
+				entries = append(entries, flow.RegisterEntry{Key: key3, Value: []byte(fmt.Sprintf("v3-%d", height))})
+			}
+			require.NoError(t, r.Store(entries, height))
+		}
+
+		pruner, err := NewRegisterPruner(unittest.Logger(), r, metrics.NewNoopCollector(), WithPruneThreshold(4))
+		require.NoError(t, err)
+
+		require.NoError(t, pruner.checkPrune(context.Background()))
+
+		// latest height 10 - threshold 4
+		pruneHeight := uint64(6)
+		require.Equal(t, pruneHeight, r.FirstHeight())
+
+		firstHeight, latestHeight, err := ReadHeightsFromBootstrappedDB(r.db)
+		require.NoError(t, err)
+		require.Equal(t, pruneHeight, firstHeight)
+		require.Equal(t, uint64(10), latestHeight)
+
+		// reads below the first height are rejected
+		_, err = r.Get(key1, pruneHeight-1)
+		require.ErrorIs(t, err, storage.ErrHeightNotIndexed)
+
+		// all reads within the retained range return the same values as before pruning
+		for height := pruneHeight; height <= latestHeight; height++ {
+			value, err := r.Get(key1, height)
+			require.NoError(t, err)
+			require.Equal(t, []byte(fmt.Sprintf("v1-%d", height)), value)
+
+			value, err = r.Get(key2, height)
+			require.NoError(t, err)
+			require.Equal(t, []byte("v2-2"), value)
+
+			expected := []byte("v3-3")
+			if height >= 9 {
+				expected = []byte("v3-9")
+			}
+			value, err = r.Get(key3, height)
+			require.NoError(t, err)
+			require.Equal(t, expected, value)
+		}
+
+		// only the versions needed for the retained range remain in the db
+		require.Equal(t, []uint64{10, 9, 8, 7, 6}, storedHeights(t, r.db, key1))
+		require.Equal(t, []uint64{2}, storedHeights(t, r.db, key2))
+		require.Equal(t, []uint64{9, 3}, storedHeights(t, r.db, key3))
+
+		// pruning again without new heights is a no-op
+		require.NoError(t, pruner.checkPrune(context.Background()))
+		require.Equal(t, pruneHeight, r.FirstHeight())
+	})
+}
+
+// TestRegisterPruner_BelowThreshold tests that nothing is pruned while the indexed height range
+// does not exceed the threshold.
+func TestRegisterPruner_BelowThreshold(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		key1 := flow.RegisterID{Owner: "owner", Key: "key1"}
+		for height := uint64(2); height <= 5; height++ {
+			entries := flow.RegisterEntries{{Key: key1, Value: []byte{byte(height)}}}
+			require.NoError(t, r.Store(entries, height))
+		}
+
+		pruner, err := NewRegisterPruner(unittest.Logger(), r, metrics.NewNoopCollector(), WithPruneThreshold(4))
+		require.NoError(t, err)
+
+		require.NoError(t, pruner.checkPrune(context.Background()))
+
+		require.Equal(t, uint64(1), r.FirstHeight())
+		require.Equal(t, []uint64{5, 4, 3, 2}, storedHeights(t, r.db, key1))
+	})
+}
+
+// TestRegisterPruner_InvalidThreshold tests that the pruner cannot be created without a retention window.
+func TestRegisterPruner_InvalidThreshold(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		_, err := NewRegisterPruner(unittest.Logger(), r, metrics.NewNoopCollector(), WithPruneThreshold(0))
+		require.Error(t, err)
+
+		_, err = NewRegisterPruner(unittest.Logger(), r, metrics.NewNoopCollector(), WithPruneThreshold(PruningDisabled))
+		require.Error(t, err)
+	})
+}
+
+// TestRegisterPruner_ConcurrentStore tests that the pruner component runs while registers are indexed,
+// and that values within the retained range remain readable.
+func TestRegisterPruner_ConcurrentStore(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		key1 := flow.RegisterID{Owner: "owner", Key: "key1"}
+		threshold := uint64(10)
+
+		pruner, err := NewRegisterPruner(
+			unittest.Logger(),
+			r,
+			metrics.NewNoopCollector(),
+			WithPruneThreshold(threshold),
+			WithPruneTickerInterval(time.Millisecond),
+			WithPruneThrottleDelay(time.Microsecond),
+		)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		signalerCtx, errChan := irrecoverable.WithSignaler(ctx)
+		pruner.Start(signalerCtx)
+		unittest.RequireCloseBefore(t, pruner.Ready(), time.Second, "pruner did not start")
+
+		latestHeight := uint64(200)
+		for height := uint64(2); height <= latestHeight; height++ {
+			entries := flow.RegisterEntries{{Key: key1, Value: []byte(fmt.Sprintf("%d", height))}}
+			require.NoError(t, r.Store(entries, height))
+		}
+
+		require.Eventually(t, func() bool {
+			return r.firstHeight.Load() == latestHeight-threshold
+		}, 5*time.Second, 10*time.Millisecond)
+
+		for height := r.FirstHeight(); height <= latestHeight; height++ {
+			value, err := r.Get(key1, height)
+			require.NoError(t, err)
+			require.Equal(t, []byte(fmt.Sprintf("%d", height)), value)
+		}
+
+		cancel()
+		unittest.RequireCloseBefore(t, pruner.Done(), time.Second, "pruner did not stop")
+
+		select {
+		case err := <-errChan:
+			require.NoError(t, err)
+		default:
+		}
+	})
+}
+
+// TestRegisterPruner_ConcurrentGet tests that reads racing with the pruner either return the value
+// of the requested height, or fail with storage.ErrHeightNotIndexed if the height was pruned meanwhile.
+func TestRegisterPruner_ConcurrentGet(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		key1 := flow.RegisterID{Owner: "owner", Key: "key1"}
+		threshold := uint64(10)
+
+		pruner, err := NewRegisterPruner(
+			unittest.Logger(),
+			r,
+			metrics.NewNoopCollector(),
+			WithPruneThreshold(threshold),
+			WithPruneTickerInterval(time.Millisecond),
+			WithPruneThrottleDelay(time.Microsecond),
+		)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		signalerCtx, errChan := irrecoverable.WithSignaler(ctx)
+		pruner.Start(signalerCtx)
+		unittest.RequireCloseBefore(t, pruner.Ready(), time.Second, "pruner did not start")
+
+		latestHeight := uint64(1000)
+		stored := make(chan struct{})
+		go func() {
+			defer close(stored)
+			for height := uint64(2); height <= latestHeight; height++ {
+				entries := flow.RegisterEntries{{Key: key1, Value: []byte(fmt.Sprintf("%d", height))}}
+				if err := r.Store(entries, height); err != nil {
+					t.Errorf("could not store height %d: %v", height, err)
+					return
+				}
+			}
+		}()
+
+		var wg sync.WaitGroup
+		var pruned atomic.Uint64
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-stored:
+						return
+					default:
+					}
+
+					// the oldest heights are the ones the pruner removes next
+					height := r.FirstHeight()
+					if height < 2 {
+						// the register is stored from height 2
+						continue
+					}
+					value, err := r.Get(key1, height)
+					if errors.Is(err, storage.ErrHeightNotIndexed) {
+						pruned.Inc()
+						continue
+					}
+					if err != nil {
+						t.Errorf("could not get height %d: %v", height, err)
+						return
+					}
+					if string(value) != fmt.Sprintf("%d", height) {
+						t.Errorf("got value %s at height %d", value, height)
+						return
+					}
+				}
+			}()
+		}
+
+		unittest.RequireReturnsBefore(t, wg.Wait, 10*time.Second, "readers did not stop")
+		t.Logf("%d reads failed because the height was pruned", pruned.Load())
+
+		cancel()
+		unittest.RequireCloseBefore(t, pruner.Done(), time.Second, "pruner did not stop")
+
+		select {
+		case err := <-errChan:
+			require.NoError(t, err)
+		default:
+		}
+	})
+}
+
+// storedHeights returns the heights of all versions of the register stored in the db, newest first.
+// Height 0 is excluded, since it is used as the exclusive upper bound.
+func storedHeights(t *testing.T, db *pebble.DB, reg flow.RegisterID) []uint64 {
+	prefix := newLookupKey(0, reg).Bytes()
+	prefix = prefix[:len(prefix)-registers.HeightSuffixLen]
+
+	iter, err := db.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: newLookupKey(0, reg).Bytes(),
+	})
+	require.NoError(t, err)
+	defer iter.Close()
+
+	var heights []uint64
+	for iter.First(); iter.Valid(); iter.Next() {
+		height, _, err := lookupKeyToRegisterID(iter.Key())
+		require.NoError(t, err)
+		heights = append(heights, height)
+	}
+	require.NoError(t, iter.Error())
+	return heights
+}
@@ -374,6 +374,124 @@ func Benchmark_PayloadStorage(b *testing.B) {
 	}
 }
 
+// TestRegisters_RegisterChanges tests listing the changes of an owner's registers between two heights
+func TestRegisters_RegisterChanges(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		owner := "owner"
+		added := flow.RegisterID{Owner: owner, Key: "added"}
+		modified := flow.RegisterID{Owner: owner, Key: "modified"}
+		removed := flow.RegisterID{Owner: owner, Key: "removed"}
+		unchanged := flow.RegisterID{Owner: owner, Key: "unchanged"}
+		rewritten := flow.RegisterID{Owner: owner, Key: "rewritten"}
+		other := flow.RegisterID{Owner: "other", Key: "modified"}
+
+		require.NoError(t, r.Store(flow.RegisterEntries{
+			{Key: modified, Value: []byte("v1")},
+			{Key: removed, Value: []byte("v1")},
+			{Key: unchanged, Value: []byte("v1")},
+			{Key: rewritten, Value: []byte("v1")},
+			{Key: other, Value: []byte("v1")},
+		}, 2))
+		require.NoError(t, r.Store(flow.RegisterEntries{
+			{Key: added, Value: []byte("v2")},
+			{Key: modified, Value: []byte("v2")},
+			{Key: rewritten, Value: []byte("v2")},
+			{Key: other, Value: []byte("v2")},
+		}, 3))
+		require.NoError(t, r.Store(flow.RegisterEntries{
+			{Key: removed, Value: []byte{}},
+			{Key: rewritten, Value: []byte("v1")},
+		}, 4))
+		require.NoError(t, r.Store(flow.RegisterEntries{
+			{Key: modified, Value: []byte("v5")},
+		}, 5))
+
+		expected := []flow.RegisterChange{
+			{ID: added, Type: flow.RegisterAdded, ValueAfter: []byte("v2")},
+			{ID: modified, Type: flow.RegisterModified, ValueBefore: []byte("v1"), ValueAfter: []byte("v2")},
+			{ID: removed, Type: flow.RegisterRemoved, ValueBefore: []byte("v1")},
+		}
+
+		t.Run("all changes", func(t *testing.T) {
+			changes, more, err := r.RegisterChanges(owner, 2, 4, nil, 10)
+			require.NoError(t, err)
+			assert.False(t, more)
+			assert.Equal(t, expected, changes)
+		})
+
+		t.Run("paginated", func(t *testing.T) {
+			changes, more, err := r.RegisterChanges(owner, 2, 4, nil, 2)
+			require.NoError(t, err)
+			assert.True(t, more)
+			assert.Equal(t, expected[:2], changes)
+
+			changes, more, err = r.RegisterChanges(owner, 2, 4, &changes[1].ID.Key, 2)
+			require.NoError(t, err)
+			assert.False(t, more)
+			assert.Equal(t, expected[2:], changes)
+		})
+
+		t.Run("no changes", func(t *testing.T) {
+			changes, more, err := r.RegisterChanges(owner, 4, 4, nil, 10)
+			require.NoError(t, err)
+			assert.False(t, more)
+			assert.Empty(t, changes)
+		})
+
+		t.Run("heights not indexed", func(t *testing.T) {
+			_, _, err := r.RegisterChanges(owner, 0, 4, nil, 10)
+			require.ErrorIs(t, err, storage.ErrHeightNotIndexed)
+
+			_, _, err = r.RegisterChanges(owner, 2, 6, nil, 10)
+			require.ErrorIs(t, err, storage.ErrHeightNotIndexed)
+		})
+	})
+}
+
+// TestRegisters_ForEachOwner tests listing the owners of all stored registers
+func TestRegisters_ForEachOwner(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		// the owner's address contains the '/' separator
+		first := flow.AddressToRegisterOwner(flow.HexToAddress("2f00000000000001"))
+		second := flow.AddressToRegisterOwner(flow.HexToAddress("2f00000000000002"))
+		third := flow.AddressToRegisterOwner(flow.HexToAddress("f000000000000000"))
+
+		require.NoError(t, r.Store(flow.RegisterEntries{
+			{Key: flow.RegisterID{Owner: "", Key: "uuid"}, Value: []byte("v1")},
+			{Key: flow.RegisterID{Owner: first, Key: "a"}, Value: []byte("v1")},
+			{Key: flow.RegisterID{Owner: first, Key: "b"}, Value: []byte("v1")},
+			{Key: flow.RegisterID{Owner: third, Key: "a"}, Value: []byte("v1")},
+		}, 2))
+		require.NoError(t, r.Store(flow.RegisterEntries{
+			{Key: flow.RegisterID{Owner: second, Key: "a"}, Value: []byte("v2")},
+			{Key: flow.RegisterID{Owner: first, Key: "a"}, Value: []byte("v2")},
+		}, 3))
+
+		t.Run("all owners", func(t *testing.T) {
+			var owners []string
+			err := r.ForEachOwner(func(owner string) error {
+				owners = append(owners, owner)
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []string{first, second, third}, owners)
+		})
+
+		t.Run("stops on error", func(t *testing.T) {
+			expectedErr := fmt.Errorf("expected error")
+			count := 0
+			err := r.ForEachOwner(func(owner string) error {
+				count++
+				return expectedErr
+			})
+			require.ErrorIs(t, err, expectedErr)
+			assert.Equal(t, 1, count)
+		})
+	})
+}
+
 func RunWithRegistersStorageAtHeight1(tb testing.TB, f func(r *Registers)) {
 	defaultHeight := uint64(1)
 	RunWithRegistersStorageAtInitialHeights(tb, defaultHeight, defaultHeight, f)
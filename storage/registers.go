@@ -29,4 +29,22 @@ type RegisterIndex interface {
 	//
 	// No errors are expected during normal operation.
 	Store(entries flow.RegisterEntries, height uint64) error
+
+	// RegisterChanges returns the registers of the given owner whose value at toHeight differs from
+	// their value at fromHeight, ordered by key.
+	//
+	// If after is not nil, only registers with a key greater than *after are returned. At most limit
+	// changes are returned, and the returned bool is true if there may be more changes after the last
+	// one returned.
+	//
+	// Expected errors:
+	// - storage.ErrHeightNotIndexed if either height was not indexed yet or is lower than the first indexed height.
+	RegisterChanges(owner string, fromHeight uint64, toHeight uint64, after *string, limit int) ([]flow.RegisterChange, bool, error)
+
+	// ForEachOwner calls f with the owner of each account which has registers stored at any indexed
+	// height, in ascending order. Registers without an owner are skipped. Iteration stops at the
+	// first error returned by f, which is returned.
+	//
+	// No other errors are expected during normal operation.
+	ForEachOwner(f func(owner string) error) error
 }